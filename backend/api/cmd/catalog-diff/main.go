@@ -0,0 +1,67 @@
+// Package main compares the scanner check catalog against a committed golden
+// snapshot and fails when check IDs have been removed or renamed.
+//
+// Usage, from backend/api:
+//
+//	go run ./cmd/catalog-diff            # report differences, exit 1 on breaking changes
+//	go run ./cmd/catalog-diff -update    # accept the current catalog as the new golden
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"cloudcop/api/internal/scanner"
+)
+
+func main() {
+	golden := flag.String("golden", "internal/scanner/testdata/catalog.json", "path to the golden catalog snapshot")
+	update := flag.Bool("update", false, "overwrite the golden snapshot with the current catalog")
+	flag.Parse()
+
+	current, err := scanner.CatalogSnapshot()
+	if err != nil {
+		log.Fatalf("Failed to build catalog: %v", err)
+	}
+
+	if *update {
+		if err := os.WriteFile(*golden, current, 0o644); err != nil {
+			log.Fatalf("Failed to write golden catalog: %v", err)
+		}
+		fmt.Printf("Updated %s\n", *golden)
+		return
+	}
+
+	previous, err := os.ReadFile(*golden)
+	if err != nil {
+		log.Fatalf("Failed to read golden catalog: %v", err)
+	}
+
+	diff, err := scanner.DiffCatalog(previous, current)
+	if err != nil {
+		log.Fatalf("Failed to diff catalogs: %v", err)
+	}
+	if diff.Empty() {
+		fmt.Println("Check catalog matches the golden snapshot")
+		return
+	}
+
+	report("Removed", diff.Removed)
+	report("Added", diff.Added)
+	report("Changed", diff.Changed)
+
+	if diff.Breaking() {
+		fmt.Println("Breaking change: check IDs were removed or renamed. If intended, rerun with -update.")
+		os.Exit(1)
+	}
+	fmt.Println("No breaking changes; rerun with -update to refresh the golden snapshot.")
+}
+
+func report(label string, ids []string) {
+	if len(ids) > 0 {
+		fmt.Printf("%s: %s\n", label, strings.Join(ids, ", "))
+	}
+}
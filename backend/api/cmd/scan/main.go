@@ -0,0 +1,203 @@
+// Package main runs a CloudCop scan from the command line without the API
+// server, database, or authentication stack, printing results to stdout.
+//
+// Usage, from backend/api:
+//
+//	go run ./cmd/scan -services s3,iam -regions us-east-1 -output sarif
+//	go run ./cmd/scan -profile audit -output csv > findings.csv
+//	go run ./cmd/scan -stream | jq 'select(.status == "FAIL")'
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"cloudcop/api/internal/report"
+	"cloudcop/api/internal/scanner"
+	"cloudcop/api/internal/scanner/registry"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// outputFormats maps each -output value to its writer.
+var outputFormats = map[string]func(*scanner.ScanResult, io.Writer) error{
+	"json":  writeJSON,
+	"csv":   report.ToCSV,
+	"sarif": report.ToSARIF,
+}
+
+// environment supplies the AWS configuration and scanners a run uses, so
+// tests can substitute fakes for the AWS SDK.
+type environment struct {
+	// loadAWS returns the AWS configuration and the account it belongs to.
+	loadAWS   func(ctx context.Context, profile string) (aws.Config, string, error)
+	factories map[string]scanner.Factory
+}
+
+// options holds the parsed command-line flags.
+type options struct {
+	profile     string
+	services    []string
+	regions     []string
+	output      string
+	stream      bool
+	includeTags bool
+}
+
+func main() {
+	env := environment{loadAWS: loadAWS, factories: registry.Factories()}
+	if err := run(context.Background(), os.Args[1:], os.Stdout, os.Stderr, env); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		fmt.Fprintf(os.Stderr, "scan: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run parses args, scans the account, and writes the result to stdout in the
+// requested format. Usage errors are written to stderr.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer, env environment) error {
+	opts, err := parseFlags(args, stderr, env.factories)
+	if err != nil {
+		return err
+	}
+
+	cfg, accountID, err := env.loadAWS(ctx, opts.profile)
+	if err != nil {
+		return fmt.Errorf("loading AWS configuration: %w", err)
+	}
+	if len(opts.regions) == 0 {
+		if cfg.Region == "" {
+			return fmt.Errorf("no region configured; pass -regions")
+		}
+		opts.regions = []string{cfg.Region}
+	}
+
+	coordinator := scanner.NewCoordinator(cfg, accountID)
+	for _, service := range opts.services {
+		coordinator.RegisterScanner(service, env.factories[service])
+	}
+
+	scanConfig := scanner.ScanConfig{
+		AccountID:   accountID,
+		Regions:     opts.regions,
+		Services:    opts.services,
+		IncludeTags: opts.includeTags,
+	}
+	if opts.stream {
+		return streamNDJSON(ctx, coordinator, scanConfig, stdout)
+	}
+
+	result, err := coordinator.StartScan(ctx, scanConfig)
+	if err != nil {
+		return err
+	}
+	return outputFormats[opts.output](result, stdout)
+}
+
+// parseFlags parses and validates args. Services default to every scanner in
+// factories.
+func parseFlags(args []string, stderr io.Writer, factories map[string]scanner.Factory) (options, error) {
+	fs := flag.NewFlagSet("scan", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	profile := fs.String("profile", "", "shared config profile to use instead of the default credential chain")
+	services := fs.String("services", "", "comma-separated services to scan (default: all)")
+	regions := fs.String("regions", "", "comma-separated regions to scan (default: the configured region)")
+	output := fs.String("output", "json", "output format: json, csv, or sarif")
+	stream := fs.Bool("stream", false, "print each finding as an NDJSON line as soon as it is found")
+	includeTags := fs.Bool("include-tags", false, "fetch resource tags and attach them to findings")
+	if err := fs.Parse(args); err != nil {
+		return options{}, err
+	}
+
+	opts := options{
+		profile:     *profile,
+		services:    splitList(*services),
+		regions:     splitList(*regions),
+		output:      *output,
+		stream:      *stream,
+		includeTags: *includeTags,
+	}
+	if _, ok := outputFormats[opts.output]; !ok {
+		return options{}, fmt.Errorf("unknown output format %q", opts.output)
+	}
+	if opts.stream && opts.output != "json" {
+		return options{}, fmt.Errorf("-stream only supports json output")
+	}
+
+	if len(opts.services) == 0 {
+		for service := range factories {
+			opts.services = append(opts.services, service)
+		}
+		slices.Sort(opts.services)
+	}
+	for _, service := range opts.services {
+		if _, ok := factories[service]; !ok {
+			return options{}, fmt.Errorf("unknown service %q", service)
+		}
+	}
+	return opts, nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// loadAWS loads configuration from the default credential chain, or from the
+// named profile, and resolves the account with STS.
+func loadAWS(ctx context.Context, profile string) (aws.Config, string, error) {
+	var optFns []func(*config.LoadOptions) error
+	if profile != "" {
+		optFns = append(optFns, config.WithSharedConfigProfile(profile))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, optFns...)
+	if err != nil {
+		return aws.Config{}, "", err
+	}
+
+	identity, err := sts.NewFromConfig(cfg).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return aws.Config{}, "", fmt.Errorf("resolving account: %w", err)
+	}
+	return cfg, aws.ToString(identity.Account), nil
+}
+
+// streamNDJSON writes each finding to w as one JSON line as soon as the
+// coordinator reports it, flushing w after every line when it buffers.
+// Findings appear in the order their service/region scans finish.
+func streamNDJSON(ctx context.Context, coordinator *scanner.Coordinator, config scanner.ScanConfig, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	flusher, _ := w.(interface{ Flush() error })
+	return coordinator.StreamScan(ctx, config, func(f scanner.Finding) error {
+		if err := enc.Encode(f); err != nil {
+			return err
+		}
+		if flusher != nil {
+			return flusher.Flush()
+		}
+		return nil
+	})
+}
+
+func writeJSON(result *scanner.ScanResult, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
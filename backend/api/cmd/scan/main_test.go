@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// mockScanner returns one failed and one passed finding per region.
+type mockScanner struct {
+	service string
+}
+
+func (m *mockScanner) Scan(_ context.Context, region string) ([]scanner.Finding, error) {
+	return []scanner.Finding{
+		{Service: m.service, Region: region, ResourceID: "res-1", CheckID: m.service + "_check", Status: scanner.StatusFail, Severity: scanner.SeverityHigh, Title: "Failed check"},
+		{Service: m.service, Region: region, ResourceID: "res-2", CheckID: m.service + "_check", Status: scanner.StatusPass, Severity: scanner.SeverityHigh, Title: "Passed check"},
+	}, nil
+}
+
+func (m *mockScanner) Service() string { return m.service }
+
+func testEnvironment(profile *string) environment {
+	factory := func(service string) scanner.Factory {
+		return func(_ aws.Config, _, _ string) scanner.ServiceScanner {
+			return &mockScanner{service: service}
+		}
+	}
+	return environment{
+		loadAWS: func(_ context.Context, p string) (aws.Config, string, error) {
+			*profile = p
+			return aws.Config{Region: "eu-west-1"}, "123456789012", nil
+		},
+		factories: map[string]scanner.Factory{"s3": factory("s3"), "iam": factory("iam")},
+	}
+}
+
+func runScan(t *testing.T, args ...string) (string, string) {
+	t.Helper()
+	var profile string
+	var stdout bytes.Buffer
+	if err := run(context.Background(), args, &stdout, io.Discard, testEnvironment(&profile)); err != nil {
+		t.Fatalf("run(%v) error = %v", args, err)
+	}
+	return stdout.String(), profile
+}
+
+func TestRun_JSONDefaults(t *testing.T) {
+	out, profile := runScan(t)
+
+	var result scanner.ScanResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("output is not JSON: %v", err)
+	}
+	if profile != "" {
+		t.Errorf("profile = %q, want the default chain", profile)
+	}
+	if result.AccountID != "123456789012" {
+		t.Errorf("AccountID = %s, want 123456789012", result.AccountID)
+	}
+	if strings.Join(result.Services, ",") != "iam,s3" || strings.Join(result.Regions, ",") != "eu-west-1" {
+		t.Errorf("scanned %v in %v, want all services in the configured region", result.Services, result.Regions)
+	}
+	if result.TotalChecks != 4 || result.FailedChecks != 2 {
+		t.Errorf("got %d checks / %d failed, want 4 / 2", result.TotalChecks, result.FailedChecks)
+	}
+}
+
+func TestRun_CSV(t *testing.T) {
+	out, profile := runScan(t, "-profile", "audit", "-services", "s3", "-regions", "us-east-1, us-west-2", "-output", "csv")
+
+	if profile != "audit" {
+		t.Errorf("profile = %q, want audit", profile)
+	}
+	rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not CSV: %v", err)
+	}
+	// Header plus two findings in each of two regions.
+	if len(rows) != 5 {
+		t.Fatalf("got %d rows, want 5", len(rows))
+	}
+	if rows[0][0] != "service" || rows[1][0] != "s3" {
+		t.Errorf("unexpected rows: %v", rows[:2])
+	}
+}
+
+func TestRun_SARIF(t *testing.T) {
+	out, _ := runScan(t, "-services", "iam", "-output", "sarif")
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []struct {
+				RuleID string `json:"ruleId"`
+				Level  string `json:"level"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal([]byte(out), &log); err != nil {
+		t.Fatalf("output is not SARIF JSON: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected SARIF log: %s", out)
+	}
+	results := log.Runs[0].Results
+	if len(results) != 1 || results[0].RuleID != "iam_check" || results[0].Level != "error" {
+		t.Errorf("results = %+v, want only the failed iam_check as an error", results)
+	}
+}
+
+// flushingBuffer records how many times the streamed output was flushed.
+type flushingBuffer struct {
+	bytes.Buffer
+	flushes int
+}
+
+func (b *flushingBuffer) Flush() error {
+	b.flushes++
+	return nil
+}
+
+func TestRun_Stream(t *testing.T) {
+	var profile string
+	var stdout flushingBuffer
+	args := []string{"-stream", "-regions", "us-east-1,us-west-2"}
+	if err := run(context.Background(), args, &stdout, io.Discard, testEnvironment(&profile)); err != nil {
+		t.Fatalf("run(%v) error = %v", args, err)
+	}
+
+	// Two findings from each of two services in each of two regions.
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	if len(lines) != 8 {
+		t.Fatalf("streamed %d lines, want one per finding (8)", len(lines))
+	}
+	for i, line := range lines {
+		var f scanner.Finding
+		if err := json.Unmarshal([]byte(line), &f); err != nil {
+			t.Fatalf("line %d is not a JSON finding: %v", i, err)
+		}
+	}
+	if stdout.flushes != len(lines) {
+		t.Errorf("flushed %d times, want once per finding (%d)", stdout.flushes, len(lines))
+	}
+}
+
+func TestRun_InvalidFlags(t *testing.T) {
+	tests := [][]string{
+		{"-output", "xml"},
+		{"-services", "s3,rds"},
+		{"-unknown"},
+		{"-stream", "-output", "csv"},
+	}
+	for _, args := range tests {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			var profile string
+			var stdout bytes.Buffer
+			if err := run(context.Background(), args, &stdout, io.Discard, testEnvironment(&profile)); err == nil {
+				t.Errorf("run(%v) succeeded, want an error", args)
+			}
+			if stdout.Len() != 0 {
+				t.Errorf("wrote %d bytes to stdout on error", stdout.Len())
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -15,19 +16,28 @@ import (
 	"cloudcop/api/internal/database"
 	"cloudcop/api/internal/graphdb"
 	"cloudcop/api/internal/handlers"
+	"cloudcop/api/internal/metrics"
 	"cloudcop/api/internal/middleware/auth"
+	"cloudcop/api/internal/middleware/ratelimit"
+	"cloudcop/api/internal/scanner"
+	"cloudcop/api/internal/scanner/registry"
+	"cloudcop/api/internal/scheduler"
+	"cloudcop/api/internal/security"
 
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-// main initializes services (PostgreSQL, optional Neo4j, and AWS auth), registers HTTP and GraphQL routes,
-// starts the API server on :8080, and performs a graceful shutdown on SIGINT/SIGTERM by stopping the credential
-// cache and closing Neo4j and database connections.
+// main initializes services (PostgreSQL, optional Neo4j, AWS auth, and the security scanning service), registers
+// HTTP and GraphQL routes, starts the scan scheduler, the API server on :8080 and the metrics server on METRICS_ADDR
+// (default 127.0.0.1:9090), and performs a graceful shutdown
+// on SIGINT/SIGTERM by stopping the scheduler and credential cache and closing Neo4j and database connections.
 //
-// If Neo4j initialization fails, the server continues to start without Neo4j support.
+// If Neo4j initialization fails, the server continues to start without Neo4j support. In self-hosted mode the
+// account of the server's own AWS credentials is registered for the self-hosted user before serving.
 func main() {
 	// Initialize PostgreSQL
 	dbURL := os.Getenv("DATABASE_URL")
@@ -38,7 +48,7 @@ func main() {
 	if err != nil {
 		log.Fatalf("Unable to connect to database: %v", err)
 	}
-	store := database.New(connPool)
+	store := database.NewStore(connPool)
 
 	// Initialize Neo4j
 	neo4jClient, err := graphdb.NewNeo4jClient(context.Background())
@@ -53,10 +63,60 @@ func main() {
 	}
 
 	cache := awsauth.NewCredentialCache(awsAuth)
-	accountsHandler := handlers.NewAccountsHandler(awsAuth, cache, store)
+	accountsHandler := handlers.NewAccountsHandler(awsAuth, cache, store.Queries)
+	if err := accountsHandler.ImportSelfHostedAccount(context.Background()); err != nil {
+		log.Printf("Warning: Failed to import self-hosted account: %v", err)
+	}
+	scansHandler := handlers.NewScansHandler(store.Queries)
+
+	rateConfig, err := ratelimit.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid rate limit configuration: %v", err)
+	}
+	queryLimiter := ratelimit.NewLimiter(rateConfig)
+
+	// Connected accounts are scanned with their assumed scan role. AI
+	// summaries are generated when the AI service address is configured.
+	summAddress := os.Getenv("AI_SERVICE_GRPC_ADDR")
+	securityService, err := security.NewService(security.Config{
+		AWSConfig:            awsAuth.Config(),
+		SummarizationAddress: summAddress,
+		EnableSummarization:  summAddress != "",
+		CredentialsFor:       scanCredentials(store.Queries, cache),
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize security service: %v", err)
+	}
+	registry.RegisterAll(securityService)
+	healthHandler := handlers.NewHealthHandler(connPool, neo4jClient, securityService.SummarizationClient())
+
+	findingMetrics := metrics.NewCollector()
+	resolver := &graph.Resolver{
+		DB:           store.Queries,
+		Auth:         awsAuth,
+		Cache:        cache,
+		Neo4j:        neo4jClient,
+		Security:     securityService,
+		Scans:        store,
+		Suppressions: store.Queries,
+		Schedules:    store.Queries,
+		Summarizer:   securityService,
+		Metrics:      findingMetrics,
+	}
+
+	// Scheduled and REST-started scans run through the same security service,
+	// suppressions and persistence as the startScan mutation.
+	scanJobsHandler := handlers.NewScanJobsHandler(resolver.RunScan, store.Queries, resolver.SaveScan)
+	scanScheduler := scheduler.New(scheduler.Config{
+		Store:   store.Queries,
+		Scanner: scheduler.ScannerFunc(resolver.RunScan),
+		Persist: resolver.SaveScan,
+	})
+	scanScheduler.Start(context.Background())
 
 	r := gin.Default()
-	r.GET("/health", handlers.Health)
+	r.GET("/health", healthHandler.Health)
+	r.GET("/livez", handlers.Livez)
 
 	api := r.Group("/api")
 	api.Use(auth.Middleware()) // Apply auth middleware to API routes including GraphQL
@@ -66,18 +126,23 @@ func main() {
 			accounts.POST("/verify", accountsHandler.VerifyAccountHandler)
 			accounts.POST("/connect", accountsHandler.ConnectAccountHandler)
 			accounts.GET("", accountsHandler.ListAccountsHandler)
+			accounts.DELETE("", accountsHandler.DisconnectAllAccountsHandler)
 			accounts.DELETE("/:id", accountsHandler.DisconnectAccountHandler)
+			accounts.GET("/:id/health", accountsHandler.AccountHealthHandler)
+		}
+
+		scans := api.Group("/scans")
+		{
+			scans.POST("", scanJobsHandler.StartScanHandler)
+			scans.GET("/:id", scanJobsHandler.ScanJobHandler)
+			scans.GET("/:id/findings.ndjson", scansHandler.StreamFindingsHandler)
 		}
 
 		// GraphQL Endpoint
-		srv := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{
-			DB:    store,
-			Auth:  awsAuth,
-			Cache: cache,
-			Neo4j: neo4jClient,
-		}}))
-
-		api.POST("/query", func(c *gin.Context) {
+		srv := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: resolver}))
+
+		// Rate limited per user, so this must stay behind the auth middleware.
+		api.POST("/query", ratelimit.Middleware(queryLimiter), func(c *gin.Context) {
 			srv.ServeHTTP(c.Writer, c.Request)
 		})
 
@@ -93,13 +158,36 @@ func main() {
 		ReadHeaderTimeout: 10 * time.Second,
 	}
 
+	// Metrics describe every team's accounts, so they are served on their own
+	// listener, bound to loopback unless METRICS_ADDR points it at a private
+	// interface for Prometheus to scrape.
+	metricsAddr := os.Getenv("METRICS_ADDR")
+	if metricsAddr == "" {
+		metricsAddr = "127.0.0.1:9090"
+	}
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", findingMetrics.Handler())
+	metricsSrv := &http.Server{
+		Addr:              metricsAddr,
+		Handler:           metricsMux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
 	/*
-		Graceful shutdown: listen for SIGINT/SIGTERM, stop the credential cache
-		background goroutine, then shutdown the HTTP server with a timeout.
+		Graceful shutdown: listen for SIGINT/SIGTERM, stop the scan scheduler,
+		background scan jobs, and the credential cache goroutines, then shutdown
+		the HTTP server with a timeout.
 	*/
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
+	go func() {
+		log.Printf("Serving metrics on %s", metricsAddr)
+		if err := metricsSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("Metrics server stopped: %v", err)
+		}
+	}()
+
 	go func() {
 		log.Println("Starting CloudCop API server on :8080")
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -110,6 +198,11 @@ func main() {
 	<-quit
 	log.Println("Shutting down server...")
 
+	scanScheduler.Stop()
+	scanJobsHandler.Stop()
+	if err := securityService.Close(); err != nil {
+		log.Printf("Error closing security service: %v", err)
+	}
 	cache.Stop()
 	if neo4jClient != nil {
 		if err := neo4jClient.Close(context.Background()); err != nil {
@@ -124,9 +217,32 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	if err := metricsSrv.Shutdown(ctx); err != nil {
+		log.Printf("Error shutting down metrics server: %v", err)
+	}
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
 
 	log.Println("Server exited gracefully")
 }
+
+// scanCredentials returns the credentials lookup of the security service: an
+// account is scanned with the scan role of the connection the scan runs for,
+// through the credential cache. Scans outside a connection cannot run, since
+// another team may have connected the same AWS account with its own role.
+func scanCredentials(queries *database.Queries, cache *awsauth.CredentialCache) func(context.Context, scanner.ScanConfig) (aws.CredentialsProvider, error) {
+	return func(ctx context.Context, config scanner.ScanConfig) (aws.CredentialsProvider, error) {
+		if config.ConnectionID == 0 {
+			return nil, fmt.Errorf("account %s is not connected", config.AccountID)
+		}
+		account, err := queries.GetAccountByID(ctx, config.ConnectionID)
+		if err != nil {
+			return nil, fmt.Errorf("account %s is not connected: %w", config.AccountID, err)
+		}
+		if account.AccountID != config.AccountID {
+			return nil, fmt.Errorf("connection %d is not for account %s", config.ConnectionID, config.AccountID)
+		}
+		return cache.Provider(account.AccountID, account.ExternalID), nil
+	}
+}
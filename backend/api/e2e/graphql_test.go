@@ -2,17 +2,50 @@ package e2e
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"cloudcop/api/graph"
+	"cloudcop/api/internal/database"
+	"cloudcop/api/internal/middleware/auth"
 	"cloudcop/api/internal/scanner"
 	"cloudcop/api/internal/scanner/s3"
 	"cloudcop/api/internal/security"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/clerkinc/clerk-sdk-go/clerk"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+// connectedAccountStore connects TestAccountID to the team of every user
+// without a database. Scans are not persisted, so the resolver serves them
+// from memory.
+type connectedAccountStore struct {
+	graph.ScanStore
+}
+
+func (s *connectedAccountStore) GetTeamByOwnerID(_ context.Context, _ string) (database.Team, error) {
+	return database.Team{ID: 1}, nil
+}
+
+func (s *connectedAccountStore) GetAccountByTeamAndAccountID(_ context.Context, arg database.GetAccountByTeamAndAccountIDParams) (database.AwsAccount, error) {
+	return database.AwsAccount{ID: 1, TeamID: arg.TeamID, AccountID: arg.AccountID}, nil
+}
+
+func (s *connectedAccountStore) GetAccountByID(_ context.Context, id int32) (database.AwsAccount, error) {
+	return database.AwsAccount{ID: id, TeamID: pgtype.Int4{Int32: 1, Valid: true}, AccountID: TestAccountID}, nil
+}
+
+func (s *connectedAccountStore) CreateScanWithFindings(_ context.Context, _ database.CreateScanParams, _ []database.InsertScanFindingsParams) (database.Scan, error) {
+	return database.Scan{}, errors.New("scans are not persisted in this test")
+}
+
+func (s *connectedAccountStore) GetScanSummary(_ context.Context, _ int32) ([]byte, error) {
+	return nil, pgx.ErrNoRows
+}
+
 // TestGraphQL_StartScan_E2E tests the GraphQL StartScan resolver end-to-end
 func TestGraphQL_StartScan_E2E(t *testing.T) {
 	if testing.Short() {
@@ -61,9 +94,11 @@ func TestGraphQL_StartScan_E2E(t *testing.T) {
 	// 3. Create Resolver
 	resolver := &graph.Resolver{
 		Security: svc,
+		Scans:    &connectedAccountStore{},
 	}
 
-	// 4. Invoke StartScan via Resolver
+	// 4. Invoke StartScan via Resolver as the owner of the connected account
+	ctx = auth.AttachContext(ctx, &clerk.User{ID: "e2e-user"})
 	mutation := resolver.Mutation()
 
 	t.Log("Invoking StartScan mutation...")
@@ -65,6 +65,7 @@ func TestS3Scanner_E2E(t *testing.T) {
 				"s3_mfa_delete":        scanner.StatusFail,
 				"s3_lifecycle_policy":  scanner.StatusFail,
 				"s3_ssl_only":          scanner.StatusFail,
+				"s3_static_website":    scanner.StatusPass,
 			},
 		},
 		{
@@ -287,6 +288,33 @@ func TestS3Scanner_E2E(t *testing.T) {
 				"s3_lifecycle_policy": scanner.StatusPass,
 			},
 		},
+		{
+			name: "static_website_bucket",
+			setupBucket: func(t *testing.T, ctx context.Context, client *awss3.Client, bucketName string) {
+				// Create bucket
+				_, err := client.CreateBucket(ctx, &awss3.CreateBucketInput{
+					Bucket: aws.String(bucketName),
+				})
+				if err != nil {
+					t.Fatalf("Failed to create bucket: %v", err)
+				}
+
+				// Enable static website hosting
+				_, err = client.PutBucketWebsite(ctx, &awss3.PutBucketWebsiteInput{
+					Bucket: aws.String(bucketName),
+					WebsiteConfiguration: &types.WebsiteConfiguration{
+						IndexDocument: &types.IndexDocument{Suffix: aws.String("index.html")},
+					},
+				})
+				if err != nil {
+					t.Fatalf("Failed to set website configuration: %v", err)
+				}
+			},
+			expectedChecks: map[string]scanner.FindingStatus{
+				"s3_static_website": scanner.StatusFail,
+				"s3_replication":    scanner.StatusFail,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -13,6 +13,7 @@ import (
 	"strconv"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/introspection"
@@ -40,9 +41,11 @@ type Config struct {
 }
 
 type ResolverRoot interface {
+	FindingSuppression() FindingSuppressionResolver
 	Mutation() MutationResolver
 	Query() QueryResolver
 	Scan() ScanResolver
+	ScanSchedule() ScanScheduleResolver
 	Team() TeamResolver
 	TeamMember() TeamMemberResolver
 	User() UserResolver
@@ -72,15 +75,20 @@ type ComplexityRoot struct {
 	}
 
 	Finding struct {
-		CheckID     func(childComplexity int) int
-		Compliance  func(childComplexity int) int
-		Description func(childComplexity int) int
-		ID          func(childComplexity int) int
-		Region      func(childComplexity int) int
-		ResourceID  func(childComplexity int) int
-		Service     func(childComplexity int) int
-		Severity    func(childComplexity int) int
-		Title       func(childComplexity int) int
+		Arn               func(childComplexity int) int
+		CheckID           func(childComplexity int) int
+		Compliance        func(childComplexity int) int
+		Confidence        func(childComplexity int) int
+		Description       func(childComplexity int) int
+		ID                func(childComplexity int) int
+		Region            func(childComplexity int) int
+		ResourceCreatedAt func(childComplexity int) int
+		ResourceID        func(childComplexity int) int
+		Service           func(childComplexity int) int
+		Severity          func(childComplexity int) int
+		Status            func(childComplexity int) int
+		Tags              func(childComplexity int) int
+		Title             func(childComplexity int) int
 	}
 
 	FindingGroupSummary struct {
@@ -95,22 +103,39 @@ type ComplexityRoot struct {
 		Title        func(childComplexity int) int
 	}
 
+	FindingSuppression struct {
+		CreatedAt func(childComplexity int) int
+		ExpiresAt func(childComplexity int) int
+		FindingID func(childComplexity int) int
+		Reason    func(childComplexity int) int
+	}
+
 	Mutation struct {
-		ConnectAccount   func(childComplexity int, accountID string, externalID string, roleArn string) int
-		StartScan        func(childComplexity int, accountID string, services []string, regions []string) int
-		VerifyAWSAccount func(childComplexity int, accountID string, externalID string) int
+		ConnectAccount     func(childComplexity int, accountID string, externalID string, roleArn string) int
+		CreateScanSchedule func(childComplexity int, accountID string, cronExpr string, services []string, regions []string) int
+		DeleteScanSchedule func(childComplexity int, accountID string, id string) int
+		StartScan          func(childComplexity int, accountID string, services []string, regions []string) int
+		SummarizeScan      func(childComplexity int, scanID string) int
+		SuppressFinding    func(childComplexity int, findingID string, reason string, expiresAt *time.Time) int
+		UnsuppressFinding  func(childComplexity int, findingID string) int
+		VerifyAWSAccount   func(childComplexity int, accountID string, externalID string) int
 	}
 
 	Query struct {
-		Me         func(childComplexity int) int
-		MyAccounts func(childComplexity int) int
-		Team       func(childComplexity int, slug string) int
+		Me            func(childComplexity int) int
+		MyAccounts    func(childComplexity int) int
+		Scan          func(childComplexity int, id string) int
+		ScanSchedules func(childComplexity int, accountID string) int
+		Scans         func(childComplexity int, accountID string, limit *int, offset *int) int
+		SecurityScore func(childComplexity int, accountID string) int
+		Team          func(childComplexity int, slug string) int
 	}
 
 	Scan struct {
 		CompletedAt  func(childComplexity int) int
 		CreatedAt    func(childComplexity int) int
-		Findings     func(childComplexity int) int
+		Errors       func(childComplexity int) int
+		Findings     func(childComplexity int, onlyFailures *bool, service *string, severity *string, status *string) int
 		ID           func(childComplexity int) int
 		OverallScore func(childComplexity int) int
 		Regions      func(childComplexity int) int
@@ -120,6 +145,24 @@ type ComplexityRoot struct {
 		Summary      func(childComplexity int) int
 	}
 
+	ScanError struct {
+		Message func(childComplexity int) int
+		Region  func(childComplexity int) int
+		Service func(childComplexity int) int
+		Type    func(childComplexity int) int
+	}
+
+	ScanSchedule struct {
+		CreatedAt func(childComplexity int) int
+		CronExpr  func(childComplexity int) int
+		Enabled   func(childComplexity int) int
+		ID        func(childComplexity int) int
+		LastRunAt func(childComplexity int) int
+		NextRunAt func(childComplexity int) int
+		Regions   func(childComplexity int) int
+		Services  func(childComplexity int) int
+	}
+
 	ScanSummary struct {
 		Actions     func(childComplexity int) int
 		Groups      func(childComplexity int) int
@@ -128,6 +171,27 @@ type ComplexityRoot struct {
 		SummaryText func(childComplexity int) int
 	}
 
+	SecurityScore struct {
+		RiskLevel         func(childComplexity int) int
+		RiskScore         func(childComplexity int) int
+		ScanID            func(childComplexity int) int
+		ScannedAt         func(childComplexity int) int
+		SeverityBreakdown func(childComplexity int) int
+		TrendDelta        func(childComplexity int) int
+	}
+
+	SeverityBreakdown struct {
+		Critical func(childComplexity int) int
+		High     func(childComplexity int) int
+		Low      func(childComplexity int) int
+		Medium   func(childComplexity int) int
+	}
+
+	Tag struct {
+		Key   func(childComplexity int) int
+		Value func(childComplexity int) int
+	}
+
 	Team struct {
 		AWSAccounts func(childComplexity int) int
 		ID          func(childComplexity int) int
@@ -149,26 +213,47 @@ type ComplexityRoot struct {
 	}
 }
 
+type FindingSuppressionResolver interface {
+	ExpiresAt(ctx context.Context, obj *database.FindingSuppression) (*time.Time, error)
+	CreatedAt(ctx context.Context, obj *database.FindingSuppression) (*time.Time, error)
+}
 type MutationResolver interface {
 	VerifyAWSAccount(ctx context.Context, accountID string, externalID string) (*model.AWSAccount, error)
 	ConnectAccount(ctx context.Context, accountID string, externalID string, roleArn string) (*model.AWSAccount, error)
 	StartScan(ctx context.Context, accountID string, services []string, regions []string) (*database.Scan, error)
+	SummarizeScan(ctx context.Context, scanID string) (*model.ScanSummary, error)
+	SuppressFinding(ctx context.Context, findingID string, reason string, expiresAt *time.Time) (*database.FindingSuppression, error)
+	UnsuppressFinding(ctx context.Context, findingID string) (bool, error)
+	CreateScanSchedule(ctx context.Context, accountID string, cronExpr string, services []string, regions []string) (*database.ScanSchedule, error)
+	DeleteScanSchedule(ctx context.Context, accountID string, id string) (bool, error)
 }
 type QueryResolver interface {
 	Me(ctx context.Context) (*database.User, error)
 	Team(ctx context.Context, slug string) (*database.Team, error)
 	MyAccounts(ctx context.Context) ([]model.AWSAccount, error)
+	SecurityScore(ctx context.Context, accountID string) (*model.SecurityScore, error)
+	Scans(ctx context.Context, accountID string, limit *int, offset *int) ([]database.Scan, error)
+	Scan(ctx context.Context, id string) (*database.Scan, error)
+	ScanSchedules(ctx context.Context, accountID string) ([]database.ScanSchedule, error)
 }
 type ScanResolver interface {
 	ID(ctx context.Context, obj *database.Scan) (string, error)
 
 	OverallScore(ctx context.Context, obj *database.Scan) (*int, error)
-	Findings(ctx context.Context, obj *database.Scan) ([]model.Finding, error)
+	Findings(ctx context.Context, obj *database.Scan, onlyFailures *bool, service *string, severity *string, status *string) ([]model.Finding, error)
 	Summary(ctx context.Context, obj *database.Scan) (*model.ScanSummary, error)
+	Errors(ctx context.Context, obj *database.Scan) ([]model.ScanError, error)
 	StartedAt(ctx context.Context, obj *database.Scan) (*string, error)
 	CompletedAt(ctx context.Context, obj *database.Scan) (*string, error)
 	CreatedAt(ctx context.Context, obj *database.Scan) (string, error)
 }
+type ScanScheduleResolver interface {
+	ID(ctx context.Context, obj *database.ScanSchedule) (string, error)
+
+	NextRunAt(ctx context.Context, obj *database.ScanSchedule) (*time.Time, error)
+	LastRunAt(ctx context.Context, obj *database.ScanSchedule) (*time.Time, error)
+	CreatedAt(ctx context.Context, obj *database.ScanSchedule) (*time.Time, error)
+}
 type TeamResolver interface {
 	ID(ctx context.Context, obj *database.Team) (string, error)
 
@@ -282,6 +367,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.ActionItemSummary.Title(childComplexity), true
 
+	case "Finding.arn":
+		if e.complexity.Finding.Arn == nil {
+			break
+		}
+
+		return e.complexity.Finding.Arn(childComplexity), true
 	case "Finding.checkId":
 		if e.complexity.Finding.CheckID == nil {
 			break
@@ -294,6 +385,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Finding.Compliance(childComplexity), true
+	case "Finding.confidence":
+		if e.complexity.Finding.Confidence == nil {
+			break
+		}
+
+		return e.complexity.Finding.Confidence(childComplexity), true
 	case "Finding.description":
 		if e.complexity.Finding.Description == nil {
 			break
@@ -312,6 +409,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Finding.Region(childComplexity), true
+	case "Finding.resourceCreatedAt":
+		if e.complexity.Finding.ResourceCreatedAt == nil {
+			break
+		}
+
+		return e.complexity.Finding.ResourceCreatedAt(childComplexity), true
 	case "Finding.resourceId":
 		if e.complexity.Finding.ResourceID == nil {
 			break
@@ -330,6 +433,18 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Finding.Severity(childComplexity), true
+	case "Finding.status":
+		if e.complexity.Finding.Status == nil {
+			break
+		}
+
+		return e.complexity.Finding.Status(childComplexity), true
+	case "Finding.tags":
+		if e.complexity.Finding.Tags == nil {
+			break
+		}
+
+		return e.complexity.Finding.Tags(childComplexity), true
 	case "Finding.title":
 		if e.complexity.Finding.Title == nil {
 			break
@@ -392,6 +507,31 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.FindingGroupSummary.Title(childComplexity), true
 
+	case "FindingSuppression.createdAt":
+		if e.complexity.FindingSuppression.CreatedAt == nil {
+			break
+		}
+
+		return e.complexity.FindingSuppression.CreatedAt(childComplexity), true
+	case "FindingSuppression.expiresAt":
+		if e.complexity.FindingSuppression.ExpiresAt == nil {
+			break
+		}
+
+		return e.complexity.FindingSuppression.ExpiresAt(childComplexity), true
+	case "FindingSuppression.findingId":
+		if e.complexity.FindingSuppression.FindingID == nil {
+			break
+		}
+
+		return e.complexity.FindingSuppression.FindingID(childComplexity), true
+	case "FindingSuppression.reason":
+		if e.complexity.FindingSuppression.Reason == nil {
+			break
+		}
+
+		return e.complexity.FindingSuppression.Reason(childComplexity), true
+
 	case "Mutation.connectAccount":
 		if e.complexity.Mutation.ConnectAccount == nil {
 			break
@@ -403,6 +543,28 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Mutation.ConnectAccount(childComplexity, args["accountId"].(string), args["externalId"].(string), args["roleArn"].(string)), true
+	case "Mutation.createScanSchedule":
+		if e.complexity.Mutation.CreateScanSchedule == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_createScanSchedule_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.CreateScanSchedule(childComplexity, args["accountId"].(string), args["cronExpr"].(string), args["services"].([]string), args["regions"].([]string)), true
+	case "Mutation.deleteScanSchedule":
+		if e.complexity.Mutation.DeleteScanSchedule == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_deleteScanSchedule_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.DeleteScanSchedule(childComplexity, args["accountId"].(string), args["id"].(string)), true
 	case "Mutation.startScan":
 		if e.complexity.Mutation.StartScan == nil {
 			break
@@ -414,6 +576,39 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Mutation.StartScan(childComplexity, args["accountId"].(string), args["services"].([]string), args["regions"].([]string)), true
+	case "Mutation.summarizeScan":
+		if e.complexity.Mutation.SummarizeScan == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_summarizeScan_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.SummarizeScan(childComplexity, args["scanId"].(string)), true
+	case "Mutation.suppressFinding":
+		if e.complexity.Mutation.SuppressFinding == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_suppressFinding_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.SuppressFinding(childComplexity, args["findingId"].(string), args["reason"].(string), args["expiresAt"].(*time.Time)), true
+	case "Mutation.unsuppressFinding":
+		if e.complexity.Mutation.UnsuppressFinding == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_unsuppressFinding_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.UnsuppressFinding(childComplexity, args["findingId"].(string)), true
 	case "Mutation.verifyAwsAccount":
 		if e.complexity.Mutation.VerifyAWSAccount == nil {
 			break
@@ -438,6 +633,50 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Query.MyAccounts(childComplexity), true
+	case "Query.scan":
+		if e.complexity.Query.Scan == nil {
+			break
+		}
+
+		args, err := ec.field_Query_scan_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Scan(childComplexity, args["id"].(string)), true
+	case "Query.scanSchedules":
+		if e.complexity.Query.ScanSchedules == nil {
+			break
+		}
+
+		args, err := ec.field_Query_scanSchedules_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.ScanSchedules(childComplexity, args["accountId"].(string)), true
+	case "Query.scans":
+		if e.complexity.Query.Scans == nil {
+			break
+		}
+
+		args, err := ec.field_Query_scans_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Scans(childComplexity, args["accountId"].(string), args["limit"].(*int), args["offset"].(*int)), true
+	case "Query.securityScore":
+		if e.complexity.Query.SecurityScore == nil {
+			break
+		}
+
+		args, err := ec.field_Query_securityScore_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.SecurityScore(childComplexity, args["accountId"].(string)), true
 	case "Query.team":
 		if e.complexity.Query.Team == nil {
 			break
@@ -462,12 +701,23 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Scan.CreatedAt(childComplexity), true
+	case "Scan.errors":
+		if e.complexity.Scan.Errors == nil {
+			break
+		}
+
+		return e.complexity.Scan.Errors(childComplexity), true
 	case "Scan.findings":
 		if e.complexity.Scan.Findings == nil {
 			break
 		}
 
-		return e.complexity.Scan.Findings(childComplexity), true
+		args, err := ec.field_Scan_findings_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Scan.Findings(childComplexity, args["onlyFailures"].(*bool), args["service"].(*string), args["severity"].(*string), args["status"].(*string)), true
 	case "Scan.id":
 		if e.complexity.Scan.ID == nil {
 			break
@@ -511,6 +761,80 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.Scan.Summary(childComplexity), true
 
+	case "ScanError.message":
+		if e.complexity.ScanError.Message == nil {
+			break
+		}
+
+		return e.complexity.ScanError.Message(childComplexity), true
+	case "ScanError.region":
+		if e.complexity.ScanError.Region == nil {
+			break
+		}
+
+		return e.complexity.ScanError.Region(childComplexity), true
+	case "ScanError.service":
+		if e.complexity.ScanError.Service == nil {
+			break
+		}
+
+		return e.complexity.ScanError.Service(childComplexity), true
+	case "ScanError.type":
+		if e.complexity.ScanError.Type == nil {
+			break
+		}
+
+		return e.complexity.ScanError.Type(childComplexity), true
+
+	case "ScanSchedule.createdAt":
+		if e.complexity.ScanSchedule.CreatedAt == nil {
+			break
+		}
+
+		return e.complexity.ScanSchedule.CreatedAt(childComplexity), true
+	case "ScanSchedule.cronExpr":
+		if e.complexity.ScanSchedule.CronExpr == nil {
+			break
+		}
+
+		return e.complexity.ScanSchedule.CronExpr(childComplexity), true
+	case "ScanSchedule.enabled":
+		if e.complexity.ScanSchedule.Enabled == nil {
+			break
+		}
+
+		return e.complexity.ScanSchedule.Enabled(childComplexity), true
+	case "ScanSchedule.id":
+		if e.complexity.ScanSchedule.ID == nil {
+			break
+		}
+
+		return e.complexity.ScanSchedule.ID(childComplexity), true
+	case "ScanSchedule.lastRunAt":
+		if e.complexity.ScanSchedule.LastRunAt == nil {
+			break
+		}
+
+		return e.complexity.ScanSchedule.LastRunAt(childComplexity), true
+	case "ScanSchedule.nextRunAt":
+		if e.complexity.ScanSchedule.NextRunAt == nil {
+			break
+		}
+
+		return e.complexity.ScanSchedule.NextRunAt(childComplexity), true
+	case "ScanSchedule.regions":
+		if e.complexity.ScanSchedule.Regions == nil {
+			break
+		}
+
+		return e.complexity.ScanSchedule.Regions(childComplexity), true
+	case "ScanSchedule.services":
+		if e.complexity.ScanSchedule.Services == nil {
+			break
+		}
+
+		return e.complexity.ScanSchedule.Services(childComplexity), true
+
 	case "ScanSummary.actions":
 		if e.complexity.ScanSummary.Actions == nil {
 			break
@@ -542,6 +866,81 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.ScanSummary.SummaryText(childComplexity), true
 
+	case "SecurityScore.riskLevel":
+		if e.complexity.SecurityScore.RiskLevel == nil {
+			break
+		}
+
+		return e.complexity.SecurityScore.RiskLevel(childComplexity), true
+	case "SecurityScore.riskScore":
+		if e.complexity.SecurityScore.RiskScore == nil {
+			break
+		}
+
+		return e.complexity.SecurityScore.RiskScore(childComplexity), true
+	case "SecurityScore.scanId":
+		if e.complexity.SecurityScore.ScanID == nil {
+			break
+		}
+
+		return e.complexity.SecurityScore.ScanID(childComplexity), true
+	case "SecurityScore.scannedAt":
+		if e.complexity.SecurityScore.ScannedAt == nil {
+			break
+		}
+
+		return e.complexity.SecurityScore.ScannedAt(childComplexity), true
+	case "SecurityScore.severityBreakdown":
+		if e.complexity.SecurityScore.SeverityBreakdown == nil {
+			break
+		}
+
+		return e.complexity.SecurityScore.SeverityBreakdown(childComplexity), true
+	case "SecurityScore.trendDelta":
+		if e.complexity.SecurityScore.TrendDelta == nil {
+			break
+		}
+
+		return e.complexity.SecurityScore.TrendDelta(childComplexity), true
+
+	case "SeverityBreakdown.critical":
+		if e.complexity.SeverityBreakdown.Critical == nil {
+			break
+		}
+
+		return e.complexity.SeverityBreakdown.Critical(childComplexity), true
+	case "SeverityBreakdown.high":
+		if e.complexity.SeverityBreakdown.High == nil {
+			break
+		}
+
+		return e.complexity.SeverityBreakdown.High(childComplexity), true
+	case "SeverityBreakdown.low":
+		if e.complexity.SeverityBreakdown.Low == nil {
+			break
+		}
+
+		return e.complexity.SeverityBreakdown.Low(childComplexity), true
+	case "SeverityBreakdown.medium":
+		if e.complexity.SeverityBreakdown.Medium == nil {
+			break
+		}
+
+		return e.complexity.SeverityBreakdown.Medium(childComplexity), true
+
+	case "Tag.key":
+		if e.complexity.Tag.Key == nil {
+			break
+		}
+
+		return e.complexity.Tag.Key(childComplexity), true
+	case "Tag.value":
+		if e.complexity.Tag.Value == nil {
+			break
+		}
+
+		return e.complexity.Tag.Value(childComplexity), true
+
 	case "Team.awsAccounts":
 		if e.complexity.Team.AWSAccounts == nil {
 			break
@@ -755,28 +1154,33 @@ func (ec *executionContext) field_Mutation_connectAccount_args(ctx context.Conte
 	return args, nil
 }
 
-func (ec *executionContext) field_Mutation_startScan_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+func (ec *executionContext) field_Mutation_createScanSchedule_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
-	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "accountId", ec.unmarshalNID2string)
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "accountId", ec.unmarshalNString2string)
 	if err != nil {
 		return nil, err
 	}
 	args["accountId"] = arg0
-	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "services", ec.unmarshalOString2ᚕstringᚄ)
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "cronExpr", ec.unmarshalNString2string)
 	if err != nil {
 		return nil, err
 	}
-	args["services"] = arg1
-	arg2, err := graphql.ProcessArgField(ctx, rawArgs, "regions", ec.unmarshalOString2ᚕstringᚄ)
+	args["cronExpr"] = arg1
+	arg2, err := graphql.ProcessArgField(ctx, rawArgs, "services", ec.unmarshalOString2ᚕstringᚄ)
 	if err != nil {
 		return nil, err
 	}
-	args["regions"] = arg2
+	args["services"] = arg2
+	arg3, err := graphql.ProcessArgField(ctx, rawArgs, "regions", ec.unmarshalOString2ᚕstringᚄ)
+	if err != nil {
+		return nil, err
+	}
+	args["regions"] = arg3
 	return args, nil
 }
 
-func (ec *executionContext) field_Mutation_verifyAwsAccount_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+func (ec *executionContext) field_Mutation_deleteScanSchedule_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
 	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "accountId", ec.unmarshalNString2string)
@@ -784,81 +1188,241 @@ func (ec *executionContext) field_Mutation_verifyAwsAccount_args(ctx context.Con
 		return nil, err
 	}
 	args["accountId"] = arg0
-	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "externalId", ec.unmarshalNString2string)
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "id", ec.unmarshalNID2string)
 	if err != nil {
 		return nil, err
 	}
-	args["externalId"] = arg1
+	args["id"] = arg1
 	return args, nil
 }
 
-func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+func (ec *executionContext) field_Mutation_startScan_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
-	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "name", ec.unmarshalNString2string)
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "accountId", ec.unmarshalNID2string)
 	if err != nil {
 		return nil, err
 	}
-	args["name"] = arg0
+	args["accountId"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "services", ec.unmarshalOString2ᚕstringᚄ)
+	if err != nil {
+		return nil, err
+	}
+	args["services"] = arg1
+	arg2, err := graphql.ProcessArgField(ctx, rawArgs, "regions", ec.unmarshalOString2ᚕstringᚄ)
+	if err != nil {
+		return nil, err
+	}
+	args["regions"] = arg2
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_team_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+func (ec *executionContext) field_Mutation_summarizeScan_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
-	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "slug", ec.unmarshalNString2string)
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "scanId", ec.unmarshalNID2string)
 	if err != nil {
 		return nil, err
 	}
-	args["slug"] = arg0
+	args["scanId"] = arg0
 	return args, nil
 }
 
-func (ec *executionContext) field___Directive_args_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+func (ec *executionContext) field_Mutation_suppressFinding_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
-	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "includeDeprecated", ec.unmarshalOBoolean2ᚖbool)
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "findingId", ec.unmarshalNID2string)
 	if err != nil {
 		return nil, err
 	}
-	args["includeDeprecated"] = arg0
+	args["findingId"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "reason", ec.unmarshalNString2string)
+	if err != nil {
+		return nil, err
+	}
+	args["reason"] = arg1
+	arg2, err := graphql.ProcessArgField(ctx, rawArgs, "expiresAt", ec.unmarshalOTime2ᚖtimeᚐTime)
+	if err != nil {
+		return nil, err
+	}
+	args["expiresAt"] = arg2
 	return args, nil
 }
 
-func (ec *executionContext) field___Field_args_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+func (ec *executionContext) field_Mutation_unsuppressFinding_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
-	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "includeDeprecated", ec.unmarshalOBoolean2ᚖbool)
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "findingId", ec.unmarshalNID2string)
 	if err != nil {
 		return nil, err
 	}
-	args["includeDeprecated"] = arg0
+	args["findingId"] = arg0
 	return args, nil
 }
 
-func (ec *executionContext) field___Type_enumValues_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+func (ec *executionContext) field_Mutation_verifyAwsAccount_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
-	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "includeDeprecated", ec.unmarshalOBoolean2bool)
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "accountId", ec.unmarshalNString2string)
 	if err != nil {
 		return nil, err
 	}
-	args["includeDeprecated"] = arg0
+	args["accountId"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "externalId", ec.unmarshalNString2string)
+	if err != nil {
+		return nil, err
+	}
+	args["externalId"] = arg1
 	return args, nil
 }
 
-func (ec *executionContext) field___Type_fields_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
-	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "includeDeprecated", ec.unmarshalOBoolean2bool)
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "name", ec.unmarshalNString2string)
 	if err != nil {
 		return nil, err
 	}
-	args["includeDeprecated"] = arg0
+	args["name"] = arg0
 	return args, nil
 }
 
-// endregion ***************************** args.gotpl *****************************
+func (ec *executionContext) field_Query_scanSchedules_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "accountId", ec.unmarshalNString2string)
+	if err != nil {
+		return nil, err
+	}
+	args["accountId"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_scan_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "id", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["id"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_scans_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "accountId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["accountId"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "limit", ec.unmarshalOInt2ᚖint)
+	if err != nil {
+		return nil, err
+	}
+	args["limit"] = arg1
+	arg2, err := graphql.ProcessArgField(ctx, rawArgs, "offset", ec.unmarshalOInt2ᚖint)
+	if err != nil {
+		return nil, err
+	}
+	args["offset"] = arg2
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_securityScore_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "accountId", ec.unmarshalNString2string)
+	if err != nil {
+		return nil, err
+	}
+	args["accountId"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_team_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "slug", ec.unmarshalNString2string)
+	if err != nil {
+		return nil, err
+	}
+	args["slug"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Scan_findings_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "onlyFailures", ec.unmarshalOBoolean2ᚖbool)
+	if err != nil {
+		return nil, err
+	}
+	args["onlyFailures"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "service", ec.unmarshalOString2ᚖstring)
+	if err != nil {
+		return nil, err
+	}
+	args["service"] = arg1
+	arg2, err := graphql.ProcessArgField(ctx, rawArgs, "severity", ec.unmarshalOString2ᚖstring)
+	if err != nil {
+		return nil, err
+	}
+	args["severity"] = arg2
+	arg3, err := graphql.ProcessArgField(ctx, rawArgs, "status", ec.unmarshalOString2ᚖstring)
+	if err != nil {
+		return nil, err
+	}
+	args["status"] = arg3
+	return args, nil
+}
+
+func (ec *executionContext) field___Directive_args_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "includeDeprecated", ec.unmarshalOBoolean2ᚖbool)
+	if err != nil {
+		return nil, err
+	}
+	args["includeDeprecated"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field___Field_args_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "includeDeprecated", ec.unmarshalOBoolean2ᚖbool)
+	if err != nil {
+		return nil, err
+	}
+	args["includeDeprecated"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field___Type_enumValues_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "includeDeprecated", ec.unmarshalOBoolean2bool)
+	if err != nil {
+		return nil, err
+	}
+	args["includeDeprecated"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field___Type_fields_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "includeDeprecated", ec.unmarshalOBoolean2bool)
+	if err != nil {
+		return nil, err
+	}
+	args["includeDeprecated"] = arg0
+	return args, nil
+}
+
+// endregion ***************************** args.gotpl *****************************
 
 // region    ************************** directives.gotpl **************************
 
@@ -1078,6 +1642,8 @@ func (ec *executionContext) fieldContext_AWSAccount_scans(_ context.Context, fie
 				return ec.fieldContext_Scan_findings(ctx, field)
 			case "summary":
 				return ec.fieldContext_Scan_summary(ctx, field)
+			case "errors":
+				return ec.fieldContext_Scan_errors(ctx, field)
 			case "startedAt":
 				return ec.fieldContext_Scan_startedAt(ctx, field)
 			case "completedAt":
@@ -1381,6 +1947,35 @@ func (ec *executionContext) fieldContext_Finding_resourceId(_ context.Context, f
 	return fc, nil
 }
 
+func (ec *executionContext) _Finding_arn(ctx context.Context, field graphql.CollectedField, obj *model.Finding) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Finding_arn,
+		func(ctx context.Context) (any, error) {
+			return obj.Arn, nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Finding_arn(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Finding",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Finding_checkId(ctx context.Context, field graphql.CollectedField, obj *model.Finding) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -1410,6 +2005,35 @@ func (ec *executionContext) fieldContext_Finding_checkId(_ context.Context, fiel
 	return fc, nil
 }
 
+func (ec *executionContext) _Finding_status(ctx context.Context, field graphql.CollectedField, obj *model.Finding) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Finding_status,
+		func(ctx context.Context) (any, error) {
+			return obj.Status, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Finding_status(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Finding",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Finding_severity(ctx context.Context, field graphql.CollectedField, obj *model.Finding) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -1439,6 +2063,35 @@ func (ec *executionContext) fieldContext_Finding_severity(_ context.Context, fie
 	return fc, nil
 }
 
+func (ec *executionContext) _Finding_confidence(ctx context.Context, field graphql.CollectedField, obj *model.Finding) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Finding_confidence,
+		func(ctx context.Context) (any, error) {
+			return obj.Confidence, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Finding_confidence(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Finding",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Finding_title(ctx context.Context, field graphql.CollectedField, obj *model.Finding) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -1526,6 +2179,70 @@ func (ec *executionContext) fieldContext_Finding_compliance(_ context.Context, f
 	return fc, nil
 }
 
+func (ec *executionContext) _Finding_resourceCreatedAt(ctx context.Context, field graphql.CollectedField, obj *model.Finding) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Finding_resourceCreatedAt,
+		func(ctx context.Context) (any, error) {
+			return obj.ResourceCreatedAt, nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Finding_resourceCreatedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Finding",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Finding_tags(ctx context.Context, field graphql.CollectedField, obj *model.Finding) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Finding_tags,
+		func(ctx context.Context) (any, error) {
+			return obj.Tags, nil
+		},
+		nil,
+		ec.marshalOTag2ᚕcloudcopᚋapiᚋgraphᚋmodelᚐTagᚄ,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Finding_tags(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Finding",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "key":
+				return ec.fieldContext_Tag_key(ctx, field)
+			case "value":
+				return ec.fieldContext_Tag_value(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Tag", field.Name)
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _FindingGroupSummary_groupId(ctx context.Context, field graphql.CollectedField, obj *model.FindingGroupSummary) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -1787,31 +2504,147 @@ func (ec *executionContext) fieldContext_FindingGroupSummary_remedy(_ context.Co
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_verifyAwsAccount(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _FindingSuppression_findingId(ctx context.Context, field graphql.CollectedField, obj *database.FindingSuppression) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Mutation_verifyAwsAccount,
+		ec.fieldContext_FindingSuppression_findingId,
 		func(ctx context.Context) (any, error) {
-			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Mutation().VerifyAWSAccount(ctx, fc.Args["accountId"].(string), fc.Args["externalId"].(string))
+			return obj.FindingID, nil
 		},
 		nil,
-		ec.marshalNAWSAccount2ᚖcloudcopᚋapiᚋgraphᚋmodelᚐAWSAccount,
+		ec.marshalNID2string,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Mutation_verifyAwsAccount(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_FindingSuppression_findingId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "FindingSuppression",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _FindingSuppression_reason(ctx context.Context, field graphql.CollectedField, obj *database.FindingSuppression) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_FindingSuppression_reason,
+		func(ctx context.Context) (any, error) {
+			return obj.Reason, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_FindingSuppression_reason(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "FindingSuppression",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _FindingSuppression_expiresAt(ctx context.Context, field graphql.CollectedField, obj *database.FindingSuppression) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_FindingSuppression_expiresAt,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.FindingSuppression().ExpiresAt(ctx, obj)
+		},
+		nil,
+		ec.marshalOTime2ᚖtimeᚐTime,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_FindingSuppression_expiresAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "FindingSuppression",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _FindingSuppression_createdAt(ctx context.Context, field graphql.CollectedField, obj *database.FindingSuppression) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_FindingSuppression_createdAt,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.FindingSuppression().CreatedAt(ctx, obj)
+		},
+		nil,
+		ec.marshalOTime2ᚖtimeᚐTime,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_FindingSuppression_createdAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "FindingSuppression",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_verifyAwsAccount(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_verifyAwsAccount,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().VerifyAWSAccount(ctx, fc.Args["accountId"].(string), fc.Args["externalId"].(string))
+		},
+		nil,
+		ec.marshalNAWSAccount2ᚖcloudcopᚋapiᚋgraphᚋmodelᚐAWSAccount,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_verifyAwsAccount(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
 			case "id":
 				return ec.fieldContext_AWSAccount_id(ctx, field)
 			case "accountId":
@@ -1940,6 +2773,8 @@ func (ec *executionContext) fieldContext_Mutation_startScan(ctx context.Context,
 				return ec.fieldContext_Scan_findings(ctx, field)
 			case "summary":
 				return ec.fieldContext_Scan_summary(ctx, field)
+			case "errors":
+				return ec.fieldContext_Scan_errors(ctx, field)
 			case "startedAt":
 				return ec.fieldContext_Scan_startedAt(ctx, field)
 			case "completedAt":
@@ -1964,82 +2799,94 @@ func (ec *executionContext) fieldContext_Mutation_startScan(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_me(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_summarizeScan(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Query_me,
+		ec.fieldContext_Mutation_summarizeScan,
 		func(ctx context.Context) (any, error) {
-			return ec.resolvers.Query().Me(ctx)
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().SummarizeScan(ctx, fc.Args["scanId"].(string))
 		},
 		nil,
-		ec.marshalNUser2ᚖcloudcopᚋapiᚋinternalᚋdatabaseᚐUser,
+		ec.marshalNScanSummary2ᚖcloudcopᚋapiᚋgraphᚋmodelᚐScanSummary,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Query_me(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_summarizeScan(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Mutation",
 		Field:      field,
 		IsMethod:   true,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "id":
-				return ec.fieldContext_User_id(ctx, field)
-			case "email":
-				return ec.fieldContext_User_email(ctx, field)
-			case "name":
-				return ec.fieldContext_User_name(ctx, field)
-			case "teams":
-				return ec.fieldContext_User_teams(ctx, field)
+			case "riskLevel":
+				return ec.fieldContext_ScanSummary_riskLevel(ctx, field)
+			case "riskScore":
+				return ec.fieldContext_ScanSummary_riskScore(ctx, field)
+			case "summaryText":
+				return ec.fieldContext_ScanSummary_summaryText(ctx, field)
+			case "groups":
+				return ec.fieldContext_ScanSummary_groups(ctx, field)
+			case "actions":
+				return ec.fieldContext_ScanSummary_actions(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type User", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type ScanSummary", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_summarizeScan_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_team(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_suppressFinding(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Query_team,
+		ec.fieldContext_Mutation_suppressFinding,
 		func(ctx context.Context) (any, error) {
 			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Query().Team(ctx, fc.Args["slug"].(string))
+			return ec.resolvers.Mutation().SuppressFinding(ctx, fc.Args["findingId"].(string), fc.Args["reason"].(string), fc.Args["expiresAt"].(*time.Time))
 		},
 		nil,
-		ec.marshalOTeam2ᚖcloudcopᚋapiᚋinternalᚋdatabaseᚐTeam,
+		ec.marshalNFindingSuppression2ᚖcloudcopᚋapiᚋinternalᚋdatabaseᚐFindingSuppression,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Query_team(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_suppressFinding(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Mutation",
 		Field:      field,
 		IsMethod:   true,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "id":
-				return ec.fieldContext_Team_id(ctx, field)
-			case "name":
-				return ec.fieldContext_Team_name(ctx, field)
-			case "slug":
-				return ec.fieldContext_Team_slug(ctx, field)
-			case "members":
-				return ec.fieldContext_Team_members(ctx, field)
-			case "awsAccounts":
-				return ec.fieldContext_Team_awsAccounts(ctx, field)
+			case "findingId":
+				return ec.fieldContext_FindingSuppression_findingId(ctx, field)
+			case "reason":
+				return ec.fieldContext_FindingSuppression_reason(ctx, field)
+			case "expiresAt":
+				return ec.fieldContext_FindingSuppression_expiresAt(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_FindingSuppression_createdAt(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Team", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type FindingSuppression", field.Name)
 		},
 	}
 	defer func() {
@@ -2049,107 +2896,97 @@ func (ec *executionContext) fieldContext_Query_team(ctx context.Context, field g
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_team_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_suppressFinding_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return fc, err
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_myAccounts(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_unsuppressFinding(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Query_myAccounts,
+		ec.fieldContext_Mutation_unsuppressFinding,
 		func(ctx context.Context) (any, error) {
-			return ec.resolvers.Query().MyAccounts(ctx)
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().UnsuppressFinding(ctx, fc.Args["findingId"].(string))
 		},
 		nil,
-		ec.marshalNAWSAccount2ᚕcloudcopᚋapiᚋgraphᚋmodelᚐAWSAccountᚄ,
+		ec.marshalNBoolean2bool,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Query_myAccounts(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_unsuppressFinding(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Mutation",
 		Field:      field,
 		IsMethod:   true,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_AWSAccount_id(ctx, field)
-			case "accountId":
-				return ec.fieldContext_AWSAccount_accountId(ctx, field)
-			case "externalId":
-				return ec.fieldContext_AWSAccount_externalId(ctx, field)
-			case "verified":
-				return ec.fieldContext_AWSAccount_verified(ctx, field)
-			case "roleArn":
-				return ec.fieldContext_AWSAccount_roleArn(ctx, field)
-			case "lastVerifiedAt":
-				return ec.fieldContext_AWSAccount_lastVerifiedAt(ctx, field)
-			case "scans":
-				return ec.fieldContext_AWSAccount_scans(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type AWSAccount", field.Name)
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_unsuppressFinding_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_createScanSchedule(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Query___type,
+		ec.fieldContext_Mutation_createScanSchedule,
 		func(ctx context.Context) (any, error) {
 			fc := graphql.GetFieldContext(ctx)
-			return ec.introspectType(fc.Args["name"].(string))
+			return ec.resolvers.Mutation().CreateScanSchedule(ctx, fc.Args["accountId"].(string), fc.Args["cronExpr"].(string), fc.Args["services"].([]string), fc.Args["regions"].([]string))
 		},
 		nil,
-		ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType,
+		ec.marshalNScanSchedule2ᚖcloudcopᚋapiᚋinternalᚋdatabaseᚐScanSchedule,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Query___type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_createScanSchedule(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Mutation",
 		Field:      field,
 		IsMethod:   true,
-		IsResolver: false,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "kind":
-				return ec.fieldContext___Type_kind(ctx, field)
-			case "name":
-				return ec.fieldContext___Type_name(ctx, field)
-			case "description":
-				return ec.fieldContext___Type_description(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
-			case "fields":
-				return ec.fieldContext___Type_fields(ctx, field)
-			case "interfaces":
-				return ec.fieldContext___Type_interfaces(ctx, field)
-			case "possibleTypes":
-				return ec.fieldContext___Type_possibleTypes(ctx, field)
-			case "enumValues":
-				return ec.fieldContext___Type_enumValues(ctx, field)
-			case "inputFields":
-				return ec.fieldContext___Type_inputFields(ctx, field)
-			case "ofType":
-				return ec.fieldContext___Type_ofType(ctx, field)
-			case "isOneOf":
-				return ec.fieldContext___Type_isOneOf(ctx, field)
+			case "id":
+				return ec.fieldContext_ScanSchedule_id(ctx, field)
+			case "cronExpr":
+				return ec.fieldContext_ScanSchedule_cronExpr(ctx, field)
+			case "services":
+				return ec.fieldContext_ScanSchedule_services(ctx, field)
+			case "regions":
+				return ec.fieldContext_ScanSchedule_regions(ctx, field)
+			case "enabled":
+				return ec.fieldContext_ScanSchedule_enabled(ctx, field)
+			case "nextRunAt":
+				return ec.fieldContext_ScanSchedule_nextRunAt(ctx, field)
+			case "lastRunAt":
+				return ec.fieldContext_ScanSchedule_lastRunAt(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_ScanSchedule_createdAt(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type ScanSchedule", field.Name)
 		},
 	}
 	defer func() {
@@ -2159,93 +2996,1282 @@ func (ec *executionContext) fieldContext_Query___type(ctx context.Context, field
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query___type_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_createScanSchedule_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return fc, err
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_deleteScanSchedule(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Query___schema,
+		ec.fieldContext_Mutation_deleteScanSchedule,
 		func(ctx context.Context) (any, error) {
-			return ec.introspectSchema()
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().DeleteScanSchedule(ctx, fc.Args["accountId"].(string), fc.Args["id"].(string))
 		},
 		nil,
-		ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema,
+		ec.marshalNBoolean2bool,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Query___schema(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_deleteScanSchedule(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Mutation",
 		Field:      field,
 		IsMethod:   true,
-		IsResolver: false,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "description":
-				return ec.fieldContext___Schema_description(ctx, field)
-			case "types":
-				return ec.fieldContext___Schema_types(ctx, field)
-			case "queryType":
-				return ec.fieldContext___Schema_queryType(ctx, field)
-			case "mutationType":
-				return ec.fieldContext___Schema_mutationType(ctx, field)
-			case "subscriptionType":
-				return ec.fieldContext___Schema_subscriptionType(ctx, field)
-			case "directives":
-				return ec.fieldContext___Schema_directives(ctx, field)
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_deleteScanSchedule_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_me(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_me,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Query().Me(ctx)
+		},
+		nil,
+		ec.marshalNUser2ᚖcloudcopᚋapiᚋinternalᚋdatabaseᚐUser,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_me(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_User_id(ctx, field)
+			case "email":
+				return ec.fieldContext_User_email(ctx, field)
+			case "name":
+				return ec.fieldContext_User_name(ctx, field)
+			case "teams":
+				return ec.fieldContext_User_teams(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type __Schema", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type User", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_team(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_team,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().Team(ctx, fc.Args["slug"].(string))
+		},
+		nil,
+		ec.marshalOTeam2ᚖcloudcopᚋapiᚋinternalᚋdatabaseᚐTeam,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_team(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Team_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Team_name(ctx, field)
+			case "slug":
+				return ec.fieldContext_Team_slug(ctx, field)
+			case "members":
+				return ec.fieldContext_Team_members(ctx, field)
+			case "awsAccounts":
+				return ec.fieldContext_Team_awsAccounts(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Team", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_team_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_myAccounts(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_myAccounts,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Query().MyAccounts(ctx)
+		},
+		nil,
+		ec.marshalNAWSAccount2ᚕcloudcopᚋapiᚋgraphᚋmodelᚐAWSAccountᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_myAccounts(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_AWSAccount_id(ctx, field)
+			case "accountId":
+				return ec.fieldContext_AWSAccount_accountId(ctx, field)
+			case "externalId":
+				return ec.fieldContext_AWSAccount_externalId(ctx, field)
+			case "verified":
+				return ec.fieldContext_AWSAccount_verified(ctx, field)
+			case "roleArn":
+				return ec.fieldContext_AWSAccount_roleArn(ctx, field)
+			case "lastVerifiedAt":
+				return ec.fieldContext_AWSAccount_lastVerifiedAt(ctx, field)
+			case "scans":
+				return ec.fieldContext_AWSAccount_scans(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type AWSAccount", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_securityScore(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_securityScore,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().SecurityScore(ctx, fc.Args["accountId"].(string))
+		},
+		nil,
+		ec.marshalOSecurityScore2ᚖcloudcopᚋapiᚋgraphᚋmodelᚐSecurityScore,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_securityScore(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "scanId":
+				return ec.fieldContext_SecurityScore_scanId(ctx, field)
+			case "riskScore":
+				return ec.fieldContext_SecurityScore_riskScore(ctx, field)
+			case "riskLevel":
+				return ec.fieldContext_SecurityScore_riskLevel(ctx, field)
+			case "severityBreakdown":
+				return ec.fieldContext_SecurityScore_severityBreakdown(ctx, field)
+			case "trendDelta":
+				return ec.fieldContext_SecurityScore_trendDelta(ctx, field)
+			case "scannedAt":
+				return ec.fieldContext_SecurityScore_scannedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type SecurityScore", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_securityScore_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_scans(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_scans,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().Scans(ctx, fc.Args["accountId"].(string), fc.Args["limit"].(*int), fc.Args["offset"].(*int))
+		},
+		nil,
+		ec.marshalNScan2ᚕcloudcopᚋapiᚋinternalᚋdatabaseᚐScanᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_scans(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Scan_id(ctx, field)
+			case "status":
+				return ec.fieldContext_Scan_status(ctx, field)
+			case "services":
+				return ec.fieldContext_Scan_services(ctx, field)
+			case "regions":
+				return ec.fieldContext_Scan_regions(ctx, field)
+			case "overallScore":
+				return ec.fieldContext_Scan_overallScore(ctx, field)
+			case "findings":
+				return ec.fieldContext_Scan_findings(ctx, field)
+			case "summary":
+				return ec.fieldContext_Scan_summary(ctx, field)
+			case "errors":
+				return ec.fieldContext_Scan_errors(ctx, field)
+			case "startedAt":
+				return ec.fieldContext_Scan_startedAt(ctx, field)
+			case "completedAt":
+				return ec.fieldContext_Scan_completedAt(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Scan_createdAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Scan", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_scans_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_scan(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_scan,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().Scan(ctx, fc.Args["id"].(string))
+		},
+		nil,
+		ec.marshalOScan2ᚖcloudcopᚋapiᚋinternalᚋdatabaseᚐScan,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_scan(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Scan_id(ctx, field)
+			case "status":
+				return ec.fieldContext_Scan_status(ctx, field)
+			case "services":
+				return ec.fieldContext_Scan_services(ctx, field)
+			case "regions":
+				return ec.fieldContext_Scan_regions(ctx, field)
+			case "overallScore":
+				return ec.fieldContext_Scan_overallScore(ctx, field)
+			case "findings":
+				return ec.fieldContext_Scan_findings(ctx, field)
+			case "summary":
+				return ec.fieldContext_Scan_summary(ctx, field)
+			case "errors":
+				return ec.fieldContext_Scan_errors(ctx, field)
+			case "startedAt":
+				return ec.fieldContext_Scan_startedAt(ctx, field)
+			case "completedAt":
+				return ec.fieldContext_Scan_completedAt(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Scan_createdAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Scan", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_scan_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_scanSchedules(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_scanSchedules,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().ScanSchedules(ctx, fc.Args["accountId"].(string))
+		},
+		nil,
+		ec.marshalNScanSchedule2ᚕcloudcopᚋapiᚋinternalᚋdatabaseᚐScanScheduleᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_scanSchedules(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_ScanSchedule_id(ctx, field)
+			case "cronExpr":
+				return ec.fieldContext_ScanSchedule_cronExpr(ctx, field)
+			case "services":
+				return ec.fieldContext_ScanSchedule_services(ctx, field)
+			case "regions":
+				return ec.fieldContext_ScanSchedule_regions(ctx, field)
+			case "enabled":
+				return ec.fieldContext_ScanSchedule_enabled(ctx, field)
+			case "nextRunAt":
+				return ec.fieldContext_ScanSchedule_nextRunAt(ctx, field)
+			case "lastRunAt":
+				return ec.fieldContext_ScanSchedule_lastRunAt(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_ScanSchedule_createdAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ScanSchedule", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_scanSchedules_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query___type,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.introspectType(fc.Args["name"].(string))
+		},
+		nil,
+		ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query___type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "kind":
+				return ec.fieldContext___Type_kind(ctx, field)
+			case "name":
+				return ec.fieldContext___Type_name(ctx, field)
+			case "description":
+				return ec.fieldContext___Type_description(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
+			case "fields":
+				return ec.fieldContext___Type_fields(ctx, field)
+			case "interfaces":
+				return ec.fieldContext___Type_interfaces(ctx, field)
+			case "possibleTypes":
+				return ec.fieldContext___Type_possibleTypes(ctx, field)
+			case "enumValues":
+				return ec.fieldContext___Type_enumValues(ctx, field)
+			case "inputFields":
+				return ec.fieldContext___Type_inputFields(ctx, field)
+			case "ofType":
+				return ec.fieldContext___Type_ofType(ctx, field)
+			case "isOneOf":
+				return ec.fieldContext___Type_isOneOf(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query___type_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query___schema,
+		func(ctx context.Context) (any, error) {
+			return ec.introspectSchema()
+		},
+		nil,
+		ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query___schema(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "description":
+				return ec.fieldContext___Schema_description(ctx, field)
+			case "types":
+				return ec.fieldContext___Schema_types(ctx, field)
+			case "queryType":
+				return ec.fieldContext___Schema_queryType(ctx, field)
+			case "mutationType":
+				return ec.fieldContext___Schema_mutationType(ctx, field)
+			case "subscriptionType":
+				return ec.fieldContext___Schema_subscriptionType(ctx, field)
+			case "directives":
+				return ec.fieldContext___Schema_directives(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type __Schema", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Scan_id(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Scan_id,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Scan().ID(ctx, obj)
+		},
+		nil,
+		ec.marshalNID2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Scan_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Scan",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Scan_status(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Scan_status,
+		func(ctx context.Context) (any, error) {
+			return obj.Status, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Scan_status(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Scan",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Scan_services(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Scan_services,
+		func(ctx context.Context) (any, error) {
+			return obj.Services, nil
+		},
+		nil,
+		ec.marshalOString2ᚕstringᚄ,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Scan_services(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Scan",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Scan_regions(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Scan_regions,
+		func(ctx context.Context) (any, error) {
+			return obj.Regions, nil
+		},
+		nil,
+		ec.marshalOString2ᚕstringᚄ,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Scan_regions(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Scan",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Scan_overallScore(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Scan_overallScore,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Scan().OverallScore(ctx, obj)
+		},
+		nil,
+		ec.marshalOInt2ᚖint,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Scan_overallScore(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Scan",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Scan_findings(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Scan_findings,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Scan().Findings(ctx, obj, fc.Args["onlyFailures"].(*bool), fc.Args["service"].(*string), fc.Args["severity"].(*string), fc.Args["status"].(*string))
+		},
+		nil,
+		ec.marshalOFinding2ᚕcloudcopᚋapiᚋgraphᚋmodelᚐFindingᚄ,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Scan_findings(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Scan",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Finding_id(ctx, field)
+			case "service":
+				return ec.fieldContext_Finding_service(ctx, field)
+			case "region":
+				return ec.fieldContext_Finding_region(ctx, field)
+			case "resourceId":
+				return ec.fieldContext_Finding_resourceId(ctx, field)
+			case "arn":
+				return ec.fieldContext_Finding_arn(ctx, field)
+			case "checkId":
+				return ec.fieldContext_Finding_checkId(ctx, field)
+			case "status":
+				return ec.fieldContext_Finding_status(ctx, field)
+			case "severity":
+				return ec.fieldContext_Finding_severity(ctx, field)
+			case "confidence":
+				return ec.fieldContext_Finding_confidence(ctx, field)
+			case "title":
+				return ec.fieldContext_Finding_title(ctx, field)
+			case "description":
+				return ec.fieldContext_Finding_description(ctx, field)
+			case "compliance":
+				return ec.fieldContext_Finding_compliance(ctx, field)
+			case "resourceCreatedAt":
+				return ec.fieldContext_Finding_resourceCreatedAt(ctx, field)
+			case "tags":
+				return ec.fieldContext_Finding_tags(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Finding", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Scan_findings_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Scan_summary(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Scan_summary,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Scan().Summary(ctx, obj)
+		},
+		nil,
+		ec.marshalOScanSummary2ᚖcloudcopᚋapiᚋgraphᚋmodelᚐScanSummary,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Scan_summary(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Scan",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "riskLevel":
+				return ec.fieldContext_ScanSummary_riskLevel(ctx, field)
+			case "riskScore":
+				return ec.fieldContext_ScanSummary_riskScore(ctx, field)
+			case "summaryText":
+				return ec.fieldContext_ScanSummary_summaryText(ctx, field)
+			case "groups":
+				return ec.fieldContext_ScanSummary_groups(ctx, field)
+			case "actions":
+				return ec.fieldContext_ScanSummary_actions(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ScanSummary", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Scan_errors(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Scan_errors,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Scan().Errors(ctx, obj)
+		},
+		nil,
+		ec.marshalOScanError2ᚕcloudcopᚋapiᚋgraphᚋmodelᚐScanErrorᚄ,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Scan_errors(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Scan",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "service":
+				return ec.fieldContext_ScanError_service(ctx, field)
+			case "region":
+				return ec.fieldContext_ScanError_region(ctx, field)
+			case "type":
+				return ec.fieldContext_ScanError_type(ctx, field)
+			case "message":
+				return ec.fieldContext_ScanError_message(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ScanError", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Scan_startedAt(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Scan_startedAt,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Scan().StartedAt(ctx, obj)
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Scan_startedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Scan",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Scan_completedAt(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Scan_completedAt,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Scan().CompletedAt(ctx, obj)
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Scan_completedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Scan",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Scan_createdAt(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Scan_createdAt,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Scan().CreatedAt(ctx, obj)
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Scan_createdAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Scan",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ScanError_service(ctx context.Context, field graphql.CollectedField, obj *model.ScanError) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_ScanError_service,
+		func(ctx context.Context) (any, error) {
+			return obj.Service, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_ScanError_service(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ScanError",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ScanError_region(ctx context.Context, field graphql.CollectedField, obj *model.ScanError) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_ScanError_region,
+		func(ctx context.Context) (any, error) {
+			return obj.Region, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_ScanError_region(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ScanError",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ScanError_type(ctx context.Context, field graphql.CollectedField, obj *model.ScanError) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_ScanError_type,
+		func(ctx context.Context) (any, error) {
+			return obj.Type, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_ScanError_type(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ScanError",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ScanError_message(ctx context.Context, field graphql.CollectedField, obj *model.ScanError) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_ScanError_message,
+		func(ctx context.Context) (any, error) {
+			return obj.Message, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_ScanError_message(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ScanError",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ScanSchedule_id(ctx context.Context, field graphql.CollectedField, obj *database.ScanSchedule) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_ScanSchedule_id,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.ScanSchedule().ID(ctx, obj)
+		},
+		nil,
+		ec.marshalNID2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_ScanSchedule_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ScanSchedule",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ScanSchedule_cronExpr(ctx context.Context, field graphql.CollectedField, obj *database.ScanSchedule) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_ScanSchedule_cronExpr,
+		func(ctx context.Context) (any, error) {
+			return obj.CronExpr, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_ScanSchedule_cronExpr(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ScanSchedule",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ScanSchedule_services(ctx context.Context, field graphql.CollectedField, obj *database.ScanSchedule) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_ScanSchedule_services,
+		func(ctx context.Context) (any, error) {
+			return obj.Services, nil
+		},
+		nil,
+		ec.marshalOString2ᚕstringᚄ,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_ScanSchedule_services(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ScanSchedule",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ScanSchedule_regions(ctx context.Context, field graphql.CollectedField, obj *database.ScanSchedule) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_ScanSchedule_regions,
+		func(ctx context.Context) (any, error) {
+			return obj.Regions, nil
+		},
+		nil,
+		ec.marshalOString2ᚕstringᚄ,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_ScanSchedule_regions(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ScanSchedule",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ScanSchedule_enabled(ctx context.Context, field graphql.CollectedField, obj *database.ScanSchedule) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_ScanSchedule_enabled,
+		func(ctx context.Context) (any, error) {
+			return obj.Enabled, nil
+		},
+		nil,
+		ec.marshalNBoolean2bool,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_ScanSchedule_enabled(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ScanSchedule",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ScanSchedule_nextRunAt(ctx context.Context, field graphql.CollectedField, obj *database.ScanSchedule) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_ScanSchedule_nextRunAt,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.ScanSchedule().NextRunAt(ctx, obj)
+		},
+		nil,
+		ec.marshalOTime2ᚖtimeᚐTime,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_ScanSchedule_nextRunAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ScanSchedule",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Scan_id(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+func (ec *executionContext) _ScanSchedule_lastRunAt(ctx context.Context, field graphql.CollectedField, obj *database.ScanSchedule) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Scan_id,
+		ec.fieldContext_ScanSchedule_lastRunAt,
 		func(ctx context.Context) (any, error) {
-			return ec.resolvers.Scan().ID(ctx, obj)
+			return ec.resolvers.ScanSchedule().LastRunAt(ctx, obj)
 		},
 		nil,
-		ec.marshalNID2string,
+		ec.marshalOTime2ᚖtimeᚐTime,
 		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_ScanSchedule_lastRunAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ScanSchedule",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ScanSchedule_createdAt(ctx context.Context, field graphql.CollectedField, obj *database.ScanSchedule) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_ScanSchedule_createdAt,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.ScanSchedule().CreatedAt(ctx, obj)
+		},
+		nil,
+		ec.marshalOTime2ᚖtimeᚐTime,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Scan_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ScanSchedule_createdAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Scan",
+		Object:     "ScanSchedule",
 		Field:      field,
 		IsMethod:   true,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ID does not have child fields")
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Scan_status(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+func (ec *executionContext) _ScanSummary_riskLevel(ctx context.Context, field graphql.CollectedField, obj *model.ScanSummary) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Scan_status,
+		ec.fieldContext_ScanSummary_riskLevel,
 		func(ctx context.Context) (any, error) {
-			return obj.Status, nil
+			return obj.RiskLevel, nil
 		},
 		nil,
 		ec.marshalNString2string,
@@ -2254,9 +4280,9 @@ func (ec *executionContext) _Scan_status(ctx context.Context, field graphql.Coll
 	)
 }
 
-func (ec *executionContext) fieldContext_Scan_status(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ScanSummary_riskLevel(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Scan",
+		Object:     "ScanSummary",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -2267,54 +4293,54 @@ func (ec *executionContext) fieldContext_Scan_status(_ context.Context, field gr
 	return fc, nil
 }
 
-func (ec *executionContext) _Scan_services(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+func (ec *executionContext) _ScanSummary_riskScore(ctx context.Context, field graphql.CollectedField, obj *model.ScanSummary) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Scan_services,
+		ec.fieldContext_ScanSummary_riskScore,
 		func(ctx context.Context) (any, error) {
-			return obj.Services, nil
+			return obj.RiskScore, nil
 		},
 		nil,
-		ec.marshalOString2ᚕstringᚄ,
+		ec.marshalNInt2int,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Scan_services(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ScanSummary_riskScore(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Scan",
+		Object:     "ScanSummary",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Scan_regions(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+func (ec *executionContext) _ScanSummary_summaryText(ctx context.Context, field graphql.CollectedField, obj *model.ScanSummary) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Scan_regions,
+		ec.fieldContext_ScanSummary_summaryText,
 		func(ctx context.Context) (any, error) {
-			return obj.Regions, nil
+			return obj.SummaryText, nil
 		},
 		nil,
-		ec.marshalOString2ᚕstringᚄ,
+		ec.marshalNString2string,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Scan_regions(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ScanSummary_summaryText(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Scan",
+		Object:     "ScanSummary",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -2325,162 +4351,261 @@ func (ec *executionContext) fieldContext_Scan_regions(_ context.Context, field g
 	return fc, nil
 }
 
-func (ec *executionContext) _Scan_overallScore(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+func (ec *executionContext) _ScanSummary_groups(ctx context.Context, field graphql.CollectedField, obj *model.ScanSummary) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Scan_overallScore,
+		ec.fieldContext_ScanSummary_groups,
 		func(ctx context.Context) (any, error) {
-			return ec.resolvers.Scan().OverallScore(ctx, obj)
+			return obj.Groups, nil
 		},
 		nil,
-		ec.marshalOInt2ᚖint,
+		ec.marshalNFindingGroupSummary2ᚕcloudcopᚋapiᚋgraphᚋmodelᚐFindingGroupSummaryᚄ,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Scan_overallScore(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ScanSummary_groups(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Scan",
+		Object:     "ScanSummary",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			switch field.Name {
+			case "groupId":
+				return ec.fieldContext_FindingGroupSummary_groupId(ctx, field)
+			case "title":
+				return ec.fieldContext_FindingGroupSummary_title(ctx, field)
+			case "service":
+				return ec.fieldContext_FindingGroupSummary_service(ctx, field)
+			case "checkId":
+				return ec.fieldContext_FindingGroupSummary_checkId(ctx, field)
+			case "severity":
+				return ec.fieldContext_FindingGroupSummary_severity(ctx, field)
+			case "findingCount":
+				return ec.fieldContext_FindingGroupSummary_findingCount(ctx, field)
+			case "resourceIds":
+				return ec.fieldContext_FindingGroupSummary_resourceIds(ctx, field)
+			case "summary":
+				return ec.fieldContext_FindingGroupSummary_summary(ctx, field)
+			case "remedy":
+				return ec.fieldContext_FindingGroupSummary_remedy(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type FindingGroupSummary", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Scan_findings(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+func (ec *executionContext) _ScanSummary_actions(ctx context.Context, field graphql.CollectedField, obj *model.ScanSummary) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Scan_findings,
+		ec.fieldContext_ScanSummary_actions,
 		func(ctx context.Context) (any, error) {
-			return ec.resolvers.Scan().Findings(ctx, obj)
+			return obj.Actions, nil
 		},
 		nil,
-		ec.marshalOFinding2ᚕcloudcopᚋapiᚋgraphᚋmodelᚐFindingᚄ,
+		ec.marshalNActionItemSummary2ᚕcloudcopᚋapiᚋgraphᚋmodelᚐActionItemSummaryᚄ,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Scan_findings(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ScanSummary_actions(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Scan",
+		Object:     "ScanSummary",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "id":
-				return ec.fieldContext_Finding_id(ctx, field)
-			case "service":
-				return ec.fieldContext_Finding_service(ctx, field)
-			case "region":
-				return ec.fieldContext_Finding_region(ctx, field)
-			case "resourceId":
-				return ec.fieldContext_Finding_resourceId(ctx, field)
-			case "checkId":
-				return ec.fieldContext_Finding_checkId(ctx, field)
-			case "severity":
-				return ec.fieldContext_Finding_severity(ctx, field)
+			case "actionId":
+				return ec.fieldContext_ActionItemSummary_actionId(ctx, field)
 			case "title":
-				return ec.fieldContext_Finding_title(ctx, field)
+				return ec.fieldContext_ActionItemSummary_title(ctx, field)
 			case "description":
-				return ec.fieldContext_Finding_description(ctx, field)
-			case "compliance":
-				return ec.fieldContext_Finding_compliance(ctx, field)
+				return ec.fieldContext_ActionItemSummary_description(ctx, field)
+			case "severity":
+				return ec.fieldContext_ActionItemSummary_severity(ctx, field)
+			case "commands":
+				return ec.fieldContext_ActionItemSummary_commands(ctx, field)
+			case "groupId":
+				return ec.fieldContext_ActionItemSummary_groupId(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Finding", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type ActionItemSummary", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Scan_summary(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+func (ec *executionContext) _SecurityScore_scanId(ctx context.Context, field graphql.CollectedField, obj *model.SecurityScore) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Scan_summary,
+		ec.fieldContext_SecurityScore_scanId,
 		func(ctx context.Context) (any, error) {
-			return ec.resolvers.Scan().Summary(ctx, obj)
+			return obj.ScanID, nil
 		},
 		nil,
-		ec.marshalOScanSummary2ᚖcloudcopᚋapiᚋgraphᚋmodelᚐScanSummary,
+		ec.marshalNID2string,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Scan_summary(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_SecurityScore_scanId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Scan",
+		Object:     "SecurityScore",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "riskLevel":
-				return ec.fieldContext_ScanSummary_riskLevel(ctx, field)
-			case "riskScore":
-				return ec.fieldContext_ScanSummary_riskScore(ctx, field)
-			case "summaryText":
-				return ec.fieldContext_ScanSummary_summaryText(ctx, field)
-			case "groups":
-				return ec.fieldContext_ScanSummary_groups(ctx, field)
-			case "actions":
-				return ec.fieldContext_ScanSummary_actions(ctx, field)
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SecurityScore_riskScore(ctx context.Context, field graphql.CollectedField, obj *model.SecurityScore) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_SecurityScore_riskScore,
+		func(ctx context.Context) (any, error) {
+			return obj.RiskScore, nil
+		},
+		nil,
+		ec.marshalNInt2int,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_SecurityScore_riskScore(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SecurityScore",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SecurityScore_riskLevel(ctx context.Context, field graphql.CollectedField, obj *model.SecurityScore) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_SecurityScore_riskLevel,
+		func(ctx context.Context) (any, error) {
+			return obj.RiskLevel, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_SecurityScore_riskLevel(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SecurityScore",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SecurityScore_severityBreakdown(ctx context.Context, field graphql.CollectedField, obj *model.SecurityScore) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_SecurityScore_severityBreakdown,
+		func(ctx context.Context) (any, error) {
+			return obj.SeverityBreakdown, nil
+		},
+		nil,
+		ec.marshalNSeverityBreakdown2ᚖcloudcopᚋapiᚋgraphᚋmodelᚐSeverityBreakdown,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_SecurityScore_severityBreakdown(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SecurityScore",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "critical":
+				return ec.fieldContext_SeverityBreakdown_critical(ctx, field)
+			case "high":
+				return ec.fieldContext_SeverityBreakdown_high(ctx, field)
+			case "medium":
+				return ec.fieldContext_SeverityBreakdown_medium(ctx, field)
+			case "low":
+				return ec.fieldContext_SeverityBreakdown_low(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type ScanSummary", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type SeverityBreakdown", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Scan_startedAt(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+func (ec *executionContext) _SecurityScore_trendDelta(ctx context.Context, field graphql.CollectedField, obj *model.SecurityScore) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Scan_startedAt,
+		ec.fieldContext_SecurityScore_trendDelta,
 		func(ctx context.Context) (any, error) {
-			return ec.resolvers.Scan().StartedAt(ctx, obj)
+			return obj.TrendDelta, nil
 		},
 		nil,
-		ec.marshalOString2ᚖstring,
+		ec.marshalOInt2ᚖint,
 		true,
 		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Scan_startedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_SecurityScore_trendDelta(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Scan",
+		Object:     "SecurityScore",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Scan_completedAt(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+func (ec *executionContext) _SecurityScore_scannedAt(ctx context.Context, field graphql.CollectedField, obj *model.SecurityScore) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Scan_completedAt,
+		ec.fieldContext_SecurityScore_scannedAt,
 		func(ctx context.Context) (any, error) {
-			return ec.resolvers.Scan().CompletedAt(ctx, obj)
+			return obj.ScannedAt, nil
 		},
 		nil,
 		ec.marshalOString2ᚖstring,
@@ -2489,12 +4614,12 @@ func (ec *executionContext) _Scan_completedAt(ctx context.Context, field graphql
 	)
 }
 
-func (ec *executionContext) fieldContext_Scan_completedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_SecurityScore_scannedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Scan",
+		Object:     "SecurityScore",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			return nil, errors.New("field of type String does not have child fields")
 		},
@@ -2502,72 +4627,72 @@ func (ec *executionContext) fieldContext_Scan_completedAt(_ context.Context, fie
 	return fc, nil
 }
 
-func (ec *executionContext) _Scan_createdAt(ctx context.Context, field graphql.CollectedField, obj *database.Scan) (ret graphql.Marshaler) {
+func (ec *executionContext) _SeverityBreakdown_critical(ctx context.Context, field graphql.CollectedField, obj *model.SeverityBreakdown) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Scan_createdAt,
+		ec.fieldContext_SeverityBreakdown_critical,
 		func(ctx context.Context) (any, error) {
-			return ec.resolvers.Scan().CreatedAt(ctx, obj)
+			return obj.Critical, nil
 		},
 		nil,
-		ec.marshalNString2string,
+		ec.marshalNInt2int,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Scan_createdAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_SeverityBreakdown_critical(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Scan",
+		Object:     "SeverityBreakdown",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ScanSummary_riskLevel(ctx context.Context, field graphql.CollectedField, obj *model.ScanSummary) (ret graphql.Marshaler) {
+func (ec *executionContext) _SeverityBreakdown_high(ctx context.Context, field graphql.CollectedField, obj *model.SeverityBreakdown) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_ScanSummary_riskLevel,
+		ec.fieldContext_SeverityBreakdown_high,
 		func(ctx context.Context) (any, error) {
-			return obj.RiskLevel, nil
+			return obj.High, nil
 		},
 		nil,
-		ec.marshalNString2string,
+		ec.marshalNInt2int,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_ScanSummary_riskLevel(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_SeverityBreakdown_high(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ScanSummary",
+		Object:     "SeverityBreakdown",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ScanSummary_riskScore(ctx context.Context, field graphql.CollectedField, obj *model.ScanSummary) (ret graphql.Marshaler) {
+func (ec *executionContext) _SeverityBreakdown_medium(ctx context.Context, field graphql.CollectedField, obj *model.SeverityBreakdown) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_ScanSummary_riskScore,
+		ec.fieldContext_SeverityBreakdown_medium,
 		func(ctx context.Context) (any, error) {
-			return obj.RiskScore, nil
+			return obj.Medium, nil
 		},
 		nil,
 		ec.marshalNInt2int,
@@ -2576,9 +4701,9 @@ func (ec *executionContext) _ScanSummary_riskScore(ctx context.Context, field gr
 	)
 }
 
-func (ec *executionContext) fieldContext_ScanSummary_riskScore(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_SeverityBreakdown_medium(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ScanSummary",
+		Object:     "SeverityBreakdown",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -2589,122 +4714,88 @@ func (ec *executionContext) fieldContext_ScanSummary_riskScore(_ context.Context
 	return fc, nil
 }
 
-func (ec *executionContext) _ScanSummary_summaryText(ctx context.Context, field graphql.CollectedField, obj *model.ScanSummary) (ret graphql.Marshaler) {
+func (ec *executionContext) _SeverityBreakdown_low(ctx context.Context, field graphql.CollectedField, obj *model.SeverityBreakdown) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_ScanSummary_summaryText,
+		ec.fieldContext_SeverityBreakdown_low,
 		func(ctx context.Context) (any, error) {
-			return obj.SummaryText, nil
+			return obj.Low, nil
 		},
 		nil,
-		ec.marshalNString2string,
+		ec.marshalNInt2int,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_ScanSummary_summaryText(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_SeverityBreakdown_low(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ScanSummary",
+		Object:     "SeverityBreakdown",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ScanSummary_groups(ctx context.Context, field graphql.CollectedField, obj *model.ScanSummary) (ret graphql.Marshaler) {
+func (ec *executionContext) _Tag_key(ctx context.Context, field graphql.CollectedField, obj *model.Tag) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_ScanSummary_groups,
+		ec.fieldContext_Tag_key,
 		func(ctx context.Context) (any, error) {
-			return obj.Groups, nil
+			return obj.Key, nil
 		},
 		nil,
-		ec.marshalNFindingGroupSummary2ᚕcloudcopᚋapiᚋgraphᚋmodelᚐFindingGroupSummaryᚄ,
+		ec.marshalNString2string,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_ScanSummary_groups(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Tag_key(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ScanSummary",
+		Object:     "Tag",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "groupId":
-				return ec.fieldContext_FindingGroupSummary_groupId(ctx, field)
-			case "title":
-				return ec.fieldContext_FindingGroupSummary_title(ctx, field)
-			case "service":
-				return ec.fieldContext_FindingGroupSummary_service(ctx, field)
-			case "checkId":
-				return ec.fieldContext_FindingGroupSummary_checkId(ctx, field)
-			case "severity":
-				return ec.fieldContext_FindingGroupSummary_severity(ctx, field)
-			case "findingCount":
-				return ec.fieldContext_FindingGroupSummary_findingCount(ctx, field)
-			case "resourceIds":
-				return ec.fieldContext_FindingGroupSummary_resourceIds(ctx, field)
-			case "summary":
-				return ec.fieldContext_FindingGroupSummary_summary(ctx, field)
-			case "remedy":
-				return ec.fieldContext_FindingGroupSummary_remedy(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type FindingGroupSummary", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ScanSummary_actions(ctx context.Context, field graphql.CollectedField, obj *model.ScanSummary) (ret graphql.Marshaler) {
+func (ec *executionContext) _Tag_value(ctx context.Context, field graphql.CollectedField, obj *model.Tag) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_ScanSummary_actions,
+		ec.fieldContext_Tag_value,
 		func(ctx context.Context) (any, error) {
-			return obj.Actions, nil
+			return obj.Value, nil
 		},
 		nil,
-		ec.marshalNActionItemSummary2ᚕcloudcopᚋapiᚋgraphᚋmodelᚐActionItemSummaryᚄ,
+		ec.marshalNString2string,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_ScanSummary_actions(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Tag_value(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ScanSummary",
+		Object:     "Tag",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "actionId":
-				return ec.fieldContext_ActionItemSummary_actionId(ctx, field)
-			case "title":
-				return ec.fieldContext_ActionItemSummary_title(ctx, field)
-			case "description":
-				return ec.fieldContext_ActionItemSummary_description(ctx, field)
-			case "severity":
-				return ec.fieldContext_ActionItemSummary_severity(ctx, field)
-			case "commands":
-				return ec.fieldContext_ActionItemSummary_commands(ctx, field)
-			case "groupId":
-				return ec.fieldContext_ActionItemSummary_groupId(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type ActionItemSummary", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
@@ -4682,16 +6773,28 @@ func (ec *executionContext) _Finding(ctx context.Context, sel ast.SelectionSet,
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "arn":
+			out.Values[i] = ec._Finding_arn(ctx, field, obj)
 		case "checkId":
 			out.Values[i] = ec._Finding_checkId(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "status":
+			out.Values[i] = ec._Finding_status(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		case "severity":
 			out.Values[i] = ec._Finding_severity(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "confidence":
+			out.Values[i] = ec._Finding_confidence(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		case "title":
 			out.Values[i] = ec._Finding_title(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
@@ -4704,6 +6807,10 @@ func (ec *executionContext) _Finding(ctx context.Context, sel ast.SelectionSet,
 			}
 		case "compliance":
 			out.Values[i] = ec._Finding_compliance(ctx, field, obj)
+		case "resourceCreatedAt":
+			out.Values[i] = ec._Finding_resourceCreatedAt(ctx, field, obj)
+		case "tags":
+			out.Values[i] = ec._Finding_tags(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -4806,6 +6913,116 @@ func (ec *executionContext) _FindingGroupSummary(ctx context.Context, sel ast.Se
 	return out
 }
 
+var findingSuppressionImplementors = []string{"FindingSuppression"}
+
+func (ec *executionContext) _FindingSuppression(ctx context.Context, sel ast.SelectionSet, obj *database.FindingSuppression) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, findingSuppressionImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("FindingSuppression")
+		case "findingId":
+			out.Values[i] = ec._FindingSuppression_findingId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "reason":
+			out.Values[i] = ec._FindingSuppression_reason(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "expiresAt":
+			field := field
+
+			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._FindingSuppression_expiresAt(ctx, field, obj)
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+		case "createdAt":
+			field := field
+
+			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._FindingSuppression_createdAt(ctx, field, obj)
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
 var mutationImplementors = []string{"Mutation"}
 
 func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet) graphql.Marshaler {
@@ -4827,21 +7044,56 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 			out.Values[i] = graphql.MarshalString("Mutation")
 		case "verifyAwsAccount":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Mutation_verifyAwsAccount(ctx, field)
+				return ec._Mutation_verifyAwsAccount(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "connectAccount":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_connectAccount(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "startScan":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_startScan(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "summarizeScan":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_summarizeScan(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "suppressFinding":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_suppressFinding(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "unsuppressFinding":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_unsuppressFinding(ctx, field)
 			})
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "connectAccount":
+		case "createScanSchedule":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Mutation_connectAccount(ctx, field)
+				return ec._Mutation_createScanSchedule(ctx, field)
 			})
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "startScan":
+		case "deleteScanSchedule":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Mutation_startScan(ctx, field)
+				return ec._Mutation_deleteScanSchedule(ctx, field)
 			})
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
@@ -4950,6 +7202,88 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 			}
 
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "securityScore":
+			field := field
+
+			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_securityScore(ctx, field)
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "scans":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_scans(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "scan":
+			field := field
+
+			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_scan(ctx, field)
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "scanSchedules":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_scanSchedules(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
 		case "__type":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
@@ -4987,25 +7321,199 @@ var scanImplementors = []string{"Scan"}
 func (ec *executionContext) _Scan(ctx context.Context, sel ast.SelectionSet, obj *database.Scan) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, scanImplementors)
 
-	out := graphql.NewFieldSet(fields)
-	deferred := make(map[string]*graphql.FieldSet)
-	for i, field := range fields {
-		switch field.Name {
-		case "__typename":
-			out.Values[i] = graphql.MarshalString("Scan")
-		case "id":
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Scan")
+		case "id":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Scan_id(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+		case "status":
+			out.Values[i] = ec._Scan_status(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "services":
+			out.Values[i] = ec._Scan_services(ctx, field, obj)
+		case "regions":
+			out.Values[i] = ec._Scan_regions(ctx, field, obj)
+		case "overallScore":
+			field := field
+
+			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Scan_overallScore(ctx, field, obj)
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+		case "findings":
+			field := field
+
+			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Scan_findings(ctx, field, obj)
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+		case "summary":
+			field := field
+
+			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Scan_summary(ctx, field, obj)
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+		case "errors":
+			field := field
+
+			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Scan_errors(ctx, field, obj)
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+		case "startedAt":
 			field := field
 
-			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
 				defer func() {
 					if r := recover(); r != nil {
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Scan_id(ctx, field, obj)
-				if res == graphql.Null {
-					atomic.AddUint32(&fs.Invalids, 1)
-				}
+				res = ec._Scan_startedAt(ctx, field, obj)
 				return res
 			}
 
@@ -5029,16 +7537,7 @@ func (ec *executionContext) _Scan(ctx context.Context, sel ast.SelectionSet, obj
 			}
 
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
-		case "status":
-			out.Values[i] = ec._Scan_status(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				atomic.AddUint32(&out.Invalids, 1)
-			}
-		case "services":
-			out.Values[i] = ec._Scan_services(ctx, field, obj)
-		case "regions":
-			out.Values[i] = ec._Scan_regions(ctx, field, obj)
-		case "overallScore":
+		case "completedAt":
 			field := field
 
 			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
@@ -5047,7 +7546,7 @@ func (ec *executionContext) _Scan(ctx context.Context, sel ast.SelectionSet, obj
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Scan_overallScore(ctx, field, obj)
+				res = ec._Scan_completedAt(ctx, field, obj)
 				return res
 			}
 
@@ -5071,16 +7570,19 @@ func (ec *executionContext) _Scan(ctx context.Context, sel ast.SelectionSet, obj
 			}
 
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
-		case "findings":
+		case "createdAt":
 			field := field
 
-			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
 				defer func() {
 					if r := recover(); r != nil {
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Scan_findings(ctx, field, obj)
+				res = ec._Scan_createdAt(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
 				return res
 			}
 
@@ -5104,16 +7606,107 @@ func (ec *executionContext) _Scan(ctx context.Context, sel ast.SelectionSet, obj
 			}
 
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
-		case "summary":
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var scanErrorImplementors = []string{"ScanError"}
+
+func (ec *executionContext) _ScanError(ctx context.Context, sel ast.SelectionSet, obj *model.ScanError) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, scanErrorImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ScanError")
+		case "service":
+			out.Values[i] = ec._ScanError_service(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "region":
+			out.Values[i] = ec._ScanError_region(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "type":
+			out.Values[i] = ec._ScanError_type(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "message":
+			out.Values[i] = ec._ScanError_message(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var scanScheduleImplementors = []string{"ScanSchedule"}
+
+func (ec *executionContext) _ScanSchedule(ctx context.Context, sel ast.SelectionSet, obj *database.ScanSchedule) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, scanScheduleImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ScanSchedule")
+		case "id":
 			field := field
 
-			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
 				defer func() {
 					if r := recover(); r != nil {
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Scan_summary(ctx, field, obj)
+				res = ec._ScanSchedule_id(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
 				return res
 			}
 
@@ -5137,7 +7730,21 @@ func (ec *executionContext) _Scan(ctx context.Context, sel ast.SelectionSet, obj
 			}
 
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
-		case "startedAt":
+		case "cronExpr":
+			out.Values[i] = ec._ScanSchedule_cronExpr(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "services":
+			out.Values[i] = ec._ScanSchedule_services(ctx, field, obj)
+		case "regions":
+			out.Values[i] = ec._ScanSchedule_regions(ctx, field, obj)
+		case "enabled":
+			out.Values[i] = ec._ScanSchedule_enabled(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "nextRunAt":
 			field := field
 
 			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
@@ -5146,7 +7753,7 @@ func (ec *executionContext) _Scan(ctx context.Context, sel ast.SelectionSet, obj
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Scan_startedAt(ctx, field, obj)
+				res = ec._ScanSchedule_nextRunAt(ctx, field, obj)
 				return res
 			}
 
@@ -5170,7 +7777,7 @@ func (ec *executionContext) _Scan(ctx context.Context, sel ast.SelectionSet, obj
 			}
 
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
-		case "completedAt":
+		case "lastRunAt":
 			field := field
 
 			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
@@ -5179,7 +7786,7 @@ func (ec *executionContext) _Scan(ctx context.Context, sel ast.SelectionSet, obj
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Scan_completedAt(ctx, field, obj)
+				res = ec._ScanSchedule_lastRunAt(ctx, field, obj)
 				return res
 			}
 
@@ -5206,16 +7813,13 @@ func (ec *executionContext) _Scan(ctx context.Context, sel ast.SelectionSet, obj
 		case "createdAt":
 			field := field
 
-			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
 				defer func() {
 					if r := recover(); r != nil {
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Scan_createdAt(ctx, field, obj)
-				if res == graphql.Null {
-					atomic.AddUint32(&fs.Invalids, 1)
-				}
+				res = ec._ScanSchedule_createdAt(ctx, field, obj)
 				return res
 			}
 
@@ -5293,8 +7897,164 @@ func (ec *executionContext) _ScanSummary(ctx context.Context, sel ast.SelectionS
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "actions":
-			out.Values[i] = ec._ScanSummary_actions(ctx, field, obj)
+		case "actions":
+			out.Values[i] = ec._ScanSummary_actions(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var securityScoreImplementors = []string{"SecurityScore"}
+
+func (ec *executionContext) _SecurityScore(ctx context.Context, sel ast.SelectionSet, obj *model.SecurityScore) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, securityScoreImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("SecurityScore")
+		case "scanId":
+			out.Values[i] = ec._SecurityScore_scanId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "riskScore":
+			out.Values[i] = ec._SecurityScore_riskScore(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "riskLevel":
+			out.Values[i] = ec._SecurityScore_riskLevel(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "severityBreakdown":
+			out.Values[i] = ec._SecurityScore_severityBreakdown(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "trendDelta":
+			out.Values[i] = ec._SecurityScore_trendDelta(ctx, field, obj)
+		case "scannedAt":
+			out.Values[i] = ec._SecurityScore_scannedAt(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var severityBreakdownImplementors = []string{"SeverityBreakdown"}
+
+func (ec *executionContext) _SeverityBreakdown(ctx context.Context, sel ast.SelectionSet, obj *model.SeverityBreakdown) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, severityBreakdownImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("SeverityBreakdown")
+		case "critical":
+			out.Values[i] = ec._SeverityBreakdown_critical(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "high":
+			out.Values[i] = ec._SeverityBreakdown_high(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "medium":
+			out.Values[i] = ec._SeverityBreakdown_medium(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "low":
+			out.Values[i] = ec._SeverityBreakdown_low(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var tagImplementors = []string{"Tag"}
+
+func (ec *executionContext) _Tag(ctx context.Context, sel ast.SelectionSet, obj *model.Tag) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, tagImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Tag")
+		case "key":
+			out.Values[i] = ec._Tag_key(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "value":
+			out.Values[i] = ec._Tag_value(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
@@ -6161,6 +8921,20 @@ func (ec *executionContext) marshalNFindingGroupSummary2ᚕcloudcopᚋapiᚋgrap
 	return ret
 }
 
+func (ec *executionContext) marshalNFindingSuppression2cloudcopᚋapiᚋinternalᚋdatabaseᚐFindingSuppression(ctx context.Context, sel ast.SelectionSet, v database.FindingSuppression) graphql.Marshaler {
+	return ec._FindingSuppression(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNFindingSuppression2ᚖcloudcopᚋapiᚋinternalᚋdatabaseᚐFindingSuppression(ctx context.Context, sel ast.SelectionSet, v *database.FindingSuppression) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._FindingSuppression(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNID2string(ctx context.Context, v any) (string, error) {
 	res, err := graphql.UnmarshalID(v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -6197,6 +8971,50 @@ func (ec *executionContext) marshalNScan2cloudcopᚋapiᚋinternalᚋdatabaseᚐ
 	return ec._Scan(ctx, sel, &v)
 }
 
+func (ec *executionContext) marshalNScan2ᚕcloudcopᚋapiᚋinternalᚋdatabaseᚐScanᚄ(ctx context.Context, sel ast.SelectionSet, v []database.Scan) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNScan2cloudcopᚋapiᚋinternalᚋdatabaseᚐScan(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
 func (ec *executionContext) marshalNScan2ᚖcloudcopᚋapiᚋinternalᚋdatabaseᚐScan(ctx context.Context, sel ast.SelectionSet, v *database.Scan) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
@@ -6207,6 +9025,92 @@ func (ec *executionContext) marshalNScan2ᚖcloudcopᚋapiᚋinternalᚋdatabase
 	return ec._Scan(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNScanError2cloudcopᚋapiᚋgraphᚋmodelᚐScanError(ctx context.Context, sel ast.SelectionSet, v model.ScanError) graphql.Marshaler {
+	return ec._ScanError(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNScanSchedule2cloudcopᚋapiᚋinternalᚋdatabaseᚐScanSchedule(ctx context.Context, sel ast.SelectionSet, v database.ScanSchedule) graphql.Marshaler {
+	return ec._ScanSchedule(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNScanSchedule2ᚕcloudcopᚋapiᚋinternalᚋdatabaseᚐScanScheduleᚄ(ctx context.Context, sel ast.SelectionSet, v []database.ScanSchedule) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNScanSchedule2cloudcopᚋapiᚋinternalᚋdatabaseᚐScanSchedule(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNScanSchedule2ᚖcloudcopᚋapiᚋinternalᚋdatabaseᚐScanSchedule(ctx context.Context, sel ast.SelectionSet, v *database.ScanSchedule) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._ScanSchedule(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNScanSummary2cloudcopᚋapiᚋgraphᚋmodelᚐScanSummary(ctx context.Context, sel ast.SelectionSet, v model.ScanSummary) graphql.Marshaler {
+	return ec._ScanSummary(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNScanSummary2ᚖcloudcopᚋapiᚋgraphᚋmodelᚐScanSummary(ctx context.Context, sel ast.SelectionSet, v *model.ScanSummary) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._ScanSummary(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNSeverityBreakdown2ᚖcloudcopᚋapiᚋgraphᚋmodelᚐSeverityBreakdown(ctx context.Context, sel ast.SelectionSet, v *model.SeverityBreakdown) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._SeverityBreakdown(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNString2string(ctx context.Context, v any) (string, error) {
 	res, err := graphql.UnmarshalString(v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -6253,6 +9157,10 @@ func (ec *executionContext) marshalNString2ᚕstringᚄ(ctx context.Context, sel
 	return ret
 }
 
+func (ec *executionContext) marshalNTag2cloudcopᚋapiᚋgraphᚋmodelᚐTag(ctx context.Context, sel ast.SelectionSet, v model.Tag) graphql.Marshaler {
+	return ec._Tag(ctx, sel, &v)
+}
+
 func (ec *executionContext) marshalNTeam2cloudcopᚋapiᚋinternalᚋdatabaseᚐTeam(ctx context.Context, sel ast.SelectionSet, v database.Team) graphql.Marshaler {
 	return ec._Team(ctx, sel, &v)
 }
@@ -6717,6 +9625,60 @@ func (ec *executionContext) marshalOScan2ᚕcloudcopᚋapiᚋinternalᚋdatabase
 	return ret
 }
 
+func (ec *executionContext) marshalOScan2ᚖcloudcopᚋapiᚋinternalᚋdatabaseᚐScan(ctx context.Context, sel ast.SelectionSet, v *database.Scan) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._Scan(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalOScanError2ᚕcloudcopᚋapiᚋgraphᚋmodelᚐScanErrorᚄ(ctx context.Context, sel ast.SelectionSet, v []model.ScanError) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNScanError2cloudcopᚋapiᚋgraphᚋmodelᚐScanError(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
 func (ec *executionContext) marshalOScanSummary2ᚖcloudcopᚋapiᚋgraphᚋmodelᚐScanSummary(ctx context.Context, sel ast.SelectionSet, v *model.ScanSummary) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -6724,6 +9686,13 @@ func (ec *executionContext) marshalOScanSummary2ᚖcloudcopᚋapiᚋgraphᚋmode
 	return ec._ScanSummary(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalOSecurityScore2ᚖcloudcopᚋapiᚋgraphᚋmodelᚐSecurityScore(ctx context.Context, sel ast.SelectionSet, v *model.SecurityScore) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._SecurityScore(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalOString2ᚕstringᚄ(ctx context.Context, v any) ([]string, error) {
 	if v == nil {
 		return nil, nil
@@ -6778,6 +9747,53 @@ func (ec *executionContext) marshalOString2ᚖstring(ctx context.Context, sel as
 	return res
 }
 
+func (ec *executionContext) marshalOTag2ᚕcloudcopᚋapiᚋgraphᚋmodelᚐTagᚄ(ctx context.Context, sel ast.SelectionSet, v []model.Tag) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNTag2cloudcopᚋapiᚋgraphᚋmodelᚐTag(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
 func (ec *executionContext) marshalOTeam2ᚕcloudcopᚋapiᚋinternalᚋdatabaseᚐTeamᚄ(ctx context.Context, sel ast.SelectionSet, v []database.Team) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -6879,6 +9895,24 @@ func (ec *executionContext) marshalOTeamMember2ᚕcloudcopᚋapiᚋinternalᚋda
 	return ret
 }
 
+func (ec *executionContext) unmarshalOTime2ᚖtimeᚐTime(ctx context.Context, v any) (*time.Time, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := graphql.UnmarshalTime(v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOTime2ᚖtimeᚐTime(ctx context.Context, sel ast.SelectionSet, v *time.Time) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	_ = sel
+	_ = ctx
+	res := graphql.MarshalTime(*v)
+	return res
+}
+
 func (ec *executionContext) marshalO__EnumValue2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐEnumValueᚄ(ctx context.Context, sel ast.SelectionSet, v []introspection.EnumValue) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
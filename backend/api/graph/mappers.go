@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"cloudcop/api/graph/model"
+	"cloudcop/api/internal/scanner"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// mapFindings converts scanner findings into their GraphQL model. Findings
+// persisted before finding IDs existed fall back to an ID derived from the
+// check and resource, and findings without a confidence take their check's.
+// Tags are sorted by key so the output is stable.
+func mapFindings(findings []scanner.Finding) []model.Finding {
+	out := make([]model.Finding, len(findings))
+	for i, f := range findings {
+		id := f.FindingID
+		if id == "" {
+			id = fmt.Sprintf("%s:%s:%s", f.CheckID, f.Region, f.ResourceID)
+		}
+		confidence := f.Confidence
+		if confidence == "" {
+			confidence = scanner.ConfidenceFor(f.CheckID)
+		}
+		out[i] = model.Finding{
+			ID:          id,
+			Service:     f.Service,
+			Region:      f.Region,
+			ResourceID:  f.ResourceID,
+			CheckID:     f.CheckID,
+			Status:      string(f.Status),
+			Severity:    string(f.Severity),
+			Confidence:  string(confidence),
+			Title:       f.Title,
+			Description: f.Description,
+			Compliance:  f.Compliance,
+		}
+		if f.ARN != "" {
+			arn := f.ARN
+			out[i].Arn = &arn
+		}
+		if f.ResourceCreatedAt != nil {
+			createdAt := f.ResourceCreatedAt.Format(time.RFC3339)
+			out[i].ResourceCreatedAt = &createdAt
+		}
+		for _, key := range slices.Sorted(maps.Keys(f.Tags)) {
+			out[i].Tags = append(out[i].Tags, model.Tag{Key: key, Value: f.Tags[key]})
+		}
+	}
+	return out
+}
+
+// mapScanErrors converts classified scan errors into their GraphQL model.
+func mapScanErrors(errs []scanner.ScanError) []model.ScanError {
+	out := make([]model.ScanError, len(errs))
+	for i, e := range errs {
+		out[i] = model.ScanError{
+			Service: e.Service,
+			Region:  e.Region,
+			Type:    string(e.Type),
+			Message: e.Message,
+		}
+	}
+	return out
+}
+
+// mapScanSummary converts a scanner.ScanSummary into its GraphQL model.
+func mapScanSummary(s *scanner.ScanSummary) *model.ScanSummary {
+	if s == nil {
+		return nil
+	}
+	groups := make([]model.FindingGroupSummary, len(s.Groups))
+	for i, g := range s.Groups {
+		groups[i] = model.FindingGroupSummary{
+			GroupID:      g.GroupID,
+			Title:        g.Title,
+			Service:      g.Service,
+			CheckID:      g.CheckID,
+			Severity:     g.Severity,
+			FindingCount: g.FindingCount,
+			ResourceIds:  g.ResourceIDs,
+			Summary:      g.Summary,
+			Remedy:       g.Remedy,
+		}
+	}
+
+	actions := make([]model.ActionItemSummary, len(s.Actions))
+	for i, a := range s.Actions {
+		actions[i] = model.ActionItemSummary{
+			ActionID:    a.ActionID,
+			Title:       a.Title,
+			Description: a.Description,
+			Severity:    a.Severity,
+			Commands:    a.Commands,
+			GroupID:     a.GroupID,
+		}
+	}
+
+	return &model.ScanSummary{
+		RiskLevel:   s.RiskLevel,
+		RiskScore:   s.RiskScore,
+		SummaryText: s.SummaryText,
+		Groups:      groups,
+		Actions:     actions,
+	}
+}
+
+// deref returns the value of an optional string argument, or "" when unset.
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
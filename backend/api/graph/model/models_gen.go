@@ -26,15 +26,20 @@ type ActionItemSummary struct {
 }
 
 type Finding struct {
-	ID          string   `json:"id"`
-	Service     string   `json:"service"`
-	Region      string   `json:"region"`
-	ResourceID  string   `json:"resourceId"`
-	CheckID     string   `json:"checkId"`
-	Severity    string   `json:"severity"`
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Compliance  []string `json:"compliance,omitempty"`
+	ID                string   `json:"id"`
+	Service           string   `json:"service"`
+	Region            string   `json:"region"`
+	ResourceID        string   `json:"resourceId"`
+	Arn               *string  `json:"arn,omitempty"`
+	CheckID           string   `json:"checkId"`
+	Status            string   `json:"status"`
+	Severity          string   `json:"severity"`
+	Confidence        string   `json:"confidence"`
+	Title             string   `json:"title"`
+	Description       string   `json:"description"`
+	Compliance        []string `json:"compliance,omitempty"`
+	ResourceCreatedAt *string  `json:"resourceCreatedAt,omitempty"`
+	Tags              []Tag    `json:"tags,omitempty"`
 }
 
 type FindingGroupSummary struct {
@@ -55,6 +60,13 @@ type Mutation struct {
 type Query struct {
 }
 
+type ScanError struct {
+	Service string `json:"service"`
+	Region  string `json:"region"`
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
 type ScanSummary struct {
 	RiskLevel   string                `json:"riskLevel"`
 	RiskScore   int                   `json:"riskScore"`
@@ -62,3 +74,24 @@ type ScanSummary struct {
 	Groups      []FindingGroupSummary `json:"groups"`
 	Actions     []ActionItemSummary   `json:"actions"`
 }
+
+type SecurityScore struct {
+	ScanID            string             `json:"scanId"`
+	RiskScore         int                `json:"riskScore"`
+	RiskLevel         string             `json:"riskLevel"`
+	SeverityBreakdown *SeverityBreakdown `json:"severityBreakdown"`
+	TrendDelta        *int               `json:"trendDelta,omitempty"`
+	ScannedAt         *string            `json:"scannedAt,omitempty"`
+}
+
+type SeverityBreakdown struct {
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Medium   int `json:"medium"`
+	Low      int `json:"low"`
+}
+
+type Tag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
@@ -5,8 +5,13 @@ import (
 	"cloudcop/api/internal/awsauth"
 	"cloudcop/api/internal/database"
 	"cloudcop/api/internal/graphdb"
+	"cloudcop/api/internal/metrics"
+	"cloudcop/api/internal/scanner"
 	"cloudcop/api/internal/security"
+	"context"
 	"sync"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // This file will not be regenerated automatically.
@@ -16,10 +21,55 @@ import (
 
 // Resolver is the dependency injection struct for the graph resolver.
 type Resolver struct {
-	DB          *database.Queries
-	Auth        *awsauth.AWSAuth
-	Cache       *awsauth.CredentialCache
-	Neo4j       *graphdb.Neo4jClient
-	Security    *security.Service
-	ScanResults sync.Map // map[string]*scanner.ScanResultWithSummary (ephemeral storage for demo)
+	DB       *database.Queries
+	Auth     *awsauth.AWSAuth
+	Cache    *awsauth.CredentialCache
+	Neo4j    *graphdb.Neo4jClient
+	Security *security.Service
+	Scans    ScanStore
+	// Suppressions holds the findings teams have accepted as risks. Team
+	// lookups go through Scans, so both must be set to manage suppressions.
+	Suppressions SuppressionStore
+	// Schedules holds the recurring scans run by the scheduler. Like
+	// Suppressions, it relies on Scans for team lookups.
+	Schedules   ScheduleStore
+	Summarizer  Summarizer
+	Metrics     *metrics.Collector
+	ScanResults sync.Map // map[scanResultKey]*scanner.ScanResultWithSummary (ephemeral storage for demo)
+}
+
+// ScanStore persists scans and their findings. It is satisfied by
+// *database.Store; a nil store keeps scan results in memory only.
+type ScanStore interface {
+	CreateScanWithFindings(ctx context.Context, arg database.CreateScanParams, findings []database.InsertScanFindingsParams) (database.Scan, error)
+	GetAccountByTeamAndAccountID(ctx context.Context, arg database.GetAccountByTeamAndAccountIDParams) (database.AwsAccount, error)
+	GetLatestScansForTeam(ctx context.Context, arg database.GetLatestScansForTeamParams) ([]database.Scan, error)
+	GetTeamByOwnerID(ctx context.Context, ownerID string) (database.Team, error)
+	GetScanForTeam(ctx context.Context, arg database.GetScanForTeamParams) (database.Scan, error)
+	GetAccountByID(ctx context.Context, id int32) (database.AwsAccount, error)
+	ListScansForTeam(ctx context.Context, arg database.ListScansForTeamParams) ([]database.Scan, error)
+	ListScanFindings(ctx context.Context, scanID pgtype.Int4) ([]database.ScanFinding, error)
+	GetScanSummary(ctx context.Context, scanID int32) ([]byte, error)
+	UpsertScanSummary(ctx context.Context, arg database.UpsertScanSummaryParams) error
+}
+
+// SuppressionStore persists the findings teams accept as risks. It is
+// satisfied by *database.Queries.
+type SuppressionStore interface {
+	UpsertFindingSuppression(ctx context.Context, arg database.UpsertFindingSuppressionParams) (database.FindingSuppression, error)
+	DeleteFindingSuppression(ctx context.Context, arg database.DeleteFindingSuppressionParams) (int64, error)
+	ListFindingSuppressionsByTeam(ctx context.Context, teamID int32) ([]database.FindingSuppression, error)
+}
+
+// ScheduleStore persists scan schedules. It is satisfied by *database.Queries.
+type ScheduleStore interface {
+	CreateScanSchedule(ctx context.Context, arg database.CreateScanScheduleParams) (database.ScanSchedule, error)
+	ListScanSchedulesByAccount(ctx context.Context, awsAccountID int32) ([]database.ScanSchedule, error)
+	DeleteScanSchedule(ctx context.Context, arg database.DeleteScanScheduleParams) (int64, error)
+}
+
+// Summarizer generates AI summaries of scan results. It is satisfied by
+// *security.Service.
+type Summarizer interface {
+	Summarize(ctx context.Context, result *scanner.ScanResult) (*scanner.ScanSummary, error)
 }
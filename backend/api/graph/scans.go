@@ -0,0 +1,401 @@
+package graph
+
+import (
+	"cloudcop/api/graph/model"
+	"cloudcop/api/internal/database"
+	"cloudcop/api/internal/middleware/auth"
+	"cloudcop/api/internal/scanner"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// saveScan persists a completed scan and its findings against the connection
+// it ran for, scoring it with the deterministic risk score. Findings below
+// minSeverity are not stored, but the score and check counts still cover them.
+// Scans outside a connection are not saved, since the same AWS account may be
+// connected by several teams.
+func (r *Resolver) saveScan(ctx context.Context, result *scanner.ScanResult, minSeverity scanner.Severity) (database.Scan, error) {
+	if result.ConnectionID == 0 {
+		return database.Scan{}, fmt.Errorf("account %s: scan did not run for a connected account", result.AccountID)
+	}
+	account, err := r.Scans.GetAccountByID(ctx, result.ConnectionID)
+	if err != nil {
+		return database.Scan{}, fmt.Errorf("looking up connection %d: %w", result.ConnectionID, err)
+	}
+	if account.AccountID != result.AccountID {
+		return database.Scan{}, fmt.Errorf("connection %d is not for account %s", result.ConnectionID, result.AccountID)
+	}
+
+	var findings []database.InsertScanFindingsParams
+	for _, f := range result.Findings {
+		if !f.Severity.AtLeast(minSeverity) {
+			continue
+		}
+		findings = append(findings, database.InsertScanFindingsParams{
+			FindingID:         f.FindingID,
+			Service:           f.Service,
+			Region:            f.Region,
+			ResourceID:        f.ResourceID,
+			ResourceArn:       pgtype.Text{String: f.ARN, Valid: f.ARN != ""},
+			ResourceCreatedAt: optionalTimestamp(f.ResourceCreatedAt),
+			CheckID:           f.CheckID,
+			Status:            string(f.Status),
+			Severity:          string(f.Severity),
+			Title:             f.Title,
+			Description:       pgtype.Text{String: f.Description, Valid: f.Description != ""},
+			Compliance:        f.Compliance,
+		})
+	}
+
+	counts := scanner.CountFailedBySeverity(result.Findings)
+	score := scanner.RiskScore(counts)
+	scan, err := r.Scans.CreateScanWithFindings(ctx, database.CreateScanParams{
+		AwsAccountID:   pgtype.Int4{Int32: account.ID, Valid: true},
+		Status:         "completed",
+		Services:       result.Services,
+		Regions:        result.Regions,
+		OverallScore:   pgtype.Int4{Int32: int32(score), Valid: true},
+		TotalChecks:    int32(result.TotalChecks),
+		PassedChecks:   int32(result.PassedChecks),
+		FailedChecks:   int32(result.FailedChecks),
+		ErrorChecks:    int32(result.ErrorChecks),
+		CriticalFailed: int32(counts.Critical),
+		HighFailed:     int32(counts.High),
+		MediumFailed:   int32(counts.Medium),
+		LowFailed:      int32(counts.Low),
+		StartedAt:      pgtype.Timestamp{Time: result.StartedAt, Valid: true},
+		CompletedAt:    pgtype.Timestamp{Time: result.CompletedAt, Valid: true},
+	}, findings)
+	if err != nil {
+		return database.Scan{}, fmt.Errorf("saving scan: %w", err)
+	}
+	r.Metrics.Observe(result)
+
+	return scan, nil
+}
+
+// SaveScan persists a scan started outside the startScan mutation, such as
+// a scheduled one, applying the security service's persistence threshold.
+func (r *Resolver) SaveScan(ctx context.Context, result *scanner.ScanResult) (database.Scan, error) {
+	var minSeverity scanner.Severity
+	if r.Security != nil {
+		minSeverity = r.Security.PersistMinSeverity()
+	}
+	return r.saveScan(ctx, result, minSeverity)
+}
+
+// scanSeverityCounts returns the failed check counts recorded on a scan.
+// They cover findings below the persistence threshold, which its stored
+// findings do not.
+func scanSeverityCounts(scan database.Scan) scanner.SeverityCounts {
+	return scanner.SeverityCounts{
+		Critical: int(scan.CriticalFailed),
+		High:     int(scan.HighFailed),
+		Medium:   int(scan.MediumFailed),
+		Low:      int(scan.LowFailed),
+	}
+}
+
+// scanRiskScore returns the risk score recorded on a scan, computing it from
+// the recorded counts if it was never set.
+func scanRiskScore(scan database.Scan) int {
+	if scan.OverallScore.Valid {
+		return int(scan.OverallScore.Int32)
+	}
+	return scanner.RiskScore(scanSeverityCounts(scan))
+}
+
+// securityScore builds the dashboard score for the latest persisted scan of
+// an account, or returns nil if the account has never been scanned. Accounts
+// outside the authenticated user's team are reported as not found.
+func (r *Resolver) securityScore(ctx context.Context, accountID string) (*model.SecurityScore, error) {
+	account, err := r.teamAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	scans, err := r.Scans.GetLatestScansForTeam(ctx, database.GetLatestScansForTeamParams{
+		AccountID: accountID,
+		TeamID:    account.TeamID,
+		Limit:     2,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loading scans: %w", err)
+	}
+	if len(scans) == 0 {
+		return nil, nil
+	}
+
+	latest := scans[0]
+	counts := scanSeverityCounts(latest)
+	score := scanRiskScore(latest)
+
+	result := &model.SecurityScore{
+		ScanID:    fmt.Sprintf("%d", latest.ID),
+		RiskScore: score,
+		RiskLevel: scanner.RiskLevel(score),
+		SeverityBreakdown: &model.SeverityBreakdown{
+			Critical: counts.Critical,
+			High:     counts.High,
+			Medium:   counts.Medium,
+			Low:      counts.Low,
+		},
+	}
+	if latest.CompletedAt.Valid {
+		scannedAt := latest.CompletedAt.Time.Format(time.RFC3339)
+		result.ScannedAt = &scannedAt
+	}
+
+	if len(scans) > 1 {
+		delta := score - scanRiskScore(scans[1])
+		result.TrendDelta = &delta
+	}
+
+	return result, nil
+}
+
+// Page sizes for listing persisted scans.
+const (
+	defaultScanPageSize = 20
+	maxScanPageSize     = 100
+)
+
+// teamForUser loads the team owned by the authenticated user.
+func (r *Resolver) teamForUser(ctx context.Context) (database.Team, error) {
+	user := auth.FromContext(ctx)
+	if user == nil {
+		return database.Team{}, fmt.Errorf("unauthorized")
+	}
+	team, err := r.Scans.GetTeamByOwnerID(ctx, user.ID)
+	if err != nil {
+		return database.Team{}, fmt.Errorf("team not found")
+	}
+	return team, nil
+}
+
+// listScans loads a page of an account's persisted scans, newest first.
+// Accounts outside the authenticated user's team have no scans.
+func (r *Resolver) listScans(ctx context.Context, accountID string, limit, offset *int) ([]database.Scan, error) {
+	pageSize := defaultScanPageSize
+	if limit != nil {
+		if *limit <= 0 {
+			return nil, fmt.Errorf("limit must be positive")
+		}
+		pageSize = min(*limit, maxScanPageSize)
+	}
+	skip := 0
+	if offset != nil {
+		if *offset < 0 {
+			return nil, fmt.Errorf("offset must not be negative")
+		}
+		skip = *offset
+	}
+
+	team, err := r.teamForUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scans, err := r.Scans.ListScansForTeam(ctx, database.ListScansForTeamParams{
+		AccountID: accountID,
+		TeamID:    pgtype.Int4{Int32: team.ID, Valid: true},
+		Limit:     int32(pageSize),
+		Offset:    int32(skip),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loading scans for account %s: %w", accountID, err)
+	}
+	if scans == nil {
+		return []database.Scan{}, nil
+	}
+	return scans, nil
+}
+
+// scanResultKey keys the in-memory result of a scan started through the
+// startScan mutation. Results are keyed by the team that ran the scan so
+// that scan IDs cannot be used to read other teams' results.
+type scanResultKey struct {
+	teamID int32
+	scanID int32
+}
+
+// cachedScanResult returns the in-memory result of a scan run by the
+// authenticated user's team, if it is still held.
+func (r *Resolver) cachedScanResult(ctx context.Context, scanID int32) (*scanner.ScanResultWithSummary, bool) {
+	if r.Scans == nil {
+		return nil, false
+	}
+	team, err := r.teamForUser(ctx)
+	if err != nil {
+		return nil, false
+	}
+	val, ok := r.ScanResults.Load(scanResultKey{teamID: team.ID, scanID: scanID})
+	if !ok {
+		return nil, false
+	}
+	return val.(*scanner.ScanResultWithSummary), true
+}
+
+// scanForUser loads a persisted scan owned by the authenticated user's team.
+// Scans of other teams are reported as not found.
+func (r *Resolver) scanForUser(ctx context.Context, scanID string) (database.Scan, error) {
+	if auth.FromContext(ctx) == nil {
+		return database.Scan{}, fmt.Errorf("unauthorized")
+	}
+	id, err := strconv.ParseInt(scanID, 10, 32)
+	if err != nil {
+		return database.Scan{}, fmt.Errorf("invalid scan ID %q", scanID)
+	}
+
+	team, err := r.teamForUser(ctx)
+	if err != nil {
+		return database.Scan{}, err
+	}
+	scan, err := r.Scans.GetScanForTeam(ctx, database.GetScanForTeamParams{
+		ID:     int32(id),
+		TeamID: pgtype.Int4{Int32: team.ID, Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return database.Scan{}, fmt.Errorf("scan %s not found", scanID)
+	}
+	if err != nil {
+		return database.Scan{}, fmt.Errorf("loading scan %s: %w", scanID, err)
+	}
+	return scan, nil
+}
+
+// loadScanResult rebuilds the result of a persisted scan from its stored
+// findings. Check counts come from the scan when it recorded them, since
+// low-severity findings may not have been stored; older scans recompute them.
+func (r *Resolver) loadScanResult(ctx context.Context, scan database.Scan) (*scanner.ScanResult, error) {
+	account, err := r.Scans.GetAccountByID(ctx, scan.AwsAccountID.Int32)
+	if err != nil {
+		return nil, fmt.Errorf("loading account for scan %d: %w", scan.ID, err)
+	}
+	findings, err := r.persistedFindings(ctx, scan.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &scanner.ScanResult{
+		AccountID:   account.AccountID,
+		Regions:     scan.Regions,
+		Services:    scan.Services,
+		StartedAt:   scan.StartedAt.Time,
+		CompletedAt: scan.CompletedAt.Time,
+		Findings:    findings,
+	}
+	for _, f := range findings {
+		switch f.Status {
+		case scanner.StatusPass:
+			result.PassedChecks++
+		case scanner.StatusError:
+			result.ErrorChecks++
+		default:
+			result.FailedChecks++
+		}
+	}
+	result.TotalChecks = len(result.Findings)
+
+	if scan.TotalChecks > 0 {
+		result.TotalChecks = int(scan.TotalChecks)
+		result.PassedChecks = int(scan.PassedChecks)
+		result.FailedChecks = int(scan.FailedChecks)
+		result.ErrorChecks = int(scan.ErrorChecks)
+	}
+	return result, nil
+}
+
+// persistedFindings loads the stored findings of a scan in the order they
+// were saved.
+func (r *Resolver) persistedFindings(ctx context.Context, scanID int32) ([]scanner.Finding, error) {
+	rows, err := r.Scans.ListScanFindings(ctx, pgtype.Int4{Int32: scanID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("loading findings for scan %d: %w", scanID, err)
+	}
+
+	findings := make([]scanner.Finding, 0, len(rows))
+	for _, row := range rows {
+		findings = append(findings, scanner.Finding{
+			FindingID:         row.FindingID,
+			Service:           row.Service,
+			Region:            row.Region,
+			ResourceID:        row.ResourceID,
+			ARN:               row.ResourceArn.String,
+			CheckID:           row.CheckID,
+			Status:            scanner.FindingStatus(row.Status),
+			Severity:          scanner.Severity(row.Severity),
+			Confidence:        scanner.ConfidenceFor(row.CheckID),
+			Title:             row.Title,
+			Description:       row.Description.String,
+			Compliance:        row.Compliance,
+			Timestamp:         row.CreatedAt.Time,
+			ResourceCreatedAt: optionalTime(row.ResourceCreatedAt),
+		})
+	}
+	return findings, nil
+}
+
+// optionalTimestamp converts an optional time to a nullable TIMESTAMP,
+// stored in UTC.
+func optionalTimestamp(t *time.Time) pgtype.Timestamp {
+	if t == nil {
+		return pgtype.Timestamp{}
+	}
+	return pgtype.Timestamp{Time: t.UTC(), Valid: true}
+}
+
+// optionalTime converts a nullable TIMESTAMP back to an optional time.
+func optionalTime(ts pgtype.Timestamp) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	t := ts.Time
+	return &t
+}
+
+// summarizeScan generates the AI summary of a persisted scan and stores it,
+// replacing any earlier summary.
+func (r *Resolver) summarizeScan(ctx context.Context, scan database.Scan) (*scanner.ScanSummary, error) {
+	result, err := r.loadScanResult(ctx, scan)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := r.Summarizer.Summarize(ctx, result)
+	if err != nil {
+		return nil, fmt.Errorf("summarizing scan %d: %w", scan.ID, err)
+	}
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return nil, fmt.Errorf("encoding summary: %w", err)
+	}
+	err = r.Scans.UpsertScanSummary(ctx, database.UpsertScanSummaryParams{ScanID: scan.ID, Summary: data})
+	if err != nil {
+		return nil, fmt.Errorf("saving summary for scan %d: %w", scan.ID, err)
+	}
+	return summary, nil
+}
+
+// persistedSummary loads the stored AI summary of a scan, or nil if the scan
+// has not been summarized.
+func (r *Resolver) persistedSummary(ctx context.Context, scanID int32) (*scanner.ScanSummary, error) {
+	data, err := r.Scans.GetScanSummary(ctx, scanID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading summary for scan %d: %w", scanID, err)
+	}
+
+	var summary scanner.ScanSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, fmt.Errorf("decoding summary for scan %d: %w", scanID, err)
+	}
+	return &summary, nil
+}
@@ -0,0 +1,672 @@
+package graph
+
+import (
+	"cloudcop/api/graph/model"
+	"cloudcop/api/internal/database"
+	"cloudcop/api/internal/middleware/auth"
+	"cloudcop/api/internal/scanner"
+	"cloudcop/api/internal/security"
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/clerkinc/clerk-sdk-go/clerk"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// fakeScanStore serves persisted scans of account 123456789012, owned by
+// team 1, from memory.
+type fakeScanStore struct {
+	ScanStore
+	scans []database.Scan
+}
+
+func (f *fakeScanStore) GetTeamByOwnerID(_ context.Context, ownerID string) (database.Team, error) {
+	if ownerID == "user_2" {
+		return database.Team{ID: 2}, nil
+	}
+	return database.Team{ID: 1}, nil
+}
+
+func (f *fakeScanStore) GetAccountByTeamAndAccountID(_ context.Context, arg database.GetAccountByTeamAndAccountIDParams) (database.AwsAccount, error) {
+	if arg.TeamID.Int32 != 1 || arg.AccountID != "123456789012" {
+		return database.AwsAccount{}, pgx.ErrNoRows
+	}
+	return database.AwsAccount{ID: 1, TeamID: arg.TeamID, AccountID: arg.AccountID}, nil
+}
+
+func (f *fakeScanStore) GetLatestScansForTeam(_ context.Context, arg database.GetLatestScansForTeamParams) ([]database.Scan, error) {
+	if arg.TeamID.Int32 != 1 || arg.AccountID != "123456789012" {
+		return nil, nil
+	}
+	if int(arg.Limit) < len(f.scans) {
+		return f.scans[:arg.Limit], nil
+	}
+	return f.scans, nil
+}
+
+func TestSecurityScore_TrendDelta(t *testing.T) {
+	completed := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	store := &fakeScanStore{
+		// Newest first, matching the query's ordering.
+		scans: []database.Scan{
+			// 1 critical + 2 high + 1 low = 25 + 20 + 1
+			{
+				ID: 2, Status: "completed", CompletedAt: pgtype.Timestamp{Time: completed, Valid: true},
+				OverallScore:   pgtype.Int4{Int32: 46, Valid: true},
+				CriticalFailed: 1, HighFailed: 2, LowFailed: 1,
+			},
+			// 3 high + 2 medium = 30 + 8, scored from the counts as
+			// overall_score was not recorded.
+			{
+				ID: 1, Status: "completed", CompletedAt: pgtype.Timestamp{Time: completed.Add(-24 * time.Hour), Valid: true},
+				HighFailed: 3, MediumFailed: 2,
+			},
+		},
+	}
+	r := &queryResolver{&Resolver{Scans: store}}
+
+	got, err := r.SecurityScore(userContext("user_1"), "123456789012")
+	if err != nil {
+		t.Fatalf("SecurityScore() error = %v", err)
+	}
+	if got == nil {
+		t.Fatal("SecurityScore() = nil, want score")
+	}
+
+	if got.ScanID != "2" {
+		t.Errorf("ScanID = %q, want 2", got.ScanID)
+	}
+	if got.RiskScore != 46 {
+		t.Errorf("RiskScore = %d, want 46", got.RiskScore)
+	}
+	if got.RiskLevel != "MEDIUM" {
+		t.Errorf("RiskLevel = %q, want MEDIUM", got.RiskLevel)
+	}
+	b := got.SeverityBreakdown
+	if b.Critical != 1 || b.High != 2 || b.Medium != 0 || b.Low != 1 {
+		t.Errorf("SeverityBreakdown = %+v, want {1 2 0 1}", *b)
+	}
+	if got.TrendDelta == nil || *got.TrendDelta != 8 {
+		t.Errorf("TrendDelta = %v, want 8", got.TrendDelta)
+	}
+	if got.ScannedAt == nil || *got.ScannedAt != "2025-01-02T03:04:05Z" {
+		t.Errorf("ScannedAt = %v, want 2025-01-02T03:04:05Z", got.ScannedAt)
+	}
+}
+
+func TestSecurityScore_SingleScan(t *testing.T) {
+	store := &fakeScanStore{
+		scans: []database.Scan{{ID: 7, Status: "completed"}},
+	}
+	r := &queryResolver{&Resolver{Scans: store}}
+
+	got, err := r.SecurityScore(userContext("user_1"), "123456789012")
+	if err != nil {
+		t.Fatalf("SecurityScore() error = %v", err)
+	}
+	if got.RiskScore != 0 || got.RiskLevel != "LOW" {
+		t.Errorf("score = %d/%s, want 0/LOW", got.RiskScore, got.RiskLevel)
+	}
+	if got.TrendDelta != nil {
+		t.Errorf("TrendDelta = %d, want nil without a previous scan", *got.TrendDelta)
+	}
+}
+
+func TestSecurityScore_NoScans(t *testing.T) {
+	r := &queryResolver{&Resolver{Scans: &fakeScanStore{}}}
+
+	got, err := r.SecurityScore(userContext("user_1"), "123456789012")
+	if err != nil {
+		t.Fatalf("SecurityScore() error = %v", err)
+	}
+	if got != nil {
+		t.Errorf("SecurityScore() = %+v, want nil", got)
+	}
+}
+
+func TestSecurityScore_TeamScoped(t *testing.T) {
+	store := &fakeScanStore{
+		scans: []database.Scan{{ID: 7, Status: "completed", OverallScore: pgtype.Int4{Int32: 25, Valid: true}, CriticalFailed: 1}},
+	}
+	r := &queryResolver{&Resolver{Scans: store}}
+
+	if _, err := r.SecurityScore(context.Background(), "123456789012"); err == nil {
+		t.Error("expected an error without an authenticated user")
+	}
+	got, err := r.SecurityScore(userContext("user_2"), "123456789012")
+	if err == nil || got != nil {
+		t.Errorf("SecurityScore() for another team = %+v, %v; want not found", got, err)
+	}
+	if _, err := r.SecurityScore(userContext("user_1"), "210987654321"); err == nil {
+		t.Error("expected an error for an account that is not connected")
+	}
+}
+
+// userContext returns a context authenticated as the user with the given ID.
+func userContext(userID string) context.Context {
+	return auth.AttachContext(context.Background(), &clerk.User{ID: userID})
+}
+
+// summaryStore serves one persisted scan owned by team 1 and records the
+// summaries saved for it.
+type summaryStore struct {
+	ScanStore
+	scan      database.Scan
+	findings  []database.ScanFinding
+	summaries map[int32][]byte
+}
+
+func (f *summaryStore) GetTeamByOwnerID(_ context.Context, _ string) (database.Team, error) {
+	return database.Team{ID: 1}, nil
+}
+
+func (f *summaryStore) GetScanForTeam(_ context.Context, arg database.GetScanForTeamParams) (database.Scan, error) {
+	if arg.ID != f.scan.ID || arg.TeamID.Int32 != 1 {
+		return database.Scan{}, pgx.ErrNoRows
+	}
+	return f.scan, nil
+}
+
+func (f *summaryStore) GetAccountByID(_ context.Context, id int32) (database.AwsAccount, error) {
+	return database.AwsAccount{ID: id, AccountID: "123456789012"}, nil
+}
+
+func (f *summaryStore) ListScanFindings(_ context.Context, _ pgtype.Int4) ([]database.ScanFinding, error) {
+	return f.findings, nil
+}
+
+func (f *summaryStore) UpsertScanSummary(_ context.Context, arg database.UpsertScanSummaryParams) error {
+	f.summaries[arg.ScanID] = arg.Summary
+	return nil
+}
+
+func (f *summaryStore) GetScanSummary(_ context.Context, scanID int32) ([]byte, error) {
+	data, ok := f.summaries[scanID]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return data, nil
+}
+
+// fakeSummarizer records the scan result it was asked to summarize.
+type fakeSummarizer struct {
+	result *scanner.ScanResult
+}
+
+func (f *fakeSummarizer) Summarize(_ context.Context, result *scanner.ScanResult) (*scanner.ScanSummary, error) {
+	f.result = result
+	return &scanner.ScanSummary{
+		RiskLevel:   "HIGH",
+		RiskScore:   10,
+		SummaryText: "One bucket is unencrypted.",
+		Groups:      []scanner.FindingGroupSummary{{GroupID: "g1", CheckID: "s3_bucket_encryption", FindingCount: 1}},
+	}, nil
+}
+
+func TestSummarizeScan(t *testing.T) {
+	store := &summaryStore{
+		scan: database.Scan{ID: 42, AwsAccountID: pgtype.Int4{Int32: 3, Valid: true}, Services: []string{"s3"}, Regions: []string{"us-east-1"}},
+		findings: []database.ScanFinding{
+			{Service: "s3", Region: "us-east-1", ResourceID: "logs", CheckID: "s3_bucket_encryption", Status: "FAIL", Severity: "HIGH", Title: "S3 bucket encryption is disabled"},
+			{Service: "s3", Region: "us-east-1", ResourceID: "logs", CheckID: "s3_bucket_versioning", Status: "PASS", Severity: "MEDIUM", Title: "S3 bucket versioning is enabled"},
+		},
+		summaries: make(map[int32][]byte),
+	}
+	summarizer := &fakeSummarizer{}
+	resolver := &Resolver{Scans: store, Summarizer: summarizer}
+	ctx := auth.AttachContext(context.Background(), &clerk.User{ID: "user_1"})
+
+	got, err := resolver.Mutation().SummarizeScan(ctx, "42")
+	if err != nil {
+		t.Fatalf("SummarizeScan() error = %v", err)
+	}
+	if got.RiskLevel != "HIGH" || got.SummaryText != "One bucket is unencrypted." || len(got.Groups) != 1 {
+		t.Errorf("SummarizeScan() = %+v", got)
+	}
+
+	result := summarizer.result
+	if result.AccountID != "123456789012" || len(result.Findings) != 2 {
+		t.Fatalf("summarized %s with %d findings, want 123456789012 with 2", result.AccountID, len(result.Findings))
+	}
+	if result.FailedChecks != 1 || result.PassedChecks != 1 {
+		t.Errorf("got %d failed / %d passed, want 1 / 1", result.FailedChecks, result.PassedChecks)
+	}
+	if _, ok := store.summaries[42]; !ok {
+		t.Fatal("summary was not persisted")
+	}
+
+	// The scan's summary field now reads the persisted summary.
+	stored, err := resolver.Scan().Summary(ctx, &store.scan)
+	if err != nil {
+		t.Fatalf("Summary() error = %v", err)
+	}
+	if stored == nil || stored.RiskScore != 10 || stored.Groups[0].CheckID != "s3_bucket_encryption" {
+		t.Errorf("Summary() = %+v, want the persisted summary", stored)
+	}
+}
+
+func TestSummarizeScan_Ownership(t *testing.T) {
+	store := &summaryStore{scan: database.Scan{ID: 42}, summaries: make(map[int32][]byte)}
+	resolver := &Resolver{Scans: store, Summarizer: &fakeSummarizer{}}
+
+	if _, err := resolver.Mutation().SummarizeScan(context.Background(), "42"); err == nil {
+		t.Error("expected an error without an authenticated user")
+	}
+
+	ctx := auth.AttachContext(context.Background(), &clerk.User{ID: "user_1"})
+	if _, err := resolver.Mutation().SummarizeScan(ctx, "7"); err == nil {
+		t.Error("expected an error for a scan outside the user's team")
+	}
+	if len(store.summaries) != 0 {
+		t.Errorf("stored %d summaries, want none", len(store.summaries))
+	}
+}
+
+// saveStore records the scan and findings written by saveScan.
+type saveStore struct {
+	ScanStore
+	scan     database.CreateScanParams
+	findings []database.InsertScanFindingsParams
+}
+
+// GetAccountByID serves connections 3 and 4, which two teams made for the
+// same AWS account.
+func (f *saveStore) GetAccountByID(_ context.Context, id int32) (database.AwsAccount, error) {
+	if id != 3 && id != 4 {
+		return database.AwsAccount{}, pgx.ErrNoRows
+	}
+	return database.AwsAccount{ID: id, AccountID: "123456789012", TeamID: pgtype.Int4{Int32: id - 2, Valid: true}}, nil
+}
+
+func (f *saveStore) CreateScanWithFindings(_ context.Context, arg database.CreateScanParams, findings []database.InsertScanFindingsParams) (database.Scan, error) {
+	f.scan = arg
+	f.findings = findings
+	return database.Scan{ID: 42}, nil
+}
+
+func (f *saveStore) ListScanFindings(_ context.Context, _ pgtype.Int4) ([]database.ScanFinding, error) {
+	rows := make([]database.ScanFinding, 0, len(f.findings))
+	for _, p := range f.findings {
+		rows = append(rows, database.ScanFinding{
+			FindingID:   p.FindingID,
+			Service:     p.Service,
+			Region:      p.Region,
+			ResourceID:  p.ResourceID,
+			ResourceArn: p.ResourceArn,
+			CheckID:     p.CheckID,
+			Status:      p.Status,
+			Severity:    p.Severity,
+		})
+	}
+	return rows, nil
+}
+
+func TestSaveScan_PersistMinSeverity(t *testing.T) {
+	result := &scanner.ScanResult{
+		AccountID:    "123456789012",
+		ConnectionID: 3,
+		Findings: []scanner.Finding{
+			{CheckID: "iam_root_mfa", ResourceID: "root", Status: scanner.StatusFail, Severity: scanner.SeverityCritical},
+			{CheckID: "s3_bucket_encryption", ResourceID: "logs", Status: scanner.StatusPass, Severity: scanner.SeverityHigh},
+			{CheckID: "s3_bucket_versioning", ResourceID: "logs", Status: scanner.StatusFail, Severity: scanner.SeverityMedium},
+			{CheckID: "s3_bucket_logging", ResourceID: "logs", Status: scanner.StatusFail, Severity: scanner.SeverityLow},
+		},
+		TotalChecks:  4,
+		PassedChecks: 1,
+		FailedChecks: 3,
+	}
+
+	tests := []struct {
+		name        string
+		minSeverity scanner.Severity
+		want        []string
+	}{
+		{"no minimum", "", []string{"iam_root_mfa", "s3_bucket_encryption", "s3_bucket_versioning", "s3_bucket_logging"}},
+		{"high and above", scanner.SeverityHigh, []string{"iam_root_mfa", "s3_bucket_encryption"}},
+		{"critical only", scanner.SeverityCritical, []string{"iam_root_mfa"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &saveStore{}
+			r := &Resolver{Scans: store}
+
+			if _, err := r.saveScan(context.Background(), result, tt.minSeverity); err != nil {
+				t.Fatalf("saveScan() error = %v", err)
+			}
+
+			var stored []string
+			for _, f := range store.findings {
+				stored = append(stored, f.CheckID)
+			}
+			if !slices.Equal(stored, tt.want) {
+				t.Errorf("stored findings %v, want %v", stored, tt.want)
+			}
+			if len(result.Findings) != 4 {
+				t.Errorf("result has %d findings after saving, want all 4", len(result.Findings))
+			}
+
+			s := store.scan
+			if s.TotalChecks != 4 || s.PassedChecks != 1 || s.FailedChecks != 3 || s.ErrorChecks != 0 {
+				t.Errorf("stored counts total=%d passed=%d failed=%d errors=%d, want 4/1/3/0",
+					s.TotalChecks, s.PassedChecks, s.FailedChecks, s.ErrorChecks)
+			}
+			// 25 (critical) + 4 (medium) + 1 (low); the score still counts unstored findings.
+			if s.OverallScore.Int32 != 30 {
+				t.Errorf("OverallScore = %d, want 30", s.OverallScore.Int32)
+			}
+			if s.CriticalFailed != 1 || s.HighFailed != 0 || s.MediumFailed != 1 || s.LowFailed != 1 {
+				t.Errorf("stored failed by severity %d/%d/%d/%d, want 1/0/1/1",
+					s.CriticalFailed, s.HighFailed, s.MediumFailed, s.LowFailed)
+			}
+		})
+	}
+}
+
+func TestSaveScan_FindingID(t *testing.T) {
+	id := scanner.FindingID("123456789012", "s3", "us-east-1", "s3_bucket_encryption", "logs")
+	result := &scanner.ScanResult{
+		AccountID:    "123456789012",
+		ConnectionID: 3,
+		Findings: []scanner.Finding{
+			{FindingID: id, Service: "s3", Region: "us-east-1", CheckID: "s3_bucket_encryption", ResourceID: "logs", Status: scanner.StatusFail, Severity: scanner.SeverityHigh},
+		},
+	}
+	store := &saveStore{}
+	r := &Resolver{Scans: store}
+	if _, err := r.saveScan(context.Background(), result, ""); err != nil {
+		t.Fatalf("saveScan() error = %v", err)
+	}
+	if len(store.findings) != 1 || store.findings[0].FindingID != id {
+		t.Fatalf("stored findings %+v, want finding ID %s", store.findings, id)
+	}
+
+	mapped := mapFindings([]scanner.Finding{
+		result.Findings[0],
+		{Region: "us-east-1", CheckID: "s3_bucket_versioning", ResourceID: "logs"},
+	})
+	if mapped[0].ID != id {
+		t.Errorf("mapped ID = %s, want %s", mapped[0].ID, id)
+	}
+	if want := "s3_bucket_versioning:us-east-1:logs"; mapped[1].ID != want {
+		t.Errorf("mapped ID without a finding ID = %s, want %s", mapped[1].ID, want)
+	}
+}
+
+func TestMapFindings_Confidence(t *testing.T) {
+	mapped := mapFindings([]scanner.Finding{
+		{CheckID: "lambda_env_secrets", Confidence: scanner.ConfidenceMedium},
+		{CheckID: "ecs_public_registry"},
+		{CheckID: "s3_bucket_encryption"},
+	})
+	for i, want := range []string{"MEDIUM", "LOW", "HIGH"} {
+		if mapped[i].Confidence != want {
+			t.Errorf("%s confidence = %q, want %s", mapped[i].CheckID, mapped[i].Confidence, want)
+		}
+	}
+}
+
+func TestMapFindings_Tags(t *testing.T) {
+	mapped := mapFindings([]scanner.Finding{
+		{CheckID: "s3_bucket_encryption", Tags: map[string]string{"owner": "data", "env": "prod"}},
+		{CheckID: "s3_bucket_versioning"},
+	})
+	want := []model.Tag{{Key: "env", Value: "prod"}, {Key: "owner", Value: "data"}}
+	if !slices.Equal(mapped[0].Tags, want) {
+		t.Errorf("mapped tags = %v, want %v", mapped[0].Tags, want)
+	}
+	if mapped[1].Tags != nil {
+		t.Errorf("mapped tags = %v, want none for an untagged resource", mapped[1].Tags)
+	}
+}
+
+func TestSaveScan_ARN(t *testing.T) {
+	const arn = "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/web/50dc6c495c0c9188"
+	result := &scanner.ScanResult{
+		AccountID:    "123456789012",
+		ConnectionID: 3,
+		Findings: []scanner.Finding{
+			{Service: "elb", CheckID: "elb_https_listener", ResourceID: "web", ARN: arn, Status: scanner.StatusFail, Severity: scanner.SeverityHigh},
+			{Service: "s3", CheckID: "s3_bucket_encryption", ResourceID: "logs", Status: scanner.StatusFail, Severity: scanner.SeverityHigh},
+		},
+	}
+	store := &saveStore{}
+	r := &Resolver{Scans: store}
+	if _, err := r.saveScan(context.Background(), result, ""); err != nil {
+		t.Fatalf("saveScan() error = %v", err)
+	}
+	if got := store.findings[0].ResourceArn; !got.Valid || got.String != arn {
+		t.Errorf("stored ARN = %+v, want %s", got, arn)
+	}
+	if got := store.findings[1].ResourceArn; got.Valid {
+		t.Errorf("stored ARN = %+v, want NULL without an ARN", got)
+	}
+
+	findings, err := r.persistedFindings(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("persistedFindings() error = %v", err)
+	}
+	if findings[0].ARN != arn || findings[1].ARN != "" {
+		t.Errorf("loaded ARNs %q, %q; want %q and none", findings[0].ARN, findings[1].ARN, arn)
+	}
+
+	mapped := mapFindings(findings)
+	if mapped[0].Arn == nil || *mapped[0].Arn != arn || mapped[1].Arn != nil {
+		t.Errorf("mapped ARNs %v, %v; want %s and null", mapped[0].Arn, mapped[1].Arn, arn)
+	}
+}
+
+func TestSaveScan_Connection(t *testing.T) {
+	store := &saveStore{}
+	r := &Resolver{Scans: store}
+
+	result := &scanner.ScanResult{AccountID: "123456789012", ConnectionID: 4}
+	if _, err := r.saveScan(context.Background(), result, ""); err != nil {
+		t.Fatalf("saveScan() error = %v", err)
+	}
+	if got := store.scan.AwsAccountID; got.Int32 != 4 {
+		t.Errorf("saved against connection %d, want the scan's connection 4", got.Int32)
+	}
+
+	for _, result := range []*scanner.ScanResult{
+		{AccountID: "123456789012"},
+		{AccountID: "210987654321", ConnectionID: 3},
+	} {
+		if _, err := r.saveScan(context.Background(), result, ""); err == nil {
+			t.Errorf("saveScan(%s, connection %d) should fail", result.AccountID, result.ConnectionID)
+		}
+	}
+}
+
+// historyStore serves the persisted scans of team 1, newest first, and
+// records the page requested.
+type historyStore struct {
+	ScanStore
+	scans    []database.Scan
+	findings map[int32][]database.ScanFinding
+	params   database.ListScansForTeamParams
+}
+
+func (f *historyStore) GetTeamByOwnerID(_ context.Context, _ string) (database.Team, error) {
+	return database.Team{ID: 1}, nil
+}
+
+func (f *historyStore) ListScansForTeam(_ context.Context, arg database.ListScansForTeamParams) ([]database.Scan, error) {
+	f.params = arg
+	if arg.AccountID != "123456789012" || arg.TeamID.Int32 != 1 {
+		return nil, nil
+	}
+	start := min(int(arg.Offset), len(f.scans))
+	end := min(start+int(arg.Limit), len(f.scans))
+	return f.scans[start:end], nil
+}
+
+func (f *historyStore) GetScanForTeam(_ context.Context, arg database.GetScanForTeamParams) (database.Scan, error) {
+	for _, s := range f.scans {
+		if s.ID == arg.ID && arg.TeamID.Int32 == 1 {
+			return s, nil
+		}
+	}
+	return database.Scan{}, pgx.ErrNoRows
+}
+
+func (f *historyStore) ListScanFindings(_ context.Context, scanID pgtype.Int4) ([]database.ScanFinding, error) {
+	return f.findings[scanID.Int32], nil
+}
+
+func TestScansQuery_Pagination(t *testing.T) {
+	store := &historyStore{scans: []database.Scan{{ID: 5}, {ID: 4}, {ID: 3}, {ID: 2}, {ID: 1}}}
+	r := &queryResolver{&Resolver{Scans: store}}
+	ctx := auth.AttachContext(context.Background(), &clerk.User{ID: "user_1"})
+
+	tests := []struct {
+		name          string
+		limit, offset *int
+		wantIDs       []int32
+		wantLimit     int32
+	}{
+		{"defaults", nil, nil, []int32{5, 4, 3, 2, 1}, defaultScanPageSize},
+		{"first page", ptr(2), nil, []int32{5, 4}, 2},
+		{"second page", ptr(2), ptr(2), []int32{3, 2}, 2},
+		{"past the end", ptr(2), ptr(10), []int32{}, 2},
+		{"limit capped", ptr(1000), nil, []int32{5, 4, 3, 2, 1}, maxScanPageSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scans, err := r.Scans(ctx, "123456789012", tt.limit, tt.offset)
+			if err != nil {
+				t.Fatalf("Scans() error = %v", err)
+			}
+			ids := []int32{}
+			for _, s := range scans {
+				ids = append(ids, s.ID)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("Scans() = %v, want %v", ids, tt.wantIDs)
+			}
+			if store.params.Limit != tt.wantLimit {
+				t.Errorf("requested limit %d, want %d", store.params.Limit, tt.wantLimit)
+			}
+		})
+	}
+
+	if scans, err := r.Scans(ctx, "999999999999", nil, nil); err != nil || scans == nil || len(scans) != 0 {
+		t.Errorf("Scans() for another team's account = %v, %v; want an empty list", scans, err)
+	}
+	if _, err := r.Scans(ctx, "123456789012", ptr(0), nil); err == nil {
+		t.Error("expected an error for a zero limit")
+	}
+	if _, err := r.Scans(ctx, "123456789012", nil, ptr(-1)); err == nil {
+		t.Error("expected an error for a negative offset")
+	}
+	if _, err := r.Scans(context.Background(), "123456789012", nil, nil); err == nil {
+		t.Error("expected an error without an authenticated user")
+	}
+}
+
+func TestScanQuery(t *testing.T) {
+	store := &historyStore{scans: []database.Scan{{ID: 42, Status: "completed"}}}
+	r := &queryResolver{&Resolver{Scans: store}}
+	ctx := auth.AttachContext(context.Background(), &clerk.User{ID: "user_1"})
+
+	scan, err := r.Scan(ctx, "42")
+	if err != nil || scan == nil || scan.ID != 42 {
+		t.Fatalf("Scan(42) = %+v, %v", scan, err)
+	}
+	if _, err := r.Scan(ctx, "7"); err == nil {
+		t.Error("expected an error for a scan outside the user's team")
+	}
+}
+
+func TestScanFindings_Filters(t *testing.T) {
+	store := &historyStore{findings: map[int32][]database.ScanFinding{
+		42: {
+			{Service: "s3", Region: "us-east-1", ResourceID: "logs", CheckID: "s3_bucket_encryption", Status: "FAIL", Severity: "HIGH"},
+			{Service: "s3", Region: "us-east-1", ResourceID: "logs", CheckID: "s3_bucket_versioning", Status: "PASS", Severity: "MEDIUM"},
+			{Service: "iam", Region: "global", ResourceID: "root", CheckID: "iam_root_mfa", Status: "FAIL", Severity: "CRITICAL"},
+			{Service: "iam", Region: "global", ResourceID: "alice", CheckID: "iam_user_mfa", Status: "PASS", Severity: "HIGH"},
+			{Service: "ec2", Region: "us-east-1", ResourceID: "i-1", CheckID: "ec2_public_ip", Status: "ERROR", Severity: "MEDIUM"},
+		},
+	}}
+	r := &scanResolver{&Resolver{Scans: store}}
+	scan := &database.Scan{ID: 42}
+
+	tests := []struct {
+		name                      string
+		onlyFailures              *bool
+		service, severity, status *string
+		want                      []string
+	}{
+		{"failures by default", nil, nil, nil, nil, []string{"s3_bucket_encryption", "iam_root_mfa"}},
+		{"all findings", ptr(false), nil, nil, nil, []string{"s3_bucket_encryption", "s3_bucket_versioning", "iam_root_mfa", "iam_user_mfa", "ec2_public_ip"}},
+		{"service", nil, ptr("iam"), nil, nil, []string{"iam_root_mfa"}},
+		{"severity across statuses", ptr(false), nil, ptr("HIGH"), nil, []string{"s3_bucket_encryption", "iam_user_mfa"}},
+		{"status replaces failure default", nil, nil, nil, ptr("PASS"), []string{"s3_bucket_versioning", "iam_user_mfa"}},
+		{"combined", nil, ptr("s3"), ptr("MEDIUM"), ptr("PASS"), []string{"s3_bucket_versioning"}},
+		{"no match", nil, ptr("kms"), nil, nil, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings, err := r.Findings(context.Background(), scan, tt.onlyFailures, tt.service, tt.severity, tt.status)
+			if err != nil {
+				t.Fatalf("Findings() error = %v", err)
+			}
+			got := []string{}
+			for _, f := range findings {
+				got = append(got, f.CheckID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Findings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestStartScan_TeamScoped(t *testing.T) {
+	svc, err := security.NewService(security.Config{})
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	r := &mutationResolver{&Resolver{Scans: &fakeScanStore{}, Security: svc}}
+
+	if _, err := r.StartScan(context.Background(), "123456789012", []string{"s3"}, nil); err == nil {
+		t.Error("expected an error without an authenticated user")
+	}
+	if _, err := r.StartScan(userContext("user_2"), "123456789012", []string{"s3"}, nil); err == nil {
+		t.Error("expected an error for an account outside the user's team")
+	}
+}
+
+// twoTeamHistoryStore is a historyStore where user_2 owns team 2.
+type twoTeamHistoryStore struct {
+	historyStore
+}
+
+func (f *twoTeamHistoryStore) GetTeamByOwnerID(ctx context.Context, ownerID string) (database.Team, error) {
+	return (&fakeScanStore{}).GetTeamByOwnerID(ctx, ownerID)
+}
+
+func TestScanFindings_CachedResultsTeamScoped(t *testing.T) {
+	r := &scanResolver{&Resolver{Scans: &twoTeamHistoryStore{}}}
+	r.ScanResults.Store(scanResultKey{teamID: 1, scanID: 42}, &scanner.ScanResultWithSummary{
+		ScanResult: &scanner.ScanResult{Findings: []scanner.Finding{
+			{CheckID: "iam_root_mfa", Status: scanner.StatusFail, Severity: scanner.SeverityCritical},
+		}},
+	})
+	scan := &database.Scan{ID: 42}
+
+	findings, err := r.Findings(userContext("user_1"), scan, nil, nil, nil, nil)
+	if err != nil || len(findings) != 1 {
+		t.Errorf("Findings() for the scanning team = %v, %v, want the cached finding", findings, err)
+	}
+	findings, err = r.Findings(userContext("user_2"), scan, nil, nil, nil, nil)
+	if err != nil || len(findings) != 0 {
+		t.Errorf("Findings() for another team = %v, %v, want none", findings, err)
+	}
+}
@@ -0,0 +1,98 @@
+package graph
+
+import (
+	"cloudcop/api/internal/database"
+	"cloudcop/api/internal/scheduler"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// teamAccount loads an account connected to the authenticated user's team.
+// Accounts of other teams are reported as not found.
+func (r *Resolver) teamAccount(ctx context.Context, accountID string) (database.AwsAccount, error) {
+	team, err := r.teamForUser(ctx)
+	if err != nil {
+		return database.AwsAccount{}, err
+	}
+	account, err := r.Scans.GetAccountByTeamAndAccountID(ctx, database.GetAccountByTeamAndAccountIDParams{
+		TeamID:    pgtype.Int4{Int32: team.ID, Valid: true},
+		AccountID: accountID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return database.AwsAccount{}, fmt.Errorf("account %s not found", accountID)
+	}
+	if err != nil {
+		return database.AwsAccount{}, fmt.Errorf("loading account %s: %w", accountID, err)
+	}
+	return account, nil
+}
+
+// createScanSchedule schedules recurring scans of one of the team's accounts,
+// first running at the next time cronExpr fires.
+func (r *Resolver) createScanSchedule(ctx context.Context, accountID, cronExpr string, services, regions []string) (*database.ScanSchedule, error) {
+	if r.Scans == nil || r.Schedules == nil {
+		return nil, fmt.Errorf("schedule store not initialized")
+	}
+	account, err := r.teamAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	params, err := scheduler.NewScheduleParams(account.ID, cronExpr, services, regions, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := r.Schedules.CreateScanSchedule(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("creating schedule for account %s: %w", accountID, err)
+	}
+	return &schedule, nil
+}
+
+// deleteScanSchedule deletes a schedule of one of the team's accounts and
+// reports whether it existed.
+func (r *Resolver) deleteScanSchedule(ctx context.Context, accountID, scheduleID string) (bool, error) {
+	if r.Scans == nil || r.Schedules == nil {
+		return false, fmt.Errorf("schedule store not initialized")
+	}
+	id, err := strconv.ParseInt(scheduleID, 10, 32)
+	if err != nil {
+		return false, fmt.Errorf("invalid schedule ID %q", scheduleID)
+	}
+	account, err := r.teamAccount(ctx, accountID)
+	if err != nil {
+		return false, err
+	}
+	n, err := r.Schedules.DeleteScanSchedule(ctx, database.DeleteScanScheduleParams{
+		ID:           int32(id),
+		AwsAccountID: account.ID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("deleting schedule %s: %w", scheduleID, err)
+	}
+	return n > 0, nil
+}
+
+// scanSchedules lists the schedules of one of the team's accounts.
+func (r *Resolver) scanSchedules(ctx context.Context, accountID string) ([]database.ScanSchedule, error) {
+	if r.Scans == nil || r.Schedules == nil {
+		return nil, fmt.Errorf("schedule store not initialized")
+	}
+	account, err := r.teamAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := r.Schedules.ListScanSchedulesByAccount(ctx, account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("loading schedules for account %s: %w", accountID, err)
+	}
+	if schedules == nil {
+		return []database.ScanSchedule{}, nil
+	}
+	return schedules, nil
+}
@@ -0,0 +1,127 @@
+package graph
+
+import (
+	"cloudcop/api/internal/database"
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// scheduleStore keeps schedules in memory. user_1 owns team 1, which account
+// 123456789012 (row 3) is connected to; user_2 owns team 2.
+type scheduleStore struct {
+	ScanStore
+	schedules []database.ScanSchedule
+}
+
+func (f *scheduleStore) GetTeamByOwnerID(_ context.Context, ownerID string) (database.Team, error) {
+	if ownerID == "user_2" {
+		return database.Team{ID: 2}, nil
+	}
+	return database.Team{ID: 1}, nil
+}
+
+func (f *scheduleStore) GetAccountByTeamAndAccountID(_ context.Context, arg database.GetAccountByTeamAndAccountIDParams) (database.AwsAccount, error) {
+	if arg.TeamID.Int32 != 1 || arg.AccountID != "123456789012" {
+		return database.AwsAccount{}, pgx.ErrNoRows
+	}
+	return database.AwsAccount{ID: 3, TeamID: arg.TeamID, AccountID: arg.AccountID}, nil
+}
+
+func (f *scheduleStore) CreateScanSchedule(_ context.Context, arg database.CreateScanScheduleParams) (database.ScanSchedule, error) {
+	s := database.ScanSchedule{
+		ID:           int32(len(f.schedules) + 1),
+		AwsAccountID: arg.AwsAccountID,
+		CronExpr:     arg.CronExpr,
+		Services:     arg.Services,
+		Regions:      arg.Regions,
+		Enabled:      true,
+		NextRunAt:    arg.NextRunAt,
+	}
+	f.schedules = append(f.schedules, s)
+	return s, nil
+}
+
+func (f *scheduleStore) ListScanSchedulesByAccount(_ context.Context, awsAccountID int32) ([]database.ScanSchedule, error) {
+	var out []database.ScanSchedule
+	for _, s := range f.schedules {
+		if s.AwsAccountID == awsAccountID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (f *scheduleStore) DeleteScanSchedule(_ context.Context, arg database.DeleteScanScheduleParams) (int64, error) {
+	before := len(f.schedules)
+	f.schedules = slices.DeleteFunc(f.schedules, func(s database.ScanSchedule) bool {
+		return s.ID == arg.ID && s.AwsAccountID == arg.AwsAccountID
+	})
+	return int64(before - len(f.schedules)), nil
+}
+
+func TestScanSchedules_Lifecycle(t *testing.T) {
+	store := &scheduleStore{}
+	resolver := &Resolver{Scans: store, Schedules: store}
+	ctx := userContext("user_1")
+
+	created, err := resolver.Mutation().CreateScanSchedule(ctx, "123456789012", "0 2 * * *", []string{"s3"}, nil)
+	if err != nil {
+		t.Fatalf("CreateScanSchedule() error = %v", err)
+	}
+	if created.AwsAccountID != 3 || created.CronExpr != "0 2 * * *" || !slices.Equal(created.Services, []string{"s3"}) {
+		t.Errorf("CreateScanSchedule() = %+v, want a daily s3 schedule for account row 3", created)
+	}
+	next, _ := resolver.ScanSchedule().NextRunAt(ctx, created)
+	if next == nil || next.Hour() != 2 || next.Minute() != 0 || !next.After(time.Now().Add(-time.Minute)) {
+		t.Errorf("NextRunAt = %v, want the next 02:00 UTC", next)
+	}
+
+	schedules, err := resolver.Query().ScanSchedules(ctx, "123456789012")
+	if err != nil || len(schedules) != 1 {
+		t.Fatalf("ScanSchedules() = %v, %v; want the created schedule", schedules, err)
+	}
+	id, _ := resolver.ScanSchedule().ID(ctx, created)
+
+	deleted, err := resolver.Mutation().DeleteScanSchedule(ctx, "123456789012", id)
+	if err != nil || !deleted {
+		t.Errorf("DeleteScanSchedule() = %v, %v; want true", deleted, err)
+	}
+	deleted, err = resolver.Mutation().DeleteScanSchedule(ctx, "123456789012", id)
+	if err != nil || deleted {
+		t.Errorf("second DeleteScanSchedule() = %v, %v; want false", deleted, err)
+	}
+}
+
+func TestScanSchedules_Validation(t *testing.T) {
+	store := &scheduleStore{}
+	resolver := &Resolver{Scans: store, Schedules: store}
+
+	if _, err := resolver.Mutation().CreateScanSchedule(context.Background(), "123456789012", "@daily", nil, nil); err == nil {
+		t.Error("expected an error without an authenticated user")
+	}
+	if _, err := resolver.Mutation().CreateScanSchedule(userContext("user_1"), "123456789012", "61 * * * *", nil, nil); err == nil {
+		t.Error("expected an error for an invalid cron expression")
+	}
+	if _, err := resolver.Mutation().CreateScanSchedule(userContext("user_2"), "123456789012", "@daily", nil, nil); err == nil {
+		t.Error("expected an error for an account outside the user's team")
+	}
+	if len(store.schedules) != 0 {
+		t.Errorf("stored %d schedules, want none", len(store.schedules))
+	}
+
+	store.schedules = []database.ScanSchedule{{ID: 1, AwsAccountID: 3, NextRunAt: pgtype.Timestamp{Time: time.Now(), Valid: true}}}
+	if _, err := resolver.Mutation().DeleteScanSchedule(userContext("user_2"), "123456789012", "1"); err == nil {
+		t.Error("expected an error deleting another team's schedule")
+	}
+	if _, err := resolver.Query().ScanSchedules(userContext("user_2"), "123456789012"); err == nil {
+		t.Error("expected an error listing another team's schedules")
+	}
+	if len(store.schedules) != 1 {
+		t.Error("another team deleted the schedule")
+	}
+}
@@ -13,11 +13,22 @@ import (
 	"cloudcop/api/internal/scanner"
 	"context"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+// ExpiresAt is the resolver for the expiresAt field.
+func (r *findingSuppressionResolver) ExpiresAt(ctx context.Context, obj *database.FindingSuppression) (*time.Time, error) {
+	return optionalTime(obj.ExpiresAt), nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *findingSuppressionResolver) CreatedAt(ctx context.Context, obj *database.FindingSuppression) (*time.Time, error) {
+	return optionalTime(obj.CreatedAt), nil
+}
+
 // VerifyAWSAccount is the resolver for the verifyAwsAccount field.
 func (r *mutationResolver) VerifyAWSAccount(ctx context.Context, accountID string, externalID string) (*model.AWSAccount, error) {
 	// Verify access via STS AssumeRole
@@ -77,8 +88,12 @@ func (r *mutationResolver) ConnectAccount(ctx context.Context, accountID string,
 
 // StartScan is the resolver for the startScan field.
 func (r *mutationResolver) StartScan(ctx context.Context, accountID string, services []string, regions []string) (*database.Scan, error) {
-	// For E2E tests, we might bypass auth or assume it's set.
-	// if auth.FromContext(ctx) == nil { return nil, fmt.Errorf("unauthorized") }
+	if auth.FromContext(ctx) == nil {
+		return nil, fmt.Errorf("unauthorized")
+	}
+	if r.Scans == nil {
+		return nil, fmt.Errorf("scan store not initialized")
+	}
 
 	// Run Scan Synchronously for Demo
 	// Note: In production, this should be async via Kestra
@@ -86,10 +101,17 @@ func (r *mutationResolver) StartScan(ctx context.Context, accountID string, serv
 		return nil, fmt.Errorf("security service not initialized")
 	}
 
-	result, err := r.Security.Scan(ctx, scanner.ScanConfig{
-		AccountID: accountID,
-		Regions:   regions,
-		Services:  services,
+	// Only accounts connected to the caller's team can be scanned, and the
+	// scan runs with that connection's role and suppressions.
+	account, err := r.teamAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := r.RunScan(ctx, scanner.ScanConfig{
+		AccountID:    accountID,
+		ConnectionID: account.ID,
+		Regions:      regions,
+		Services:     services,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("scan failed: %w", err)
@@ -98,8 +120,17 @@ func (r *mutationResolver) StartScan(ctx context.Context, accountID string, serv
 	// Generate ID
 	scanID := int32(time.Now().Unix())
 
+	// Persist the scan, keeping the ephemeral result available if saving
+	// fails.
+	saved, err := r.saveScan(ctx, result.ScanResult, r.Security.PersistMinSeverity())
+	if err != nil {
+		log.Printf("Warning: could not persist scan: %v", err)
+	} else {
+		scanID = saved.ID
+	}
+
 	// Store result in ephemeral cache
-	r.ScanResults.Store(fmt.Sprintf("%d", scanID), result)
+	r.ScanResults.Store(scanResultKey{teamID: account.TeamID.Int32, scanID: scanID}, result)
 
 	// Return DB model stub
 	now := time.Now()
@@ -109,11 +140,12 @@ func (r *mutationResolver) StartScan(ctx context.Context, accountID string, serv
 	}
 
 	return &database.Scan{
-		ID:        scanID,
-		Status:    "completed",
-		Services:  services,
-		Regions:   regions,
-		CreatedAt: pgtype.Timestamp{Time: now, Valid: true},
+		ID:           scanID,
+		AwsAccountID: pgtype.Int4{Int32: account.ID, Valid: true},
+		Status:       "completed",
+		Services:     services,
+		Regions:      regions,
+		CreatedAt:    pgtype.Timestamp{Time: now, Valid: true},
 		OverallScore: pgtype.Int4{
 			Int32: score,
 			Valid: result.Summary != nil,
@@ -121,6 +153,46 @@ func (r *mutationResolver) StartScan(ctx context.Context, accountID string, serv
 	}, nil
 }
 
+// SummarizeScan is the resolver for the summarizeScan field.
+func (r *mutationResolver) SummarizeScan(ctx context.Context, scanID string) (*model.ScanSummary, error) {
+	if r.Scans == nil {
+		return nil, fmt.Errorf("scan store not initialized")
+	}
+	if r.Summarizer == nil {
+		return nil, fmt.Errorf("summarization service not initialized")
+	}
+
+	scan, err := r.scanForUser(ctx, scanID)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := r.summarizeScan(ctx, scan)
+	if err != nil {
+		return nil, err
+	}
+	return mapScanSummary(summary), nil
+}
+
+// SuppressFinding is the resolver for the suppressFinding field.
+func (r *mutationResolver) SuppressFinding(ctx context.Context, findingID string, reason string, expiresAt *time.Time) (*database.FindingSuppression, error) {
+	return r.suppressFinding(ctx, findingID, reason, expiresAt)
+}
+
+// UnsuppressFinding is the resolver for the unsuppressFinding field.
+func (r *mutationResolver) UnsuppressFinding(ctx context.Context, findingID string) (bool, error) {
+	return r.unsuppressFinding(ctx, findingID)
+}
+
+// CreateScanSchedule is the resolver for the createScanSchedule field.
+func (r *mutationResolver) CreateScanSchedule(ctx context.Context, accountID string, cronExpr string, services []string, regions []string) (*database.ScanSchedule, error) {
+	return r.createScanSchedule(ctx, accountID, cronExpr, services, regions)
+}
+
+// DeleteScanSchedule is the resolver for the deleteScanSchedule field.
+func (r *mutationResolver) DeleteScanSchedule(ctx context.Context, accountID string, id string) (bool, error) {
+	return r.deleteScanSchedule(ctx, accountID, id)
+}
+
 // Me is the resolver for the me field.
 func (r *queryResolver) Me(ctx context.Context) (*database.User, error) {
 	user := auth.FromContext(ctx)
@@ -166,6 +238,39 @@ func (r *queryResolver) MyAccounts(ctx context.Context) ([]model.AWSAccount, err
 	return []model.AWSAccount{}, nil
 }
 
+// SecurityScore is the resolver for the securityScore field.
+func (r *queryResolver) SecurityScore(ctx context.Context, accountID string) (*model.SecurityScore, error) {
+	if r.Resolver.Scans == nil {
+		return nil, fmt.Errorf("scan store not initialized")
+	}
+	return r.securityScore(ctx, accountID)
+}
+
+// Scans is the resolver for the scans field.
+func (r *queryResolver) Scans(ctx context.Context, accountID string, limit *int, offset *int) ([]database.Scan, error) {
+	if r.Resolver.Scans == nil {
+		return nil, fmt.Errorf("scan store not initialized")
+	}
+	return r.listScans(ctx, accountID, limit, offset)
+}
+
+// Scan is the resolver for the scan field.
+func (r *queryResolver) Scan(ctx context.Context, id string) (*database.Scan, error) {
+	if r.Resolver.Scans == nil {
+		return nil, fmt.Errorf("scan store not initialized")
+	}
+	scan, err := r.scanForUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &scan, nil
+}
+
+// ScanSchedules is the resolver for the scanSchedules field.
+func (r *queryResolver) ScanSchedules(ctx context.Context, accountID string) ([]database.ScanSchedule, error) {
+	return r.scanSchedules(ctx, accountID)
+}
+
 // ID is the resolver for the id field.
 func (r *scanResolver) ID(ctx context.Context, obj *database.Scan) (string, error) {
 	_ = ctx
@@ -183,66 +288,52 @@ func (r *scanResolver) OverallScore(ctx context.Context, obj *database.Scan) (*i
 }
 
 // Findings is the resolver for the findings field.
-func (r *scanResolver) Findings(ctx context.Context, obj *database.Scan) ([]model.Finding, error) {
-	_ = ctx
-	_ = obj
-	return []model.Finding{}, nil
-}
+func (r *scanResolver) Findings(ctx context.Context, obj *database.Scan, onlyFailures *bool, service *string, severity *string, status *string) ([]model.Finding, error) {
+	filter := scanner.FindingFilter{
+		OnlyFailures: (onlyFailures == nil || *onlyFailures) && status == nil,
+		Service:      deref(service),
+		Severity:     scanner.Severity(deref(severity)),
+		Status:       scanner.FindingStatus(deref(status)),
+	}
 
-// Summary is the resolver for the summary field.
-func (r *scanResolver) Summary(ctx context.Context, obj *database.Scan) (*model.ScanSummary, error) {
-	_ = ctx
-	id := fmt.Sprintf("%d", obj.ID)
-	val, ok := r.ScanResults.Load(id)
-	if !ok {
-		return nil, nil
+	if result, ok := r.cachedScanResult(ctx, obj.ID); ok {
+		return mapFindings(filter.Apply(result.Findings)), nil
 	}
-	result := val.(*scanner.ScanResultWithSummary)
-	if result.Summary == nil {
-		return nil, nil
+	if r.Scans == nil {
+		return []model.Finding{}, nil
 	}
 
-	return mapScanSummary(result.Summary), nil
+	findings, err := r.persistedFindings(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	return mapFindings(filter.Apply(findings)), nil
 }
 
-func mapScanSummary(s *scanner.ScanSummary) *model.ScanSummary {
-	if s == nil {
-		return nil
+// Summary is the resolver for the summary field.
+func (r *scanResolver) Summary(ctx context.Context, obj *database.Scan) (*model.ScanSummary, error) {
+	if result, ok := r.cachedScanResult(ctx, obj.ID); ok && result.Summary != nil {
+		return mapScanSummary(result.Summary), nil
 	}
-	groups := make([]model.FindingGroupSummary, len(s.Groups))
-	for i, g := range s.Groups {
-		groups[i] = model.FindingGroupSummary{
-			GroupID:      g.GroupID,
-			Title:        g.Title,
-			Service:      g.Service,
-			CheckID:      g.CheckID,
-			Severity:     g.Severity,
-			FindingCount: g.FindingCount,
-			ResourceIds:  g.ResourceIDs,
-			Summary:      g.Summary,
-			Remedy:       g.Remedy,
-		}
+	if r.Scans == nil {
+		return nil, nil
 	}
 
-	actions := make([]model.ActionItemSummary, len(s.Actions))
-	for i, a := range s.Actions {
-		actions[i] = model.ActionItemSummary{
-			ActionID:    a.ActionID,
-			Title:       a.Title,
-			Description: a.Description,
-			Severity:    a.Severity,
-			Commands:    a.Commands,
-			GroupID:     a.GroupID,
-		}
+	// Summaries generated after the scan are only persisted.
+	summary, err := r.persistedSummary(ctx, obj.ID)
+	if err != nil {
+		return nil, err
 	}
+	return mapScanSummary(summary), nil
+}
 
-	return &model.ScanSummary{
-		RiskLevel:   s.RiskLevel,
-		RiskScore:   s.RiskScore,
-		SummaryText: s.SummaryText,
-		Groups:      groups,
-		Actions:     actions,
+// Errors is the resolver for the errors field.
+func (r *scanResolver) Errors(ctx context.Context, obj *database.Scan) ([]model.ScanError, error) {
+	result, ok := r.cachedScanResult(ctx, obj.ID)
+	if !ok {
+		return nil, nil
 	}
+	return mapScanErrors(result.Errors), nil
 }
 
 // StartedAt is the resolver for the startedAt field.
@@ -274,6 +365,26 @@ func (r *scanResolver) CreatedAt(ctx context.Context, obj *database.Scan) (strin
 	return "", nil
 }
 
+// ID is the resolver for the id field.
+func (r *scanScheduleResolver) ID(ctx context.Context, obj *database.ScanSchedule) (string, error) {
+	return fmt.Sprintf("%d", obj.ID), nil
+}
+
+// NextRunAt is the resolver for the nextRunAt field.
+func (r *scanScheduleResolver) NextRunAt(ctx context.Context, obj *database.ScanSchedule) (*time.Time, error) {
+	return optionalTime(obj.NextRunAt), nil
+}
+
+// LastRunAt is the resolver for the lastRunAt field.
+func (r *scanScheduleResolver) LastRunAt(ctx context.Context, obj *database.ScanSchedule) (*time.Time, error) {
+	return optionalTime(obj.LastRunAt), nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *scanScheduleResolver) CreatedAt(ctx context.Context, obj *database.ScanSchedule) (*time.Time, error) {
+	return optionalTime(obj.CreatedAt), nil
+}
+
 // ID is the resolver for the id field.
 func (r *teamResolver) ID(ctx context.Context, obj *database.Team) (string, error) {
 	_ = ctx
@@ -317,6 +428,11 @@ func (r *userResolver) Teams(ctx context.Context, obj *database.User) ([]databas
 	return []database.Team{}, nil
 }
 
+// FindingSuppression returns FindingSuppressionResolver implementation.
+func (r *Resolver) FindingSuppression() FindingSuppressionResolver {
+	return &findingSuppressionResolver{r}
+}
+
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
@@ -326,6 +442,9 @@ func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 // Scan returns ScanResolver implementation.
 func (r *Resolver) Scan() ScanResolver { return &scanResolver{r} }
 
+// ScanSchedule returns ScanScheduleResolver implementation.
+func (r *Resolver) ScanSchedule() ScanScheduleResolver { return &scanScheduleResolver{r} }
+
 // Team returns TeamResolver implementation.
 func (r *Resolver) Team() TeamResolver { return &teamResolver{r} }
 
@@ -335,9 +454,11 @@ func (r *Resolver) TeamMember() TeamMemberResolver { return &teamMemberResolver{
 // User returns UserResolver implementation.
 func (r *Resolver) User() UserResolver { return &userResolver{r} }
 
+type findingSuppressionResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
 type scanResolver struct{ *Resolver }
+type scanScheduleResolver struct{ *Resolver }
 type teamResolver struct{ *Resolver }
 type teamMemberResolver struct{ *Resolver }
 type userResolver struct{ *Resolver }
@@ -0,0 +1,109 @@
+package graph
+
+import (
+	"cloudcop/api/internal/database"
+	"cloudcop/api/internal/middleware/auth"
+	"cloudcop/api/internal/scanner"
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// RunScan runs a scan through the security service, dropping the findings that
+// the team of the scanned connection has suppressed.
+func (r *Resolver) RunScan(ctx context.Context, config scanner.ScanConfig) (*scanner.ScanResultWithSummary, error) {
+	if r.Security == nil {
+		return nil, fmt.Errorf("security service not initialized")
+	}
+	suppressed, err := r.suppressedFindings(ctx, config.ConnectionID, time.Now())
+	if err != nil {
+		// Scanning without suppressions only reports more, so it is safe.
+		log.Printf("Warning: could not load suppressions for account %s: %v", config.AccountID, err)
+	}
+	config.SuppressedFindings = append(slices.Clone(config.SuppressedFindings), suppressed...)
+	return r.Security.Scan(ctx, config)
+}
+
+// suppressedFindings returns the finding IDs suppressed at now by the team
+// that owns the connection. Expired suppressions are ignored, and scans
+// outside a connection have none.
+func (r *Resolver) suppressedFindings(ctx context.Context, connectionID int32, now time.Time) ([]string, error) {
+	if r.Scans == nil || r.Suppressions == nil || connectionID == 0 {
+		return nil, nil
+	}
+	account, err := r.Scans.GetAccountByID(ctx, connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("looking up connection %d: %w", connectionID, err)
+	}
+	suppressions, err := r.Suppressions.ListFindingSuppressionsByTeam(ctx, account.TeamID.Int32)
+	if err != nil {
+		return nil, fmt.Errorf("loading suppressions: %w", err)
+	}
+
+	var ids []string
+	for _, s := range suppressions {
+		if s.ExpiresAt.Valid && !s.ExpiresAt.Time.After(now) {
+			continue
+		}
+		ids = append(ids, s.FindingID)
+	}
+	return ids, nil
+}
+
+// suppressFinding suppresses a finding for the authenticated user's team,
+// replacing the reason and expiry of an existing suppression.
+func (r *Resolver) suppressFinding(ctx context.Context, findingID, reason string, expiresAt *time.Time) (*database.FindingSuppression, error) {
+	if r.Scans == nil || r.Suppressions == nil {
+		return nil, fmt.Errorf("suppression store not initialized")
+	}
+	if strings.TrimSpace(findingID) == "" {
+		return nil, fmt.Errorf("findingId is required")
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, fmt.Errorf("reason is required")
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("expiresAt must be in the future")
+	}
+
+	team, err := r.teamForUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	suppression, err := r.Suppressions.UpsertFindingSuppression(ctx, database.UpsertFindingSuppressionParams{
+		TeamID:    team.ID,
+		FindingID: findingID,
+		Reason:    strings.TrimSpace(reason),
+		ExpiresAt: optionalTimestamp(expiresAt),
+		CreatedBy: pgtype.Text{String: auth.FromContext(ctx).ID, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("suppressing finding %s: %w", findingID, err)
+	}
+	return &suppression, nil
+}
+
+// unsuppressFinding removes the team's suppression of a finding and reports
+// whether there was one.
+func (r *Resolver) unsuppressFinding(ctx context.Context, findingID string) (bool, error) {
+	if r.Scans == nil || r.Suppressions == nil {
+		return false, fmt.Errorf("suppression store not initialized")
+	}
+	team, err := r.teamForUser(ctx)
+	if err != nil {
+		return false, err
+	}
+	n, err := r.Suppressions.DeleteFindingSuppression(ctx, database.DeleteFindingSuppressionParams{
+		TeamID:    team.ID,
+		FindingID: findingID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("unsuppressing finding %s: %w", findingID, err)
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,168 @@
+package graph
+
+import (
+	"cloudcop/api/internal/database"
+	"cloudcop/api/internal/middleware/auth"
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/clerkinc/clerk-sdk-go/clerk"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// suppressionStore keeps suppressions in memory. The authenticated user owns
+// team 1, which connects account 123456789012 as connection 3. Team 2
+// connects the same account as connection 4.
+type suppressionStore struct {
+	ScanStore
+	suppressions []database.FindingSuppression
+}
+
+func (f *suppressionStore) GetTeamByOwnerID(_ context.Context, _ string) (database.Team, error) {
+	return database.Team{ID: 1}, nil
+}
+
+func (f *suppressionStore) GetAccountByID(_ context.Context, id int32) (database.AwsAccount, error) {
+	return database.AwsAccount{ID: id, AccountID: "123456789012", TeamID: pgtype.Int4{Int32: id - 2, Valid: true}}, nil
+}
+
+func (f *suppressionStore) UpsertFindingSuppression(_ context.Context, arg database.UpsertFindingSuppressionParams) (database.FindingSuppression, error) {
+	s := database.FindingSuppression{
+		ID:        int32(len(f.suppressions) + 1),
+		TeamID:    arg.TeamID,
+		FindingID: arg.FindingID,
+		Reason:    arg.Reason,
+		ExpiresAt: arg.ExpiresAt,
+		CreatedBy: arg.CreatedBy,
+	}
+	for i, existing := range f.suppressions {
+		if existing.TeamID == arg.TeamID && existing.FindingID == arg.FindingID {
+			s.ID = existing.ID
+			f.suppressions[i] = s
+			return s, nil
+		}
+	}
+	f.suppressions = append(f.suppressions, s)
+	return s, nil
+}
+
+func (f *suppressionStore) DeleteFindingSuppression(_ context.Context, arg database.DeleteFindingSuppressionParams) (int64, error) {
+	before := len(f.suppressions)
+	f.suppressions = slices.DeleteFunc(f.suppressions, func(s database.FindingSuppression) bool {
+		return s.TeamID == arg.TeamID && s.FindingID == arg.FindingID
+	})
+	return int64(before - len(f.suppressions)), nil
+}
+
+func (f *suppressionStore) ListFindingSuppressionsByTeam(_ context.Context, teamID int32) ([]database.FindingSuppression, error) {
+	var out []database.FindingSuppression
+	for _, s := range f.suppressions {
+		if s.TeamID == teamID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func TestSuppressFinding_CreateAndDelete(t *testing.T) {
+	store := &suppressionStore{}
+	r := &mutationResolver{&Resolver{Scans: store, Suppressions: store}}
+	ctx := auth.AttachContext(context.Background(), &clerk.User{ID: "user_1"})
+	expiresAt := time.Now().Add(30 * 24 * time.Hour)
+
+	got, err := r.SuppressFinding(ctx, "finding-1", "  Accepted until migration  ", &expiresAt)
+	if err != nil {
+		t.Fatalf("SuppressFinding() error = %v", err)
+	}
+	if got.TeamID != 1 || got.FindingID != "finding-1" || got.Reason != "Accepted until migration" {
+		t.Errorf("SuppressFinding() = %+v, want finding-1 on team 1 with the trimmed reason", got)
+	}
+	if got.CreatedBy.String != "user_1" || !got.ExpiresAt.Valid || !got.ExpiresAt.Time.Equal(expiresAt.UTC()) {
+		t.Errorf("SuppressFinding() stored by %q until %v, want user_1 until %v", got.CreatedBy.String, got.ExpiresAt, expiresAt)
+	}
+
+	// Suppressing again replaces the reason and clears the expiry.
+	if _, err := r.SuppressFinding(ctx, "finding-1", "Risk accepted", nil); err != nil {
+		t.Fatalf("second SuppressFinding() error = %v", err)
+	}
+	if len(store.suppressions) != 1 || store.suppressions[0].Reason != "Risk accepted" || store.suppressions[0].ExpiresAt.Valid {
+		t.Errorf("suppressions = %+v, want one without expiry", store.suppressions)
+	}
+
+	removed, err := r.UnsuppressFinding(ctx, "finding-1")
+	if err != nil || !removed {
+		t.Fatalf("UnsuppressFinding() = %v, %v, want true", removed, err)
+	}
+	if len(store.suppressions) != 0 {
+		t.Errorf("suppressions = %+v, want none", store.suppressions)
+	}
+	removed, err = r.UnsuppressFinding(ctx, "finding-1")
+	if err != nil || removed {
+		t.Errorf("second UnsuppressFinding() = %v, %v, want false", removed, err)
+	}
+}
+
+func TestSuppressFinding_Invalid(t *testing.T) {
+	store := &suppressionStore{}
+	r := &mutationResolver{&Resolver{Scans: store, Suppressions: store}}
+	ctx := auth.AttachContext(context.Background(), &clerk.User{ID: "user_1"})
+	past := time.Now().Add(-time.Hour)
+
+	if _, err := r.SuppressFinding(ctx, "finding-1", " ", nil); err == nil {
+		t.Error("SuppressFinding() without a reason should fail")
+	}
+	if _, err := r.SuppressFinding(ctx, "finding-1", "Accepted", &past); err == nil {
+		t.Error("SuppressFinding() expiring in the past should fail")
+	}
+	if _, err := r.SuppressFinding(context.Background(), "finding-1", "Accepted", nil); err == nil {
+		t.Error("SuppressFinding() without a user should fail")
+	}
+	if _, err := r.UnsuppressFinding(context.Background(), "finding-1"); err == nil {
+		t.Error("UnsuppressFinding() without a user should fail")
+	}
+	if len(store.suppressions) != 0 {
+		t.Errorf("suppressions = %+v, want none", store.suppressions)
+	}
+}
+
+func TestSuppressedFindings_IgnoresExpired(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	store := &suppressionStore{suppressions: []database.FindingSuppression{
+		{TeamID: 1, FindingID: "forever"},
+		{TeamID: 1, FindingID: "until-next-week", ExpiresAt: pgtype.Timestamp{Time: now.Add(7 * 24 * time.Hour), Valid: true}},
+		{TeamID: 1, FindingID: "expired", ExpiresAt: pgtype.Timestamp{Time: now.Add(-time.Minute), Valid: true}},
+		{TeamID: 2, FindingID: "other-team"},
+	}}
+	r := &Resolver{Scans: store, Suppressions: store}
+
+	got, err := r.suppressedFindings(context.Background(), 3, now)
+	if err != nil {
+		t.Fatalf("suppressedFindings() error = %v", err)
+	}
+	if want := []string{"forever", "until-next-week"}; !slices.Equal(got, want) {
+		t.Errorf("suppressedFindings() = %v, want %v", got, want)
+	}
+}
+
+func TestSuppressedFindings_UsesConnectionTeam(t *testing.T) {
+	store := &suppressionStore{suppressions: []database.FindingSuppression{
+		{TeamID: 1, FindingID: "team-1"},
+		{TeamID: 2, FindingID: "team-2"},
+	}}
+	r := &Resolver{Scans: store, Suppressions: store}
+
+	// Both connections are for the same AWS account, so only the connection
+	// tells the teams apart.
+	got, err := r.suppressedFindings(context.Background(), 4, time.Now())
+	if err != nil {
+		t.Fatalf("suppressedFindings() error = %v", err)
+	}
+	if want := []string{"team-2"}; !slices.Equal(got, want) {
+		t.Errorf("suppressedFindings() = %v, want %v", got, want)
+	}
+	if got, _ := r.suppressedFindings(context.Background(), 0, time.Now()); len(got) != 0 {
+		t.Errorf("suppressedFindings() without a connection = %v, want none", got)
+	}
+}
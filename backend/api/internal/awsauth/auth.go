@@ -24,7 +24,9 @@ It handles both production mode (using STS AssumeRole) and self-hosted mode
 */
 type AWSAuth struct {
 	cfg             aws.Config
-	stsClient       *sts.Client
+	stsClient       stsAPI
+	stsOptions      STSOptions
+	identities      identityCache
 	selfHosting     bool
 	endpointURL     string
 	roleName        string
@@ -33,6 +35,7 @@ type AWSAuth struct {
 
 // NewAWSAuth creates and returns a configured AWSAuth based on environment.
 // It detects self-hosting when SELF_HOSTING == "1", supports an AWS_ENDPOINT_URL override,
+// uses regional STS endpoints unless AWS_STS_REGIONAL_ENDPOINTS is "legacy" (AWS_STS_REGION overrides the STS region),
 // and in self-hosted mode requires AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY; otherwise it uses
 // the default AWS credential chain.
 // It returns the initialized *AWSAuth or an error if configuration fails or required environment variables are missing.
@@ -112,9 +115,16 @@ func NewAWSAuth() (*AWSAuth, error) {
 		cfg.BaseEndpoint = aws.String(endpointURL)
 	}
 
+	/*
+		Use the regional STS endpoint unless configured otherwise, so role
+		assumption stays in-region and does not depend on the global endpoint.
+	*/
+	stsOptions := stsOptionsFromEnv(region)
+
 	return &AWSAuth{
 		cfg:             cfg,
-		stsClient:       sts.NewFromConfig(cfg),
+		stsClient:       newSTSClient(cfg, stsOptions),
+		stsOptions:      stsOptions,
 		selfHosting:     selfHosting,
 		endpointURL:     endpointURL,
 		roleName:        roleName,
@@ -137,9 +147,15 @@ func (a *AWSAuth) AssumeRole(ctx context.Context, input AssumeRoleInput) (*Crede
 
 	/*
 		Construct the IAM role ARN to assume.
-		The role name is configurable via AWS_ROLE_NAME environment variable.
+		The role name is configurable via AWS_ROLE_NAME environment variable,
+		and the partition follows the configured region unless given explicitly,
+		since GovCloud and China roles use their own ARN prefixes.
 	*/
-	roleARN := fmt.Sprintf("arn:aws:iam::%s:role/%s", input.AccountID, a.roleName)
+	partition := input.Partition
+	if partition == "" {
+		partition = PartitionForRegion(a.cfg.Region)
+	}
+	roleARN := RoleARN(partition, input.AccountID, a.roleName)
 	sessionName := fmt.Sprintf("CloudCopSession-%d", time.Now().Unix())
 
 	result, err := a.stsClient.AssumeRole(ctx, &sts.AssumeRoleInput{
@@ -169,9 +185,22 @@ func (a *AWSAuth) AssumeRole(ctx context.Context, input AssumeRoleInput) (*Crede
 VerifyAccountAccess verifies that we can access the specified AWS account.
 In production mode, it assumes the role and gets caller identity.
 In self-hosted mode, it uses direct credentials to get caller identity.
+Successful results are reused for a short TTL per account and external ID.
 */
 func (a *AWSAuth) VerifyAccountAccess(ctx context.Context, input AssumeRoleInput) (*AccountInfo, error) {
-	var stsClient *sts.Client
+	if info, ok := a.identities.get(a.identityKey(input)); ok {
+		return info, nil
+	}
+	return a.RecheckAccountAccess(ctx, input)
+}
+
+// RecheckAccountAccess verifies access like VerifyAccountAccess but always
+// calls STS, for health checks that must not report a memoized success. A
+// successful result replaces the cached one; a failure drops it.
+func (a *AWSAuth) RecheckAccountAccess(ctx context.Context, input AssumeRoleInput) (*AccountInfo, error) {
+	key := a.identityKey(input)
+
+	var stsClient stsAPI
 
 	if a.selfHosting {
 		/*
@@ -184,6 +213,7 @@ func (a *AWSAuth) VerifyAccountAccess(ctx context.Context, input AssumeRoleInput
 		*/
 		creds, err := a.AssumeRole(ctx, input)
 		if err != nil {
+			a.identities.invalidate(key)
 			return nil, err
 		}
 
@@ -197,7 +227,7 @@ func (a *AWSAuth) VerifyAccountAccess(ctx context.Context, input AssumeRoleInput
 			creds.SessionToken,
 		)
 
-		stsClient = sts.NewFromConfig(cfg)
+		stsClient = newSTSClient(cfg, a.stsOptions)
 	}
 
 	/*
@@ -206,25 +236,54 @@ func (a *AWSAuth) VerifyAccountAccess(ctx context.Context, input AssumeRoleInput
 	*/
 	identity, err := stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
 	if err != nil {
+		a.identities.invalidate(key)
 		return nil, fmt.Errorf("failed to verify account access: %w", err)
 	}
 
-	return &AccountInfo{
-		AccountID: aws.ToString(identity.Account),
-		ARN:       aws.ToString(identity.Arn),
-		UserID:    aws.ToString(identity.UserId),
-	}, nil
+	info := accountInfo(identity)
+	a.identities.set(key, info)
+	return info, nil
+}
+
+// identityKey returns the identity cache key of input. Self-hosted
+// deployments verify their own credentials whatever the input.
+func (a *AWSAuth) identityKey(input AssumeRoleInput) string {
+	if a.selfHosting {
+		return selfIdentityKey
+	}
+	return cacheKey(input.AccountID, input.ExternalID)
+}
+
+// Config returns a copy of the AWS configuration the server runs with: its
+// region, endpoint override, and own credentials.
+func (a *AWSAuth) Config() aws.Config {
+	return a.cfg.Copy()
 }
 
 /*
 GetAccountID retrieves the AWS account ID using current credentials.
 This is primarily used in self-hosted mode during initial setup.
+The identity is memoized for a short TTL.
 */
 func (a *AWSAuth) GetAccountID(ctx context.Context) (string, error) {
+	if info, ok := a.identities.get(selfIdentityKey); ok {
+		return info.AccountID, nil
+	}
+
 	identity, err := a.stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
 	if err != nil {
 		return "", fmt.Errorf("failed to get account ID: %w", err)
 	}
 
-	return aws.ToString(identity.Account), nil
+	info := accountInfo(identity)
+	a.identities.set(selfIdentityKey, info)
+	return info.AccountID, nil
+}
+
+func accountInfo(identity *sts.GetCallerIdentityOutput) *AccountInfo {
+	return &AccountInfo{
+		AccountID: aws.ToString(identity.Account),
+		ARN:       aws.ToString(identity.Arn),
+		UserID:    aws.ToString(identity.UserId),
+	}
 }
@@ -2,26 +2,43 @@ package awsauth
 
 import (
 	"context"
+	"log"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
 )
 
 const (
 	// Refresh credentials 5 minutes before expiration
 	refreshBuffer = 5 * time.Minute
+	// invalidatedTTL is how long an invalidation is remembered in the store,
+	// long enough for the refresh loop of every replica to see it.
+	invalidatedTTL = time.Hour
 )
 
 // CredentialCache manages cached AWS credentials with automatic refresh
 type CredentialCache struct {
-	mu          sync.RWMutex
-	credentials map[string]*cachedCredentials // key: "accountID:externalID"
-	auth        *AWSAuth
-	stopCh      chan struct{}
+	store  CredentialStore
+	auth   *AWSAuth
+	stopCh chan struct{}
+
+	// keys tracks the credentials this process has used, so the refresh
+	// loop knows what to keep warm without listing the store.
+	mu   sync.RWMutex
+	keys map[string]struct{} // key: "accountID:externalID"
 }
 
-type cachedCredentials struct {
-	creds       *Credentials
-	lastRefresh time.Time
+// CredentialCacheOption configures a CredentialCache.
+type CredentialCacheOption func(*CredentialCache)
+
+// WithCredentialStore replaces the in-memory store, e.g. with one shared
+// between replicas.
+func WithCredentialStore(store CredentialStore) CredentialCacheOption {
+	return func(c *CredentialCache) {
+		c.store = store
+	}
 }
 
 // cacheKey generates a composite key from accountID and externalID
@@ -29,14 +46,24 @@ func cacheKey(accountID, externalID string) string {
 	return accountID + ":" + externalID
 }
 
+// invalidatedKey is the store key of the tombstone left when the
+// credentials under key are invalidated.
+func invalidatedKey(key string) string {
+	return "invalidated:" + key
+}
+
 // NewCredentialCache creates a CredentialCache that stores per-account AWS
 // credentials and starts a background goroutine that periodically refreshes
 // expiring credentials using the provided AWSAuth.
-func NewCredentialCache(auth *AWSAuth) *CredentialCache {
+func NewCredentialCache(auth *AWSAuth, opts ...CredentialCacheOption) *CredentialCache {
 	cache := &CredentialCache{
-		credentials: make(map[string]*cachedCredentials),
-		auth:        auth,
-		stopCh:      make(chan struct{}),
+		store:  newMemoryStore(),
+		auth:   auth,
+		stopCh: make(chan struct{}),
+		keys:   make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(cache)
 	}
 
 	// Start background refresh goroutine
@@ -54,14 +81,24 @@ func (c *CredentialCache) Stop() {
 func (c *CredentialCache) GetCredentials(ctx context.Context, accountID, externalID string) (*Credentials, error) {
 	key := cacheKey(accountID, externalID)
 
-	c.mu.RLock()
-	cached, exists := c.credentials[key]
-	c.mu.RUnlock()
-
+	// A failing store is treated as a miss so scans keep working.
+	cached, exists, err := c.store.Get(ctx, key)
+	if err != nil {
+		log.Printf("Warning: failed to read cached credentials for %s: %v", accountID, err)
+	}
 	if exists {
+		c.track(key)
 		// Check if credentials are still valid
-		if time.Until(cached.creds.Expiration) > refreshBuffer {
-			return cached.creds, nil
+		if time.Until(cached.Expiration) > refreshBuffer {
+			return cached, nil
+		}
+	}
+
+	// The credentials are in use again, such as for a reconnected account,
+	// so the refresh loop may keep them warm.
+	if !exists {
+		if err := c.store.Delete(ctx, invalidatedKey(key)); err != nil {
+			log.Printf("Warning: failed to clear invalidation of credentials for %s: %v", accountID, err)
 		}
 	}
 
@@ -69,6 +106,31 @@ func (c *CredentialCache) GetCredentials(ctx context.Context, accountID, externa
 	return c.RefreshCredentials(ctx, accountID, externalID)
 }
 
+// Provider returns a credentials provider for AWS clients acting in the
+// account, backed by the cache. In self-hosted mode, where roles are not
+// assumed, it returns the server's own credentials.
+func (c *CredentialCache) Provider(accountID, externalID string) aws.CredentialsProvider {
+	if c.auth.selfHosting {
+		return c.auth.cfg.Credentials
+	}
+	return aws.NewCredentialsCache(aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+		creds, err := c.GetCredentials(ctx, accountID, externalID)
+		if err != nil {
+			return aws.Credentials{}, err
+		}
+		return aws.Credentials{
+			AccessKeyID:     creds.AccessKeyID,
+			SecretAccessKey: creds.SecretAccessKey,
+			SessionToken:    creds.SessionToken,
+			Source:          "CloudCopCredentialCache",
+			CanExpire:       true,
+			Expires:         creds.Expiration,
+		}, nil
+	}), func(o *aws.CredentialsCacheOptions) {
+		o.ExpiryWindow = refreshBuffer
+	})
+}
+
 // RefreshCredentials fetches new credentials and updates the cache
 func (c *CredentialCache) RefreshCredentials(ctx context.Context, accountID, externalID string) (*Credentials, error) {
 	creds, err := c.auth.AssumeRole(ctx, AssumeRoleInput{
@@ -79,23 +141,54 @@ func (c *CredentialCache) RefreshCredentials(ctx context.Context, accountID, ext
 		return nil, err
 	}
 
+	c.auth.InvalidateIdentity(accountID, externalID)
+
 	key := cacheKey(accountID, externalID)
-	c.mu.Lock()
-	c.credentials[key] = &cachedCredentials{
-		creds:       creds,
-		lastRefresh: time.Now(),
+	if err := c.store.Set(ctx, key, creds, time.Until(creds.Expiration)); err != nil {
+		log.Printf("Warning: failed to cache credentials for %s: %v", accountID, err)
 	}
-	c.mu.Unlock()
+	c.track(key)
 
 	return creds, nil
 }
 
-// InvalidateCredentials removes credentials from cache
+// InvalidateCredentials removes credentials from cache. It leaves a
+// tombstone in the store so that replicas sharing it stop refreshing the
+// credentials instead of assuming the role again.
 func (c *CredentialCache) InvalidateCredentials(accountID, externalID string) {
 	key := cacheKey(accountID, externalID)
 	c.mu.Lock()
-	delete(c.credentials, key)
+	delete(c.keys, key)
 	c.mu.Unlock()
+
+	ctx := context.Background()
+	if err := c.store.Set(ctx, invalidatedKey(key), &Credentials{}, invalidatedTTL); err != nil {
+		log.Printf("Warning: failed to record invalidation of credentials for %s: %v", accountID, err)
+	}
+	if err := c.store.Delete(ctx, key); err != nil {
+		log.Printf("Warning: failed to delete cached credentials for %s: %v", accountID, err)
+	}
+
+	c.auth.InvalidateIdentity(accountID, externalID)
+}
+
+// InvalidateByAccount removes the credentials cached for accountID under
+// every external ID this process has used with it, such as when the account
+// is disconnected and its stored external ID may have changed since.
+func (c *CredentialCache) InvalidateByAccount(accountID string) {
+	prefix := cacheKey(accountID, "")
+	c.mu.RLock()
+	var externalIDs []string
+	for key := range c.keys {
+		if strings.HasPrefix(key, prefix) {
+			externalIDs = append(externalIDs, key[len(prefix):])
+		}
+	}
+	c.mu.RUnlock()
+
+	for _, externalID := range externalIDs {
+		c.InvalidateCredentials(accountID, externalID)
+	}
 }
 
 // refreshLoop periodically checks and refreshes expiring credentials
@@ -113,16 +206,45 @@ func (c *CredentialCache) refreshLoop() {
 	}
 }
 
-// refreshExpiring refreshes credentials that are about to expire
+// track records that this process uses the credentials stored under key.
+func (c *CredentialCache) track(key string) {
+	c.mu.Lock()
+	c.keys[key] = struct{}{}
+	c.mu.Unlock()
+}
+
+// refreshExpiring refreshes tracked credentials that are about to expire or
+// have dropped out of the store. Credentials another replica already
+// refreshed are left alone, and invalidated ones, such as those of an
+// account another replica disconnected, are no longer tracked.
 func (c *CredentialCache) refreshExpiring() {
 	c.mu.RLock()
+	keys := make([]string, 0, len(c.keys))
+	for key := range c.keys {
+		keys = append(keys, key)
+	}
+	c.mu.RUnlock()
+
 	type expiringCred struct {
 		accountID  string
 		externalID string
 	}
 	var expiring []expiringCred
-	for key, cached := range c.credentials {
-		if time.Until(cached.creds.Expiration) <= refreshBuffer {
+	for _, key := range keys {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		cached, exists, err := c.store.Get(ctx, key)
+		cancel()
+		if err != nil {
+			log.Printf("Warning: failed to read cached credentials: %v", err)
+			continue
+		}
+		if !exists && c.invalidated(key) {
+			c.mu.Lock()
+			delete(c.keys, key)
+			c.mu.Unlock()
+			continue
+		}
+		if !exists || time.Until(cached.Expiration) <= refreshBuffer {
 			// Parse composite key back to accountID and externalID
 			// This is a simple split - in production you might want more robust parsing
 			parts := splitCacheKey(key)
@@ -134,7 +256,6 @@ func (c *CredentialCache) refreshExpiring() {
 			}
 		}
 	}
-	c.mu.RUnlock()
 
 	// Refresh expiring credentials
 	for _, cred := range expiring {
@@ -146,6 +267,19 @@ func (c *CredentialCache) refreshExpiring() {
 	}
 }
 
+// invalidated reports whether the credentials under key were invalidated,
+// by this or another replica. A failing store is treated as not invalidated,
+// so credentials in use keep being refreshed.
+func (c *CredentialCache) invalidated(key string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	_, tombstoned, err := c.store.Get(ctx, invalidatedKey(key))
+	if err != nil {
+		log.Printf("Warning: failed to read invalidation of cached credentials: %v", err)
+	}
+	return tombstoned
+}
+
 // splitCacheKey splits a composite cache key into accountID and externalID
 func splitCacheKey(key string) []string {
 	// Find the first colon to split accountID and externalID
@@ -157,9 +291,10 @@ func splitCacheKey(key string) []string {
 	return []string{key}
 }
 
-// GetCachedCredentialsCount returns the number of cached credentials
+// GetCachedCredentialsCount returns the number of credentials this process
+// has cached and not invalidated.
 func (c *CredentialCache) GetCachedCredentialsCount() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return len(c.credentials)
+	return len(c.keys)
 }
@@ -19,12 +19,7 @@ func TestCredentialCache_SetGet(t *testing.T) {
 	}
 
 	// Set credentials manually using composite key
-	cache.mu.Lock()
-	cache.credentials[cacheKey("123456789012", "test-external-id")] = &cachedCredentials{
-		creds:       creds,
-		lastRefresh: time.Now(),
-	}
-	cache.mu.Unlock()
+	_ = cache.store.Set(context.Background(), cacheKey("123456789012", "test-external-id"), creds, time.Hour)
 
 	// Get credentials
 	retrieved, err := cache.GetCredentials(context.Background(), "123456789012", "test-external-id")
@@ -60,12 +55,7 @@ func TestCredentialCache_ThreadSafety(_ *testing.T) {
 
 			accountID := "123456789012"
 			externalID := "test-external-id"
-			cache.mu.Lock()
-			cache.credentials[cacheKey(accountID, externalID)] = &cachedCredentials{
-				creds:       creds,
-				lastRefresh: time.Now(),
-			}
-			cache.mu.Unlock()
+			_ = cache.store.Set(context.Background(), cacheKey(accountID, externalID), creds, time.Hour)
 
 			/*
 				Try to get credentials to verify thread-safe access.
@@ -91,12 +81,8 @@ func TestCredentialCache_Invalidate(t *testing.T) {
 		Expiration:      time.Now().Add(1 * time.Hour),
 	}
 
-	cache.mu.Lock()
-	cache.credentials[cacheKey(accountID, externalID)] = &cachedCredentials{
-		creds:       creds,
-		lastRefresh: time.Now(),
-	}
-	cache.mu.Unlock()
+	_ = cache.store.Set(context.Background(), cacheKey(accountID, externalID), creds, time.Hour)
+	cache.track(cacheKey(accountID, externalID))
 
 	// Verify it exists
 	if cache.GetCachedCredentialsCount() != 1 {
@@ -110,6 +96,9 @@ func TestCredentialCache_Invalidate(t *testing.T) {
 	if cache.GetCachedCredentialsCount() != 0 {
 		t.Error("Expected 0 cached credentials after invalidation")
 	}
+	if _, ok, _ := cache.store.Get(context.Background(), cacheKey(accountID, externalID)); ok {
+		t.Error("Expected credentials to be deleted from the store")
+	}
 }
 
 func TestCredentialCache_ExpirationDetection(t *testing.T) {
@@ -148,3 +137,33 @@ func TestCredentialCache_ExpirationDetection(t *testing.T) {
 		})
 	}
 }
+
+func TestCredentialCache_InvalidateByAccount(t *testing.T) {
+	auth, _ := NewAWSAuth()
+	cache := NewCredentialCache(auth)
+	defer cache.Stop()
+
+	creds := &Credentials{AccessKeyID: "AKIATEST", Expiration: time.Now().Add(time.Hour)}
+	keys := []string{
+		cacheKey("123456789012", "ext-old"),
+		cacheKey("123456789012", "ext-new"),
+		cacheKey("1234567890123", "ext-old"), // shares the account ID as a prefix
+		cacheKey("210987654321", "ext-old"),
+	}
+	for _, key := range keys {
+		_ = cache.store.Set(context.Background(), key, creds, time.Hour)
+		cache.track(key)
+	}
+
+	cache.InvalidateByAccount("123456789012")
+
+	if got := cache.GetCachedCredentialsCount(); got != 2 {
+		t.Errorf("GetCachedCredentialsCount() = %d, want 2", got)
+	}
+	for i, key := range keys {
+		_, ok, _ := cache.store.Get(context.Background(), key)
+		if wantKept := i >= 2; ok != wantKept {
+			t.Errorf("%s present = %v, want %v", key, ok, wantKept)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package awsauth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+const (
+	// identityTTL is how long a caller identity is reused before STS is asked again.
+	identityTTL = time.Minute
+	// selfIdentityKey caches the identity of the service's own credentials.
+	selfIdentityKey = "self"
+)
+
+// stsAPI is the subset of the STS client used by AWSAuth.
+type stsAPI interface {
+	AssumeRole(ctx context.Context, params *sts.AssumeRoleInput, optFns ...func(*sts.Options)) (*sts.AssumeRoleOutput, error)
+	GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
+}
+
+// identityCache memoizes caller identities per credential set so repeated
+// verifications within the TTL do not call STS. The zero value is ready to use.
+type identityCache struct {
+	mu         sync.Mutex
+	identities map[string]cachedIdentity
+}
+
+type cachedIdentity struct {
+	info      AccountInfo
+	expiresAt time.Time
+}
+
+// get returns the cached identity for key if it has not expired.
+func (c *identityCache) get(key string) (*AccountInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.identities[key]
+	if !ok || time.Now().After(cached.expiresAt) {
+		return nil, false
+	}
+	info := cached.info
+	return &info, true
+}
+
+// set caches info under key for identityTTL.
+func (c *identityCache) set(key string, info *AccountInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.identities == nil {
+		c.identities = make(map[string]cachedIdentity)
+	}
+	c.identities[key] = cachedIdentity{info: *info, expiresAt: time.Now().Add(identityTTL)}
+}
+
+// invalidate drops the cached identity for key.
+func (c *identityCache) invalidate(key string) {
+	c.mu.Lock()
+	delete(c.identities, key)
+	c.mu.Unlock()
+}
+
+// InvalidateIdentity discards the memoized caller identity for an account,
+// so the next verification calls STS again. It is called whenever the
+// account's credentials are refreshed or removed.
+func (a *AWSAuth) InvalidateIdentity(accountID, externalID string) {
+	a.identities.invalidate(cacheKey(accountID, externalID))
+}
@@ -0,0 +1,92 @@
+package awsauth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// fakeSTSClient counts STS calls and returns a fixed identity.
+type fakeSTSClient struct {
+	stsAPI
+	identityCalls int
+}
+
+func (f *fakeSTSClient) GetCallerIdentity(_ context.Context, _ *sts.GetCallerIdentityInput, _ ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	f.identityCalls++
+	return &sts.GetCallerIdentityOutput{
+		Account: aws.String("123456789012"),
+		Arn:     aws.String("arn:aws:iam::123456789012:user/cloudcop"),
+		UserId:  aws.String("AIDATEST"),
+	}, nil
+}
+
+func TestGetAccountID_Memoized(t *testing.T) {
+	client := &fakeSTSClient{}
+	a := &AWSAuth{stsClient: client, selfHosting: true}
+
+	for range 2 {
+		accountID, err := a.GetAccountID(context.Background())
+		if err != nil {
+			t.Fatalf("GetAccountID() error = %v", err)
+		}
+		if accountID != "123456789012" {
+			t.Errorf("GetAccountID() = %s, want 123456789012", accountID)
+		}
+	}
+	if client.identityCalls != 1 {
+		t.Errorf("GetCallerIdentity called %d times, want 1", client.identityCalls)
+	}
+
+	// Self-hosted verification reuses the same identity.
+	if _, err := a.VerifyAccountAccess(context.Background(), AssumeRoleInput{}); err != nil {
+		t.Fatalf("VerifyAccountAccess() error = %v", err)
+	}
+	if client.identityCalls != 1 {
+		t.Errorf("GetCallerIdentity called %d times after verification, want 1", client.identityCalls)
+	}
+}
+
+func TestIdentityCache_Invalidate(t *testing.T) {
+	var c identityCache
+	c.set(cacheKey("123456789012", "ext"), &AccountInfo{AccountID: "123456789012"})
+
+	if _, ok := c.get(cacheKey("123456789012", "ext")); !ok {
+		t.Fatal("expected cached identity")
+	}
+	if _, ok := c.get(cacheKey("123456789012", "other")); ok {
+		t.Error("identity cached for a different external ID")
+	}
+
+	c.invalidate(cacheKey("123456789012", "ext"))
+	if _, ok := c.get(cacheKey("123456789012", "ext")); ok {
+		t.Error("identity still cached after invalidation")
+	}
+}
+
+func TestRecheckAccountAccess_BypassesCache(t *testing.T) {
+	client := &fakeSTSClient{}
+	a := &AWSAuth{stsClient: client, selfHosting: true}
+
+	if _, err := a.VerifyAccountAccess(context.Background(), AssumeRoleInput{}); err != nil {
+		t.Fatalf("VerifyAccountAccess() error = %v", err)
+	}
+	for range 2 {
+		if _, err := a.RecheckAccountAccess(context.Background(), AssumeRoleInput{}); err != nil {
+			t.Fatalf("RecheckAccountAccess() error = %v", err)
+		}
+	}
+	if client.identityCalls != 3 {
+		t.Errorf("GetCallerIdentity called %d times, want every recheck to call STS", client.identityCalls)
+	}
+
+	// The recheck refreshed the cache for later cached verifications.
+	if _, err := a.VerifyAccountAccess(context.Background(), AssumeRoleInput{}); err != nil {
+		t.Fatalf("VerifyAccountAccess() error = %v", err)
+	}
+	if client.identityCalls != 3 {
+		t.Errorf("GetCallerIdentity called %d times, want the cached identity reused", client.identityCalls)
+	}
+}
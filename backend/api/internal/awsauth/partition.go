@@ -0,0 +1,57 @@
+package awsauth
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// AWS partitions CloudCop can scan. Each partition has its own ARN prefix,
+// so a role ARN built for the wrong one is rejected by STS.
+const (
+	PartitionAWS      = "aws"
+	PartitionGovCloud = "aws-us-gov"
+	PartitionChina    = "aws-cn"
+)
+
+// defaultRegions are the regions of each partition that every account can
+// call without opting in.
+var defaultRegions = map[string][]string{
+	PartitionAWS: {
+		"us-east-1", "us-east-2", "us-west-1", "us-west-2",
+		"eu-west-1", "eu-west-2", "eu-central-1",
+		"ap-southeast-1", "ap-southeast-2", "ap-northeast-1",
+	},
+	PartitionGovCloud: {"us-gov-east-1", "us-gov-west-1"},
+	PartitionChina:    {"cn-north-1", "cn-northwest-1"},
+}
+
+// DefaultRegions returns a copy of the regions of a partition that need no
+// opt-in. Unknown and empty partitions get the standard partition's regions.
+func DefaultRegions(partition string) []string {
+	if regions, ok := defaultRegions[partition]; ok {
+		return slices.Clone(regions)
+	}
+	return slices.Clone(defaultRegions[PartitionAWS])
+}
+
+// PartitionForRegion returns the partition a region belongs to. Unknown and
+// empty regions are assumed to be in the standard partition.
+func PartitionForRegion(region string) string {
+	switch {
+	case strings.HasPrefix(region, "us-gov-"):
+		return PartitionGovCloud
+	case strings.HasPrefix(region, "cn-"):
+		return PartitionChina
+	default:
+		return PartitionAWS
+	}
+}
+
+// RoleARN returns the ARN of an IAM role in the given partition.
+func RoleARN(partition, accountID, roleName string) string {
+	if partition == "" {
+		partition = PartitionAWS
+	}
+	return fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, accountID, roleName)
+}
@@ -0,0 +1,114 @@
+package awsauth
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/aws-sdk-go-v2/service/sts/types"
+)
+
+// roleARNRecorder records the role ARN of each AssumeRole call.
+type roleARNRecorder struct {
+	stsAPI
+	roleARN string
+}
+
+func (r *roleARNRecorder) AssumeRole(_ context.Context, params *sts.AssumeRoleInput, _ ...func(*sts.Options)) (*sts.AssumeRoleOutput, error) {
+	r.roleARN = aws.ToString(params.RoleArn)
+	return &sts.AssumeRoleOutput{Credentials: &types.Credentials{Expiration: aws.Time(time.Now().Add(time.Hour))}}, nil
+}
+
+func TestPartitionForRegion(t *testing.T) {
+	tests := []struct {
+		region string
+		want   string
+	}{
+		{"us-east-1", PartitionAWS},
+		{"eu-central-1", PartitionAWS},
+		{"", PartitionAWS},
+		{"us-gov-west-1", PartitionGovCloud},
+		{"us-gov-east-1", PartitionGovCloud},
+		{"cn-north-1", PartitionChina},
+		{"cn-northwest-1", PartitionChina},
+	}
+	for _, tt := range tests {
+		if got := PartitionForRegion(tt.region); got != tt.want {
+			t.Errorf("PartitionForRegion(%q) = %s, want %s", tt.region, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultRegions(t *testing.T) {
+	tests := []struct {
+		partition string
+		want      string
+	}{
+		{PartitionAWS, "us-east-1"},
+		{"", "us-east-1"},
+		{PartitionGovCloud, "us-gov-west-1"},
+		{PartitionChina, "cn-north-1"},
+	}
+	for _, tt := range tests {
+		regions := DefaultRegions(tt.partition)
+		if !slices.Contains(regions, tt.want) {
+			t.Errorf("DefaultRegions(%q) = %v, want it to include %s", tt.partition, regions, tt.want)
+		}
+		for _, r := range regions {
+			if PartitionForRegion(r) != PartitionForRegion(tt.want) {
+				t.Errorf("DefaultRegions(%q) includes %s from another partition", tt.partition, r)
+			}
+		}
+	}
+
+	regions := DefaultRegions(PartitionAWS)
+	regions[0] = "changed"
+	if DefaultRegions(PartitionAWS)[0] == "changed" {
+		t.Error("DefaultRegions() returned the shared list")
+	}
+}
+
+func TestAssumeRole_PartitionARN(t *testing.T) {
+	tests := []struct {
+		name      string
+		region    string
+		partition string
+		want      string
+	}{
+		{"standard", "us-east-1", "", "arn:aws:iam::123456789012:role/CloudCopSecurityScanRole"},
+		{"govcloud region", "us-gov-west-1", "", "arn:aws-us-gov:iam::123456789012:role/CloudCopSecurityScanRole"},
+		{"china region", "cn-north-1", "", "arn:aws-cn:iam::123456789012:role/CloudCopSecurityScanRole"},
+		{"explicit partition", "us-east-1", PartitionGovCloud, "arn:aws-us-gov:iam::123456789012:role/CloudCopSecurityScanRole"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &roleARNRecorder{}
+			a := &AWSAuth{
+				cfg:             aws.Config{Region: tt.region},
+				stsClient:       client,
+				roleName:        "CloudCopSecurityScanRole",
+				sessionDuration: 3600,
+			}
+			_, err := a.AssumeRole(context.Background(), AssumeRoleInput{
+				AccountID:  "123456789012",
+				ExternalID: "ext",
+				Partition:  tt.partition,
+			})
+			if err != nil {
+				t.Fatalf("AssumeRole() error = %v", err)
+			}
+			if client.roleARN != tt.want {
+				t.Errorf("assumed %s, want %s", client.roleARN, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoleARN_DefaultPartition(t *testing.T) {
+	if got := RoleARN("", "123456789012", "Scan"); got != "arn:aws:iam::123456789012:role/Scan" {
+		t.Errorf("RoleARN() = %s", got)
+	}
+}
@@ -0,0 +1,79 @@
+package awsauth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CredentialStore holds assumed-role credentials for a CredentialCache.
+// The default keeps them in process memory; a shared implementation such as
+// Redis lets replicas reuse each other's sessions instead of each assuming
+// the role. Implementations must be safe for concurrent use.
+type CredentialStore interface {
+	// Get returns the credentials stored under key. It reports false when
+	// there are none or their TTL has passed.
+	Get(ctx context.Context, key string) (*Credentials, bool, error)
+	// Set stores creds under key, replacing any earlier value, until ttl elapses.
+	Set(ctx context.Context, key string, creds *Credentials, ttl time.Duration) error
+	// Delete removes the credentials stored under key, if any.
+	Delete(ctx context.Context, key string) error
+}
+
+// memoryStore is the default CredentialStore, a map local to the process.
+type memoryStore struct {
+	mu      sync.RWMutex
+	entries map[string]memoryEntry
+	now     func() time.Time
+}
+
+type memoryEntry struct {
+	creds     *Credentials
+	expiresAt time.Time
+}
+
+// NewMemoryCredentialStore returns a CredentialStore that keeps credentials
+// in process memory.
+func NewMemoryCredentialStore() CredentialStore {
+	return newMemoryStore()
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		entries: make(map[string]memoryEntry),
+		now:     time.Now,
+	}
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) (*Credentials, bool, error) {
+	s.mu.RLock()
+	entry, ok := s.entries[key]
+	s.mu.RUnlock()
+	if !ok || !s.now().Before(entry.expiresAt) {
+		return nil, false, nil
+	}
+	return entry.creds, true, nil
+}
+
+func (s *memoryStore) Set(_ context.Context, key string, creds *Credentials, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Expired entries are only dropped on write, which is frequent enough
+	// for the handful of accounts a deployment connects.
+	now := s.now()
+	for k, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(s.entries, k)
+		}
+	}
+	s.entries[key] = memoryEntry{creds: creds, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+func (s *memoryStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	delete(s.entries, key)
+	s.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,262 @@
+package awsauth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/aws-sdk-go-v2/service/sts/types"
+)
+
+// fakeStore is a CredentialStore with a settable clock that records the TTL
+// of each write, standing in for a shared store such as Redis.
+type fakeStore struct {
+	mu      sync.Mutex
+	now     time.Time
+	entries map[string]memoryEntry
+	ttls    map[string]time.Duration
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		now:     time.Now(),
+		entries: make(map[string]memoryEntry),
+		ttls:    make(map[string]time.Duration),
+	}
+}
+
+func (f *fakeStore) Get(_ context.Context, key string) (*Credentials, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entry, ok := f.entries[key]
+	if !ok || !f.now.Before(entry.expiresAt) {
+		return nil, false, nil
+	}
+	return entry.creds, true, nil
+}
+
+func (f *fakeStore) Set(_ context.Context, key string, creds *Credentials, ttl time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.entries[key] = memoryEntry{creds: creds, expiresAt: f.now.Add(ttl)}
+	f.ttls[key] = ttl
+	return nil
+}
+
+func (f *fakeStore) Delete(_ context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.entries, key)
+	return nil
+}
+
+// assumeRoleClient issues hour-long credentials named after the call count.
+type assumeRoleClient struct {
+	stsAPI
+	mu    sync.Mutex
+	calls int
+}
+
+func (c *assumeRoleClient) AssumeRole(_ context.Context, _ *sts.AssumeRoleInput, _ ...func(*sts.Options)) (*sts.AssumeRoleOutput, error) {
+	c.mu.Lock()
+	c.calls++
+	calls := c.calls
+	c.mu.Unlock()
+	return &sts.AssumeRoleOutput{Credentials: &types.Credentials{
+		AccessKeyId:     aws.String(fmt.Sprintf("ASIA%d", calls)),
+		SecretAccessKey: aws.String("secret"),
+		SessionToken:    aws.String("token"),
+		Expiration:      aws.Time(time.Now().Add(time.Hour)),
+	}}, nil
+}
+
+func newStoreTestCache(t *testing.T, store CredentialStore) (*CredentialCache, *assumeRoleClient) {
+	t.Helper()
+	client := &assumeRoleClient{}
+	cache := NewCredentialCache(&AWSAuth{stsClient: client, roleName: "CloudCopSecurityScanRole"}, WithCredentialStore(store))
+	t.Cleanup(cache.Stop)
+	return cache, client
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	creds := &Credentials{AccessKeyID: "AKIATEST", Expiration: now.Add(time.Hour)}
+
+	if _, ok, err := store.Get(ctx, "a:x"); ok || err != nil {
+		t.Fatalf("Get() on an empty store = %v, %v", ok, err)
+	}
+	if err := store.Set(ctx, "a:x", creds, time.Minute); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if got, ok, _ := store.Get(ctx, "a:x"); !ok || got.AccessKeyID != "AKIATEST" {
+		t.Fatalf("Get() = %+v, %v; want the stored credentials", got, ok)
+	}
+
+	now = now.Add(59 * time.Second)
+	if _, ok, _ := store.Get(ctx, "a:x"); !ok {
+		t.Error("credentials expired before their TTL")
+	}
+	now = now.Add(time.Second)
+	if _, ok, _ := store.Get(ctx, "a:x"); ok {
+		t.Error("credentials returned after their TTL")
+	}
+
+	// Writing drops expired entries.
+	_ = store.Set(ctx, "b:y", creds, time.Minute)
+	if len(store.entries) != 1 {
+		t.Errorf("store holds %d entries, want the expired one dropped", len(store.entries))
+	}
+
+	if err := store.Delete(ctx, "b:y"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, ok, _ := store.Get(ctx, "b:y"); ok {
+		t.Error("credentials returned after Delete()")
+	}
+	if err := store.Delete(ctx, "missing"); err != nil {
+		t.Errorf("Delete() of a missing key error = %v", err)
+	}
+}
+
+func TestCredentialCache_SharedStore(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeStore()
+	key := cacheKey("123456789012", "ext")
+
+	// Credentials cached by another replica are reused without STS.
+	_ = store.Set(ctx, key, &Credentials{AccessKeyID: "SHARED", Expiration: time.Now().Add(time.Hour)}, time.Hour)
+	cache, client := newStoreTestCache(t, store)
+	creds, err := cache.GetCredentials(ctx, "123456789012", "ext")
+	if err != nil {
+		t.Fatalf("GetCredentials() error = %v", err)
+	}
+	if creds.AccessKeyID != "SHARED" || client.calls != 0 {
+		t.Errorf("GetCredentials() = %s after %d AssumeRole calls, want the shared credentials", creds.AccessKeyID, client.calls)
+	}
+
+	// A second cache on the same store sees credentials the first one assumed.
+	other, _ := newStoreTestCache(t, store)
+	if _, err := other.RefreshCredentials(ctx, "210987654321", "ext"); err != nil {
+		t.Fatalf("RefreshCredentials() error = %v", err)
+	}
+	creds, err = cache.GetCredentials(ctx, "210987654321", "ext")
+	if err != nil {
+		t.Fatalf("GetCredentials() error = %v", err)
+	}
+	if creds.AccessKeyID != "ASIA1" || client.calls != 0 {
+		t.Errorf("GetCredentials() = %s after %d AssumeRole calls, want the other cache's credentials", creds.AccessKeyID, client.calls)
+	}
+	if ttl := store.ttls[cacheKey("210987654321", "ext")]; ttl <= 55*time.Minute || ttl > time.Hour {
+		t.Errorf("stored with TTL %s, want the time until expiration", ttl)
+	}
+
+	cache.InvalidateCredentials("210987654321", "ext")
+	if _, ok, _ := store.Get(ctx, cacheKey("210987654321", "ext")); ok {
+		t.Error("InvalidateCredentials() left the credentials in the store")
+	}
+}
+
+func TestCredentialCache_RefreshesExpiringFromStore(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeStore()
+	cache, client := newStoreTestCache(t, store)
+
+	expiring := cacheKey("111111111111", "ext")
+	fresh := cacheKey("222222222222", "ext")
+	evicted := cacheKey("333333333333", "ext")
+	_ = store.Set(ctx, expiring, &Credentials{AccessKeyID: "OLD", Expiration: time.Now().Add(time.Minute)}, time.Minute)
+	_ = store.Set(ctx, fresh, &Credentials{AccessKeyID: "FRESH", Expiration: time.Now().Add(time.Hour)}, time.Hour)
+	for _, key := range []string{expiring, fresh, evicted} {
+		cache.track(key)
+	}
+
+	// Credentials within the refresh buffer are assumed again on read.
+	creds, err := cache.GetCredentials(ctx, "111111111111", "ext")
+	if err != nil {
+		t.Fatalf("GetCredentials() error = %v", err)
+	}
+	if creds.AccessKeyID == "OLD" || client.calls != 1 {
+		t.Fatalf("GetCredentials() = %s after %d AssumeRole calls, want new credentials", creds.AccessKeyID, client.calls)
+	}
+
+	// The refresh loop renews what dropped out of the store and leaves
+	// credentials that are still fresh, e.g. renewed by another replica.
+	cache.refreshExpiring()
+	if client.calls != 2 {
+		t.Errorf("AssumeRole called %d times, want one more for the evicted credentials", client.calls)
+	}
+	if creds, ok, _ := store.Get(ctx, evicted); !ok || creds.AccessKeyID == "" {
+		t.Error("evicted credentials were not refreshed into the store")
+	}
+	if creds, _, _ := store.Get(ctx, fresh); creds.AccessKeyID != "FRESH" {
+		t.Errorf("fresh credentials replaced with %s", creds.AccessKeyID)
+	}
+}
+
+func TestCredentialCache_InvalidationSharedAcrossReplicas(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeStore()
+	first, _ := newStoreTestCache(t, store)
+	second, client := newStoreTestCache(t, store)
+
+	if _, err := second.GetCredentials(ctx, "123456789012", "ext"); err != nil {
+		t.Fatalf("GetCredentials() error = %v", err)
+	}
+
+	// The first replica disconnects the account. The second must not assume
+	// the role again to put the credentials back.
+	first.InvalidateCredentials("123456789012", "ext")
+	second.refreshExpiring()
+	if client.calls != 1 {
+		t.Errorf("AssumeRole called %d times, want no refresh of invalidated credentials", client.calls)
+	}
+	if _, ok, _ := store.Get(ctx, cacheKey("123456789012", "ext")); ok {
+		t.Error("invalidated credentials were written back to the store")
+	}
+	if n := second.GetCachedCredentialsCount(); n != 0 {
+		t.Errorf("second replica tracks %d credentials, want 0", n)
+	}
+
+	// Using the credentials again, e.g. after reconnecting, keeps them warm.
+	if _, err := second.GetCredentials(ctx, "123456789012", "ext"); err != nil {
+		t.Fatalf("GetCredentials() error = %v", err)
+	}
+	_ = store.Delete(ctx, cacheKey("123456789012", "ext"))
+	second.refreshExpiring()
+	if client.calls != 3 {
+		t.Errorf("AssumeRole called %d times, want the reconnected credentials refreshed", client.calls)
+	}
+}
+
+func TestCredentialCache_Provider(t *testing.T) {
+	ctx := context.Background()
+	cache, client := newStoreTestCache(t, newFakeStore())
+
+	provider := cache.Provider("123456789012", "ext")
+	for range 2 {
+		creds, err := provider.Retrieve(ctx)
+		if err != nil {
+			t.Fatalf("Retrieve() error = %v", err)
+		}
+		if creds.AccessKeyID != "ASIA1" || !creds.CanExpire {
+			t.Errorf("Retrieve() = %s (expires: %v), want the assumed role's expiring credentials", creds.AccessKeyID, creds.CanExpire)
+		}
+	}
+	if client.calls != 1 {
+		t.Errorf("AssumeRole called %d times, want 1", client.calls)
+	}
+
+	own := aws.NewCredentialsCache(aws.AnonymousCredentials{})
+	selfHosted := NewCredentialCache(&AWSAuth{selfHosting: true, cfg: aws.Config{Credentials: own}})
+	t.Cleanup(selfHosted.Stop)
+	if got := selfHosted.Provider("123456789012", "self-hosted"); got != own {
+		t.Errorf("self-hosted Provider() = %v, want the server's own credentials", got)
+	}
+}
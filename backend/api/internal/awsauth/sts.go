@@ -0,0 +1,57 @@
+package awsauth
+
+import (
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// STSEndpoint selects which STS endpoint role assumption calls are sent to.
+// The values match the AWS_STS_REGIONAL_ENDPOINTS setting used by AWS SDKs.
+type STSEndpoint string
+
+const (
+	// RegionalSTSEndpoint sends calls to sts.<region>.amazonaws.com, keeping
+	// them in-region and independent of the global endpoint's availability.
+	RegionalSTSEndpoint STSEndpoint = "regional"
+	// GlobalSTSEndpoint sends calls to the legacy sts.amazonaws.com endpoint.
+	GlobalSTSEndpoint STSEndpoint = "legacy"
+)
+
+// stsGlobalRegion is the pseudo-region the SDK resolves to the global STS endpoint.
+const stsGlobalRegion = "aws-global"
+
+// STSOptions configures the STS client used for role assumption.
+type STSOptions struct {
+	// Endpoint selects the regional or global endpoint. Defaults to regional.
+	Endpoint STSEndpoint
+	// Region is the STS region for regional endpoints. Defaults to the AWS config region.
+	Region string
+}
+
+// stsOptionsFromEnv reads STS settings from AWS_STS_REGIONAL_ENDPOINTS and
+// AWS_STS_REGION, defaulting to the regional endpoint in the given region.
+func stsOptionsFromEnv(region string) STSOptions {
+	opts := STSOptions{Endpoint: RegionalSTSEndpoint, Region: region}
+	if os.Getenv("AWS_STS_REGIONAL_ENDPOINTS") == string(GlobalSTSEndpoint) {
+		opts.Endpoint = GlobalSTSEndpoint
+	}
+	if stsRegion := os.Getenv("AWS_STS_REGION"); stsRegion != "" {
+		opts.Region = stsRegion
+	}
+	return opts
+}
+
+// newSTSClient creates an STS client that targets the endpoint chosen by opts.
+// A BaseEndpoint on cfg, such as a LocalStack URL, still takes precedence.
+func newSTSClient(cfg aws.Config, opts STSOptions) *sts.Client {
+	return sts.NewFromConfig(cfg, func(o *sts.Options) {
+		switch {
+		case opts.Endpoint == GlobalSTSEndpoint:
+			o.Region = stsGlobalRegion
+		case opts.Region != "":
+			o.Region = opts.Region
+		}
+	})
+}
@@ -0,0 +1,61 @@
+package awsauth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+func resolveSTSEndpoint(t *testing.T, client *sts.Client) string {
+	t.Helper()
+	o := client.Options()
+	endpoint, err := o.EndpointResolverV2.ResolveEndpoint(context.Background(), sts.EndpointParameters{
+		Region: aws.String(o.Region),
+	})
+	if err != nil {
+		t.Fatalf("resolving STS endpoint: %v", err)
+	}
+	return endpoint.URI.String()
+}
+
+func TestNewSTSClient_Endpoints(t *testing.T) {
+	cfg := aws.Config{Region: "us-east-1"}
+
+	tests := []struct {
+		name string
+		opts STSOptions
+		want string
+	}{
+		{"regional in config region", STSOptions{Endpoint: RegionalSTSEndpoint}, "https://sts.us-east-1.amazonaws.com"},
+		{"regional in chosen region", STSOptions{Endpoint: RegionalSTSEndpoint, Region: "eu-central-1"}, "https://sts.eu-central-1.amazonaws.com"},
+		{"global", STSOptions{Endpoint: GlobalSTSEndpoint, Region: "eu-central-1"}, "https://sts.amazonaws.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveSTSEndpoint(t, newSTSClient(cfg, tt.opts)); got != tt.want {
+				t.Errorf("endpoint = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSTSOptionsFromEnv(t *testing.T) {
+	t.Setenv("AWS_STS_REGIONAL_ENDPOINTS", "")
+	t.Setenv("AWS_STS_REGION", "")
+	if got := stsOptionsFromEnv("ap-southeast-2"); got != (STSOptions{Endpoint: RegionalSTSEndpoint, Region: "ap-southeast-2"}) {
+		t.Errorf("default options = %+v, want regional in ap-southeast-2", got)
+	}
+
+	t.Setenv("AWS_STS_REGION", "eu-west-1")
+	if got := stsOptionsFromEnv("ap-southeast-2"); got.Region != "eu-west-1" {
+		t.Errorf("Region = %s, want eu-west-1", got.Region)
+	}
+
+	t.Setenv("AWS_STS_REGIONAL_ENDPOINTS", "legacy")
+	if got := stsOptionsFromEnv("ap-southeast-2"); got.Endpoint != GlobalSTSEndpoint {
+		t.Errorf("Endpoint = %s, want legacy", got.Endpoint)
+	}
+}
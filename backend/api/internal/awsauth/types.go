@@ -24,6 +24,9 @@ type AccountInfo struct {
 type AssumeRoleInput struct {
 	AccountID  string
 	ExternalID string
+	// Partition is the account's AWS partition, such as "aws-us-gov". Empty
+	// means the partition of the region CloudCop is configured for.
+	Partition string
 }
 
 // Common error types
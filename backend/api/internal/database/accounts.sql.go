@@ -71,6 +71,9 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 const createTeam = `-- name: CreateTeam :one
 INSERT INTO teams (name, slug, owner_id)
 VALUES ($1, $2, $3)
+ON CONFLICT (slug) DO UPDATE SET
+    slug = EXCLUDED.slug
+WHERE teams.owner_id = EXCLUDED.owner_id
 RETURNING id, name, slug, owner_id, created_at
 `
 
@@ -120,9 +123,10 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 	return i, err
 }
 
-const deleteAccount = `-- name: DeleteAccount :exec
+const deleteAccount = `-- name: DeleteAccount :one
 DELETE FROM aws_accounts
 WHERE account_id = $1 AND team_id = $2
+RETURNING id, team_id, account_id, external_id, role_arn, verified, last_verified_at, created_at
 `
 
 type DeleteAccountParams struct {
@@ -130,9 +134,76 @@ type DeleteAccountParams struct {
 	TeamID    pgtype.Int4
 }
 
-func (q *Queries) DeleteAccount(ctx context.Context, arg DeleteAccountParams) error {
-	_, err := q.db.Exec(ctx, deleteAccount, arg.AccountID, arg.TeamID)
-	return err
+func (q *Queries) DeleteAccount(ctx context.Context, arg DeleteAccountParams) (AwsAccount, error) {
+	row := q.db.QueryRow(ctx, deleteAccount, arg.AccountID, arg.TeamID)
+	var i AwsAccount
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.AccountID,
+		&i.ExternalID,
+		&i.RoleArn,
+		&i.Verified,
+		&i.LastVerifiedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteAccountsByTeamID = `-- name: DeleteAccountsByTeamID :many
+DELETE FROM aws_accounts
+WHERE team_id = $1
+RETURNING id, team_id, account_id, external_id, role_arn, verified, last_verified_at, created_at
+`
+
+func (q *Queries) DeleteAccountsByTeamID(ctx context.Context, teamID pgtype.Int4) ([]AwsAccount, error) {
+	rows, err := q.db.Query(ctx, deleteAccountsByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AwsAccount
+	for rows.Next() {
+		var i AwsAccount
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeamID,
+			&i.AccountID,
+			&i.ExternalID,
+			&i.RoleArn,
+			&i.Verified,
+			&i.LastVerifiedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAccountByAccountID = `-- name: GetAccountByAccountID :one
+SELECT id, team_id, account_id, external_id, role_arn, verified, last_verified_at, created_at FROM aws_accounts
+WHERE account_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetAccountByAccountID(ctx context.Context, accountID string) (AwsAccount, error) {
+	row := q.db.QueryRow(ctx, getAccountByAccountID, accountID)
+	var i AwsAccount
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.AccountID,
+		&i.ExternalID,
+		&i.RoleArn,
+		&i.Verified,
+		&i.LastVerifiedAt,
+		&i.CreatedAt,
+	)
+	return i, err
 }
 
 const getAccountByExternalID = `-- name: GetAccountByExternalID :one
@@ -156,6 +227,53 @@ func (q *Queries) GetAccountByExternalID(ctx context.Context, externalID string)
 	return i, err
 }
 
+const getAccountByID = `-- name: GetAccountByID :one
+SELECT id, team_id, account_id, external_id, role_arn, verified, last_verified_at, created_at FROM aws_accounts
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetAccountByID(ctx context.Context, id int32) (AwsAccount, error) {
+	row := q.db.QueryRow(ctx, getAccountByID, id)
+	var i AwsAccount
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.AccountID,
+		&i.ExternalID,
+		&i.RoleArn,
+		&i.Verified,
+		&i.LastVerifiedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getAccountByTeamAndAccountID = `-- name: GetAccountByTeamAndAccountID :one
+SELECT id, team_id, account_id, external_id, role_arn, verified, last_verified_at, created_at FROM aws_accounts
+WHERE team_id = $1 AND account_id = $2 LIMIT 1
+`
+
+type GetAccountByTeamAndAccountIDParams struct {
+	TeamID    pgtype.Int4
+	AccountID string
+}
+
+func (q *Queries) GetAccountByTeamAndAccountID(ctx context.Context, arg GetAccountByTeamAndAccountIDParams) (AwsAccount, error) {
+	row := q.db.QueryRow(ctx, getAccountByTeamAndAccountID, arg.TeamID, arg.AccountID)
+	var i AwsAccount
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.AccountID,
+		&i.ExternalID,
+		&i.RoleArn,
+		&i.Verified,
+		&i.LastVerifiedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getAccountsByTeamID = `-- name: GetAccountsByTeamID :many
 SELECT id, team_id, account_id, external_id, role_arn, verified, last_verified_at, created_at FROM aws_accounts
 WHERE team_id = $1
@@ -224,3 +342,55 @@ func (q *Queries) GetUser(ctx context.Context, id string) (User, error) {
 	)
 	return i, err
 }
+
+const updateAccountConnection = `-- name: UpdateAccountConnection :one
+UPDATE aws_accounts
+SET external_id = $2, role_arn = $3, verified = TRUE, last_verified_at = $4
+WHERE id = $1
+RETURNING id, team_id, account_id, external_id, role_arn, verified, last_verified_at, created_at
+`
+
+type UpdateAccountConnectionParams struct {
+	ID             int32
+	ExternalID     string
+	RoleArn        pgtype.Text
+	LastVerifiedAt pgtype.Timestamp
+}
+
+func (q *Queries) UpdateAccountConnection(ctx context.Context, arg UpdateAccountConnectionParams) (AwsAccount, error) {
+	row := q.db.QueryRow(ctx, updateAccountConnection,
+		arg.ID,
+		arg.ExternalID,
+		arg.RoleArn,
+		arg.LastVerifiedAt,
+	)
+	var i AwsAccount
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.AccountID,
+		&i.ExternalID,
+		&i.RoleArn,
+		&i.Verified,
+		&i.LastVerifiedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateAccountLastVerified = `-- name: UpdateAccountLastVerified :exec
+UPDATE aws_accounts
+SET verified = TRUE, last_verified_at = $1
+WHERE team_id = $2 AND account_id = $3
+`
+
+type UpdateAccountLastVerifiedParams struct {
+	LastVerifiedAt pgtype.Timestamp
+	TeamID         pgtype.Int4
+	AccountID      string
+}
+
+func (q *Queries) UpdateAccountLastVerified(ctx context.Context, arg UpdateAccountLastVerifiedParams) error {
+	_, err := q.db.Exec(ctx, updateAccountLastVerified, arg.LastVerifiedAt, arg.TeamID, arg.AccountID)
+	return err
+}
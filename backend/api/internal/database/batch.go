@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// findingsBatchSize is the number of findings sent per COPY. Large scans are
+// split so a single statement never holds an unbounded number of rows.
+const findingsBatchSize = 5000
+
+// BatchInsertFindings inserts findings for the scan with a COPY per
+// findingsBatchSize rows instead of one INSERT per finding. The ScanID of
+// each finding is set to scanID. It returns the number of rows inserted.
+// Callers that also create the scan should use Store.CreateScanWithFindings
+// so both are written in one transaction.
+func (q *Queries) BatchInsertFindings(ctx context.Context, scanID int32, findings []InsertScanFindingsParams) (int64, error) {
+	var inserted int64
+	for start := 0; start < len(findings); start += findingsBatchSize {
+		chunk := findings[start:min(start+findingsBatchSize, len(findings))]
+		rows := make([]InsertScanFindingsParams, len(chunk))
+		for i, f := range chunk {
+			f.ScanID = pgtype.Int4{Int32: scanID, Valid: true}
+			rows[i] = f
+		}
+		n, err := q.InsertScanFindings(ctx, rows)
+		if err != nil {
+			return inserted, fmt.Errorf("inserting findings %d-%d: %w", start, start+len(chunk)-1, err)
+		}
+		inserted += n
+	}
+	return inserted, nil
+}
+
+// TxDBTX is a DBTX that can start transactions, such as *pgxpool.Pool or
+// *pgx.Conn.
+type TxDBTX interface {
+	DBTX
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// Store adds queries that span several statements, and so must run in a
+// transaction, to the generated Queries.
+type Store struct {
+	*Queries
+	db TxDBTX
+}
+
+// NewStore creates a Store that runs its queries on db.
+func NewStore(db TxDBTX) *Store {
+	return &Store{Queries: New(db), db: db}
+}
+
+// CreateScanWithFindings creates a scan and batch-inserts its findings in a
+// single transaction, so a failure leaves neither behind.
+func (s *Store) CreateScanWithFindings(ctx context.Context, arg CreateScanParams, findings []InsertScanFindingsParams) (Scan, error) {
+	var scan Scan
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		q := s.WithTx(tx)
+		var err error
+		scan, err = q.CreateScan(ctx, arg)
+		if err != nil {
+			return fmt.Errorf("creating scan: %w", err)
+		}
+		if _, err := q.BatchInsertFindings(ctx, scan.ID, findings); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return Scan{}, err
+	}
+	return scan, nil
+}
@@ -0,0 +1,228 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// copyRecorder is a DBTX that records COPY calls. Any other statement fails
+// the test, since batch inserts must not fall back to per-row INSERTs.
+type copyRecorder struct {
+	t      *testing.T
+	copies [][]InsertScanFindingsParams
+}
+
+func (r *copyRecorder) Exec(_ context.Context, sql string, _ ...interface{}) (pgconn.CommandTag, error) {
+	r.t.Errorf("unexpected Exec: %s", sql)
+	return pgconn.CommandTag{}, nil
+}
+
+func (r *copyRecorder) Query(_ context.Context, sql string, _ ...interface{}) (pgx.Rows, error) {
+	r.t.Errorf("unexpected Query: %s", sql)
+	return nil, fmt.Errorf("unexpected query")
+}
+
+func (r *copyRecorder) QueryRow(_ context.Context, sql string, _ ...interface{}) pgx.Row {
+	r.t.Errorf("unexpected QueryRow: %s", sql)
+	return nil
+}
+
+func (r *copyRecorder) CopyFrom(_ context.Context, table pgx.Identifier, _ []string, src pgx.CopyFromSource) (int64, error) {
+	if table.Sanitize() != `"scan_findings"` {
+		r.t.Errorf("CopyFrom table = %s, want scan_findings", table.Sanitize())
+	}
+	var rows []InsertScanFindingsParams
+	for src.Next() {
+		values, err := src.Values()
+		if err != nil {
+			return 0, err
+		}
+		rows = append(rows, InsertScanFindingsParams{
+			ScanID:  values[0].(pgtype.Int4),
+			CheckID: values[7].(string),
+		})
+	}
+	r.copies = append(r.copies, rows)
+	return int64(len(rows)), src.Err()
+}
+
+func testFindings(n int) []InsertScanFindingsParams {
+	findings := make([]InsertScanFindingsParams, n)
+	for i := range findings {
+		findings[i] = InsertScanFindingsParams{
+			FindingID:  fmt.Sprintf("f%d", i),
+			Service:    "s3",
+			Region:     "us-east-1",
+			ResourceID: fmt.Sprintf("bucket-%d", i),
+			CheckID:    "s3_bucket_encryption",
+			Status:     "FAIL",
+			Severity:   "HIGH",
+			Title:      "S3 bucket encryption is not enabled",
+			Compliance: []string{"SOC2-CC6.1"},
+		}
+	}
+	return findings
+}
+
+func TestBatchInsertFindings(t *testing.T) {
+	tests := []struct {
+		name   string
+		count  int
+		copies []int
+	}{
+		{"none", 0, nil},
+		{"single batch", 1200, []int{1200}},
+		{"exact batch", findingsBatchSize, []int{findingsBatchSize}},
+		{"chunked", 2*findingsBatchSize + 1, []int{findingsBatchSize, findingsBatchSize, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &copyRecorder{t: t}
+			findings := testFindings(tt.count)
+
+			n, err := New(rec).BatchInsertFindings(context.Background(), 42, findings)
+			if err != nil {
+				t.Fatalf("BatchInsertFindings() error = %v", err)
+			}
+			if n != int64(tt.count) {
+				t.Errorf("inserted %d rows, want %d", n, tt.count)
+			}
+			if len(rec.copies) != len(tt.copies) {
+				t.Fatalf("made %d COPY calls, want %d", len(rec.copies), len(tt.copies))
+			}
+			for i, rows := range rec.copies {
+				if len(rows) != tt.copies[i] {
+					t.Errorf("COPY %d had %d rows, want %d", i, len(rows), tt.copies[i])
+				}
+				for _, row := range rows {
+					if row.ScanID != (pgtype.Int4{Int32: 42, Valid: true}) {
+						t.Fatalf("row has ScanID %+v, want 42", row.ScanID)
+					}
+				}
+			}
+			for _, f := range findings {
+				if f.ScanID.Valid {
+					t.Fatal("BatchInsertFindings modified the caller's findings")
+				}
+			}
+		})
+	}
+}
+
+// statementCounter is a pgx tracer counting the statements and COPYs a
+// connection runs.
+type statementCounter struct {
+	mu      sync.Mutex
+	inserts int
+	copies  int
+}
+
+func (c *statementCounter) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if strings.Contains(data.SQL, "INSERT INTO scan_findings") {
+		c.inserts++
+	}
+	return ctx
+}
+
+func (c *statementCounter) TraceQueryEnd(context.Context, *pgx.Conn, pgx.TraceQueryEndData) {}
+
+func (c *statementCounter) TraceCopyFromStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceCopyFromStartData) context.Context {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.copies++
+	return ctx
+}
+
+func (c *statementCounter) TraceCopyFromEnd(context.Context, *pgx.Conn, pgx.TraceCopyFromEndData) {}
+
+// testConn connects to the database in CLOUDCOP_TEST_DATABASE_URL, applying
+// the schema, or skips the test when it is not set.
+func testConn(t *testing.T, tracer *statementCounter) *pgx.Conn {
+	t.Helper()
+	url := os.Getenv("CLOUDCOP_TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("CLOUDCOP_TEST_DATABASE_URL is not set")
+	}
+	config, err := pgx.ParseConfig(url)
+	if err != nil {
+		t.Fatalf("parsing CLOUDCOP_TEST_DATABASE_URL: %v", err)
+	}
+	config.Tracer = tracer
+
+	ctx := context.Background()
+	conn, err := pgx.ConnectConfig(ctx, config)
+	if err != nil {
+		t.Fatalf("connecting to test database: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close(context.Background()) })
+
+	schema, err := os.ReadFile("schema.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.Exec(ctx, string(schema)); err != nil {
+		t.Fatalf("applying schema: %v", err)
+	}
+	return conn
+}
+
+func TestStore_CreateScanWithFindings(t *testing.T) {
+	tracer := &statementCounter{}
+	conn := testConn(t, tracer)
+	ctx := context.Background()
+	store := NewStore(conn)
+
+	const n = 2000
+	scan, err := store.CreateScanWithFindings(ctx, CreateScanParams{Status: "completed"}, testFindings(n))
+	if err != nil {
+		t.Fatalf("CreateScanWithFindings() error = %v", err)
+	}
+	t.Cleanup(func() { _, _ = conn.Exec(context.Background(), "DELETE FROM scans WHERE id = $1", scan.ID) })
+
+	if tracer.copies != 1 || tracer.inserts != 0 {
+		t.Errorf("inserting %d findings ran %d COPYs and %d INSERTs, want 1 COPY", n, tracer.copies, tracer.inserts)
+	}
+	findings, err := store.ListScanFindings(ctx, pgtype.Int4{Int32: scan.ID, Valid: true})
+	if err != nil {
+		t.Fatalf("ListScanFindings() error = %v", err)
+	}
+	if len(findings) != n {
+		t.Errorf("scan has %d findings, want %d", len(findings), n)
+	}
+}
+
+func TestStore_CreateScanWithFindings_RollsBack(t *testing.T) {
+	conn := testConn(t, &statementCounter{})
+	ctx := context.Background()
+
+	countScans := func() int {
+		var count int
+		if err := conn.QueryRow(ctx, "SELECT count(*) FROM scans").Scan(&count); err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+	before := countScans()
+
+	// PostgreSQL rejects NUL bytes in text, failing the COPY after the scan
+	// row has been inserted.
+	findings := testFindings(3)
+	findings[2].Title = "bad\x00title"
+	if _, err := NewStore(conn).CreateScanWithFindings(ctx, CreateScanParams{Status: "completed"}, findings); err == nil {
+		t.Fatal("CreateScanWithFindings() error = nil, want the COPY to fail")
+	}
+	if after := countScans(); after != before {
+		t.Errorf("scans went from %d to %d, want the failed scan rolled back", before, after)
+	}
+}
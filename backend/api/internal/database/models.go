@@ -37,34 +37,72 @@ type ChatMessage struct {
 	CreatedAt      pgtype.Timestamp
 }
 
+type FindingSuppression struct {
+	ID        int32
+	TeamID    int32
+	FindingID string
+	Reason    string
+	ExpiresAt pgtype.Timestamp
+	CreatedBy pgtype.Text
+	CreatedAt pgtype.Timestamp
+}
+
 type Scan struct {
+	ID             int32
+	AwsAccountID   pgtype.Int4
+	Status         string
+	Services       []string
+	Regions        []string
+	OverallScore   pgtype.Int4
+	TotalChecks    int32
+	PassedChecks   int32
+	FailedChecks   int32
+	ErrorChecks    int32
+	CriticalFailed int32
+	HighFailed     int32
+	MediumFailed   int32
+	LowFailed      int32
+	StartedAt      pgtype.Timestamp
+	CompletedAt    pgtype.Timestamp
+	CreatedAt      pgtype.Timestamp
+}
+
+type ScanFinding struct {
+	ID                int32
+	ScanID            pgtype.Int4
+	FindingID         string
+	Service           string
+	Region            string
+	ResourceID        string
+	ResourceArn       pgtype.Text
+	ResourceCreatedAt pgtype.Timestamp
+	CheckID           string
+	Status            string
+	Severity          string
+	Title             string
+	Description       pgtype.Text
+	Compliance        []string
+	CreatedAt         pgtype.Timestamp
+}
+
+type ScanAiSummary struct {
+	ScanID    int32
+	Summary   []byte
+	CreatedAt pgtype.Timestamp
+}
+
+type ScanSchedule struct {
 	ID           int32
-	AwsAccountID pgtype.Int4
-	Status       string
+	AwsAccountID int32
+	CronExpr     string
 	Services     []string
 	Regions      []string
-	OverallScore pgtype.Int4
-	StartedAt    pgtype.Timestamp
-	CompletedAt  pgtype.Timestamp
+	Enabled      bool
+	NextRunAt    pgtype.Timestamp
+	LastRunAt    pgtype.Timestamp
 	CreatedAt    pgtype.Timestamp
 }
 
-type ScanFinding struct {
-	ID          int32
-	ScanID      pgtype.Int4
-	Service     string
-	Region      string
-	ResourceID  string
-	ResourceArn pgtype.Text
-	CheckID     string
-	Status      string
-	Severity    string
-	Title       string
-	Description pgtype.Text
-	Compliance  []string
-	CreatedAt   pgtype.Timestamp
-}
-
 type Team struct {
 	ID        int32
 	Name      string
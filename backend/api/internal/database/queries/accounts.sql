@@ -15,6 +15,14 @@ INSERT INTO aws_accounts (team_id, account_id, external_id, role_arn, verified,
 VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
+-- name: GetAccountByAccountID :one
+SELECT * FROM aws_accounts
+WHERE account_id = $1 LIMIT 1;
+
+-- name: GetAccountByID :one
+SELECT * FROM aws_accounts
+WHERE id = $1 LIMIT 1;
+
 -- name: GetAccountByExternalID :one
 SELECT * FROM aws_accounts
 WHERE external_id = $1 LIMIT 1;
@@ -23,13 +31,37 @@ WHERE external_id = $1 LIMIT 1;
 SELECT * FROM aws_accounts
 WHERE team_id = $1;
 
--- name: DeleteAccount :exec
+-- name: GetAccountByTeamAndAccountID :one
+SELECT * FROM aws_accounts
+WHERE team_id = $1 AND account_id = $2 LIMIT 1;
+
+-- name: UpdateAccountLastVerified :exec
+UPDATE aws_accounts
+SET verified = TRUE, last_verified_at = $1
+WHERE team_id = $2 AND account_id = $3;
+
+-- name: UpdateAccountConnection :one
+UPDATE aws_accounts
+SET external_id = $2, role_arn = $3, verified = TRUE, last_verified_at = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteAccount :one
 DELETE FROM aws_accounts
-WHERE account_id = $1 AND team_id = $2;
+WHERE account_id = $1 AND team_id = $2
+RETURNING *;
+
+-- name: DeleteAccountsByTeamID :many
+DELETE FROM aws_accounts
+WHERE team_id = $1
+RETURNING *;
 
 -- name: CreateTeam :one
 INSERT INTO teams (name, slug, owner_id)
 VALUES ($1, $2, $3)
+ON CONFLICT (slug) DO UPDATE SET
+    slug = EXCLUDED.slug
+WHERE teams.owner_id = EXCLUDED.owner_id
 RETURNING *;
 
 -- name: GetTeamByOwnerID :one
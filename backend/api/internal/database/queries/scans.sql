@@ -0,0 +1,48 @@
+-- name: CreateScan :one
+INSERT INTO scans (aws_account_id, status, services, regions, overall_score, total_checks, passed_checks, failed_checks, error_checks, critical_failed, high_failed, medium_failed, low_failed, started_at, completed_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+RETURNING *;
+
+-- name: CreateScanFinding :exec
+INSERT INTO scan_findings (scan_id, finding_id, service, region, resource_id, resource_arn, resource_created_at, check_id, status, severity, title, description, compliance)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13);
+
+-- name: InsertScanFindings :copyfrom
+INSERT INTO scan_findings (scan_id, finding_id, service, region, resource_id, resource_arn, resource_created_at, check_id, status, severity, title, description, compliance)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13);
+
+-- name: GetLatestScansForTeam :many
+SELECT s.* FROM scans s
+JOIN aws_accounts a ON a.id = s.aws_account_id
+WHERE a.account_id = $1 AND a.team_id = $2 AND s.status = 'completed'
+ORDER BY s.completed_at DESC, s.id DESC
+LIMIT $3;
+
+-- name: GetScanForTeam :one
+SELECT s.* FROM scans s
+JOIN aws_accounts a ON a.id = s.aws_account_id
+WHERE s.id = $1 AND a.team_id = $2
+LIMIT 1;
+
+-- name: ListScansForTeam :many
+SELECT s.* FROM scans s
+JOIN aws_accounts a ON a.id = s.aws_account_id
+WHERE a.account_id = $1 AND a.team_id = $2
+ORDER BY s.created_at DESC, s.id DESC
+LIMIT $3 OFFSET $4;
+
+-- name: ListScanFindings :many
+SELECT * FROM scan_findings
+WHERE scan_id = $1
+ORDER BY id;
+
+-- name: UpsertScanSummary :exec
+INSERT INTO scan_ai_summaries (scan_id, summary)
+VALUES ($1, $2)
+ON CONFLICT (scan_id) DO UPDATE SET
+    summary = EXCLUDED.summary,
+    created_at = CURRENT_TIMESTAMP;
+
+-- name: GetScanSummary :one
+SELECT summary FROM scan_ai_summaries
+WHERE scan_id = $1 LIMIT 1;
@@ -0,0 +1,28 @@
+-- name: CreateScanSchedule :one
+INSERT INTO scan_schedules (aws_account_id, cron_expr, services, regions, next_run_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListScanSchedulesByAccount :many
+SELECT * FROM scan_schedules
+WHERE aws_account_id = $1
+ORDER BY id;
+
+-- name: DeleteScanSchedule :execrows
+DELETE FROM scan_schedules
+WHERE id = $1 AND aws_account_id = $2;
+
+-- name: ListDueScanSchedules :many
+SELECT s.id, s.aws_account_id, s.cron_expr, s.services, s.regions, s.next_run_at, a.account_id, a.team_id
+FROM scan_schedules s
+JOIN aws_accounts a ON a.id = s.aws_account_id
+WHERE s.enabled AND s.next_run_at <= $1
+ORDER BY s.next_run_at;
+
+-- name: ClaimScanScheduleRun :execrows
+-- Advances a due schedule to its next run. It only matches while next_run_at
+-- still holds the due time the caller read, so when several servers see the
+-- same due schedule exactly one claims it.
+UPDATE scan_schedules
+SET last_run_at = @last_run_at, next_run_at = @next_run_at
+WHERE id = @id AND next_run_at = @due_at;
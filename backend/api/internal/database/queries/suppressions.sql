@@ -0,0 +1,17 @@
+-- name: UpsertFindingSuppression :one
+INSERT INTO finding_suppressions (team_id, finding_id, reason, expires_at, created_by)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (team_id, finding_id) DO UPDATE SET
+    reason = EXCLUDED.reason,
+    expires_at = EXCLUDED.expires_at,
+    created_by = EXCLUDED.created_by
+RETURNING *;
+
+-- name: DeleteFindingSuppression :execrows
+DELETE FROM finding_suppressions
+WHERE team_id = $1 AND finding_id = $2;
+
+-- name: ListFindingSuppressionsByTeam :many
+SELECT * FROM finding_suppressions
+WHERE team_id = $1
+ORDER BY id;
@@ -44,6 +44,17 @@ CREATE TABLE IF NOT EXISTS scans (
   services TEXT[], -- Array of services scanned
   regions TEXT[], -- Array of regions scanned
   overall_score INTEGER,
+  -- Check counts cover every finding, including any not persisted below the
+  -- configured minimum severity
+  total_checks INTEGER NOT NULL DEFAULT 0,
+  passed_checks INTEGER NOT NULL DEFAULT 0,
+  failed_checks INTEGER NOT NULL DEFAULT 0,
+  error_checks INTEGER NOT NULL DEFAULT 0,
+  -- Failed checks by severity, from which overall_score is computed
+  critical_failed INTEGER NOT NULL DEFAULT 0,
+  high_failed INTEGER NOT NULL DEFAULT 0,
+  medium_failed INTEGER NOT NULL DEFAULT 0,
+  low_failed INTEGER NOT NULL DEFAULT 0,
   started_at TIMESTAMP,
   completed_at TIMESTAMP,
   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
@@ -53,10 +64,12 @@ CREATE TABLE IF NOT EXISTS scans (
 CREATE TABLE IF NOT EXISTS scan_findings (
   id SERIAL PRIMARY KEY,
   scan_id INTEGER REFERENCES scans(id) ON DELETE CASCADE,
+  finding_id TEXT NOT NULL DEFAULT '', -- Stable across scans of the same issue
   service TEXT NOT NULL,
   region TEXT NOT NULL,
   resource_id TEXT NOT NULL,
   resource_arn TEXT,
+  resource_created_at TIMESTAMP, -- When the resource was created, if AWS reports it
   check_id TEXT NOT NULL,
   status TEXT NOT NULL, -- 'PASS', 'FAIL'
   severity TEXT NOT NULL, -- 'LOW', 'MEDIUM', 'HIGH', 'CRITICAL'
@@ -66,6 +79,41 @@ CREATE TABLE IF NOT EXISTS scan_findings (
   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
+-- Scan Summaries (AI-generated; may be added after the scan completes)
+CREATE TABLE IF NOT EXISTS scan_ai_summaries (
+  scan_id INTEGER PRIMARY KEY REFERENCES scans(id) ON DELETE CASCADE,
+  summary JSONB NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Scan Schedules (recurring scans; cron expressions are evaluated in UTC)
+CREATE TABLE IF NOT EXISTS scan_schedules (
+  id SERIAL PRIMARY KEY,
+  aws_account_id INTEGER NOT NULL REFERENCES aws_accounts(id) ON DELETE CASCADE,
+  cron_expr TEXT NOT NULL,
+  services TEXT[] NOT NULL,
+  regions TEXT[] NOT NULL,
+  enabled BOOLEAN NOT NULL DEFAULT TRUE,
+  next_run_at TIMESTAMP NOT NULL,
+  last_run_at TIMESTAMP,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS scan_schedules_next_run_at_idx ON scan_schedules (next_run_at) WHERE enabled;
+
+-- Finding Suppressions (accepted risks; scans skip a suppressed finding ID
+-- until expires_at, or indefinitely when it is NULL)
+CREATE TABLE IF NOT EXISTS finding_suppressions (
+  id SERIAL PRIMARY KEY,
+  team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+  finding_id TEXT NOT NULL,
+  reason TEXT NOT NULL,
+  expires_at TIMESTAMP,
+  created_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE(team_id, finding_id)
+);
+
 -- Chat Conversations
 CREATE TABLE IF NOT EXISTS chat_conversations (
   id SERIAL PRIMARY KEY,
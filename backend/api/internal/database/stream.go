@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// This file holds hand-written queries that sqlc cannot express, such as
+// row-by-row callbacks that avoid materializing large result sets.
+
+const streamScanFindings = `
+SELECT id, scan_id, finding_id, service, region, resource_id, resource_arn, resource_created_at, check_id, status, severity, title, description, compliance, created_at
+FROM scan_findings
+WHERE scan_id = $1
+  AND ($2::bool = FALSE OR status = 'FAIL')
+  AND ($3::text = '' OR service = $3)
+  AND ($4::text = '' OR severity = $4)
+  AND ($5::text = '' OR status = $5)
+ORDER BY id
+`
+
+// StreamScanFindingsParams selects the findings streamed for a scan. Empty
+// Service, Severity, and Status match every finding.
+type StreamScanFindingsParams struct {
+	ScanID       pgtype.Int4
+	OnlyFailures bool
+	Service      string
+	Severity     string
+	Status       string
+}
+
+// StreamScanFindings calls fn for each finding of a scan in insertion order,
+// reading rows one at a time so memory use does not grow with the scan size.
+// Iteration stops at the first error returned by fn.
+func (q *Queries) StreamScanFindings(ctx context.Context, arg StreamScanFindingsParams, fn func(ScanFinding) error) error {
+	rows, err := q.db.Query(ctx, streamScanFindings,
+		arg.ScanID,
+		arg.OnlyFailures,
+		arg.Service,
+		arg.Severity,
+		arg.Status,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i ScanFinding
+		if err := rows.Scan(
+			&i.ID,
+			&i.ScanID,
+			&i.FindingID,
+			&i.Service,
+			&i.Region,
+			&i.ResourceID,
+			&i.ResourceArn,
+			&i.ResourceCreatedAt,
+			&i.CheckID,
+			&i.Status,
+			&i.Severity,
+			&i.Title,
+			&i.Description,
+			&i.Compliance,
+			&i.CreatedAt,
+		); err != nil {
+			return err
+		}
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
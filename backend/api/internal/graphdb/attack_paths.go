@@ -0,0 +1,200 @@
+package graphdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// GraphStore persists scanned resources and findings as a graph and answers
+// reachability queries over it.
+type GraphStore interface {
+	UpsertResource(ctx context.Context, resource Resource) error
+	UpsertFinding(ctx context.Context, accountID string, finding scanner.Finding) error
+	FindAttackPaths(ctx context.Context, accountID string) ([]AttackPath, error)
+}
+
+// queryExecutor runs a Cypher statement and returns every resulting record.
+type queryExecutor interface {
+	ExecuteQuery(ctx context.Context, query string, params map[string]any) ([]*neo4j.Record, error)
+}
+
+// Resource is a node in the security graph.
+type Resource struct {
+	// ID is the AWS resource identifier (ARN or ID).
+	ID string
+	// AccountID is the AWS account that owns the resource.
+	AccountID string
+	// Service is the AWS service name (e.g., "ec2").
+	Service string
+	// Region is the AWS region of the resource.
+	Region string
+	// Type distinguishes resources within a service (e.g., "instance", "security_group").
+	Type string
+	// InternetFacing marks resources directly reachable from the internet.
+	InternetFacing bool
+	// Sensitive marks resources that hold data or privileges worth protecting.
+	Sensitive bool
+	// Reaches lists resource IDs that this resource can reach, e.g. an
+	// instance reaching the bucket its role can read.
+	Reaches []string
+}
+
+// AttackPath is a chain of resources leading from the internet to a sensitive resource.
+type AttackPath struct {
+	// Target is the sensitive resource at the end of the path.
+	Target string
+	// Hops lists resource IDs from the internet-facing entry point to Target.
+	Hops []string
+	// FailedChecks lists failed check IDs on resources along the path.
+	FailedChecks []string
+}
+
+// maxAttackPathHops bounds the variable-length match in FindAttackPaths.
+// Cypher does not accept parameters for relationship length bounds.
+const maxAttackPathHops = 6
+
+// upsertResourceQuery replaces the resource's internet exposure and outgoing
+// REACHES edges with those of the latest scan, so an edge the resource no
+// longer has cannot keep an attack path alive.
+const upsertResourceQuery = `
+MERGE (r:Resource {id: $id, account_id: $accountID})
+SET r.service = $service, r.region = $region, r.type = $type,
+    r.internet_facing = $internetFacing, r.sensitive = $sensitive
+WITH r
+OPTIONAL MATCH (r)-[out:REACHES]->(:Resource)
+DELETE out
+WITH DISTINCT r
+OPTIONAL MATCH (:Internet {account_id: $accountID})-[exposed:REACHES]->(r)
+DELETE exposed
+WITH DISTINCT r
+CALL {
+  WITH r
+  WITH r WHERE $internetFacing
+  MERGE (i:Internet {account_id: $accountID})
+  MERGE (i)-[:REACHES]->(r)
+}
+WITH r
+UNWIND $reaches AS targetID
+MERGE (t:Resource {id: targetID, account_id: $accountID})
+MERGE (r)-[:REACHES]->(t)`
+
+const upsertFindingQuery = `
+MERGE (r:Resource {id: $resourceID, account_id: $accountID})
+ON CREATE SET r.service = $service, r.region = $region
+MERGE (f:Finding {check_id: $checkID, resource_id: $resourceID, account_id: $accountID})
+SET f.status = $status, f.severity = $severity, f.title = $title, f.detected_at = $detectedAt
+MERGE (r)-[:HAS_FINDING]->(f)`
+
+var findAttackPathsQuery = fmt.Sprintf(`
+MATCH p = (:Internet {account_id: $accountID})-[:REACHES*1..%d]->(target:Resource {account_id: $accountID, sensitive: true})
+WITH p, target, nodes(p)[1..] AS hops
+RETURN target.id AS target,
+       [n IN hops | n.id] AS hops,
+       [n IN hops | [(n)-[:HAS_FINDING]->(f:Finding {status: 'FAIL'}) | f.check_id]] AS failed_checks
+ORDER BY length(p)`, maxAttackPathHops)
+
+// Store is the Neo4j-backed GraphStore.
+type Store struct {
+	exec queryExecutor
+}
+
+var _ GraphStore = (*Store)(nil)
+
+// NewStore returns a Store that runs queries through client.
+func NewStore(client *Neo4jClient) *Store {
+	return &Store{exec: client}
+}
+
+// UpsertResource creates or updates a resource node along with its internet
+// exposure and outgoing reachability edges, dropping edges from earlier
+// scans that resource no longer has.
+func (s *Store) UpsertResource(ctx context.Context, resource Resource) error {
+	reaches := resource.Reaches
+	if reaches == nil {
+		reaches = []string{}
+	}
+	_, err := s.exec.ExecuteQuery(ctx, upsertResourceQuery, map[string]any{
+		"id":             resource.ID,
+		"accountID":      resource.AccountID,
+		"service":        resource.Service,
+		"region":         resource.Region,
+		"type":           resource.Type,
+		"internetFacing": resource.InternetFacing,
+		"sensitive":      resource.Sensitive,
+		"reaches":        reaches,
+	})
+	if err != nil {
+		return fmt.Errorf("upserting resource %s: %w", resource.ID, err)
+	}
+	return nil
+}
+
+// UpsertFinding attaches a finding to its resource node, creating the
+// resource if it has not been seen yet.
+func (s *Store) UpsertFinding(ctx context.Context, accountID string, finding scanner.Finding) error {
+	_, err := s.exec.ExecuteQuery(ctx, upsertFindingQuery, map[string]any{
+		"resourceID": finding.ResourceID,
+		"accountID":  accountID,
+		"service":    finding.Service,
+		"region":     finding.Region,
+		"checkID":    finding.CheckID,
+		"status":     string(finding.Status),
+		"severity":   string(finding.Severity),
+		"title":      finding.Title,
+		"detectedAt": finding.Timestamp.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return fmt.Errorf("upserting finding %s on %s: %w", finding.CheckID, finding.ResourceID, err)
+	}
+	return nil
+}
+
+// FindAttackPaths returns paths from the internet to sensitive resources in
+// the account, shortest first.
+func (s *Store) FindAttackPaths(ctx context.Context, accountID string) ([]AttackPath, error) {
+	records, err := s.exec.ExecuteQuery(ctx, findAttackPathsQuery, map[string]any{"accountID": accountID})
+	if err != nil {
+		return nil, fmt.Errorf("finding attack paths: %w", err)
+	}
+
+	paths := make([]AttackPath, 0, len(records))
+	for _, rec := range records {
+		target, _, err := neo4j.GetRecordValue[string](rec, "target")
+		if err != nil {
+			return nil, fmt.Errorf("reading attack path target: %w", err)
+		}
+		hops, _, err := neo4j.GetRecordValue[[]any](rec, "hops")
+		if err != nil {
+			return nil, fmt.Errorf("reading attack path hops: %w", err)
+		}
+		checks, _, err := neo4j.GetRecordValue[[]any](rec, "failed_checks")
+		if err != nil {
+			return nil, fmt.Errorf("reading attack path checks: %w", err)
+		}
+
+		path := AttackPath{Target: target, Hops: toStrings(hops)}
+		for _, perHop := range checks {
+			if list, ok := perHop.([]any); ok {
+				path.FailedChecks = append(path.FailedChecks, toStrings(list)...)
+			}
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// toStrings keeps the string elements of a Cypher list.
+func toStrings(values []any) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
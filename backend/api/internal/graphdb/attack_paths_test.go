@@ -0,0 +1,141 @@
+package graphdb
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+type executedQuery struct {
+	query  string
+	params map[string]any
+}
+
+// fakeExecutor records queries and returns canned records.
+type fakeExecutor struct {
+	calls   []executedQuery
+	records []*neo4j.Record
+}
+
+func (f *fakeExecutor) ExecuteQuery(_ context.Context, query string, params map[string]any) ([]*neo4j.Record, error) {
+	f.calls = append(f.calls, executedQuery{query: query, params: params})
+	return f.records, nil
+}
+
+func TestStore_UpsertResource(t *testing.T) {
+	exec := &fakeExecutor{}
+	store := &Store{exec: exec}
+
+	err := store.UpsertResource(context.Background(), Resource{
+		ID:             "i-123",
+		AccountID:      "123456789012",
+		Service:        "ec2",
+		Region:         "us-east-1",
+		Type:           "instance",
+		InternetFacing: true,
+		Reaches:        []string{"arn:aws:s3:::customer-data"},
+	})
+	if err != nil {
+		t.Fatalf("UpsertResource() error = %v", err)
+	}
+
+	if len(exec.calls) != 1 {
+		t.Fatalf("expected 1 query, got %d", len(exec.calls))
+	}
+	params := exec.calls[0].params
+	if params["id"] != "i-123" || params["accountID"] != "123456789012" || params["internetFacing"] != true {
+		t.Errorf("unexpected params: %+v", params)
+	}
+	if !reflect.DeepEqual(params["reaches"], []string{"arn:aws:s3:::customer-data"}) {
+		t.Errorf("reaches = %v", params["reaches"])
+	}
+
+	// Stale edges are deleted before the current ones are merged.
+	query := exec.calls[0].query
+	deleteOut := strings.Index(query, "DELETE out")
+	deleteExposed := strings.Index(query, "DELETE exposed")
+	mergeReaches := strings.Index(query, "MERGE (r)-[:REACHES]->(t)")
+	mergeInternet := strings.Index(query, "MERGE (i)-[:REACHES]->(r)")
+	if deleteOut < 0 || deleteExposed < 0 || deleteOut > mergeReaches || deleteExposed > mergeInternet {
+		t.Errorf("query does not delete old REACHES edges before merging new ones:\n%s", query)
+	}
+}
+
+func TestStore_UpsertFinding(t *testing.T) {
+	exec := &fakeExecutor{}
+	store := &Store{exec: exec}
+
+	err := store.UpsertFinding(context.Background(), "123456789012", scanner.Finding{
+		Service:    "ec2",
+		Region:     "us-east-1",
+		ResourceID: "sg-123",
+		CheckID:    "ec2_sg_unrestricted_ingress",
+		Status:     scanner.StatusFail,
+		Severity:   scanner.SeverityHigh,
+		Title:      "Security group allows unrestricted ingress",
+		Timestamp:  time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("UpsertFinding() error = %v", err)
+	}
+
+	params := exec.calls[0].params
+	want := map[string]any{
+		"resourceID": "sg-123",
+		"accountID":  "123456789012",
+		"service":    "ec2",
+		"region":     "us-east-1",
+		"checkID":    "ec2_sg_unrestricted_ingress",
+		"status":     "FAIL",
+		"severity":   "HIGH",
+		"title":      "Security group allows unrestricted ingress",
+		"detectedAt": "2024-06-01T12:00:00Z",
+	}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("params = %+v, want %+v", params, want)
+	}
+}
+
+func TestStore_FindAttackPaths(t *testing.T) {
+	exec := &fakeExecutor{
+		records: []*neo4j.Record{
+			{
+				Keys: []string{"target", "hops", "failed_checks"},
+				Values: []any{
+					"arn:aws:s3:::customer-data",
+					[]any{"sg-123", "i-123", "arn:aws:s3:::customer-data"},
+					[]any{[]any{"ec2_sg_unrestricted_ingress"}, []any{"ec2_public_ip", "ec2_imdsv2_required"}, []any{}},
+				},
+			},
+		},
+	}
+	store := &Store{exec: exec}
+
+	paths, err := store.FindAttackPaths(context.Background(), "123456789012")
+	if err != nil {
+		t.Fatalf("FindAttackPaths() error = %v", err)
+	}
+
+	call := exec.calls[0]
+	if call.params["accountID"] != "123456789012" || len(call.params) != 1 {
+		t.Errorf("params = %+v, want only accountID", call.params)
+	}
+	if !strings.Contains(call.query, ":Internet") || !strings.Contains(call.query, "sensitive: true") {
+		t.Errorf("query does not match internet-to-sensitive paths:\n%s", call.query)
+	}
+
+	want := []AttackPath{{
+		Target:       "arn:aws:s3:::customer-data",
+		Hops:         []string{"sg-123", "i-123", "arn:aws:s3:::customer-data"},
+		FailedChecks: []string{"ec2_sg_unrestricted_ingress", "ec2_public_ip", "ec2_imdsv2_required"},
+	}}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("paths = %+v, want %+v", paths, want)
+	}
+}
@@ -35,6 +35,11 @@ func (c *Neo4jClient) Close(ctx context.Context) error {
 	return c.driver.Close(ctx)
 }
 
+// VerifyConnectivity checks that the driver can reach the Neo4j server.
+func (c *Neo4jClient) VerifyConnectivity(ctx context.Context) error {
+	return c.driver.VerifyConnectivity(ctx)
+}
+
 // RunQuery executes a Cypher query with the provided parameters.
 func (c *Neo4jClient) RunQuery(ctx context.Context, query string, params map[string]interface{}) (neo4j.ResultWithContext, error) {
 	session := c.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
@@ -50,3 +55,13 @@ func (c *Neo4jClient) RunQuery(ctx context.Context, query string, params map[str
 
 	return result, nil
 }
+
+// ExecuteQuery runs a Cypher statement in a managed transaction and returns
+// all records, so callers never hold a result past its session.
+func (c *Neo4jClient) ExecuteQuery(ctx context.Context, query string, params map[string]any) ([]*neo4j.Record, error) {
+	result, err := neo4j.ExecuteQuery(ctx, c.driver, query, params, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+	return result.Records, nil
+}
@@ -12,6 +12,8 @@ func (c *Neo4jClient) InitializeSchema(ctx context.Context) error {
 		"CREATE INDEX IF NOT EXISTS FOR (n:EC2Instance) ON (n.region)",
 		"CREATE CONSTRAINT IF NOT EXISTS FOR (n:S3Bucket) REQUIRE n.name IS UNIQUE",
 		"CREATE CONSTRAINT IF NOT EXISTS FOR (n:IAMRole) REQUIRE n.arn IS UNIQUE",
+		"CREATE CONSTRAINT IF NOT EXISTS FOR (n:Resource) REQUIRE (n.id, n.account_id) IS UNIQUE",
+		"CREATE INDEX IF NOT EXISTS FOR (n:Finding) ON (n.account_id, n.resource_id)",
 	}
 
 	for _, q := range queries {
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/http"
 	"time"
@@ -11,23 +12,58 @@ import (
 	"cloudcop/api/internal/middleware/auth"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+// accountVerifier checks that CloudCop can access a customer AWS account.
+type accountVerifier interface {
+	VerifyAccountAccess(ctx context.Context, input awsauth.AssumeRoleInput) (*awsauth.AccountInfo, error)
+	RecheckAccountAccess(ctx context.Context, input awsauth.AssumeRoleInput) (*awsauth.AccountInfo, error)
+}
+
+// accountIdentifier resolves the AWS account of CloudCop's own credentials.
+type accountIdentifier interface {
+	GetAccountID(ctx context.Context) (string, error)
+}
+
+// accountStore is the subset of database queries used by AccountsHandler.
+type accountStore interface {
+	CreateUser(ctx context.Context, arg database.CreateUserParams) (database.User, error)
+	GetTeamByOwnerID(ctx context.Context, ownerID string) (database.Team, error)
+	CreateTeam(ctx context.Context, arg database.CreateTeamParams) (database.Team, error)
+	AddTeamMember(ctx context.Context, arg database.AddTeamMemberParams) (database.TeamMember, error)
+	CreateAccount(ctx context.Context, arg database.CreateAccountParams) (database.AwsAccount, error)
+	GetAccountsByTeamID(ctx context.Context, teamID pgtype.Int4) ([]database.AwsAccount, error)
+	GetAccountByTeamAndAccountID(ctx context.Context, arg database.GetAccountByTeamAndAccountIDParams) (database.AwsAccount, error)
+	UpdateAccountLastVerified(ctx context.Context, arg database.UpdateAccountLastVerifiedParams) error
+	UpdateAccountConnection(ctx context.Context, arg database.UpdateAccountConnectionParams) (database.AwsAccount, error)
+	DeleteAccount(ctx context.Context, arg database.DeleteAccountParams) (database.AwsAccount, error)
+	DeleteAccountsByTeamID(ctx context.Context, teamID pgtype.Int4) ([]database.AwsAccount, error)
+}
+
+// credentialInvalidator drops cached credentials for disconnected accounts.
+type credentialInvalidator interface {
+	InvalidateCredentials(accountID, externalID string)
+	InvalidateByAccount(accountID string)
+}
+
 // AccountsHandler manages AWS account connection endpoints
 type AccountsHandler struct {
-	auth  *awsauth.AWSAuth
-	cache *awsauth.CredentialCache
-	store *database.Queries
+	auth     accountVerifier
+	identity accountIdentifier
+	cache    credentialInvalidator
+	store    accountStore
 }
 
 // NewAccountsHandler constructs an AccountsHandler wired with the provided AWS authentication helper,
 // credential cache, and database queries. It returns a handler ready to be registered with HTTP routes.
 func NewAccountsHandler(auth *awsauth.AWSAuth, cache *awsauth.CredentialCache, store *database.Queries) *AccountsHandler {
 	return &AccountsHandler{
-		auth:  auth,
-		cache: cache,
-		store: store,
+		auth:     auth,
+		identity: auth,
+		cache:    cache,
+		store:    store,
 	}
 }
 
@@ -95,7 +131,9 @@ func (h *AccountsHandler) VerifyAccountHandler(c *gin.Context) {
 	})
 }
 
-// ConnectAccountHandler creates a new AWS account connection
+// ConnectAccountHandler creates a new AWS account connection. It is safe to
+// retry: connecting an account the team already has stores the newly verified
+// external ID and role and returns the existing connection with 200.
 // POST /api/accounts/connect
 func (h *AccountsHandler) ConnectAccountHandler(c *gin.Context) {
 	user := auth.FromContext(c.Request.Context())
@@ -134,7 +172,9 @@ func (h *AccountsHandler) ConnectAccountHandler(c *gin.Context) {
 	// Ensure Team exists (MVP: Auto-create team for user if not exists)
 	team, err := h.store.GetTeamByOwnerID(c.Request.Context(), user.ID)
 	if err != nil {
-		// If not found, create
+		// If not found, create. CreateTeam returns the existing team when a
+		// concurrent request has just created it, and no row when the slug
+		// belongs to another owner's team.
 		slug := user.ID // simplified slug
 		team, err = h.store.CreateTeam(c.Request.Context(), database.CreateTeamParams{
 			Name:    name + "'s Team",
@@ -153,33 +193,89 @@ func (h *AccountsHandler) ConnectAccountHandler(c *gin.Context) {
 		})
 	}
 
+	teamID := pgtype.Int4{Int32: team.ID, Valid: true}
+	verifiedAt := pgtype.Timestamp{Time: time.Now(), Valid: true}
+
+	// A repeated connect, such as a client retrying after a network error,
+	// refreshes the existing connection instead of failing on the duplicate.
+	existing, err := h.store.GetAccountByTeamAndAccountID(c.Request.Context(), database.GetAccountByTeamAndAccountIDParams{
+		TeamID:    teamID,
+		AccountID: accountInfo.AccountID,
+	})
+	switch {
+	case err == nil:
+		h.reconnectAccount(c, existing, req.ExternalID, accountInfo.ARN, verifiedAt, user.ID)
+		return
+	case !errors.Is(err, pgx.ErrNoRows):
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up account connection"})
+		return
+	}
+
 	// Store connection in DB
 	acct, err := h.store.CreateAccount(c.Request.Context(), database.CreateAccountParams{
-		TeamID:         pgtype.Int4{Int32: team.ID, Valid: true},
+		TeamID:         teamID,
 		AccountID:      accountInfo.AccountID,
 		ExternalID:     req.ExternalID, // Use the verified external ID from request
 		RoleArn:        pgtype.Text{String: accountInfo.ARN, Valid: true},
 		Verified:       pgtype.Bool{Bool: true, Valid: true},
-		LastVerifiedAt: pgtype.Timestamp{Time: time.Now(), Valid: true},
+		LastVerifiedAt: verifiedAt,
 	})
 	if err != nil {
+		// A concurrent retry may have created the connection since the lookup.
+		existing, lookupErr := h.store.GetAccountByTeamAndAccountID(c.Request.Context(), database.GetAccountByTeamAndAccountIDParams{
+			TeamID:    teamID,
+			AccountID: accountInfo.AccountID,
+		})
+		if lookupErr == nil {
+			h.reconnectAccount(c, existing, req.ExternalID, accountInfo.ARN, verifiedAt, user.ID)
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store account connection"})
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"success": true,
-		"message": "Account connection created successfully",
-		"connection": gin.H{
-			"id":         acct.ID,
-			"account_id": acct.AccountID,
-			"arn":        acct.RoleArn.String,
-			"verified":   acct.Verified.Bool,
-			"user_id":    user.ID,
-		},
+		"success":    true,
+		"message":    "Account connection created successfully",
+		"connection": connectionResponse(acct, user.ID),
+	})
+}
+
+// reconnectAccount stores the external ID and role ARN just verified for an
+// already connected account and responds with the updated connection. The
+// credentials cached for the connection's previous external ID are dropped,
+// so scans assume the role the way it was last verified.
+func (h *AccountsHandler) reconnectAccount(c *gin.Context, acct database.AwsAccount, externalID, roleArn string, verifiedAt pgtype.Timestamp, userID string) {
+	updated, err := h.store.UpdateAccountConnection(c.Request.Context(), database.UpdateAccountConnectionParams{
+		ID:             acct.ID,
+		ExternalID:     externalID,
+		RoleArn:        pgtype.Text{String: roleArn, Valid: true},
+		LastVerifiedAt: verifiedAt,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update account connection"})
+		return
+	}
+	h.invalidateAccount(acct)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"message":    "Account already connected",
+		"connection": connectionResponse(updated, userID),
 	})
 }
 
+// connectionResponse is the JSON form of a connected account.
+func connectionResponse(acct database.AwsAccount, userID string) gin.H {
+	return gin.H{
+		"id":         acct.ID,
+		"account_id": acct.AccountID,
+		"arn":        acct.RoleArn.String,
+		"verified":   acct.Verified.Bool,
+		"user_id":    userID,
+	}
+}
+
 // ListAccountsHandler lists all connected AWS accounts for the authenticated user
 // GET /api/accounts
 func (h *AccountsHandler) ListAccountsHandler(c *gin.Context) {
@@ -249,27 +345,137 @@ func (h *AccountsHandler) DisconnectAccountHandler(c *gin.Context) {
 		return
 	}
 
-	// Find the account first to get ExternalID for cache invalidation
-	// We might need a GetAccountByID query, but for now assuming we pass AWS Account ID or DB ID?
-	// The route param is :id. Let's assume it's the AWS Account ID string for simplicity in this MVP,
-	// or we add a query to get by DB ID.
-	// Let's assume it's the AWS Account ID for now strictly.
-
-	// Invalidate credentials
-	h.cache.InvalidateCredentials(accountIDParam, "")
-
-	// Delete from DB
-	err = h.store.DeleteAccount(c.Request.Context(), database.DeleteAccountParams{
+	// The route param is the AWS Account ID. Only the team's own connection
+	// is deleted, and its credentials are invalidated once it is gone.
+	acct, err := h.store.DeleteAccount(c.Request.Context(), database.DeleteAccountParams{
 		AccountID: accountIDParam,
 		TeamID:    pgtype.Int4{Int32: team.ID, Valid: true},
 	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to disconnect account"})
 		return
 	}
+	h.invalidateAccount(acct)
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"message": "Account disconnected successfully",
 	})
 }
+
+// invalidateAccount drops the cached credentials of a connection:
+// those of its stored external ID, in the store every replica shares, and
+// those this process cached under any earlier external ID.
+func (h *AccountsHandler) invalidateAccount(acct database.AwsAccount) {
+	h.cache.InvalidateCredentials(acct.AccountID, acct.ExternalID)
+	h.cache.InvalidateByAccount(acct.AccountID)
+}
+
+// DisconnectAllAccountsHandler removes every AWS account connection of the
+// authenticated user's team, e.g. when offboarding the team. The accounts are
+// deleted by a single statement, so either all of them are disconnected or
+// none are.
+// DELETE /api/accounts
+func (h *AccountsHandler) DisconnectAllAccountsHandler(c *gin.Context) {
+	user := auth.FromContext(c.Request.Context())
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	team, err := h.store.GetTeamByOwnerID(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Team not found"})
+		return
+	}
+
+	accounts, err := h.store.DeleteAccountsByTeamID(c.Request.Context(), pgtype.Int4{Int32: team.ID, Valid: true})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to disconnect accounts"})
+		return
+	}
+
+	for _, acct := range accounts {
+		h.invalidateAccount(acct)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":      true,
+		"message":      "Accounts disconnected successfully",
+		"disconnected": len(accounts),
+	})
+}
+
+// AccountHealthHandler re-verifies access to a connected AWS account using its
+// stored external ID, so stale connections (deleted role, rotated external ID)
+// surface before a scan fails.
+// GET /api/accounts/:id/health
+func (h *AccountsHandler) AccountHealthHandler(c *gin.Context) {
+	accountIDParam := c.Param("id")
+	if accountIDParam == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Account ID is required",
+		})
+		return
+	}
+
+	user := auth.FromContext(c.Request.Context())
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	team, err := h.store.GetTeamByOwnerID(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Team not found"})
+		return
+	}
+	teamID := pgtype.Int4{Int32: team.ID, Valid: true}
+
+	acct, err := h.store.GetAccountByTeamAndAccountID(c.Request.Context(), database.GetAccountByTeamAndAccountIDParams{
+		TeamID:    teamID,
+		AccountID: accountIDParam,
+	})
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
+		return
+	}
+
+	// A health check must reflect the role as it is now, so it skips the
+	// short-lived verification cache.
+	checkedAt := time.Now().UTC()
+	if _, err := h.auth.RecheckAccountAccess(c.Request.Context(), awsauth.AssumeRoleInput{
+		AccountID:  acct.AccountID,
+		ExternalID: acct.ExternalID,
+	}); err != nil {
+		message := "Failed to verify AWS account access"
+		if errors.Is(err, awsauth.ErrAssumeRoleFailed) || errors.Is(err, awsauth.ErrInvalidExternalID) {
+			message = "Unable to assume role: check that the role exists and trusts the stored external ID"
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"healthy":      false,
+			"last_checked": checkedAt,
+			"error":        message,
+		})
+		return
+	}
+
+	err = h.store.UpdateAccountLastVerified(c.Request.Context(), database.UpdateAccountLastVerifiedParams{
+		LastVerifiedAt: pgtype.Timestamp{Time: checkedAt, Valid: true},
+		TeamID:         teamID,
+		AccountID:      acct.AccountID,
+	})
+	if err != nil {
+		log.Printf("Error updating last verified time for account %s: %v", acct.AccountID, err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"healthy":      true,
+		"last_checked": checkedAt,
+		"error":        nil,
+	})
+}
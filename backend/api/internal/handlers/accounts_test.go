@@ -0,0 +1,408 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"cloudcop/api/internal/awsauth"
+	"cloudcop/api/internal/database"
+	"cloudcop/api/internal/middleware/auth"
+
+	"github.com/clerkinc/clerk-sdk-go/clerk"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type fakeVerifier struct {
+	err   error
+	input awsauth.AssumeRoleInput
+	// rechecks counts the verifications that bypassed the cache.
+	rechecks int
+}
+
+func (f *fakeVerifier) RecheckAccountAccess(ctx context.Context, input awsauth.AssumeRoleInput) (*awsauth.AccountInfo, error) {
+	f.rechecks++
+	return f.VerifyAccountAccess(ctx, input)
+}
+
+func (f *fakeVerifier) VerifyAccountAccess(_ context.Context, input awsauth.AssumeRoleInput) (*awsauth.AccountInfo, error) {
+	f.input = input
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &awsauth.AccountInfo{
+		AccountID: input.AccountID,
+		ARN:       "arn:aws:sts::" + input.AccountID + ":assumed-role/CloudCopScanRole/" + input.ExternalID,
+	}, nil
+}
+
+// fakeAccountStore serves a single team and account. Methods that are not
+// overridden panic via the nil embedded interface.
+type fakeAccountStore struct {
+	accountStore
+	account      database.AwsAccount
+	created      []database.CreateAccountParams
+	updated      []database.UpdateAccountLastVerifiedParams
+	reconnected  []database.UpdateAccountConnectionParams
+	teamAccounts []database.AwsAccount
+	deleteErr    error
+}
+
+func (f *fakeAccountStore) GetTeamByOwnerID(_ context.Context, _ string) (database.Team, error) {
+	return database.Team{ID: 1}, nil
+}
+
+func (f *fakeAccountStore) GetAccountByTeamAndAccountID(_ context.Context, arg database.GetAccountByTeamAndAccountIDParams) (database.AwsAccount, error) {
+	if arg.AccountID != f.account.AccountID {
+		return database.AwsAccount{}, pgx.ErrNoRows
+	}
+	return f.account, nil
+}
+
+func (f *fakeAccountStore) CreateUser(_ context.Context, arg database.CreateUserParams) (database.User, error) {
+	return database.User{ID: arg.ID, Email: arg.Email}, nil
+}
+
+func (f *fakeAccountStore) CreateAccount(_ context.Context, arg database.CreateAccountParams) (database.AwsAccount, error) {
+	f.created = append(f.created, arg)
+	f.account = database.AwsAccount{
+		ID:             int32(len(f.created)),
+		TeamID:         arg.TeamID,
+		AccountID:      arg.AccountID,
+		ExternalID:     arg.ExternalID,
+		RoleArn:        arg.RoleArn,
+		Verified:       arg.Verified,
+		LastVerifiedAt: arg.LastVerifiedAt,
+	}
+	return f.account, nil
+}
+
+func (f *fakeAccountStore) UpdateAccountLastVerified(_ context.Context, arg database.UpdateAccountLastVerifiedParams) error {
+	f.updated = append(f.updated, arg)
+	return nil
+}
+
+func (f *fakeAccountStore) UpdateAccountConnection(_ context.Context, arg database.UpdateAccountConnectionParams) (database.AwsAccount, error) {
+	f.reconnected = append(f.reconnected, arg)
+	f.account.ExternalID = arg.ExternalID
+	f.account.RoleArn = arg.RoleArn
+	f.account.Verified = pgtype.Bool{Bool: true, Valid: true}
+	f.account.LastVerifiedAt = arg.LastVerifiedAt
+	return f.account, nil
+}
+
+func (f *fakeAccountStore) DeleteAccount(_ context.Context, arg database.DeleteAccountParams) (database.AwsAccount, error) {
+	if f.deleteErr != nil {
+		return database.AwsAccount{}, f.deleteErr
+	}
+	if arg.AccountID != f.account.AccountID || arg.TeamID.Int32 != 1 {
+		return database.AwsAccount{}, pgx.ErrNoRows
+	}
+	deleted := f.account
+	f.account = database.AwsAccount{}
+	return deleted, nil
+}
+
+func (f *fakeAccountStore) DeleteAccountsByTeamID(_ context.Context, _ pgtype.Int4) ([]database.AwsAccount, error) {
+	if f.deleteErr != nil {
+		return nil, f.deleteErr
+	}
+	deleted := f.teamAccounts
+	f.teamAccounts = nil
+	return deleted, nil
+}
+
+// fakeInvalidator records the accounts whose credentials were invalidated,
+// and the account and external ID pairs invalidated individually.
+type fakeInvalidator struct {
+	accounts    []string
+	credentials []string
+}
+
+func (f *fakeInvalidator) InvalidateCredentials(accountID, externalID string) {
+	f.credentials = append(f.credentials, accountID+":"+externalID)
+}
+
+func (f *fakeInvalidator) InvalidateByAccount(accountID string) {
+	f.accounts = append(f.accounts, accountID)
+}
+
+func serveHealth(t *testing.T, h *AccountsHandler, accountID string) (int, map[string]any) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/accounts/:id/health", h.AccountHealthHandler)
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/accounts/%s/health", accountID), nil)
+	req = req.WithContext(auth.AttachContext(req.Context(), &clerk.User{ID: "user_1"}))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON response %q: %v", w.Body.String(), err)
+	}
+	return w.Code, body
+}
+
+func TestAccountHealthHandler_Healthy(t *testing.T) {
+	verifier := &fakeVerifier{}
+	store := &fakeAccountStore{account: database.AwsAccount{AccountID: "123456789012", ExternalID: "ext-1"}}
+	h := &AccountsHandler{auth: verifier, store: store}
+
+	code, body := serveHealth(t, h, "123456789012")
+
+	if code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	if body["healthy"] != true || body["error"] != nil || body["last_checked"] == nil {
+		t.Errorf("body = %v, want healthy with last_checked and no error", body)
+	}
+	if verifier.input.ExternalID != "ext-1" {
+		t.Errorf("verified with external ID %q, want stored ext-1", verifier.input.ExternalID)
+	}
+	if verifier.rechecks != 1 {
+		t.Errorf("uncached verifications = %d, want 1", verifier.rechecks)
+	}
+	if len(store.updated) != 1 || !store.updated[0].LastVerifiedAt.Valid {
+		t.Errorf("LastVerifiedAt updates = %+v, want one valid update", store.updated)
+	}
+}
+
+func TestAccountHealthHandler_Failing(t *testing.T) {
+	verifier := &fakeVerifier{err: fmt.Errorf("%w: AccessDenied", awsauth.ErrAssumeRoleFailed)}
+	store := &fakeAccountStore{account: database.AwsAccount{AccountID: "123456789012", ExternalID: "ext-1"}}
+	h := &AccountsHandler{auth: verifier, store: store}
+
+	code, body := serveHealth(t, h, "123456789012")
+
+	if code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	if body["healthy"] != false {
+		t.Errorf("healthy = %v, want false", body["healthy"])
+	}
+	if msg, _ := body["error"].(string); msg == "" {
+		t.Errorf("error = %v, want a message", body["error"])
+	}
+	if len(store.updated) != 0 {
+		t.Errorf("LastVerifiedAt updated on failure: %+v", store.updated)
+	}
+}
+
+func TestAccountHealthHandler_UnknownAccount(t *testing.T) {
+	h := &AccountsHandler{auth: &fakeVerifier{}, store: &fakeAccountStore{}}
+
+	code, _ := serveHealth(t, h, "999999999999")
+
+	if code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", code)
+	}
+}
+
+func serveDisconnectAll(t *testing.T, h *AccountsHandler) (int, map[string]any) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.DELETE("/api/accounts", h.DisconnectAllAccountsHandler)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/accounts", nil)
+	req = req.WithContext(auth.AttachContext(req.Context(), &clerk.User{ID: "user_1"}))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON response %q: %v", w.Body.String(), err)
+	}
+	return w.Code, body
+}
+
+func TestDisconnectAllAccountsHandler(t *testing.T) {
+	store := &fakeAccountStore{teamAccounts: []database.AwsAccount{
+		{AccountID: "123456789012", ExternalID: "ext-1"},
+		{AccountID: "210987654321", ExternalID: "ext-2"},
+	}}
+	cache := &fakeInvalidator{}
+	h := &AccountsHandler{cache: cache, store: store}
+
+	code, body := serveDisconnectAll(t, h)
+
+	if code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	if body["disconnected"] != float64(2) {
+		t.Errorf("disconnected = %v, want 2", body["disconnected"])
+	}
+	if len(store.teamAccounts) != 0 {
+		t.Errorf("accounts left after disconnect: %+v", store.teamAccounts)
+	}
+	if want := []string{"123456789012", "210987654321"}; !slices.Equal(cache.accounts, want) {
+		t.Errorf("invalidated %v, want %v", cache.accounts, want)
+	}
+	if want := []string{"123456789012:ext-1", "210987654321:ext-2"}; !slices.Equal(cache.credentials, want) {
+		t.Errorf("invalidated credentials %v, want %v", cache.credentials, want)
+	}
+}
+
+func TestDisconnectAllAccountsHandler_DeleteFails(t *testing.T) {
+	store := &fakeAccountStore{
+		teamAccounts: []database.AwsAccount{{AccountID: "123456789012"}},
+		deleteErr:    errors.New("connection reset"),
+	}
+	cache := &fakeInvalidator{}
+	h := &AccountsHandler{cache: cache, store: store}
+
+	code, _ := serveDisconnectAll(t, h)
+
+	if code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", code)
+	}
+	if len(cache.accounts) != 0 {
+		t.Errorf("invalidated %v after a failed delete, want none", cache.accounts)
+	}
+}
+
+func serveDisconnect(t *testing.T, h *AccountsHandler, accountID string) int {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.DELETE("/api/accounts/:id", h.DisconnectAccountHandler)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/accounts/"+accountID, nil)
+	req = req.WithContext(auth.AttachContext(req.Context(), &clerk.User{ID: "user_1"}))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestDisconnectAccountHandler(t *testing.T) {
+	store := &fakeAccountStore{account: database.AwsAccount{AccountID: "123456789012", ExternalID: "ext-1"}}
+	cache := &fakeInvalidator{}
+	h := &AccountsHandler{cache: cache, store: store}
+
+	// Accounts the team has not connected are left alone, including any
+	// credentials other teams have cached for them.
+	if code := serveDisconnect(t, h, "210987654321"); code != http.StatusNotFound {
+		t.Errorf("unknown account status = %d, want 404", code)
+	}
+	if len(cache.accounts) != 0 || len(cache.credentials) != 0 {
+		t.Errorf("invalidated %v %v for an account the team does not own", cache.accounts, cache.credentials)
+	}
+
+	if code := serveDisconnect(t, h, "123456789012"); code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	if want := []string{"123456789012:ext-1"}; !slices.Equal(cache.credentials, want) {
+		t.Errorf("invalidated credentials %v, want %v", cache.credentials, want)
+	}
+}
+
+func TestDisconnectAccountHandler_DeleteFails(t *testing.T) {
+	store := &fakeAccountStore{
+		account:   database.AwsAccount{AccountID: "123456789012", ExternalID: "ext-1"},
+		deleteErr: errors.New("connection reset"),
+	}
+	cache := &fakeInvalidator{}
+	h := &AccountsHandler{cache: cache, store: store}
+
+	if code := serveDisconnect(t, h, "123456789012"); code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", code)
+	}
+	if len(cache.accounts) != 0 || len(cache.credentials) != 0 {
+		t.Errorf("invalidated %v %v after a failed delete, want none", cache.accounts, cache.credentials)
+	}
+}
+
+func serveConnect(t *testing.T, h *AccountsHandler, body string) (int, map[string]any) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/api/accounts/connect", h.ConnectAccountHandler)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/accounts/connect", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(auth.AttachContext(req.Context(), &clerk.User{ID: "user_1"}))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resp map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON response %q: %v", w.Body.String(), err)
+	}
+	return w.Code, resp
+}
+
+func TestConnectAccountHandler_FirstConnect(t *testing.T) {
+	store := &fakeAccountStore{}
+	h := &AccountsHandler{auth: &fakeVerifier{}, store: store}
+
+	code, body := serveConnect(t, h, `{"account_id":"123456789012","external_id":"ext-1"}`)
+
+	if code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201", code)
+	}
+	if len(store.created) != 1 || store.created[0].ExternalID != "ext-1" {
+		t.Errorf("created = %+v, want one account with ext-1", store.created)
+	}
+	if len(store.reconnected) != 0 {
+		t.Errorf("connection updated on first connect: %+v", store.reconnected)
+	}
+	conn, _ := body["connection"].(map[string]any)
+	if conn["account_id"] != "123456789012" || conn["id"] != float64(1) {
+		t.Errorf("connection = %v, want account 123456789012 with id 1", conn)
+	}
+}
+
+func TestConnectAccountHandler_RepeatConnect(t *testing.T) {
+	store := &fakeAccountStore{}
+	cache := &fakeInvalidator{}
+	h := &AccountsHandler{auth: &fakeVerifier{}, cache: cache, store: store}
+
+	if code, _ := serveConnect(t, h, `{"account_id":"123456789012","external_id":"ext-1"}`); code != http.StatusCreated {
+		t.Fatalf("first connect status = %d, want 201", code)
+	}
+	code, body := serveConnect(t, h, `{"account_id":"123456789012","external_id":"ext-2"}`)
+
+	if code != http.StatusOK {
+		t.Fatalf("repeat connect status = %d, want 200", code)
+	}
+	if len(store.created) != 1 {
+		t.Errorf("created %d accounts, want the first connect only", len(store.created))
+	}
+	if len(store.reconnected) != 1 {
+		t.Fatalf("reconnects = %+v, want one", store.reconnected)
+	}
+	if got := store.reconnected[0]; got.ID != 1 || got.ExternalID != "ext-2" || !strings.HasSuffix(got.RoleArn.String, "/ext-2") || !got.LastVerifiedAt.Valid {
+		t.Errorf("reconnect = %+v, want connection 1 stored with ext-2 and its verified role", got)
+	}
+	if !slices.Equal(cache.credentials, []string{"123456789012:ext-1"}) || !slices.Equal(cache.accounts, []string{"123456789012"}) {
+		t.Errorf("invalidated credentials %v and accounts %v, want those cached for ext-1", cache.credentials, cache.accounts)
+	}
+	conn, _ := body["connection"].(map[string]any)
+	if conn["id"] != float64(1) || conn["verified"] != true || !strings.HasSuffix(conn["arn"].(string), "/ext-2") {
+		t.Errorf("connection = %v, want the existing connection with the new role", conn)
+	}
+}
+
+func TestConnectAccountHandler_VerificationFails(t *testing.T) {
+	store := &fakeAccountStore{}
+	h := &AccountsHandler{auth: &fakeVerifier{err: awsauth.ErrAssumeRoleFailed}, store: store}
+
+	code, _ := serveConnect(t, h, `{"account_id":"123456789012","external_id":"wrong"}`)
+
+	if code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", code)
+	}
+	if len(store.created) != 0 || len(store.reconnected) != 0 {
+		t.Errorf("store changed after failed verification: created %+v, reconnected %+v", store.created, store.reconnected)
+	}
+}
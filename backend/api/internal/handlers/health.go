@@ -1,12 +1,142 @@
 // Package handlers contains HTTP request handlers for the API.
 package handlers
 
-import "github.com/gin-gonic/gin"
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
 
-// Health handles health check requests.
-func Health(c *gin.Context) {
-	c.JSON(200, gin.H{
-		"status":  "ok",
+	"cloudcop/api/internal/graphdb"
+	"cloudcop/api/internal/summarization"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc/connectivity"
+)
+
+// dependencyTimeout bounds each dependency check, so a hung database cannot
+// hold up a readiness probe past its own timeout.
+const dependencyTimeout = 2 * time.Second
+
+// Dependency and overall readiness states reported by HealthHandler.
+const (
+	healthOK          = "ok"
+	healthDown        = "down"
+	healthDisabled    = "disabled"
+	healthDegraded    = "degraded"
+	healthUnavailable = "unavailable"
+)
+
+// pinger is satisfied by *pgxpool.Pool.
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// graphPinger is satisfied by *graphdb.Neo4jClient.
+type graphPinger interface {
+	VerifyConnectivity(ctx context.Context) error
+}
+
+// channelStater is satisfied by *summarization.Client.
+type channelStater interface {
+	State() connectivity.State
+}
+
+// dependencyStatus is the state of one downstream dependency. /health is
+// unauthenticated, so why a dependency is down is logged rather than
+// returned.
+type dependencyStatus struct {
+	Status string `json:"status"`
+}
+
+// HealthHandler reports whether the API and the services it depends on are
+// ready to serve requests.
+type HealthHandler struct {
+	db        pinger
+	graph     graphPinger
+	summaries channelStater
+}
+
+// NewHealthHandler constructs a HealthHandler checking the database pool and,
+// when they are configured, Neo4j and the summarization service. A nil Neo4j
+// client or summarization client is reported as disabled.
+func NewHealthHandler(db *pgxpool.Pool, graph *graphdb.Neo4jClient, summaries *summarization.Client) *HealthHandler {
+	h := &HealthHandler{db: db}
+	if graph != nil {
+		h.graph = graph
+	}
+	if summaries != nil {
+		h.summaries = summaries
+	}
+	return h
+}
+
+// Livez handles liveness probes. It only reports that the process is serving
+// requests and never touches a dependency.
+func Livez(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":  healthOK,
 		"service": "api",
 	})
 }
+
+// Health handles readiness probes, reporting the state of each dependency.
+// It responds 503 when Postgres or a configured Neo4j is unreachable. The
+// summarization service only powers optional scan summaries, so losing it
+// degrades the status but keeps the 200.
+// GET /health
+func (h *HealthHandler) Health(c *gin.Context) {
+	ctx := c.Request.Context()
+	deps := map[string]dependencyStatus{
+		"postgres": check(ctx, "postgres", h.db.Ping),
+	}
+	if h.graph != nil {
+		deps["neo4j"] = check(ctx, "neo4j", h.graph.VerifyConnectivity)
+	} else {
+		deps["neo4j"] = dependencyStatus{Status: healthDisabled}
+	}
+	if h.summaries != nil {
+		deps["summarization"] = channelStatus(h.summaries.State())
+	} else {
+		deps["summarization"] = dependencyStatus{Status: healthDisabled}
+	}
+
+	status, code := healthOK, http.StatusOK
+	switch {
+	case deps["postgres"].Status == healthDown || deps["neo4j"].Status == healthDown:
+		status, code = healthUnavailable, http.StatusServiceUnavailable
+	case deps["summarization"].Status == healthDown:
+		status = healthDegraded
+	}
+
+	c.JSON(code, gin.H{
+		"status":       status,
+		"service":      "api",
+		"dependencies": deps,
+	})
+}
+
+// check runs ping under dependencyTimeout, logging why the named dependency
+// is down when it fails.
+func check(ctx context.Context, name string, ping func(context.Context) error) dependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, dependencyTimeout)
+	defer cancel()
+	if err := ping(ctx); err != nil {
+		log.Printf("Health check: %s is down: %v", name, err)
+		return dependencyStatus{Status: healthDown}
+	}
+	return dependencyStatus{Status: healthOK}
+}
+
+// channelStatus maps a gRPC connectivity state to a dependency status. An
+// idle or connecting channel has not failed yet, so it counts as up.
+func channelStatus(state connectivity.State) dependencyStatus {
+	switch state {
+	case connectivity.TransientFailure, connectivity.Shutdown:
+		log.Printf("Health check: summarization channel is %s", state)
+		return dependencyStatus{Status: healthDown}
+	default:
+		return dependencyStatus{Status: healthOK}
+	}
+}
@@ -1,7 +1,133 @@
 package handlers
 
-import "testing"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
 
-func TestHealth(_ *testing.T) {
-	// placeholder
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/connectivity"
+)
+
+type fakePinger struct{ err error }
+
+func (f fakePinger) Ping(context.Context) error { return f.err }
+
+func (f fakePinger) VerifyConnectivity(context.Context) error { return f.err }
+
+type fakeChannel connectivity.State
+
+func (f fakeChannel) State() connectivity.State { return connectivity.State(f) }
+
+type healthResponse struct {
+	Status       string                       `json:"status"`
+	Dependencies map[string]map[string]string `json:"dependencies"`
+}
+
+func serveReadiness(t *testing.T, h *HealthHandler) (int, healthResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/health", h.Health)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	var resp healthResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decoding response %q: %v", w.Body.String(), err)
+	}
+	return w.Code, resp
+}
+
+func TestHealth(t *testing.T) {
+	down := errors.New("connection refused")
+
+	tests := []struct {
+		name       string
+		handler    *HealthHandler
+		wantCode   int
+		wantStatus string
+		wantDeps   map[string]string
+	}{
+		{
+			name:       "all healthy",
+			handler:    &HealthHandler{db: fakePinger{}, graph: fakePinger{}, summaries: fakeChannel(connectivity.Ready)},
+			wantCode:   http.StatusOK,
+			wantStatus: healthOK,
+			wantDeps:   map[string]string{"postgres": healthOK, "neo4j": healthOK, "summarization": healthOK},
+		},
+		{
+			name:       "optional dependencies not configured",
+			handler:    &HealthHandler{db: fakePinger{}},
+			wantCode:   http.StatusOK,
+			wantStatus: healthOK,
+			wantDeps:   map[string]string{"postgres": healthOK, "neo4j": healthDisabled, "summarization": healthDisabled},
+		},
+		{
+			name:       "postgres down",
+			handler:    &HealthHandler{db: fakePinger{err: down}, graph: fakePinger{}},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: healthUnavailable,
+			wantDeps:   map[string]string{"postgres": healthDown, "neo4j": healthOK},
+		},
+		{
+			name:       "neo4j down",
+			handler:    &HealthHandler{db: fakePinger{}, graph: fakePinger{err: down}},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: healthUnavailable,
+			wantDeps:   map[string]string{"postgres": healthOK, "neo4j": healthDown},
+		},
+		{
+			name:       "summarization failing",
+			handler:    &HealthHandler{db: fakePinger{}, graph: fakePinger{}, summaries: fakeChannel(connectivity.TransientFailure)},
+			wantCode:   http.StatusOK,
+			wantStatus: healthDegraded,
+			wantDeps:   map[string]string{"summarization": healthDown},
+		},
+		{
+			name:       "summarization idle",
+			handler:    &HealthHandler{db: fakePinger{}, summaries: fakeChannel(connectivity.Idle)},
+			wantCode:   http.StatusOK,
+			wantStatus: healthOK,
+			wantDeps:   map[string]string{"summarization": healthOK},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, resp := serveReadiness(t, tt.handler)
+			if code != tt.wantCode {
+				t.Errorf("code = %d, want %d", code, tt.wantCode)
+			}
+			if resp.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", resp.Status, tt.wantStatus)
+			}
+			for name, want := range tt.wantDeps {
+				dep := resp.Dependencies[name]
+				if dep["status"] != want {
+					t.Errorf("%s status = %q, want %q", name, dep["status"], want)
+				}
+				if len(dep) != 1 {
+					t.Errorf("%s = %v, want only its status", name, dep)
+				}
+			}
+		})
+	}
+}
+
+func TestLivez(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/livez", Livez)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/livez", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("code = %d, want %d", w.Code, http.StatusOK)
+	}
 }
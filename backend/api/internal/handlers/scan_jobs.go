@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"cloudcop/api/internal/database"
+	"cloudcop/api/internal/jobs"
+	"cloudcop/api/internal/middleware/auth"
+	"cloudcop/api/internal/scanner"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// scanRunner runs a scan to completion.
+type scanRunner interface {
+	Scan(ctx context.Context, config scanner.ScanConfig) (*scanner.ScanResultWithSummary, error)
+}
+
+// scanRunnerFunc adapts a function to a scanRunner.
+type scanRunnerFunc func(ctx context.Context, config scanner.ScanConfig) (*scanner.ScanResultWithSummary, error)
+
+// Scan calls f(ctx, config).
+func (f scanRunnerFunc) Scan(ctx context.Context, config scanner.ScanConfig) (*scanner.ScanResultWithSummary, error) {
+	return f(ctx, config)
+}
+
+// scanJobStore is the subset of database queries used by ScanJobsHandler.
+type scanJobStore interface {
+	GetTeamByOwnerID(ctx context.Context, ownerID string) (database.Team, error)
+	GetAccountByTeamAndAccountID(ctx context.Context, arg database.GetAccountByTeamAndAccountIDParams) (database.AwsAccount, error)
+}
+
+// scanJobResult is the outcome of a completed scan job.
+type scanJobResult struct {
+	// scanID is the persisted scan, or zero if it was not saved.
+	scanID int32
+	result *scanner.ScanResultWithSummary
+}
+
+// ScanJobsHandler starts scans in the background and reports their progress,
+// for clients that cannot hold a request open for the length of a scan.
+type ScanJobsHandler struct {
+	scans   scanRunner
+	store   scanJobStore
+	persist func(ctx context.Context, result *scanner.ScanResult) (database.Scan, error)
+	jobs    *jobs.Manager[scanJobResult]
+}
+
+// NewScanJobsHandler constructs a ScanJobsHandler that runs scans with scan
+// and saves completed ones with persist. Pass the same runner as the
+// scheduler, such as graph.Resolver.RunScan, so team suppressions apply to
+// API-started scans too. A nil scan leaves scan submission disabled.
+func NewScanJobsHandler(scan func(ctx context.Context, config scanner.ScanConfig) (*scanner.ScanResultWithSummary, error), store *database.Queries, persist func(ctx context.Context, result *scanner.ScanResult) (database.Scan, error)) *ScanJobsHandler {
+	h := &ScanJobsHandler{
+		store:   store,
+		persist: persist,
+		jobs:    jobs.NewManager[scanJobResult](0, 0),
+	}
+	if scan != nil {
+		h.scans = scanRunnerFunc(scan)
+	}
+	return h
+}
+
+// Stop cancels scans that are still pending or running and waits for them
+// to return.
+func (h *ScanJobsHandler) Stop() {
+	h.jobs.Stop()
+}
+
+// StartScanRequest represents the request to start a scan
+type StartScanRequest struct {
+	AccountID string   `json:"account_id" binding:"required"`
+	Services  []string `json:"services"`
+	Regions   []string `json:"regions"`
+}
+
+// StartScanHandler starts a scan of a connected account in the background
+// and responds immediately with the ID of the job to poll.
+// POST /api/scans
+func (h *ScanJobsHandler) StartScanHandler(c *gin.Context) {
+	user := auth.FromContext(c.Request.Context())
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	if h.scans == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Scanning is not configured"})
+		return
+	}
+	var req StartScanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request",
+		})
+		return
+	}
+
+	team, err := h.store.GetTeamByOwnerID(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Team not found"})
+		return
+	}
+	account, err := h.store.GetAccountByTeamAndAccountID(c.Request.Context(), database.GetAccountByTeamAndAccountIDParams{
+		TeamID:    pgtype.Int4{Int32: team.ID, Valid: true},
+		AccountID: req.AccountID,
+	})
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
+		return
+	}
+
+	config := scanner.ScanConfig{
+		AccountID:    req.AccountID,
+		ConnectionID: account.ID,
+		Regions:      req.Regions,
+		Services:     req.Services,
+	}
+	job, err := h.jobs.Submit(user.ID, func(ctx context.Context) (scanJobResult, error) {
+		return h.runScan(ctx, config)
+	})
+	if errors.Is(err, jobs.ErrTooManyJobs) {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many scans in progress, try again later"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start scan"})
+		return
+	}
+
+	c.Header("Location", "/api/scans/"+job.ID)
+	c.JSON(http.StatusAccepted, gin.H{
+		"job_id": job.ID,
+		"status": job.Status,
+	})
+}
+
+// runScan runs a scan job and saves the result when persistence is
+// configured. As with the startScan mutation, a scan that could not be saved
+// still completes with its in-memory result.
+func (h *ScanJobsHandler) runScan(ctx context.Context, config scanner.ScanConfig) (scanJobResult, error) {
+	result, err := h.scans.Scan(ctx, config)
+	if err != nil {
+		return scanJobResult{}, fmt.Errorf("scan failed: %w", err)
+	}
+	if result.Cancelled {
+		return scanJobResult{}, errors.New("scan cancelled before completion")
+	}
+
+	out := scanJobResult{result: result}
+	if h.persist != nil {
+		saved, err := h.persist(ctx, result.ScanResult)
+		if err != nil {
+			log.Printf("Warning: could not persist scan for account %s: %v", config.AccountID, err)
+		} else {
+			out.scanID = saved.ID
+		}
+	}
+	return out, nil
+}
+
+// ScanJobHandler reports the status of a scan job, including the scan
+// result once it has completed.
+// GET /api/scans/:id
+func (h *ScanJobsHandler) ScanJobHandler(c *gin.Context) {
+	user := auth.FromContext(c.Request.Context())
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	// Jobs of other users are reported as missing rather than forbidden, so
+	// job IDs cannot be probed.
+	job, ok := h.jobs.Get(c.Param("id"))
+	if !ok || job.Owner != user.ID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Scan job not found"})
+		return
+	}
+
+	response := gin.H{
+		"job_id":     job.ID,
+		"status":     job.Status,
+		"created_at": job.CreatedAt,
+	}
+	if !job.StartedAt.IsZero() {
+		response["started_at"] = job.StartedAt
+	}
+	if !job.CompletedAt.IsZero() {
+		response["completed_at"] = job.CompletedAt
+	}
+	switch job.Status {
+	case jobs.StatusCompleted:
+		if job.Result.scanID != 0 {
+			response["scan_id"] = job.Result.scanID
+		}
+		response["result"] = job.Result.result
+	case jobs.StatusFailed:
+		response["error"] = job.Err.Error()
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cloudcop/api/internal/database"
+	"cloudcop/api/internal/jobs"
+	"cloudcop/api/internal/middleware/auth"
+	"cloudcop/api/internal/scanner"
+	"cloudcop/api/internal/security"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/clerkinc/clerk-sdk-go/clerk"
+	"github.com/gin-gonic/gin"
+)
+
+// fakeScanRunner blocks each scan until release is closed, when set.
+type fakeScanRunner struct {
+	release chan struct{}
+	err     error
+	config  scanner.ScanConfig
+}
+
+func (f *fakeScanRunner) Scan(ctx context.Context, config scanner.ScanConfig) (*scanner.ScanResultWithSummary, error) {
+	f.config = config
+	if f.release != nil {
+		select {
+		case <-f.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &scanner.ScanResultWithSummary{ScanResult: &scanner.ScanResult{
+		AccountID: config.AccountID,
+		Findings:  []scanner.Finding{{CheckID: "s3_bucket_encryption", Status: scanner.StatusFail}},
+	}}, nil
+}
+
+func newTestScanJobsHandler(runner *fakeScanRunner) *ScanJobsHandler {
+	return &ScanJobsHandler{
+		scans: runner,
+		store: &fakeAccountStore{account: database.AwsAccount{AccountID: "123456789012"}},
+		persist: func(context.Context, *scanner.ScanResult) (database.Scan, error) {
+			return database.Scan{ID: 7}, nil
+		},
+		jobs: jobs.NewManager[scanJobResult](0, 0),
+	}
+}
+
+func serveScanJobs(t *testing.T, h *ScanJobsHandler, userID, method, path, body string) (int, map[string]any) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/api/scans", h.StartScanHandler)
+	r.GET("/api/scans/:id", h.ScanJobHandler)
+
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(auth.AttachContext(req.Context(), &clerk.User{ID: userID}))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resp map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON response %q: %v", w.Body.String(), err)
+	}
+	return w.Code, resp
+}
+
+// pollScanJob polls the job until it has finished.
+func pollScanJob(t *testing.T, h *ScanJobsHandler, jobID string) map[string]any {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		code, body := serveScanJobs(t, h, "user_1", http.MethodGet, "/api/scans/"+jobID, "")
+		if code != http.StatusOK {
+			t.Fatalf("poll status = %d, want 200", code)
+		}
+		if status := jobs.Status(body["status"].(string)); status.Done() {
+			return body
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", jobID)
+	return nil
+}
+
+func TestScanJobs_SubmitAndPoll(t *testing.T) {
+	runner := &fakeScanRunner{release: make(chan struct{})}
+	h := newTestScanJobsHandler(runner)
+	defer h.Stop()
+
+	code, body := serveScanJobs(t, h, "user_1", http.MethodPost, "/api/scans",
+		`{"account_id":"123456789012","services":["s3"],"regions":["us-east-1"]}`)
+	if code != http.StatusAccepted {
+		t.Fatalf("submit status = %d, want 202", code)
+	}
+	jobID, _ := body["job_id"].(string)
+	if jobID == "" {
+		t.Fatalf("submit body = %v, want a job_id", body)
+	}
+
+	_, body = serveScanJobs(t, h, "user_1", http.MethodGet, "/api/scans/"+jobID, "")
+	if status := body["status"]; status != "pending" && status != "running" {
+		t.Errorf("status before the scan returns = %v, want pending or running", status)
+	}
+	if _, ok := body["result"]; ok {
+		t.Error("unfinished job reported a result")
+	}
+
+	close(runner.release)
+	body = pollScanJob(t, h, jobID)
+	if body["status"] != "completed" || body["scan_id"] != float64(7) {
+		t.Errorf("finished job = %v, want completed with scan_id 7", body)
+	}
+	result, _ := body["result"].(map[string]any)
+	if findings, _ := result["findings"].([]any); len(findings) != 1 {
+		t.Errorf("result = %v, want the scan's finding", result)
+	}
+	if runner.config.AccountID != "123456789012" || len(runner.config.Services) != 1 || len(runner.config.Regions) != 1 {
+		t.Errorf("scan config = %+v, want the requested account, services, and regions", runner.config)
+	}
+}
+
+// stubScanner reports one failed encryption finding per region.
+type stubScanner struct{}
+
+func (stubScanner) Service() string { return "s3" }
+
+func (stubScanner) Scan(_ context.Context, region string) ([]scanner.Finding, error) {
+	return []scanner.Finding{{
+		Service:    "s3",
+		Region:     region,
+		ResourceID: "logs",
+		CheckID:    "s3_bucket_encryption",
+		Status:     scanner.StatusFail,
+		Severity:   scanner.SeverityHigh,
+	}}, nil
+}
+
+func TestScanJobs_SecurityService(t *testing.T) {
+	service, err := security.NewService(security.Config{AWSConfig: aws.Config{Region: "us-east-1"}})
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	service.RegisterScanner("s3", func(aws.Config, string, string) scanner.ServiceScanner { return stubScanner{} })
+
+	var saved *scanner.ScanResult
+	h := NewScanJobsHandler(service.Scan, nil, func(_ context.Context, result *scanner.ScanResult) (database.Scan, error) {
+		saved = result
+		return database.Scan{ID: 9}, nil
+	})
+	h.store = &fakeAccountStore{account: database.AwsAccount{AccountID: "123456789012"}}
+	defer h.Stop()
+
+	code, body := serveScanJobs(t, h, "user_1", http.MethodPost, "/api/scans",
+		`{"account_id":"123456789012","services":["s3"],"regions":["us-east-1","eu-west-1"]}`)
+	if code != http.StatusAccepted {
+		t.Fatalf("submit status = %d, want 202", code)
+	}
+	body = pollScanJob(t, h, body["job_id"].(string))
+
+	if body["status"] != "completed" || body["scan_id"] != float64(9) {
+		t.Fatalf("finished job = %v, want completed with scan_id 9", body)
+	}
+	if saved == nil || saved.AccountID != "123456789012" || saved.FailedChecks != 2 {
+		t.Errorf("persisted result = %+v, want the account's two failed checks", saved)
+	}
+}
+
+func TestScanJobs_FailedScan(t *testing.T) {
+	h := newTestScanJobsHandler(&fakeScanRunner{err: errors.New("no credentials")})
+	defer h.Stop()
+
+	_, body := serveScanJobs(t, h, "user_1", http.MethodPost, "/api/scans", `{"account_id":"123456789012"}`)
+	body = pollScanJob(t, h, body["job_id"].(string))
+
+	if body["status"] != "failed" || body["error"] != "scan failed: no credentials" {
+		t.Errorf("finished job = %v, want failed with the scan error", body)
+	}
+}
+
+func TestScanJobs_Rejections(t *testing.T) {
+	h := newTestScanJobsHandler(&fakeScanRunner{})
+	defer h.Stop()
+
+	if code, _ := serveScanJobs(t, h, "user_1", http.MethodPost, "/api/scans", `{}`); code != http.StatusBadRequest {
+		t.Errorf("missing account status = %d, want 400", code)
+	}
+	if code, _ := serveScanJobs(t, h, "user_1", http.MethodPost, "/api/scans", `{"account_id":"999999999999"}`); code != http.StatusNotFound {
+		t.Errorf("unconnected account status = %d, want 404", code)
+	}
+	if code, _ := serveScanJobs(t, h, "user_1", http.MethodGet, "/api/scans/job-unknown", ""); code != http.StatusNotFound {
+		t.Errorf("unknown job status = %d, want 404", code)
+	}
+
+	_, body := serveScanJobs(t, h, "user_1", http.MethodPost, "/api/scans", `{"account_id":"123456789012"}`)
+	if code, _ := serveScanJobs(t, h, "user_2", http.MethodGet, "/api/scans/"+body["job_id"].(string), ""); code != http.StatusNotFound {
+		t.Errorf("another user's job status = %d, want 404", code)
+	}
+
+	unconfigured := &ScanJobsHandler{jobs: jobs.NewManager[scanJobResult](0, 0)}
+	if code, _ := serveScanJobs(t, unconfigured, "user_1", http.MethodPost, "/api/scans", `{"account_id":"123456789012"}`); code != http.StatusServiceUnavailable {
+		t.Errorf("unconfigured status = %d, want 503", code)
+	}
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"cloudcop/api/internal/database"
+	"cloudcop/api/internal/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ndjsonFlushEvery is how many lines are written between flushes, so clients
+// receive findings steadily without a flush per line.
+const ndjsonFlushEvery = 500
+
+// scanStore is the subset of database queries used by ScansHandler.
+type scanStore interface {
+	GetTeamByOwnerID(ctx context.Context, ownerID string) (database.Team, error)
+	GetScanForTeam(ctx context.Context, arg database.GetScanForTeamParams) (database.Scan, error)
+	StreamScanFindings(ctx context.Context, arg database.StreamScanFindingsParams, fn func(database.ScanFinding) error) error
+}
+
+// ScansHandler serves persisted scan data over REST
+type ScansHandler struct {
+	store scanStore
+}
+
+// NewScansHandler constructs a ScansHandler backed by the provided database queries.
+func NewScansHandler(store *database.Queries) *ScansHandler {
+	return &ScansHandler{store: store}
+}
+
+// findingLine is the NDJSON representation of a persisted finding
+type findingLine struct {
+	ID                int32      `json:"id"`
+	FindingID         string     `json:"finding_id"`
+	Service           string     `json:"service"`
+	Region            string     `json:"region"`
+	ResourceID        string     `json:"resource_id"`
+	ResourceArn       string     `json:"resource_arn,omitempty"`
+	ResourceCreatedAt *time.Time `json:"resource_created_at,omitempty"`
+	CheckID           string     `json:"check_id"`
+	Status            string     `json:"status"`
+	Severity          string     `json:"severity"`
+	Title             string     `json:"title"`
+	Description       string     `json:"description,omitempty"`
+	Compliance        []string   `json:"compliance"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// StreamFindingsHandler streams a scan's findings as newline-delimited JSON,
+// one finding per line, straight from the database cursor.
+// Query params match the arguments of the findings query: only_failures
+// (default true, ignored when status is set), service, severity and status.
+// GET /api/scans/:id/findings.ndjson
+func (h *ScansHandler) StreamFindingsHandler(c *gin.Context) {
+	scanID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scan ID"})
+		return
+	}
+
+	onlyFailures := true
+	if raw := c.Query("only_failures"); raw != "" {
+		onlyFailures, err = strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid only_failures value"})
+			return
+		}
+	}
+	status := c.Query("status")
+	onlyFailures = onlyFailures && status == ""
+
+	user := auth.FromContext(c.Request.Context())
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	team, err := h.store.GetTeamByOwnerID(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Team not found"})
+		return
+	}
+
+	scan, err := h.store.GetScanForTeam(c.Request.Context(), database.GetScanForTeamParams{
+		ID:     int32(scanID),
+		TeamID: pgtype.Int4{Int32: team.ID, Valid: true},
+	})
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Scan not found"})
+		return
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Status(http.StatusOK)
+
+	enc := json.NewEncoder(c.Writer)
+	written := 0
+	err = h.store.StreamScanFindings(c.Request.Context(), database.StreamScanFindingsParams{
+		ScanID:       pgtype.Int4{Int32: scan.ID, Valid: true},
+		OnlyFailures: onlyFailures,
+		Service:      c.Query("service"),
+		Severity:     c.Query("severity"),
+		Status:       status,
+	}, func(f database.ScanFinding) error {
+		if err := enc.Encode(findingLine{
+			ID:                f.ID,
+			FindingID:         f.FindingID,
+			Service:           f.Service,
+			Region:            f.Region,
+			ResourceID:        f.ResourceID,
+			ResourceArn:       f.ResourceArn.String,
+			ResourceCreatedAt: resourceCreatedAt(f.ResourceCreatedAt),
+			CheckID:           f.CheckID,
+			Status:            f.Status,
+			Severity:          f.Severity,
+			Title:             f.Title,
+			Description:       f.Description.String,
+			Compliance:        f.Compliance,
+			CreatedAt:         f.CreatedAt.Time,
+		}); err != nil {
+			return err
+		}
+		written++
+		if written%ndjsonFlushEvery == 0 {
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		// Headers are already sent, so the truncated stream is the only signal to the client.
+		log.Printf("Error streaming findings for scan %d: %v", scan.ID, err)
+	}
+	c.Writer.Flush()
+}
+
+// resourceCreatedAt returns a finding's stored resource creation time, or
+// nil when AWS did not report one.
+func resourceCreatedAt(ts pgtype.Timestamp) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	t := ts.Time
+	return &t
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	"cloudcop/api/internal/database"
+	"cloudcop/api/internal/middleware/auth"
+
+	"github.com/clerkinc/clerk-sdk-go/clerk"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// fakeScanStore generates findings on the fly so the test never holds the
+// whole scan in memory itself.
+type fakeScanStore struct {
+	scanID   int32
+	findings int
+	// failEvery marks every nth finding as FAIL; the rest PASS.
+	failEvery int
+}
+
+func (f *fakeScanStore) GetTeamByOwnerID(_ context.Context, _ string) (database.Team, error) {
+	return database.Team{ID: 1}, nil
+}
+
+func (f *fakeScanStore) GetScanForTeam(_ context.Context, arg database.GetScanForTeamParams) (database.Scan, error) {
+	if arg.ID != f.scanID {
+		return database.Scan{}, errors.New("no rows in result set")
+	}
+	return database.Scan{ID: f.scanID}, nil
+}
+
+func (f *fakeScanStore) StreamScanFindings(_ context.Context, arg database.StreamScanFindingsParams, fn func(database.ScanFinding) error) error {
+	description := strings.Repeat("x", 1024)
+	for i := 0; i < f.findings; i++ {
+		status := "PASS"
+		if i%f.failEvery == 0 {
+			status = "FAIL"
+		}
+		service, severity := "s3", "HIGH"
+		if i%2 == 1 {
+			service, severity = "ec2", "LOW"
+		}
+		if arg.OnlyFailures && status != "FAIL" ||
+			arg.Service != "" && arg.Service != service ||
+			arg.Severity != "" && arg.Severity != severity ||
+			arg.Status != "" && arg.Status != status {
+			continue
+		}
+		err := fn(database.ScanFinding{
+			ID:          int32(i),
+			ScanID:      arg.ScanID,
+			FindingID:   fmt.Sprintf("finding-%d", i),
+			Service:     service,
+			Region:      "us-east-1",
+			ResourceID:  fmt.Sprintf("bucket-%d", i),
+			CheckID:     "s3_bucket_encryption",
+			Status:      status,
+			Severity:    severity,
+			Title:       "S3 bucket encryption is disabled",
+			Description: pgtype.Text{String: description, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lineCounter is a streaming http.ResponseWriter that counts NDJSON lines
+// and discards the body, sampling peak heap use as data arrives.
+type lineCounter struct {
+	header   http.Header
+	status   int
+	lines    int
+	flushes  int
+	peakHeap uint64
+}
+
+func (w *lineCounter) Header() http.Header { return w.header }
+
+func (w *lineCounter) WriteHeader(status int) { w.status = status }
+
+func (w *lineCounter) Write(p []byte) (int, error) {
+	before := w.lines
+	w.lines += bytes.Count(p, []byte("\n"))
+	if w.lines/10000 != before/10000 {
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+		w.peakHeap = max(w.peakHeap, m.HeapAlloc)
+	}
+	return len(p), nil
+}
+
+func (w *lineCounter) Flush() { w.flushes++ }
+
+func streamFindings(h *ScansHandler, target string, w http.ResponseWriter) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/scans/:id/findings.ndjson", h.StreamFindingsHandler)
+
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req = req.WithContext(auth.AttachContext(req.Context(), &clerk.User{ID: "user_1"}))
+	r.ServeHTTP(w, req)
+}
+
+func TestStreamFindingsHandler_LargeScan(t *testing.T) {
+	const total = 100000
+	h := &ScansHandler{store: &fakeScanStore{scanID: 42, findings: total, failEvery: 1}}
+	w := &lineCounter{header: make(http.Header)}
+
+	runtime.GC()
+	var start runtime.MemStats
+	runtime.ReadMemStats(&start)
+
+	streamFindings(h, "/api/scans/42/findings.ndjson", w)
+
+	if w.status != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.status)
+	}
+	if ct := w.header.Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("Content-Type = %q, want application/x-ndjson", ct)
+	}
+	if w.lines != total {
+		t.Errorf("streamed %d lines, want %d", w.lines, total)
+	}
+	if w.flushes < total/ndjsonFlushEvery {
+		t.Errorf("flushed %d times, want at least %d", w.flushes, total/ndjsonFlushEvery)
+	}
+
+	// The body is over 100MB; a streaming handler should stay far below that.
+	const limit = 64 << 20
+	if grown := w.peakHeap - min(w.peakHeap, start.HeapAlloc); grown > limit {
+		t.Errorf("heap grew by %d MB while streaming, want under %d MB", grown>>20, limit>>20)
+	}
+}
+
+func TestStreamFindingsHandler_Filters(t *testing.T) {
+	store := &fakeScanStore{scanID: 42, findings: 1000, failEvery: 4}
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"", 250},
+		{"?only_failures=true", 250},
+		{"?only_failures=false", 1000},
+		{"?only_failures=false&service=ec2", 500},
+		{"?service=s3", 250},
+		{"?service=ec2", 0},
+		{"?only_failures=false&severity=LOW", 500},
+		{"?status=PASS", 750},
+		{"?status=PASS&service=ec2", 500},
+		{"?only_failures=true&status=FAIL&severity=HIGH", 250},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			w := &lineCounter{header: make(http.Header)}
+			streamFindings(&ScansHandler{store: store}, "/api/scans/42/findings.ndjson"+tt.query, w)
+
+			if w.lines != tt.want {
+				t.Errorf("streamed %d lines, want %d", w.lines, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamFindingsHandler_FindingID(t *testing.T) {
+	h := &ScansHandler{store: &fakeScanStore{scanID: 42, findings: 2, failEvery: 1}}
+	w := httptest.NewRecorder()
+	streamFindings(h, "/api/scans/42/findings.ndjson", w)
+
+	dec := json.NewDecoder(w.Body)
+	for i := 0; i < 2; i++ {
+		var line map[string]any
+		if err := dec.Decode(&line); err != nil {
+			t.Fatalf("decoding line %d: %v", i, err)
+		}
+		if want := fmt.Sprintf("finding-%d", i); line["finding_id"] != want {
+			t.Errorf("line %d finding_id = %v, want %q", i, line["finding_id"], want)
+		}
+	}
+}
+
+func TestStreamFindingsHandler_Errors(t *testing.T) {
+	h := &ScansHandler{store: &fakeScanStore{scanID: 42, failEvery: 1}}
+
+	tests := []struct {
+		target string
+		want   int
+	}{
+		{"/api/scans/abc/findings.ndjson", http.StatusBadRequest},
+		{"/api/scans/42/findings.ndjson?only_failures=maybe", http.StatusBadRequest},
+		{"/api/scans/7/findings.ndjson", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			streamFindings(h, tt.target, w)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
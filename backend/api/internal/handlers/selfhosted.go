@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"cloudcop/api/internal/database"
+	"cloudcop/api/internal/middleware/auth"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// selfHostedExternalID is stored for the imported account, which is scanned
+// with CloudCop's own credentials rather than through an assumed role.
+const selfHostedExternalID = "self-hosted"
+
+// ImportSelfHostedAccount registers the AWS account of CloudCop's own
+// credentials as a verified account of the self-hosted user's team, so scans
+// work without connecting an account through AssumeRole. It does nothing
+// unless SELF_HOSTING=1 and is safe to call on every startup: an account
+// imported earlier only has its verification time refreshed.
+func (h *AccountsHandler) ImportSelfHostedAccount(ctx context.Context) error {
+	if os.Getenv("SELF_HOSTING") != "1" {
+		return nil
+	}
+
+	accountID, err := h.identity.GetAccountID(ctx)
+	if err != nil {
+		return fmt.Errorf("resolving self-hosted account: %w", err)
+	}
+
+	user := auth.SelfHostedUser()
+	userCtx := auth.AttachContext(ctx, user)
+	email, _ := auth.EmailFromContext(userCtx)
+	name, _ := auth.FullnameFromContext(userCtx)
+	if _, err := h.store.CreateUser(ctx, database.CreateUserParams{
+		ID:    user.ID,
+		Email: email,
+		Name:  pgtype.Text{String: name, Valid: name != ""},
+	}); err != nil {
+		return fmt.Errorf("creating self-hosted user: %w", err)
+	}
+
+	team, err := h.store.GetTeamByOwnerID(ctx, user.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		team, err = h.store.CreateTeam(ctx, database.CreateTeamParams{
+			Name:    name + "'s Team",
+			Slug:    user.ID,
+			OwnerID: user.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("creating self-hosted team: %w", err)
+		}
+		if _, err := h.store.AddTeamMember(ctx, database.AddTeamMemberParams{
+			TeamID: team.ID,
+			UserID: user.ID,
+			Role:   "owner",
+		}); err != nil {
+			return fmt.Errorf("adding self-hosted user to team: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("looking up self-hosted team: %w", err)
+	}
+
+	teamID := pgtype.Int4{Int32: team.ID, Valid: true}
+	verifiedAt := pgtype.Timestamp{Time: time.Now(), Valid: true}
+
+	_, err = h.store.GetAccountByTeamAndAccountID(ctx, database.GetAccountByTeamAndAccountIDParams{
+		TeamID:    teamID,
+		AccountID: accountID,
+	})
+	switch {
+	case err == nil:
+		return h.store.UpdateAccountLastVerified(ctx, database.UpdateAccountLastVerifiedParams{
+			LastVerifiedAt: verifiedAt,
+			TeamID:         teamID,
+			AccountID:      accountID,
+		})
+	case !errors.Is(err, pgx.ErrNoRows):
+		return fmt.Errorf("looking up self-hosted account: %w", err)
+	}
+
+	if _, err := h.store.CreateAccount(ctx, database.CreateAccountParams{
+		TeamID:         teamID,
+		AccountID:      accountID,
+		ExternalID:     selfHostedExternalID,
+		Verified:       pgtype.Bool{Bool: true, Valid: true},
+		LastVerifiedAt: verifiedAt,
+	}); err != nil {
+		return fmt.Errorf("creating self-hosted account: %w", err)
+	}
+	return nil
+}
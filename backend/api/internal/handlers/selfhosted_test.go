@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"cloudcop/api/internal/database"
+)
+
+// fakeIdentity resolves a fixed account ID and counts the lookups.
+type fakeIdentity struct {
+	accountID string
+	calls     int
+}
+
+func (f *fakeIdentity) GetAccountID(_ context.Context) (string, error) {
+	f.calls++
+	return f.accountID, nil
+}
+
+func TestImportSelfHostedAccount_Idempotent(t *testing.T) {
+	t.Setenv("SELF_HOSTING", "1")
+	identity := &fakeIdentity{accountID: "123456789012"}
+	store := &fakeAccountStore{}
+	h := &AccountsHandler{identity: identity, store: store}
+
+	// A restart imports again; the second run must find the first's record.
+	for range 2 {
+		if err := h.ImportSelfHostedAccount(context.Background()); err != nil {
+			t.Fatalf("ImportSelfHostedAccount() error = %v", err)
+		}
+	}
+
+	if len(store.created) != 1 {
+		t.Fatalf("created %d accounts, want 1", len(store.created))
+	}
+	got := store.created[0]
+	want := database.CreateAccountParams{AccountID: "123456789012", ExternalID: selfHostedExternalID}
+	if got.AccountID != want.AccountID || got.ExternalID != want.ExternalID || !got.TeamID.Valid {
+		t.Errorf("created %+v, want account %s with external ID %s on the team", got, want.AccountID, want.ExternalID)
+	}
+	if !got.Verified.Bool || !got.LastVerifiedAt.Valid {
+		t.Errorf("created account verified=%v at %v, want verified", got.Verified, got.LastVerifiedAt)
+	}
+	if len(store.updated) != 1 || store.updated[0].AccountID != "123456789012" {
+		t.Errorf("LastVerifiedAt updates = %+v, want one for the imported account", store.updated)
+	}
+}
+
+func TestImportSelfHostedAccount_NotSelfHosted(t *testing.T) {
+	t.Setenv("SELF_HOSTING", "")
+	identity := &fakeIdentity{accountID: "123456789012"}
+	store := &fakeAccountStore{}
+	h := &AccountsHandler{identity: identity, store: store}
+
+	if err := h.ImportSelfHostedAccount(context.Background()); err != nil {
+		t.Fatalf("ImportSelfHostedAccount() error = %v", err)
+	}
+	if identity.calls != 0 || len(store.created) != 0 {
+		t.Errorf("imported outside self-hosted mode: %d lookups, %d accounts", identity.calls, len(store.created))
+	}
+}
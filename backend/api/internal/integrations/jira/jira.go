@@ -0,0 +1,266 @@
+// Package jira files failing CloudCop findings as Jira issues through the
+// Jira Cloud REST API v3.
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"cloudcop/api/internal/scanner"
+)
+
+const (
+	// defaultIssueType is used when JiraConfig.IssueType is empty.
+	defaultIssueType = "Bug"
+	// defaultTimeout bounds each request when JiraConfig.HTTPClient is nil.
+	defaultTimeout = 30 * time.Second
+	// issueLabel is added to every issue so CloudCop's issues can be found
+	// with a single JQL query.
+	issueLabel = "cloudcop"
+	// maxErrorBody caps how much of an error response is included in errors.
+	maxErrorBody = 1024
+	// maxSummaryLength is the longest summary Jira accepts.
+	maxSummaryLength = 255
+)
+
+// IssueStore maps finding IDs to the keys of the issues filed for them, so
+// findings reported by every scan are only filed once.
+type IssueStore interface {
+	// IssueKey returns the key of the issue filed for findingID, if any.
+	IssueKey(ctx context.Context, findingID string) (key string, ok bool, err error)
+	// SaveIssueKey records that the issue key was filed for findingID.
+	SaveIssueKey(ctx context.Context, findingID, key string) error
+}
+
+// JiraConfig configures where and how issues are created.
+type JiraConfig struct {
+	// BaseURL is the Jira site, such as https://example.atlassian.net.
+	BaseURL string
+	// Email and APIToken authenticate with basic auth, as Jira Cloud
+	// requires. With Email empty, APIToken is sent as a bearer personal
+	// access token instead, for Jira Data Center.
+	Email    string
+	APIToken string
+	// ProjectKey is the project issues are created in.
+	ProjectKey string
+	// IssueType names the type of created issues. Empty uses "Bug".
+	IssueType string
+	// Labels are added to every issue alongside "cloudcop".
+	Labels []string
+	// Store records the issues already created. Required.
+	Store IssueStore
+	// HTTPClient sends the requests. Nil uses a client with a 30s timeout.
+	HTTPClient *http.Client
+}
+
+// CreateIssues creates one issue for each failing critical or high finding
+// that has no issue yet, keyed by finding ID, and returns the keys of the
+// created issues. Findings sharing an ID are filed once. If a request fails,
+// the keys created before it are returned along with the error.
+func CreateIssues(ctx context.Context, cfg JiraConfig, findings []scanner.Finding) ([]string, error) {
+	if cfg.BaseURL == "" || cfg.ProjectKey == "" || cfg.APIToken == "" {
+		return nil, errors.New("jira: BaseURL, ProjectKey, and APIToken are required")
+	}
+	if cfg.Store == nil {
+		return nil, errors.New("jira: an IssueStore is required")
+	}
+
+	var created []string
+	seen := make(map[string]bool)
+	for _, f := range findings {
+		if !fileable(f) || seen[f.FindingID] {
+			continue
+		}
+		seen[f.FindingID] = true
+
+		if _, ok, err := cfg.Store.IssueKey(ctx, f.FindingID); err != nil {
+			return created, fmt.Errorf("jira: looking up issue for finding %s: %w", f.FindingID, err)
+		} else if ok {
+			continue
+		}
+
+		key, err := createIssue(ctx, cfg, f)
+		if err != nil {
+			return created, fmt.Errorf("jira: creating issue for finding %s: %w", f.FindingID, err)
+		}
+		created = append(created, key)
+		if err := cfg.Store.SaveIssueKey(ctx, f.FindingID, key); err != nil {
+			return created, fmt.Errorf("jira: recording issue %s for finding %s: %w", key, f.FindingID, err)
+		}
+	}
+	return created, nil
+}
+
+// fileable reports whether f warrants an issue: a failed critical or high
+// severity check with a finding ID to deduplicate by.
+func fileable(f scanner.Finding) bool {
+	if f.Status != scanner.StatusFail || f.FindingID == "" {
+		return false
+	}
+	return f.Severity == scanner.SeverityCritical || f.Severity == scanner.SeverityHigh
+}
+
+// createIssue creates the issue for f and returns its key.
+func createIssue(ctx context.Context, cfg JiraConfig, f scanner.Finding) (string, error) {
+	body, err := json.Marshal(issuePayload(cfg, f))
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(cfg.BaseURL, "/")+"/rest/api/3/issue", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if cfg.Email != "" {
+		req.SetBasicAuth(cfg.Email, cfg.APIToken)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+cfg.APIToken)
+	}
+
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return "", fmt.Errorf("jira returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var out struct {
+		Key string `json:"key"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("decoding response: %w", err)
+	}
+	if out.Key == "" {
+		return "", errors.New("response has no issue key")
+	}
+	return out.Key, nil
+}
+
+// issueRequest is the body of a create issue request.
+type issueRequest struct {
+	Fields issueFields `json:"fields"`
+}
+
+type issueFields struct {
+	Project     keyRef   `json:"project"`
+	IssueType   nameRef  `json:"issuetype"`
+	Summary     string   `json:"summary"`
+	Description adfNode  `json:"description"`
+	Labels      []string `json:"labels"`
+}
+
+type keyRef struct {
+	Key string `json:"key"`
+}
+
+type nameRef struct {
+	Name string `json:"name"`
+}
+
+// adfNode is a node of an Atlassian Document Format document, the rich text
+// format API v3 requires for descriptions.
+type adfNode struct {
+	Type    string    `json:"type"`
+	Version int       `json:"version,omitempty"`
+	Text    string    `json:"text,omitempty"`
+	Content []adfNode `json:"content,omitempty"`
+}
+
+// issuePayload builds the create request for f.
+func issuePayload(cfg JiraConfig, f scanner.Finding) issueRequest {
+	issueType := cfg.IssueType
+	if issueType == "" {
+		issueType = defaultIssueType
+	}
+
+	// Labels cannot contain spaces; the finding ID label lets an issue be
+	// found from a finding even if the store is lost.
+	labels := append([]string{issueLabel, issueLabel + "-" + f.FindingID}, cfg.Labels...)
+
+	return issueRequest{Fields: issueFields{
+		Project:   keyRef{Key: cfg.ProjectKey},
+		IssueType: nameRef{Name: issueType},
+		Summary:   summary(f),
+		Description: adfNode{Type: "doc", Version: 1, Content: []adfNode{
+			paragraph(f.Description),
+			paragraph(fmt.Sprintf("Check: %s | Service: %s | Region: %s", f.CheckID, f.Service, f.Region)),
+			paragraph(fmt.Sprintf("Resource: %s", resourceName(f))),
+			paragraph(fmt.Sprintf("Finding ID: %s", f.FindingID)),
+		}},
+		Labels: labels,
+	}}
+}
+
+// summary returns the issue summary for f, cut to the length Jira accepts.
+func summary(f scanner.Finding) string {
+	s := fmt.Sprintf("[CloudCop] %s: %s (%s)", f.Severity, f.Title, f.ResourceID)
+	if r := []rune(s); len(r) > maxSummaryLength {
+		return string(r[:maxSummaryLength-1]) + "…"
+	}
+	return s
+}
+
+// resourceName returns the ARN of f's resource, or its ID when the ARN is unknown.
+func resourceName(f scanner.Finding) string {
+	if f.ARN != "" {
+		return f.ARN
+	}
+	return f.ResourceID
+}
+
+func paragraph(text string) adfNode {
+	if text == "" {
+		return adfNode{Type: "paragraph"}
+	}
+	return adfNode{Type: "paragraph", Content: []adfNode{{Type: "text", Text: text}}}
+}
+
+// MemoryStore is an IssueStore held in memory, for one-off exports and tests.
+type MemoryStore struct {
+	mu   sync.Mutex
+	keys map[string]string
+}
+
+// NewMemoryStore returns a MemoryStore seeded with existing finding ID to
+// issue key mappings, which may be nil.
+func NewMemoryStore(existing map[string]string) *MemoryStore {
+	keys := make(map[string]string, len(existing))
+	for id, key := range existing {
+		keys[id] = key
+	}
+	return &MemoryStore{keys: keys}
+}
+
+// IssueKey implements IssueStore.
+func (s *MemoryStore) IssueKey(_ context.Context, findingID string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, ok := s.keys[findingID]
+	return key, ok, nil
+}
+
+// SaveIssueKey implements IssueStore.
+func (s *MemoryStore) SaveIssueKey(_ context.Context, findingID, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[findingID] = key
+	return nil
+}
@@ -0,0 +1,209 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"cloudcop/api/internal/scanner"
+)
+
+// fakeJira records create issue requests and answers with sequential keys.
+type fakeJira struct {
+	mu       sync.Mutex
+	requests []issueRequest
+	auth     []string
+	failNext bool
+}
+
+func (f *fakeJira) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/rest/api/3/issue" {
+		http.Error(w, "unexpected request "+r.Method+" "+r.URL.Path, http.StatusNotFound)
+		return
+	}
+	var req issueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failNext {
+		f.failNext = false
+		http.Error(w, `{"errorMessages":["Issue type is required"]}`, http.StatusBadRequest)
+		return
+	}
+	f.requests = append(f.requests, req)
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprintf(w, `{"id":"%d","key":"SEC-%d","self":"%s"}`, 10000+len(f.requests), len(f.requests), r.Host)
+}
+
+func finding(resource string, status scanner.FindingStatus, severity scanner.Severity) scanner.Finding {
+	return scanner.Finding{
+		FindingID:   scanner.FindingID("123456789012", "s3", "us-east-1", "s3_bucket_public_access", resource),
+		Service:     "s3",
+		Region:      "us-east-1",
+		ResourceID:  resource,
+		ARN:         "arn:aws:s3:::" + resource,
+		CheckID:     "s3_bucket_public_access",
+		Status:      status,
+		Severity:    severity,
+		Title:       "S3 bucket is publicly accessible",
+		Description: "Bucket " + resource + " allows public reads",
+	}
+}
+
+func newTestConfig(t *testing.T, server *fakeJira, store IssueStore) JiraConfig {
+	t.Helper()
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+	return JiraConfig{
+		BaseURL:    ts.URL + "/",
+		Email:      "security@example.com",
+		APIToken:   "token",
+		ProjectKey: "SEC",
+		Labels:     []string{"aws"},
+		Store:      store,
+		HTTPClient: ts.Client(),
+	}
+}
+
+func TestCreateIssues_Payload(t *testing.T) {
+	server := &fakeJira{}
+	cfg := newTestConfig(t, server, NewMemoryStore(nil))
+
+	keys, err := CreateIssues(context.Background(), cfg, []scanner.Finding{finding("public-data", scanner.StatusFail, scanner.SeverityCritical)})
+	if err != nil {
+		t.Fatalf("CreateIssues() error = %v", err)
+	}
+	if !slices.Equal(keys, []string{"SEC-1"}) {
+		t.Fatalf("CreateIssues() = %v, want [SEC-1]", keys)
+	}
+
+	// security@example.com:token
+	if want := "Basic c2VjdXJpdHlAZXhhbXBsZS5jb206dG9rZW4="; server.auth[0] != want {
+		t.Errorf("Authorization = %q, want %q", server.auth[0], want)
+	}
+	fields := server.requests[0].Fields
+	if fields.Project.Key != "SEC" || fields.IssueType.Name != "Bug" {
+		t.Errorf("project/issue type = %s/%s, want SEC/Bug", fields.Project.Key, fields.IssueType.Name)
+	}
+	if want := "[CloudCop] CRITICAL: S3 bucket is publicly accessible (public-data)"; fields.Summary != want {
+		t.Errorf("summary = %q, want %q", fields.Summary, want)
+	}
+	f := finding("public-data", scanner.StatusFail, scanner.SeverityCritical)
+	if want := []string{"cloudcop", "cloudcop-" + f.FindingID, "aws"}; !slices.Equal(fields.Labels, want) {
+		t.Errorf("labels = %v, want %v", fields.Labels, want)
+	}
+	if fields.Description.Type != "doc" || fields.Description.Version != 1 {
+		t.Errorf("description is not an ADF document: %+v", fields.Description)
+	}
+	var text []string
+	for _, p := range fields.Description.Content {
+		for _, n := range p.Content {
+			text = append(text, n.Text)
+		}
+	}
+	for _, want := range []string{"allows public reads", "arn:aws:s3:::public-data", f.FindingID} {
+		if !strings.Contains(strings.Join(text, "\n"), want) {
+			t.Errorf("description %q does not mention %q", text, want)
+		}
+	}
+}
+
+func TestCreateIssues_Dedup(t *testing.T) {
+	server := &fakeJira{}
+	existing := finding("already-filed", scanner.StatusFail, scanner.SeverityHigh)
+	store := NewMemoryStore(map[string]string{existing.FindingID: "SEC-99"})
+	cfg := newTestConfig(t, server, store)
+
+	findings := []scanner.Finding{
+		finding("public-data", scanner.StatusFail, scanner.SeverityCritical),
+		finding("public-data", scanner.StatusFail, scanner.SeverityCritical),
+		existing,
+		finding("logs", scanner.StatusFail, scanner.SeverityHigh),
+		finding("medium", scanner.StatusFail, scanner.SeverityMedium),
+		finding("fixed", scanner.StatusPass, scanner.SeverityCritical),
+		finding("unknown", scanner.StatusError, scanner.SeverityCritical),
+	}
+
+	keys, err := CreateIssues(context.Background(), cfg, findings)
+	if err != nil {
+		t.Fatalf("CreateIssues() error = %v", err)
+	}
+	if !slices.Equal(keys, []string{"SEC-1", "SEC-2"}) {
+		t.Errorf("CreateIssues() = %v, want [SEC-1 SEC-2]", keys)
+	}
+	if key, ok, _ := store.IssueKey(context.Background(), findings[3].FindingID); !ok || key != "SEC-2" {
+		t.Errorf("store has %q for logs, want SEC-2", key)
+	}
+
+	// A later scan reporting the same findings files nothing new.
+	keys, err = CreateIssues(context.Background(), cfg, findings)
+	if err != nil {
+		t.Fatalf("second CreateIssues() error = %v", err)
+	}
+	if len(keys) != 0 || len(server.requests) != 2 {
+		t.Errorf("second CreateIssues() = %v after %d requests, want no new issues", keys, len(server.requests))
+	}
+}
+
+func TestCreateIssues_Error(t *testing.T) {
+	server := &fakeJira{}
+	store := NewMemoryStore(nil)
+	cfg := newTestConfig(t, server, store)
+	cfg.Email = ""
+
+	first := finding("first", scanner.StatusFail, scanner.SeverityHigh)
+	second := finding("second", scanner.StatusFail, scanner.SeverityHigh)
+	if _, err := CreateIssues(context.Background(), cfg, []scanner.Finding{first}); err != nil {
+		t.Fatalf("CreateIssues() error = %v", err)
+	}
+	if server.auth[0] != "Bearer token" {
+		t.Errorf("Authorization = %q, want a bearer token without an email", server.auth[0])
+	}
+
+	server.failNext = true
+	keys, err := CreateIssues(context.Background(), cfg, []scanner.Finding{second})
+	if err == nil || !strings.Contains(err.Error(), "Issue type is required") {
+		t.Fatalf("CreateIssues() error = %v, want Jira's error message", err)
+	}
+	if len(keys) != 0 {
+		t.Errorf("CreateIssues() = %v, want no keys", keys)
+	}
+	if _, ok, _ := store.IssueKey(context.Background(), second.FindingID); ok {
+		t.Error("failed issue was recorded in the store")
+	}
+
+	// The failed finding is retried on the next export.
+	keys, err = CreateIssues(context.Background(), cfg, []scanner.Finding{first, second})
+	if err != nil || !slices.Equal(keys, []string{"SEC-2"}) {
+		t.Errorf("retry CreateIssues() = %v, %v, want [SEC-2]", keys, err)
+	}
+}
+
+func TestCreateIssues_InvalidConfig(t *testing.T) {
+	if _, err := CreateIssues(context.Background(), JiraConfig{BaseURL: "https://example.atlassian.net", APIToken: "t", ProjectKey: "SEC"}, nil); err == nil {
+		t.Error("CreateIssues() without a store should fail")
+	}
+	if _, err := CreateIssues(context.Background(), JiraConfig{Store: NewMemoryStore(nil)}, nil); err == nil {
+		t.Error("CreateIssues() without a site should fail")
+	}
+}
+
+func TestSummary_Truncated(t *testing.T) {
+	f := finding(strings.Repeat("b", 300), scanner.StatusFail, scanner.SeverityHigh)
+	if got := []rune(summary(f)); len(got) != maxSummaryLength {
+		t.Errorf("summary length = %d, want %d", len(got), maxSummaryLength)
+	}
+}
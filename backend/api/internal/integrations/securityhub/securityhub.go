@@ -0,0 +1,196 @@
+// Package securityhub exports CloudCop findings to AWS Security Hub in the
+// AWS Security Finding Format (ASFF).
+package securityhub
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloudcop/api/internal/awsauth"
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/securityhub"
+	securityhubtypes "github.com/aws/aws-sdk-go-v2/service/securityhub/types"
+)
+
+const (
+	// schemaVersion is the ASFF schema version the findings conform to.
+	schemaVersion = "2018-10-08"
+	// findingType classifies every CloudCop finding in the ASFF type taxonomy.
+	findingType = "Software and Configuration Checks/AWS Security Best Practices"
+	// globalRegion is where findings from global services such as IAM are imported.
+	globalRegion = "us-east-1"
+	// maxBatchSize is the BatchImportFindings limit per request.
+	maxBatchSize = 100
+)
+
+// resourceTypes maps scanner services to ASFF resource types.
+var resourceTypes = map[string]string{
+	"s3":         "AwsS3Bucket",
+	"ec2":        "AwsEc2Instance",
+	"iam":        "AwsIamUser",
+	"lambda":     "AwsLambdaFunction",
+	"dynamodb":   "AwsDynamoDbTable",
+	"ecs":        "AwsEcsTaskDefinition",
+	"kms":        "AwsKmsKey",
+	"eks":        "AwsEksCluster",
+	"elb":        "AwsElbv2LoadBalancer",
+	"cloudtrail": "AwsCloudTrailTrail",
+	"opensearch": "AwsOpenSearchServiceDomain",
+}
+
+// ToSecurityHub converts the findings of a scan into ASFF findings. Finding
+// IDs are derived from the account, region, check, and resource, so
+// re-importing a later scan updates the existing Security Hub findings.
+func ToSecurityHub(result *scanner.ScanResult) []securityhubtypes.AwsSecurityFinding {
+	if result == nil {
+		return nil
+	}
+
+	updatedAt := result.CompletedAt
+	if updatedAt.IsZero() {
+		updatedAt = time.Now().UTC()
+	}
+
+	findings := make([]securityhubtypes.AwsSecurityFinding, 0, len(result.Findings))
+	for _, f := range result.Findings {
+		findings = append(findings, toFinding(result.AccountID, f, updatedAt))
+	}
+	return findings
+}
+
+func toFinding(accountID string, f scanner.Finding, updatedAt time.Time) securityhubtypes.AwsSecurityFinding {
+	region := homeRegion(f.Region)
+
+	createdAt := f.Timestamp
+	if createdAt.IsZero() {
+		createdAt = updatedAt
+	}
+
+	return securityhubtypes.AwsSecurityFinding{
+		SchemaVersion: aws.String(schemaVersion),
+		Id:            aws.String(fmt.Sprintf("cloudcop/%s/%s/%s/%s", accountID, f.Region, f.CheckID, f.ResourceID)),
+		ProductArn:    aws.String(ProductARN(region, accountID)),
+		GeneratorId:   aws.String("cloudcop/" + f.CheckID),
+		AwsAccountId:  aws.String(accountID),
+		Types:         []string{findingType},
+		CreatedAt:     aws.String(createdAt.UTC().Format(time.RFC3339)),
+		UpdatedAt:     aws.String(updatedAt.UTC().Format(time.RFC3339)),
+		Title:         aws.String(f.Title),
+		Description:   aws.String(f.Description),
+		Region:        aws.String(region),
+		Severity:      &securityhubtypes.Severity{Label: severityLabel(f)},
+		Compliance: &securityhubtypes.Compliance{
+			Status:              complianceStatus(f.Status),
+			RelatedRequirements: f.Compliance,
+		},
+		Resources: []securityhubtypes.Resource{{
+			Id:        aws.String(f.ResourceID),
+			Type:      aws.String(resourceType(f.Service)),
+			Region:    aws.String(region),
+			Partition: securityhubtypes.Partition(awsauth.PartitionForRegion(region)),
+		}},
+		ProductFields: map[string]string{
+			"cloudcop/CheckId":    f.CheckID,
+			"cloudcop/Confidence": string(f.Confidence),
+		},
+	}
+}
+
+// ProductARN returns the ARN of the account's default custom-integration
+// product, which Security Hub requires for findings it did not generate.
+func ProductARN(region, accountID string) string {
+	return fmt.Sprintf("arn:%s:securityhub:%s:%s:product/%s/default", awsauth.PartitionForRegion(region), region, accountID, accountID)
+}
+
+// homeRegion returns the region a finding is imported into.
+func homeRegion(region string) string {
+	if region == "" || region == "global" {
+		return globalRegion
+	}
+	return region
+}
+
+// severityLabel maps finding severity to an ASFF label. Passed checks are
+// informational so they do not count toward Security Hub's severity totals.
+func severityLabel(f scanner.Finding) securityhubtypes.SeverityLabel {
+	if f.Status == scanner.StatusPass {
+		return securityhubtypes.SeverityLabelInformational
+	}
+	switch f.Severity {
+	case scanner.SeverityCritical:
+		return securityhubtypes.SeverityLabelCritical
+	case scanner.SeverityHigh:
+		return securityhubtypes.SeverityLabelHigh
+	case scanner.SeverityMedium:
+		return securityhubtypes.SeverityLabelMedium
+	case scanner.SeverityLow:
+		return securityhubtypes.SeverityLabelLow
+	default:
+		return securityhubtypes.SeverityLabelInformational
+	}
+}
+
+func complianceStatus(status scanner.FindingStatus) securityhubtypes.ComplianceStatus {
+	switch status {
+	case scanner.StatusPass:
+		return securityhubtypes.ComplianceStatusPassed
+	case scanner.StatusFail:
+		return securityhubtypes.ComplianceStatusFailed
+	default:
+		return securityhubtypes.ComplianceStatusNotAvailable
+	}
+}
+
+func resourceType(service string) string {
+	if t, ok := resourceTypes[service]; ok {
+		return t
+	}
+	return "Other"
+}
+
+// securityHubAPI is the subset of the Security Hub client used by Uploader.
+type securityHubAPI interface {
+	BatchImportFindings(ctx context.Context, params *securityhub.BatchImportFindingsInput, optFns ...func(*securityhub.Options)) (*securityhub.BatchImportFindingsOutput, error)
+}
+
+// Uploader imports ASFF findings into Security Hub.
+type Uploader struct {
+	client securityHubAPI
+}
+
+// NewUploader creates an Uploader using the provided AWS configuration.
+// Security Hub only accepts findings whose ProductArn region matches the
+// client's region, so cfg.Region should match the findings being imported.
+func NewUploader(cfg aws.Config) *Uploader {
+	return &Uploader{client: securityhub.NewFromConfig(cfg)}
+}
+
+// BatchImportFindings imports findings in batches of up to 100 and returns
+// the number imported. Findings rejected by Security Hub are reported in the
+// returned error after all batches have been sent.
+func (u *Uploader) BatchImportFindings(ctx context.Context, findings []securityhubtypes.AwsSecurityFinding) (int, error) {
+	imported := 0
+	var rejected []string
+	for start := 0; start < len(findings); start += maxBatchSize {
+		end := min(start+maxBatchSize, len(findings))
+		out, err := u.client.BatchImportFindings(ctx, &securityhub.BatchImportFindingsInput{
+			Findings: findings[start:end],
+		})
+		if err != nil {
+			return imported, fmt.Errorf("importing findings: %w", err)
+		}
+		imported += int(aws.ToInt32(out.SuccessCount))
+		for _, failed := range out.FailedFindings {
+			rejected = append(rejected, fmt.Sprintf("%s (%s)", aws.ToString(failed.Id), aws.ToString(failed.ErrorMessage)))
+		}
+	}
+
+	if len(rejected) > 0 {
+		return imported, fmt.Errorf("security hub rejected %d findings: %s", len(rejected), strings.Join(rejected, "; "))
+	}
+	return imported, nil
+}
@@ -0,0 +1,185 @@
+package securityhub
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/securityhub"
+	securityhubtypes "github.com/aws/aws-sdk-go-v2/service/securityhub/types"
+)
+
+func testResult() *scanner.ScanResult {
+	completed := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	return &scanner.ScanResult{
+		AccountID:   "123456789012",
+		CompletedAt: completed,
+		Findings: []scanner.Finding{
+			{Service: "s3", Region: "eu-west-1", ResourceID: "public-bucket", CheckID: "s3_bucket_public_access", Status: scanner.StatusFail, Severity: scanner.SeverityCritical, Title: "S3 bucket has public access via ACL", Description: "Bucket grants AllUsers", Compliance: []string{"CIS-2.1.5"}, Timestamp: completed},
+			{Service: "iam", Region: "global", ResourceID: "alice", CheckID: "iam_user_mfa", Status: scanner.StatusFail, Severity: scanner.SeverityHigh, Title: "IAM user without MFA"},
+			{Service: "ec2", Region: "us-west-2", ResourceID: "i-123", CheckID: "ec2_public_ip", Status: scanner.StatusFail, Severity: scanner.SeverityMedium, Title: "EC2 instance has public IP address"},
+			{Service: "dynamodb", Region: "us-west-2", ResourceID: "us-west-2", CheckID: "dynamodb_vpc_endpoint", Status: scanner.StatusFail, Severity: scanner.SeverityLow, Title: "No DynamoDB VPC endpoint"},
+			{Service: "s3", Region: "eu-west-1", ResourceID: "private-bucket", CheckID: "s3_bucket_encryption", Status: scanner.StatusPass, Severity: scanner.SeverityHigh, Title: "S3 bucket is encrypted"},
+			{Service: "kms", Region: "eu-west-1", ResourceID: "key-1", CheckID: "kms_broad_grant", Status: scanner.StatusError, Severity: scanner.SeverityMedium, Title: "Access denied"},
+		},
+	}
+}
+
+func TestToSecurityHub_RequiredFields(t *testing.T) {
+	findings := ToSecurityHub(testResult())
+
+	if len(findings) != 6 {
+		t.Fatalf("expected 6 findings, got %d", len(findings))
+	}
+	for _, f := range findings {
+		required := map[string]*string{
+			"SchemaVersion": f.SchemaVersion,
+			"Id":            f.Id,
+			"ProductArn":    f.ProductArn,
+			"GeneratorId":   f.GeneratorId,
+			"AwsAccountId":  f.AwsAccountId,
+			"CreatedAt":     f.CreatedAt,
+			"UpdatedAt":     f.UpdatedAt,
+			"Title":         f.Title,
+			"Description":   f.Description,
+		}
+		for field, value := range required {
+			if value == nil {
+				t.Errorf("%s: %s is not set", aws.ToString(f.Id), field)
+			}
+		}
+		if len(f.Types) == 0 {
+			t.Errorf("%s: Types is empty", aws.ToString(f.Id))
+		}
+		if len(f.Resources) != 1 || f.Resources[0].Id == nil || f.Resources[0].Type == nil {
+			t.Errorf("%s: Resources = %+v, want one resource with Id and Type", aws.ToString(f.Id), f.Resources)
+		}
+		if f.Compliance == nil || f.Compliance.Status == "" {
+			t.Errorf("%s: Compliance.Status is not set", aws.ToString(f.Id))
+		}
+		if _, err := time.Parse(time.RFC3339, aws.ToString(f.UpdatedAt)); err != nil {
+			t.Errorf("UpdatedAt %q is not RFC 3339: %v", aws.ToString(f.UpdatedAt), err)
+		}
+	}
+
+	first := findings[0]
+	if got := aws.ToString(first.Id); got != "cloudcop/123456789012/eu-west-1/s3_bucket_public_access/public-bucket" {
+		t.Errorf("Id = %s", got)
+	}
+	if got := aws.ToString(first.ProductArn); got != "arn:aws:securityhub:eu-west-1:123456789012:product/123456789012/default" {
+		t.Errorf("ProductArn = %s", got)
+	}
+	if got := aws.ToString(first.Resources[0].Type); got != "AwsS3Bucket" {
+		t.Errorf("Resources[0].Type = %s, want AwsS3Bucket", got)
+	}
+	if first.Compliance.Status != securityhubtypes.ComplianceStatusFailed || len(first.Compliance.RelatedRequirements) != 1 {
+		t.Errorf("Compliance = %+v, want FAILED with CIS-2.1.5", first.Compliance)
+	}
+
+	// Global findings are imported into the default region.
+	if got := aws.ToString(findings[1].ProductArn); !strings.Contains(got, ":us-east-1:") {
+		t.Errorf("global finding ProductArn = %s, want us-east-1", got)
+	}
+}
+
+func TestToSecurityHub_Partition(t *testing.T) {
+	tests := []struct {
+		region        string
+		wantPartition securityhubtypes.Partition
+		wantARN       string
+	}{
+		{"eu-west-1", securityhubtypes.PartitionAws, "arn:aws:securityhub:eu-west-1:123456789012:product/123456789012/default"},
+		{"us-gov-west-1", securityhubtypes.PartitionAwsUsGov, "arn:aws-us-gov:securityhub:us-gov-west-1:123456789012:product/123456789012/default"},
+		{"cn-north-1", securityhubtypes.PartitionAwsCn, "arn:aws-cn:securityhub:cn-north-1:123456789012:product/123456789012/default"},
+	}
+	for _, tt := range tests {
+		findings := ToSecurityHub(&scanner.ScanResult{
+			AccountID: "123456789012",
+			Findings:  []scanner.Finding{{Service: "s3", Region: tt.region, ResourceID: "b", CheckID: "s3_bucket_encryption", Status: scanner.StatusFail}},
+		})
+		f := findings[0]
+		if got := aws.ToString(f.ProductArn); got != tt.wantARN {
+			t.Errorf("%s: ProductArn = %s, want %s", tt.region, got, tt.wantARN)
+		}
+		if got := f.Resources[0].Partition; got != tt.wantPartition {
+			t.Errorf("%s: resource partition = %s, want %s", tt.region, got, tt.wantPartition)
+		}
+	}
+}
+
+func TestToSecurityHub_SeverityLabels(t *testing.T) {
+	findings := ToSecurityHub(testResult())
+
+	want := []struct {
+		severity   securityhubtypes.SeverityLabel
+		compliance securityhubtypes.ComplianceStatus
+	}{
+		{securityhubtypes.SeverityLabelCritical, securityhubtypes.ComplianceStatusFailed},
+		{securityhubtypes.SeverityLabelHigh, securityhubtypes.ComplianceStatusFailed},
+		{securityhubtypes.SeverityLabelMedium, securityhubtypes.ComplianceStatusFailed},
+		{securityhubtypes.SeverityLabelLow, securityhubtypes.ComplianceStatusFailed},
+		{securityhubtypes.SeverityLabelInformational, securityhubtypes.ComplianceStatusPassed},
+		{securityhubtypes.SeverityLabelMedium, securityhubtypes.ComplianceStatusNotAvailable},
+	}
+	for i, w := range want {
+		f := findings[i]
+		if f.Severity == nil || f.Severity.Label != w.severity {
+			t.Errorf("%s: Severity = %+v, want %s", aws.ToString(f.Id), f.Severity, w.severity)
+		}
+		if f.Compliance.Status != w.compliance {
+			t.Errorf("%s: Compliance.Status = %s, want %s", aws.ToString(f.Id), f.Compliance.Status, w.compliance)
+		}
+	}
+}
+
+func TestToSecurityHub_NilResult(t *testing.T) {
+	if got := ToSecurityHub(nil); got != nil {
+		t.Errorf("ToSecurityHub(nil) = %v, want nil", got)
+	}
+}
+
+// fakeSecurityHubClient accepts every finding except those whose ID is listed in reject.
+type fakeSecurityHubClient struct {
+	batches []int
+	reject  map[string]bool
+}
+
+func (f *fakeSecurityHubClient) BatchImportFindings(_ context.Context, params *securityhub.BatchImportFindingsInput, _ ...func(*securityhub.Options)) (*securityhub.BatchImportFindingsOutput, error) {
+	f.batches = append(f.batches, len(params.Findings))
+	out := &securityhub.BatchImportFindingsOutput{SuccessCount: aws.Int32(0), FailedCount: aws.Int32(0)}
+	for _, finding := range params.Findings {
+		if f.reject[aws.ToString(finding.Id)] {
+			*out.FailedCount++
+			out.FailedFindings = append(out.FailedFindings, securityhubtypes.ImportFindingsError{Id: finding.Id, ErrorMessage: aws.String("invalid")})
+			continue
+		}
+		*out.SuccessCount++
+	}
+	return out, nil
+}
+
+func TestUploader_BatchImportFindings(t *testing.T) {
+	var findings []securityhubtypes.AwsSecurityFinding
+	for i := 0; i < 250; i++ {
+		findings = append(findings, securityhubtypes.AwsSecurityFinding{Id: aws.String(fmt.Sprintf("finding-%d", i))})
+	}
+	client := &fakeSecurityHubClient{reject: map[string]bool{"finding-7": true}}
+	u := &Uploader{client: client}
+
+	imported, err := u.BatchImportFindings(context.Background(), findings)
+
+	if imported != 249 {
+		t.Errorf("imported = %d, want 249", imported)
+	}
+	if err == nil || !strings.Contains(err.Error(), "finding-7") {
+		t.Errorf("error = %v, want rejection of finding-7", err)
+	}
+	if fmt.Sprint(client.batches) != "[100 100 50]" {
+		t.Errorf("batches = %v, want [100 100 50]", client.batches)
+	}
+}
@@ -0,0 +1,220 @@
+// Package jobs tracks work that runs in the background after the request
+// starting it has returned, so clients can poll for its outcome.
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultMaxJobs is how many jobs a Manager tracks at once.
+	defaultMaxJobs = 100
+	// defaultMaxRunning is how many jobs a Manager runs at once.
+	defaultMaxRunning = 4
+)
+
+// ErrTooManyJobs is returned by Submit when the manager is tracking its
+// maximum number of jobs and none of them has finished.
+var ErrTooManyJobs = errors.New("too many jobs in progress")
+
+// Status is the lifecycle state of a job.
+type Status string
+
+// Job statuses.
+const (
+	// StatusPending jobs are waiting for a free slot to run in.
+	StatusPending Status = "pending"
+	// StatusRunning jobs have started and not yet returned.
+	StatusRunning Status = "running"
+	// StatusCompleted jobs returned a result.
+	StatusCompleted Status = "completed"
+	// StatusFailed jobs returned an error or panicked.
+	StatusFailed Status = "failed"
+)
+
+// Done reports whether a job in this status has finished.
+func (s Status) Done() bool {
+	return s == StatusCompleted || s == StatusFailed
+}
+
+// Job is a snapshot of a submitted job.
+type Job[T any] struct {
+	// ID identifies the job to Get.
+	ID string
+	// Owner is who submitted the job, so callers can keep jobs private.
+	Owner  string
+	Status Status
+	// Result is set once the job has completed.
+	Result T
+	// Err is set once the job has failed.
+	Err         error
+	CreatedAt   time.Time
+	StartedAt   time.Time
+	CompletedAt time.Time
+}
+
+// Manager runs jobs in background goroutines and keeps their outcome in
+// memory. It bounds both how many jobs run at once and how many are
+// tracked; the oldest finished jobs are forgotten to make room for new
+// ones. A Manager is safe for concurrent use.
+type Manager[T any] struct {
+	maxJobs int
+	slots   chan struct{}
+	now     func() time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu    sync.Mutex
+	jobs  map[string]*Job[T]
+	order []string // job IDs, oldest first
+}
+
+// NewManager creates a Manager tracking up to maxJobs jobs and running up
+// to maxRunning of them at once. Non-positive values use the defaults of
+// 100 and 4.
+func NewManager[T any](maxJobs, maxRunning int) *Manager[T] {
+	if maxJobs <= 0 {
+		maxJobs = defaultMaxJobs
+	}
+	if maxRunning <= 0 {
+		maxRunning = defaultMaxRunning
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager[T]{
+		maxJobs: maxJobs,
+		slots:   make(chan struct{}, maxRunning),
+		now:     time.Now,
+		ctx:     ctx,
+		cancel:  cancel,
+		jobs:    make(map[string]*Job[T]),
+	}
+}
+
+// Submit starts run in the background on behalf of owner and returns the
+// pending job. run's context is cancelled by Stop. It returns
+// ErrTooManyJobs when no room can be made for the job.
+func (m *Manager[T]) Submit(owner string, run func(ctx context.Context) (T, error)) (Job[T], error) {
+	m.mu.Lock()
+	if len(m.order) >= m.maxJobs && !m.evictFinished() {
+		m.mu.Unlock()
+		return Job[T]{}, ErrTooManyJobs
+	}
+	job := &Job[T]{
+		ID:        newJobID(),
+		Owner:     owner,
+		Status:    StatusPending,
+		CreatedAt: m.now(),
+	}
+	m.jobs[job.ID] = job
+	m.order = append(m.order, job.ID)
+	snapshot := *job
+	m.mu.Unlock()
+
+	m.wg.Add(1)
+	go m.run(job.ID, run)
+	return snapshot, nil
+}
+
+// Get returns a snapshot of the job with the given ID.
+func (m *Manager[T]) Get(id string) (Job[T], bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return Job[T]{}, false
+	}
+	return *job, true
+}
+
+// Stop cancels every pending and running job and waits for them to finish.
+func (m *Manager[T]) Stop() {
+	m.cancel()
+	m.wg.Wait()
+}
+
+// run waits for a free slot, then runs the job and records its outcome.
+func (m *Manager[T]) run(id string, run func(ctx context.Context) (T, error)) {
+	defer m.wg.Done()
+
+	select {
+	case m.slots <- struct{}{}:
+		defer func() { <-m.slots }()
+	case <-m.ctx.Done():
+	}
+	// A slot freed by Stop may be taken before the cancellation is seen.
+	if err := m.ctx.Err(); err != nil {
+		var zero T
+		m.finish(id, zero, err)
+		return
+	}
+
+	m.update(id, func(job *Job[T]) {
+		job.Status = StatusRunning
+		job.StartedAt = m.now()
+	})
+
+	result, err := runSafely(m.ctx, run)
+	m.finish(id, result, err)
+}
+
+// runSafely calls run, turning a panic into an error so one bad job cannot
+// take down the process.
+func runSafely[T any](ctx context.Context, run func(ctx context.Context) (T, error)) (result T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return run(ctx)
+}
+
+// finish records the outcome of a job.
+func (m *Manager[T]) finish(id string, result T, err error) {
+	m.update(id, func(job *Job[T]) {
+		job.CompletedAt = m.now()
+		if err != nil {
+			job.Status = StatusFailed
+			job.Err = err
+			return
+		}
+		job.Status = StatusCompleted
+		job.Result = result
+	})
+}
+
+// update applies fn to the job with the given ID, if it is still tracked.
+func (m *Manager[T]) update(id string, fn func(*Job[T])) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if job, ok := m.jobs[id]; ok {
+		fn(job)
+	}
+}
+
+// evictFinished forgets the oldest finished job. It reports false when
+// every tracked job is still pending or running. m.mu must be held.
+func (m *Manager[T]) evictFinished() bool {
+	for i, id := range m.order {
+		if m.jobs[id].Status.Done() {
+			delete(m.jobs, id)
+			m.order = append(m.order[:i], m.order[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// newJobID returns a random job identifier.
+func newJobID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b) // crypto/rand.Read never returns an error
+	return "job-" + hex.EncodeToString(b)
+}
@@ -0,0 +1,153 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// waitFor polls the job until it has finished.
+func waitFor[T any](t *testing.T, m *Manager[T], id string) Job[T] {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		job, ok := m.Get(id)
+		if !ok {
+			t.Fatalf("job %s not found", id)
+		}
+		if job.Status.Done() {
+			return job
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return Job[T]{}
+}
+
+func TestManager_Lifecycle(t *testing.T) {
+	m := NewManager[int](0, 0)
+	defer m.Stop()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	job, err := m.Submit("user_1", func(context.Context) (int, error) {
+		close(started)
+		<-release
+		return 42, nil
+	})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if job.Status != StatusPending || job.Owner != "user_1" || job.ID == "" {
+		t.Errorf("submitted job = %+v, want pending job owned by user_1", job)
+	}
+
+	<-started
+	if got, _ := m.Get(job.ID); got.Status != StatusRunning || got.StartedAt.IsZero() {
+		t.Errorf("started job = %+v, want running", got)
+	}
+
+	close(release)
+	done := waitFor(t, m, job.ID)
+	if done.Status != StatusCompleted || done.Result != 42 || done.Err != nil || done.CompletedAt.IsZero() {
+		t.Errorf("finished job = %+v, want completed with 42", done)
+	}
+}
+
+func TestManager_Failures(t *testing.T) {
+	m := NewManager[int](0, 0)
+	defer m.Stop()
+
+	failed, _ := m.Submit("user_1", func(context.Context) (int, error) {
+		return 0, errors.New("boom")
+	})
+	panicked, _ := m.Submit("user_1", func(context.Context) (int, error) {
+		panic("bad job")
+	})
+
+	if job := waitFor(t, m, failed.ID); job.Status != StatusFailed || job.Err == nil || job.Err.Error() != "boom" {
+		t.Errorf("failed job = %+v, want failed with boom", job)
+	}
+	if job := waitFor(t, m, panicked.ID); job.Status != StatusFailed || job.Err == nil {
+		t.Errorf("panicked job = %+v, want failed", job)
+	}
+}
+
+func TestManager_LimitsRunningJobs(t *testing.T) {
+	m := NewManager[int](0, 1)
+	defer m.Stop()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	first, _ := m.Submit("user_1", func(context.Context) (int, error) {
+		close(started)
+		<-release
+		return 1, nil
+	})
+	<-started
+	second, _ := m.Submit("user_1", func(context.Context) (int, error) { return 2, nil })
+
+	time.Sleep(10 * time.Millisecond)
+	if job, _ := m.Get(second.ID); job.Status != StatusPending {
+		t.Errorf("second job status = %s while the first runs, want pending", job.Status)
+	}
+
+	close(release)
+	waitFor(t, m, first.ID)
+	if job := waitFor(t, m, second.ID); job.Result != 2 {
+		t.Errorf("second job result = %d, want 2", job.Result)
+	}
+}
+
+func TestManager_EvictsOldestFinishedJob(t *testing.T) {
+	m := NewManager[int](2, 0)
+	defer m.Stop()
+
+	quick, _ := m.Submit("user_1", func(context.Context) (int, error) { return 1, nil })
+	waitFor(t, m, quick.ID)
+
+	release := make(chan struct{})
+	defer close(release)
+	block := func(ctx context.Context) (int, error) {
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return 0, nil
+	}
+	if _, err := m.Submit("user_1", block); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if _, err := m.Submit("user_1", block); err != nil {
+		t.Fatalf("Submit() with a finished job to evict error = %v", err)
+	}
+	if _, ok := m.Get(quick.ID); ok {
+		t.Error("finished job was not evicted")
+	}
+
+	if _, err := m.Submit("user_1", block); !errors.Is(err, ErrTooManyJobs) {
+		t.Errorf("Submit() with only unfinished jobs error = %v, want ErrTooManyJobs", err)
+	}
+}
+
+func TestManager_StopCancelsJobs(t *testing.T) {
+	m := NewManager[int](0, 1)
+
+	started := make(chan struct{})
+	running, _ := m.Submit("user_1", func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	<-started
+	pending, _ := m.Submit("user_1", func(context.Context) (int, error) { return 1, nil })
+
+	m.Stop()
+
+	for _, id := range []string{running.ID, pending.ID} {
+		if job, _ := m.Get(id); job.Status != StatusFailed || !errors.Is(job.Err, context.Canceled) {
+			t.Errorf("job %s after Stop = %s (%v), want failed with context.Canceled", id, job.Status, job.Err)
+		}
+	}
+}
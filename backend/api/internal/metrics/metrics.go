@@ -0,0 +1,119 @@
+// Package metrics exports the security posture of scanned accounts as
+// Prometheus metrics.
+package metrics
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// severities and statuses are the label values every scanned service
+// reports, so a combination with no findings is exported as 0 rather than
+// missing.
+var (
+	severities = []scanner.Severity{scanner.SeverityLow, scanner.SeverityMedium, scanner.SeverityHigh, scanner.SeverityCritical}
+	statuses   = []scanner.FindingStatus{scanner.StatusPass, scanner.StatusFail, scanner.StatusError}
+)
+
+// Collector holds the findings gauges, updated from each completed scan.
+// Labels are limited to account, service, severity, and status; resource
+// IDs are never used as labels so the number of series stays bounded. The
+// account label holds AccountLabel of the account ID rather than the ID
+// itself, so scrapes do not reveal which AWS accounts are connected.
+type Collector struct {
+	// mu serializes Observe, so the reset and refill of an account's series
+	// is never interleaved with another scan of the same account.
+	mu       sync.Mutex
+	registry *prometheus.Registry
+	findings *prometheus.GaugeVec
+	lastScan *prometheus.GaugeVec
+}
+
+// NewCollector creates a Collector with its own registry.
+func NewCollector() *Collector {
+	c := &Collector{
+		registry: prometheus.NewRegistry(),
+		findings: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cloudcop_findings_total",
+			Help: "Findings of the latest scan of each account and service, by severity and status.",
+		}, []string{"account", "service", "severity", "status"}),
+		lastScan: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cloudcop_last_scan_timestamp_seconds",
+			Help: "Completion time of the latest scan of each account, in seconds since the Unix epoch.",
+		}, []string{"account"}),
+	}
+	c.registry.MustRegister(c.findings, c.lastScan)
+	return c
+}
+
+// Registry returns the registry the gauges are registered on.
+func (c *Collector) Registry() *prometheus.Registry {
+	return c.registry
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (c *Collector) Handler() http.Handler {
+	return promhttp.HandlerFor(c.registry, promhttp.HandlerOpts{})
+}
+
+// AccountLabel returns the value of the account label for an AWS account
+// ID: a truncated SHA-256 hash, stable across restarts so series continue
+// between scans.
+func AccountLabel(accountID string) string {
+	sum := sha256.Sum256([]byte(accountID))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Observe replaces the findings gauges of every service covered by result
+// with the counts from result. Services outside the scan keep the values of
+// their latest scan. A nil Collector ignores the call.
+func (c *Collector) Observe(result *scanner.ScanResult) {
+	if c == nil || result == nil {
+		return
+	}
+
+	counts := make(map[[3]string]int)
+	for _, f := range result.Findings {
+		if f.Status != scanner.StatusFail || f.Aggregated == nil {
+			counts[[3]string{f.Service, string(f.Severity), string(f.Status)}]++
+			continue
+		}
+		// An aggregate finding stands in for every failure beyond the
+		// check's cap, each at its own severity.
+		for severity, n := range map[scanner.Severity]int{
+			scanner.SeverityCritical: f.Aggregated.Critical,
+			scanner.SeverityHigh:     f.Aggregated.High,
+			scanner.SeverityMedium:   f.Aggregated.Medium,
+			scanner.SeverityLow:      f.Aggregated.Low,
+		} {
+			counts[[3]string{f.Service, string(severity), string(f.Status)}] += n
+		}
+	}
+
+	account := AccountLabel(result.AccountID)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, service := range result.Services {
+		c.findings.DeletePartialMatch(prometheus.Labels{"account": account, "service": service})
+		for _, severity := range severities {
+			for _, status := range statuses {
+				c.findings.WithLabelValues(account, service, string(severity), string(status)).Set(0)
+			}
+		}
+	}
+	for key, n := range counts {
+		c.findings.WithLabelValues(account, key[0], key[1], key[2]).Set(float64(n))
+	}
+	if !result.CompletedAt.IsZero() {
+		c.lastScan.WithLabelValues(account).Set(float64(result.CompletedAt.Unix()))
+	}
+}
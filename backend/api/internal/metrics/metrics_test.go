@@ -0,0 +1,168 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"cloudcop/api/internal/scanner"
+)
+
+// gather scrapes c's registry and returns the value of each series of name,
+// keyed by its label values joined with "/".
+func gather(t *testing.T, c *Collector, name string) map[string]float64 {
+	t.Helper()
+	families, err := c.Registry().Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+
+	values := make(map[string]float64)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			key := labels["account"]
+			if labels["service"] != "" {
+				key = fmt.Sprintf("%s/%s/%s/%s", labels["account"], labels["service"], labels["severity"], labels["status"])
+			}
+			values[key] = m.GetGauge().GetValue()
+		}
+	}
+	return values
+}
+
+func finding(service string, severity scanner.Severity, status scanner.FindingStatus) scanner.Finding {
+	return scanner.Finding{Service: service, ResourceID: "arn:aws:s3:::bucket", Severity: severity, Status: status}
+}
+
+func TestCollector_Observe(t *testing.T) {
+	completed := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := NewCollector()
+	account := AccountLabel("123456789012")
+
+	c.Observe(&scanner.ScanResult{
+		AccountID:   "123456789012",
+		Services:    []string{"s3", "iam"},
+		CompletedAt: completed,
+		Findings: []scanner.Finding{
+			finding("s3", scanner.SeverityCritical, scanner.StatusFail),
+			finding("s3", scanner.SeverityCritical, scanner.StatusFail),
+			finding("s3", scanner.SeverityHigh, scanner.StatusPass),
+			finding("iam", scanner.SeverityMedium, scanner.StatusError),
+		},
+	})
+
+	got := gather(t, c, "cloudcop_findings_total")
+	want := map[string]float64{
+		account + "/s3/CRITICAL/FAIL":  2,
+		account + "/s3/HIGH/PASS":      1,
+		account + "/s3/LOW/FAIL":       0,
+		account + "/iam/MEDIUM/ERROR":  1,
+		account + "/iam/CRITICAL/FAIL": 0,
+	}
+	for key, value := range want {
+		v, ok := got[key]
+		if !ok {
+			t.Errorf("%s missing", key)
+			continue
+		}
+		if v != value {
+			t.Errorf("%s = %v, want %v", key, v, value)
+		}
+	}
+	if n := len(got); n != 2*len(severities)*len(statuses) {
+		t.Errorf("got %d series, want %d", n, 2*len(severities)*len(statuses))
+	}
+
+	if got := gather(t, c, "cloudcop_last_scan_timestamp_seconds")[account]; got != float64(completed.Unix()) {
+		t.Errorf("last scan = %v, want %v", got, completed.Unix())
+	}
+
+	// A later scan of s3 alone replaces its counts and leaves iam untouched.
+	c.Observe(&scanner.ScanResult{
+		AccountID:   "123456789012",
+		Services:    []string{"s3"},
+		CompletedAt: completed.Add(time.Hour),
+		Findings:    []scanner.Finding{finding("s3", scanner.SeverityHigh, scanner.StatusFail)},
+	})
+
+	got = gather(t, c, "cloudcop_findings_total")
+	for key, value := range map[string]float64{
+		account + "/s3/CRITICAL/FAIL": 0,
+		account + "/s3/HIGH/FAIL":     1,
+		account + "/s3/HIGH/PASS":     0,
+		account + "/iam/MEDIUM/ERROR": 1,
+	} {
+		if got[key] != value {
+			t.Errorf("after rescan %s = %v, want %v", key, got[key], value)
+		}
+	}
+}
+
+func TestCollector_ObserveCappedResult(t *testing.T) {
+	c := NewCollector()
+	account := AccountLabel("123456789012")
+
+	aggregate := finding("s3", scanner.SeverityCritical, scanner.StatusFail)
+	aggregate.ResourceID = "*"
+	aggregate.Aggregated = &scanner.SeverityCounts{Critical: 1, High: 3, Low: 2}
+	c.Observe(&scanner.ScanResult{
+		AccountID: "123456789012",
+		Services:  []string{"s3"},
+		Findings: []scanner.Finding{
+			finding("s3", scanner.SeverityHigh, scanner.StatusFail),
+			aggregate,
+		},
+	})
+
+	got := gather(t, c, "cloudcop_findings_total")
+	for key, value := range map[string]float64{
+		account + "/s3/CRITICAL/FAIL": 1,
+		account + "/s3/HIGH/FAIL":     4,
+		account + "/s3/MEDIUM/FAIL":   0,
+		account + "/s3/LOW/FAIL":      2,
+	} {
+		if got[key] != value {
+			t.Errorf("%s = %v, want %v", key, got[key], value)
+		}
+	}
+}
+
+func TestCollector_NilSafe(t *testing.T) {
+	var c *Collector
+	c.Observe(&scanner.ScanResult{AccountID: "123456789012"})
+	NewCollector().Observe(nil)
+}
+
+func TestCollector_Handler(t *testing.T) {
+	c := NewCollector()
+	c.Observe(&scanner.ScanResult{
+		AccountID: "123456789012",
+		Services:  []string{"s3"},
+		Findings:  []scanner.Finding{finding("s3", scanner.SeverityCritical, scanner.StatusFail)},
+	})
+
+	w := httptest.NewRecorder()
+	c.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(w.Body)
+
+	line := `cloudcop_findings_total{account="` + AccountLabel("123456789012") + `",service="s3",severity="CRITICAL",status="FAIL"} 1`
+	if !strings.Contains(string(body), line) {
+		t.Errorf("response does not contain %q:\n%s", line, body)
+	}
+	if strings.Contains(string(body), "123456789012") {
+		t.Error("response exposes the AWS account ID")
+	}
+	if strings.Contains(string(body), "arn:aws:s3") {
+		t.Error("response exposes a resource ID as a label")
+	}
+}
@@ -19,6 +19,26 @@ type contextKey struct {
 	name string
 }
 
+// SelfHostedUser returns the user every request is attributed to in
+// self-hosted mode.
+func SelfHostedUser() *clerk.User {
+	firstName := "Self"
+	lastName := "Hosted"
+	emailID := "mock_email_id"
+	return &clerk.User{
+		ID:                    "mock_user_id",
+		FirstName:             &firstName,
+		LastName:              &lastName,
+		PrimaryEmailAddressID: &emailID,
+		EmailAddresses: []clerk.EmailAddress{
+			{
+				ID:           emailID,
+				EmailAddress: "support@cloudcop.dev",
+			},
+		},
+	}
+}
+
 // Middleware returns a Gin handler that authenticates requests and, when successful,
 // attaches the authenticated Clerk user to the request context.
 //
@@ -34,23 +54,7 @@ func Middleware() gin.HandlerFunc {
 		// Self-hosted mode mock auth
 		selfHosting := os.Getenv("SELF_HOSTING") != ""
 		if selfHosting {
-			firstName := "Self"
-			lastName := "Hosted"
-			emailID := "mock_email_id"
-			email := "support@cloudcop.dev"
-			user := clerk.User{
-				ID:                    "mock_user_id",
-				FirstName:             &firstName,
-				LastName:              &lastName,
-				PrimaryEmailAddressID: &emailID,
-				EmailAddresses: []clerk.EmailAddress{
-					{
-						ID:           emailID,
-						EmailAddress: email,
-					},
-				},
-			}
-			ctx := AttachContext(r.Context(), &user)
+			ctx := AttachContext(r.Context(), SelfHostedUser())
 			c.Request = r.WithContext(ctx)
 			c.Next()
 			return
@@ -0,0 +1,156 @@
+// Package ratelimit provides per-client token-bucket rate limiting middleware.
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"cloudcop/api/internal/middleware/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// defaultPerMinute is the sustained number of requests a client may make
+	// each minute.
+	defaultPerMinute = 120
+	// defaultBurst is how many requests a client may make back to back
+	// before the sustained rate applies.
+	defaultBurst = 30
+	// sweepInterval is how often idle clients' buckets are dropped.
+	sweepInterval = time.Minute
+)
+
+// Config sets the rate each client is allowed.
+type Config struct {
+	// PerMinute is the sustained request rate. Zero or negative disables
+	// rate limiting.
+	PerMinute float64
+	// Burst is the bucket size, the number of requests allowed at once.
+	// Values below one are treated as one.
+	Burst int
+}
+
+// ConfigFromEnv reads GRAPHQL_RATE_LIMIT (requests per minute, 0 disables
+// limiting) and GRAPHQL_RATE_BURST, using the defaults for unset variables.
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{PerMinute: defaultPerMinute, Burst: defaultBurst}
+	if v := os.Getenv("GRAPHQL_RATE_LIMIT"); v != "" {
+		perMinute, err := strconv.ParseFloat(v, 64)
+		if err != nil || math.IsNaN(perMinute) || math.IsInf(perMinute, 0) {
+			return Config{}, fmt.Errorf("invalid GRAPHQL_RATE_LIMIT %q", v)
+		}
+		cfg.PerMinute = perMinute
+	}
+	if v := os.Getenv("GRAPHQL_RATE_BURST"); v != "" {
+		burst, err := strconv.Atoi(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid GRAPHQL_RATE_BURST %q", v)
+		}
+		cfg.Burst = burst
+	}
+	return cfg, nil
+}
+
+// bucket holds a client's available tokens as of last.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter tracks a token bucket per client key. It is safe for concurrent use.
+type Limiter struct {
+	rate  float64 // tokens added per second
+	burst float64
+	now   func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewLimiter returns a Limiter enforcing cfg. It returns nil when cfg
+// disables rate limiting; a nil Limiter allows every request.
+func NewLimiter(cfg Config) *Limiter {
+	if cfg.PerMinute <= 0 {
+		return nil
+	}
+	return &Limiter{
+		rate:    cfg.PerMinute / 60,
+		burst:   float64(max(cfg.Burst, 1)),
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow takes a token from key's bucket. When the bucket is empty it
+// reports false and how long until the next token is available.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+		return false, wait
+	}
+	b.tokens--
+	return true, 0
+}
+
+// sweep drops the buckets of clients idle long enough to have refilled, so
+// the map does not grow with every address ever seen. Callers hold l.mu.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Middleware returns a Gin handler that rate limits requests per client.
+// Clients are keyed by the authenticated user, so it must run after
+// auth.Middleware; anonymous requests are keyed by client IP. Requests over
+// the limit get HTTP 429 with a Retry-After header in whole seconds.
+func Middleware(l *Limiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ok, wait := l.Allow(clientKey(c))
+		if ok {
+			c.Next()
+			return
+		}
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Rate limit exceeded"})
+	}
+}
+
+// clientKey identifies the client a request is counted against.
+func clientKey(c *gin.Context) string {
+	if user := auth.FromContext(c.Request.Context()); user != nil && user.ID != "" {
+		return "user:" + user.ID
+	}
+	return "ip:" + c.ClientIP()
+}
@@ -0,0 +1,144 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cloudcop/api/internal/middleware/auth"
+
+	"github.com/clerkinc/clerk-sdk-go/clerk"
+	"github.com/gin-gonic/gin"
+)
+
+// fakeClock is a controllable time source for a Limiter.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestRouter(t *testing.T, cfg Config) (*gin.Engine, *fakeClock) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := NewLimiter(cfg)
+	if limiter != nil {
+		limiter.now = clock.Now
+	}
+
+	r := gin.New()
+	r.POST("/api/query", Middleware(limiter), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"data": nil})
+	})
+	return r, clock
+}
+
+func query(r *gin.Engine, userID, ip string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/query", nil)
+	req.RemoteAddr = ip + ":12345"
+	if userID != "" {
+		req = req.WithContext(auth.AttachContext(req.Context(), &clerk.User{ID: userID}))
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware_LimitsAfterBurst(t *testing.T) {
+	r, clock := newTestRouter(t, Config{PerMinute: 30, Burst: 3})
+
+	for i := range 3 {
+		if w := query(r, "user_1", "192.0.2.1"); w.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want 200 within the burst", i+1, w.Code)
+		}
+	}
+
+	w := query(r, "user_1", "192.0.2.1")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429 after the burst", w.Code)
+	}
+	// 30 requests a minute refill one token every 2 seconds.
+	if got := w.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("Retry-After = %q, want 2", got)
+	}
+
+	// Other users and anonymous clients have their own buckets.
+	if w := query(r, "user_2", "192.0.2.1"); w.Code != http.StatusOK {
+		t.Errorf("other user: status = %d, want 200", w.Code)
+	}
+	if w := query(r, "", "192.0.2.1"); w.Code != http.StatusOK {
+		t.Errorf("anonymous client: status = %d, want 200", w.Code)
+	}
+
+	clock.Advance(2 * time.Second)
+	if w := query(r, "user_1", "192.0.2.1"); w.Code != http.StatusOK {
+		t.Errorf("after refill: status = %d, want 200", w.Code)
+	}
+	if w := query(r, "user_1", "192.0.2.1"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("after one refilled token: status = %d, want 429", w.Code)
+	}
+}
+
+func TestMiddleware_KeysAnonymousClientsByIP(t *testing.T) {
+	r, _ := newTestRouter(t, Config{PerMinute: 60, Burst: 1})
+
+	if w := query(r, "", "192.0.2.1"); w.Code != http.StatusOK {
+		t.Fatalf("first request: status = %d, want 200", w.Code)
+	}
+	if w := query(r, "", "192.0.2.1"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("same IP: status = %d, want 429", w.Code)
+	}
+	if w := query(r, "", "198.51.100.7"); w.Code != http.StatusOK {
+		t.Errorf("other IP: status = %d, want 200", w.Code)
+	}
+}
+
+func TestMiddleware_Disabled(t *testing.T) {
+	r, _ := newTestRouter(t, Config{PerMinute: 0, Burst: 1})
+
+	for i := range 10 {
+		if w := query(r, "user_1", "192.0.2.1"); w.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want 200 with limiting disabled", i+1, w.Code)
+		}
+	}
+}
+
+func TestLimiter_SweepsIdleClients(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := NewLimiter(Config{PerMinute: 60, Burst: 5})
+	l.now = clock.Now
+
+	l.Allow("ip:192.0.2.1")
+	clock.Advance(2 * sweepInterval)
+	l.Allow("ip:198.51.100.7")
+
+	if _, ok := l.buckets["ip:192.0.2.1"]; ok || len(l.buckets) != 1 {
+		t.Errorf("buckets = %v, want only the active client", l.buckets)
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("GRAPHQL_RATE_LIMIT", "")
+	t.Setenv("GRAPHQL_RATE_BURST", "")
+	cfg, err := ConfigFromEnv()
+	if err != nil || cfg != (Config{PerMinute: defaultPerMinute, Burst: defaultBurst}) {
+		t.Errorf("ConfigFromEnv() = %+v, %v, want defaults", cfg, err)
+	}
+
+	t.Setenv("GRAPHQL_RATE_LIMIT", "600")
+	t.Setenv("GRAPHQL_RATE_BURST", "50")
+	cfg, err = ConfigFromEnv()
+	if err != nil || cfg != (Config{PerMinute: 600, Burst: 50}) {
+		t.Errorf("ConfigFromEnv() = %+v, %v, want 600/min with burst 50", cfg, err)
+	}
+
+	t.Setenv("GRAPHQL_RATE_LIMIT", "lots")
+	if _, err := ConfigFromEnv(); err == nil {
+		t.Error("ConfigFromEnv() accepted an invalid GRAPHQL_RATE_LIMIT")
+	}
+}
@@ -0,0 +1,199 @@
+// Package orgs discovers the member accounts of an AWS Organization, so a
+// whole organization can be scanned from its management account without
+// connecting each account by hand.
+package orgs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloudcop/api/internal/awsauth"
+	"cloudcop/api/internal/security"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	"github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// DefaultRoleName is the role assumed in each member account unless
+// overridden. It matches the role the CloudCop CloudFormation template creates.
+const DefaultRoleName = "CloudCopSecurityScanRole"
+
+// sessionName identifies organization scans in the member accounts' CloudTrail.
+const sessionName = "CloudCopOrgScan"
+
+// organizationsAPI is the subset of the Organizations client used for discovery.
+type organizationsAPI interface {
+	ListAccounts(ctx context.Context, params *organizations.ListAccountsInput, optFns ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error)
+}
+
+// Target is a member account and the role to assume to scan it.
+type Target struct {
+	AccountID string
+	Name      string
+	RoleARN   string
+}
+
+// Skipped is a member account left out of an organization scan.
+type Skipped struct {
+	AccountID string
+	Name      string
+	Reason    string
+}
+
+// Discoverer lists the accounts of the organization its credentials belong
+// to. The credentials must be those of the management account or of a
+// delegated administrator.
+type Discoverer struct {
+	client     organizationsAPI
+	sts        stscreds.AssumeRoleAPIClient
+	region     string
+	roleName   string
+	roleNames  map[string]string
+	externalID string
+}
+
+// Option configures a Discoverer.
+type Option func(*Discoverer)
+
+// WithRoleName sets the role assumed in every member account without an
+// account-specific role name.
+func WithRoleName(name string) Option {
+	return func(d *Discoverer) {
+		d.roleName = name
+	}
+}
+
+// WithAccountRoleName sets the role assumed in one member account, for
+// accounts that were set up with a different role.
+func WithAccountRoleName(accountID, name string) Option {
+	return func(d *Discoverer) {
+		d.roleNames[accountID] = name
+	}
+}
+
+// WithExternalID sets the external ID passed when assuming the member
+// account roles.
+func WithExternalID(externalID string) Option {
+	return func(d *Discoverer) {
+		d.externalID = externalID
+	}
+}
+
+// New creates a Discoverer using the management account credentials in cfg.
+func New(cfg aws.Config, opts ...Option) *Discoverer {
+	d := &Discoverer{
+		client:    organizations.NewFromConfig(cfg),
+		sts:       sts.NewFromConfig(cfg),
+		region:    cfg.Region,
+		roleName:  DefaultRoleName,
+		roleNames: make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Targets lists the organization's accounts and returns a target for each
+// active one. Suspended, closed, and not yet activated accounts cannot be
+// scanned and are returned as skipped.
+func (d *Discoverer) Targets(ctx context.Context) ([]Target, []Skipped, error) {
+	var targets []Target
+	var skipped []Skipped
+
+	paginator := organizations.NewListAccountsPaginator(d.client, &organizations.ListAccountsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("listing organization accounts: %w", err)
+		}
+		for _, account := range page.Accounts {
+			id := aws.ToString(account.Id)
+			name := aws.ToString(account.Name)
+			if account.State != types.AccountStateActive {
+				skipped = append(skipped, Skipped{
+					AccountID: id,
+					Name:      name,
+					Reason:    fmt.Sprintf("account is %s", account.State),
+				})
+				continue
+			}
+			targets = append(targets, Target{
+				AccountID: id,
+				Name:      name,
+				RoleARN:   awsauth.RoleARN(d.partition(account), id, d.roleNameFor(id)),
+			})
+		}
+	}
+	return targets, skipped, nil
+}
+
+// ScanConfigs returns a multi-account scan configuration for every active
+// account whose role can be assumed. Accounts where assuming the role fails,
+// typically because a service control policy denies it or the role was
+// never deployed, are returned as skipped instead of failing the scan.
+func (d *Discoverer) ScanConfigs(ctx context.Context, regions, services []string) ([]security.AccountScanConfig, []Skipped, error) {
+	targets, skipped, err := d.Targets(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	configs := make([]security.AccountScanConfig, 0, len(targets))
+	for _, target := range targets {
+		creds := d.Credentials(target)
+		if _, err := creds.Retrieve(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil, nil, ctx.Err()
+			}
+			skipped = append(skipped, Skipped{
+				AccountID: target.AccountID,
+				Name:      target.Name,
+				Reason:    fmt.Sprintf("assuming %s: %v", target.RoleARN, err),
+			})
+			continue
+		}
+		configs = append(configs, security.AccountScanConfig{
+			AccountID:   target.AccountID,
+			Credentials: creds,
+			Regions:     regions,
+			Services:    services,
+		})
+	}
+	return configs, skipped, nil
+}
+
+// Credentials returns a cached provider of credentials for target's role,
+// refreshed shortly before they expire.
+func (d *Discoverer) Credentials(target Target) aws.CredentialsProvider {
+	provider := stscreds.NewAssumeRoleProvider(d.sts, target.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+		o.RoleSessionName = sessionName
+		if d.externalID != "" {
+			o.ExternalID = aws.String(d.externalID)
+		}
+	})
+	return aws.NewCredentialsCache(provider, func(o *aws.CredentialsCacheOptions) {
+		o.ExpiryWindow = 5 * time.Minute
+	})
+}
+
+// roleNameFor returns the role to assume in accountID.
+func (d *Discoverer) roleNameFor(accountID string) string {
+	if name, ok := d.roleNames[accountID]; ok {
+		return name
+	}
+	return d.roleName
+}
+
+// partition returns the partition of account from its ARN, falling back to
+// the partition of the configured region.
+func (d *Discoverer) partition(account types.Account) string {
+	if parsed, err := arn.Parse(aws.ToString(account.Arn)); err == nil {
+		return parsed.Partition
+	}
+	return awsauth.PartitionForRegion(d.region)
+}
@@ -0,0 +1,171 @@
+package orgs
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	"github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	ststypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+	"github.com/aws/smithy-go"
+)
+
+// fakeOrganizationsClient serves ListAccounts from fixed pages.
+type fakeOrganizationsClient struct {
+	pages [][]types.Account
+	err   error
+}
+
+func (f *fakeOrganizationsClient) ListAccounts(_ context.Context, in *organizations.ListAccountsInput, _ ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	page := 0
+	if in.NextToken != nil {
+		page = len(aws.ToString(in.NextToken))
+	}
+	out := &organizations.ListAccountsOutput{Accounts: f.pages[page]}
+	if page+1 < len(f.pages) {
+		out.NextToken = aws.String(strings.Repeat("x", page+1))
+	}
+	return out, nil
+}
+
+// fakeSTSClient assumes any role except those denied by SCP.
+type fakeSTSClient struct {
+	denied  map[string]bool
+	assumed []*sts.AssumeRoleInput
+}
+
+func (f *fakeSTSClient) AssumeRole(_ context.Context, in *sts.AssumeRoleInput, _ ...func(*sts.Options)) (*sts.AssumeRoleOutput, error) {
+	f.assumed = append(f.assumed, in)
+	if f.denied[aws.ToString(in.RoleArn)] {
+		return nil, &smithy.GenericAPIError{Code: "AccessDenied", Message: "explicit deny in a service control policy"}
+	}
+	return &sts.AssumeRoleOutput{Credentials: &ststypes.Credentials{
+		AccessKeyId:     aws.String("AKIAEXAMPLE"),
+		SecretAccessKey: aws.String("secret"),
+		SessionToken:    aws.String("token"),
+		Expiration:      aws.Time(time.Now().Add(time.Hour)),
+	}}, nil
+}
+
+func account(id, name string, state types.AccountState) types.Account {
+	return types.Account{
+		Id:    aws.String(id),
+		Name:  aws.String(name),
+		Arn:   aws.String("arn:aws:organizations::111111111111:account/o-abc/" + id),
+		State: state,
+	}
+}
+
+func newTestDiscoverer(orgs *fakeOrganizationsClient, stsClient *fakeSTSClient, opts ...Option) *Discoverer {
+	d := &Discoverer{
+		client:    orgs,
+		sts:       stsClient,
+		region:    "us-east-1",
+		roleName:  DefaultRoleName,
+		roleNames: make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+func TestDiscoverer_Targets(t *testing.T) {
+	client := &fakeOrganizationsClient{pages: [][]types.Account{
+		{
+			account("111111111111", "management", types.AccountStateActive),
+			account("222222222222", "workloads", types.AccountStateActive),
+		},
+		{
+			account("333333333333", "retired", types.AccountStateSuspended),
+			account("444444444444", "legacy", types.AccountStateActive),
+			account("555555555555", "closing", types.AccountStatePendingClosure),
+		},
+	}}
+	d := newTestDiscoverer(client, &fakeSTSClient{}, WithAccountRoleName("444444444444", "LegacyAuditRole"))
+
+	targets, skipped, err := d.Targets(context.Background())
+	if err != nil {
+		t.Fatalf("Targets() error = %v", err)
+	}
+
+	want := []Target{
+		{AccountID: "111111111111", Name: "management", RoleARN: "arn:aws:iam::111111111111:role/CloudCopSecurityScanRole"},
+		{AccountID: "222222222222", Name: "workloads", RoleARN: "arn:aws:iam::222222222222:role/CloudCopSecurityScanRole"},
+		{AccountID: "444444444444", Name: "legacy", RoleARN: "arn:aws:iam::444444444444:role/LegacyAuditRole"},
+	}
+	if !slices.Equal(targets, want) {
+		t.Errorf("Targets() = %+v, want %+v", targets, want)
+	}
+
+	var skippedIDs []string
+	for _, s := range skipped {
+		skippedIDs = append(skippedIDs, s.AccountID)
+	}
+	if !slices.Equal(skippedIDs, []string{"333333333333", "555555555555"}) {
+		t.Errorf("skipped = %+v, want the suspended and closing accounts", skipped)
+	}
+	if !strings.Contains(skipped[0].Reason, "SUSPENDED") {
+		t.Errorf("skipped reason = %q, want it to name the account state", skipped[0].Reason)
+	}
+}
+
+func TestDiscoverer_Targets_ListError(t *testing.T) {
+	notInUse := &smithy.GenericAPIError{Code: "AWSOrganizationsNotInUseException"}
+	d := newTestDiscoverer(&fakeOrganizationsClient{err: notInUse}, &fakeSTSClient{})
+
+	if _, _, err := d.Targets(context.Background()); !errors.Is(err, notInUse) {
+		t.Errorf("Targets() error = %v, want %v", err, notInUse)
+	}
+}
+
+func TestDiscoverer_ScanConfigs(t *testing.T) {
+	client := &fakeOrganizationsClient{pages: [][]types.Account{{
+		account("222222222222", "workloads", types.AccountStateActive),
+		account("333333333333", "retired", types.AccountStateSuspended),
+		account("666666666666", "sandbox", types.AccountStateActive),
+	}}}
+	stsClient := &fakeSTSClient{denied: map[string]bool{
+		"arn:aws:iam::666666666666:role/CloudCopSecurityScanRole": true,
+	}}
+	d := newTestDiscoverer(client, stsClient, WithExternalID("cloudcop-ext"))
+
+	regions, services := []string{"us-east-1"}, []string{"s3", "iam"}
+	configs, skipped, err := d.ScanConfigs(context.Background(), regions, services)
+	if err != nil {
+		t.Fatalf("ScanConfigs() error = %v", err)
+	}
+
+	if len(configs) != 1 || configs[0].AccountID != "222222222222" {
+		t.Fatalf("configs = %+v, want only 222222222222", configs)
+	}
+	if !slices.Equal(configs[0].Regions, regions) || !slices.Equal(configs[0].Services, services) {
+		t.Errorf("config scope = %v %v, want %v %v", configs[0].Regions, configs[0].Services, regions, services)
+	}
+	creds, err := configs[0].Credentials.Retrieve(context.Background())
+	if err != nil || creds.AccessKeyID != "AKIAEXAMPLE" {
+		t.Errorf("Credentials.Retrieve() = %+v, %v", creds, err)
+	}
+	if len(stsClient.assumed) != 2 {
+		t.Errorf("AssumeRole called %d times, want 2 (credentials are cached after the probe)", len(stsClient.assumed))
+	}
+	if got := aws.ToString(stsClient.assumed[0].ExternalId); got != "cloudcop-ext" {
+		t.Errorf("ExternalId = %q, want cloudcop-ext", got)
+	}
+
+	if len(skipped) != 2 {
+		t.Fatalf("skipped = %+v, want the suspended and SCP-denied accounts", skipped)
+	}
+	if skipped[1].AccountID != "666666666666" || !strings.Contains(skipped[1].Reason, "AccessDenied") {
+		t.Errorf("skipped[1] = %+v, want 666666666666 denied by SCP", skipped[1])
+	}
+}
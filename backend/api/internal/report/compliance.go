@@ -0,0 +1,104 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"cloudcop/api/internal/scanner"
+)
+
+// ComplianceOptions controls what a compliance export contains.
+type ComplianceOptions struct {
+	// IncludePassed adds PASS findings to each control's evidence so auditors
+	// can see which resources satisfy it. Pass counts are always reported.
+	IncludePassed bool
+}
+
+// ComplianceExport is a per-control view of a scan for auditors.
+type ComplianceExport struct {
+	AccountID   string              `json:"account_id"`
+	GeneratedAt time.Time           `json:"generated_at"`
+	Controls    []ComplianceControl `json:"controls"`
+}
+
+// ComplianceControl summarizes the findings mapped to one framework control,
+// such as "CIS-2.1.1".
+type ComplianceControl struct {
+	Control  string               `json:"control"`
+	Passed   int                  `json:"passed"`
+	Failed   int                  `json:"failed"`
+	Errored  int                  `json:"errored"`
+	Evidence []ComplianceEvidence `json:"evidence"`
+}
+
+// ComplianceEvidence is a single check outcome supporting a control.
+type ComplianceEvidence struct {
+	Service    string                `json:"service"`
+	Region     string                `json:"region"`
+	ResourceID string                `json:"resource_id"`
+	CheckID    string                `json:"check_id"`
+	Status     scanner.FindingStatus `json:"status"`
+	Title      string                `json:"title"`
+}
+
+// BuildCompliance groups findings by the compliance controls they map to.
+// Controls are sorted by name and evidence keeps the scan's finding order.
+func BuildCompliance(result *scanner.ScanResult, opts ComplianceOptions) *ComplianceExport {
+	controls := make(map[string]*ComplianceControl)
+	for _, f := range result.Findings {
+		for _, control := range f.Compliance {
+			c, ok := controls[control]
+			if !ok {
+				c = &ComplianceControl{Control: control}
+				controls[control] = c
+			}
+
+			switch f.Status {
+			case scanner.StatusPass:
+				c.Passed++
+				if !opts.IncludePassed {
+					continue
+				}
+			case scanner.StatusFail:
+				c.Failed++
+			case scanner.StatusError:
+				c.Errored++
+			}
+			c.Evidence = append(c.Evidence, ComplianceEvidence{
+				Service:    f.Service,
+				Region:     f.Region,
+				ResourceID: f.ResourceID,
+				CheckID:    f.CheckID,
+				Status:     f.Status,
+				Title:      f.Title,
+			})
+		}
+	}
+
+	export := &ComplianceExport{
+		AccountID:   result.AccountID,
+		GeneratedAt: result.CompletedAt,
+		Controls:    make([]ComplianceControl, 0, len(controls)),
+	}
+	for _, c := range controls {
+		export.Controls = append(export.Controls, *c)
+	}
+	sort.Slice(export.Controls, func(i, j int) bool {
+		return export.Controls[i].Control < export.Controls[j].Control
+	})
+	return export
+}
+
+// ToComplianceJSON writes the compliance export for result as indented JSON.
+func ToComplianceJSON(result *scanner.ScanResult, opts ComplianceOptions, w io.Writer) error {
+	if result == nil {
+		return fmt.Errorf("scan result is required")
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(BuildCompliance(result, opts))
+}
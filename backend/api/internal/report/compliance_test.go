@@ -0,0 +1,101 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"cloudcop/api/internal/scanner"
+)
+
+func complianceResult() *scanner.ScanResult {
+	ts := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	return &scanner.ScanResult{
+		AccountID:   "123456789012",
+		CompletedAt: ts,
+		Findings: []scanner.Finding{
+			{
+				Service: "s3", Region: "us-east-1", ResourceID: "logs-bucket", CheckID: "s3_bucket_encryption",
+				Status: scanner.StatusFail, Severity: scanner.SeverityHigh,
+				Title: "S3 bucket encryption is disabled", Compliance: []string{"CIS-2.1.1"},
+			},
+			{
+				Service: "s3", Region: "us-east-1", ResourceID: "data-bucket", CheckID: "s3_bucket_encryption",
+				Status: scanner.StatusPass, Severity: scanner.SeverityHigh,
+				Title: "S3 bucket encryption is enabled", Compliance: []string{"CIS-2.1.1"},
+			},
+			{
+				Service: "s3", Region: "us-east-1", ResourceID: "data-bucket", CheckID: "s3_bucket_versioning",
+				Status: scanner.StatusPass, Severity: scanner.SeverityMedium,
+				Title: "S3 bucket versioning is enabled", Compliance: []string{"CIS-2.1.3"},
+			},
+		},
+	}
+}
+
+func findControl(t *testing.T, export *ComplianceExport, name string) ComplianceControl {
+	t.Helper()
+	for _, c := range export.Controls {
+		if c.Control == name {
+			return c
+		}
+	}
+	t.Fatalf("control %s not in export", name)
+	return ComplianceControl{}
+}
+
+func TestBuildCompliance_PassEvidence(t *testing.T) {
+	result := complianceResult()
+
+	export := BuildCompliance(result, ComplianceOptions{IncludePassed: true})
+
+	control := findControl(t, export, "CIS-2.1.3")
+	if control.Passed != 1 || control.Failed != 0 {
+		t.Errorf("CIS-2.1.3 counts = %d passed/%d failed, want 1/0", control.Passed, control.Failed)
+	}
+	if len(control.Evidence) != 1 || control.Evidence[0].Status != scanner.StatusPass {
+		t.Errorf("CIS-2.1.3 evidence = %+v, want the passing versioning check", control.Evidence)
+	}
+
+	// The dashboard's default findings view hides the same passing check.
+	for _, f := range (scanner.FindingFilter{OnlyFailures: true}).Apply(result.Findings) {
+		if f.Status == scanner.StatusPass {
+			t.Errorf("OnlyFailures returned passing finding %s/%s", f.CheckID, f.ResourceID)
+		}
+	}
+}
+
+func TestBuildCompliance_ExcludePassedKeepsCounts(t *testing.T) {
+	export := BuildCompliance(complianceResult(), ComplianceOptions{})
+
+	control := findControl(t, export, "CIS-2.1.1")
+	if control.Passed != 1 || control.Failed != 1 {
+		t.Errorf("CIS-2.1.1 counts = %d passed/%d failed, want 1/1", control.Passed, control.Failed)
+	}
+	if len(control.Evidence) != 1 || control.Evidence[0].Status != scanner.StatusFail {
+		t.Errorf("CIS-2.1.1 evidence = %+v, want only the failing check", control.Evidence)
+	}
+	if got := findControl(t, export, "CIS-2.1.3"); got.Passed != 1 || len(got.Evidence) != 0 {
+		t.Errorf("CIS-2.1.3 = %+v, want a pass count without evidence", got)
+	}
+}
+
+func TestToComplianceJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ToComplianceJSON(complianceResult(), ComplianceOptions{IncludePassed: true}, &buf); err != nil {
+		t.Fatalf("ToComplianceJSON() error = %v", err)
+	}
+
+	var export ComplianceExport
+	if err := json.Unmarshal(buf.Bytes(), &export); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if export.AccountID != "123456789012" || len(export.Controls) != 2 {
+		t.Errorf("export = %+v, want 2 controls for 123456789012", export)
+	}
+
+	if err := ToComplianceJSON(nil, ComplianceOptions{}, &buf); err == nil {
+		t.Error("ToComplianceJSON(nil) expected error")
+	}
+}
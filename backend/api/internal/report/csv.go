@@ -0,0 +1,44 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"cloudcop/api/internal/scanner"
+)
+
+// csvHeader lists the columns written by ToCSV.
+var csvHeader = []string{"service", "region", "resource_id", "check_id", "status", "severity", "title", "description", "compliance"}
+
+// ToCSV writes one row per finding, in scan order, with compliance controls
+// joined by semicolons.
+func ToCSV(result *scanner.ScanResult, w io.Writer) error {
+	if result == nil {
+		return fmt.Errorf("scan result is required")
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, f := range result.Findings {
+		err := cw.Write([]string{
+			f.Service,
+			f.Region,
+			f.ResourceID,
+			f.CheckID,
+			string(f.Status),
+			string(f.Severity),
+			f.Title,
+			f.Description,
+			strings.Join(f.Compliance, ";"),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
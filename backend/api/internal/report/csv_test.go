@@ -0,0 +1,36 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+)
+
+func TestToCSV(t *testing.T) {
+	result := complianceResult()
+	result.Findings[0].Description = `Bucket "logs-bucket", unencrypted`
+	result.Findings[0].Compliance = []string{"CIS-2.1.1", "SOC2-CC6.1"}
+
+	var buf bytes.Buffer
+	if err := ToCSV(result, &buf); err != nil {
+		t.Fatalf("ToCSV() error = %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(rows) != len(result.Findings)+1 {
+		t.Fatalf("got %d rows, want header plus %d findings", len(rows), len(result.Findings))
+	}
+	first := rows[1]
+	if first[3] != "s3_bucket_encryption" || first[4] != "FAIL" || first[5] != "HIGH" {
+		t.Errorf("first row = %v", first)
+	}
+	if first[7] != `Bucket "logs-bucket", unencrypted` {
+		t.Errorf("description = %q, want it round-tripped", first[7])
+	}
+	if first[8] != "CIS-2.1.1;SOC2-CC6.1" {
+		t.Errorf("compliance = %q, want controls joined by semicolons", first[8])
+	}
+}
@@ -0,0 +1,97 @@
+// Package report renders scan results into shareable documents.
+package report
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"time"
+
+	"cloudcop/api/internal/scanner"
+)
+
+//go:embed report.html.tmpl
+var reportTemplate string
+
+var tmpl = template.Must(template.New("report").Funcs(template.FuncMap{
+	"date": func(t time.Time) string { return t.Format("2006-01-02 15:04 MST") },
+}).Parse(reportTemplate))
+
+// severityOrder lists severities from most to least urgent.
+var severityOrder = []scanner.Severity{
+	scanner.SeverityCritical,
+	scanner.SeverityHigh,
+	scanner.SeverityMedium,
+	scanner.SeverityLow,
+}
+
+type reportData struct {
+	Result   *scanner.ScanResult
+	Summary  *scanner.ScanSummary
+	Counts   scanner.SeverityCounts
+	Services []serviceSection
+}
+
+type serviceSection struct {
+	Name       string
+	Severities []severitySection
+}
+
+type severitySection struct {
+	Severity scanner.Severity
+	Findings []scanner.Finding
+}
+
+// ToHTML renders result as a standalone HTML report. Failed findings are
+// grouped by service and then severity; summary may be nil when AI
+// summarization was skipped. All finding fields are escaped by html/template.
+func ToHTML(result *scanner.ScanResult, summary *scanner.ScanSummary, w io.Writer) error {
+	if result == nil {
+		return fmt.Errorf("scan result is required")
+	}
+
+	data := reportData{
+		Result:  result,
+		Summary: summary,
+		Counts:  scanner.CountFailedBySeverity(result.Findings),
+	}
+
+	grouped := make(map[string]map[scanner.Severity][]scanner.Finding)
+	for _, f := range result.Findings {
+		if f.Status != scanner.StatusFail {
+			continue
+		}
+		if grouped[f.Service] == nil {
+			grouped[f.Service] = make(map[scanner.Severity][]scanner.Finding)
+		}
+		grouped[f.Service][f.Severity] = append(grouped[f.Service][f.Severity], f)
+	}
+
+	services := make([]string, 0, len(grouped))
+	for service := range grouped {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	for _, service := range services {
+		section := serviceSection{Name: service}
+		for _, sev := range severityOrder {
+			findings := grouped[service][sev]
+			if len(findings) == 0 {
+				continue
+			}
+			sort.SliceStable(findings, func(i, j int) bool {
+				return findings[i].ResourceID < findings[j].ResourceID
+			})
+			section.Severities = append(section.Severities, severitySection{Severity: sev, Findings: findings})
+		}
+		data.Services = append(data.Services, section)
+	}
+
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("rendering report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package report
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"cloudcop/api/internal/scanner"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func testResult() *scanner.ScanResult {
+	ts := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	return &scanner.ScanResult{
+		AccountID:    "123456789012",
+		Regions:      []string{"us-east-1"},
+		Services:     []string{"s3", "ec2"},
+		StartedAt:    ts,
+		CompletedAt:  ts.Add(90 * time.Second),
+		TotalChecks:  4,
+		PassedChecks: 1,
+		FailedChecks: 3,
+		Findings: []scanner.Finding{
+			{
+				Service: "s3", Region: "us-east-1", ResourceID: "logs-bucket", CheckID: "s3_bucket_encryption",
+				Status: scanner.StatusFail, Severity: scanner.SeverityHigh,
+				Title: "S3 bucket encryption is disabled", Description: "Bucket logs-bucket has no default encryption",
+				Compliance: []string{"CIS-2.1.1", "PCI-DSS-3.4"}, Timestamp: ts,
+			},
+			{
+				Service: "s3", Region: "us-east-1", ResourceID: "logs-bucket", CheckID: "s3_versioning",
+				Status: scanner.StatusPass, Severity: scanner.SeverityMedium,
+				Title: "S3 bucket versioning is enabled", Timestamp: ts,
+			},
+			{
+				Service: "ec2", Region: "us-east-1", ResourceID: "sg-0abc", CheckID: "ec2_unused_sg",
+				Status: scanner.StatusFail, Severity: scanner.SeverityLow,
+				Title: "Security group is unused", Description: "Security group sg-0abc is not attached to any resource",
+				Compliance: []string{"SOC2-CC6.1"}, Timestamp: ts,
+			},
+			{
+				Service: "ec2", Region: "us-east-1", ResourceID: "i-0123", CheckID: "ec2_public_ip",
+				Status: scanner.StatusFail, Severity: scanner.SeverityCritical,
+				Title: "EC2 instance has public IP address", Description: "Instance i-0123 has public IP 203.0.113.10",
+				Timestamp: ts,
+			},
+		},
+	}
+}
+
+func TestToHTML_Golden(t *testing.T) {
+	summary := &scanner.ScanSummary{
+		RiskLevel:   "HIGH",
+		RiskScore:   72,
+		SummaryText: "Public instances & unencrypted buckets need attention.",
+	}
+
+	var buf bytes.Buffer
+	if err := ToHTML(testResult(), summary, &buf); err != nil {
+		t.Fatalf("ToHTML() error = %v", err)
+	}
+
+	golden := filepath.Join("testdata", "report.golden.html")
+	if *update {
+		if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+			t.Fatalf("writing golden file: %v", err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("reading golden file: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("rendered report does not match %s; run with -update to regenerate\n got:\n%s", golden, buf.String())
+	}
+}
+
+func TestToHTML_CountsCappedFindings(t *testing.T) {
+	result := testResult()
+	result.Findings = append(result.Findings, scanner.Finding{
+		Service: "ec2", Region: "us-east-1", ResourceID: "*", CheckID: "ec2_unused_sg",
+		Status: scanner.StatusFail, Severity: scanner.SeverityHigh,
+		Title:      "Security group is unused (and 5 more resources affected)",
+		Aggregated: &scanner.SeverityCounts{High: 2, Low: 3},
+	})
+
+	var buf bytes.Buffer
+	if err := ToHTML(result, nil, &buf); err != nil {
+		t.Fatalf("ToHTML() error = %v", err)
+	}
+	// Critical, high, medium, and low failures, with the aggregate's five.
+	if want := "<td>1</td><td>3</td><td>0</td><td>4</td></tr>"; !strings.Contains(buf.String(), want) {
+		t.Errorf("report totals do not include the capped failures, want %q in:\n%s", want, buf.String())
+	}
+}
+
+func TestToHTML_EscapesUserInput(t *testing.T) {
+	result := testResult()
+	result.Findings[0].ResourceID = `<script>alert("x")</script>`
+	result.Findings[0].Description = `<img src=x onerror=alert(1)>`
+
+	var buf bytes.Buffer
+	if err := ToHTML(result, nil, &buf); err != nil {
+		t.Fatalf("ToHTML() error = %v", err)
+	}
+	out := buf.String()
+
+	if strings.Contains(out, "<script>") || strings.Contains(out, "<img") {
+		t.Errorf("report contains unescaped HTML:\n%s", out)
+	}
+	if !strings.Contains(out, "&lt;script&gt;") {
+		t.Error("expected escaped resource ID in report")
+	}
+}
+
+func TestToHTML_NilResult(t *testing.T) {
+	if err := ToHTML(nil, nil, &bytes.Buffer{}); err == nil {
+		t.Error("expected error for nil result")
+	}
+}
@@ -0,0 +1,120 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"cloudcop/api/internal/scanner"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID     string          `json:"ruleId"`
+	Level      string          `json:"level"`
+	Message    sarifMessage    `json:"message"`
+	Locations  []sarifLocation `json:"locations"`
+	Properties sarifProperties `json:"properties"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+type sarifProperties struct {
+	Severity   scanner.Severity `json:"severity"`
+	Compliance []string         `json:"compliance,omitempty"`
+}
+
+// ToSARIF writes the failed findings of result as a SARIF 2.1.0 log, so scans
+// can be uploaded to code-scanning dashboards. Each check becomes a rule and
+// each resource a logical location; passed and errored checks are omitted.
+func ToSARIF(result *scanner.ScanResult, w io.Writer) error {
+	if result == nil {
+		return fmt.Errorf("scan result is required")
+	}
+
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: "CloudCop", Rules: []sarifRule{}}},
+		Results: []sarifResult{},
+	}
+	rules := make(map[string]bool)
+	for _, f := range result.Findings {
+		if f.Status != scanner.StatusFail {
+			continue
+		}
+		if !rules[f.CheckID] {
+			rules[f.CheckID] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+				ID:               f.CheckID,
+				ShortDescription: sarifMessage{Text: f.Title},
+			})
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:  f.CheckID,
+			Level:   sarifLevel(f.Severity),
+			Message: sarifMessage{Text: f.Description},
+			Locations: []sarifLocation{{LogicalLocations: []sarifLogicalLocation{{
+				Name:               f.ResourceID,
+				FullyQualifiedName: fmt.Sprintf("%s/%s/%s/%s", result.AccountID, f.Region, f.Service, f.ResourceID),
+				Kind:               "resource",
+			}}}},
+			Properties: sarifProperties{Severity: f.Severity, Compliance: f.Compliance},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}})
+}
+
+// sarifLevel maps finding severity to a SARIF result level.
+func sarifLevel(severity scanner.Severity) string {
+	switch severity {
+	case scanner.SeverityCritical, scanner.SeverityHigh:
+		return "error"
+	case scanner.SeverityMedium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
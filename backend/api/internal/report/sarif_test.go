@@ -0,0 +1,52 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"cloudcop/api/internal/scanner"
+)
+
+func TestToSARIF(t *testing.T) {
+	result := complianceResult()
+	result.Findings = append(result.Findings, scanner.Finding{
+		Service: "s3", Region: "us-east-1", ResourceID: "tmp-bucket", CheckID: "s3_bucket_encryption",
+		Status: scanner.StatusFail, Severity: scanner.SeverityHigh, Title: "S3 bucket encryption is disabled",
+	})
+
+	var buf bytes.Buffer
+	if err := ToSARIF(result, &buf); err != nil {
+		t.Fatalf("ToSARIF() error = %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 1 || run.Tool.Driver.Rules[0].ID != "s3_bucket_encryption" {
+		t.Errorf("rules = %+v, want one rule per failed check", run.Tool.Driver.Rules)
+	}
+	if len(run.Results) != 2 {
+		t.Fatalf("got %d results, want only the 2 failed findings", len(run.Results))
+	}
+	loc := run.Results[0].Locations[0].LogicalLocations[0]
+	if run.Results[0].Level != "error" || loc.Name != "logs-bucket" || loc.FullyQualifiedName != "123456789012/us-east-1/s3/logs-bucket" {
+		t.Errorf("first result = %+v", run.Results[0])
+	}
+}
+
+func TestSarifLevel(t *testing.T) {
+	tests := map[scanner.Severity]string{
+		scanner.SeverityCritical: "error",
+		scanner.SeverityHigh:     "error",
+		scanner.SeverityMedium:   "warning",
+		scanner.SeverityLow:      "note",
+	}
+	for severity, want := range tests {
+		if got := sarifLevel(severity); got != want {
+			t.Errorf("sarifLevel(%s) = %s, want %s", severity, got, want)
+		}
+	}
+}
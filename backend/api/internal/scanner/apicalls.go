@@ -0,0 +1,68 @@
+package scanner
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// apiCallCounter counts the AWS API requests made by the clients of a scan,
+// keyed by the SDK service ID such as "S3" or "EC2".
+type apiCallCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func newAPICallCounter() *apiCallCounter {
+	return &apiCallCounter{counts: make(map[string]int)}
+}
+
+// instrument returns cfg with the counting middleware added to every client
+// built from it.
+func (c *apiCallCounter) instrument(cfg aws.Config) aws.Config {
+	cfg.APIOptions = append(slices.Clone(cfg.APIOptions), c.addMiddleware)
+	return cfg
+}
+
+// addMiddleware counts requests at the end of the finalize step, after the
+// retryer, so each attempt of a retried request is counted.
+func (c *apiCallCounter) addMiddleware(stack *middleware.Stack) error {
+	return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("CloudCopAPICallCounter",
+		func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			c.record(awsmiddleware.GetServiceID(ctx))
+			return next.HandleFinalize(ctx, in)
+		}), middleware.After)
+}
+
+func (c *apiCallCounter) record(service string) {
+	c.mu.Lock()
+	c.counts[service]++
+	c.mu.Unlock()
+}
+
+// snapshot returns a copy of the counts so far.
+func (c *apiCallCounter) snapshot() map[string]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return maps.Clone(c.counts)
+}
+
+type apiCallCounterKey struct{}
+
+// withAPICallCounter returns a copy of ctx whose scan tasks count their API
+// calls with counter.
+func withAPICallCounter(ctx context.Context, counter *apiCallCounter) context.Context {
+	return context.WithValue(ctx, apiCallCounterKey{}, counter)
+}
+
+// apiCallCounterFromContext returns the counter attached to ctx, or nil when
+// the scan is not tracking API calls.
+func apiCallCounterFromContext(ctx context.Context) *apiCallCounter {
+	counter, _ := ctx.Value(apiCallCounterKey{}).(*apiCallCounter)
+	return counter
+}
@@ -0,0 +1,109 @@
+package scanner
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// invokeAPI runs one request to serviceID through a middleware stack built
+// from cfg's API options, ending in a fake handler instead of a transport.
+func invokeAPI(t *testing.T, cfg aws.Config, serviceID string) {
+	t.Helper()
+	stack := middleware.NewStack("FakeOperation", smithyhttp.NewStackRequest)
+	for _, fn := range cfg.APIOptions {
+		if err := fn(stack); err != nil {
+			t.Fatalf("applying API option: %v", err)
+		}
+	}
+	handler := middleware.DecorateHandler(middleware.HandlerFunc(
+		func(context.Context, interface{}) (interface{}, middleware.Metadata, error) {
+			return struct{}{}, middleware.Metadata{}, nil
+		}), stack)
+
+	ctx := awsmiddleware.SetServiceID(context.Background(), serviceID)
+	if _, _, err := handler.Handle(ctx, struct{}{}); err != nil {
+		t.Fatalf("handling request: %v", err)
+	}
+}
+
+func TestAPICallCounter_CountsPerService(t *testing.T) {
+	counter := newAPICallCounter()
+	cfg := counter.instrument(aws.Config{})
+
+	for range 3 {
+		invokeAPI(t, cfg, "S3")
+	}
+	invokeAPI(t, cfg, "EC2")
+
+	want := map[string]int{"S3": 3, "EC2": 1}
+	if got := counter.snapshot(); !maps.Equal(got, want) {
+		t.Errorf("counts = %v, want %v", got, want)
+	}
+}
+
+func TestAPICallCounter_InstrumentLeavesConfigUnchanged(t *testing.T) {
+	base := aws.Config{APIOptions: make([]func(*middleware.Stack) error, 0, 4)}
+	first := newAPICallCounter().instrument(base)
+	second := newAPICallCounter()
+	_ = second.instrument(base)
+
+	invokeAPI(t, first, "S3")
+	if len(base.APIOptions) != 0 {
+		t.Errorf("base config gained %d API options", len(base.APIOptions))
+	}
+	if got := second.snapshot(); len(got) != 0 {
+		t.Errorf("second counter recorded %v from the first config's client", got)
+	}
+}
+
+// callingScanner makes a fixed number of calls through the config its
+// factory was given.
+type callingScanner struct {
+	t       *testing.T
+	service string
+	cfg     aws.Config
+	calls   int
+}
+
+func (s *callingScanner) Service() string { return s.service }
+
+func (s *callingScanner) Scan(context.Context, string) ([]Finding, error) {
+	for range s.calls {
+		invokeAPI(s.t, s.cfg, s.service)
+	}
+	return []Finding{{CheckID: "check", Status: StatusPass}}, nil
+}
+
+func TestCoordinator_StartScan_TrackAPICalls(t *testing.T) {
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+	for service, calls := range map[string]int{"S3": 4, "IAM": 2} {
+		coord.RegisterScanner(service, func(cfg aws.Config, _, _ string) ServiceScanner {
+			return &callingScanner{t: t, service: service, cfg: cfg, calls: calls}
+		})
+	}
+	config := ScanConfig{AccountID: "123456789012", Regions: []string{"us-east-1", "eu-west-1"}, Services: []string{"S3", "IAM"}}
+
+	result, err := coord.StartScan(context.Background(), config)
+	if err != nil {
+		t.Fatalf("StartScan: %v", err)
+	}
+	if result.APICallCounts != nil {
+		t.Errorf("APICallCounts = %v without TrackAPICalls, want nil", result.APICallCounts)
+	}
+
+	config.TrackAPICalls = true
+	result, err = coord.StartScan(context.Background(), config)
+	if err != nil {
+		t.Fatalf("StartScan: %v", err)
+	}
+	want := map[string]int{"S3": 8, "IAM": 4}
+	if !maps.Equal(result.APICallCounts, want) {
+		t.Errorf("APICallCounts = %v, want %v", result.APICallCounts, want)
+	}
+}
@@ -0,0 +1,11 @@
+package scanner
+
+// WithARN sets the ARN of each finding to arn and returns findings. It is for
+// resources whose ARN cannot be built from their ResourceID, such as load
+// balancers, whose ARNs end in an ID that AWS assigns.
+func WithARN(findings []Finding, arn string) []Finding {
+	for i := range findings {
+		findings[i].ARN = arn
+	}
+	return findings
+}
@@ -0,0 +1,166 @@
+package scanner
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCacheTTL is how long cached resource lists are reused when
+	// CacheOptions.TTL is unset.
+	DefaultCacheTTL = 5 * time.Minute
+	// DefaultCacheSize bounds how many resource lists a cache holds when
+	// CacheOptions.MaxEntries is unset.
+	DefaultCacheSize = 1000
+)
+
+// CacheOptions configures the resource cache shared by scans.
+type CacheOptions struct {
+	// Enabled turns on resource caching. Disabled caches always call AWS.
+	Enabled bool
+	// TTL is how long a cached resource list is reused. Defaults to DefaultCacheTTL.
+	TTL time.Duration
+	// MaxEntries bounds how many resource lists are cached, evicting the
+	// least recently used. Defaults to DefaultCacheSize.
+	MaxEntries int
+}
+
+// CacheKey identifies a cached resource list.
+type CacheKey struct {
+	AccountID string
+	Service   string
+	Region    string
+	// Resource names the cached listing, e.g. "instances" or "buckets".
+	Resource string
+}
+
+type cacheEntry struct {
+	key       CacheKey
+	value     any
+	expiresAt time.Time
+}
+
+// ResourceCache is an in-memory, concurrency-safe LRU cache of resource
+// listings with a TTL, so repeated scans of an account within the TTL skip
+// identical list calls. A nil *ResourceCache is valid and caches nothing.
+type ResourceCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	now     func() time.Time
+	order   *list.List
+	entries map[CacheKey]*list.Element
+}
+
+// NewResourceCache creates a resource cache, or returns nil when caching is disabled.
+func NewResourceCache(opts CacheOptions) *ResourceCache {
+	if !opts.Enabled {
+		return nil
+	}
+	ttl := opts.TTL
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	size := opts.MaxEntries
+	if size <= 0 {
+		size = DefaultCacheSize
+	}
+	return &ResourceCache{
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		order:   list.New(),
+		entries: make(map[CacheKey]*list.Element),
+	}
+}
+
+func (c *ResourceCache) get(key CacheKey) (any, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if c.now().After(entry.expiresAt) {
+		c.remove(elem)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+func (c *ResourceCache) set(key CacheKey, value any) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(c.ttl)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		entry.value, entry.expiresAt = value, expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, value: value, expiresAt: expiresAt})
+	if c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+// Len returns the number of cached resource lists, including expired ones
+// not yet evicted.
+func (c *ResourceCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *ResourceCache) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*cacheEntry).key)
+}
+
+type cacheCtxKey struct{}
+
+// WithResourceCache returns a copy of ctx carrying the given resource cache.
+func WithResourceCache(ctx context.Context, cache *ResourceCache) context.Context {
+	return context.WithValue(ctx, cacheCtxKey{}, cache)
+}
+
+// ResourceCacheFromContext returns the resource cache attached to ctx, or nil if none is set.
+func ResourceCacheFromContext(ctx context.Context) *ResourceCache {
+	cache, _ := ctx.Value(cacheCtxKey{}).(*ResourceCache)
+	return cache
+}
+
+// CachedList returns the cached value for key from the context's resource
+// cache, calling fetch and caching its result on a miss. Errors are not
+// cached. Concurrent misses for the same key may each call fetch.
+func CachedList[T any](ctx context.Context, key CacheKey, fetch func(context.Context) (T, error)) (T, error) {
+	cache := ResourceCacheFromContext(ctx)
+	if v, ok := cache.get(key); ok {
+		if typed, ok := v.(T); ok {
+			return typed, nil
+		}
+	}
+
+	value, err := fetch(ctx)
+	if err != nil {
+		return value, err
+	}
+	cache.set(key, value)
+	return value, nil
+}
@@ -0,0 +1,105 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCachedList_TTL(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewResourceCache(CacheOptions{Enabled: true, TTL: time.Minute})
+	cache.now = func() time.Time { return now }
+	ctx := WithResourceCache(context.Background(), cache)
+	key := CacheKey{AccountID: "123456789012", Service: "ec2", Region: "us-east-1", Resource: "instances"}
+
+	calls := 0
+	fetch := func(context.Context) ([]string, error) {
+		calls++
+		return []string{"i-123"}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		got, err := CachedList(ctx, key, fetch)
+		if err != nil || len(got) != 1 {
+			t.Fatalf("CachedList() = %v, %v", got, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("fetch called %d times within TTL, want 1", calls)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := CachedList(ctx, key, fetch); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("fetch called %d times after TTL expiry, want 2", calls)
+	}
+}
+
+func TestCachedList_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewResourceCache(CacheOptions{Enabled: true, MaxEntries: 2})
+	ctx := WithResourceCache(context.Background(), cache)
+	keys := []CacheKey{
+		{AccountID: "111111111111", Service: "s3", Resource: "buckets"},
+		{AccountID: "222222222222", Service: "s3", Resource: "buckets"},
+		{AccountID: "333333333333", Service: "s3", Resource: "buckets"},
+	}
+
+	calls := 0
+	fetch := func(context.Context) ([]string, error) {
+		calls++
+		return []string{"bucket"}, nil
+	}
+	for _, key := range []CacheKey{keys[0], keys[1], keys[0], keys[2]} {
+		if _, err := CachedList(ctx, key, fetch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d, want the cache bounded to 2", cache.Len())
+	}
+
+	calls = 0
+	if _, err := CachedList(ctx, keys[0], fetch); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 0 {
+		t.Error("recently used listing was evicted")
+	}
+	if _, err := CachedList(ctx, keys[1], fetch); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Error("least recently used listing was not evicted")
+	}
+}
+
+func TestCachedList_ErrorsNotCached(t *testing.T) {
+	ctx := WithResourceCache(context.Background(), NewResourceCache(CacheOptions{Enabled: true}))
+	key := CacheKey{Service: "s3", Resource: "buckets"}
+
+	calls := 0
+	fetch := func(context.Context) ([]string, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("throttled")
+		}
+		return []string{"bucket"}, nil
+	}
+
+	if _, err := CachedList(ctx, key, fetch); err == nil {
+		t.Fatal("expected error from first fetch")
+	}
+	if got, err := CachedList(ctx, key, fetch); err != nil || len(got) != 1 {
+		t.Errorf("CachedList() after error = %v, %v", got, err)
+	}
+}
+
+func TestNewResourceCache_Disabled(t *testing.T) {
+	if cache := NewResourceCache(CacheOptions{}); cache != nil {
+		t.Errorf("NewResourceCache(disabled) = %v, want nil", cache)
+	}
+}
@@ -0,0 +1,100 @@
+package scanner
+
+import (
+	"fmt"
+	"strings"
+)
+
+// aggregateResourceID is the resource ID of the finding that stands in for
+// the failures a check reported beyond ScanConfig.MaxFindingsPerCheck.
+const aggregateResourceID = "*"
+
+// findingCapper limits how many failed findings each check reports. Failures
+// beyond the cap are held back and later summarized by one aggregate finding
+// per check, so a single sweeping misconfiguration cannot bury the rest of
+// a scan under thousands of near-identical findings.
+type findingCapper struct {
+	max       int
+	accountID string
+	kept      map[string]int
+	excess    map[string][]Finding
+	order     []string // check IDs in the order they first exceeded the cap
+}
+
+func newFindingCapper(max int, accountID string) *findingCapper {
+	return &findingCapper{
+		max:       max,
+		accountID: accountID,
+		kept:      make(map[string]int),
+		excess:    make(map[string][]Finding),
+	}
+}
+
+// admit reports whether f should be returned individually. Findings that
+// are not returned are folded into the check's aggregate finding.
+func (c *findingCapper) admit(f Finding) bool {
+	if c.max <= 0 || f.Status != StatusFail {
+		return true
+	}
+	if c.kept[f.CheckID] < c.max {
+		c.kept[f.CheckID]++
+		return true
+	}
+	if len(c.excess[f.CheckID]) == 0 {
+		c.order = append(c.order, f.CheckID)
+	}
+	c.excess[f.CheckID] = append(c.excess[f.CheckID], f)
+	return false
+}
+
+// apply returns the findings admitted by the cap followed by the aggregate
+// findings, preserving the order of the admitted ones.
+func (c *findingCapper) apply(findings []Finding) []Finding {
+	capped := make([]Finding, 0, len(findings))
+	for _, f := range findings {
+		if c.admit(f) {
+			capped = append(capped, f)
+		}
+	}
+	return append(capped, c.aggregates()...)
+}
+
+// aggregates returns one finding per check that exceeded the cap, listing
+// the resources whose findings were held back and counting them by
+// severity.
+func (c *findingCapper) aggregates() []Finding {
+	aggregates := make([]Finding, 0, len(c.order))
+	for _, checkID := range c.order {
+		excess := c.excess[checkID]
+		first := excess[0]
+
+		region, severity := first.Region, first.Severity
+		var counts SeverityCounts
+		resources := make([]string, len(excess))
+		for i, f := range excess {
+			resources[i] = f.ResourceID
+			counts.Add(f.Severity, 1)
+			if f.Region != region {
+				region = "multiple"
+			}
+			if severityWeights[f.Severity] > severityWeights[severity] {
+				severity = f.Severity
+			}
+		}
+
+		aggregate := first
+		aggregate.FindingID = FindingID(c.accountID, first.Service, region, checkID, aggregateResourceID)
+		aggregate.Region = region
+		aggregate.Severity = severity
+		aggregate.ResourceID = aggregateResourceID
+		aggregate.ARN = ""
+		aggregate.ResourceCreatedAt = nil
+		aggregate.Title = fmt.Sprintf("%s (and %d more resources affected)", first.Title, len(excess))
+		aggregate.Description = fmt.Sprintf("%d more resources failed this check beyond the limit of %d findings per check: %s",
+			len(excess), c.max, strings.Join(resources, ", "))
+		aggregate.Managed = false
+		aggregate.Aggregated = &counts
+		aggregates = append(aggregates, aggregate)
+	}
+	return aggregates
+}
@@ -0,0 +1,104 @@
+package scanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"cloudcop/api/internal/scanner/compliance"
+)
+
+// catalogVersion is bumped when the snapshot layout itself changes.
+const catalogVersion = 1
+
+// CatalogEntry describes a single check as seen by downstream integrations.
+type CatalogEntry struct {
+	CheckID    string     `json:"check_id"`
+	Confidence Confidence `json:"confidence"`
+	Compliance []string   `json:"compliance"`
+}
+
+// Catalog is the serialized set of checks the scanners publish.
+type Catalog struct {
+	Version int            `json:"version"`
+	Checks  []CatalogEntry `json:"checks"`
+}
+
+// CatalogSnapshot returns the current check catalog as indented JSON with
+// checks sorted by ID, so identical catalogs always serialize identically.
+func CatalogSnapshot() ([]byte, error) {
+	catalog := Catalog{Version: catalogVersion}
+	for _, id := range compliance.CheckIDs() {
+		catalog.Checks = append(catalog.Checks, CatalogEntry{
+			CheckID:    id,
+			Confidence: ConfidenceFor(id),
+			Compliance: compliance.GetCompliance(id),
+		})
+	}
+
+	data, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// CatalogDiff lists the differences between two catalog snapshots.
+type CatalogDiff struct {
+	// Removed lists check IDs present in the old catalog but not the new one.
+	// A renamed check shows up here and in Added.
+	Removed []string
+	// Added lists check IDs that are new in the new catalog.
+	Added []string
+	// Changed lists check IDs whose confidence or compliance mapping changed.
+	Changed []string
+}
+
+// Breaking reports whether integrations keyed on check IDs would break.
+func (d CatalogDiff) Breaking() bool {
+	return len(d.Removed) > 0
+}
+
+// Empty reports whether the two catalogs are identical.
+func (d CatalogDiff) Empty() bool {
+	return len(d.Removed) == 0 && len(d.Added) == 0 && len(d.Changed) == 0
+}
+
+// DiffCatalog compares two serialized catalogs produced by CatalogSnapshot.
+func DiffCatalog(oldData, newData []byte) (CatalogDiff, error) {
+	var oldCatalog, newCatalog Catalog
+	if err := json.Unmarshal(oldData, &oldCatalog); err != nil {
+		return CatalogDiff{}, fmt.Errorf("parsing old catalog: %w", err)
+	}
+	if err := json.Unmarshal(newData, &newCatalog); err != nil {
+		return CatalogDiff{}, fmt.Errorf("parsing new catalog: %w", err)
+	}
+
+	oldChecks := catalogIndex(oldCatalog)
+	newChecks := catalogIndex(newCatalog)
+
+	var diff CatalogDiff
+	for _, entry := range oldCatalog.Checks {
+		current, ok := newChecks[entry.CheckID]
+		switch {
+		case !ok:
+			diff.Removed = append(diff.Removed, entry.CheckID)
+		case current.Confidence != entry.Confidence || !slices.Equal(current.Compliance, entry.Compliance):
+			diff.Changed = append(diff.Changed, entry.CheckID)
+		}
+	}
+	for _, entry := range newCatalog.Checks {
+		if _, ok := oldChecks[entry.CheckID]; !ok {
+			diff.Added = append(diff.Added, entry.CheckID)
+		}
+	}
+	return diff, nil
+}
+
+func catalogIndex(c Catalog) map[string]CatalogEntry {
+	index := make(map[string]CatalogEntry, len(c.Checks))
+	for _, entry := range c.Checks {
+		index[entry.CheckID] = entry
+	}
+	return index
+}
@@ -0,0 +1,93 @@
+package scanner
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"testing"
+)
+
+const goldenCatalog = "testdata/catalog.json"
+
+func TestCatalogSnapshot_MatchesGolden(t *testing.T) {
+	golden, err := os.ReadFile(goldenCatalog)
+	if err != nil {
+		t.Fatalf("reading golden catalog: %v", err)
+	}
+	current, err := CatalogSnapshot()
+	if err != nil {
+		t.Fatalf("CatalogSnapshot() error = %v", err)
+	}
+
+	diff, err := DiffCatalog(golden, current)
+	if err != nil {
+		t.Fatalf("DiffCatalog() error = %v", err)
+	}
+	if diff.Breaking() {
+		t.Fatalf("check IDs removed or renamed: %v; if intended, run `go run ./cmd/catalog-diff -update`", diff.Removed)
+	}
+	if !diff.Empty() {
+		t.Errorf("golden catalog is stale (added %v, changed %v); run `go run ./cmd/catalog-diff -update`", diff.Added, diff.Changed)
+	}
+}
+
+func TestCatalogSnapshot_Stable(t *testing.T) {
+	first, err := CatalogSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := CatalogSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(first) != string(second) {
+		t.Error("CatalogSnapshot() is not deterministic")
+	}
+}
+
+func TestDiffCatalog_RemovedCheck(t *testing.T) {
+	current, err := CatalogSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var catalog Catalog
+	if err := json.Unmarshal(current, &catalog); err != nil {
+		t.Fatal(err)
+	}
+	catalog.Checks = slices.DeleteFunc(catalog.Checks, func(e CatalogEntry) bool {
+		return e.CheckID == "iam_inline_policies"
+	})
+	catalog.Checks = append(catalog.Checks, CatalogEntry{CheckID: "iam_user_inline_policies", Confidence: ConfidenceHigh})
+	renamed, err := json.Marshal(catalog)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := DiffCatalog(current, renamed)
+	if err != nil {
+		t.Fatalf("DiffCatalog() error = %v", err)
+	}
+	if !diff.Breaking() {
+		t.Error("Breaking() = false, want true for a renamed check")
+	}
+	if !slices.Equal(diff.Removed, []string{"iam_inline_policies"}) {
+		t.Errorf("Removed = %v, want [iam_inline_policies]", diff.Removed)
+	}
+	if !slices.Equal(diff.Added, []string{"iam_user_inline_policies"}) {
+		t.Errorf("Added = %v, want [iam_user_inline_policies]", diff.Added)
+	}
+}
+
+func TestDiffCatalog_ChangedMappingIsNotBreaking(t *testing.T) {
+	oldData := []byte(`{"version":1,"checks":[{"check_id":"s3_bucket_logging","confidence":"HIGH","compliance":["CIS-2.1.2"]}]}`)
+	newData := []byte(`{"version":1,"checks":[{"check_id":"s3_bucket_logging","confidence":"HIGH","compliance":["CIS-2.1.2","NIST-AU-2"]}]}`)
+
+	diff, err := DiffCatalog(oldData, newData)
+	if err != nil {
+		t.Fatalf("DiffCatalog() error = %v", err)
+	}
+	if diff.Breaking() || !slices.Equal(diff.Changed, []string{"s3_bucket_logging"}) {
+		t.Errorf("got %+v, want only s3_bucket_logging changed", diff)
+	}
+}
@@ -0,0 +1,735 @@
+package checks
+
+import "cloudcop/api/internal/scanner"
+
+// builtin lists the checks run by CloudCop's scanners. Compliance
+// requirements come from the compliance mappings.
+var builtin = []CheckMetadata{
+	// S3 Checks
+	{
+		ID:              "s3_bucket_public_access",
+		Service:         "s3",
+		Title:           "S3 bucket ACL public access",
+		DefaultSeverity: scanner.SeverityCritical,
+		Description:     "Checks whether the bucket ACL grants access to all users (critical) or to any authenticated AWS account (high), and which permissions it grants.",
+		RemediationHint: "Remove AllUsers and AuthenticatedUsers grants from the bucket ACL and disable ACLs with bucket owner enforced object ownership.",
+	},
+	{
+		ID:              "s3_bucket_policy_public",
+		Service:         "s3",
+		Title:           "S3 bucket policy public access",
+		DefaultSeverity: scanner.SeverityCritical,
+		Description:     "Checks whether the bucket policy allows any principal to access the bucket without a restricting condition.",
+		RemediationHint: "Restrict the policy's Principal to specific accounts or roles, or add conditions such as aws:SourceVpce.",
+	},
+	{
+		ID:              "s3_bucket_policy_overbroad",
+		Service:         "s3",
+		Title:           "S3 bucket policy over-broad principal",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether an Allow statement grants the \"*\" principal access gated only by conditions that do not identify the caller, such as aws:SecureTransport or aws:Referer, or by wildcard aws:PrincipalOrgID, aws:SourceIp, or account values.",
+		RemediationHint: "Name specific principals, or add an aws:SourceVpce, aws:SourceIp, or exact aws:PrincipalOrgID condition to statements that must use \"*\".",
+	},
+	{
+		ID:              "s3_bucket_encryption",
+		Service:         "s3",
+		Title:           "S3 bucket default encryption",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether the bucket encrypts new objects by default.",
+		RemediationHint: "Enable default encryption with SSE-S3 or SSE-KMS.",
+	},
+	{
+		ID:              "s3_bucket_key",
+		Service:         "s3",
+		Title:           "S3 Bucket Key for SSE-KMS",
+		DefaultSeverity: scanner.SeverityLow,
+		Description:     "Checks whether buckets encrypted by default with SSE-KMS use an S3 Bucket Key to reduce KMS requests.",
+		RemediationHint: "Enable the bucket key on the bucket's default encryption rule (BucketKeyEnabled).",
+	},
+	{
+		ID:              "s3_bucket_versioning",
+		Service:         "s3",
+		Title:           "S3 bucket versioning",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether versioning is enabled so overwritten or deleted objects can be recovered.",
+		RemediationHint: "Enable versioning on the bucket.",
+	},
+	{
+		ID:              "s3_bucket_logging",
+		Service:         "s3",
+		Title:           "S3 server access logging",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether server access logging records requests made to the bucket.",
+		RemediationHint: "Enable server access logging to a dedicated log bucket.",
+	},
+	{
+		ID:              "s3_block_public_access",
+		Service:         "s3",
+		Title:           "S3 Block Public Access",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether all four Block Public Access settings are enabled on the bucket.",
+		RemediationHint: "Enable every Block Public Access setting on the bucket or account.",
+	},
+	{
+		ID:              "s3_mfa_delete",
+		Service:         "s3",
+		Title:           "S3 MFA Delete",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether deleting object versions or suspending versioning requires MFA.",
+		RemediationHint: "Enable MFA Delete with the root account's MFA device.",
+	},
+	{
+		ID:              "s3_lifecycle_policy",
+		Service:         "s3",
+		Title:           "S3 lifecycle policy",
+		DefaultSeverity: scanner.SeverityLow,
+		Description:     "Checks whether lifecycle rules expire or transition objects so data is not kept indefinitely.",
+		RemediationHint: "Add lifecycle rules that expire or archive objects according to your retention policy.",
+	},
+	{
+		ID:              "s3_ssl_only",
+		Service:         "s3",
+		Title:           "S3 HTTPS-only access",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether the bucket policy denies requests that are not sent over TLS.",
+		RemediationHint: "Add a bucket policy statement denying requests where aws:SecureTransport is false.",
+	},
+	{
+		ID:              "s3_object_lock",
+		Service:         "s3",
+		Title:           "S3 Object Lock",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether Object Lock protects objects from being deleted or overwritten.",
+		RemediationHint: "Enable Object Lock with a default retention period for buckets holding records or backups.",
+	},
+	{
+		ID:              "s3_static_website",
+		Service:         "s3",
+		Title:           "S3 static website hosting",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether the bucket serves its contents as a public static website.",
+		RemediationHint: "Disable website hosting, or serve the content through CloudFront with origin access control.",
+	},
+	{
+		ID:              "s3_replication",
+		Service:         "s3",
+		Title:           "S3 replication",
+		DefaultSeverity: scanner.SeverityLow,
+		Description:     "Checks whether an enabled replication rule copies objects to another bucket.",
+		RemediationHint: "Configure replication to a bucket in another region or account.",
+	},
+	{
+		ID:              "s3_data_residency",
+		Service:         "s3",
+		Title:           "S3 data residency",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether buckets covered by a data residency policy are located in one of its allowed regions.",
+		RemediationHint: "Copy the data to a bucket in an allowed region and delete the original bucket.",
+	},
+	{
+		ID:              "s3_notification_cross_account",
+		Service:         "s3",
+		Title:           "S3 cross-account event notifications",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether bucket event notifications are sent to Lambda functions, SNS topics, or SQS queues in other accounts.",
+		RemediationHint: "Point the notifications at targets in the bucket's account, or remove the notification configuration.",
+	},
+
+	// EC2 Checks
+	{
+		ID:              "ec2_sg_unrestricted_ingress",
+		Service:         "ec2",
+		Title:           "Security group unrestricted ingress",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether a security group allows inbound traffic from 0.0.0.0/0.",
+		RemediationHint: "Limit ingress rules to known CIDR ranges or security groups.",
+	},
+	{
+		ID:              "ec2_instance_sg_unrestricted",
+		Service:         "ec2",
+		Title:           "Instance security group unrestricted ingress",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether a security group attached to an instance allows inbound traffic from 0.0.0.0/0.",
+		RemediationHint: "Limit the instance's security group rules to known CIDR ranges or security groups.",
+	},
+	{
+		ID:              "ec2_sg_dangerous_ports",
+		Service:         "ec2",
+		Title:           "Security group exposes sensitive ports",
+		DefaultSeverity: scanner.SeverityCritical,
+		Description:     "Checks whether a security group exposes administrative or database ports such as SSH, RDP, or MySQL to the internet.",
+		RemediationHint: "Remove internet ingress on the port and reach the service through a VPN, bastion, or Session Manager.",
+	},
+	{
+		ID:              "ec2_imdsv2_required",
+		Service:         "ec2",
+		Title:           "EC2 IMDSv2 required",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether the instance requires session tokens for the instance metadata service.",
+		RemediationHint: "Set HttpTokens to required on the instance metadata options.",
+	},
+	{
+		ID:              "ec2_imdsv1_usage",
+		Service:         "ec2",
+		Title:           "EC2 IMDSv1 exposure",
+		DefaultSeverity: scanner.SeverityCritical,
+		Description:     "Checks whether the instance accepts IMDSv1 requests, rating internet-reachable instances as critical and private ones as medium.",
+		RemediationHint: "Require IMDSv2, starting with instances in public subnets.",
+	},
+	{
+		ID:              "ec2_ebs_encryption",
+		Service:         "ec2",
+		Title:           "EBS volume encryption",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether the EBS volumes attached to the instance are encrypted.",
+		RemediationHint: "Enable EBS encryption by default and migrate unencrypted volumes through an encrypted snapshot copy.",
+	},
+	{
+		ID:              "ec2_public_ip",
+		Service:         "ec2",
+		Title:           "EC2 public IP address",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether the instance has a public IP address.",
+		RemediationHint: "Place the instance in a private subnet behind a load balancer or NAT gateway.",
+	},
+	{
+		ID:              "ec2_public_subnet",
+		Service:         "ec2",
+		Title:           "EC2 public subnet placement",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether the instance's subnet routes 0.0.0.0/0 or ::/0 to an internet gateway.",
+		RemediationHint: "Move the instance to a private subnet that reaches the internet through a NAT gateway.",
+	},
+	{
+		ID:              "ec2_detailed_monitoring",
+		Service:         "ec2",
+		Title:           "EC2 detailed monitoring",
+		DefaultSeverity: scanner.SeverityLow,
+		Description:     "Checks whether the instance publishes CloudWatch metrics at one-minute intervals.",
+		RemediationHint: "Enable detailed monitoring on the instance.",
+	},
+	{
+		ID:              "ec2_termination_protection",
+		Service:         "ec2",
+		Title:           "EC2 termination protection",
+		DefaultSeverity: scanner.SeverityLow,
+		Description:     "Checks whether the instance has API termination protection enabled.",
+		RemediationHint: "Enable termination protection on production instances with modify-instance-attribute --disable-api-termination.",
+	},
+	{
+		ID:              "ec2_long_stopped",
+		Service:         "ec2",
+		Title:           "Long-stopped EC2 instance",
+		DefaultSeverity: scanner.SeverityLow,
+		Description:     "Checks whether a stopped instance has been stopped for longer than the configured number of days (30 by default).",
+		RemediationHint: "Terminate instances that are no longer needed, or snapshot their volumes first.",
+	},
+	{
+		ID:              "ec2_iam_role",
+		Service:         "ec2",
+		Title:           "EC2 instance profile",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether the instance uses an IAM role rather than long-lived credentials.",
+		RemediationHint: "Attach an instance profile and remove access keys stored on the instance.",
+	},
+	{
+		ID:              "ec2_unassociated_eip",
+		Service:         "ec2",
+		Title:           "Unassociated Elastic IP",
+		DefaultSeverity: scanner.SeverityLow,
+		Description:     "Checks for Elastic IP addresses that are allocated but not associated with a resource.",
+		RemediationHint: "Release Elastic IPs that are no longer needed.",
+	},
+	{
+		ID:              "ec2_unused_sg",
+		Service:         "ec2",
+		Title:           "Unused security group",
+		DefaultSeverity: scanner.SeverityLow,
+		Description:     "Checks for security groups that are not attached to any instance.",
+		RemediationHint: "Delete security groups that are no longer used.",
+	},
+	{
+		ID:              "ec2_default_sg_in_use",
+		Service:         "ec2",
+		Title:           "Default security group in use",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether any instance or network interface is attached to a VPC's default security group.",
+		RemediationHint: "Move the resources to purpose-built security groups that grant only the access they need.",
+	},
+	{
+		ID:              "ec2_default_sg_rules",
+		Service:         "ec2",
+		Title:           "Default security group restricts all traffic",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether a VPC's default security group has any inbound or outbound rules.",
+		RemediationHint: "Remove every inbound and outbound rule from the default security group.",
+	},
+	{
+		ID:              "ec2_snapshot_encryption",
+		Service:         "ec2",
+		Title:           "EBS snapshot encryption",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether EBS snapshots owned by the account are encrypted.",
+		RemediationHint: "Copy the snapshot with encryption enabled and delete the unencrypted original.",
+	},
+	{
+		ID:              "ec2_snapshot_public",
+		Service:         "ec2",
+		Title:           "Public EBS snapshot",
+		DefaultSeverity: scanner.SeverityCritical,
+		Description:     "Checks whether an EBS snapshot grants create-volume permission to all AWS accounts.",
+		RemediationHint: "Remove the public create-volume permission and share the snapshot with specific accounts instead.",
+	},
+
+	// IAM Checks
+	{
+		ID:              "iam_unused_access_keys",
+		Service:         "iam",
+		Title:           "Unused IAM access keys",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks for active access keys that have never been used or have not been used recently.",
+		RemediationHint: "Deactivate and delete access keys that are not in use.",
+	},
+	{
+		ID:              "iam_access_key_rotation",
+		Service:         "iam",
+		Title:           "IAM access key rotation",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether active access keys are older than the rotation threshold.",
+		RemediationHint: "Create a new access key, switch clients to it, and delete the old key.",
+	},
+	{
+		ID:              "iam_root_usage",
+		Service:         "iam",
+		Title:           "Root account usage",
+		DefaultSeverity: scanner.SeverityCritical,
+		Description:     "Checks whether the root account has signed in or used access keys recently.",
+		RemediationHint: "Use IAM roles for day-to-day work and reserve the root account for tasks that require it.",
+	},
+	{
+		ID:              "iam_user_mfa",
+		Service:         "iam",
+		Title:           "IAM user MFA",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether the IAM user has an MFA device.",
+		RemediationHint: "Assign a virtual or hardware MFA device to the user.",
+	},
+	{
+		ID:              "iam_root_mfa",
+		Service:         "iam",
+		Title:           "Root account MFA",
+		DefaultSeverity: scanner.SeverityCritical,
+		Description:     "Checks whether the root account has MFA enabled.",
+		RemediationHint: "Enable a hardware or virtual MFA device for the root account.",
+	},
+	{
+		ID:              "iam_overly_permissive",
+		Service:         "iam",
+		Title:           "Overly permissive IAM policy",
+		DefaultSeverity: scanner.SeverityCritical,
+		Description:     "Checks customer managed policies for statements that allow all actions on all resources.",
+		RemediationHint: "Replace wildcard actions and resources with the specific permissions the workload needs.",
+	},
+	{
+		ID:              "iam_not_action",
+		Service:         "iam",
+		Title:           "IAM policy allows NotAction",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks customer managed policies and user inline policies for Allow statements that use NotAction.",
+		RemediationHint: "List the allowed actions explicitly, or turn the statement into a Deny.",
+	},
+	{
+		ID:              "iam_password_policy",
+		Service:         "iam",
+		Title:           "IAM password policy",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether the account password policy meets length, complexity, and reuse requirements.",
+		RemediationHint: "Configure a password policy with a minimum length of 14 and reuse prevention.",
+	},
+	{
+		ID:              "iam_unused_users",
+		Service:         "iam",
+		Title:           "Inactive IAM users",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks for IAM users that have never signed in or have been inactive for over 90 days.",
+		RemediationHint: "Remove users that no longer need access.",
+	},
+	{
+		ID:              "iam_inline_policies",
+		Service:         "iam",
+		Title:           "IAM user inline policies",
+		DefaultSeverity: scanner.SeverityLow,
+		Description:     "Checks whether permissions are attached to the user as inline policies.",
+		RemediationHint: "Move inline permissions into managed policies attached to groups or roles.",
+	},
+	{
+		ID:              "iam_cross_account_trust",
+		Service:         "iam",
+		Title:           "IAM role cross-account trust",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether a role's trust policy allows principals in other accounts to assume it.",
+		RemediationHint: "Limit trusted accounts to those you control and require an external ID for third parties.",
+	},
+	{
+		ID:              "iam_console_without_mfa",
+		Service:         "iam",
+		Title:           "Console access without MFA",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks for IAM users that can sign in to the console without MFA.",
+		RemediationHint: "Enable MFA for the user or remove the login profile.",
+	},
+	{
+		ID:              "iam_scan_role_least_privilege",
+		Service:         "iam",
+		Title:           "CloudCop scan role permissions",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether the role CloudCop scans with is limited to the read-only permissions scanning requires.",
+		RemediationHint: "Redeploy the scan role from the CloudCop CloudFormation template.",
+	},
+
+	// Lambda Checks
+	{
+		ID:              "lambda_env_secrets",
+		Service:         "lambda",
+		Title:           "Lambda secrets in environment variables",
+		DefaultSeverity: scanner.SeverityCritical,
+		Description:     "Checks function environment variables for names that suggest secrets such as passwords or API keys.",
+		RemediationHint: "Move secrets to Secrets Manager or Parameter Store and read them at runtime.",
+	},
+	{
+		ID:              "lambda_cloudwatch_logs",
+		Service:         "lambda",
+		Title:           "Lambda CloudWatch Logs",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks how the function's logs are delivered to CloudWatch Logs.",
+		RemediationHint: "Configure a log group with a retention period for the function.",
+	},
+	{
+		ID:              "lambda_vpc_config",
+		Service:         "lambda",
+		Title:           "Lambda VPC configuration",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether the function runs inside a VPC.",
+		RemediationHint: "Attach the function to private subnets if it accesses resources in your VPC.",
+	},
+	{
+		ID:              "lambda_dlq",
+		Service:         "lambda",
+		Title:           "Lambda dead letter queue",
+		DefaultSeverity: scanner.SeverityLow,
+		Description:     "Checks whether failed asynchronous invocations are sent to a dead letter queue.",
+		RemediationHint: "Configure an SQS queue or SNS topic as the function's dead letter target.",
+	},
+	{
+		ID:              "lambda_tracing",
+		Service:         "lambda",
+		Title:           "Lambda X-Ray tracing",
+		DefaultSeverity: scanner.SeverityLow,
+		Description:     "Checks whether active X-Ray tracing is enabled for the function.",
+		RemediationHint: "Set the function's tracing mode to Active.",
+	},
+	{
+		ID:              "lambda_reserved_concurrency",
+		Service:         "lambda",
+		Title:           "Lambda reserved concurrency",
+		DefaultSeverity: scanner.SeverityLow,
+		Description:     "Checks whether the function has reserved concurrency to bound its scaling.",
+		RemediationHint: "Set reserved concurrency to the function's expected peak.",
+	},
+	{
+		ID:              "lambda_timeout",
+		Service:         "lambda",
+		Title:           "Lambda timeout",
+		DefaultSeverity: scanner.SeverityLow,
+		Description:     "Checks whether the function timeout exceeds the recommended limit.",
+		RemediationHint: "Lower the timeout to slightly above the function's expected run time.",
+	},
+	{
+		ID:              "lambda_public_url",
+		Service:         "lambda",
+		Title:           "Lambda function URL authentication",
+		DefaultSeverity: scanner.SeverityCritical,
+		Description:     "Checks whether the function URL accepts unauthenticated requests (auth type NONE).",
+		RemediationHint: "Set the function URL auth type to AWS_IAM, or delete the URL and front the function with API Gateway.",
+	},
+	{
+		ID:              "lambda_public_permission",
+		Service:         "lambda",
+		Title:           "Lambda resource policy public access",
+		DefaultSeverity: scanner.SeverityCritical,
+		Description:     "Checks whether the function's resource-based policy grants the \"*\" principal access without an aws:SourceAccount, aws:SourceArn, or aws:PrincipalOrgID condition.",
+		RemediationHint: "Remove the public permission with lambda remove-permission, or add a source account or ARN condition when granting a service access.",
+	},
+
+	// ECS Checks
+	{
+		ID:              "ecs_privileged_container",
+		Service:         "ecs",
+		Title:           "ECS privileged containers",
+		DefaultSeverity: scanner.SeverityCritical,
+		Description:     "Checks whether a task definition runs containers in privileged mode.",
+		RemediationHint: "Remove the privileged flag and grant only the Linux capabilities the container needs.",
+	},
+	{
+		ID:              "ecs_public_registry",
+		Service:         "ecs",
+		Title:           "ECS public registry images",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether container images are pulled from public registries rather than ECR.",
+		RemediationHint: "Mirror the images into a private ECR repository with image scanning.",
+	},
+	{
+		ID:              "ecs_task_iam_role",
+		Service:         "ecs",
+		Title:           "ECS task role",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether the task definition assigns an IAM task role.",
+		RemediationHint: "Assign a task role scoped to the permissions the containers need.",
+	},
+	{
+		ID:              "ecs_awsvpc_mode",
+		Service:         "ecs",
+		Title:           "ECS awsvpc network mode",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether tasks use the awsvpc network mode so each task has its own security groups.",
+		RemediationHint: "Set the task definition's network mode to awsvpc.",
+	},
+	{
+		ID:              "ecs_secrets_in_env",
+		Service:         "ecs",
+		Title:           "ECS secrets in environment variables",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks container environment variables for names that suggest secrets.",
+		RemediationHint: "Reference secrets from Secrets Manager or Parameter Store in the container's secrets field.",
+	},
+	{
+		ID:              "ecs_cloudwatch_logs",
+		Service:         "ecs",
+		Title:           "ECS container logging",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether containers send their logs to CloudWatch Logs.",
+		RemediationHint: "Configure the awslogs log driver for each container.",
+	},
+
+	// DynamoDB Checks
+	{
+		ID:              "dynamodb_encryption",
+		Service:         "dynamodb",
+		Title:           "DynamoDB encryption",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether the table is encrypted at rest.",
+		RemediationHint: "Encrypt the table with an AWS managed or customer managed KMS key.",
+	},
+	{
+		ID:              "dynamodb_pitr",
+		Service:         "dynamodb",
+		Title:           "DynamoDB point-in-time recovery",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether point-in-time recovery is enabled for the table.",
+		RemediationHint: "Enable point-in-time recovery on the table.",
+	},
+	{
+		ID:              "dynamodb_backup",
+		Service:         "dynamodb",
+		Title:           "DynamoDB on-demand backups",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether the table has a recent on-demand backup.",
+		RemediationHint: "Schedule on-demand backups with AWS Backup.",
+	},
+	{
+		ID:              "dynamodb_ttl",
+		Service:         "dynamodb",
+		Title:           "DynamoDB time to live",
+		DefaultSeverity: scanner.SeverityLow,
+		Description:     "Checks whether TTL expires items so data is not kept indefinitely.",
+		RemediationHint: "Enable TTL on an expiry timestamp attribute.",
+	},
+	{
+		ID:              "dynamodb_auto_scaling",
+		Service:         "dynamodb",
+		Title:           "DynamoDB capacity scaling",
+		DefaultSeverity: scanner.SeverityLow,
+		Description:     "Checks whether the table uses on-demand capacity or scales its provisioned capacity.",
+		RemediationHint: "Switch to on-demand capacity or configure auto scaling for the table.",
+	},
+	{
+		ID:              "dynamodb_vpc_endpoint",
+		Service:         "dynamodb",
+		Title:           "DynamoDB gateway endpoint",
+		DefaultSeverity: scanner.SeverityLow,
+		Description:     "Checks whether the region has a DynamoDB gateway VPC endpoint so traffic stays on the AWS network.",
+		RemediationHint: "Create a DynamoDB gateway endpoint and add it to the VPC route tables.",
+	},
+
+	// KMS Checks
+	{
+		ID:              "kms_broad_grant",
+		Service:         "kms",
+		Title:           "KMS key broad grants",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks key grants for principals or operations broader than the grantee needs.",
+		RemediationHint: "Retire the grant and issue a new one limited to the required operations and constraints.",
+	},
+
+	// EKS Checks
+	{
+		ID:              "eks_irsa_configured",
+		Service:         "eks",
+		Title:           "EKS IAM roles for service accounts",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether the cluster has an IAM OIDC provider so pods can use IAM roles for service accounts.",
+		RemediationHint: "Associate an IAM OIDC provider with the cluster and give workloads their own roles.",
+	},
+	{
+		ID:              "eks_node_imdsv2",
+		Service:         "eks",
+		Title:           "EKS node IMDSv2",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether node group launch templates require IMDSv2.",
+		RemediationHint: "Set HttpTokens to required in the node group's launch template.",
+	},
+	{
+		ID:              "eks_private_endpoint",
+		Service:         "eks",
+		Title:           "EKS API endpoint exposure",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether the cluster's public API server endpoint accepts connections from 0.0.0.0/0.",
+		RemediationHint: "Enable private endpoint access and disable public access, or limit public access to known CIDR ranges.",
+	},
+	{
+		ID:              "eks_control_plane_logging",
+		Service:         "eks",
+		Title:           "EKS control plane logging",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether the cluster sends audit and authenticator control plane logs to CloudWatch.",
+		RemediationHint: "Enable the audit and authenticator log types in the cluster's logging configuration.",
+	},
+	{
+		ID:              "eks_secrets_encryption",
+		Service:         "eks",
+		Title:           "EKS secrets encryption",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether Kubernetes secrets are envelope encrypted with a KMS key.",
+		RemediationHint: "Associate a KMS key with the cluster for secrets encryption.",
+	},
+	{
+		ID:              "eks_version_supported",
+		Service:         "eks",
+		Title:           "EKS Kubernetes version",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether the cluster runs a Kubernetes version still in EKS standard support.",
+		RemediationHint: "Upgrade the control plane and node groups to a supported Kubernetes version.",
+	},
+
+	// ELB Checks
+	{
+		ID:              "elb_https_listener",
+		Service:         "elb",
+		Title:           "Load balancer encrypted listener",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether the load balancer has at least one HTTPS or TLS listener.",
+		RemediationHint: "Add an HTTPS or TLS listener and redirect HTTP traffic to it.",
+	},
+	{
+		ID:              "elb_tls_policy",
+		Service:         "elb",
+		Title:           "Load balancer TLS policy",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether encrypted listeners use a current TLS security policy.",
+		RemediationHint: "Switch listeners to a TLS 1.2 or later security policy.",
+	},
+	{
+		ID:              "elb_access_logs",
+		Service:         "elb",
+		Title:           "Load balancer access logs",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether the load balancer writes access logs to S3.",
+		RemediationHint: "Enable access logging to an S3 bucket.",
+	},
+	{
+		ID:              "elb_deletion_protection",
+		Service:         "elb",
+		Title:           "Load balancer deletion protection",
+		DefaultSeverity: scanner.SeverityLow,
+		Description:     "Checks whether deletion protection is enabled on the load balancer.",
+		RemediationHint: "Enable deletion protection on production load balancers.",
+	},
+
+	// CloudTrail Checks
+	{
+		ID:              "cloudtrail_data_events",
+		Service:         "cloudtrail",
+		Title:           "CloudTrail data events",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether the trail logs both management and data events.",
+		RemediationHint: "Add event selectors for data events on the resources you need to audit.",
+	},
+	{
+		ID:              "cloudtrail_lake_encryption",
+		Service:         "cloudtrail",
+		Title:           "CloudTrail Lake encryption",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether the event data store is encrypted with a customer managed KMS key.",
+		RemediationHint: "Configure a customer managed KMS key on the event data store.",
+	},
+	{
+		ID:              "cloudtrail_lake_retention",
+		Service:         "cloudtrail",
+		Title:           "CloudTrail Lake retention",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether the event data store retains events for at least the minimum retention period.",
+		RemediationHint: "Raise the event data store's retention period.",
+	},
+
+	// OpenSearch Checks
+	{
+		ID:              "opensearch_encryption_at_rest",
+		Service:         "opensearch",
+		Title:           "OpenSearch encryption at rest",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether the domain encrypts its indices, logs, and automated snapshots at rest.",
+		RemediationHint: "Enable encryption at rest on the domain; older engine versions require migrating to a new domain.",
+	},
+	{
+		ID:              "opensearch_node_to_node_encryption",
+		Service:         "opensearch",
+		Title:           "OpenSearch node-to-node encryption",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether traffic between the domain's nodes is encrypted with TLS.",
+		RemediationHint: "Enable node-to-node encryption in the domain's security configuration.",
+	},
+	{
+		ID:              "opensearch_https_enforced",
+		Service:         "opensearch",
+		Title:           "OpenSearch HTTPS enforcement",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether the domain endpoint rejects requests over plain HTTP.",
+		RemediationHint: "Enable \"Require HTTPS\" in the domain endpoint options and set a current TLS security policy.",
+	},
+	{
+		ID:              "opensearch_vpc_only",
+		Service:         "opensearch",
+		Title:           "OpenSearch VPC access",
+		DefaultSeverity: scanner.SeverityHigh,
+		Description:     "Checks whether the domain is placed in a VPC rather than exposing a public endpoint.",
+		RemediationHint: "Recreate the domain with VPC access and restore its data from a snapshot; network access cannot be changed in place.",
+	},
+	{
+		ID:              "opensearch_access_policy_public",
+		Service:         "opensearch",
+		Title:           "OpenSearch access policy public access",
+		DefaultSeverity: scanner.SeverityCritical,
+		Description:     "Checks whether a domain with a public endpoint has an access policy granting the \"*\" principal access without an aws:SourceIp, VPC, or organization condition.",
+		RemediationHint: "Restrict the access policy to specific IAM principals or source IP ranges, or enable fine-grained access control.",
+	},
+
+	// WAF Checks
+	{
+		ID:              "waf_not_associated",
+		Service:         "waf",
+		Title:           "WAF web ACL association",
+		DefaultSeverity: scanner.SeverityMedium,
+		Description:     "Checks whether internet-facing application load balancers, REST API stages, and CloudFront distributions are associated with a WAF web ACL.",
+		RemediationHint: "Associate a web ACL with the resource, starting from the AWS managed core rule set.",
+	},
+}
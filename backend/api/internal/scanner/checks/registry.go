@@ -0,0 +1,95 @@
+// Package checks describes every security check the scanners run, giving the
+// UI and integrations a single catalog of check metadata.
+package checks
+
+import (
+	"maps"
+	"slices"
+
+	"cloudcop/api/internal/scanner"
+	"cloudcop/api/internal/scanner/compliance"
+)
+
+// CheckMetadata describes a single security check.
+type CheckMetadata struct {
+	// ID is the check ID findings are reported under.
+	ID string `json:"id"`
+	// Service is the scanner that runs the check.
+	Service string `json:"service"`
+	// Title names the check independently of its outcome.
+	Title string `json:"title"`
+	// DefaultSeverity is the severity of a failed finding before any
+	// organization override. Some checks raise or lower it based on context.
+	DefaultSeverity scanner.Severity `json:"default_severity"`
+	// Description explains what the check evaluates.
+	Description string `json:"description"`
+	// Compliance lists the framework requirements the check maps to.
+	Compliance []string `json:"compliance"`
+	// RemediationHint is a short description of how to fix a failure.
+	RemediationHint string `json:"remediation_hint"`
+}
+
+// Registry indexes check metadata by check ID.
+type Registry struct {
+	checks map[string]CheckMetadata
+}
+
+// NewRegistry creates a registry of the given checks. Checks without explicit
+// compliance requirements take them from the compliance mappings.
+func NewRegistry(checks ...CheckMetadata) *Registry {
+	r := &Registry{checks: make(map[string]CheckMetadata, len(checks))}
+	for _, c := range checks {
+		if c.Compliance == nil {
+			c.Compliance = compliance.GetCompliance(c.ID)
+		}
+		r.checks[c.ID] = c
+	}
+	return r
+}
+
+// Get returns the metadata of the check with the given ID.
+func (r *Registry) Get(id string) (CheckMetadata, bool) {
+	c, ok := r.checks[id]
+	if !ok {
+		return CheckMetadata{}, false
+	}
+	return c.clone(), true
+}
+
+// All returns every registered check sorted by ID.
+func (r *Registry) All() []CheckMetadata {
+	all := make([]CheckMetadata, 0, len(r.checks))
+	for _, id := range slices.Sorted(maps.Keys(r.checks)) {
+		all = append(all, r.checks[id].clone())
+	}
+	return all
+}
+
+// ByService returns the checks run by service sorted by ID.
+func (r *Registry) ByService(service string) []CheckMetadata {
+	var checks []CheckMetadata
+	for _, c := range r.All() {
+		if c.Service == service {
+			checks = append(checks, c)
+		}
+	}
+	return checks
+}
+
+// clone copies the compliance slice so callers cannot modify the registry.
+func (c CheckMetadata) clone() CheckMetadata {
+	c.Compliance = slices.Clone(c.Compliance)
+	return c
+}
+
+// Default is the registry of CloudCop's built-in checks.
+var Default = NewRegistry(builtin...)
+
+// Compliance returns the compliance requirements of a built-in check, or an
+// empty slice for unknown check IDs.
+func Compliance(checkID string) []string {
+	if c, ok := Default.Get(checkID); ok {
+		return c.Compliance
+	}
+	return []string{}
+}
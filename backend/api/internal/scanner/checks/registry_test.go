@@ -0,0 +1,148 @@
+package checks
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"cloudcop/api/internal/scanner"
+)
+
+// findingConstructors are the scanner helpers whose first argument is a check ID.
+var findingConstructors = map[string]bool{
+	"createFinding":       true,
+	"accessDeniedFinding": true,
+}
+
+// scannerCheckIDs parses the service scanner packages and returns every
+// check ID passed as a literal to a finding constructor.
+func scannerCheckIDs(t *testing.T) map[string]string {
+	t.Helper()
+
+	files, err := filepath.Glob("../*/*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := make(map[string]string)
+	fset := token.NewFileSet()
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatalf("parsing %s: %v", path, err)
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || !findingConstructors[sel.Sel.Name] {
+				return true
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			id, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				t.Fatalf("%s: %v", fset.Position(lit.Pos()), err)
+			}
+			ids[id] = fset.Position(lit.Pos()).String()
+			return true
+		})
+	}
+	return ids
+}
+
+func TestDefault_CoversScannerChecks(t *testing.T) {
+	ids := scannerCheckIDs(t)
+	if len(ids) < len(builtin) {
+		t.Fatalf("found %d check IDs in scanner sources, want at least %d; did the source layout change?", len(ids), len(builtin))
+	}
+
+	for id, pos := range ids {
+		c, ok := Default.Get(id)
+		if !ok {
+			t.Errorf("%s: check %s is not registered", pos, id)
+			continue
+		}
+		if len(c.Compliance) == 0 {
+			t.Errorf("%s: check %s has no compliance mapping", pos, id)
+		}
+	}
+}
+
+var severities = []scanner.Severity{
+	scanner.SeverityCritical,
+	scanner.SeverityHigh,
+	scanner.SeverityMedium,
+	scanner.SeverityLow,
+}
+
+func TestDefault_Metadata(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, c := range builtin {
+		if seen[c.ID] {
+			t.Errorf("check %s is registered twice", c.ID)
+		}
+		seen[c.ID] = true
+
+		if !strings.HasPrefix(c.ID, c.Service+"_") {
+			t.Errorf("check %s has service %q", c.ID, c.Service)
+		}
+		if c.Title == "" || c.Description == "" || c.RemediationHint == "" {
+			t.Errorf("check %s is missing a title, description, or remediation hint", c.ID)
+		}
+		if !slices.Contains(severities, c.DefaultSeverity) {
+			t.Errorf("check %s has invalid default severity %q", c.ID, c.DefaultSeverity)
+		}
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry(
+		CheckMetadata{ID: "s3_b", Service: "s3"},
+		CheckMetadata{ID: "ec2_a", Service: "ec2"},
+		CheckMetadata{ID: "s3_a", Service: "s3", Compliance: []string{"CUSTOM-1"}},
+	)
+
+	var all []string
+	for _, c := range r.All() {
+		all = append(all, c.ID)
+	}
+	if want := []string{"ec2_a", "s3_a", "s3_b"}; !slices.Equal(all, want) {
+		t.Errorf("All() = %v, want %v", all, want)
+	}
+
+	var s3 []string
+	for _, c := range r.ByService("s3") {
+		s3 = append(s3, c.ID)
+	}
+	if want := []string{"s3_a", "s3_b"}; !slices.Equal(s3, want) {
+		t.Errorf("ByService(s3) = %v, want %v", s3, want)
+	}
+	if got := r.ByService("kms"); len(got) != 0 {
+		t.Errorf("ByService(kms) = %v, want none", got)
+	}
+
+	c, ok := r.Get("s3_a")
+	if !ok || !slices.Equal(c.Compliance, []string{"CUSTOM-1"}) {
+		t.Fatalf("Get(s3_a) = %+v, %v", c, ok)
+	}
+	c.Compliance[0] = "MUTATED"
+	if again, _ := r.Get("s3_a"); again.Compliance[0] != "CUSTOM-1" {
+		t.Error("modifying returned metadata changed the registry")
+	}
+	if _, ok := r.Get("missing"); ok {
+		t.Error("Get(missing) reported a check")
+	}
+}
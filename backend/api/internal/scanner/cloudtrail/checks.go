@@ -0,0 +1,144 @@
+package cloudtrail
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail/types"
+)
+
+// eventCoverage records which event categories a trail's selectors log.
+type eventCoverage struct {
+	management bool
+	data       bool
+}
+
+// selectorCoverage evaluates a trail's basic or advanced event selectors. A
+// trail uses one kind or the other, never both.
+func selectorCoverage(out *cloudtrail.GetEventSelectorsOutput) eventCoverage {
+	var coverage eventCoverage
+	for _, sel := range out.EventSelectors {
+		// Management events default to included; a ReadOnly or WriteOnly
+		// selector only logs half of them.
+		if sel.IncludeManagementEvents == nil || *sel.IncludeManagementEvents {
+			if sel.ReadWriteType == "" || sel.ReadWriteType == types.ReadWriteTypeAll {
+				coverage.management = true
+			}
+		}
+		if len(sel.DataResources) > 0 {
+			coverage.data = true
+		}
+	}
+	for _, sel := range out.AdvancedEventSelectors {
+		for _, field := range sel.FieldSelectors {
+			if aws.ToString(field.Field) != "eventCategory" {
+				continue
+			}
+			if slices.Contains(field.Equals, "Management") {
+				coverage.management = true
+			}
+			if slices.Contains(field.Equals, "Data") {
+				coverage.data = true
+			}
+		}
+	}
+	return coverage
+}
+
+// checkDataEvents fails trails that do not log both management and data
+// events, since data events are the only record of object-level access.
+func (c *Scanner) checkDataEvents(ctx context.Context, trail types.Trail) []scanner.Finding {
+	name := aws.ToString(trail.Name)
+	out, err := c.client.GetEventSelectors(ctx, &cloudtrail.GetEventSelectorsInput{
+		TrailName: trail.TrailARN,
+	})
+	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{c.accessDeniedFinding("cloudtrail_data_events", name, err)}
+		}
+		return nil
+	}
+
+	coverage := selectorCoverage(out)
+	var missing []string
+	if !coverage.management {
+		missing = append(missing, "management events")
+	}
+	if !coverage.data {
+		missing = append(missing, "data events")
+	}
+
+	if len(missing) > 0 {
+		return []scanner.Finding{c.createFinding(
+			"cloudtrail_data_events",
+			name,
+			"CloudTrail trail does not log all event types",
+			fmt.Sprintf("Trail %s does not log %s", name, strings.Join(missing, " or ")),
+			scanner.StatusFail,
+			scanner.SeverityMedium,
+		)}
+	}
+	return []scanner.Finding{c.createFinding(
+		"cloudtrail_data_events",
+		name,
+		"CloudTrail trail logs management and data events",
+		fmt.Sprintf("Trail %s logs both management and data events", name),
+		scanner.StatusPass,
+		scanner.SeverityMedium,
+	)}
+}
+
+// checkLakeEncryption fails event data stores encrypted with the default
+// AWS owned key rather than a customer managed KMS key.
+func (c *Scanner) checkLakeEncryption(store *cloudtrail.GetEventDataStoreOutput) []scanner.Finding {
+	name := aws.ToString(store.Name)
+	if aws.ToString(store.KmsKeyId) == "" {
+		return []scanner.Finding{c.createFinding(
+			"cloudtrail_lake_encryption",
+			name,
+			"CloudTrail Lake event data store uses an AWS owned key",
+			fmt.Sprintf("Event data store %s is not encrypted with a customer managed KMS key", name),
+			scanner.StatusFail,
+			scanner.SeverityMedium,
+		)}
+	}
+	return []scanner.Finding{c.createFinding(
+		"cloudtrail_lake_encryption",
+		name,
+		"CloudTrail Lake event data store uses a customer managed key",
+		fmt.Sprintf("Event data store %s is encrypted with KMS key %s", name, aws.ToString(store.KmsKeyId)),
+		scanner.StatusPass,
+		scanner.SeverityMedium,
+	)}
+}
+
+// checkLakeRetention fails event data stores that keep events for less than
+// the minimum retention period.
+func (c *Scanner) checkLakeRetention(store *cloudtrail.GetEventDataStoreOutput) []scanner.Finding {
+	name := aws.ToString(store.Name)
+	days := int(aws.ToInt32(store.RetentionPeriod))
+	if days < c.retentionDays() {
+		return []scanner.Finding{c.createFinding(
+			"cloudtrail_lake_retention",
+			name,
+			"CloudTrail Lake event data store retention is too short",
+			fmt.Sprintf("Event data store %s retains events for %d days, less than the required %d", name, days, c.retentionDays()),
+			scanner.StatusFail,
+			scanner.SeverityMedium,
+		)}
+	}
+	return []scanner.Finding{c.createFinding(
+		"cloudtrail_lake_retention",
+		name,
+		"CloudTrail Lake event data store retention is sufficient",
+		fmt.Sprintf("Event data store %s retains events for %d days", name, days),
+		scanner.StatusPass,
+		scanner.SeverityMedium,
+	)}
+}
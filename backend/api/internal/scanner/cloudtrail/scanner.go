@@ -0,0 +1,235 @@
+// Package cloudtrail provides CloudTrail and CloudTrail Lake security scanning capabilities.
+package cloudtrail
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloudcop/api/internal/awsauth"
+	"cloudcop/api/internal/scanner"
+	"cloudcop/api/internal/scanner/checks"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail/types"
+)
+
+// minRetentionDays is the default retention an event data store needs for
+// cloudtrail_lake_retention to pass, matching the one-year audit log
+// requirement of PCI DSS 10.7.
+const minRetentionDays = 365
+
+// cloudtrailAPI is the subset of the CloudTrail client used by the scanner.
+type cloudtrailAPI interface {
+	DescribeTrails(ctx context.Context, params *cloudtrail.DescribeTrailsInput, optFns ...func(*cloudtrail.Options)) (*cloudtrail.DescribeTrailsOutput, error)
+	GetEventSelectors(ctx context.Context, params *cloudtrail.GetEventSelectorsInput, optFns ...func(*cloudtrail.Options)) (*cloudtrail.GetEventSelectorsOutput, error)
+	ListEventDataStores(ctx context.Context, params *cloudtrail.ListEventDataStoresInput, optFns ...func(*cloudtrail.Options)) (*cloudtrail.ListEventDataStoresOutput, error)
+	GetEventDataStore(ctx context.Context, params *cloudtrail.GetEventDataStoreInput, optFns ...func(*cloudtrail.Options)) (*cloudtrail.GetEventDataStoreOutput, error)
+}
+
+// Scanner performs security checks on CloudTrail trails and event data stores.
+type Scanner struct {
+	client    cloudtrailAPI
+	region    string
+	accountID string
+
+	minRetentionDays int
+}
+
+// Option configures a Scanner.
+type Option func(*Scanner)
+
+// WithMinRetentionDays overrides how many days an event data store must retain
+// events for cloudtrail_lake_retention to pass. Non-positive values keep the default.
+func WithMinRetentionDays(days int) Option {
+	return func(s *Scanner) {
+		s.minRetentionDays = days
+	}
+}
+
+// NewScanner creates a new CloudTrail scanner for the given region and account ID.
+func NewScanner(cfg aws.Config, region, accountID string, opts ...Option) scanner.ServiceScanner {
+	s := &Scanner{
+		client:    cloudtrail.NewFromConfig(cfg),
+		region:    region,
+		accountID: accountID,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// NewFactory returns a scanner.Factory that builds Scanners with opts applied.
+func NewFactory(opts ...Option) scanner.Factory {
+	return func(cfg aws.Config, region, accountID string) scanner.ServiceScanner {
+		return NewScanner(cfg, region, accountID, opts...)
+	}
+}
+
+// Service returns the AWS service name.
+func (c *Scanner) Service() string {
+	return "cloudtrail"
+}
+
+// Scan executes all CloudTrail security checks against the trails homed in
+// the region and its CloudTrail Lake event data stores.
+func (c *Scanner) Scan(ctx context.Context, _ string) ([]scanner.Finding, error) {
+	trails, err := c.listTrails(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing trails: %w", err)
+	}
+	scanner.CountResources(ctx, len(trails))
+
+	scope := scanner.ScopeFromContext(ctx)
+	findings := scanner.TraceChecks(ctx, "cloudtrail.trails", func(ctx context.Context) []scanner.Finding {
+		var trailFindings []scanner.Finding
+		for _, trail := range trails {
+			if !scope.Includes(aws.ToString(trail.Name)) {
+				continue
+			}
+			trailFindings = append(trailFindings, c.checkDataEvents(ctx, trail)...)
+		}
+		return trailFindings
+	})
+
+	stores, err := c.listEventDataStores(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing event data stores: %w", err)
+	}
+	scanner.CountResources(ctx, len(stores))
+	findings = append(findings, scanner.TraceChecks(ctx, "cloudtrail.event_data_stores", func(ctx context.Context) []scanner.Finding {
+		var storeFindings []scanner.Finding
+		for _, store := range stores {
+			name := aws.ToString(store.Name)
+			if !scope.Includes(name) {
+				continue
+			}
+			storeFindings = append(storeFindings, c.checkEventDataStore(ctx, store)...)
+		}
+		return storeFindings
+	})...)
+
+	return findings, nil
+}
+
+// checkEventDataStore fetches an event data store's configuration and
+// evaluates its encryption and retention.
+func (c *Scanner) checkEventDataStore(ctx context.Context, store types.EventDataStore) []scanner.Finding {
+	name := aws.ToString(store.Name)
+	storeARN := aws.ToString(store.EventDataStoreArn)
+	out, err := c.client.GetEventDataStore(ctx, &cloudtrail.GetEventDataStoreInput{
+		EventDataStore: store.EventDataStoreArn,
+	})
+	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return scanner.WithARN([]scanner.Finding{
+				c.accessDeniedFinding("cloudtrail_lake_encryption", name, err),
+				c.accessDeniedFinding("cloudtrail_lake_retention", name, err),
+			}, storeARN)
+		}
+		return nil
+	}
+
+	var findings []scanner.Finding
+	findings = append(findings, c.checkLakeEncryption(out)...)
+	findings = append(findings, c.checkLakeRetention(out)...)
+	return scanner.WithARN(findings, storeARN)
+}
+
+// retentionDays returns the configured minimum retention, falling back to the default.
+func (c *Scanner) retentionDays() int {
+	if c.minRetentionDays > 0 {
+		return c.minRetentionDays
+	}
+	return minRetentionDays
+}
+
+// listTrails returns the trails whose home region is the scanned region, so
+// multi-region trails are evaluated once rather than in every region.
+func (c *Scanner) listTrails(ctx context.Context) ([]types.Trail, error) {
+	output, err := c.client.DescribeTrails(ctx, &cloudtrail.DescribeTrailsInput{
+		IncludeShadowTrails: aws.Bool(false),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var trails []types.Trail
+	for _, trail := range output.TrailList {
+		if home := aws.ToString(trail.HomeRegion); home == "" || home == c.region {
+			trails = append(trails, trail)
+		}
+	}
+	return trails, nil
+}
+
+// listEventDataStores returns event data stores that are not pending deletion.
+func (c *Scanner) listEventDataStores(ctx context.Context) ([]types.EventDataStore, error) {
+	var stores []types.EventDataStore
+	paginator := cloudtrail.NewListEventDataStoresPaginator(c.client, &cloudtrail.ListEventDataStoresInput{})
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, store := range output.EventDataStores {
+			if store.Status == types.EventDataStoreStatusPendingDeletion {
+				continue
+			}
+			stores = append(stores, store)
+		}
+	}
+	return stores, nil
+}
+
+func (c *Scanner) createFinding(checkID, resourceID, title, description string, status scanner.FindingStatus, severity scanner.Severity) scanner.Finding {
+	return scanner.Finding{
+		FindingID:   scanner.FindingID(c.accountID, c.Service(), c.region, checkID, resourceID),
+		Service:     c.Service(),
+		Region:      c.region,
+		ResourceID:  resourceID,
+		ARN:         c.resourceARN(checkID, resourceID),
+		CheckID:     checkID,
+		Status:      status,
+		Severity:    severity,
+		Confidence:  scanner.ConfidenceFor(checkID),
+		Title:       title,
+		Description: description,
+		Compliance:  checks.Compliance(checkID),
+		Timestamp:   time.Now(),
+	}
+}
+
+// resourceARN returns the ARN of the named trail. Event data store ARNs end
+// in an ID that CloudTrail assigns, so Lake findings have their ARN set by
+// checkEventDataStore.
+func (c *Scanner) resourceARN(checkID, trailName string) string {
+	if strings.HasPrefix(checkID, "cloudtrail_lake_") {
+		return ""
+	}
+	return arn.ARN{
+		Partition: awsauth.PartitionForRegion(c.region),
+		Service:   "cloudtrail",
+		Region:    c.region,
+		AccountID: c.accountID,
+		Resource:  "trail/" + trailName,
+	}.String()
+}
+
+// accessDeniedFinding records that checkID could not be evaluated for
+// resourceID because the scan role was denied the underlying API call.
+func (c *Scanner) accessDeniedFinding(checkID, resourceID string, err error) scanner.Finding {
+	return c.createFinding(
+		checkID,
+		resourceID,
+		"Insufficient permissions to evaluate check",
+		scanner.AccessDeniedDescription(err),
+		scanner.StatusError,
+		scanner.SeverityMedium,
+	)
+}
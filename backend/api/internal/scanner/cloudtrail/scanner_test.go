@@ -0,0 +1,227 @@
+package cloudtrail
+
+import (
+	"context"
+	"testing"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail/types"
+)
+
+// fakeCloudTrailClient implements cloudtrailAPI with canned responses.
+// Methods that are not overridden panic via the nil embedded interface.
+type fakeCloudTrailClient struct {
+	cloudtrailAPI
+	trails    []types.Trail
+	selectors map[string]*cloudtrail.GetEventSelectorsOutput
+	stores    []*cloudtrail.GetEventDataStoreOutput
+}
+
+func (f *fakeCloudTrailClient) DescribeTrails(_ context.Context, _ *cloudtrail.DescribeTrailsInput, _ ...func(*cloudtrail.Options)) (*cloudtrail.DescribeTrailsOutput, error) {
+	return &cloudtrail.DescribeTrailsOutput{TrailList: f.trails}, nil
+}
+
+func (f *fakeCloudTrailClient) GetEventSelectors(_ context.Context, params *cloudtrail.GetEventSelectorsInput, _ ...func(*cloudtrail.Options)) (*cloudtrail.GetEventSelectorsOutput, error) {
+	return f.selectors[aws.ToString(params.TrailName)], nil
+}
+
+func (f *fakeCloudTrailClient) ListEventDataStores(_ context.Context, _ *cloudtrail.ListEventDataStoresInput, _ ...func(*cloudtrail.Options)) (*cloudtrail.ListEventDataStoresOutput, error) {
+	out := &cloudtrail.ListEventDataStoresOutput{}
+	for _, store := range f.stores {
+		out.EventDataStores = append(out.EventDataStores, types.EventDataStore{
+			EventDataStoreArn: store.EventDataStoreArn,
+			Name:              store.Name,
+			Status:            store.Status,
+		})
+	}
+	return out, nil
+}
+
+func (f *fakeCloudTrailClient) GetEventDataStore(_ context.Context, params *cloudtrail.GetEventDataStoreInput, _ ...func(*cloudtrail.Options)) (*cloudtrail.GetEventDataStoreOutput, error) {
+	for _, store := range f.stores {
+		if aws.ToString(store.EventDataStoreArn) == aws.ToString(params.EventDataStore) {
+			return store, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestNewScanner(t *testing.T) {
+	s := NewScanner(aws.Config{Region: "us-east-1"}, "us-east-1", "123456789012")
+
+	cs, ok := s.(*Scanner)
+	if !ok {
+		t.Fatal("NewScanner did not return *Scanner type")
+	}
+	if cs.region != "us-east-1" || cs.accountID != "123456789012" || cs.client == nil {
+		t.Errorf("unexpected scanner: %+v", cs)
+	}
+	if got := cs.Service(); got != "cloudtrail" {
+		t.Errorf("Service() = %s, want cloudtrail", got)
+	}
+}
+
+func TestSelectorCoverage(t *testing.T) {
+	s3Objects := []types.DataResource{{Type: aws.String("AWS::S3::Object"), Values: []string{"arn:aws:s3"}}}
+	category := func(values ...string) types.AdvancedEventSelector {
+		return types.AdvancedEventSelector{FieldSelectors: []types.AdvancedFieldSelector{
+			{Field: aws.String("eventCategory"), Equals: values},
+		}}
+	}
+
+	tests := []struct {
+		name string
+		out  *cloudtrail.GetEventSelectorsOutput
+		want eventCoverage
+	}{
+		{
+			name: "default selector",
+			out: &cloudtrail.GetEventSelectorsOutput{EventSelectors: []types.EventSelector{
+				{ReadWriteType: types.ReadWriteTypeAll, IncludeManagementEvents: aws.Bool(true)},
+			}},
+			want: eventCoverage{management: true},
+		},
+		{
+			name: "management and S3 data events",
+			out: &cloudtrail.GetEventSelectorsOutput{EventSelectors: []types.EventSelector{
+				{ReadWriteType: types.ReadWriteTypeAll, IncludeManagementEvents: aws.Bool(true), DataResources: s3Objects},
+			}},
+			want: eventCoverage{management: true, data: true},
+		},
+		{
+			name: "write-only management events",
+			out: &cloudtrail.GetEventSelectorsOutput{EventSelectors: []types.EventSelector{
+				{ReadWriteType: types.ReadWriteTypeWriteOnly, IncludeManagementEvents: aws.Bool(true), DataResources: s3Objects},
+			}},
+			want: eventCoverage{data: true},
+		},
+		{
+			name: "data events only",
+			out: &cloudtrail.GetEventSelectorsOutput{EventSelectors: []types.EventSelector{
+				{ReadWriteType: types.ReadWriteTypeAll, IncludeManagementEvents: aws.Bool(false), DataResources: s3Objects},
+			}},
+			want: eventCoverage{data: true},
+		},
+		{
+			name: "advanced selectors for both",
+			out: &cloudtrail.GetEventSelectorsOutput{AdvancedEventSelectors: []types.AdvancedEventSelector{
+				category("Management"),
+				category("Data"),
+			}},
+			want: eventCoverage{management: true, data: true},
+		},
+		{
+			name: "advanced management only",
+			out: &cloudtrail.GetEventSelectorsOutput{AdvancedEventSelectors: []types.AdvancedEventSelector{
+				category("Management"),
+			}},
+			want: eventCoverage{management: true},
+		},
+		{name: "no selectors", out: &cloudtrail.GetEventSelectorsOutput{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := selectorCoverage(tt.out); got != tt.want {
+				t.Errorf("selectorCoverage() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckLakeEncryption(t *testing.T) {
+	s := &Scanner{region: "us-east-1", accountID: "123456789012"}
+
+	tests := []struct {
+		name     string
+		kmsKeyID *string
+		want     scanner.FindingStatus
+	}{
+		{"customer managed key", aws.String("arn:aws:kms:us-east-1:123456789012:key/abcd"), scanner.StatusPass},
+		{"AWS owned key", nil, scanner.StatusFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := s.checkLakeEncryption(&cloudtrail.GetEventDataStoreOutput{Name: aws.String("audit"), KmsKeyId: tt.kmsKeyID})
+
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			f := findings[0]
+			if f.CheckID != "cloudtrail_lake_encryption" || f.Status != tt.want || f.Severity != scanner.SeverityMedium {
+				t.Errorf("got %s %s/%s, want cloudtrail_lake_encryption %s/MEDIUM", f.CheckID, f.Status, f.Severity, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanner_Scan(t *testing.T) {
+	client := &fakeCloudTrailClient{
+		trails: []types.Trail{
+			{Name: aws.String("org-trail"), TrailARN: aws.String("arn:trail/org-trail"), HomeRegion: aws.String("us-east-1")},
+			{Name: aws.String("mgmt-only"), TrailARN: aws.String("arn:trail/mgmt-only"), HomeRegion: aws.String("us-east-1")},
+			{Name: aws.String("eu-trail"), TrailARN: aws.String("arn:trail/eu-trail"), HomeRegion: aws.String("eu-west-1")},
+		},
+		selectors: map[string]*cloudtrail.GetEventSelectorsOutput{
+			"arn:trail/org-trail": {EventSelectors: []types.EventSelector{{
+				ReadWriteType:           types.ReadWriteTypeAll,
+				IncludeManagementEvents: aws.Bool(true),
+				DataResources:           []types.DataResource{{Type: aws.String("AWS::S3::Object"), Values: []string{"arn:aws:s3"}}},
+			}}},
+			"arn:trail/mgmt-only": {EventSelectors: []types.EventSelector{{
+				ReadWriteType:           types.ReadWriteTypeAll,
+				IncludeManagementEvents: aws.Bool(true),
+			}}},
+		},
+		stores: []*cloudtrail.GetEventDataStoreOutput{
+			{EventDataStoreArn: aws.String("arn:eds/audit"), Name: aws.String("audit"), KmsKeyId: aws.String("key-1"), RetentionPeriod: aws.Int32(2557), Status: types.EventDataStoreStatusEnabled},
+			{EventDataStoreArn: aws.String("arn:eds/scratch"), Name: aws.String("scratch"), RetentionPeriod: aws.Int32(90), Status: types.EventDataStoreStatusEnabled},
+			{EventDataStoreArn: aws.String("arn:eds/old"), Name: aws.String("old"), Status: types.EventDataStoreStatusPendingDeletion},
+		},
+	}
+	s := &Scanner{client: client, region: "us-east-1", accountID: "123456789012"}
+
+	findings, err := s.Scan(context.Background(), "us-east-1")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	got := make(map[string]scanner.FindingStatus)
+	arns := make(map[string]string)
+	for _, f := range findings {
+		got[f.ResourceID+"/"+f.CheckID] = f.Status
+		arns[f.ResourceID] = f.ARN
+	}
+	want := map[string]scanner.FindingStatus{
+		"org-trail/cloudtrail_data_events":   scanner.StatusPass,
+		"mgmt-only/cloudtrail_data_events":   scanner.StatusFail,
+		"audit/cloudtrail_lake_encryption":   scanner.StatusPass,
+		"audit/cloudtrail_lake_retention":    scanner.StatusPass,
+		"scratch/cloudtrail_lake_encryption": scanner.StatusFail,
+		"scratch/cloudtrail_lake_retention":  scanner.StatusFail,
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %d findings %v, want %d", len(got), got, len(want))
+	}
+	for key, status := range want {
+		if got[key] != status {
+			t.Errorf("%s = %q, want %s", key, got[key], status)
+		}
+	}
+
+	// Trail ARNs are built from the name; event data store ARNs come from
+	// the store.
+	wantARNs := map[string]string{
+		"org-trail": "arn:aws:cloudtrail:us-east-1:123456789012:trail/org-trail",
+		"audit":     "arn:eds/audit",
+	}
+	for resource, want := range wantARNs {
+		if arns[resource] != want {
+			t.Errorf("%s ARN = %q, want %q", resource, arns[resource], want)
+		}
+	}
+}
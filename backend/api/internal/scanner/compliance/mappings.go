@@ -1,6 +1,11 @@
 // Package compliance provides compliance framework mappings for security checks.
 package compliance
 
+import (
+	"maps"
+	"slices"
+)
+
 // Framework represents a compliance framework.
 type Framework string
 
@@ -20,47 +25,63 @@ const (
 // checkMappings maps check IDs to their compliance framework requirements.
 var checkMappings = map[string][]string{
 	// S3 Checks
-	"s3_bucket_public_access": {"CIS-2.1.5", "SOC2-CC6.1", "NIST-AC-3", "PCI-DSS-1.3"},
-	"s3_bucket_policy_public": {"CIS-2.1.5", "SOC2-CC6.1", "NIST-AC-3", "PCI-DSS-1.3"},
-	"s3_bucket_encryption":    {"CIS-2.1.1", "SOC2-CC6.1", "NIST-SC-13", "PCI-DSS-3.4", "GDPR-32"},
-	"s3_bucket_versioning":    {"CIS-2.1.3", "SOC2-CC6.1", "NIST-CP-9"},
-	"s3_bucket_logging":       {"CIS-2.1.2", "SOC2-CC7.2", "NIST-AU-2", "PCI-DSS-10.1"},
-	"s3_block_public_access":  {"CIS-2.1.4", "SOC2-CC6.1", "NIST-AC-3", "PCI-DSS-1.3"},
-	"s3_mfa_delete":           {"CIS-2.1.3", "SOC2-CC6.1", "NIST-IA-2"},
-	"s3_lifecycle_policy":     {"SOC2-CC6.1", "NIST-SI-12"},
-	"s3_ssl_only":             {"CIS-2.1.2", "SOC2-CC6.7", "NIST-SC-8", "PCI-DSS-4.1"},
-	"s3_object_lock":          {"SOC2-CC6.1", "NIST-CP-9"},
+	"s3_bucket_public_access":       {"CIS-2.1.5", "SOC2-CC6.1", "NIST-AC-3", "PCI-DSS-1.3"},
+	"s3_bucket_policy_public":       {"CIS-2.1.5", "SOC2-CC6.1", "NIST-AC-3", "PCI-DSS-1.3"},
+	"s3_bucket_policy_overbroad":    {"SOC2-CC6.1", "NIST-AC-3", "NIST-AC-6", "PCI-DSS-7.1"},
+	"s3_bucket_encryption":          {"CIS-2.1.1", "SOC2-CC6.1", "NIST-SC-13", "PCI-DSS-3.4", "GDPR-32"},
+	"s3_bucket_key":                 {"NIST-SC-12"},
+	"s3_bucket_versioning":          {"CIS-2.1.3", "SOC2-CC6.1", "NIST-CP-9"},
+	"s3_bucket_logging":             {"CIS-2.1.2", "SOC2-CC7.2", "NIST-AU-2", "PCI-DSS-10.1"},
+	"s3_block_public_access":        {"CIS-2.1.4", "SOC2-CC6.1", "NIST-AC-3", "PCI-DSS-1.3"},
+	"s3_mfa_delete":                 {"CIS-2.1.3", "SOC2-CC6.1", "NIST-IA-2"},
+	"s3_lifecycle_policy":           {"SOC2-CC6.1", "NIST-SI-12"},
+	"s3_ssl_only":                   {"CIS-2.1.2", "SOC2-CC6.7", "NIST-SC-8", "PCI-DSS-4.1"},
+	"s3_object_lock":                {"SOC2-CC6.1", "NIST-CP-9"},
+	"s3_static_website":             {"SOC2-CC6.1", "NIST-AC-3", "PCI-DSS-1.3"},
+	"s3_replication":                {"SOC2-A1.2", "NIST-CP-9", "NIST-CP-6"},
+	"s3_data_residency":             {"SOC2-CC6.1", "GDPR-44"},
+	"s3_notification_cross_account": {"SOC2-CC6.1", "NIST-AC-4"},
 
 	// EC2 Checks
-	"ec2_sg_unrestricted_ingress": {"CIS-5.1", "SOC2-CC6.1", "NIST-AC-4", "PCI-DSS-1.2"},
-	"ec2_sg_dangerous_ports":      {"CIS-5.2", "SOC2-CC6.1", "NIST-AC-4", "PCI-DSS-1.2"},
-	"ec2_imdsv2_required":         {"CIS-5.6", "SOC2-CC6.1", "NIST-AC-3"},
-	"ec2_ebs_encryption":          {"CIS-2.2.1", "SOC2-CC6.1", "NIST-SC-28", "PCI-DSS-3.4", "GDPR-32"},
-	"ec2_public_ip":               {"SOC2-CC6.1", "NIST-AC-4"},
-	"ec2_cloudwatch_monitoring":   {"CIS-4.1", "SOC2-CC7.2", "NIST-AU-2"},
-	"ec2_detailed_monitoring":     {"SOC2-CC7.2", "NIST-AU-6"},
-	"ec2_iam_role":                {"CIS-4.2", "SOC2-CC6.3", "NIST-AC-6"},
-	"ec2_unassociated_eip":        {"SOC2-CC6.1", "NIST-CM-8"},
-	"ec2_unused_sg_rules":         {"SOC2-CC6.1", "NIST-CM-2"},
-	"ec2_vpc_flow_logs":           {"CIS-3.7", "SOC2-CC7.2", "NIST-AU-2", "PCI-DSS-10.1"},
-	"ec2_imdsv1_usage":            {"CIS-5.6", "SOC2-CC6.1", "NIST-AC-3"},
+	"ec2_sg_unrestricted_ingress":  {"CIS-5.1", "SOC2-CC6.1", "NIST-AC-4", "PCI-DSS-1.2"},
+	"ec2_instance_sg_unrestricted": {"CIS-5.1", "SOC2-CC6.1", "NIST-AC-4", "PCI-DSS-1.2"},
+	"ec2_sg_dangerous_ports":       {"CIS-5.2", "SOC2-CC6.1", "NIST-AC-4", "PCI-DSS-1.2"},
+	"ec2_imdsv2_required":          {"CIS-5.6", "SOC2-CC6.1", "NIST-AC-3"},
+	"ec2_ebs_encryption":           {"CIS-2.2.1", "SOC2-CC6.1", "NIST-SC-28", "PCI-DSS-3.4", "GDPR-32"},
+	"ec2_public_ip":                {"SOC2-CC6.1", "NIST-AC-4"},
+	"ec2_public_subnet":            {"SOC2-CC6.1", "PCI-DSS-1.3", "NIST-AC-4"},
+	"ec2_cloudwatch_monitoring":    {"CIS-4.1", "SOC2-CC7.2", "NIST-AU-2"},
+	"ec2_detailed_monitoring":      {"SOC2-CC7.2", "NIST-AU-6"},
+	"ec2_iam_role":                 {"CIS-4.2", "SOC2-CC6.3", "NIST-AC-6"},
+	"ec2_termination_protection":   {"SOC2-CC7.1", "NIST-CP-10"},
+	"ec2_long_stopped":             {"SOC2-CC6.1", "NIST-CM-8"},
+	"ec2_unassociated_eip":         {"SOC2-CC6.1", "NIST-CM-8"},
+	"ec2_unused_sg_rules":          {"SOC2-CC6.1", "NIST-CM-2"},
+	"ec2_unused_sg":                {"SOC2-CC6.1", "NIST-CM-2"},
+	"ec2_default_sg_in_use":        {"CIS-5.4", "SOC2-CC6.1", "NIST-AC-4", "PCI-DSS-1.2"},
+	"ec2_default_sg_rules":         {"CIS-5.4", "SOC2-CC6.1", "NIST-AC-4", "PCI-DSS-1.2"},
+	"ec2_vpc_flow_logs":            {"CIS-3.7", "SOC2-CC7.2", "NIST-AU-2", "PCI-DSS-10.1"},
+	"ec2_imdsv1_usage":             {"CIS-5.6", "SOC2-CC6.1", "NIST-AC-3"},
+	"ec2_snapshot_encryption":      {"CIS-2.2.1", "SOC2-CC6.1", "NIST-SC-28", "PCI-DSS-3.4", "GDPR-32"},
+	"ec2_snapshot_public":          {"SOC2-CC6.1", "NIST-AC-3", "NIST-AC-21", "PCI-DSS-1.3", "GDPR-32"},
 
 	// IAM Checks
-	"iam_unused_access_keys":   {"CIS-1.12", "SOC2-CC6.1", "NIST-AC-2"},
-	"iam_access_key_rotation":  {"CIS-1.14", "SOC2-CC6.1", "NIST-IA-5", "PCI-DSS-8.2"},
-	"iam_root_usage":           {"CIS-1.7", "SOC2-CC6.1", "NIST-AC-6", "PCI-DSS-8.1"},
-	"iam_user_mfa":             {"CIS-1.10", "SOC2-CC6.1", "NIST-IA-2", "PCI-DSS-8.3"},
-	"iam_root_mfa":             {"CIS-1.5", "SOC2-CC6.1", "NIST-IA-2", "PCI-DSS-8.3"},
-	"iam_overly_permissive":    {"CIS-1.16", "SOC2-CC6.1", "NIST-AC-6", "PCI-DSS-7.1"},
-	"iam_privilege_escalation": {"SOC2-CC6.1", "NIST-AC-6"},
-	"iam_password_policy":      {"CIS-1.8", "SOC2-CC6.1", "NIST-IA-5", "PCI-DSS-8.2"},
-	"iam_unused_users":         {"CIS-1.12", "SOC2-CC6.1", "NIST-AC-2"},
-	"iam_inline_policies":      {"CIS-1.16", "SOC2-CC6.1", "NIST-AC-6"},
-	"iam_cross_account_trust":  {"SOC2-CC6.1", "NIST-AC-4"},
-	"iam_service_role_trust":   {"SOC2-CC6.1", "NIST-AC-6"},
-	"iam_admin_access_users":   {"CIS-1.16", "SOC2-CC6.1", "NIST-AC-6", "PCI-DSS-7.1"},
-	"iam_not_action":           {"SOC2-CC6.1", "NIST-AC-6"},
-	"iam_console_without_mfa":  {"CIS-1.10", "SOC2-CC6.1", "NIST-IA-2", "PCI-DSS-8.3"},
+	"iam_unused_access_keys":        {"CIS-1.12", "SOC2-CC6.1", "NIST-AC-2"},
+	"iam_access_key_rotation":       {"CIS-1.14", "SOC2-CC6.1", "NIST-IA-5", "PCI-DSS-8.2"},
+	"iam_root_usage":                {"CIS-1.7", "SOC2-CC6.1", "NIST-AC-6", "PCI-DSS-8.1"},
+	"iam_user_mfa":                  {"CIS-1.10", "SOC2-CC6.1", "NIST-IA-2", "PCI-DSS-8.3"},
+	"iam_root_mfa":                  {"CIS-1.5", "SOC2-CC6.1", "NIST-IA-2", "PCI-DSS-8.3"},
+	"iam_overly_permissive":         {"CIS-1.16", "SOC2-CC6.1", "NIST-AC-6", "PCI-DSS-7.1"},
+	"iam_privilege_escalation":      {"SOC2-CC6.1", "NIST-AC-6"},
+	"iam_password_policy":           {"CIS-1.8", "SOC2-CC6.1", "NIST-IA-5", "PCI-DSS-8.2"},
+	"iam_unused_users":              {"CIS-1.12", "SOC2-CC6.1", "NIST-AC-2"},
+	"iam_inline_policies":           {"CIS-1.16", "SOC2-CC6.1", "NIST-AC-6"},
+	"iam_cross_account_trust":       {"SOC2-CC6.1", "NIST-AC-4"},
+	"iam_service_role_trust":        {"SOC2-CC6.1", "NIST-AC-6"},
+	"iam_admin_access_users":        {"CIS-1.16", "SOC2-CC6.1", "NIST-AC-6", "PCI-DSS-7.1"},
+	"iam_not_action":                {"SOC2-CC6.1", "NIST-AC-6"},
+	"iam_console_without_mfa":       {"CIS-1.10", "SOC2-CC6.1", "NIST-IA-2", "PCI-DSS-8.3"},
+	"iam_scan_role_least_privilege": {"SOC2-CC6.3", "NIST-AC-6"},
 
 	// Lambda Checks
 	"lambda_env_secrets":          {"SOC2-CC6.1", "NIST-SC-28", "PCI-DSS-3.4", "GDPR-32"},
@@ -71,6 +92,8 @@ var checkMappings = map[string][]string{
 	"lambda_tracing":              {"SOC2-CC7.2", "NIST-AU-6"},
 	"lambda_reserved_concurrency": {"SOC2-CC6.1", "NIST-SC-5"},
 	"lambda_timeout":              {"SOC2-CC7.1", "NIST-SI-2"},
+	"lambda_public_url":           {"SOC2-CC6.1", "NIST-AC-3", "PCI-DSS-1.3"},
+	"lambda_public_permission":    {"SOC2-CC6.1", "NIST-AC-3", "PCI-DSS-1.3"},
 
 	// ECS Checks
 	"ecs_privileged_container": {"CIS-5.1", "SOC2-CC6.1", "NIST-AC-6"},
@@ -89,6 +112,38 @@ var checkMappings = map[string][]string{
 	"dynamodb_ttl":          {"GDPR-17", "NIST-SI-12"},
 	"dynamodb_auto_scaling": {"SOC2-CC7.1", "NIST-CP-10"},
 	"dynamodb_vpc_endpoint": {"SOC2-CC6.1", "NIST-AC-4"},
+
+	// KMS Checks
+	"kms_broad_grant": {"SOC2-CC6.1", "NIST-AC-6", "PCI-DSS-3.5"},
+
+	// EKS Checks
+	"eks_irsa_configured":       {"SOC2-CC6.3", "NIST-AC-6"},
+	"eks_private_endpoint":      {"SOC2-CC6.1", "NIST-AC-4", "PCI-DSS-1.3"},
+	"eks_control_plane_logging": {"SOC2-CC7.2", "NIST-AU-2", "PCI-DSS-10.1"},
+	"eks_secrets_encryption":    {"SOC2-CC6.1", "NIST-SC-28", "PCI-DSS-3.4", "GDPR-32"},
+	"eks_version_supported":     {"SOC2-CC7.1", "NIST-SI-2"},
+	"eks_node_imdsv2":           {"CIS-5.6", "SOC2-CC6.1", "NIST-AC-3"},
+
+	// ELB Checks
+	"elb_https_listener":      {"SOC2-CC6.7", "NIST-SC-8", "PCI-DSS-4.1"},
+	"elb_tls_policy":          {"SOC2-CC6.7", "NIST-SC-8", "PCI-DSS-4.1"},
+	"elb_access_logs":         {"SOC2-CC7.2", "NIST-AU-2", "PCI-DSS-10.1"},
+	"elb_deletion_protection": {"SOC2-CC7.1", "NIST-CP-10"},
+
+	// CloudTrail Checks
+	"cloudtrail_data_events":     {"CIS-3.8", "SOC2-CC7.2", "NIST-AU-2", "PCI-DSS-10.2"},
+	"cloudtrail_lake_encryption": {"CIS-3.5", "SOC2-CC6.1", "NIST-AU-9", "PCI-DSS-10.5"},
+	"cloudtrail_lake_retention":  {"SOC2-CC7.2", "NIST-AU-11", "PCI-DSS-10.7"},
+
+	// OpenSearch Checks
+	"opensearch_encryption_at_rest":      {"SOC2-CC6.1", "NIST-SC-28", "PCI-DSS-3.4", "GDPR-32"},
+	"opensearch_node_to_node_encryption": {"SOC2-CC6.7", "NIST-SC-8", "PCI-DSS-4.1"},
+	"opensearch_https_enforced":          {"SOC2-CC6.7", "NIST-SC-8", "PCI-DSS-4.1"},
+	"opensearch_vpc_only":                {"SOC2-CC6.1", "NIST-AC-4", "PCI-DSS-1.3"},
+	"opensearch_access_policy_public":    {"SOC2-CC6.1", "NIST-AC-3", "PCI-DSS-1.3"},
+
+	// WAF Checks
+	"waf_not_associated": {"SOC2-CC6.6", "NIST-SC-7", "PCI-DSS-6.6"},
 }
 
 // GetCompliance returns a copy of the compliance framework codes associated with the given check ID.
@@ -103,3 +158,8 @@ func GetCompliance(checkID string) []string {
 	}
 	return []string{}
 }
+
+// CheckIDs returns every mapped check ID in sorted order.
+func CheckIDs() []string {
+	return slices.Sorted(maps.Keys(checkMappings))
+}
@@ -0,0 +1,20 @@
+package scanner
+
+// checkConfidence lists checks whose detection logic is heuristic.
+// Checks not listed evaluate deterministic resource configuration.
+var checkConfidence = map[string]Confidence{
+	// Substring matching on variable names cannot tell a secret from a key ID or public key
+	"lambda_env_secrets": ConfidenceMedium,
+	"ecs_secrets_in_env": ConfidenceMedium,
+	// Registry detection is inferred from the image reference format
+	"ecs_public_registry": ConfidenceLow,
+}
+
+// ConfidenceFor returns the confidence level for the given check ID.
+// Checks without an explicit entry default to ConfidenceHigh.
+func ConfidenceFor(checkID string) Confidence {
+	if c, ok := checkConfidence[checkID]; ok {
+		return c
+	}
+	return ConfidenceHigh
+}
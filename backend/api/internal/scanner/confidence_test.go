@@ -0,0 +1,24 @@
+package scanner
+
+import "testing"
+
+func TestConfidenceFor(t *testing.T) {
+	tests := []struct {
+		checkID string
+		want    Confidence
+	}{
+		{"lambda_env_secrets", ConfidenceMedium},
+		{"ecs_secrets_in_env", ConfidenceMedium},
+		{"ecs_public_registry", ConfidenceLow},
+		{"s3_bucket_encryption", ConfidenceHigh},
+		{"unknown_check", ConfidenceHigh},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.checkID, func(t *testing.T) {
+			if got := ConfidenceFor(tt.checkID); got != tt.want {
+				t.Errorf("ConfidenceFor(%q) = %v, want %v", tt.checkID, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,43 +2,96 @@
 package scanner
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"cloudcop/api/internal/awsauth"
+
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/singleflight"
 )
 
+// defaultMaxWorkers limits concurrent scans to prevent overwhelming APIs.
+const defaultMaxWorkers = 10
+
+// streamFlushInterval is the longest StreamFindings buffers output while
+// findings keep arriving.
+const streamFlushInterval = time.Second
+
+// Factory builds a ServiceScanner for the given AWS config, region, and account ID.
+type Factory func(cfg aws.Config, region, accountID string) ServiceScanner
+
 // Coordinator orchestrates parallel scanning across regions and services.
 type Coordinator struct {
 	cfg       aws.Config
 	accountID string
-	scanners  map[string]func(aws.Config, string, string) ServiceScanner
+	scanners  map[string]Factory
+	cache     *ResourceCache
+	tracer    trace.Tracer
+	endpoints EndpointOptions
+	// listRegions returns the regions to scan when a ScanConfig names none.
+	listRegions func(ctx context.Context, cfg aws.Config) []string
 }
 
 // NewCoordinator creates a new scan coordinator with an initialized scanner factory registry.
 func NewCoordinator(cfg aws.Config, accountID string) *Coordinator {
 	return &Coordinator{
-		cfg:       cfg,
-		accountID: accountID,
-		scanners:  make(map[string]func(aws.Config, string, string) ServiceScanner),
+		cfg:         cfg,
+		accountID:   accountID,
+		scanners:    make(map[string]Factory),
+		tracer:      noop.NewTracerProvider().Tracer(TracerName),
+		listRegions: GetEnabledRegions,
 	}
 }
 
 // RegisterScanner registers a scanner factory for a service.
-func (c *Coordinator) RegisterScanner(service string, factory func(aws.Config, string, string) ServiceScanner) {
+func (c *Coordinator) RegisterScanner(service string, factory Factory) {
 	c.scanners[service] = factory
 }
 
+// UseResourceCache configures the resource cache shared by subsequent scans,
+// replacing any existing cache. Disabled options turn caching off.
+func (c *Coordinator) UseResourceCache(opts CacheOptions) {
+	c.cache = NewResourceCache(opts)
+}
+
+// ForAccount returns a coordinator that scans accountID with cfg, sharing this
+// coordinator's registered scanners, resource cache, tracer, and endpoint
+// options. Scanners must be registered before calling ForAccount.
+func (c *Coordinator) ForAccount(cfg aws.Config, accountID string) *Coordinator {
+	return &Coordinator{
+		cfg:         cfg,
+		accountID:   accountID,
+		scanners:    c.scanners,
+		cache:       c.cache,
+		tracer:      c.tracer,
+		endpoints:   c.endpoints,
+		listRegions: c.listRegions,
+	}
+}
+
 // ScanTask represents a single scan task for a service/region combination.
 type ScanTask struct {
 	Service string
 	Region  string
+	Scope   Scope
+	// Since and Prior are set for incremental scans: resources unmodified
+	// since Since may be skipped, carrying forward their findings in Prior.
+	Since time.Time
+	Prior []Finding
 }
 
 // ScanTaskResult holds the result of a single scan task.
@@ -46,137 +99,399 @@ type ScanTaskResult struct {
 	Task     ScanTask
 	Findings []Finding
 	Error    error
+	// Resources is the number of resources the scanner enumerated through
+	// CountResources; ResourcesCounted is false if it reported none.
+	Resources        int
+	ResourcesCounted bool
 }
 
 // StartScan executes security scans across the specified regions and services.
+// The scan runs under the scan ID in ctx, or a new one if ctx has none; it is
+// available to scanners through ScanIDFromContext and stamped on the result
+// and every finding.
 func (c *Coordinator) StartScan(ctx context.Context, config ScanConfig) (*ScanResult, error) {
 	startedAt := time.Now().UTC()
 
-	var tasks []ScanTask
-	for _, region := range config.Regions {
-		for _, service := range config.Services {
-			if _, exists := c.scanners[service]; exists {
-				tasks = append(tasks, ScanTask{Service: service, Region: region})
-			} else {
-				log.Printf("Warning: No scanner registered for service %s", service)
-			}
-		}
+	ctx, scanID := ensureScanID(ctx)
+	ctx, span := c.tracer.Start(ctx, "scan", trace.WithAttributes(
+		attrAccountID.String(config.AccountID),
+		attrScanID.String(scanID),
+	))
+	defer span.End()
+
+	var calls *apiCallCounter
+	if config.TrackAPICalls {
+		calls = newAPICallCounter()
+		ctx = withAPICallCounter(ctx, calls)
 	}
 
-	if len(tasks) == 0 {
-		return nil, fmt.Errorf("no valid scan tasks: check that services have registered scanners")
+	config = c.withDefaultRegions(ctx, config)
+	tasks, err := c.scanTasks(ctx, config)
+	if err != nil {
+		return nil, err
 	}
 
-	results := c.executeParallel(ctx, tasks)
+	results := c.executeParallel(ctx, tasks, config)
 
 	var allFindings []Finding
-	var scanErrors []error
+	var scanErrors []ScanError
+	coverage := make([]ServiceCoverage, 0, len(results))
 
 	for _, result := range results {
+		coverage = append(coverage, coverageFor(result))
 		if result.Error != nil {
-			scanErrors = append(scanErrors, fmt.Errorf("%s/%s: %w", result.Task.Service, result.Task.Region, result.Error))
+			scanErrors = append(scanErrors, ScanError{
+				Service: result.Task.Service,
+				Region:  result.Task.Region,
+				Type:    ClassifyError(result.Error),
+				Message: redact(result.Error.Error()),
+			})
 			continue
 		}
 		allFindings = append(allFindings, result.Findings...)
 	}
+	for i := range allFindings {
+		allFindings[i].ScanID = scanID
+	}
+	allFindings = slices.DeleteFunc(allFindings, func(f Finding) bool {
+		return (f.Managed && !config.includeManaged()) || !config.includesCheck(f.CheckID) || config.suppressed(f.FindingID)
+	})
+	applySeverityOverrides(allFindings, config.SeverityOverrides)
+	sort.Slice(coverage, func(i, j int) bool {
+		if coverage[i].Service != coverage[j].Service {
+			return coverage[i].Service < coverage[j].Service
+		}
+		return coverage[i].Region < coverage[j].Region
+	})
 
 	passedChecks := 0
 	failedChecks := 0
+	errorChecks := 0
 	for _, f := range allFindings {
-		if f.Status == StatusPass {
+		switch f.Status {
+		case StatusPass:
 			passedChecks++
-		} else {
+		case StatusError:
+			errorChecks++
+		default:
 			failedChecks++
 		}
 	}
 
+	totalChecks := len(allFindings)
+	if !config.includePassing() {
+		allFindings = slices.DeleteFunc(allFindings, func(f Finding) bool {
+			return f.Status == StatusPass
+		})
+	}
+	allFindings = newFindingCapper(config.MaxFindingsPerCheck, config.AccountID).apply(allFindings)
+
 	// Log any errors (but don't fail the entire scan)
-	for _, err := range scanErrors {
-		log.Printf("Scan error: %v", err)
+	for _, e := range scanErrors {
+		Logf(ctx, "Scan error: %s/%s (%s): %s", e.Service, e.Region, e.Type, e.Message)
 	}
 
+	// Tasks that finished before cancellation keep their findings, so a
+	// cancelled scan still reports partial results instead of failing.
+	cancelled := ctx.Err() != nil
+	if cancelled {
+		Logf(ctx, "Scan cancelled, returning partial results: %v", ctx.Err())
+	}
+
+	span.SetAttributes(attrFindings.Int(len(allFindings)))
+	var apiCalls map[string]int
+	if calls != nil {
+		apiCalls = calls.snapshot()
+	}
 	return &ScanResult{
-		AccountID:    config.AccountID,
-		Regions:      config.Regions,
-		Services:     config.Services,
-		Findings:     allFindings,
-		StartedAt:    startedAt,
-		CompletedAt:  time.Now().UTC(),
-		TotalChecks:  len(allFindings),
-		PassedChecks: passedChecks,
-		FailedChecks: failedChecks,
+		ScanID:        scanID,
+		AccountID:     config.AccountID,
+		ConnectionID:  config.ConnectionID,
+		Regions:       config.Regions,
+		Services:      config.Services,
+		Findings:      allFindings,
+		StartedAt:     startedAt,
+		CompletedAt:   time.Now().UTC(),
+		TotalChecks:   totalChecks,
+		PassedChecks:  passedChecks,
+		FailedChecks:  failedChecks,
+		ErrorChecks:   errorChecks,
+		Coverage:      coverage,
+		Cancelled:     cancelled,
+		Errors:        scanErrors,
+		APICallCounts: apiCalls,
 	}, nil
 }
 
-// executeParallel runs scan tasks concurrently using a bounded worker pool.
-func (c *Coordinator) executeParallel(ctx context.Context, tasks []ScanTask) []ScanTaskResult {
-	const maxWorkers = 10 // Limit concurrent scans to prevent overwhelming APIs
+// StreamScan runs the same scan as StartScan but calls fn with each finding as
+// soon as its service/region task completes, instead of aggregating a
+// ScanResult. Findings arrive in task completion order, and fn is never called
+// concurrently. Managed, check, passing, and severity options apply as in StartScan.
+// With MaxFindingsPerCheck set, the aggregate findings for capped checks are
+// sent after every task has finished.
+// If fn returns an error the scan is stopped and that error is returned.
+// Findings carry the scan ID as in StartScan.
+func (c *Coordinator) StreamScan(ctx context.Context, config ScanConfig, fn func(Finding) error) error {
+	ctx, scanID := ensureScanID(ctx)
+	ctx, span := c.tracer.Start(ctx, "scan", trace.WithAttributes(
+		attrAccountID.String(config.AccountID),
+		attrScanID.String(scanID),
+	))
+	defer span.End()
+
+	config = c.withDefaultRegions(ctx, config)
+	tasks, err := c.scanTasks(ctx, config)
+	if err != nil {
+		return err
+	}
 
-	var wg sync.WaitGroup
-	resultsChan := make(chan ScanTaskResult, len(tasks))
-	tasksChan := make(chan ScanTask, len(tasks))
-
-	for i := 0; i < maxWorkers; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-
-			for task := range tasksChan {
-				select {
-				case <-ctx.Done():
-					resultsChan <- ScanTaskResult{
-						Task:  task,
-						Error: ctx.Err(),
-					}
-					continue
-				default:
-				}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-				result := ScanTaskResult{Task: task}
+	results := c.runTasks(ctx, tasks, config)
+	capper := newFindingCapper(config.MaxFindingsPerCheck, config.AccountID)
+	var streamErr error
+	for result := range results {
+		if streamErr != nil {
+			continue // drain so workers can exit
+		}
+		if result.Error != nil {
+			Logf(ctx, "Scan error: %s/%s (%s): %s", result.Task.Service, result.Task.Region, ClassifyError(result.Error), redact(result.Error.Error()))
+			continue
+		}
+		applySeverityOverrides(result.Findings, config.SeverityOverrides)
+		for _, f := range result.Findings {
+			if (f.Managed && !config.includeManaged()) || !config.includesCheck(f.CheckID) ||
+				config.suppressed(f.FindingID) || (f.Status == StatusPass && !config.includePassing()) {
+				continue
+			}
+			f.ScanID = scanID
+			if !capper.admit(f) {
+				continue
+			}
+			if err := fn(f); err != nil {
+				streamErr = err
+				cancel()
+				break
+			}
+		}
+	}
+	if streamErr != nil {
+		return streamErr
+	}
+	for _, f := range capper.aggregates() {
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-				factory, exists := c.scanners[task.Service]
-				if !exists {
-					result.Error = fmt.Errorf("no scanner registered for service %s", task.Service)
-					resultsChan <- result
-					continue
-				}
+// StreamFindings streams a scan as newline-delimited JSON, writing one
+// Finding object per line as task results arrive so large scans never hold
+// every finding in memory. Output is buffered and flushed at least every
+// streamFlushInterval while findings arrive, and once more when the scan
+// ends; writers that implement Flush(), such as http.ResponseWriter, are
+// flushed along with the buffer. Write errors stop the scan and are returned.
+func (c *Coordinator) StreamFindings(ctx context.Context, config ScanConfig, w io.Writer) error {
+	buf := bufio.NewWriter(w)
+	enc := json.NewEncoder(buf)
+	lastFlush := time.Now()
+
+	flush := func() error {
+		if err := buf.Flush(); err != nil {
+			return fmt.Errorf("flushing findings: %w", err)
+		}
+		if f, ok := w.(interface{ Flush() }); ok {
+			f.Flush()
+		}
+		lastFlush = time.Now()
+		return nil
+	}
 
-				regionalCfg := c.cfg.Copy()
-				regionalCfg.Region = task.Region
+	err := c.StreamScan(ctx, config, func(f Finding) error {
+		if err := enc.Encode(f); err != nil {
+			return fmt.Errorf("writing finding: %w", err)
+		}
+		if time.Since(lastFlush) >= streamFlushInterval {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return flush()
+}
 
-				scanner := factory(regionalCfg, task.Region, c.accountID)
+// withDefaultRegions returns config with the regions enabled for the account
+// filled in when it names none.
+func (c *Coordinator) withDefaultRegions(ctx context.Context, config ScanConfig) ScanConfig {
+	if len(config.Regions) == 0 {
+		config.Regions = c.listRegions(ctx, c.cfg)
+	}
+	return config
+}
 
-				findings, err := scanner.Scan(ctx, task.Region)
-				if err != nil {
-					result.Error = err
-					resultsChan <- result
-					continue
+// scanTasks expands config into one task per region and registered service.
+func (c *Coordinator) scanTasks(ctx context.Context, config ScanConfig) ([]ScanTask, error) {
+	var tasks []ScanTask
+	for _, region := range config.Regions {
+		for _, service := range config.Services {
+			if _, exists := c.scanners[service]; exists {
+				task := ScanTask{Service: service, Region: region, Scope: config.ScopeFor(service)}
+				if !config.IncrementalSince.IsZero() {
+					task.Since = config.IncrementalSince
+					task.Prior = config.priorFindings(service, region)
 				}
-
-				result.Findings = findings
-				resultsChan <- result
+				tasks = append(tasks, task)
+			} else {
+				Logf(ctx, "Warning: No scanner registered for service %s", service)
 			}
-		}()
+		}
 	}
 
-	go func() {
-		for _, task := range tasks {
-			tasksChan <- task
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("no valid scan tasks: check that services have registered scanners")
+	}
+	return tasks, nil
+}
+
+// applySeverityOverrides sets the configured severity on findings whose check
+// ID has an override. Pass/fail counts are unaffected since status is kept.
+func applySeverityOverrides(findings []Finding, overrides map[string]Severity) {
+	if len(overrides) == 0 {
+		return
+	}
+	for i := range findings {
+		if severity, ok := overrides[findings[i].CheckID]; ok {
+			findings[i].Severity = severity
 		}
-		close(tasksChan)
-	}()
+	}
+}
+
+// coverageFor describes the outcome of a single scan task. A task is only
+// empty when its scanner counted zero resources and reported nothing, never
+// merely because it returned no findings.
+func coverageFor(result ScanTaskResult) ServiceCoverage {
+	c := ServiceCoverage{Service: result.Task.Service, Region: result.Task.Region}
+	switch {
+	case result.Error != nil:
+		c.Status = CoverageFailed
+		c.Message = redact(fmt.Sprintf("scan of %s in %s failed: %v", c.Service, c.Region, result.Error))
+	case result.ResourcesCounted && result.Resources == 0 && len(result.Findings) == 0:
+		c.Status = CoverageEmpty
+		c.Message = fmt.Sprintf("0 resources found for service %s in region %s", c.Service, c.Region)
+	case result.ResourcesCounted:
+		c.Status = CoverageScanned
+		c.Message = fmt.Sprintf("%d resources, %d findings for service %s in region %s", result.Resources, len(result.Findings), c.Service, c.Region)
+	default:
+		c.Status = CoverageScanned
+		c.Message = fmt.Sprintf("%d findings for service %s in region %s", len(result.Findings), c.Service, c.Region)
+	}
+	return c
+}
+
+// executeParallel runs scan tasks concurrently and collects their results.
+func (c *Coordinator) executeParallel(ctx context.Context, tasks []ScanTask, config ScanConfig) []ScanTaskResult {
+	var results []ScanTaskResult
+	for result := range c.runTasks(ctx, tasks, config) {
+		results = append(results, result)
+	}
+	return results
+}
+
+// runTasks runs scan tasks region by region and delivers each result as its
+// task completes. Up to config.regionConcurrency() regions are in flight, each
+// running up to config.serviceConcurrency() of its services, and no more than
+// config.maxWorkers() tasks run in total. The channel is closed once every
+// task has reported.
+func (c *Coordinator) runTasks(ctx context.Context, tasks []ScanTask, config ScanConfig) <-chan ScanTaskResult {
+	resultsChan := make(chan ScanTaskResult, len(tasks))
+	workers := make(chan struct{}, config.maxWorkers())
 
 	go func() {
-		wg.Wait()
+		var regions errgroup.Group
+		regions.SetLimit(config.regionConcurrency())
+		for _, regionTasks := range tasksByRegion(tasks) {
+			regions.Go(func() error {
+				var services errgroup.Group
+				services.SetLimit(config.serviceConcurrency())
+				for _, task := range regionTasks {
+					services.Go(func() error {
+						workers <- struct{}{}
+						defer func() { <-workers }()
+
+						if err := ctx.Err(); err != nil {
+							resultsChan <- ScanTaskResult{Task: task, Error: err}
+							return nil
+						}
+						resultsChan <- c.runTask(ctx, task)
+						return nil
+					})
+				}
+				return services.Wait()
+			})
+		}
+		_ = regions.Wait()
 		close(resultsChan)
 	}()
 
-	var results []ScanTaskResult
-	for result := range resultsChan {
-		results = append(results, result)
+	return resultsChan
+}
+
+// tasksByRegion groups tasks by region, keeping regions in the order they
+// first appear.
+func tasksByRegion(tasks []ScanTask) [][]ScanTask {
+	var groups [][]ScanTask
+	index := make(map[string]int)
+	for _, task := range tasks {
+		i, ok := index[task.Region]
+		if !ok {
+			i = len(groups)
+			index[task.Region] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], task)
 	}
+	return groups
+}
 
-	return results
+// runTask scans a single service/region in its own span.
+func (c *Coordinator) runTask(ctx context.Context, task ScanTask) ScanTaskResult {
+	result := ScanTaskResult{Task: task}
+
+	factory, exists := c.scanners[task.Service]
+	if !exists {
+		result.Error = fmt.Errorf("no scanner registered for service %s", task.Service)
+		return result
+	}
+
+	ctx, span := c.tracer.Start(ctx, "scan."+task.Service, trace.WithAttributes(
+		attrService.String(task.Service),
+		attrRegion.String(task.Region),
+	))
+	defer func() { endTaskSpan(span, result) }()
+
+	cfg := c.regionalConfig(task.Service, task.Region)
+	if calls := apiCallCounterFromContext(ctx); calls != nil {
+		cfg = calls.instrument(cfg)
+	}
+	scanner := factory(cfg, task.Region, c.accountID)
+
+	resources := &resourceCounter{}
+	scanCtx := withResourceCounter(WithResourceCache(WithScope(ctx, task.Scope), c.cache), resources)
+	findings, err := scanIncremental(scanCtx, scanner, task)
+	result.Resources, result.ResourcesCounted = resources.total()
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	normalizeFindings(ctx, findings)
+	redactFindings(findings)
+	result.Findings = findings
+	return result
 }
 
 // GetSupportedServices returns the list of services that have registered scanners.
@@ -190,23 +505,32 @@ func (c *Coordinator) GetSupportedServices() []string {
 
 // GetDefaultRegions returns the default AWS regions to scan.
 func GetDefaultRegions() []string {
-	return []string{
-		"us-east-1",
-		"us-east-2",
-		"us-west-1",
-		"us-west-2",
-		"eu-west-1",
-		"eu-west-2",
-		"eu-central-1",
-		"ap-southeast-1",
-		"ap-southeast-2",
-		"ap-northeast-1",
-	}
+	return awsauth.DefaultRegions(awsauth.PartitionAWS)
+}
+
+// regionCacheTTL is how long GetAllRegions reuses a partition's region list
+// before fetching it again, so regions AWS launches are picked up.
+const regionCacheTTL = 24 * time.Hour
+
+// regionCacheEntry is a partition's region list and when it was fetched.
+type regionCacheEntry struct {
+	regions   []string
+	fetchedAt time.Time
 }
 
 var (
-	cachedRegions   []string
-	cachedRegionsMu sync.RWMutex
+	// regionCache holds the GetAllRegions result of each partition. Region
+	// lists differ between partitions, so GovCloud and China accounts must not
+	// be served the commercial list.
+	regionCache   = make(map[string]regionCacheEntry)
+	regionCacheMu sync.RWMutex
+	// regionFetches collapses concurrent fetches for the same partition into
+	// a single DescribeRegions call.
+	regionFetches singleflight.Group
+	// newRegionsClient builds the client regions are fetched with. Tests
+	// replace it to avoid calling EC2.
+	newRegionsClient = func(cfg aws.Config) regionsAPI { return ec2.NewFromConfig(cfg) }
+
 	fallbackRegions = []string{
 		"us-east-1", "us-east-2", "us-west-1", "us-west-2",
 		"af-south-1",
@@ -220,39 +544,98 @@ var (
 	}
 )
 
-// GetAllRegions returns all AWS regions dynamically via EC2 DescribeRegions API.
-// Results are cached after the first successful call. Falls back to a hardcoded
-// list if the API call fails.
+// regionsAPI is the subset of the EC2 client used to list regions.
+type regionsAPI interface {
+	DescribeRegions(ctx context.Context, params *ec2.DescribeRegionsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error)
+}
+
+// enabledOptInStatuses are the DescribeRegions opt-in statuses of regions an
+// account can call. Any other status, such as not-opted-in, means requests to
+// the region fail authorization.
+var enabledOptInStatuses = map[string]bool{
+	"opt-in-not-required": true,
+	"opted-in":            true,
+}
+
+// GetAllRegions returns all AWS regions dynamically via EC2 DescribeRegions API,
+// including opt-in regions the account has not enabled. Use GetEnabledRegions
+// to list only the regions the account can scan.
+// Results are cached per partition for regionCacheTTL. Falls back to a
+// hardcoded list for the partition if the API call fails.
 func GetAllRegions(ctx context.Context, cfg aws.Config) []string {
-	cachedRegionsMu.RLock()
-	if len(cachedRegions) > 0 {
-		// Return a copy to prevent mutation
-		result := make([]string, len(cachedRegions))
-		copy(result, cachedRegions)
-		cachedRegionsMu.RUnlock()
-		return result
+	partition := awsauth.PartitionForRegion(cfg.Region)
+
+	regionCacheMu.RLock()
+	entry, ok := regionCache[partition]
+	regionCacheMu.RUnlock()
+	if ok && time.Since(entry.fetchedAt) < regionCacheTTL {
+		return slices.Clone(entry.regions)
 	}
-	cachedRegionsMu.RUnlock()
 
-	regions, err := fetchRegionsFromEC2(ctx, cfg)
+	regions, err := loadRegions(ctx, cfg, partition)
 	if err != nil {
+		if ok {
+			log.Printf("Failed to refresh regions from EC2 API, using cached list: %v", err)
+			return slices.Clone(entry.regions)
+		}
 		log.Printf("Failed to fetch regions from EC2 API, using fallback: %v", err)
-		return fallbackRegions
+		return fallbackRegionsFor(partition)
+	}
+	return regions
+}
+
+// RefreshRegions fetches the region list of cfg's partition again, replacing
+// the cached one regardless of its age. On error the cached list is kept.
+func RefreshRegions(ctx context.Context, cfg aws.Config) ([]string, error) {
+	return loadRegions(ctx, cfg, awsauth.PartitionForRegion(cfg.Region))
+}
+
+// loadRegions fetches the regions of a partition and caches them. Callers
+// loading the same partition at the same time share one DescribeRegions call.
+func loadRegions(ctx context.Context, cfg aws.Config, partition string) ([]string, error) {
+	v, err, _ := regionFetches.Do(partition, func() (interface{}, error) {
+		regions, err := fetchRegions(ctx, newRegionsClient(cfg), false)
+		if err != nil {
+			return nil, err
+		}
+		regionCacheMu.Lock()
+		regionCache[partition] = regionCacheEntry{regions: regions, fetchedAt: time.Now()}
+		regionCacheMu.Unlock()
+		return regions, nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	// Return a copy so callers can't mutate the cached list.
+	return slices.Clone(v.([]string)), nil
+}
 
-	cachedRegionsMu.Lock()
-	// Store a copy to prevent mutation
-	cachedRegions = make([]string, len(regions))
-	copy(cachedRegions, regions)
-	cachedRegionsMu.Unlock()
+// fallbackRegionsFor returns a copy of the hardcoded region list of a
+// partition. Outside the standard partition every region is a default one.
+func fallbackRegionsFor(partition string) []string {
+	if partition != awsauth.PartitionAWS {
+		return awsauth.DefaultRegions(partition)
+	}
+	return slices.Clone(fallbackRegions)
+}
 
+// GetEnabledRegions returns the regions enabled for the account cfg signs in
+// to: those that need no opt-in and the opt-in regions it has opted in to.
+// Results are not cached since they differ between accounts. Falls back to
+// the default regions of cfg's partition, none of which need opt-in, if the
+// API call fails.
+func GetEnabledRegions(ctx context.Context, cfg aws.Config) []string {
+	regions, err := fetchRegions(ctx, newRegionsClient(cfg), true)
+	if err != nil {
+		Logf(ctx, "Failed to fetch enabled regions from EC2 API, using defaults: %v", err)
+		return awsauth.DefaultRegions(awsauth.PartitionForRegion(cfg.Region))
+	}
 	return regions
 }
 
-// fetchRegionsFromEC2 calls EC2 DescribeRegions API to get all available regions.
-func fetchRegionsFromEC2(ctx context.Context, cfg aws.Config) ([]string, error) {
-	client := ec2.NewFromConfig(cfg)
-
+// fetchRegions calls EC2 DescribeRegions to list every region, keeping only
+// those with an enabled opt-in status when enabledOnly is set.
+func fetchRegions(ctx context.Context, client regionsAPI, enabledOnly bool) ([]string, error) {
 	output, err := client.DescribeRegions(ctx, &ec2.DescribeRegionsInput{
 		AllRegions: aws.Bool(true),
 	})
@@ -265,12 +648,8 @@ func fetchRegionsFromEC2(ctx context.Context, cfg aws.Config) ([]string, error)
 		if region.RegionName == nil {
 			continue
 		}
-		// Skip regions that are not opted-in
-		if region.OptInStatus != nil {
-			status := strings.ToLower(*region.OptInStatus)
-			if status == "not-opted-in" {
-				continue
-			}
+		if enabledOnly && !enabledOptInStatuses[strings.ToLower(aws.ToString(region.OptInStatus))] {
+			continue
 		}
 		regions = append(regions, *region.RegionName)
 	}
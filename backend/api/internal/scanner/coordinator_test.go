@@ -1,12 +1,25 @@
 package scanner
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"cloudcop/api/internal/awsauth"
+
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
 )
 
 // mockScanner implements ServiceScanner for testing
@@ -15,6 +28,8 @@ type mockScanner struct {
 	findings []Finding
 	err      error
 	delay    time.Duration
+	// resources is reported through CountResources unless it is nil.
+	resources *int
 }
 
 func (m *mockScanner) Service() string {
@@ -29,6 +44,9 @@ func (m *mockScanner) Scan(ctx context.Context, _ string) ([]Finding, error) {
 			return nil, ctx.Err()
 		}
 	}
+	if m.resources != nil {
+		CountResources(ctx, *m.resources)
+	}
 	return m.findings, m.err
 }
 
@@ -145,6 +163,342 @@ func TestCoordinator_StartScan_Success(t *testing.T) {
 	}
 }
 
+func TestCoordinator_StartScan_SeverityOverrides(t *testing.T) {
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+	coord.RegisterScanner("s3", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{
+			service: "s3",
+			findings: []Finding{
+				{CheckID: "s3_bucket_encryption", ResourceID: "dev-bucket", Status: StatusFail, Severity: SeverityHigh},
+				{CheckID: "s3_bucket_logging", ResourceID: "dev-bucket", Status: StatusFail, Severity: SeverityMedium},
+				{CheckID: "s3_bucket_versioning", ResourceID: "dev-bucket", Status: StatusPass, Severity: SeverityMedium},
+			},
+		}
+	})
+
+	config := ScanConfig{
+		AccountID: "123456789012",
+		Regions:   []string{"us-east-1"},
+		Services:  []string{"s3"},
+	}
+	baseline, err := coord.StartScan(context.Background(), config)
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+
+	config.SeverityOverrides = map[string]Severity{
+		"s3_bucket_encryption": SeverityLow,
+		"s3_bucket_versioning": SeverityCritical,
+	}
+	result, err := coord.StartScan(context.Background(), config)
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+
+	want := map[string]Severity{
+		"s3_bucket_encryption": SeverityLow,
+		"s3_bucket_logging":    SeverityMedium,
+		"s3_bucket_versioning": SeverityCritical,
+	}
+	for _, f := range result.Findings {
+		if f.Severity != want[f.CheckID] {
+			t.Errorf("%s: Severity = %s, want %s", f.CheckID, f.Severity, want[f.CheckID])
+		}
+	}
+	if result.PassedChecks != 1 || result.FailedChecks != 2 {
+		t.Errorf("got %d passed / %d failed, want 1 / 2", result.PassedChecks, result.FailedChecks)
+	}
+
+	// High+Medium before the override, Low+Medium after; the passed check does not count.
+	if got := RiskScore(CountFailedBySeverity(baseline.Findings)); got != 14 {
+		t.Errorf("baseline risk score = %d, want 14", got)
+	}
+	if got := RiskScore(CountFailedBySeverity(result.Findings)); got != 5 {
+		t.Errorf("overridden risk score = %d, want 5", got)
+	}
+}
+
+func TestCoordinator_StartScan_ExcludePassing(t *testing.T) {
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+	coord.RegisterScanner("s3", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{
+			service: "s3",
+			findings: []Finding{
+				{CheckID: "s3_bucket_encryption", Status: StatusFail, Severity: SeverityHigh},
+				{CheckID: "s3_bucket_logging", Status: StatusPass, Severity: SeverityMedium},
+				{CheckID: "s3_bucket_versioning", Status: StatusPass, Severity: SeverityMedium},
+				{CheckID: "s3_bucket_public_access", Status: StatusError, Severity: SeverityMedium},
+			},
+		}
+	})
+
+	includePassing := false
+	result, err := coord.StartScan(context.Background(), ScanConfig{
+		AccountID:      "123456789012",
+		Regions:        []string{"us-east-1"},
+		Services:       []string{"s3"},
+		IncludePassing: &includePassing,
+	})
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+
+	if len(result.Findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d", len(result.Findings))
+	}
+	for _, f := range result.Findings {
+		if f.Status == StatusPass {
+			t.Errorf("passed finding %s returned", f.CheckID)
+		}
+	}
+	if result.TotalChecks != 4 || result.PassedChecks != 2 || result.FailedChecks != 1 || result.ErrorChecks != 1 {
+		t.Errorf("got %d total / %d passed / %d failed / %d errors, want 4 / 2 / 1 / 1",
+			result.TotalChecks, result.PassedChecks, result.FailedChecks, result.ErrorChecks)
+	}
+}
+
+func TestCoordinator_StartScan_MaxFindingsPerCheck(t *testing.T) {
+	findings := []Finding{{Service: "ec2", CheckID: "ec2_sg_default_deny", Status: StatusPass, Severity: SeverityHigh}}
+	for i := range 100 {
+		findings = append(findings, Finding{
+			Service:    "ec2",
+			Region:     "us-east-1",
+			ResourceID: fmt.Sprintf("sg-%03d", i),
+			CheckID:    "ec2_sg_open_ports",
+			Status:     StatusFail,
+			Severity:   SeverityHigh,
+			Title:      "Security group allows a dangerous port from the internet",
+		})
+	}
+	findings = append(findings, Finding{Service: "ec2", CheckID: "ec2_imdsv2", Status: StatusFail, Severity: SeverityMedium})
+
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+	coord.RegisterScanner("ec2", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{service: "ec2", findings: findings}
+	})
+
+	result, err := coord.StartScan(context.Background(), ScanConfig{
+		AccountID:           "123456789012",
+		Regions:             []string{"us-east-1"},
+		Services:            []string{"ec2"},
+		MaxFindingsPerCheck: 53,
+	})
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+
+	var individual []Finding
+	var aggregate *Finding
+	for _, f := range result.Findings {
+		switch {
+		case f.CheckID != "ec2_sg_open_ports":
+		case f.ResourceID == aggregateResourceID:
+			aggregate = &f
+		default:
+			individual = append(individual, f)
+		}
+	}
+	if len(result.Findings) != 56 || len(individual) != 53 {
+		t.Fatalf("got %d findings with %d individual ec2_sg_open_ports, want 56 with 53", len(result.Findings), len(individual))
+	}
+	if individual[52].ResourceID != "sg-052" {
+		t.Errorf("last individual finding is %s, want the first 53 kept in order", individual[52].ResourceID)
+	}
+
+	if aggregate == nil {
+		t.Fatal("no aggregate finding for ec2_sg_open_ports")
+	}
+	if !strings.HasSuffix(aggregate.Title, "(and 47 more resources affected)") {
+		t.Errorf("aggregate title = %q", aggregate.Title)
+	}
+	if !strings.Contains(aggregate.Description, "sg-053, sg-054") || !strings.HasSuffix(aggregate.Description, "sg-099") {
+		t.Errorf("aggregate description = %q, want it to list sg-053 through sg-099", aggregate.Description)
+	}
+	if aggregate.Status != StatusFail || aggregate.Severity != SeverityHigh || aggregate.Region != "us-east-1" || aggregate.ScanID != result.ScanID {
+		t.Errorf("aggregate = %+v", *aggregate)
+	}
+	if aggregate.FindingID == "" || aggregate.FindingID == individual[0].FindingID {
+		t.Errorf("aggregate FindingID = %q, want its own ID", aggregate.FindingID)
+	}
+
+	if result.TotalChecks != 102 || result.FailedChecks != 101 {
+		t.Errorf("got %d total / %d failed, want counts to include every failure", result.TotalChecks, result.FailedChecks)
+	}
+	if got, want := CountFailedBySeverity(result.Findings), (SeverityCounts{High: 100, Medium: 1}); got != want {
+		t.Errorf("CountFailedBySeverity() = %+v, want %+v including the capped failures", got, want)
+	}
+
+	var streamed []Finding
+	err = coord.StreamScan(context.Background(), ScanConfig{
+		AccountID:           "123456789012",
+		Regions:             []string{"us-east-1"},
+		Services:            []string{"ec2"},
+		MaxFindingsPerCheck: 53,
+	}, func(f Finding) error {
+		streamed = append(streamed, f)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamScan() error = %v", err)
+	}
+	if len(streamed) != 56 || streamed[55].ResourceID != aggregateResourceID {
+		t.Errorf("streamed %d findings ending with %q, want 56 ending with the aggregate", len(streamed), streamed[len(streamed)-1].ResourceID)
+	}
+}
+
+func TestCoordinator_StartScan_ExcludeManaged(t *testing.T) {
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+	coord.RegisterScanner("iam", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{
+			service: "iam",
+			findings: []Finding{
+				{CheckID: "iam_cross_account_trust", ResourceID: "AWSServiceRoleForSupport", Status: StatusFail, Severity: SeverityHigh, Managed: true},
+				{CheckID: "iam_cross_account_trust", ResourceID: "partner-access", Status: StatusFail, Severity: SeverityHigh},
+				{CheckID: "iam_root_mfa", ResourceID: "root", Status: StatusPass, Severity: SeverityCritical},
+			},
+		}
+	})
+
+	config := ScanConfig{
+		AccountID: "123456789012",
+		Regions:   []string{"global"},
+		Services:  []string{"iam"},
+	}
+	result, err := coord.StartScan(context.Background(), config)
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+	if result.FailedChecks != 2 || len(result.Findings) != 3 {
+		t.Errorf("by default got %d failed of %d findings, want 2 of 3", result.FailedChecks, len(result.Findings))
+	}
+
+	includeManaged := false
+	config.IncludeManaged = &includeManaged
+	result, err = coord.StartScan(context.Background(), config)
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+	if result.FailedChecks != 1 || result.TotalChecks != 2 {
+		t.Errorf("got %d failed / %d total, want 1 / 2", result.FailedChecks, result.TotalChecks)
+	}
+	for _, f := range result.Findings {
+		if f.Managed {
+			t.Errorf("managed finding %s on %s returned", f.CheckID, f.ResourceID)
+		}
+	}
+	if got := RiskScore(CountFailedBySeverity(result.Findings)); got != 10 {
+		t.Errorf("risk score = %d, want 10 without the managed finding", got)
+	}
+}
+
+func TestCoordinator_StartScan_CheckSelection(t *testing.T) {
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+	coord.RegisterScanner("s3", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{
+			service: "s3",
+			findings: []Finding{
+				{CheckID: "s3_bucket_encryption", Status: StatusFail},
+				{CheckID: "s3_bucket_versioning", Status: StatusPass},
+				{CheckID: "s3_bucket_logging", Status: StatusFail},
+			},
+		}
+	})
+
+	tests := []struct {
+		name      string
+		checkIDs  []string
+		disabled  []string
+		want      []string
+		wantTotal int
+	}{
+		{"all checks", nil, nil, []string{"s3_bucket_encryption", "s3_bucket_versioning", "s3_bucket_logging"}, 3},
+		{"enabled only", []string{"s3_bucket_encryption", "s3_bucket_versioning"}, nil, []string{"s3_bucket_encryption", "s3_bucket_versioning"}, 2},
+		{"disabled", nil, []string{"s3_bucket_logging"}, []string{"s3_bucket_encryption", "s3_bucket_versioning"}, 2},
+		{"disabled wins", []string{"s3_bucket_encryption", "s3_bucket_logging"}, []string{"s3_bucket_logging"}, []string{"s3_bucket_encryption"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := ScanConfig{
+				AccountID:      "123456789012",
+				Regions:        []string{"us-east-1"},
+				Services:       []string{"s3"},
+				CheckIDs:       tt.checkIDs,
+				DisabledChecks: tt.disabled,
+			}
+			result, err := coord.StartScan(context.Background(), config)
+			if err != nil {
+				t.Fatalf("StartScan() error = %v", err)
+			}
+			var got []string
+			for _, f := range result.Findings {
+				got = append(got, f.CheckID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("findings = %v, want %v", got, tt.want)
+			}
+			if result.TotalChecks != tt.wantTotal {
+				t.Errorf("TotalChecks = %d, want %d", result.TotalChecks, tt.wantTotal)
+			}
+
+			var streamed []string
+			if err := coord.StreamScan(context.Background(), config, func(f Finding) error {
+				streamed = append(streamed, f.CheckID)
+				return nil
+			}); err != nil {
+				t.Fatalf("StreamScan() error = %v", err)
+			}
+			if !slices.Equal(streamed, tt.want) {
+				t.Errorf("streamed = %v, want %v", streamed, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoordinator_StartScan_SuppressedFindings(t *testing.T) {
+	suppressed := FindingID("123456789012", "s3", "us-east-1", "s3_bucket_logging", "logs")
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+	coord.RegisterScanner("s3", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{
+			service: "s3",
+			findings: []Finding{
+				{FindingID: FindingID("123456789012", "s3", "us-east-1", "s3_bucket_logging", "data"), CheckID: "s3_bucket_logging", Status: StatusFail},
+				{FindingID: suppressed, CheckID: "s3_bucket_logging", Status: StatusFail},
+			},
+		}
+	})
+
+	config := ScanConfig{
+		AccountID:          "123456789012",
+		Regions:            []string{"us-east-1"},
+		Services:           []string{"s3"},
+		SuppressedFindings: []string{suppressed},
+	}
+	result, err := coord.StartScan(context.Background(), config)
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+	if len(result.Findings) != 1 || result.Findings[0].FindingID == suppressed {
+		t.Errorf("findings = %+v, want only the unsuppressed one", result.Findings)
+	}
+	if result.FailedChecks != 1 {
+		t.Errorf("FailedChecks = %d, want 1", result.FailedChecks)
+	}
+
+	var streamed int
+	if err := coord.StreamScan(context.Background(), config, func(f Finding) error {
+		if f.FindingID == suppressed {
+			t.Errorf("streamed suppressed finding %s", f.FindingID)
+		}
+		streamed++
+		return nil
+	}); err != nil {
+		t.Fatalf("StreamScan() error = %v", err)
+	}
+	if streamed != 1 {
+		t.Errorf("streamed %d findings, want 1", streamed)
+	}
+}
+
 func TestCoordinator_StartScan_MultipleRegions(t *testing.T) {
 	coord := NewCoordinator(aws.Config{}, "123456789012")
 
@@ -265,6 +619,201 @@ func TestCoordinator_StartScan_Parallel(t *testing.T) {
 	}
 }
 
+func TestCoordinator_StartScan_SingleWorker(t *testing.T) {
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+
+	coord.RegisterScanner("s3", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{
+			service: "s3",
+			delay:   100 * time.Millisecond,
+			findings: []Finding{
+				{CheckID: "s3_test", Status: StatusPass},
+			},
+		}
+	})
+
+	config := ScanConfig{
+		AccountID:  "123456789012",
+		Regions:    []string{"us-east-1", "us-west-2", "eu-west-1"},
+		Services:   []string{"s3"},
+		MaxWorkers: 1,
+	}
+
+	start := time.Now()
+	result, err := coord.StartScan(context.Background(), config)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+
+	// A single worker runs the three 100ms tasks one after another.
+	if elapsed < 300*time.Millisecond {
+		t.Errorf("Scan took %v, expected serial execution to take at least 300ms", elapsed)
+	}
+
+	if len(result.Findings) != 3 {
+		t.Errorf("Expected 3 findings, got %d", len(result.Findings))
+	}
+}
+
+func TestScanConfig_maxWorkers(t *testing.T) {
+	tests := []struct {
+		configured int
+		want       int
+	}{
+		{0, defaultMaxWorkers},
+		{-3, defaultMaxWorkers},
+		{1, 1},
+		{32, 32},
+	}
+	for _, tt := range tests {
+		if got := (ScanConfig{MaxWorkers: tt.configured}).maxWorkers(); got != tt.want {
+			t.Errorf("maxWorkers() with MaxWorkers=%d = %d, want %d", tt.configured, got, tt.want)
+		}
+	}
+}
+
+// concurrencyRecorder tracks how many regions, and how many services within
+// each region, are being scanned at the same time.
+type concurrencyRecorder struct {
+	mu             sync.Mutex
+	active         map[string]int
+	peakRegions    int
+	peakPerRegion  map[string]int
+	regionsStarted []string
+}
+
+func newConcurrencyRecorder() *concurrencyRecorder {
+	return &concurrencyRecorder{active: make(map[string]int), peakPerRegion: make(map[string]int)}
+}
+
+func (r *concurrencyRecorder) factory(service string) Factory {
+	return func(_ aws.Config, region, _ string) ServiceScanner {
+		return &recordingScanner{recorder: r, service: service, region: region}
+	}
+}
+
+type recordingScanner struct {
+	recorder *concurrencyRecorder
+	service  string
+	region   string
+}
+
+func (s *recordingScanner) Service() string { return s.service }
+
+func (s *recordingScanner) Scan(_ context.Context, _ string) ([]Finding, error) {
+	r := s.recorder
+	r.mu.Lock()
+	if r.active[s.region] == 0 && !slices.Contains(r.regionsStarted, s.region) {
+		r.regionsStarted = append(r.regionsStarted, s.region)
+	}
+	r.active[s.region]++
+	r.peakPerRegion[s.region] = max(r.peakPerRegion[s.region], r.active[s.region])
+	regions := 0
+	for _, n := range r.active {
+		if n > 0 {
+			regions++
+		}
+	}
+	r.peakRegions = max(r.peakRegions, regions)
+	r.mu.Unlock()
+
+	time.Sleep(30 * time.Millisecond)
+
+	r.mu.Lock()
+	r.active[s.region]--
+	r.mu.Unlock()
+	return []Finding{{Service: s.service, Region: s.region, Status: StatusPass}}, nil
+}
+
+func TestCoordinator_StartScan_RegionConcurrency(t *testing.T) {
+	tests := []struct {
+		name               string
+		regionConcurrency  int
+		serviceConcurrency int
+		wantPeakRegions    int
+		wantPeakServices   int
+	}{
+		{"one region at a time", 1, 0, 1, 3},
+		{"one service per region", 0, 1, 3, 1},
+		{"both bounded", 2, 2, 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := newConcurrencyRecorder()
+			coord := NewCoordinator(aws.Config{}, "123456789012")
+			for _, service := range []string{"s3", "ec2", "iam"} {
+				coord.RegisterScanner(service, recorder.factory(service))
+			}
+
+			result, err := coord.StartScan(context.Background(), ScanConfig{
+				AccountID:          "123456789012",
+				Regions:            []string{"us-east-1", "us-west-2", "eu-west-1"},
+				Services:           []string{"s3", "ec2", "iam"},
+				RegionConcurrency:  tt.regionConcurrency,
+				ServiceConcurrency: tt.serviceConcurrency,
+			})
+			if err != nil {
+				t.Fatalf("StartScan() error = %v", err)
+			}
+			if len(result.Findings) != 9 {
+				t.Errorf("got %d findings, want one per service and region", len(result.Findings))
+			}
+
+			if recorder.peakRegions != tt.wantPeakRegions {
+				t.Errorf("%d regions scanned at once, want %d", recorder.peakRegions, tt.wantPeakRegions)
+			}
+			for region, peak := range recorder.peakPerRegion {
+				if peak != tt.wantPeakServices {
+					t.Errorf("%d services scanned at once in %s, want %d", peak, region, tt.wantPeakServices)
+				}
+			}
+		})
+	}
+}
+
+func TestCoordinator_StartScan_RegionsInOrder(t *testing.T) {
+	recorder := newConcurrencyRecorder()
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+	coord.RegisterScanner("s3", recorder.factory("s3"))
+	coord.RegisterScanner("ec2", recorder.factory("ec2"))
+
+	regions := []string{"us-east-1", "us-west-2", "eu-west-1"}
+	if _, err := coord.StartScan(context.Background(), ScanConfig{
+		AccountID:         "123456789012",
+		Regions:           regions,
+		Services:          []string{"s3", "ec2"},
+		RegionConcurrency: 1,
+	}); err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+	// With one region at a time, a region finishes before the next starts.
+	if !slices.Equal(recorder.regionsStarted, regions) {
+		t.Errorf("regions started in order %v, want %v", recorder.regionsStarted, regions)
+	}
+}
+
+func TestScanConfig_concurrency(t *testing.T) {
+	tests := []struct {
+		config                  ScanConfig
+		wantRegion, wantService int
+	}{
+		{ScanConfig{}, defaultMaxWorkers, defaultMaxWorkers},
+		{ScanConfig{RegionConcurrency: 2, ServiceConcurrency: 3}, 2, 3},
+		{ScanConfig{RegionConcurrency: -1, ServiceConcurrency: -1, MaxWorkers: 4}, 4, 4},
+		{ScanConfig{RegionConcurrency: 25, ServiceConcurrency: 25, MaxWorkers: 8}, 8, 8},
+	}
+	for _, tt := range tests {
+		if got := tt.config.regionConcurrency(); got != tt.wantRegion {
+			t.Errorf("regionConcurrency() for %+v = %d, want %d", tt.config, got, tt.wantRegion)
+		}
+		if got := tt.config.serviceConcurrency(); got != tt.wantService {
+			t.Errorf("serviceConcurrency() for %+v = %d, want %d", tt.config, got, tt.wantService)
+		}
+	}
+}
+
 func TestCoordinator_StartScan_ResultMetadata(t *testing.T) {
 	coord := NewCoordinator(aws.Config{}, "123456789012")
 
@@ -307,6 +856,204 @@ func TestCoordinator_StartScan_ResultMetadata(t *testing.T) {
 	}
 }
 
+// scopeRecorder records the scope each scan receives.
+type scopeRecorder struct {
+	service string
+	mu      sync.Mutex
+	scopes  []Scope
+}
+
+func (r *scopeRecorder) Service() string {
+	return r.service
+}
+
+func (r *scopeRecorder) Scan(ctx context.Context, _ string) ([]Finding, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scopes = append(r.scopes, ScopeFromContext(ctx))
+	return nil, nil
+}
+
+func TestCoordinator_StartScan_PassesScope(t *testing.T) {
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+
+	ec2Rec := &scopeRecorder{service: "ec2"}
+	s3Rec := &scopeRecorder{service: "s3"}
+	coord.RegisterScanner("ec2", func(_ aws.Config, _, _ string) ServiceScanner { return ec2Rec })
+	coord.RegisterScanner("s3", func(_ aws.Config, _, _ string) ServiceScanner { return s3Rec })
+
+	_, err := coord.StartScan(context.Background(), ScanConfig{
+		AccountID:            "123456789012",
+		Regions:              []string{"us-east-1"},
+		Services:             []string{"ec2", "s3"},
+		ResourceIDs:          []string{"i-123"},
+		DisableAccountChecks: []string{"ec2"},
+	})
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+
+	if len(ec2Rec.scopes) != 1 || !ec2Rec.scopes[0].SkipAccountChecks {
+		t.Errorf("ec2 scopes = %+v, want account checks skipped", ec2Rec.scopes)
+	}
+	if len(s3Rec.scopes) != 1 || s3Rec.scopes[0].SkipAccountChecks {
+		t.Errorf("s3 scopes = %+v, want account checks enabled", s3Rec.scopes)
+	}
+	if !ec2Rec.scopes[0].Includes("i-123") || ec2Rec.scopes[0].Includes("i-456") {
+		t.Errorf("ec2 scope ResourceIDs = %v, want [i-123]", ec2Rec.scopes[0].ResourceIDs)
+	}
+}
+
+// scanIDRecorder records the scan ID each scan receives and reports one finding.
+type scanIDRecorder struct {
+	mu      sync.Mutex
+	scanIDs []string
+}
+
+func (r *scanIDRecorder) Service() string {
+	return "s3"
+}
+
+func (r *scanIDRecorder) Scan(ctx context.Context, region string) ([]Finding, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scanIDs = append(r.scanIDs, ScanIDFromContext(ctx))
+	return []Finding{{CheckID: "s3_test", Region: region, Status: StatusFail}}, nil
+}
+
+func TestCoordinator_StartScan_ScanID(t *testing.T) {
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+	rec := &scanIDRecorder{}
+	coord.RegisterScanner("s3", func(_ aws.Config, _, _ string) ServiceScanner { return rec })
+	config := ScanConfig{
+		AccountID: "123456789012",
+		Regions:   []string{"us-east-1", "eu-west-1"},
+		Services:  []string{"s3"},
+	}
+
+	result, err := coord.StartScan(context.Background(), config)
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+	if !strings.HasPrefix(result.ScanID, "scan-") {
+		t.Fatalf("ScanID = %q, want a generated scan ID", result.ScanID)
+	}
+	if want := []string{result.ScanID, result.ScanID}; !slices.Equal(rec.scanIDs, want) {
+		t.Errorf("scanners saw scan IDs %v, want %v", rec.scanIDs, want)
+	}
+	for _, f := range result.Findings {
+		if f.ScanID != result.ScanID {
+			t.Errorf("finding in %s has ScanID %q, want %q", f.Region, f.ScanID, result.ScanID)
+		}
+	}
+
+	again, err := coord.StartScan(context.Background(), config)
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+	if again.ScanID == result.ScanID {
+		t.Errorf("two scans share scan ID %q", again.ScanID)
+	}
+
+	given, err := coord.StartScan(WithScanID(context.Background(), "scan-given"), config)
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+	if given.ScanID != "scan-given" {
+		t.Errorf("ScanID = %q, want the ID from the context", given.ScanID)
+	}
+}
+
+func TestScope_Includes(t *testing.T) {
+	if !(Scope{}).Includes("anything") {
+		t.Error("empty scope should include every resource")
+	}
+	scope := Scope{ResourceIDs: []string{"i-123"}}
+	if !scope.Includes("i-123") || scope.Includes("i-456") {
+		t.Errorf("Includes() mismatch for scope %v", scope.ResourceIDs)
+	}
+}
+
+func TestCoordinator_StartScan_Coverage(t *testing.T) {
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+
+	none, two := 0, 2
+	coord.RegisterScanner("ecs", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{service: "ecs", resources: &none}
+	})
+	coord.RegisterScanner("s3", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{service: "s3", findings: []Finding{{CheckID: "test", Status: StatusPass}}}
+	})
+	coord.RegisterScanner("lambda", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{service: "lambda", err: errors.New("access denied")}
+	})
+	// Resources were listed but no check reported on them.
+	coord.RegisterScanner("kms", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{service: "kms", resources: &two}
+	})
+	// Nothing was counted, so the lack of findings does not make it empty.
+	coord.RegisterScanner("eks", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{service: "eks"}
+	})
+
+	result, err := coord.StartScan(context.Background(), ScanConfig{
+		AccountID: "123456789012",
+		Regions:   []string{"us-east-1"},
+		Services:  []string{"ecs", "s3", "lambda", "kms", "eks"},
+	})
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+
+	want := map[string]CoverageStatus{
+		"ecs":    CoverageEmpty,
+		"eks":    CoverageScanned,
+		"kms":    CoverageScanned,
+		"lambda": CoverageFailed,
+		"s3":     CoverageScanned,
+	}
+	if len(result.Coverage) != len(want) {
+		t.Fatalf("Coverage has %d entries, want %d", len(result.Coverage), len(want))
+	}
+	for _, c := range result.Coverage {
+		if c.Status != want[c.Service] {
+			t.Errorf("%s coverage status = %v, want %v", c.Service, c.Status, want[c.Service])
+		}
+		if c.Service == "ecs" && c.Message != "0 resources found for service ecs in region us-east-1" {
+			t.Errorf("ecs coverage message = %q", c.Message)
+		}
+		if c.Service == "kms" && c.Message != "2 resources, 0 findings for service kms in region us-east-1" {
+			t.Errorf("kms coverage message = %q", c.Message)
+		}
+	}
+}
+
+func TestCoordinator_StartScan_CountsErrorFindings(t *testing.T) {
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+	coord.RegisterScanner("s3", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{
+			service: "s3",
+			findings: []Finding{
+				{CheckID: "a", Status: StatusPass},
+				{CheckID: "b", Status: StatusFail},
+				{CheckID: "c", Status: StatusError},
+			},
+		}
+	})
+
+	result, err := coord.StartScan(context.Background(), ScanConfig{
+		AccountID: "123456789012",
+		Regions:   []string{"us-east-1"},
+		Services:  []string{"s3"},
+	})
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+	if result.PassedChecks != 1 || result.FailedChecks != 1 || result.ErrorChecks != 1 {
+		t.Errorf("got passed=%d failed=%d error=%d, want 1/1/1", result.PassedChecks, result.FailedChecks, result.ErrorChecks)
+	}
+}
+
 func TestGetDefaultRegions(t *testing.T) {
 	regions := GetDefaultRegions()
 
@@ -358,6 +1105,199 @@ func TestGetAllRegions(t *testing.T) {
 	}
 }
 
+// fakeRegionsClient serves a fixed DescribeRegions response.
+type fakeRegionsClient struct {
+	regions []ec2types.Region
+	err     error
+}
+
+func (f *fakeRegionsClient) DescribeRegions(_ context.Context, params *ec2.DescribeRegionsInput, _ ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error) {
+	if !aws.ToBool(params.AllRegions) {
+		return nil, errors.New("expected AllRegions")
+	}
+	return &ec2.DescribeRegionsOutput{Regions: f.regions}, f.err
+}
+
+func TestFetchRegions(t *testing.T) {
+	region := func(name, status string) ec2types.Region {
+		return ec2types.Region{RegionName: aws.String(name), OptInStatus: aws.String(status)}
+	}
+	client := &fakeRegionsClient{regions: []ec2types.Region{
+		region("us-west-2", "opt-in-not-required"),
+		region("af-south-1", "not-opted-in"),
+		region("ap-east-1", "opted-in"),
+		region("us-east-1", "opt-in-not-required"),
+		region("me-south-1", "not-opted-in"),
+		{OptInStatus: aws.String("opted-in")},
+	}}
+
+	tests := []struct {
+		name        string
+		enabledOnly bool
+		want        []string
+	}{
+		{"enabled only", true, []string{"ap-east-1", "us-east-1", "us-west-2"}},
+		{"all", false, []string{"af-south-1", "ap-east-1", "me-south-1", "us-east-1", "us-west-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fetchRegions(context.Background(), client, tt.enabledOnly)
+			if err != nil {
+				t.Fatalf("fetchRegions() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("fetchRegions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	none := &fakeRegionsClient{regions: []ec2types.Region{region("af-south-1", "not-opted-in")}}
+	if _, err := fetchRegions(context.Background(), none, true); err == nil {
+		t.Error("fetchRegions() with no enabled regions should fail")
+	}
+}
+
+// countingRegionsClient serves a fixed region list and counts calls.
+type countingRegionsClient struct {
+	regions []string
+	calls   *atomic.Int32
+}
+
+func (c countingRegionsClient) DescribeRegions(context.Context, *ec2.DescribeRegionsInput, ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error) {
+	c.calls.Add(1)
+	// Hold the call open so concurrent callers overlap.
+	time.Sleep(20 * time.Millisecond)
+	out := &ec2.DescribeRegionsOutput{}
+	for _, r := range c.regions {
+		out.Regions = append(out.Regions, ec2types.Region{RegionName: aws.String(r)})
+	}
+	return out, nil
+}
+
+func TestGetAllRegions_CachesPerPartition(t *testing.T) {
+	var commercialCalls, govCalls atomic.Int32
+	origClient := newRegionsClient
+	newRegionsClient = func(cfg aws.Config) regionsAPI {
+		if strings.HasPrefix(cfg.Region, "us-gov-") {
+			return countingRegionsClient{regions: []string{"us-gov-west-1", "us-gov-east-1"}, calls: &govCalls}
+		}
+		return countingRegionsClient{regions: []string{"us-west-2", "us-east-1"}, calls: &commercialCalls}
+	}
+	regionCacheMu.Lock()
+	origCache := regionCache
+	regionCache = make(map[string]regionCacheEntry)
+	regionCacheMu.Unlock()
+	t.Cleanup(func() {
+		newRegionsClient = origClient
+		regionCacheMu.Lock()
+		regionCache = origCache
+		regionCacheMu.Unlock()
+	})
+
+	commercial := aws.Config{Region: "us-east-1"}
+	gov := aws.Config{Region: "us-gov-west-1"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			GetAllRegions(context.Background(), commercial)
+		}()
+	}
+	wg.Wait()
+	if n := commercialCalls.Load(); n != 1 {
+		t.Errorf("concurrent GetAllRegions() made %d DescribeRegions calls, want 1", n)
+	}
+
+	if got := GetAllRegions(context.Background(), commercial); !slices.Equal(got, []string{"us-east-1", "us-west-2"}) {
+		t.Errorf("GetAllRegions(commercial) = %v", got)
+	}
+	if got := GetAllRegions(context.Background(), gov); !slices.Equal(got, []string{"us-gov-east-1", "us-gov-west-1"}) {
+		t.Errorf("GetAllRegions(GovCloud) = %v, want the GovCloud regions", got)
+	}
+	if commercialCalls.Load() != 1 || govCalls.Load() != 1 {
+		t.Errorf("DescribeRegions calls = %d commercial / %d GovCloud, want 1 / 1", commercialCalls.Load(), govCalls.Load())
+	}
+
+	if _, err := RefreshRegions(context.Background(), commercial); err != nil {
+		t.Fatalf("RefreshRegions() error = %v", err)
+	}
+	if n := commercialCalls.Load(); n != 2 {
+		t.Errorf("RefreshRegions() made %d total DescribeRegions calls, want 2", n)
+	}
+	if n := govCalls.Load(); n != 1 {
+		t.Errorf("RefreshRegions(commercial) refetched GovCloud regions")
+	}
+
+	// An expired entry is fetched again.
+	regionCacheMu.Lock()
+	entry := regionCache[awsauth.PartitionGovCloud]
+	entry.fetchedAt = time.Now().Add(-regionCacheTTL)
+	regionCache[awsauth.PartitionGovCloud] = entry
+	regionCacheMu.Unlock()
+	GetAllRegions(context.Background(), gov)
+	if n := govCalls.Load(); n != 2 {
+		t.Errorf("GetAllRegions() after TTL made %d total GovCloud calls, want 2", n)
+	}
+}
+
+func TestGetEnabledRegions(t *testing.T) {
+	origClient := newRegionsClient
+	t.Cleanup(func() { newRegionsClient = origClient })
+
+	newRegionsClient = func(aws.Config) regionsAPI {
+		return &fakeRegionsClient{regions: []ec2types.Region{
+			{RegionName: aws.String("us-east-1"), OptInStatus: aws.String("opt-in-not-required")},
+			{RegionName: aws.String("af-south-1"), OptInStatus: aws.String("not-opted-in")},
+		}}
+	}
+	if got := GetEnabledRegions(context.Background(), aws.Config{Region: "us-east-1"}); !slices.Equal(got, []string{"us-east-1"}) {
+		t.Errorf("GetEnabledRegions() = %v, want the enabled regions", got)
+	}
+
+	newRegionsClient = func(aws.Config) regionsAPI {
+		return &fakeRegionsClient{err: errors.New("access denied")}
+	}
+	tests := []struct {
+		region string
+		want   []string
+	}{
+		{"us-east-1", GetDefaultRegions()},
+		{"us-gov-west-1", []string{"us-gov-east-1", "us-gov-west-1"}},
+		{"cn-north-1", []string{"cn-north-1", "cn-northwest-1"}},
+	}
+	for _, tt := range tests {
+		if got := GetEnabledRegions(context.Background(), aws.Config{Region: tt.region}); !slices.Equal(got, tt.want) {
+			t.Errorf("GetEnabledRegions(%s) after a failure = %v, want %v", tt.region, got, tt.want)
+		}
+	}
+}
+
+func TestCoordinator_DefaultsToEnabledRegions(t *testing.T) {
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+	coord.listRegions = func(context.Context, aws.Config) []string {
+		return []string{"us-east-1", "ap-east-1"}
+	}
+	coord.RegisterScanner("s3", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{service: "s3"}
+	})
+
+	result, err := coord.ForAccount(aws.Config{}, "210987654321").StartScan(context.Background(), ScanConfig{
+		AccountID: "210987654321",
+		Services:  []string{"s3"},
+	})
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+	if !slices.Equal(result.Regions, []string{"us-east-1", "ap-east-1"}) {
+		t.Errorf("Regions = %v, want the enabled regions", result.Regions)
+	}
+	if len(result.Coverage) != 2 {
+		t.Errorf("expected a task per enabled region, got coverage %+v", result.Coverage)
+	}
+}
+
 func TestCoordinator_ContextCancellation(t *testing.T) {
 	coord := NewCoordinator(aws.Config{}, "123456789012")
 
@@ -370,11 +1310,19 @@ func TestCoordinator_ContextCancellation(t *testing.T) {
 			},
 		}
 	})
+	coord.RegisterScanner("iam", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{
+			service: "iam",
+			findings: []Finding{
+				{CheckID: "iam_test", Status: StatusFail},
+			},
+		}
+	})
 
 	config := ScanConfig{
 		AccountID: "123456789012",
 		Regions:   []string{"us-east-1"},
-		Services:  []string{"s3"},
+		Services:  []string{"s3", "iam"},
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
@@ -389,8 +1337,200 @@ func TestCoordinator_ContextCancellation(t *testing.T) {
 		t.Errorf("Scan took %v with cancelled context, expected faster completion", elapsed)
 	}
 
-	_ = err
-	_ = result
+	if err != nil {
+		t.Fatalf("StartScan() error = %v, want partial results", err)
+	}
+	if !result.Cancelled {
+		t.Error("Cancelled = false, want true")
+	}
+	if len(result.Findings) != 1 || result.Findings[0].CheckID != "iam_test" {
+		t.Errorf("Findings = %+v, want the completed iam_test finding", result.Findings)
+	}
+	if result.FailedChecks != 1 {
+		t.Errorf("FailedChecks = %d, want 1", result.FailedChecks)
+	}
+	for _, c := range result.Coverage {
+		want := CoverageScanned
+		if c.Service == "s3" {
+			want = CoverageFailed
+		}
+		if c.Status != want {
+			t.Errorf("%s coverage = %s, want %s", c.Service, c.Status, want)
+		}
+	}
+}
+
+func TestCoordinator_StreamScan(t *testing.T) {
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+	coord.RegisterScanner("s3", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{
+			service: "s3",
+			findings: []Finding{
+				{CheckID: "s3_test", Status: StatusPass, Severity: SeverityLow},
+				{CheckID: "s3_other", Status: StatusFail, Severity: SeverityLow},
+			},
+		}
+	})
+
+	config := ScanConfig{
+		AccountID:         "123456789012",
+		Regions:           []string{"us-east-1", "us-west-2", "eu-west-1"},
+		Services:          []string{"s3"},
+		IncludePassing:    aws.Bool(false),
+		SeverityOverrides: map[string]Severity{"s3_other": SeverityHigh},
+	}
+
+	var streamed []Finding
+	err := coord.StreamScan(context.Background(), config, func(f Finding) error {
+		streamed = append(streamed, f)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamScan() error = %v", err)
+	}
+	if len(streamed) != 3 {
+		t.Fatalf("streamed %d findings, want the 3 failures", len(streamed))
+	}
+	for _, f := range streamed {
+		if f.CheckID != "s3_other" || f.Severity != SeverityHigh {
+			t.Errorf("streamed %s/%s, want s3_other/HIGH", f.CheckID, f.Severity)
+		}
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = coord.StreamScan(context.Background(), config, func(Finding) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("StreamScan() = %v after %d calls, want the callback error after 1", err, calls)
+	}
+}
+
+// flushingBuffer records how often StreamFindings flushes its writer.
+type flushingBuffer struct {
+	bytes.Buffer
+	flushes int
+}
+
+func (b *flushingBuffer) Flush() { b.flushes++ }
+
+// failingWriter rejects every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestCoordinator_StreamFindings(t *testing.T) {
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+	for _, service := range []string{"s3", "ec2"} {
+		coord.RegisterScanner(service, func(_ aws.Config, region, _ string) ServiceScanner {
+			findings := make([]Finding, 500)
+			for i := range findings {
+				findings[i] = Finding{
+					Service:    service,
+					Region:     region,
+					ResourceID: fmt.Sprintf("resource-%d", i),
+					CheckID:    service + "_test",
+					Status:     StatusFail,
+					Severity:   SeverityMedium,
+				}
+			}
+			return &mockScanner{service: service, findings: findings}
+		})
+	}
+
+	var out flushingBuffer
+	err := coord.StreamFindings(context.Background(), ScanConfig{
+		AccountID: "123456789012",
+		Regions:   []string{"us-east-1", "us-west-2", "eu-west-1"},
+		Services:  []string{"s3", "ec2"},
+	}, &out)
+	if err != nil {
+		t.Fatalf("StreamFindings() error = %v", err)
+	}
+
+	lines := 0
+	seen := make(map[string]bool)
+	sc := bufio.NewScanner(&out)
+	for sc.Scan() {
+		lines++
+		var f Finding
+		if err := json.Unmarshal(sc.Bytes(), &f); err != nil {
+			t.Fatalf("line %d is not a JSON finding: %v: %s", lines, err, sc.Text())
+		}
+		if f.CheckID == "" || f.Region == "" || f.Status != StatusFail {
+			t.Errorf("line %d decoded to incomplete finding %+v", lines, f)
+		}
+		seen[f.Service+"/"+f.Region+"/"+f.ResourceID] = true
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatalf("reading stream: %v", err)
+	}
+	if lines != 3000 || len(seen) != 3000 {
+		t.Errorf("streamed %d lines of %d distinct findings, want 3000", lines, len(seen))
+	}
+	if out.flushes == 0 {
+		t.Error("the writer was never flushed")
+	}
+}
+
+func TestCoordinator_StreamFindings_WriteError(t *testing.T) {
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+	coord.RegisterScanner("s3", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{service: "s3", findings: []Finding{{CheckID: "s3_test", Status: StatusFail}}}
+	})
+
+	err := coord.StreamFindings(context.Background(), ScanConfig{
+		AccountID: "123456789012",
+		Regions:   []string{"us-east-1"},
+		Services:  []string{"s3"},
+	}, failingWriter{})
+	if err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("StreamFindings() error = %v, want the write error", err)
+	}
+}
+
+func TestCoordinator_StartScan_ErrorReport(t *testing.T) {
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+	coord.RegisterScanner("iam", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{service: "iam", err: &smithy.GenericAPIError{Code: "AccessDenied", Message: "not authorized"}}
+	})
+	coord.RegisterScanner("ec2", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{service: "ec2", err: fmt.Errorf("describing instances: %w", &smithy.GenericAPIError{Code: "RequestLimitExceeded"})}
+	})
+	coord.RegisterScanner("s3", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{service: "s3", findings: []Finding{{CheckID: "s3_test", Status: StatusFail}}}
+	})
+
+	result, err := coord.StartScan(context.Background(), ScanConfig{
+		AccountID: "123456789012",
+		Regions:   []string{"us-east-1"},
+		Services:  []string{"iam", "ec2", "s3"},
+	})
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+
+	got := make(map[string]ErrorType)
+	for _, e := range result.Errors {
+		if e.Region != "us-east-1" || e.Message == "" {
+			t.Errorf("unexpected error entry: %+v", e)
+		}
+		got[e.Service] = e.Type
+	}
+	want := map[string]ErrorType{"iam": ErrorAccessDenied, "ec2": ErrorThrottling}
+	if len(got) != len(want) {
+		t.Fatalf("Errors = %+v, want one for iam and one for ec2", result.Errors)
+	}
+	for service, errType := range want {
+		if got[service] != errType {
+			t.Errorf("%s error type = %s, want %s", service, got[service], errType)
+		}
+	}
+	if len(result.Findings) != 1 {
+		t.Errorf("got %d findings, want the s3 finding to survive", len(result.Findings))
+	}
 }
 
 func TestScanTaskResult(t *testing.T) {
@@ -0,0 +1,16 @@
+package scanner
+
+import "time"
+
+// WithResourceCreatedAt sets the resource creation time of each finding to
+// createdAt and returns findings. Scanners use it on the findings of one
+// resource once its checks have run; a nil createdAt leaves them unchanged.
+func WithResourceCreatedAt(findings []Finding, createdAt *time.Time) []Finding {
+	if createdAt == nil {
+		return findings
+	}
+	for i := range findings {
+		findings[i].ResourceCreatedAt = createdAt
+	}
+	return findings
+}
@@ -4,12 +4,15 @@ package dynamodb
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"cloudcop/api/internal/scanner"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 )
 
 func (d *Scanner) checkEncryption(ctx context.Context, tableName string) []scanner.Finding {
@@ -148,3 +151,73 @@ func (d *Scanner) checkAutoScaling(ctx context.Context, tableName string) []scan
 		scanner.SeverityLow,
 	)}
 }
+
+func (d *Scanner) checkBackup(ctx context.Context, tableName string, now time.Time) []scanner.Finding {
+	days := d.backupWindowDays()
+	backups, err := d.client.ListBackups(ctx, &dynamodb.ListBackupsInput{
+		TableName:           aws.String(tableName),
+		BackupType:          types.BackupTypeFilterUser,
+		TimeRangeLowerBound: aws.Time(now.AddDate(0, 0, -days)),
+		TimeRangeUpperBound: aws.Time(now),
+		Limit:               aws.Int32(1),
+	})
+	if err != nil {
+		return nil
+	}
+
+	if len(backups.BackupSummaries) > 0 {
+		return []scanner.Finding{d.createFinding(
+			"dynamodb_backup",
+			tableName,
+			"DynamoDB table has a recent on-demand backup",
+			fmt.Sprintf("Table %s has an on-demand backup from the last %d days", tableName, days),
+			scanner.StatusPass,
+			scanner.SeverityMedium,
+		)}
+	}
+	return []scanner.Finding{d.createFinding(
+		"dynamodb_backup",
+		tableName,
+		"DynamoDB table has no recent on-demand backup",
+		fmt.Sprintf("Table %s has no on-demand backup in the last %d days", tableName, days),
+		scanner.StatusFail,
+		scanner.SeverityMedium,
+	)}
+}
+
+// checkVPCEndpoint verifies the region has a DynamoDB gateway endpoint so
+// table traffic from VPCs stays on the AWS network. It emits a single
+// region-level finding.
+func (d *Scanner) checkVPCEndpoint(ctx context.Context) []scanner.Finding {
+	serviceName := fmt.Sprintf("com.amazonaws.%s.dynamodb", d.region)
+	out, err := d.ec2Client.DescribeVpcEndpoints(ctx, &ec2.DescribeVpcEndpointsInput{
+		Filters: []ec2types.Filter{
+			{Name: aws.String("service-name"), Values: []string{serviceName}},
+			{Name: aws.String("vpc-endpoint-type"), Values: []string{string(ec2types.VpcEndpointTypeGateway)}},
+		},
+	})
+	if err != nil {
+		return nil
+	}
+
+	for _, endpoint := range out.VpcEndpoints {
+		if endpoint.State == ec2types.StateAvailable {
+			return []scanner.Finding{d.createFinding(
+				"dynamodb_vpc_endpoint",
+				d.region,
+				"DynamoDB gateway VPC endpoint exists",
+				fmt.Sprintf("VPC %s routes DynamoDB traffic through gateway endpoint %s", aws.ToString(endpoint.VpcId), aws.ToString(endpoint.VpcEndpointId)),
+				scanner.StatusPass,
+				scanner.SeverityLow,
+			)}
+		}
+	}
+	return []scanner.Finding{d.createFinding(
+		"dynamodb_vpc_endpoint",
+		d.region,
+		"No DynamoDB gateway VPC endpoint",
+		fmt.Sprintf("No available DynamoDB gateway endpoint exists in %s; VPC traffic to DynamoDB traverses the public endpoint", d.region),
+		scanner.StatusFail,
+		scanner.SeverityLow,
+	)}
+}
@@ -0,0 +1,129 @@
+package dynamodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// fakeDynamoDBClient implements dynamodbAPI with canned backups.
+// Methods that are not overridden panic via the nil embedded interface.
+type fakeDynamoDBClient struct {
+	dynamodbAPI
+	backups map[string][]time.Time
+	input   *dynamodb.ListBackupsInput
+}
+
+func (f *fakeDynamoDBClient) ListBackups(_ context.Context, params *dynamodb.ListBackupsInput, _ ...func(*dynamodb.Options)) (*dynamodb.ListBackupsOutput, error) {
+	f.input = params
+	out := &dynamodb.ListBackupsOutput{}
+	for _, created := range f.backups[aws.ToString(params.TableName)] {
+		if created.Before(aws.ToTime(params.TimeRangeLowerBound)) {
+			continue
+		}
+		out.BackupSummaries = append(out.BackupSummaries, types.BackupSummary{BackupCreationDateTime: aws.Time(created)})
+	}
+	return out, nil
+}
+
+type fakeEC2Client struct {
+	ec2API
+	endpoints []ec2types.VpcEndpoint
+	input     *ec2.DescribeVpcEndpointsInput
+}
+
+func (f *fakeEC2Client) DescribeVpcEndpoints(_ context.Context, params *ec2.DescribeVpcEndpointsInput, _ ...func(*ec2.Options)) (*ec2.DescribeVpcEndpointsOutput, error) {
+	f.input = params
+	return &ec2.DescribeVpcEndpointsOutput{VpcEndpoints: f.endpoints}, nil
+}
+
+func TestCheckBackup(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	client := &fakeDynamoDBClient{
+		backups: map[string][]time.Time{
+			"orders":   {now.AddDate(0, 0, -2)},
+			"sessions": {now.AddDate(0, 0, -30)},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		scanner *Scanner
+		table   string
+		want    scanner.FindingStatus
+	}{
+		{"recent backup", &Scanner{client: client, region: "us-east-1"}, "orders", scanner.StatusPass},
+		{"stale backup", &Scanner{client: client, region: "us-east-1"}, "sessions", scanner.StatusFail},
+		{"no backups", &Scanner{client: client, region: "us-east-1"}, "events", scanner.StatusFail},
+		{"stale backup within wider window", &Scanner{client: client, region: "us-east-1", backupMaxAgeDays: 45}, "sessions", scanner.StatusPass},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := tt.scanner.checkBackup(context.Background(), tt.table, now)
+
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			if findings[0].Status != tt.want || findings[0].CheckID != "dynamodb_backup" {
+				t.Errorf("got %s %s, want dynamodb_backup %s", findings[0].CheckID, findings[0].Status, tt.want)
+			}
+		})
+	}
+
+	if client.input.BackupType != types.BackupTypeFilterUser {
+		t.Errorf("BackupType = %s, want USER (on-demand)", client.input.BackupType)
+	}
+}
+
+func TestCheckVPCEndpoint(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoints []ec2types.VpcEndpoint
+		want      scanner.FindingStatus
+	}{
+		{
+			name: "available gateway endpoint",
+			endpoints: []ec2types.VpcEndpoint{
+				{VpcEndpointId: aws.String("vpce-123"), VpcId: aws.String("vpc-1"), State: ec2types.StateAvailable},
+			},
+			want: scanner.StatusPass,
+		},
+		{
+			name: "endpoint pending",
+			endpoints: []ec2types.VpcEndpoint{
+				{VpcEndpointId: aws.String("vpce-123"), VpcId: aws.String("vpc-1"), State: ec2types.StatePending},
+			},
+			want: scanner.StatusFail,
+		},
+		{name: "no endpoint", want: scanner.StatusFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ec2Client := &fakeEC2Client{endpoints: tt.endpoints}
+			s := &Scanner{ec2Client: ec2Client, region: "eu-west-1"}
+
+			findings := s.checkVPCEndpoint(context.Background())
+
+			if len(findings) != 1 {
+				t.Fatalf("expected a single region-level finding, got %d", len(findings))
+			}
+			f := findings[0]
+			if f.Status != tt.want || f.ResourceID != "eu-west-1" {
+				t.Errorf("got %s for %s, want %s for eu-west-1", f.Status, f.ResourceID, tt.want)
+			}
+			if got := ec2Client.input.Filters[0].Values[0]; got != "com.amazonaws.eu-west-1.dynamodb" {
+				t.Errorf("service-name filter = %s", got)
+			}
+		})
+	}
+}
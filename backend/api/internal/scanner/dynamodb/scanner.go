@@ -4,26 +4,77 @@ package dynamodb
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
+	"cloudcop/api/internal/awsauth"
 	"cloudcop/api/internal/scanner"
-	"cloudcop/api/internal/scanner/compliance"
+	"cloudcop/api/internal/scanner/checks"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
+// backupMaxAgeDays is the default window in which a table must have an
+// on-demand backup for dynamodb_backup to pass.
+const backupMaxAgeDays = 7
+
+// dynamodbAPI is the subset of the DynamoDB client used by the scanner.
+type dynamodbAPI interface {
+	ListTables(ctx context.Context, params *dynamodb.ListTablesInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListTablesOutput, error)
+	DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
+	DescribeContinuousBackups(ctx context.Context, params *dynamodb.DescribeContinuousBackupsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeContinuousBackupsOutput, error)
+	DescribeTimeToLive(ctx context.Context, params *dynamodb.DescribeTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error)
+	ListBackups(ctx context.Context, params *dynamodb.ListBackupsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListBackupsOutput, error)
+	ListTagsOfResource(ctx context.Context, params *dynamodb.ListTagsOfResourceInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListTagsOfResourceOutput, error)
+}
+
+// ec2API is the subset of the EC2 client used to look up VPC endpoints.
+type ec2API interface {
+	DescribeVpcEndpoints(ctx context.Context, params *ec2.DescribeVpcEndpointsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVpcEndpointsOutput, error)
+}
+
 // Scanner performs security checks on DynamoDB tables.
 type Scanner struct {
-	client *dynamodb.Client
-	region string
+	client    dynamodbAPI
+	ec2Client ec2API
+	region    string
+	accountID string
+
+	backupMaxAgeDays int
+}
+
+// Option configures a Scanner.
+type Option func(*Scanner)
+
+// WithBackupMaxAgeDays overrides how recent a table's latest on-demand backup
+// must be for dynamodb_backup to pass. Non-positive values keep the default.
+func WithBackupMaxAgeDays(days int) Option {
+	return func(s *Scanner) {
+		s.backupMaxAgeDays = days
+	}
 }
 
 // NewScanner creates a new DynamoDB scanner for the given region.
-func NewScanner(cfg aws.Config, region, _ string) scanner.ServiceScanner {
-	return &Scanner{
-		client: dynamodb.NewFromConfig(cfg),
-		region: region,
+func NewScanner(cfg aws.Config, region, accountID string, opts ...Option) scanner.ServiceScanner {
+	s := &Scanner{
+		client:    dynamodb.NewFromConfig(cfg),
+		ec2Client: ec2.NewFromConfig(cfg),
+		region:    region,
+		accountID: accountID,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// NewFactory returns a scanner.Factory that builds Scanners with opts applied.
+func NewFactory(opts ...Option) scanner.Factory {
+	return func(cfg aws.Config, region, accountID string) scanner.ServiceScanner {
+		return NewScanner(cfg, region, accountID, opts...)
 	}
 }
 
@@ -48,17 +99,64 @@ func (d *Scanner) Scan(ctx context.Context, region string) ([]scanner.Finding, e
 	if err != nil {
 		return nil, fmt.Errorf("listing tables: %w", err)
 	}
+	scanner.CountResources(ctx, len(tables))
 
-	for _, tableName := range tables {
-		findings = append(findings, d.checkEncryption(ctx, tableName)...)
-		findings = append(findings, d.checkPITR(ctx, tableName)...)
-		findings = append(findings, d.checkTTL(ctx, tableName)...)
-		findings = append(findings, d.checkAutoScaling(ctx, tableName)...)
+	includeTags := scanner.ScopeFromContext(ctx).IncludeTags
+	findings = append(findings, scanner.TraceChecks(ctx, "dynamodb.tables", func(ctx context.Context) []scanner.Finding {
+		var tableFindings []scanner.Finding
+		for _, tableName := range tables {
+			own := slices.Concat(
+				d.checkEncryption(ctx, tableName),
+				d.checkPITR(ctx, tableName),
+				d.checkTTL(ctx, tableName),
+				d.checkAutoScaling(ctx, tableName),
+				d.checkBackup(ctx, tableName, time.Now()),
+			)
+			if includeTags {
+				own = scanner.WithTags(own, d.tableTags(ctx, tableName))
+			}
+			tableFindings = append(tableFindings, own...)
+		}
+		return tableFindings
+	})...)
+
+	// The VPC endpoint check is region-wide, so it only runs once per scan
+	// and only when there are tables whose traffic it would carry.
+	if len(tables) > 0 && !scanner.ScopeFromContext(ctx).SkipAccountChecks {
+		findings = append(findings, scanner.TraceChecks(ctx, "dynamodb.vpc_endpoint", d.checkVPCEndpoint)...)
 	}
 
 	return findings, nil
 }
 
+// tableTags returns the table's tags, or nil if they cannot be read.
+func (d *Scanner) tableTags(ctx context.Context, tableName string) map[string]string {
+	tags := make(map[string]string)
+	input := &dynamodb.ListTagsOfResourceInput{ResourceArn: aws.String(d.resourceARN("", tableName))}
+	for {
+		out, err := d.client.ListTagsOfResource(ctx, input)
+		if err != nil {
+			scanner.Logf(ctx, "Warning: failed to list tags of table %s: %v", tableName, err)
+			return nil
+		}
+		for _, tag := range out.Tags {
+			tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+		if out.NextToken == nil {
+			return tags
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+// backupWindowDays returns the configured backup window, falling back to the default.
+func (d *Scanner) backupWindowDays() int {
+	if d.backupMaxAgeDays > 0 {
+		return d.backupMaxAgeDays
+	}
+	return backupMaxAgeDays
+}
+
 func (d *Scanner) listTables(ctx context.Context) ([]string, error) {
 	var tables []string
 	paginator := dynamodb.NewListTablesPaginator(d.client, &dynamodb.ListTablesInput{})
@@ -75,15 +173,33 @@ func (d *Scanner) listTables(ctx context.Context) ([]string, error) {
 
 func (d *Scanner) createFinding(checkID, resourceID, title, description string, status scanner.FindingStatus, severity scanner.Severity) scanner.Finding {
 	return scanner.Finding{
+		FindingID:   scanner.FindingID(d.accountID, d.Service(), d.region, checkID, resourceID),
 		Service:     d.Service(),
 		Region:      d.region,
 		ResourceID:  resourceID,
+		ARN:         d.resourceARN(checkID, resourceID),
 		CheckID:     checkID,
 		Status:      status,
 		Severity:    severity,
+		Confidence:  scanner.ConfidenceFor(checkID),
 		Title:       title,
 		Description: description,
-		Compliance:  compliance.GetCompliance(checkID),
+		Compliance:  checks.Compliance(checkID),
 		Timestamp:   time.Now().UTC(),
 	}
 }
+
+// resourceARN returns the ARN of the named table, or "" for the region-wide
+// VPC endpoint check, which is not about a table.
+func (d *Scanner) resourceARN(checkID, tableName string) string {
+	if checkID == "dynamodb_vpc_endpoint" {
+		return ""
+	}
+	return arn.ARN{
+		Partition: awsauth.PartitionForRegion(d.region),
+		Service:   "dynamodb",
+		Region:    d.region,
+		AccountID: d.accountID,
+		Resource:  "table/" + tableName,
+	}.String()
+}
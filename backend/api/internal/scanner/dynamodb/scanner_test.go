@@ -1,12 +1,15 @@
 package dynamodb
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"cloudcop/api/internal/scanner"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
 const testServiceName = "dynamodb"
@@ -95,6 +98,9 @@ func TestScanner_createFinding(t *testing.T) {
 			if finding.CheckID != tt.checkID {
 				t.Errorf("CheckID = %v, want %v", finding.CheckID, tt.checkID)
 			}
+			if want := scanner.FindingID(s.accountID, finding.Service, finding.Region, tt.checkID, tt.resourceID); finding.FindingID != want {
+				t.Errorf("FindingID = %v, want %v", finding.FindingID, want)
+			}
 			if finding.Status != tt.status {
 				t.Errorf("Status = %v, want %v", finding.Status, tt.status)
 			}
@@ -135,3 +141,84 @@ func TestScanner_createFinding_ComplianceMappings(t *testing.T) {
 		t.Error("Expected compliance mappings for dynamodb_encryption check")
 	}
 }
+
+func TestScanner_resourceARN(t *testing.T) {
+	s := &Scanner{region: "us-east-1", accountID: "123456789012"}
+
+	tests := []struct {
+		checkID    string
+		resourceID string
+		want       string
+	}{
+		{"dynamodb_pitr", "orders", "arn:aws:dynamodb:us-east-1:123456789012:table/orders"},
+		{"dynamodb_vpc_endpoint", "us-east-1", ""},
+	}
+	for _, tt := range tests {
+		if got := s.createFinding(tt.checkID, tt.resourceID, "", "", scanner.StatusPass, scanner.SeverityLow).ARN; got != tt.want {
+			t.Errorf("%s: ARN = %q, want %q", tt.checkID, got, tt.want)
+		}
+	}
+}
+
+// taggedTableClient lists one bare table whose tags come back over two pages.
+type taggedTableClient struct {
+	fakeDynamoDBClient
+	tagCalls int
+}
+
+func (f *taggedTableClient) ListTables(context.Context, *dynamodb.ListTablesInput, ...func(*dynamodb.Options)) (*dynamodb.ListTablesOutput, error) {
+	return &dynamodb.ListTablesOutput{TableNames: []string{"orders"}}, nil
+}
+
+func (f *taggedTableClient) DescribeTable(context.Context, *dynamodb.DescribeTableInput, ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	return &dynamodb.DescribeTableOutput{Table: &types.TableDescription{TableName: aws.String("orders")}}, nil
+}
+
+func (f *taggedTableClient) DescribeContinuousBackups(context.Context, *dynamodb.DescribeContinuousBackupsInput, ...func(*dynamodb.Options)) (*dynamodb.DescribeContinuousBackupsOutput, error) {
+	return &dynamodb.DescribeContinuousBackupsOutput{}, nil
+}
+
+func (f *taggedTableClient) DescribeTimeToLive(context.Context, *dynamodb.DescribeTimeToLiveInput, ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error) {
+	return &dynamodb.DescribeTimeToLiveOutput{}, nil
+}
+
+func (f *taggedTableClient) ListTagsOfResource(_ context.Context, params *dynamodb.ListTagsOfResourceInput, _ ...func(*dynamodb.Options)) (*dynamodb.ListTagsOfResourceOutput, error) {
+	f.tagCalls++
+	if aws.ToString(params.ResourceArn) != "arn:aws:dynamodb:us-east-1:123456789012:table/orders" {
+		return nil, &types.ResourceNotFoundException{}
+	}
+	if params.NextToken == nil {
+		return &dynamodb.ListTagsOfResourceOutput{
+			Tags:      []types.Tag{{Key: aws.String("owner"), Value: aws.String("checkout")}},
+			NextToken: aws.String("page-2"),
+		}, nil
+	}
+	return &dynamodb.ListTagsOfResourceOutput{Tags: []types.Tag{{Key: aws.String("env"), Value: aws.String("prod")}}}, nil
+}
+
+func TestScanner_Scan_IncludeTags(t *testing.T) {
+	for _, include := range []bool{true, false} {
+		client := &taggedTableClient{}
+		s := &Scanner{client: client, region: "us-east-1", accountID: "123456789012"}
+		ctx := scanner.WithScope(context.Background(), scanner.Scope{SkipAccountChecks: true, IncludeTags: include})
+
+		findings, err := s.Scan(ctx, "us-east-1")
+		if err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+		if len(findings) == 0 {
+			t.Fatal("Scan() returned no findings")
+		}
+		for _, f := range findings {
+			if include && (f.Tags["owner"] != "checkout" || f.Tags["env"] != "prod") {
+				t.Errorf("%s tags = %v, want owner=checkout and env=prod", f.CheckID, f.Tags)
+			}
+			if !include && f.Tags != nil {
+				t.Errorf("%s tags = %v without IncludeTags, want none", f.CheckID, f.Tags)
+			}
+		}
+		if !include && client.tagCalls != 0 {
+			t.Errorf("ListTagsOfResource called %d times without IncludeTags", client.tagCalls)
+		}
+	}
+}
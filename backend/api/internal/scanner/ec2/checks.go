@@ -4,6 +4,9 @@ package ec2
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"cloudcop/api/internal/scanner"
 
@@ -12,9 +15,15 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 )
 
-const ipv4Any = "0.0.0.0/0"
+const (
+	ipv4Any = "0.0.0.0/0"
+	ipv6Any = "::/0"
+)
 
-var dangerousPorts = map[int32]string{
+// defaultDangerousPorts are the ports, by the service usually listening on
+// them, that ec2_sg_dangerous_ports flags when open to the internet. Scanners
+// can add or remove ports with WithDangerousPorts and WithoutDangerousPorts.
+var defaultDangerousPorts = map[int32]string{
 	22:    "SSH",
 	3389:  "RDP",
 	3306:  "MySQL",
@@ -46,6 +55,24 @@ func (e *Scanner) checkPublicIP(_ context.Context, instance types.Instance) []sc
 	)}
 }
 
+// acceptPublicInstance downgrades public-access findings for an instance the
+// account has allow-listed by ID or tagged PublicIntentional=true.
+func acceptPublicInstance(allow scanner.PublicAllowList, instance types.Instance, findings []scanner.Finding) []scanner.Finding {
+	if !allow.Allows(aws.ToString(instance.InstanceId), instanceTags(instance)) {
+		return findings
+	}
+	return scanner.AcceptPublic(findings)
+}
+
+// instanceTags returns the instance's tags as a map.
+func instanceTags(instance types.Instance) map[string]string {
+	tags := make(map[string]string, len(instance.Tags))
+	for _, tag := range instance.Tags {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return tags
+}
+
 // checkEBSEncryption checks if EBS volumes are encrypted using a pre-fetched volume map
 func (e *Scanner) checkEBSEncryption(instance types.Instance, volumeMap map[string]*types.Volume) []scanner.Finding {
 	instanceID := aws.ToString(instance.InstanceId)
@@ -90,6 +117,7 @@ func (e *Scanner) checkEBSEncryption(instance types.Instance, volumeMap map[stri
 				scanner.SeverityMedium,
 			))
 		}
+		findings[len(findings)-1].ResourceCreatedAt = vol.CreateTime
 	}
 	return findings
 }
@@ -156,6 +184,155 @@ func (e *Scanner) checkIMDSv2(_ context.Context, instance types.Instance) []scan
 	)}
 }
 
+// allowsIMDSv1 reports whether the instance metadata service answers IMDSv1
+// requests. Missing metadata options mean the defaults, which allow it.
+func allowsIMDSv1(instance types.Instance) bool {
+	opts := instance.MetadataOptions
+	if opts == nil {
+		return true
+	}
+	return opts.HttpEndpoint != types.InstanceMetadataEndpointStateDisabled &&
+		opts.HttpTokens != types.HttpTokensStateRequired
+}
+
+// checkIMDSv1Usage flags instances that accept IMDSv1 and are reachable from
+// the internet, where an SSRF can leak role credentials. Private instances
+// with the same setting are reported at a lower severity; ec2_imdsv2_required
+// covers the enforcement setting itself.
+func (e *Scanner) checkIMDSv1Usage(instance types.Instance, publicSubnets map[string]bool) []scanner.Finding {
+	instanceID := aws.ToString(instance.InstanceId)
+	if !allowsIMDSv1(instance) {
+		return []scanner.Finding{e.createFinding(
+			"ec2_imdsv1_usage",
+			instanceID,
+			"EC2 instance does not accept IMDSv1",
+			fmt.Sprintf("Instance %s requires IMDSv2 or has the metadata endpoint disabled", instanceID),
+			scanner.StatusPass,
+			scanner.SeverityCritical,
+		)}
+	}
+
+	if instance.PublicIpAddress != nil || publicSubnets[aws.ToString(instance.SubnetId)] {
+		return []scanner.Finding{e.createFinding(
+			"ec2_imdsv1_usage",
+			instanceID,
+			"Internet-reachable EC2 instance accepts IMDSv1",
+			fmt.Sprintf("Instance %s is reachable from the internet and accepts IMDSv1, so an SSRF flaw can expose its role credentials", instanceID),
+			scanner.StatusFail,
+			scanner.SeverityCritical,
+		)}
+	}
+	return []scanner.Finding{e.createFinding(
+		"ec2_imdsv1_usage",
+		instanceID,
+		"Private EC2 instance accepts IMDSv1",
+		fmt.Sprintf("Instance %s accepts IMDSv1 but has no public IP and is not in a public subnet", instanceID),
+		scanner.StatusFail,
+		scanner.SeverityMedium,
+	)}
+}
+
+// checkPublicSubnet flags instances placed in a subnet whose route table
+// sends default traffic to an internet gateway. Unlike ec2_public_ip this
+// catches instances that can gain a public address later, for example from
+// an Elastic IP or the subnet's auto-assign setting. Instances whose subnet
+// could not be resolved are skipped.
+func (e *Scanner) checkPublicSubnet(instance types.Instance, publicSubnets map[string]bool) []scanner.Finding {
+	instanceID := aws.ToString(instance.InstanceId)
+	subnetID := aws.ToString(instance.SubnetId)
+	public, ok := publicSubnets[subnetID]
+	if !ok {
+		return nil
+	}
+
+	if public {
+		return []scanner.Finding{e.createFinding(
+			"ec2_public_subnet",
+			instanceID,
+			"EC2 instance is in a public subnet",
+			fmt.Sprintf("Instance %s is in subnet %s, whose route table sends internet traffic to an internet gateway", instanceID, subnetID),
+			scanner.StatusFail,
+			scanner.SeverityMedium,
+		)}
+	}
+	return []scanner.Finding{e.createFinding(
+		"ec2_public_subnet",
+		instanceID,
+		"EC2 instance is in a private subnet",
+		fmt.Sprintf("Instance %s is in subnet %s, which has no route to an internet gateway", instanceID, subnetID),
+		scanner.StatusPass,
+		scanner.SeverityMedium,
+	)}
+}
+
+// publicSubnets reports, for the subnet of each instance, whether its route
+// table sends default traffic to an internet gateway. Route tables for all
+// the instances' VPCs are fetched in one paginated call.
+func (e *Scanner) publicSubnets(ctx context.Context, instances []types.Instance) (map[string]bool, error) {
+	subnetVPCs := make(map[string]string)
+	var vpcIDs []string
+	for _, instance := range instances {
+		if instance.SubnetId == nil {
+			continue
+		}
+		vpcID := aws.ToString(instance.VpcId)
+		if !slices.Contains(vpcIDs, vpcID) {
+			vpcIDs = append(vpcIDs, vpcID)
+		}
+		subnetVPCs[aws.ToString(instance.SubnetId)] = vpcID
+	}
+	if len(subnetVPCs) == 0 {
+		return nil, nil
+	}
+
+	explicit := make(map[string]bool)
+	mainTables := make(map[string]bool)
+	paginator := ec2.NewDescribeRouteTablesPaginator(e.client, &ec2.DescribeRouteTablesInput{
+		Filters: []types.Filter{{Name: aws.String("vpc-id"), Values: vpcIDs}},
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, table := range output.RouteTables {
+			public := routesToInternet(table)
+			for _, assoc := range table.Associations {
+				switch {
+				case aws.ToBool(assoc.Main):
+					mainTables[aws.ToString(table.VpcId)] = public
+				case assoc.SubnetId != nil:
+					explicit[aws.ToString(assoc.SubnetId)] = public
+				}
+			}
+		}
+	}
+
+	// Subnets without an explicit association use their VPC's main route table.
+	public := make(map[string]bool, len(subnetVPCs))
+	for subnetID, vpcID := range subnetVPCs {
+		if p, ok := explicit[subnetID]; ok {
+			public[subnetID] = p
+		} else {
+			public[subnetID] = mainTables[vpcID]
+		}
+	}
+	return public, nil
+}
+
+// routesToInternet reports whether a route table has a default route to an internet gateway.
+func routesToInternet(table types.RouteTable) bool {
+	for _, route := range table.Routes {
+		if !strings.HasPrefix(aws.ToString(route.GatewayId), "igw-") {
+			continue
+		}
+		if aws.ToString(route.DestinationCidrBlock) == ipv4Any || aws.ToString(route.DestinationIpv6CidrBlock) == ipv6Any {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *Scanner) checkIAMRole(_ context.Context, instance types.Instance) []scanner.Finding {
 	instanceID := aws.ToString(instance.InstanceId)
 	if instance.IamInstanceProfile != nil {
@@ -253,6 +430,7 @@ func (e *Scanner) checkDangerousPorts(ctx context.Context) []scanner.Finding {
 	if err != nil {
 		return nil
 	}
+	dangerousPorts := e.dangerousPortSet()
 	for _, sg := range sgs.SecurityGroups {
 		sgID := aws.ToString(sg.GroupId)
 		for _, perm := range sg.IpPermissions {
@@ -296,3 +474,219 @@ func (e *Scanner) checkDangerousPorts(ctx context.Context) []scanner.Finding {
 	}
 	return findings
 }
+
+// checkUnusedSecurityGroups flags security groups that are not attached to any
+// instance or network interface and are not referenced by the rules of an
+// attached group. ENIs cover non-instance usage such as load balancers,
+// Lambda, and RDS. References from unused groups do not count, so a chain of
+// leftover groups is reported in full.
+func (e *Scanner) checkUnusedSecurityGroups(ctx context.Context, instances []types.Instance) []scanner.Finding {
+	var groups []types.SecurityGroup
+	sgPaginator := ec2.NewDescribeSecurityGroupsPaginator(e.client, &ec2.DescribeSecurityGroupsInput{})
+	for sgPaginator.HasMorePages() {
+		output, err := sgPaginator.NextPage(ctx)
+		if err != nil {
+			return e.unusedSecurityGroupsError(ctx, "security-groups", err)
+		}
+		groups = append(groups, output.SecurityGroups...)
+	}
+
+	attached := make(map[string]bool)
+	for _, instance := range instances {
+		for _, sg := range instance.SecurityGroups {
+			attached[aws.ToString(sg.GroupId)] = true
+		}
+	}
+
+	eniPaginator := ec2.NewDescribeNetworkInterfacesPaginator(e.client, &ec2.DescribeNetworkInterfacesInput{})
+	for eniPaginator.HasMorePages() {
+		output, err := eniPaginator.NextPage(ctx)
+		if err != nil {
+			// Without ENI data every non-instance group would look orphaned
+			return e.unusedSecurityGroupsError(ctx, "network-interfaces", err)
+		}
+		for _, eni := range output.NetworkInterfaces {
+			for _, sg := range eni.Groups {
+				attached[aws.ToString(sg.GroupId)] = true
+			}
+		}
+	}
+
+	inUse := maps.Clone(attached)
+	for _, sg := range groups {
+		if !attached[aws.ToString(sg.GroupId)] {
+			continue
+		}
+		for _, perms := range [][]types.IpPermission{sg.IpPermissions, sg.IpPermissionsEgress} {
+			for _, perm := range perms {
+				for _, pair := range perm.UserIdGroupPairs {
+					inUse[aws.ToString(pair.GroupId)] = true
+				}
+			}
+		}
+	}
+
+	var findings []scanner.Finding
+	for _, sg := range groups {
+		sgID := aws.ToString(sg.GroupId)
+		// Default groups cannot be deleted, so reporting them as unused is noise
+		if aws.ToString(sg.GroupName) == "default" || inUse[sgID] {
+			continue
+		}
+		findings = append(findings, e.createFinding(
+			"ec2_unused_sg",
+			sgID,
+			"Security group is not in use",
+			fmt.Sprintf("SG %s (%s) is not attached to any instance or network interface", sgID, aws.ToString(sg.GroupName)),
+			scanner.StatusFail,
+			scanner.SeverityLow,
+		))
+	}
+	return findings
+}
+
+// unusedSecurityGroupsError reports a failed listing of resource for the
+// unused security group check, which cannot be evaluated without it.
+func (e *Scanner) unusedSecurityGroupsError(ctx context.Context, resource string, err error) []scanner.Finding {
+	if scanner.IsAccessDenied(err) {
+		return []scanner.Finding{e.accessDeniedFinding("ec2_unused_sg", resource, err)}
+	}
+	scanner.Logf(ctx, "Warning: failed to list %s for unused security group check: %v", resource, err)
+	return nil
+}
+
+// defaultSecurityGroupsError reports a failed listing of resource for the
+// default security group checks in checkIDs, which cannot be evaluated
+// without it.
+func (e *Scanner) defaultSecurityGroupsError(ctx context.Context, resource string, err error, checkIDs ...string) []scanner.Finding {
+	if !scanner.IsAccessDenied(err) {
+		scanner.Logf(ctx, "Warning: failed to list %s for default security group checks: %v", resource, err)
+		return nil
+	}
+	findings := make([]scanner.Finding, len(checkIDs))
+	for i, checkID := range checkIDs {
+		findings[i] = e.accessDeniedFinding(checkID, resource, err)
+	}
+	return findings
+}
+
+// defaultSecurityGroupName is the name of the security group AWS creates in
+// every VPC. It cannot be deleted or renamed.
+const defaultSecurityGroupName = "default"
+
+// checkDefaultSecurityGroupUsage flags default security groups that are
+// attached to instances or network interfaces, and, separately, default
+// groups that allow any traffic. CIS expects resources to use purpose-built
+// groups and the default group to deny all traffic.
+func (e *Scanner) checkDefaultSecurityGroupUsage(ctx context.Context, instances []types.Instance) []scanner.Finding {
+	var defaults []types.SecurityGroup
+	sgPaginator := ec2.NewDescribeSecurityGroupsPaginator(e.client, &ec2.DescribeSecurityGroupsInput{
+		Filters: []types.Filter{{Name: aws.String("group-name"), Values: []string{defaultSecurityGroupName}}},
+	})
+	for sgPaginator.HasMorePages() {
+		output, err := sgPaginator.NextPage(ctx)
+		if err != nil {
+			return e.defaultSecurityGroupsError(ctx, "security-groups", err, "ec2_default_sg_in_use", "ec2_default_sg_rules")
+		}
+		for _, sg := range output.SecurityGroups {
+			if aws.ToString(sg.GroupName) == defaultSecurityGroupName {
+				defaults = append(defaults, sg)
+			}
+		}
+	}
+	if len(defaults) == 0 {
+		return nil
+	}
+
+	defaultIDs := make([]string, len(defaults))
+	attached := make(map[string][]string, len(defaults))
+	attach := func(sgID, resourceID string) {
+		if _, ok := attached[sgID]; ok && !slices.Contains(attached[sgID], resourceID) {
+			attached[sgID] = append(attached[sgID], resourceID)
+		}
+	}
+	for i, sg := range defaults {
+		defaultIDs[i] = aws.ToString(sg.GroupId)
+		attached[defaultIDs[i]] = nil
+	}
+
+	for _, instance := range instances {
+		for _, sg := range instance.SecurityGroups {
+			attach(aws.ToString(sg.GroupId), aws.ToString(instance.InstanceId))
+		}
+	}
+	eniPaginator := ec2.NewDescribeNetworkInterfacesPaginator(e.client, &ec2.DescribeNetworkInterfacesInput{
+		Filters: []types.Filter{{Name: aws.String("group-id"), Values: defaultIDs}},
+	})
+	var findings []scanner.Finding
+	inUseKnown := true
+	for eniPaginator.HasMorePages() {
+		output, err := eniPaginator.NextPage(ctx)
+		if err != nil {
+			// Without ENI data, groups used only by load balancers, Lambda
+			// functions, and the like would wrongly look unused, so only the
+			// rules are evaluated.
+			findings = e.defaultSecurityGroupsError(ctx, "network-interfaces", err, "ec2_default_sg_in_use")
+			inUseKnown = false
+			break
+		}
+		for _, eni := range output.NetworkInterfaces {
+			// An instance's interfaces are reported under the instance.
+			resourceID := aws.ToString(eni.NetworkInterfaceId)
+			if eni.Attachment != nil && eni.Attachment.InstanceId != nil {
+				resourceID = aws.ToString(eni.Attachment.InstanceId)
+			}
+			for _, sg := range eni.Groups {
+				attach(aws.ToString(sg.GroupId), resourceID)
+			}
+		}
+	}
+
+	for _, sg := range defaults {
+		sgID := aws.ToString(sg.GroupId)
+		vpcID := aws.ToString(sg.VpcId)
+
+		switch users := attached[sgID]; {
+		case !inUseKnown:
+		case len(users) > 0:
+			findings = append(findings, e.createFinding(
+				"ec2_default_sg_in_use",
+				sgID,
+				"Default security group is in use",
+				fmt.Sprintf("Default SG %s of VPC %s is attached to %d resource(s): %s", sgID, vpcID, len(users), strings.Join(users, ", ")),
+				scanner.StatusFail,
+				scanner.SeverityMedium,
+			))
+		default:
+			findings = append(findings, e.createFinding(
+				"ec2_default_sg_in_use",
+				sgID,
+				"Default security group is not in use",
+				fmt.Sprintf("Default SG %s of VPC %s is not attached to any instance or network interface", sgID, vpcID),
+				scanner.StatusPass,
+				scanner.SeverityMedium,
+			))
+		}
+
+		if ingress, egress := len(sg.IpPermissions), len(sg.IpPermissionsEgress); ingress > 0 || egress > 0 {
+			findings = append(findings, e.createFinding(
+				"ec2_default_sg_rules",
+				sgID,
+				"Default security group allows traffic",
+				fmt.Sprintf("Default SG %s of VPC %s has %d ingress and %d egress rule(s); it should have none", sgID, vpcID, ingress, egress),
+				scanner.StatusFail,
+				scanner.SeverityMedium,
+			))
+		} else {
+			findings = append(findings, e.createFinding(
+				"ec2_default_sg_rules",
+				sgID,
+				"Default security group restricts all traffic",
+				fmt.Sprintf("Default SG %s of VPC %s has no ingress or egress rules", sgID, vpcID),
+				scanner.StatusPass,
+				scanner.SeverityMedium,
+			))
+		}
+	}
+	return findings
+}
@@ -0,0 +1,556 @@
+package ec2
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+)
+
+// fakeEC2Client implements ec2API with canned responses.
+// Methods that are not overridden panic via the nil embedded interface.
+type fakeEC2Client struct {
+	ec2API
+	instances            []types.Instance
+	volumes              []types.Volume
+	securityGroups       []types.SecurityGroup
+	networkInterfaces    []types.NetworkInterface
+	securityGroupsErr    error
+	networkInterfacesErr error
+	routeTables          []types.RouteTable
+
+	// terminationProtected lists instances with termination protection;
+	// instanceAttributeErr fails every DescribeInstanceAttribute call.
+	terminationProtected map[string]bool
+	instanceAttributeErr error
+
+	// snapshots are returned snapshotPageSize at a time when it is set.
+	snapshots           []types.Snapshot
+	snapshotPageSize    int
+	snapshotPermissions map[string][]types.CreateVolumePermission
+
+	describeInstancesCalls  int
+	describeRouteTableCalls int
+	describeSnapshotsCalls  int
+	snapshotsInput          *ec2.DescribeSnapshotsInput
+}
+
+func (f *fakeEC2Client) DescribeInstances(_ context.Context, _ *ec2.DescribeInstancesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+	f.describeInstancesCalls++
+	return &ec2.DescribeInstancesOutput{Reservations: []types.Reservation{{Instances: f.instances}}}, nil
+}
+
+func (f *fakeEC2Client) DescribeVolumes(_ context.Context, _ *ec2.DescribeVolumesInput, _ ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error) {
+	return &ec2.DescribeVolumesOutput{Volumes: f.volumes}, nil
+}
+
+func (f *fakeEC2Client) DescribeSecurityGroups(_ context.Context, _ *ec2.DescribeSecurityGroupsInput, _ ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error) {
+	if f.securityGroupsErr != nil {
+		return nil, f.securityGroupsErr
+	}
+	return &ec2.DescribeSecurityGroupsOutput{SecurityGroups: f.securityGroups}, nil
+}
+
+func (f *fakeEC2Client) DescribeNetworkInterfaces(_ context.Context, _ *ec2.DescribeNetworkInterfacesInput, _ ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	if f.networkInterfacesErr != nil {
+		return nil, f.networkInterfacesErr
+	}
+	return &ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: f.networkInterfaces}, nil
+}
+
+func (f *fakeEC2Client) DescribeRouteTables(_ context.Context, _ *ec2.DescribeRouteTablesInput, _ ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error) {
+	f.describeRouteTableCalls++
+	return &ec2.DescribeRouteTablesOutput{RouteTables: f.routeTables}, nil
+}
+
+func (f *fakeEC2Client) DescribeInstanceAttribute(_ context.Context, params *ec2.DescribeInstanceAttributeInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstanceAttributeOutput, error) {
+	if f.instanceAttributeErr != nil {
+		return nil, f.instanceAttributeErr
+	}
+	return &ec2.DescribeInstanceAttributeOutput{
+		InstanceId:            params.InstanceId,
+		DisableApiTermination: &types.AttributeBooleanValue{Value: aws.Bool(f.terminationProtected[aws.ToString(params.InstanceId)])},
+	}, nil
+}
+
+func (f *fakeEC2Client) DescribeSnapshots(_ context.Context, params *ec2.DescribeSnapshotsInput, _ ...func(*ec2.Options)) (*ec2.DescribeSnapshotsOutput, error) {
+	f.describeSnapshotsCalls++
+	f.snapshotsInput = params
+	if f.snapshotPageSize == 0 {
+		return &ec2.DescribeSnapshotsOutput{Snapshots: f.snapshots}, nil
+	}
+
+	start := 0
+	if params.NextToken != nil {
+		start, _ = strconv.Atoi(*params.NextToken)
+	}
+	end := min(start+f.snapshotPageSize, len(f.snapshots))
+	out := &ec2.DescribeSnapshotsOutput{Snapshots: f.snapshots[start:end]}
+	if end < len(f.snapshots) {
+		out.NextToken = aws.String(strconv.Itoa(end))
+	}
+	return out, nil
+}
+
+func (f *fakeEC2Client) DescribeSnapshotAttribute(_ context.Context, params *ec2.DescribeSnapshotAttributeInput, _ ...func(*ec2.Options)) (*ec2.DescribeSnapshotAttributeOutput, error) {
+	perms, ok := f.snapshotPermissions[aws.ToString(params.SnapshotId)]
+	if !ok {
+		return nil, errors.New("snapshot not found")
+	}
+	return &ec2.DescribeSnapshotAttributeOutput{SnapshotId: params.SnapshotId, CreateVolumePermissions: perms}, nil
+}
+
+func newTestScanner(client ec2API) *Scanner {
+	return &Scanner{
+		client:    client,
+		region:    "us-east-1",
+		accountID: "123456789012",
+	}
+}
+
+func TestCheckUnusedSecurityGroups(t *testing.T) {
+	client := &fakeEC2Client{
+		securityGroups: []types.SecurityGroup{
+			{GroupId: aws.String("sg-default"), GroupName: aws.String("default")},
+			{GroupId: aws.String("sg-instance"), GroupName: aws.String("web")},
+			{GroupId: aws.String("sg-alb"), GroupName: aws.String("alb")},
+			{
+				GroupId:   aws.String("sg-db"),
+				GroupName: aws.String("db"),
+				IpPermissions: []types.IpPermission{
+					{UserIdGroupPairs: []types.UserIdGroupPair{{GroupId: aws.String("sg-referenced")}}},
+				},
+			},
+			{GroupId: aws.String("sg-referenced"), GroupName: aws.String("app")},
+			{
+				GroupId:   aws.String("sg-orphan"),
+				GroupName: aws.String("old-bastion"),
+				IpPermissions: []types.IpPermission{
+					{UserIdGroupPairs: []types.UserIdGroupPair{{GroupId: aws.String("sg-orphan-peer")}}},
+				},
+			},
+			// Referenced only by the unused sg-orphan, so unused as well.
+			{GroupId: aws.String("sg-orphan-peer"), GroupName: aws.String("old-jump")},
+		},
+		networkInterfaces: []types.NetworkInterface{
+			{Groups: []types.GroupIdentifier{{GroupId: aws.String("sg-alb")}}},
+			{Groups: []types.GroupIdentifier{{GroupId: aws.String("sg-db")}}},
+		},
+	}
+	instances := []types.Instance{
+		{
+			InstanceId:     aws.String("i-123"),
+			SecurityGroups: []types.GroupIdentifier{{GroupId: aws.String("sg-instance")}},
+		},
+	}
+
+	findings := newTestScanner(client).checkUnusedSecurityGroups(context.Background(), instances)
+
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d: %+v", len(findings), findings)
+	}
+	if findings[0].ResourceID != "sg-orphan" || findings[1].ResourceID != "sg-orphan-peer" {
+		t.Errorf("ResourceIDs = %v, %v; want sg-orphan, sg-orphan-peer", findings[0].ResourceID, findings[1].ResourceID)
+	}
+	f := findings[0]
+	if f.CheckID != "ec2_unused_sg" {
+		t.Errorf("CheckID = %v, want ec2_unused_sg", f.CheckID)
+	}
+	if f.Severity != scanner.SeverityLow || f.Status != scanner.StatusFail {
+		t.Errorf("got %s/%s, want FAIL/LOW", f.Status, f.Severity)
+	}
+	if len(f.Compliance) == 0 {
+		t.Error("expected compliance mappings for ec2_unused_sg")
+	}
+}
+
+func TestCheckUnusedSecurityGroups_ListError(t *testing.T) {
+	client := &fakeEC2Client{securityGroupsErr: &smithy.GenericAPIError{Code: "UnauthorizedOperation"}}
+
+	findings := newTestScanner(client).checkUnusedSecurityGroups(context.Background(), nil)
+
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %+v", len(findings), findings)
+	}
+	if f := findings[0]; f.CheckID != "ec2_unused_sg" || f.Status != scanner.StatusError {
+		t.Errorf("got %s/%s, want an ERROR finding for ec2_unused_sg", f.CheckID, f.Status)
+	}
+
+	client.securityGroupsErr = errors.New("throttled")
+	if findings := newTestScanner(client).checkUnusedSecurityGroups(context.Background(), nil); len(findings) != 0 {
+		t.Errorf("expected no findings for a logged error, got %+v", findings)
+	}
+}
+
+func TestCheckDefaultSecurityGroupUsage(t *testing.T) {
+	allowAll := []types.IpPermission{{IpProtocol: aws.String("-1"), IpRanges: []types.IpRange{{CidrIp: aws.String(ipv4Any)}}}}
+	client := &fakeEC2Client{
+		securityGroups: []types.SecurityGroup{
+			{GroupId: aws.String("sg-a"), GroupName: aws.String("default"), VpcId: aws.String("vpc-a"), IpPermissionsEgress: allowAll},
+			{GroupId: aws.String("sg-b"), GroupName: aws.String("default"), VpcId: aws.String("vpc-b")},
+			{GroupId: aws.String("sg-c"), GroupName: aws.String("default"), VpcId: aws.String("vpc-c"), IpPermissions: allowAll},
+			{GroupId: aws.String("sg-d"), GroupName: aws.String("default"), VpcId: aws.String("vpc-d")},
+			{GroupId: aws.String("sg-web"), GroupName: aws.String("web"), VpcId: aws.String("vpc-a"), IpPermissions: allowAll},
+		},
+		networkInterfaces: []types.NetworkInterface{
+			{
+				NetworkInterfaceId: aws.String("eni-1"),
+				Attachment:         &types.NetworkInterfaceAttachment{InstanceId: aws.String("i-1")},
+				Groups:             []types.GroupIdentifier{{GroupId: aws.String("sg-a")}},
+			},
+			{NetworkInterfaceId: aws.String("eni-lb"), Groups: []types.GroupIdentifier{{GroupId: aws.String("sg-b")}}},
+			{NetworkInterfaceId: aws.String("eni-web"), Groups: []types.GroupIdentifier{{GroupId: aws.String("sg-web")}}},
+		},
+	}
+	instances := []types.Instance{
+		{InstanceId: aws.String("i-1"), SecurityGroups: []types.GroupIdentifier{{GroupId: aws.String("sg-a")}}},
+		{InstanceId: aws.String("i-2"), SecurityGroups: []types.GroupIdentifier{{GroupId: aws.String("sg-web")}}},
+	}
+
+	findings := newTestScanner(client).checkDefaultSecurityGroupUsage(context.Background(), instances)
+
+	got := make(map[string]scanner.Finding)
+	for _, f := range findings {
+		got[f.CheckID+"/"+f.ResourceID] = f
+	}
+	want := map[string]scanner.FindingStatus{
+		"ec2_default_sg_in_use/sg-a": scanner.StatusFail,
+		"ec2_default_sg_rules/sg-a":  scanner.StatusFail,
+		"ec2_default_sg_in_use/sg-b": scanner.StatusFail,
+		"ec2_default_sg_rules/sg-b":  scanner.StatusPass,
+		"ec2_default_sg_in_use/sg-c": scanner.StatusPass,
+		"ec2_default_sg_rules/sg-c":  scanner.StatusFail,
+		"ec2_default_sg_in_use/sg-d": scanner.StatusPass,
+		"ec2_default_sg_rules/sg-d":  scanner.StatusPass,
+	}
+	if len(findings) != len(want) {
+		t.Errorf("got %d findings, want %d: %+v", len(findings), len(want), findings)
+	}
+	for key, status := range want {
+		f, ok := got[key]
+		if !ok {
+			t.Errorf("missing finding %s", key)
+			continue
+		}
+		if f.Status != status || f.Severity != scanner.SeverityMedium {
+			t.Errorf("%s = %s/%s, want %s/MEDIUM", key, f.Status, f.Severity, status)
+		}
+	}
+
+	// i-1 is attached both directly and through its interface; it is listed once.
+	if desc := got["ec2_default_sg_in_use/sg-a"].Description; !strings.Contains(desc, "1 resource(s): i-1") {
+		t.Errorf("sg-a description = %q, want it to list i-1 once", desc)
+	}
+	if desc := got["ec2_default_sg_in_use/sg-b"].Description; !strings.Contains(desc, "eni-lb") {
+		t.Errorf("sg-b description = %q, want it to list eni-lb", desc)
+	}
+}
+
+func TestCheckDefaultSecurityGroupUsage_ListError(t *testing.T) {
+	denied := &smithy.GenericAPIError{Code: "UnauthorizedOperation"}
+	client := &fakeEC2Client{securityGroupsErr: denied}
+
+	findings := newTestScanner(client).checkDefaultSecurityGroupUsage(context.Background(), nil)
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d: %+v", len(findings), findings)
+	}
+	for _, f := range findings {
+		if f.Status != scanner.StatusError {
+			t.Errorf("%s = %s, want an ERROR finding", f.CheckID, f.Status)
+		}
+	}
+
+	client.securityGroupsErr = errors.New("throttled")
+	if findings := newTestScanner(client).checkDefaultSecurityGroupUsage(context.Background(), nil); len(findings) != 0 {
+		t.Errorf("expected no findings for a logged error, got %+v", findings)
+	}
+
+	// Without network interfaces only the rules can be evaluated.
+	client = &fakeEC2Client{
+		securityGroups:       []types.SecurityGroup{{GroupId: aws.String("sg-a"), GroupName: aws.String("default"), VpcId: aws.String("vpc-a")}},
+		networkInterfacesErr: denied,
+	}
+	got := make(map[string]scanner.FindingStatus)
+	for _, f := range newTestScanner(client).checkDefaultSecurityGroupUsage(context.Background(), nil) {
+		got[f.CheckID+"/"+f.ResourceID] = f.Status
+	}
+	want := map[string]scanner.FindingStatus{
+		"ec2_default_sg_in_use/network-interfaces": scanner.StatusError,
+		"ec2_default_sg_rules/sg-a":                scanner.StatusPass,
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("findings = %v, want %v", got, want)
+	}
+}
+
+func TestCheckDangerousPorts_Configured(t *testing.T) {
+	openRange := func(from, to int32) types.IpPermission {
+		return types.IpPermission{FromPort: aws.Int32(from), ToPort: aws.Int32(to), IpRanges: []types.IpRange{{CidrIp: aws.String(ipv4Any)}}}
+	}
+	client := &fakeEC2Client{securityGroups: []types.SecurityGroup{{
+		GroupId:       aws.String("sg-1"),
+		IpPermissions: []types.IpPermission{openRange(22, 22), openRange(9200, 9200), openRange(11211, 11211)},
+	}}}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []string // flagged services
+	}{
+		{"defaults", nil, []string{"SSH"}},
+		{"added ports", []Option{WithDangerousPorts(map[int32]string{9200: "Elasticsearch", 11211: "memcached"})}, []string{"Elasticsearch", "SSH", "memcached"}},
+		{"removed default", []Option{WithoutDangerousPorts(22)}, nil},
+		{"replaced defaults", []Option{WithOnlyDangerousPorts(map[int32]string{9200: "Elasticsearch"})}, []string{"Elasticsearch"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestScanner(client)
+			for _, opt := range tt.opts {
+				opt(s)
+			}
+
+			var got []string
+			for _, f := range s.checkDangerousPorts(context.Background()) {
+				got = append(got, strings.TrimSuffix(strings.TrimPrefix(f.Title, "Security group exposes "), " port to internet"))
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("flagged %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, ok := defaultDangerousPorts[9200]; ok || defaultDangerousPorts[22] != "SSH" {
+		t.Error("options modified the default dangerous ports")
+	}
+}
+
+func TestScanner_Scan_ReusesCachedInstances(t *testing.T) {
+	client := &fakeEC2Client{
+		instances: []types.Instance{{InstanceId: aws.String("i-123")}},
+	}
+	s := newTestScanner(client)
+	base := scanner.WithScope(context.Background(), scanner.Scope{SkipAccountChecks: true})
+	ctx := scanner.WithResourceCache(base, scanner.NewResourceCache(scanner.CacheOptions{Enabled: true}))
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.Scan(ctx, "us-east-1"); err != nil {
+			t.Fatalf("scan %d: %v", i+1, err)
+		}
+	}
+	if client.describeInstancesCalls != 1 {
+		t.Errorf("DescribeInstances called %d times with cache, want 1", client.describeInstancesCalls)
+	}
+
+	// Without a cache every scan lists instances again.
+	if _, err := s.Scan(base, "us-east-1"); err != nil {
+		t.Fatalf("uncached scan: %v", err)
+	}
+	if client.describeInstancesCalls != 2 {
+		t.Errorf("DescribeInstances called %d times after uncached scan, want 2", client.describeInstancesCalls)
+	}
+}
+
+func TestCheckIMDSv1Usage(t *testing.T) {
+	publicSubnets := map[string]bool{"subnet-public": true, "subnet-private": false}
+
+	tests := []struct {
+		name     string
+		options  *types.InstanceMetadataOptionsResponse
+		publicIP *string
+		subnet   string
+		want     scanner.FindingStatus
+		severity scanner.Severity
+	}{
+		{
+			name:     "tokens required",
+			options:  &types.InstanceMetadataOptionsResponse{HttpEndpoint: types.InstanceMetadataEndpointStateEnabled, HttpTokens: types.HttpTokensStateRequired},
+			publicIP: aws.String("203.0.113.10"),
+			want:     scanner.StatusPass,
+			severity: scanner.SeverityCritical,
+		},
+		{
+			name:     "endpoint disabled",
+			options:  &types.InstanceMetadataOptionsResponse{HttpEndpoint: types.InstanceMetadataEndpointStateDisabled, HttpTokens: types.HttpTokensStateOptional},
+			publicIP: aws.String("203.0.113.10"),
+			want:     scanner.StatusPass,
+			severity: scanner.SeverityCritical,
+		},
+		{
+			name:     "optional tokens with public IP",
+			options:  &types.InstanceMetadataOptionsResponse{HttpEndpoint: types.InstanceMetadataEndpointStateEnabled, HttpTokens: types.HttpTokensStateOptional},
+			publicIP: aws.String("203.0.113.10"),
+			want:     scanner.StatusFail,
+			severity: scanner.SeverityCritical,
+		},
+		{
+			name:     "optional tokens in public subnet",
+			options:  &types.InstanceMetadataOptionsResponse{HttpEndpoint: types.InstanceMetadataEndpointStateEnabled, HttpTokens: types.HttpTokensStateOptional},
+			subnet:   "subnet-public",
+			want:     scanner.StatusFail,
+			severity: scanner.SeverityCritical,
+		},
+		{
+			name:     "optional tokens in private subnet",
+			options:  &types.InstanceMetadataOptionsResponse{HttpEndpoint: types.InstanceMetadataEndpointStateEnabled, HttpTokens: types.HttpTokensStateOptional},
+			subnet:   "subnet-private",
+			want:     scanner.StatusFail,
+			severity: scanner.SeverityMedium,
+		},
+		{
+			name:     "default metadata options with public IP",
+			publicIP: aws.String("203.0.113.10"),
+			want:     scanner.StatusFail,
+			severity: scanner.SeverityCritical,
+		},
+		{
+			name:     "default metadata options in private subnet",
+			subnet:   "subnet-private",
+			want:     scanner.StatusFail,
+			severity: scanner.SeverityMedium,
+		},
+	}
+
+	s := newTestScanner(&fakeEC2Client{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := types.Instance{
+				InstanceId:      aws.String("i-123"),
+				MetadataOptions: tt.options,
+				PublicIpAddress: tt.publicIP,
+			}
+			if tt.subnet != "" {
+				instance.SubnetId = aws.String(tt.subnet)
+			}
+
+			findings := s.checkIMDSv1Usage(instance, publicSubnets)
+
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			f := findings[0]
+			if f.CheckID != "ec2_imdsv1_usage" || f.Status != tt.want || f.Severity != tt.severity {
+				t.Errorf("got %s %s/%s, want ec2_imdsv1_usage %s/%s", f.CheckID, f.Status, f.Severity, tt.want, tt.severity)
+			}
+		})
+	}
+}
+
+func TestPublicSubnets(t *testing.T) {
+	igwRoute := types.Route{DestinationCidrBlock: aws.String(ipv4Any), GatewayId: aws.String("igw-123")}
+	natRoute := types.Route{DestinationCidrBlock: aws.String(ipv4Any), NatGatewayId: aws.String("nat-123")}
+	client := &fakeEC2Client{
+		routeTables: []types.RouteTable{
+			{
+				VpcId:        aws.String("vpc-1"),
+				Routes:       []types.Route{igwRoute},
+				Associations: []types.RouteTableAssociation{{Main: aws.Bool(true)}},
+			},
+			{
+				VpcId:        aws.String("vpc-1"),
+				Routes:       []types.Route{natRoute},
+				Associations: []types.RouteTableAssociation{{SubnetId: aws.String("subnet-private")}},
+			},
+		},
+	}
+	instance := func(id, subnet string) types.Instance {
+		return types.Instance{InstanceId: aws.String(id), VpcId: aws.String("vpc-1"), SubnetId: aws.String(subnet)}
+	}
+
+	public, err := newTestScanner(client).publicSubnets(context.Background(), []types.Instance{
+		instance("i-1", "subnet-private"),
+		instance("i-2", "subnet-main"),
+	})
+	if err != nil {
+		t.Fatalf("publicSubnets() error = %v", err)
+	}
+	if public["subnet-private"] {
+		t.Error("subnet routed through a NAT gateway reported as public")
+	}
+	if !public["subnet-main"] {
+		t.Error("subnet using a main route table with an internet gateway not reported as public")
+	}
+}
+
+func TestCheckPublicSubnet(t *testing.T) {
+	igwRoute := types.Route{DestinationCidrBlock: aws.String(ipv4Any), GatewayId: aws.String("igw-123")}
+	igwIPv6Route := types.Route{DestinationIpv6CidrBlock: aws.String(ipv6Any), GatewayId: aws.String("igw-123")}
+	natRoute := types.Route{DestinationCidrBlock: aws.String(ipv4Any), NatGatewayId: aws.String("nat-123")}
+	localRoute := types.Route{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local")}
+
+	instance := func(id, vpc, subnet string) types.Instance {
+		i := types.Instance{InstanceId: aws.String(id), VpcId: aws.String(vpc)}
+		if subnet != "" {
+			i.SubnetId = aws.String(subnet)
+		}
+		return i
+	}
+	client := &fakeEC2Client{
+		instances: []types.Instance{
+			instance("i-web", "vpc-1", "subnet-web"),
+			instance("i-app", "vpc-1", "subnet-app"),
+			instance("i-main", "vpc-1", "subnet-unassociated"),
+			instance("i-v6", "vpc-2", "subnet-v6"),
+			instance("i-isolated", "vpc-2", "subnet-isolated"),
+			instance("i-classic", "", ""),
+		},
+		routeTables: []types.RouteTable{
+			{
+				VpcId:        aws.String("vpc-1"),
+				Routes:       []types.Route{localRoute, igwRoute},
+				Associations: []types.RouteTableAssociation{{Main: aws.Bool(true)}, {SubnetId: aws.String("subnet-web")}},
+			},
+			{
+				VpcId:        aws.String("vpc-1"),
+				Routes:       []types.Route{localRoute, natRoute},
+				Associations: []types.RouteTableAssociation{{SubnetId: aws.String("subnet-app")}},
+			},
+			{
+				VpcId:        aws.String("vpc-2"),
+				Routes:       []types.Route{localRoute, igwIPv6Route},
+				Associations: []types.RouteTableAssociation{{SubnetId: aws.String("subnet-v6")}},
+			},
+			{
+				VpcId:        aws.String("vpc-2"),
+				Routes:       []types.Route{localRoute},
+				Associations: []types.RouteTableAssociation{{Main: aws.Bool(true)}},
+			},
+		},
+	}
+
+	ctx := scanner.WithScope(context.Background(), scanner.Scope{SkipAccountChecks: true})
+	findings, err := newTestScanner(client).Scan(ctx, "us-east-1")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	got := make(map[string]scanner.FindingStatus)
+	for _, f := range findings {
+		if f.CheckID == "ec2_public_subnet" {
+			got[f.ResourceID] = f.Status
+		}
+	}
+	want := map[string]scanner.FindingStatus{
+		"i-web":      scanner.StatusFail,
+		"i-app":      scanner.StatusPass,
+		"i-main":     scanner.StatusFail,
+		"i-v6":       scanner.StatusFail,
+		"i-isolated": scanner.StatusPass,
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("ec2_public_subnet statuses = %v, want %v", got, want)
+	}
+	if client.describeRouteTableCalls != 1 {
+		t.Errorf("DescribeRouteTables called %d times, want 1 per scan", client.describeRouteTableCalls)
+	}
+}
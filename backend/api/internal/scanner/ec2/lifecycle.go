@@ -0,0 +1,120 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// transitionTimePattern extracts the timestamp EC2 appends to the state
+// transition reason, as in "User initiated (2024-01-15 10:30:45 GMT)".
+var transitionTimePattern = regexp.MustCompile(`\((\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}) GMT\)`)
+
+// checkTerminationProtection flags instances that can be terminated through
+// the API without first disabling termination protection.
+func (e *Scanner) checkTerminationProtection(ctx context.Context, instance types.Instance) []scanner.Finding {
+	instanceID := aws.ToString(instance.InstanceId)
+	if !isLive(instance) {
+		return nil
+	}
+
+	out, err := e.client.DescribeInstanceAttribute(ctx, &ec2.DescribeInstanceAttributeInput{
+		InstanceId: instance.InstanceId,
+		Attribute:  types.InstanceAttributeNameDisableApiTermination,
+	})
+	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{e.accessDeniedFinding("ec2_termination_protection", instanceID, err)}
+		}
+		scanner.Logf(ctx, "Warning: failed to describe termination protection for %s: %v", instanceID, err)
+		return nil
+	}
+
+	if out.DisableApiTermination != nil && aws.ToBool(out.DisableApiTermination.Value) {
+		return []scanner.Finding{e.createFinding(
+			"ec2_termination_protection",
+			instanceID,
+			"EC2 termination protection is enabled",
+			fmt.Sprintf("Instance %s cannot be terminated until termination protection is disabled", instanceID),
+			scanner.StatusPass,
+			scanner.SeverityLow,
+		)}
+	}
+	return []scanner.Finding{e.createFinding(
+		"ec2_termination_protection",
+		instanceID,
+		"EC2 termination protection is disabled",
+		fmt.Sprintf("Instance %s can be terminated by a single API call or console action", instanceID),
+		scanner.StatusFail,
+		scanner.SeverityLow,
+	)}
+}
+
+// isLive reports whether an instance still exists, i.e. is not terminated or
+// on its way to being terminated.
+func isLive(instance types.Instance) bool {
+	if instance.State == nil {
+		return true
+	}
+	switch instance.State.Name {
+	case types.InstanceStateNameShuttingDown, types.InstanceStateNameTerminated:
+		return false
+	}
+	return true
+}
+
+// checkStoppedInstance flags instances that have been stopped for longer
+// than the configured threshold as of now. Instances that are not stopped,
+// or whose stop time cannot be determined, are not reported.
+func (e *Scanner) checkStoppedInstance(instance types.Instance, now time.Time) []scanner.Finding {
+	if instance.State == nil || instance.State.Name != types.InstanceStateNameStopped {
+		return nil
+	}
+	instanceID := aws.ToString(instance.InstanceId)
+	stoppedAt, ok := stoppedSince(instance)
+	if !ok {
+		return nil
+	}
+
+	days := int(now.Sub(stoppedAt).Hours() / 24)
+	if now.Sub(stoppedAt) > e.stoppedMaxAge() {
+		return []scanner.Finding{e.createFinding(
+			"ec2_long_stopped",
+			instanceID,
+			"EC2 instance has been stopped for a long time",
+			fmt.Sprintf("Instance %s has been stopped since %s (%d days) and still incurs EBS and Elastic IP charges",
+				instanceID, stoppedAt.Format(time.DateOnly), days),
+			scanner.StatusFail,
+			scanner.SeverityLow,
+		)}
+	}
+	return []scanner.Finding{e.createFinding(
+		"ec2_long_stopped",
+		instanceID,
+		"EC2 instance was stopped recently",
+		fmt.Sprintf("Instance %s has been stopped since %s (%d days)", instanceID, stoppedAt.Format(time.DateOnly), days),
+		scanner.StatusPass,
+		scanner.SeverityLow,
+	)}
+}
+
+// stoppedSince returns when the instance was stopped, parsed from its state
+// transition reason.
+func stoppedSince(instance types.Instance) (time.Time, bool) {
+	m := transitionTimePattern.FindStringSubmatch(aws.ToString(instance.StateTransitionReason))
+	if m == nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.DateTime, m[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
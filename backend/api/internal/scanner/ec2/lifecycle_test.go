@@ -0,0 +1,122 @@
+package ec2
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+)
+
+func TestCheckStoppedInstance(t *testing.T) {
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	stopped := func(reason string) types.Instance {
+		return types.Instance{
+			InstanceId:            aws.String("i-123"),
+			State:                 &types.InstanceState{Name: types.InstanceStateNameStopped},
+			StateTransitionReason: aws.String(reason),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		maxDays  int
+		instance types.Instance
+		want     scanner.FindingStatus // empty when no finding is expected
+	}{
+		{"stopped yesterday", 0, stopped("User initiated (2025-03-13 09:00:00 GMT)"), scanner.StatusPass},
+		{"stopped exactly at threshold", 0, stopped("User initiated (2025-02-12 12:00:00 GMT)"), scanner.StatusPass},
+		{"stopped just past threshold", 0, stopped("User initiated (2025-02-12 11:59:59 GMT)"), scanner.StatusFail},
+		{"stopped months ago", 0, stopped("User initiated (2024-11-01 08:15:00 GMT)"), scanner.StatusFail},
+		{"custom threshold not reached", 7, stopped("User initiated (2025-03-08 12:00:00 GMT)"), scanner.StatusPass},
+		{"custom threshold exceeded", 7, stopped("User initiated (2025-03-01 12:00:00 GMT)"), scanner.StatusFail},
+		{"non-positive threshold keeps default", -1, stopped("User initiated (2025-03-01 12:00:00 GMT)"), scanner.StatusPass},
+		{"unknown stop time", 0, stopped("Server.ScheduledStop"), ""},
+		{"running", 0, types.Instance{InstanceId: aws.String("i-123"), State: &types.InstanceState{Name: types.InstanceStateNameRunning}}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestScanner(&fakeEC2Client{})
+			WithStoppedMaxDays(tt.maxDays)(s)
+
+			findings := s.checkStoppedInstance(tt.instance, now)
+
+			if tt.want == "" {
+				if len(findings) != 0 {
+					t.Errorf("got %d findings, want none", len(findings))
+				}
+				return
+			}
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			if f := findings[0]; f.CheckID != "ec2_long_stopped" || f.Status != tt.want || f.Severity != scanner.SeverityLow {
+				t.Errorf("got %s %s/%s, want ec2_long_stopped %s/LOW", f.CheckID, f.Status, f.Severity, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckTerminationProtection(t *testing.T) {
+	running := &types.InstanceState{Name: types.InstanceStateNameRunning}
+	tests := []struct {
+		name     string
+		client   *fakeEC2Client
+		instance types.Instance
+		want     scanner.FindingStatus
+	}{
+		{
+			name:     "protected",
+			client:   &fakeEC2Client{terminationProtected: map[string]bool{"i-123": true}},
+			instance: types.Instance{InstanceId: aws.String("i-123"), State: running},
+			want:     scanner.StatusPass,
+		},
+		{
+			name:     "unprotected",
+			client:   &fakeEC2Client{},
+			instance: types.Instance{InstanceId: aws.String("i-123"), State: running},
+			want:     scanner.StatusFail,
+		},
+		{
+			name:     "access denied",
+			client:   &fakeEC2Client{instanceAttributeErr: &smithy.GenericAPIError{Code: "UnauthorizedOperation"}},
+			instance: types.Instance{InstanceId: aws.String("i-123"), State: running},
+			want:     scanner.StatusError,
+		},
+		{
+			name:     "other error",
+			client:   &fakeEC2Client{instanceAttributeErr: errors.New("throttled")},
+			instance: types.Instance{InstanceId: aws.String("i-123"), State: running},
+		},
+		{
+			name:     "terminated",
+			client:   &fakeEC2Client{},
+			instance: types.Instance{InstanceId: aws.String("i-123"), State: &types.InstanceState{Name: types.InstanceStateNameTerminated}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := newTestScanner(tt.client).checkTerminationProtection(context.Background(), tt.instance)
+
+			if tt.want == "" {
+				if len(findings) != 0 {
+					t.Errorf("got %d findings, want none", len(findings))
+				}
+				return
+			}
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			if f := findings[0]; f.CheckID != "ec2_termination_protection" || f.Status != tt.want {
+				t.Errorf("got %s %s, want ec2_termination_protection %s", f.CheckID, f.Status, tt.want)
+			}
+		})
+	}
+}
@@ -4,31 +4,142 @@ package ec2
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"cloudcop/api/internal/awsauth"
 	"cloudcop/api/internal/scanner"
-	"cloudcop/api/internal/scanner/compliance"
+	"cloudcop/api/internal/scanner/checks"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 )
 
+// ec2API is the subset of the EC2 client used by the scanner.
+type ec2API interface {
+	DescribeInstances(ctx context.Context, params *ec2.DescribeInstancesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error)
+	DescribeVolumes(ctx context.Context, params *ec2.DescribeVolumesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error)
+	DescribeSecurityGroups(ctx context.Context, params *ec2.DescribeSecurityGroupsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error)
+	DescribeAddresses(ctx context.Context, params *ec2.DescribeAddressesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeAddressesOutput, error)
+	DescribeNetworkInterfaces(ctx context.Context, params *ec2.DescribeNetworkInterfacesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error)
+	DescribeRouteTables(ctx context.Context, params *ec2.DescribeRouteTablesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error)
+	DescribeSnapshots(ctx context.Context, params *ec2.DescribeSnapshotsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSnapshotsOutput, error)
+	DescribeSnapshotAttribute(ctx context.Context, params *ec2.DescribeSnapshotAttributeInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSnapshotAttributeOutput, error)
+	DescribeInstanceAttribute(ctx context.Context, params *ec2.DescribeInstanceAttributeInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstanceAttributeOutput, error)
+}
+
+// defaultStoppedMaxDays is how long an instance may stay stopped before
+// ec2_long_stopped flags it. Stopped instances still bill for their EBS
+// volumes and Elastic IPs.
+const defaultStoppedMaxDays = 30
+
 // Scanner performs security checks on EC2 resources.
 type Scanner struct {
-	client    *ec2.Client
+	client    ec2API
 	region    string
 	accountID string
+
+	stoppedMaxDays int
+	// dangerousPorts overrides defaultDangerousPorts when set.
+	dangerousPorts map[int32]string
+}
+
+// Option configures a Scanner.
+type Option func(*Scanner)
+
+// WithStoppedMaxDays overrides how many days an instance may stay stopped
+// before ec2_long_stopped fails. Non-positive values keep the default.
+func WithStoppedMaxDays(days int) Option {
+	return func(s *Scanner) {
+		s.stoppedMaxDays = days
+	}
+}
+
+// WithDangerousPorts adds ports, keyed to the name of the service listening
+// on them, to those ec2_sg_dangerous_ports flags. A port that is already
+// flagged takes the new service name.
+func WithDangerousPorts(ports map[int32]string) Option {
+	return func(s *Scanner) {
+		set := s.editableDangerousPorts()
+		for port, service := range ports {
+			set[port] = service
+		}
+	}
+}
+
+// WithoutDangerousPorts stops ec2_sg_dangerous_ports from flagging ports,
+// such as ones a customer intentionally exposes.
+func WithoutDangerousPorts(ports ...int32) Option {
+	return func(s *Scanner) {
+		set := s.editableDangerousPorts()
+		for _, port := range ports {
+			delete(set, port)
+		}
+	}
+}
+
+// WithOnlyDangerousPorts replaces the default dangerous ports with ports.
+func WithOnlyDangerousPorts(ports map[int32]string) Option {
+	return func(s *Scanner) {
+		s.dangerousPorts = make(map[int32]string, len(ports))
+		for port, service := range ports {
+			s.dangerousPorts[port] = service
+		}
+	}
 }
 
 // NewScanner creates a new EC2 Scanner configured with the provided AWS config, region, and account ID.
 // The returned Scanner uses an EC2 client constructed from cfg and is initialized with region and accountID.
-func NewScanner(cfg aws.Config, region, accountID string) scanner.ServiceScanner {
-	return &Scanner{
+func NewScanner(cfg aws.Config, region, accountID string, opts ...Option) scanner.ServiceScanner {
+	s := &Scanner{
 		client:    ec2.NewFromConfig(cfg),
 		region:    region,
 		accountID: accountID,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// NewFactory returns a scanner.Factory that builds Scanners with opts applied.
+func NewFactory(opts ...Option) scanner.Factory {
+	return func(cfg aws.Config, region, accountID string) scanner.ServiceScanner {
+		return NewScanner(cfg, region, accountID, opts...)
+	}
+}
+
+// stoppedMaxAge returns how long an instance may stay stopped before
+// ec2_long_stopped fails.
+func (e *Scanner) stoppedMaxAge() time.Duration {
+	days := defaultStoppedMaxDays
+	if e.stoppedMaxDays > 0 {
+		days = e.stoppedMaxDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// dangerousPortSet returns the ports ec2_sg_dangerous_ports flags.
+func (e *Scanner) dangerousPortSet() map[int32]string {
+	if e.dangerousPorts == nil {
+		return defaultDangerousPorts
+	}
+	return e.dangerousPorts
+}
+
+// editableDangerousPorts returns the scanner's own copy of its dangerous
+// ports, so options never modify the shared defaults.
+func (e *Scanner) editableDangerousPorts() map[int32]string {
+	if e.dangerousPorts == nil {
+		e.dangerousPorts = make(map[int32]string, len(defaultDangerousPorts))
+		for port, service := range defaultDangerousPorts {
+			e.dangerousPorts[port] = service
+		}
+	}
+	return e.dangerousPorts
 }
 
 // Service returns the AWS service name.
@@ -39,11 +150,22 @@ func (e *Scanner) Service() string {
 // Scan executes all EC2 security checks.
 func (e *Scanner) Scan(ctx context.Context, _ string) ([]scanner.Finding, error) {
 	var findings []scanner.Finding
+	scope := scanner.ScopeFromContext(ctx)
 
-	instances, err := e.listInstances(ctx)
+	allInstances, err := e.listInstances(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("listing instances: %w", err)
 	}
+	scanner.CountResources(ctx, len(allInstances))
+
+	// Per-resource checks only cover targeted instances; account-level checks
+	// below still need the full list to judge what is in use.
+	var instances []types.Instance
+	for _, instance := range allInstances {
+		if scope.Includes(aws.ToString(instance.InstanceId)) {
+			instances = append(instances, instance)
+		}
+	}
 
 	// Collect all volume IDs for batch fetching
 	volumeIDs := make([]string, 0)
@@ -73,7 +195,7 @@ func (e *Scanner) Scan(ctx context.Context, _ string) ([]scanner.Finding, error)
 		})
 		if err != nil {
 			// Log error but continue - individual checks will report missing volumes
-			fmt.Printf("Warning: failed to batch fetch volumes: %v\n", err)
+			scanner.Logf(ctx, "Warning: failed to batch fetch volumes: %v", err)
 		} else {
 			for i := range volumes.Volumes {
 				vol := &volumes.Volumes[i]
@@ -91,7 +213,7 @@ func (e *Scanner) Scan(ctx context.Context, _ string) ([]scanner.Finding, error)
 			GroupIds: sgIDs,
 		})
 		if err != nil {
-			fmt.Printf("Warning: failed to batch fetch security groups: %v\n", err)
+			scanner.Logf(ctx, "Warning: failed to batch fetch security groups: %v", err)
 		} else {
 			for i := range sgs.SecurityGroups {
 				sg := &sgs.SecurityGroups[i]
@@ -102,25 +224,68 @@ func (e *Scanner) Scan(ctx context.Context, _ string) ([]scanner.Finding, error)
 		}
 	}
 
-	for _, instance := range instances {
-		instanceID := aws.ToString(instance.InstanceId)
-		findings = append(findings, e.checkPublicIP(ctx, instance)...)
-		findings = append(findings, e.checkEBSEncryption(instance, volumeMap)...)
-		findings = append(findings, e.checkSecurityGroups(instance, sgMap)...)
-		findings = append(findings, e.checkIMDSv2(ctx, instance)...)
-		findings = append(findings, e.checkIAMRole(ctx, instance)...)
-		findings = append(findings, e.checkDetailedMonitoring(ctx, instance)...)
-		_ = instanceID
+	// Route tables decide which instances sit in public subnets, for both the
+	// subnet placement and IMDSv1 exposure checks.
+	publicSubnets, err := e.publicSubnets(ctx, instances)
+	if err != nil {
+		scanner.Logf(ctx, "Warning: failed to fetch route tables: %v", err)
+	}
+
+	findings = append(findings, scanner.TraceChecks(ctx, "ec2.instances", func(ctx context.Context) []scanner.Finding {
+		var instanceFindings []scanner.Finding
+		for _, instance := range instances {
+			// Volume and security group findings are about those resources,
+			// so only the instance's own findings carry its launch time,
+			// the closest EC2 reports to when the instance was created, and
+			// its tags.
+			instanceFindings = append(instanceFindings, e.checkEBSEncryption(instance, volumeMap)...)
+			instanceFindings = append(instanceFindings, e.checkSecurityGroups(instance, sgMap)...)
+			own := scanner.WithResourceCreatedAt(slices.Concat(
+				acceptPublicInstance(scope.PublicAllowList, instance, e.checkPublicIP(ctx, instance)),
+				acceptPublicInstance(scope.PublicAllowList, instance, e.checkPublicSubnet(instance, publicSubnets)),
+				e.checkIMDSv2(ctx, instance),
+				e.checkIMDSv1Usage(instance, publicSubnets),
+				e.checkIAMRole(ctx, instance),
+				e.checkDetailedMonitoring(ctx, instance),
+				e.checkTerminationProtection(ctx, instance),
+				e.checkStoppedInstance(instance, time.Now()),
+			), instance.LaunchTime)
+			if scope.IncludeTags {
+				// DescribeInstances already returns tags, so this is free.
+				own = scanner.WithTags(own, instanceTags(instance))
+			}
+			instanceFindings = append(instanceFindings, own...)
+		}
+		return instanceFindings
+	})...)
+
+	findings = append(findings, scanner.TraceChecks(ctx, "ec2.snapshots", func(ctx context.Context) []scanner.Finding {
+		return e.scanSnapshots(ctx, scope)
+	})...)
+
+	if scope.SkipAccountChecks {
+		return findings, nil
 	}
 
-	findings = append(findings, e.checkUnassociatedElasticIPs(ctx)...)
-	findings = append(findings, e.checkUnrestrictedSecurityGroups(ctx)...)
-	findings = append(findings, e.checkDangerousPorts(ctx)...)
+	findings = append(findings, scanner.TraceChecks(ctx, "ec2.account", func(ctx context.Context) []scanner.Finding {
+		var accountFindings []scanner.Finding
+		accountFindings = append(accountFindings, e.checkUnassociatedElasticIPs(ctx)...)
+		accountFindings = append(accountFindings, e.checkUnrestrictedSecurityGroups(ctx)...)
+		accountFindings = append(accountFindings, e.checkDangerousPorts(ctx)...)
+		accountFindings = append(accountFindings, e.checkUnusedSecurityGroups(ctx, allInstances)...)
+		accountFindings = append(accountFindings, e.checkDefaultSecurityGroupUsage(ctx, allInstances)...)
+		return accountFindings
+	})...)
 
 	return findings, nil
 }
 
 func (e *Scanner) listInstances(ctx context.Context) ([]types.Instance, error) {
+	key := scanner.CacheKey{AccountID: e.accountID, Service: e.Service(), Region: e.region, Resource: "instances"}
+	return scanner.CachedList(ctx, key, e.describeInstances)
+}
+
+func (e *Scanner) describeInstances(ctx context.Context) ([]types.Instance, error) {
 	var instances []types.Instance
 	paginator := ec2.NewDescribeInstancesPaginator(e.client, &ec2.DescribeInstancesInput{})
 
@@ -138,15 +303,62 @@ func (e *Scanner) listInstances(ctx context.Context) ([]types.Instance, error) {
 
 func (e *Scanner) createFinding(checkID, resourceID, title, description string, status scanner.FindingStatus, severity scanner.Severity) scanner.Finding {
 	return scanner.Finding{
+		FindingID:   scanner.FindingID(e.accountID, e.Service(), e.region, checkID, resourceID),
 		Service:     e.Service(),
 		Region:      e.region,
 		ResourceID:  resourceID,
+		ARN:         e.resourceARN(resourceID),
 		CheckID:     checkID,
 		Status:      status,
 		Severity:    severity,
+		Confidence:  scanner.ConfidenceFor(checkID),
 		Title:       title,
 		Description: description,
-		Compliance:  compliance.GetCompliance(checkID),
+		Compliance:  checks.Compliance(checkID),
 		Timestamp:   time.Now(),
 	}
 }
+
+// resourceTypes maps the prefix of an EC2 resource ID to the resource type
+// in its ARN.
+var resourceTypes = map[string]string{
+	"i":        "instance",
+	"vol":      "volume",
+	"snap":     "snapshot",
+	"sg":       "security-group",
+	"eipalloc": "elastic-ip",
+}
+
+// resourceARN returns the ARN of the resource an EC2 ID identifies, or "" for
+// an ID with an unknown prefix. Snapshot ARNs carry no account.
+func (e *Scanner) resourceARN(resourceID string) string {
+	prefix, _, _ := strings.Cut(resourceID, "-")
+	resourceType, ok := resourceTypes[prefix]
+	if !ok {
+		return ""
+	}
+	accountID := e.accountID
+	if resourceType == "snapshot" {
+		accountID = ""
+	}
+	return arn.ARN{
+		Partition: awsauth.PartitionForRegion(e.region),
+		Service:   "ec2",
+		Region:    e.region,
+		AccountID: accountID,
+		Resource:  resourceType + "/" + resourceID,
+	}.String()
+}
+
+// accessDeniedFinding records that checkID could not be evaluated for
+// resourceID because the scan role was denied the underlying API call.
+func (e *Scanner) accessDeniedFinding(checkID, resourceID string, err error) scanner.Finding {
+	return e.createFinding(
+		checkID,
+		resourceID,
+		"Insufficient permissions to evaluate check",
+		scanner.AccessDeniedDescription(err),
+		scanner.StatusError,
+		scanner.SeverityMedium,
+	)
+}
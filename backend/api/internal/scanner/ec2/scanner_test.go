@@ -1,12 +1,15 @@
 package ec2
 
 import (
+	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"cloudcop/api/internal/scanner"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 )
 
 const testServiceName = "ec2"
@@ -108,6 +111,9 @@ func TestScanner_createFinding(t *testing.T) {
 			if finding.CheckID != tt.checkID {
 				t.Errorf("CheckID = %v, want %v", finding.CheckID, tt.checkID)
 			}
+			if want := scanner.FindingID(s.accountID, finding.Service, finding.Region, tt.checkID, tt.resourceID); finding.FindingID != want {
+				t.Errorf("FindingID = %v, want %v", finding.FindingID, want)
+			}
 			if finding.Status != tt.status {
 				t.Errorf("Status = %v, want %v", finding.Status, tt.status)
 			}
@@ -136,14 +142,14 @@ func TestDangerousPortsMap(t *testing.T) {
 	}
 
 	for port, name := range expectedPorts {
-		if dangerousPorts[port] != name {
-			t.Errorf("dangerousPorts[%d] = %v, want %v", port, dangerousPorts[port], name)
+		if defaultDangerousPorts[port] != name {
+			t.Errorf("defaultDangerousPorts[%d] = %v, want %v", port, defaultDangerousPorts[port], name)
 		}
 	}
 
 	// Verify map has expected count
-	if len(dangerousPorts) != len(expectedPorts) {
-		t.Errorf("dangerousPorts has %d entries, want %d", len(dangerousPorts), len(expectedPorts))
+	if len(defaultDangerousPorts) != len(expectedPorts) {
+		t.Errorf("defaultDangerousPorts has %d entries, want %d", len(defaultDangerousPorts), len(expectedPorts))
 	}
 }
 
@@ -152,3 +158,152 @@ func TestIPv4AnyConstant(t *testing.T) {
 		t.Errorf("ipv4Any = %v, want 0.0.0.0/0", ipv4Any)
 	}
 }
+
+func TestScanner_Scan_TargetedWithoutAccountChecks(t *testing.T) {
+	client := &fakeEC2Client{
+		instances: []types.Instance{
+			{
+				InstanceId:      aws.String("i-target"),
+				PublicIpAddress: aws.String("203.0.113.10"),
+				BlockDeviceMappings: []types.InstanceBlockDeviceMapping{
+					{Ebs: &types.EbsInstanceBlockDevice{VolumeId: aws.String("vol-target")}},
+				},
+			},
+			{InstanceId: aws.String("i-other")},
+		},
+		volumes: []types.Volume{
+			{VolumeId: aws.String("vol-target"), Encrypted: aws.Bool(true)},
+		},
+		securityGroups: []types.SecurityGroup{
+			{GroupId: aws.String("sg-orphan"), GroupName: aws.String("old-bastion")},
+		},
+	}
+
+	ctx := scanner.WithScope(context.Background(), scanner.Scope{
+		ResourceIDs:       []string{"i-target"},
+		SkipAccountChecks: true,
+	})
+	findings, err := newTestScanner(client).Scan(ctx, "us-east-1")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(findings) == 0 {
+		t.Fatal("expected findings for the targeted instance")
+	}
+
+	for _, f := range findings {
+		if f.ResourceID != "i-target" && f.ResourceID != "vol-target" {
+			t.Errorf("unexpected finding outside target: %s on %s", f.CheckID, f.ResourceID)
+		}
+		if strings.Contains(f.Description, "i-other") {
+			t.Errorf("finding references untargeted instance: %s", f.Description)
+		}
+	}
+}
+
+func TestScanner_Scan_ResourceCreatedAt(t *testing.T) {
+	launched := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	volumeCreated := launched.Add(-time.Hour)
+	client := &fakeEC2Client{
+		instances: []types.Instance{{
+			InstanceId:      aws.String("i-1"),
+			LaunchTime:      aws.Time(launched),
+			PublicIpAddress: aws.String("203.0.113.10"),
+			BlockDeviceMappings: []types.InstanceBlockDeviceMapping{
+				{Ebs: &types.EbsInstanceBlockDevice{VolumeId: aws.String("vol-1")}},
+			},
+		}},
+		volumes: []types.Volume{{VolumeId: aws.String("vol-1"), Encrypted: aws.Bool(false), CreateTime: aws.Time(volumeCreated)}},
+	}
+
+	ctx := scanner.WithScope(context.Background(), scanner.Scope{SkipAccountChecks: true})
+	findings, err := newTestScanner(client).Scan(ctx, "us-east-1")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	want := map[string]time.Time{"i-1": launched, "vol-1": volumeCreated}
+	seen := make(map[string]bool)
+	for _, f := range findings {
+		created, ok := want[f.ResourceID]
+		if !ok {
+			continue
+		}
+		seen[f.ResourceID] = true
+		if f.ResourceCreatedAt == nil || !f.ResourceCreatedAt.Equal(created) {
+			t.Errorf("%s on %s: ResourceCreatedAt = %v, want %v", f.CheckID, f.ResourceID, f.ResourceCreatedAt, created)
+		}
+		if f.Timestamp.Equal(created) {
+			t.Errorf("%s on %s: Timestamp is the resource creation time, want the detection time", f.CheckID, f.ResourceID)
+		}
+	}
+	if !seen["i-1"] || !seen["vol-1"] {
+		t.Errorf("findings = %+v, want findings on both i-1 and vol-1", findings)
+	}
+}
+
+func TestScanner_Scan_IncludeTags(t *testing.T) {
+	client := &fakeEC2Client{
+		instances: []types.Instance{{
+			InstanceId:      aws.String("i-1"),
+			PublicIpAddress: aws.String("203.0.113.10"),
+			Tags:            []types.Tag{{Key: aws.String("env"), Value: aws.String("prod")}},
+			BlockDeviceMappings: []types.InstanceBlockDeviceMapping{
+				{Ebs: &types.EbsInstanceBlockDevice{VolumeId: aws.String("vol-1")}},
+			},
+		}},
+		volumes: []types.Volume{{VolumeId: aws.String("vol-1"), Encrypted: aws.Bool(false)}},
+	}
+
+	for _, include := range []bool{true, false} {
+		ctx := scanner.WithScope(context.Background(), scanner.Scope{SkipAccountChecks: true, IncludeTags: include})
+		findings, err := newTestScanner(client).Scan(ctx, "us-east-1")
+		if err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+
+		var tagged int
+		for _, f := range findings {
+			switch {
+			case f.ResourceID == "i-1" && include:
+				if f.Tags["env"] != "prod" {
+					t.Errorf("%s on i-1: Tags = %v, want env=prod", f.CheckID, f.Tags)
+				}
+				tagged++
+			case f.Tags != nil:
+				// Volume findings never carry the instance's tags.
+				t.Errorf("%s on %s: Tags = %v, want none (IncludeTags=%v)", f.CheckID, f.ResourceID, f.Tags, include)
+			}
+		}
+		if include && tagged == 0 {
+			t.Errorf("findings = %+v, want tagged findings on i-1", findings)
+		}
+	}
+}
+
+func TestScanner_resourceARN(t *testing.T) {
+	s := &Scanner{region: "us-west-2", accountID: "123456789012"}
+
+	tests := []struct {
+		resourceID string
+		want       string
+	}{
+		{"i-0abc123", "arn:aws:ec2:us-west-2:123456789012:instance/i-0abc123"},
+		{"vol-0abc123", "arn:aws:ec2:us-west-2:123456789012:volume/vol-0abc123"},
+		{"sg-0abc123", "arn:aws:ec2:us-west-2:123456789012:security-group/sg-0abc123"},
+		{"eipalloc-0abc123", "arn:aws:ec2:us-west-2:123456789012:elastic-ip/eipalloc-0abc123"},
+		{"snap-0abc123", "arn:aws:ec2:us-west-2::snapshot/snap-0abc123"},
+		{"unknown", ""},
+	}
+	for _, tt := range tests {
+		if got := s.resourceARN(tt.resourceID); got != tt.want {
+			t.Errorf("resourceARN(%q) = %q, want %q", tt.resourceID, got, tt.want)
+		}
+	}
+
+	gov := &Scanner{region: "us-gov-west-1", accountID: "123456789012"}
+	if got, want := gov.createFinding("ec2_public_ip", "i-0abc123", "", "", scanner.StatusPass, scanner.SeverityHigh).ARN,
+		"arn:aws-us-gov:ec2:us-gov-west-1:123456789012:instance/i-0abc123"; got != want {
+		t.Errorf("ARN = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,148 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"golang.org/x/sync/errgroup"
+)
+
+// snapshotAttributeConcurrency is the number of snapshot permission lookups
+// run in parallel. DescribeSnapshotAttribute takes one snapshot per call.
+const snapshotAttributeConcurrency = 10
+
+// snapshotsPageSize is the largest page DescribeSnapshots returns.
+const snapshotsPageSize = 1000
+
+// scanSnapshots runs the snapshot checks on every in-scope snapshot the
+// account owns in the region.
+func (e *Scanner) scanSnapshots(ctx context.Context, scope scanner.Scope) []scanner.Finding {
+	allSnapshots, err := e.listSnapshots(ctx)
+	if err != nil {
+		scanner.Logf(ctx, "Warning: failed to list snapshots: %v", err)
+		return nil
+	}
+
+	var snapshots []types.Snapshot
+	for _, snapshot := range allSnapshots {
+		if scope.Includes(aws.ToString(snapshot.SnapshotId)) {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+
+	// Each goroutine writes only its own slot, so no locking is needed.
+	results := make([][]scanner.Finding, len(snapshots))
+	var g errgroup.Group
+	g.SetLimit(snapshotAttributeConcurrency)
+	for i, snapshot := range snapshots {
+		g.Go(func() error {
+			findings := e.checkSnapshotEncryption(snapshot)
+			findings = append(findings, acceptPublicSnapshot(scope.PublicAllowList, snapshot, e.checkPublicSnapshots(ctx, snapshot))...)
+			results[i] = findings
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return slices.Concat(results...)
+}
+
+func (e *Scanner) listSnapshots(ctx context.Context) ([]types.Snapshot, error) {
+	key := scanner.CacheKey{AccountID: e.accountID, Service: e.Service(), Region: e.region, Resource: "snapshots"}
+	return scanner.CachedList(ctx, key, e.describeSnapshots)
+}
+
+// describeSnapshots lists the snapshots owned by the account. Public and
+// shared snapshots of other accounts are excluded, as there can be millions.
+func (e *Scanner) describeSnapshots(ctx context.Context) ([]types.Snapshot, error) {
+	var snapshots []types.Snapshot
+	paginator := ec2.NewDescribeSnapshotsPaginator(e.client, &ec2.DescribeSnapshotsInput{
+		OwnerIds:   []string{"self"},
+		MaxResults: aws.Int32(snapshotsPageSize),
+	})
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, output.Snapshots...)
+	}
+	return snapshots, nil
+}
+
+func (e *Scanner) checkSnapshotEncryption(snapshot types.Snapshot) []scanner.Finding {
+	snapshotID := aws.ToString(snapshot.SnapshotId)
+	if aws.ToBool(snapshot.Encrypted) {
+		return []scanner.Finding{e.createFinding(
+			"ec2_snapshot_encryption",
+			snapshotID,
+			"EBS snapshot is encrypted",
+			fmt.Sprintf("Snapshot %s of volume %s is encrypted", snapshotID, aws.ToString(snapshot.VolumeId)),
+			scanner.StatusPass,
+			scanner.SeverityHigh,
+		)}
+	}
+	return []scanner.Finding{e.createFinding(
+		"ec2_snapshot_encryption",
+		snapshotID,
+		"EBS snapshot is not encrypted",
+		fmt.Sprintf("Snapshot %s of volume %s is not encrypted", snapshotID, aws.ToString(snapshot.VolumeId)),
+		scanner.StatusFail,
+		scanner.SeverityHigh,
+	)}
+}
+
+// checkPublicSnapshots flags snapshots whose create-volume permission is
+// granted to the "all" group, which lets any AWS account copy the data.
+// Sharing with specific accounts is not reported.
+func (e *Scanner) checkPublicSnapshots(ctx context.Context, snapshot types.Snapshot) []scanner.Finding {
+	snapshotID := aws.ToString(snapshot.SnapshotId)
+	output, err := e.client.DescribeSnapshotAttribute(ctx, &ec2.DescribeSnapshotAttributeInput{
+		SnapshotId: snapshot.SnapshotId,
+		Attribute:  types.SnapshotAttributeNameCreateVolumePermission,
+	})
+	if err != nil {
+		scanner.Logf(ctx, "Warning: failed to describe permissions of snapshot %s: %v", snapshotID, err)
+		return nil
+	}
+
+	for _, perm := range output.CreateVolumePermissions {
+		if perm.Group == types.PermissionGroupAll {
+			return []scanner.Finding{e.createFinding(
+				"ec2_snapshot_public",
+				snapshotID,
+				"EBS snapshot is publicly shared",
+				fmt.Sprintf("Snapshot %s can be copied by any AWS account", snapshotID),
+				scanner.StatusFail,
+				scanner.SeverityCritical,
+			)}
+		}
+	}
+	return []scanner.Finding{e.createFinding(
+		"ec2_snapshot_public",
+		snapshotID,
+		"EBS snapshot is not public",
+		fmt.Sprintf("Snapshot %s is not shared publicly", snapshotID),
+		scanner.StatusPass,
+		scanner.SeverityCritical,
+	)}
+}
+
+// acceptPublicSnapshot downgrades public-access findings for a snapshot the
+// account has allow-listed by ID or tagged PublicIntentional=true.
+func acceptPublicSnapshot(allow scanner.PublicAllowList, snapshot types.Snapshot, findings []scanner.Finding) []scanner.Finding {
+	tags := make(map[string]string, len(snapshot.Tags))
+	for _, tag := range snapshot.Tags {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	if !allow.Allows(aws.ToString(snapshot.SnapshotId), tags) {
+		return findings
+	}
+	return scanner.AcceptPublic(findings)
+}
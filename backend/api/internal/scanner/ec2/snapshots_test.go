@@ -0,0 +1,123 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func TestScanSnapshots(t *testing.T) {
+	client := &fakeEC2Client{
+		snapshots: []types.Snapshot{
+			{SnapshotId: aws.String("snap-encrypted"), VolumeId: aws.String("vol-1"), Encrypted: aws.Bool(true)},
+			{SnapshotId: aws.String("snap-plain"), VolumeId: aws.String("vol-2"), Encrypted: aws.Bool(false)},
+			{SnapshotId: aws.String("snap-public"), VolumeId: aws.String("vol-3"), Encrypted: aws.Bool(false)},
+			{SnapshotId: aws.String("snap-shared"), VolumeId: aws.String("vol-4"), Encrypted: aws.Bool(true)},
+			{SnapshotId: aws.String("snap-unknown"), VolumeId: aws.String("vol-5"), Encrypted: aws.Bool(true)},
+		},
+		snapshotPermissions: map[string][]types.CreateVolumePermission{
+			"snap-encrypted": nil,
+			"snap-plain":     nil,
+			"snap-public":    {{Group: types.PermissionGroupAll}},
+			"snap-shared":    {{UserId: aws.String("210987654321")}},
+		},
+	}
+
+	findings := newTestScanner(client).scanSnapshots(context.Background(), scanner.Scope{PublicAllowList: scanner.PublicAllowList{DisableTag: true}})
+
+	type result struct {
+		status   scanner.FindingStatus
+		severity scanner.Severity
+	}
+	got := make(map[string]result)
+	for _, f := range findings {
+		got[f.CheckID+"/"+f.ResourceID] = result{f.Status, f.Severity}
+	}
+	want := map[string]result{
+		"ec2_snapshot_encryption/snap-encrypted": {scanner.StatusPass, scanner.SeverityHigh},
+		"ec2_snapshot_encryption/snap-plain":     {scanner.StatusFail, scanner.SeverityHigh},
+		"ec2_snapshot_encryption/snap-public":    {scanner.StatusFail, scanner.SeverityHigh},
+		"ec2_snapshot_encryption/snap-shared":    {scanner.StatusPass, scanner.SeverityHigh},
+		"ec2_snapshot_encryption/snap-unknown":   {scanner.StatusPass, scanner.SeverityHigh},
+		"ec2_snapshot_public/snap-encrypted":     {scanner.StatusPass, scanner.SeverityCritical},
+		"ec2_snapshot_public/snap-plain":         {scanner.StatusPass, scanner.SeverityCritical},
+		"ec2_snapshot_public/snap-public":        {scanner.StatusFail, scanner.SeverityCritical},
+		"ec2_snapshot_public/snap-shared":        {scanner.StatusPass, scanner.SeverityCritical},
+	}
+	if len(got) != len(want) || len(findings) != len(want) {
+		t.Errorf("got %d findings %v, want %d", len(findings), got, len(want))
+	}
+	for key, w := range want {
+		if g, ok := got[key]; !ok || g != w {
+			t.Errorf("%s = %+v, want %+v", key, g, w)
+		}
+	}
+
+	in := client.snapshotsInput
+	if !slices.Equal(in.OwnerIds, []string{"self"}) {
+		t.Errorf("DescribeSnapshots OwnerIds = %v, want [self]", in.OwnerIds)
+	}
+	if aws.ToInt32(in.MaxResults) != snapshotsPageSize {
+		t.Errorf("DescribeSnapshots MaxResults = %d, want %d", aws.ToInt32(in.MaxResults), snapshotsPageSize)
+	}
+}
+
+func TestScanSnapshots_Paginates(t *testing.T) {
+	client := &fakeEC2Client{
+		snapshotPageSize:    2,
+		snapshotPermissions: make(map[string][]types.CreateVolumePermission),
+	}
+	for i := range 5 {
+		id := fmt.Sprintf("snap-%d", i)
+		client.snapshots = append(client.snapshots, types.Snapshot{SnapshotId: aws.String(id), Encrypted: aws.Bool(true)})
+		client.snapshotPermissions[id] = nil
+	}
+
+	findings := newTestScanner(client).scanSnapshots(context.Background(), scanner.Scope{})
+	if len(findings) != 10 {
+		t.Errorf("got %d findings, want 2 for each of 5 snapshots", len(findings))
+	}
+	if client.describeSnapshotsCalls != 3 {
+		t.Errorf("DescribeSnapshots called %d times, want 3 pages", client.describeSnapshotsCalls)
+	}
+}
+
+func TestScanSnapshots_AcceptedPublic(t *testing.T) {
+	client := &fakeEC2Client{
+		snapshots: []types.Snapshot{
+			{
+				SnapshotId: aws.String("snap-dataset"),
+				Encrypted:  aws.Bool(false),
+				Tags:       []types.Tag{{Key: aws.String(scanner.PublicIntentionalTag), Value: aws.String("true")}},
+			},
+			{SnapshotId: aws.String("snap-other"), Encrypted: aws.Bool(false)},
+		},
+		snapshotPermissions: map[string][]types.CreateVolumePermission{
+			"snap-dataset": {{Group: types.PermissionGroupAll}},
+		},
+	}
+	ctx := context.Background()
+
+	findings := newTestScanner(client).scanSnapshots(ctx, scanner.Scope{ResourceIDs: []string{"snap-dataset"}})
+	for _, f := range findings {
+		if f.ResourceID != "snap-dataset" {
+			t.Errorf("unexpected finding outside target: %s on %s", f.CheckID, f.ResourceID)
+		}
+		if f.CheckID == "ec2_snapshot_public" && (f.Status != scanner.StatusFail || f.Severity != scanner.SeverityLow) {
+			t.Errorf("tagged public snapshot got %s/%s, want FAIL/LOW", f.Status, f.Severity)
+		}
+		// The allow-list only affects public-access checks.
+		if f.CheckID == "ec2_snapshot_encryption" && f.Severity != scanner.SeverityHigh {
+			t.Errorf("ec2_snapshot_encryption severity = %s, want HIGH", f.Severity)
+		}
+	}
+	if len(findings) != 2 {
+		t.Errorf("got %d findings, want 2", len(findings))
+	}
+}
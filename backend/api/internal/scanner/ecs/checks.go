@@ -144,25 +144,22 @@ func (e *Scanner) checkNetworkMode(_ context.Context, taskDef *types.TaskDefinit
 
 func (e *Scanner) checkSecretsInEnv(_ context.Context, taskDef *types.TaskDefinition) []scanner.Finding {
 	taskDefArn := aws.ToString(taskDef.TaskDefinitionArn)
+	matcher := e.envSecretMatcher()
 	var findings []scanner.Finding
 
 	for _, container := range taskDef.ContainerDefinitions {
 		containerName := aws.ToString(container.Name)
 		for _, env := range container.Environment {
 			envName := aws.ToString(env.Name)
-			upperName := strings.ToUpper(envName)
-			for _, pattern := range sensitiveEnvPatterns {
-				if strings.Contains(upperName, pattern) {
-					findings = append(findings, e.createFinding(
-						"ecs_secrets_in_env",
-						taskDefArn,
-						"ECS container has secrets in environment variables",
-						fmt.Sprintf("Container %s has sensitive env var %s (use secrets)", containerName, envName),
-						scanner.StatusFail,
-						scanner.SeverityHigh,
-					))
-					break
-				}
+			if matcher.Matches(envName) {
+				findings = append(findings, e.createFinding(
+					"ecs_secrets_in_env",
+					taskDefArn,
+					"ECS container has secrets in environment variables",
+					fmt.Sprintf("Container %s has sensitive env var %s (use secrets)", containerName, envName),
+					scanner.StatusFail,
+					scanner.SeverityHigh,
+				))
 			}
 		}
 	}
@@ -0,0 +1,132 @@
+package ecs
+
+import (
+	"context"
+	"slices"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"golang.org/x/sync/errgroup"
+)
+
+// The most services and tasks the describe APIs accept per call.
+const (
+	describeServicesBatchSize = 10
+	describeTasksBatchSize    = 100
+)
+
+// runningTaskDefinitions returns the task definitions used by the services
+// and running tasks of every cluster, in cluster order. Clusters are
+// enumerated concurrently, bounded by the describe concurrency, and their
+// services and tasks are described in the largest batches the API allows.
+// Only listing the clusters can fail; a cluster whose services or tasks
+// cannot be read is logged and contributes what could be read.
+func (e *Scanner) runningTaskDefinitions(ctx context.Context) ([]string, error) {
+	clusters, err := e.listClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each goroutine writes only its own slot, so no locking is needed.
+	results := make([][]string, len(clusters))
+	var g errgroup.Group
+	g.SetLimit(e.concurrency())
+	for i, cluster := range clusters {
+		g.Go(func() error {
+			results[i] = slices.Concat(e.serviceTaskDefinitions(ctx, cluster), e.taskTaskDefinitions(ctx, cluster))
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return slices.Concat(results...), nil
+}
+
+func (e *Scanner) listClusters(ctx context.Context) ([]string, error) {
+	var clusters []string
+	paginator := ecs.NewListClustersPaginator(e.client, &ecs.ListClustersInput{})
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, output.ClusterArns...)
+	}
+	return clusters, nil
+}
+
+// serviceTaskDefinitions returns the task definition each service of cluster
+// is deployed with.
+func (e *Scanner) serviceTaskDefinitions(ctx context.Context, cluster string) []string {
+	var services []string
+	paginator := ecs.NewListServicesPaginator(e.client, &ecs.ListServicesInput{
+		Cluster:    aws.String(cluster),
+		MaxResults: aws.Int32(100),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			scanner.Logf(ctx, "Warning: failed to list services of cluster %s: %v", cluster, err)
+			break
+		}
+		services = append(services, output.ServiceArns...)
+	}
+
+	var taskDefs []string
+	for batch := range slices.Chunk(services, describeServicesBatchSize) {
+		output, err := e.client.DescribeServices(ctx, &ecs.DescribeServicesInput{
+			Cluster:  aws.String(cluster),
+			Services: batch,
+		})
+		if err != nil {
+			scanner.Logf(ctx, "Warning: failed to describe services of cluster %s: %v", cluster, err)
+			continue
+		}
+		for _, service := range output.Services {
+			if service.TaskDefinition != nil {
+				taskDefs = append(taskDefs, aws.ToString(service.TaskDefinition))
+			}
+		}
+	}
+	return taskDefs
+}
+
+// taskTaskDefinitions returns the task definition of each running task in
+// cluster, including tasks started outside a service.
+func (e *Scanner) taskTaskDefinitions(ctx context.Context, cluster string) []string {
+	var tasks []string
+	paginator := ecs.NewListTasksPaginator(e.client, &ecs.ListTasksInput{
+		Cluster:       aws.String(cluster),
+		DesiredStatus: types.DesiredStatusRunning,
+		MaxResults:    aws.Int32(100),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			scanner.Logf(ctx, "Warning: failed to list tasks of cluster %s: %v", cluster, err)
+			break
+		}
+		tasks = append(tasks, output.TaskArns...)
+	}
+
+	var taskDefs []string
+	for batch := range slices.Chunk(tasks, describeTasksBatchSize) {
+		output, err := e.client.DescribeTasks(ctx, &ecs.DescribeTasksInput{
+			Cluster: aws.String(cluster),
+			Tasks:   batch,
+		})
+		if err != nil {
+			scanner.Logf(ctx, "Warning: failed to describe tasks of cluster %s: %v", cluster, err)
+			continue
+		}
+		for _, task := range output.Tasks {
+			if task.TaskDefinitionArn != nil {
+				taskDefs = append(taskDefs, aws.ToString(task.TaskDefinitionArn))
+			}
+		}
+	}
+	return taskDefs
+}
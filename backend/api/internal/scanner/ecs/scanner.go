@@ -4,32 +4,101 @@ package ecs
 import (
 	"context"
 	"fmt"
-	"log"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"cloudcop/api/internal/scanner"
-	"cloudcop/api/internal/scanner/compliance"
+	"cloudcop/api/internal/scanner/checks"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
 	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"golang.org/x/sync/errgroup"
 )
 
+// describeConcurrency is the default number of task definitions described in parallel.
+const describeConcurrency = 10
+
+// ecsAPI is the subset of the ECS client used by the scanner.
+type ecsAPI interface {
+	ListTaskDefinitions(ctx context.Context, params *ecs.ListTaskDefinitionsInput, optFns ...func(*ecs.Options)) (*ecs.ListTaskDefinitionsOutput, error)
+	DescribeTaskDefinition(ctx context.Context, params *ecs.DescribeTaskDefinitionInput, optFns ...func(*ecs.Options)) (*ecs.DescribeTaskDefinitionOutput, error)
+	ListClusters(ctx context.Context, params *ecs.ListClustersInput, optFns ...func(*ecs.Options)) (*ecs.ListClustersOutput, error)
+	ListServices(ctx context.Context, params *ecs.ListServicesInput, optFns ...func(*ecs.Options)) (*ecs.ListServicesOutput, error)
+	DescribeServices(ctx context.Context, params *ecs.DescribeServicesInput, optFns ...func(*ecs.Options)) (*ecs.DescribeServicesOutput, error)
+	ListTasks(ctx context.Context, params *ecs.ListTasksInput, optFns ...func(*ecs.Options)) (*ecs.ListTasksOutput, error)
+	DescribeTasks(ctx context.Context, params *ecs.DescribeTasksInput, optFns ...func(*ecs.Options)) (*ecs.DescribeTasksOutput, error)
+}
+
 // Scanner performs security checks on ECS resources.
 type Scanner struct {
-	client    *ecs.Client
+	client    ecsAPI
 	region    string
 	accountID string
+
+	extraEnvPatterns    []string
+	allowedEnvVars      []string
+	describeConcurrency int
+	allRevisions        bool
+}
+
+// Option configures a Scanner.
+type Option func(*Scanner)
+
+// WithSensitiveEnvPatterns adds substrings that mark an environment variable
+// name as sensitive, on top of the built-in patterns.
+func WithSensitiveEnvPatterns(patterns ...string) Option {
+	return func(s *Scanner) {
+		s.extraEnvPatterns = append(s.extraEnvPatterns, patterns...)
+	}
+}
+
+// WithAllowedEnvVars exempts exact environment variable names (e.g. PUBLIC_KEY)
+// from secret detection.
+func WithAllowedEnvVars(names ...string) Option {
+	return func(s *Scanner) {
+		s.allowedEnvVars = append(s.allowedEnvVars, names...)
+	}
+}
+
+// WithDescribeConcurrency sets how many task definitions are described and
+// checked in parallel. Non-positive values keep the default.
+func WithDescribeConcurrency(n int) Option {
+	return func(s *Scanner) {
+		s.describeConcurrency = n
+	}
+}
+
+// WithAllRevisions scans every active revision of each task definition family
+// instead of only the latest one.
+func WithAllRevisions() Option {
+	return func(s *Scanner) {
+		s.allRevisions = true
+	}
 }
 
 // NewScanner creates and returns a Scanner that implements scanner.ServiceScanner for ECS security scanning.
 // cfg is the AWS SDK configuration used to initialize the ECS client; region and accountID are stored as scanner metadata.
-func NewScanner(cfg aws.Config, region, accountID string) scanner.ServiceScanner {
-	return &Scanner{
+func NewScanner(cfg aws.Config, region, accountID string, opts ...Option) scanner.ServiceScanner {
+	s := &Scanner{
 		client:    ecs.NewFromConfig(cfg),
 		region:    region,
 		accountID: accountID,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// NewFactory returns a scanner.Factory that builds Scanners with opts applied.
+func NewFactory(opts ...Option) scanner.Factory {
+	return func(cfg aws.Config, region, accountID string) scanner.ServiceScanner {
+		return NewScanner(cfg, region, accountID, opts...)
+	}
 }
 
 // Service returns the AWS service name.
@@ -38,34 +107,88 @@ func (e *Scanner) Service() string {
 }
 
 // Scan executes all ECS security checks.
+// Task definitions are described and checked concurrently, bounded by the
+// configured describe concurrency; findings keep the listing order.
 func (e *Scanner) Scan(ctx context.Context, _ string) ([]scanner.Finding, error) {
+	taskDefs, err := e.taskDefinitionsToScan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scanner.CountResources(ctx, len(taskDefs))
+
+	return scanner.TraceChecks(ctx, "ecs.task_definitions", func(ctx context.Context) []scanner.Finding {
+		// Each goroutine writes only its own slot, so no locking is needed.
+		results := make([][]scanner.Finding, len(taskDefs))
+		var g errgroup.Group
+		g.SetLimit(e.concurrency())
+		for i, taskDefArn := range taskDefs {
+			g.Go(func() error {
+				results[i] = e.scanTaskDefinition(ctx, taskDefArn)
+				return nil
+			})
+		}
+		_ = g.Wait()
+		return slices.Concat(results...)
+	}), nil
+}
+
+// scanTaskDefinition describes a task definition and runs every check on it.
+func (e *Scanner) scanTaskDefinition(ctx context.Context, taskDefArn string) []scanner.Finding {
+	taskDef, err := e.describeTaskDefinition(ctx, taskDefArn)
+	if err != nil {
+		scanner.Logf(ctx, "Warning: failed to describe task definition %s: %v", taskDefArn, err)
+		return nil
+	}
+
 	var findings []scanner.Finding
+	findings = append(findings, e.checkPrivilegedContainers(ctx, taskDef)...)
+	findings = append(findings, e.checkPublicRegistry(ctx, taskDef)...)
+	findings = append(findings, e.checkTaskIAMRole(ctx, taskDef)...)
+	findings = append(findings, e.checkNetworkMode(ctx, taskDef)...)
+	findings = append(findings, e.checkSecretsInEnv(ctx, taskDef)...)
+	findings = append(findings, e.checkCloudWatchLogs(ctx, taskDef)...)
+	return findings
+}
 
+// taskDefinitionsToScan returns the listed task definitions followed by any
+// other revisions that services or running tasks use. A service keeps
+// running an older revision until it is redeployed, so those are checked
+// even when only the latest revisions are listed. Only listing the task
+// definitions can fail; when the clusters cannot be listed, the in-use
+// lookup is logged and the listed revisions are scanned alone.
+func (e *Scanner) taskDefinitionsToScan(ctx context.Context) ([]string, error) {
 	taskDefs, err := e.listTaskDefinitions(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("listing task definitions: %w", err)
 	}
+	inUse, err := e.runningTaskDefinitions(ctx)
+	if err != nil {
+		scanner.Logf(ctx, "Warning: failed to list clusters for in-use task definitions: %v", err)
+		return taskDefs, nil
+	}
 
-	for _, taskDefArn := range taskDefs {
-		taskDef, err := e.describeTaskDefinition(ctx, taskDefArn)
-		if err != nil {
-			log.Printf("Warning: failed to describe task definition %s: %v", taskDefArn, err)
-			continue
+	seen := make(map[string]bool, len(taskDefs))
+	for _, arn := range taskDefs {
+		seen[arn] = true
+	}
+	for _, arn := range inUse {
+		if !seen[arn] {
+			seen[arn] = true
+			taskDefs = append(taskDefs, arn)
 		}
-		findings = append(findings, e.checkPrivilegedContainers(ctx, taskDef)...)
-		findings = append(findings, e.checkPublicRegistry(ctx, taskDef)...)
-		findings = append(findings, e.checkTaskIAMRole(ctx, taskDef)...)
-		findings = append(findings, e.checkNetworkMode(ctx, taskDef)...)
-		findings = append(findings, e.checkSecretsInEnv(ctx, taskDef)...)
-		findings = append(findings, e.checkCloudWatchLogs(ctx, taskDef)...)
 	}
-
-	return findings, nil
+	return taskDefs, nil
 }
 
+// listTaskDefinitions lists ACTIVE task definition revisions; inactive
+// revisions cannot be used to launch tasks and only inflate the scan.
+// Unless all revisions were requested, only the latest revision of each
+// family is returned.
 func (e *Scanner) listTaskDefinitions(ctx context.Context) ([]string, error) {
 	var taskDefs []string
-	paginator := ecs.NewListTaskDefinitionsPaginator(e.client, &ecs.ListTaskDefinitionsInput{})
+	paginator := ecs.NewListTaskDefinitionsPaginator(e.client, &ecs.ListTaskDefinitionsInput{
+		Status: types.TaskDefinitionStatusActive,
+	})
 
 	for paginator.HasMorePages() {
 		output, err := paginator.NextPage(ctx)
@@ -74,7 +197,54 @@ func (e *Scanner) listTaskDefinitions(ctx context.Context) ([]string, error) {
 		}
 		taskDefs = append(taskDefs, output.TaskDefinitionArns...)
 	}
-	return taskDefs, nil
+	if e.allRevisions {
+		return taskDefs, nil
+	}
+	return latestRevisions(taskDefs), nil
+}
+
+// latestRevisions keeps the highest revision of each task definition family,
+// in the order each family first appears. ARNs that cannot be parsed are kept.
+func latestRevisions(arns []string) []string {
+	var latest []string
+	index := make(map[string]int)
+	revisions := make(map[string]int)
+	for _, arn := range arns {
+		family, revision, ok := parseTaskDefinitionARN(arn)
+		if !ok {
+			latest = append(latest, arn)
+			continue
+		}
+		i, seen := index[family]
+		switch {
+		case !seen:
+			index[family] = len(latest)
+			revisions[family] = revision
+			latest = append(latest, arn)
+		case revision > revisions[family]:
+			revisions[family] = revision
+			latest[i] = arn
+		}
+	}
+	return latest
+}
+
+// parseTaskDefinitionARN splits a task definition ARN such as
+// arn:aws:ecs:us-east-1:123456789012:task-definition/web:7 into its family and revision.
+func parseTaskDefinitionARN(arn string) (string, int, bool) {
+	_, name, ok := strings.Cut(arn, ":task-definition/")
+	if !ok {
+		return "", 0, false
+	}
+	family, rev, ok := strings.Cut(name, ":")
+	if !ok {
+		return "", 0, false
+	}
+	revision, err := strconv.Atoi(rev)
+	if err != nil {
+		return "", 0, false
+	}
+	return family, revision, true
 }
 
 func (e *Scanner) describeTaskDefinition(ctx context.Context, arn string) (*types.TaskDefinition, error) {
@@ -89,15 +259,41 @@ func (e *Scanner) describeTaskDefinition(ctx context.Context, arn string) (*type
 
 func (e *Scanner) createFinding(checkID, resourceID, title, description string, status scanner.FindingStatus, severity scanner.Severity) scanner.Finding {
 	return scanner.Finding{
+		FindingID:   scanner.FindingID(e.accountID, e.Service(), e.region, checkID, resourceID),
 		Service:     e.Service(),
 		Region:      e.region,
 		ResourceID:  resourceID,
+		ARN:         resourceARN(resourceID),
 		CheckID:     checkID,
 		Status:      status,
 		Severity:    severity,
+		Confidence:  scanner.ConfidenceFor(checkID),
 		Title:       title,
 		Description: description,
-		Compliance:  compliance.GetCompliance(checkID),
+		Compliance:  checks.Compliance(checkID),
 		Timestamp:   time.Now(),
 	}
 }
+
+// resourceARN returns resourceID when it is an ARN. ECS findings are keyed by
+// task definition ARN, so no ARN needs to be built.
+func resourceARN(resourceID string) string {
+	if arn.IsARN(resourceID) {
+		return resourceID
+	}
+	return ""
+}
+
+// envSecretMatcher returns the matcher for sensitive environment variable
+// names, combining the built-in patterns with any configured via options.
+func (e *Scanner) envSecretMatcher() scanner.SecretNameMatcher {
+	return scanner.NewSecretNameMatcher(slices.Concat(sensitiveEnvPatterns, e.extraEnvPatterns), e.allowedEnvVars)
+}
+
+// concurrency returns the configured describe concurrency.
+func (e *Scanner) concurrency() int {
+	if e.describeConcurrency > 0 {
+		return e.describeConcurrency
+	}
+	return describeConcurrency
+}
@@ -1,12 +1,22 @@
 package ecs
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"cloudcop/api/internal/scanner"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
 )
 
 const testServiceName = "ecs"
@@ -108,6 +118,9 @@ func TestScanner_createFinding(t *testing.T) {
 			if finding.CheckID != tt.checkID {
 				t.Errorf("CheckID = %v, want %v", finding.CheckID, tt.checkID)
 			}
+			if want := scanner.FindingID(s.accountID, finding.Service, finding.Region, tt.checkID, tt.resourceID); finding.FindingID != want {
+				t.Errorf("FindingID = %v, want %v", finding.FindingID, want)
+			}
 			if finding.Status != tt.status {
 				t.Errorf("Status = %v, want %v", finding.Status, tt.status)
 			}
@@ -144,3 +157,360 @@ func TestSensitiveEnvPatterns(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckSecretsInEnv_Options(t *testing.T) {
+	taskDef := &types.TaskDefinition{
+		TaskDefinitionArn: aws.String("arn:aws:ecs:us-east-1:123456789012:task-definition/web:1"),
+		ContainerDefinitions: []types.ContainerDefinition{
+			{
+				Name: aws.String("web"),
+				Environment: []types.KeyValuePair{
+					{Name: aws.String("PUBLIC_KEY"), Value: aws.String("ssh-ed25519 AAAA")},
+					{Name: aws.String("ACME_DSN"), Value: aws.String("postgres://...")},
+				},
+			},
+		},
+	}
+
+	s := NewScanner(aws.Config{Region: "us-east-1"}, "us-east-1", "123456789012",
+		WithSensitiveEnvPatterns("ACME_"),
+		WithAllowedEnvVars("public_key"),
+	).(*Scanner)
+
+	findings := s.checkSecretsInEnv(context.Background(), taskDef)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %+v", len(findings), findings)
+	}
+	if !strings.Contains(findings[0].Description, "ACME_DSN") {
+		t.Errorf("Description = %q, want ACME_DSN flagged", findings[0].Description)
+	}
+	if strings.Contains(findings[0].Description, "postgres://") {
+		t.Errorf("Description = %q, includes the variable's value", findings[0].Description)
+	}
+}
+
+// fakeECSClient serves a fixed set of task definitions, optionally adding
+// latency to each describe call to mimic the API, and the clusters using them.
+type fakeECSClient struct {
+	ecsAPI
+	taskDefs   map[string]*types.TaskDefinition
+	arns       []string
+	latency    time.Duration
+	listStatus types.TaskDefinitionStatus
+	describes  atomic.Int32
+
+	clusters    []fakeCluster
+	clustersErr error
+	// Calls of the batched describe APIs.
+	describeServicesCalls atomic.Int32
+	describeTasksCalls    atomic.Int32
+}
+
+// fakeCluster maps the ARNs of a cluster's services and running tasks to
+// the task definitions they use.
+type fakeCluster struct {
+	arn      string
+	services map[string]string
+	tasks    map[string]string
+}
+
+func (f *fakeECSClient) cluster(arn string) fakeCluster {
+	for _, c := range f.clusters {
+		if c.arn == arn {
+			return c
+		}
+	}
+	return fakeCluster{}
+}
+
+func newFakeECSClient(n int, latency time.Duration) *fakeECSClient {
+	f := &fakeECSClient{taskDefs: make(map[string]*types.TaskDefinition), latency: latency}
+	for i := 0; i < n; i++ {
+		arn := fmt.Sprintf("arn:aws:ecs:us-east-1:123456789012:task-definition/app-%d:1", i)
+		container := types.ContainerDefinition{
+			Name:       aws.String("app"),
+			Image:      aws.String("123456789012.dkr.ecr.us-east-1.amazonaws.com/app:latest"),
+			Privileged: aws.Bool(i%3 == 0),
+		}
+		if i%4 == 0 {
+			container.Environment = []types.KeyValuePair{{Name: aws.String("DB_PASSWORD"), Value: aws.String("hunter2")}}
+		}
+		if i%5 == 0 {
+			container.LogConfiguration = &types.LogConfiguration{LogDriver: types.LogDriverAwslogs}
+		}
+		taskDef := &types.TaskDefinition{
+			TaskDefinitionArn:    aws.String(arn),
+			Family:               aws.String(fmt.Sprintf("app-%d", i)),
+			ContainerDefinitions: []types.ContainerDefinition{container},
+			NetworkMode:          types.NetworkModeAwsvpc,
+		}
+		if i%2 == 0 {
+			taskDef.TaskRoleArn = aws.String("arn:aws:iam::123456789012:role/app")
+			taskDef.NetworkMode = types.NetworkModeBridge
+		}
+		f.taskDefs[arn] = taskDef
+		f.arns = append(f.arns, arn)
+	}
+	return f
+}
+
+func (f *fakeECSClient) ListTaskDefinitions(_ context.Context, params *ecs.ListTaskDefinitionsInput, _ ...func(*ecs.Options)) (*ecs.ListTaskDefinitionsOutput, error) {
+	f.listStatus = params.Status
+	return &ecs.ListTaskDefinitionsOutput{TaskDefinitionArns: f.arns}, nil
+}
+
+func (f *fakeECSClient) ListClusters(_ context.Context, _ *ecs.ListClustersInput, _ ...func(*ecs.Options)) (*ecs.ListClustersOutput, error) {
+	if f.clustersErr != nil {
+		return nil, f.clustersErr
+	}
+	out := &ecs.ListClustersOutput{}
+	for _, c := range f.clusters {
+		out.ClusterArns = append(out.ClusterArns, c.arn)
+	}
+	return out, nil
+}
+
+func (f *fakeECSClient) ListServices(_ context.Context, params *ecs.ListServicesInput, _ ...func(*ecs.Options)) (*ecs.ListServicesOutput, error) {
+	services := slices.Sorted(maps.Keys(f.cluster(aws.ToString(params.Cluster)).services))
+	return &ecs.ListServicesOutput{ServiceArns: services}, nil
+}
+
+func (f *fakeECSClient) DescribeServices(_ context.Context, params *ecs.DescribeServicesInput, _ ...func(*ecs.Options)) (*ecs.DescribeServicesOutput, error) {
+	f.describeServicesCalls.Add(1)
+	if len(params.Services) > describeServicesBatchSize {
+		return nil, fmt.Errorf("too many services: %d", len(params.Services))
+	}
+	c := f.cluster(aws.ToString(params.Cluster))
+	out := &ecs.DescribeServicesOutput{}
+	for _, arn := range params.Services {
+		out.Services = append(out.Services, types.Service{ServiceArn: aws.String(arn), TaskDefinition: aws.String(c.services[arn])})
+	}
+	return out, nil
+}
+
+func (f *fakeECSClient) ListTasks(_ context.Context, params *ecs.ListTasksInput, _ ...func(*ecs.Options)) (*ecs.ListTasksOutput, error) {
+	tasks := slices.Sorted(maps.Keys(f.cluster(aws.ToString(params.Cluster)).tasks))
+	return &ecs.ListTasksOutput{TaskArns: tasks}, nil
+}
+
+func (f *fakeECSClient) DescribeTasks(_ context.Context, params *ecs.DescribeTasksInput, _ ...func(*ecs.Options)) (*ecs.DescribeTasksOutput, error) {
+	f.describeTasksCalls.Add(1)
+	if len(params.Tasks) > describeTasksBatchSize {
+		return nil, fmt.Errorf("too many tasks: %d", len(params.Tasks))
+	}
+	c := f.cluster(aws.ToString(params.Cluster))
+	out := &ecs.DescribeTasksOutput{}
+	for _, arn := range params.Tasks {
+		out.Tasks = append(out.Tasks, types.Task{TaskArn: aws.String(arn), TaskDefinitionArn: aws.String(c.tasks[arn])})
+	}
+	return out, nil
+}
+
+func (f *fakeECSClient) DescribeTaskDefinition(_ context.Context, params *ecs.DescribeTaskDefinitionInput, _ ...func(*ecs.Options)) (*ecs.DescribeTaskDefinitionOutput, error) {
+	f.describes.Add(1)
+	time.Sleep(f.latency)
+	taskDef, ok := f.taskDefs[aws.ToString(params.TaskDefinition)]
+	if !ok {
+		return nil, errors.New("task definition not found")
+	}
+	return &ecs.DescribeTaskDefinitionOutput{TaskDefinition: taskDef}, nil
+}
+
+// serialScan runs the checks one task definition at a time, as Scan did before
+// it was parallelized.
+func serialScan(ctx context.Context, s *Scanner) []scanner.Finding {
+	taskDefs, _ := s.taskDefinitionsToScan(ctx)
+	var findings []scanner.Finding
+	for _, arn := range taskDefs {
+		findings = append(findings, s.scanTaskDefinition(ctx, arn)...)
+	}
+	return findings
+}
+
+func TestScanner_Scan_MatchesSerial(t *testing.T) {
+	client := newFakeECSClient(60, 0)
+	// A listed ARN that fails to describe is skipped, as before.
+	client.arns = append(client.arns, "arn:aws:ecs:us-east-1:123456789012:task-definition/deleted:1")
+	s := &Scanner{client: client, region: "us-east-1", accountID: "123456789012", describeConcurrency: 8}
+
+	got, err := s.Scan(context.Background(), "us-east-1")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	want := serialScan(context.Background(), s)
+
+	if client.listStatus != types.TaskDefinitionStatusActive {
+		t.Errorf("ListTaskDefinitions Status = %q, want ACTIVE", client.listStatus)
+	}
+	if len(got) == 0 || len(got) != len(want) {
+		t.Fatalf("got %d findings, serial scan produced %d", len(got), len(want))
+	}
+	for i := range want {
+		got[i].Timestamp, want[i].Timestamp = time.Time{}, time.Time{}
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("finding %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestScanner_Scan_LatestRevisionOnly(t *testing.T) {
+	client := &fakeECSClient{taskDefs: make(map[string]*types.TaskDefinition)}
+	for _, rev := range []int{1, 3, 2} {
+		arn := fmt.Sprintf("arn:aws:ecs:us-east-1:123456789012:task-definition/web:%d", rev)
+		client.taskDefs[arn] = &types.TaskDefinition{
+			TaskDefinitionArn: aws.String(arn),
+			Family:            aws.String("web"),
+			NetworkMode:       types.NetworkModeAwsvpc,
+		}
+		client.arns = append(client.arns, arn)
+	}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{
+			name: "latest revision by default",
+			want: []string{"arn:aws:ecs:us-east-1:123456789012:task-definition/web:3"},
+		},
+		{
+			name: "all revisions when requested",
+			opts: []Option{WithAllRevisions()},
+			want: client.arns,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Scanner{client: client, region: "us-east-1", accountID: "123456789012"}
+			for _, opt := range tt.opts {
+				opt(s)
+			}
+
+			findings, err := s.Scan(context.Background(), "us-east-1")
+			if err != nil {
+				t.Fatalf("Scan() error = %v", err)
+			}
+
+			var scanned []string
+			for _, f := range findings {
+				if !slices.Contains(scanned, f.ResourceID) {
+					scanned = append(scanned, f.ResourceID)
+				}
+			}
+			if !slices.Equal(scanned, tt.want) {
+				t.Errorf("scanned %v, want %v", scanned, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanner_Scan_BatchedDescribe(t *testing.T) {
+	const (
+		families  = 40
+		revisions = 3
+		clusters  = 3
+		services  = 25
+		tasks     = 150
+	)
+	client := newFakeECSClient(families, 0)
+	// Give every family newer revisions; only the latest is listed for
+	// scanning.
+	for _, first := range slices.Clone(client.arns) {
+		family := strings.TrimSuffix(first, ":1")
+		for rev := 2; rev <= revisions; rev++ {
+			arn := fmt.Sprintf("%s:%d", family, rev)
+			client.taskDefs[arn] = client.taskDefs[first]
+			client.arns = append(client.arns, arn)
+		}
+	}
+	// Every cluster's services still run the first revision of one family
+	// each, and its tasks the latest revision.
+	for c := 0; c < clusters; c++ {
+		cluster := fakeCluster{
+			arn:      fmt.Sprintf("arn:aws:ecs:us-east-1:123456789012:cluster/c%d", c),
+			services: make(map[string]string),
+			tasks:    make(map[string]string),
+		}
+		for i := 0; i < services; i++ {
+			arn := fmt.Sprintf("arn:aws:ecs:us-east-1:123456789012:service/c%d/svc-%02d", c, i)
+			cluster.services[arn] = fmt.Sprintf("arn:aws:ecs:us-east-1:123456789012:task-definition/app-%d:1", i)
+		}
+		for i := 0; i < tasks; i++ {
+			arn := fmt.Sprintf("arn:aws:ecs:us-east-1:123456789012:task/c%d/%03d", c, i)
+			cluster.tasks[arn] = fmt.Sprintf("arn:aws:ecs:us-east-1:123456789012:task-definition/app-%d:%d", i%families, revisions)
+		}
+		client.clusters = append(client.clusters, cluster)
+	}
+	s := &Scanner{client: client, region: "us-east-1", accountID: "123456789012"}
+
+	findings, err := s.Scan(context.Background(), "us-east-1")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	// ceil(25/10) service batches and ceil(150/100) task batches per cluster.
+	if got, want := client.describeServicesCalls.Load(), int32(clusters*3); got != want {
+		t.Errorf("DescribeServices called %d times, want %d", got, want)
+	}
+	if got, want := client.describeTasksCalls.Load(), int32(clusters*2); got != want {
+		t.Errorf("DescribeTasks called %d times, want %d", got, want)
+	}
+	// The latest revision of every family, plus the first revisions the
+	// services run, each described once however many clusters use them.
+	if got, want := client.describes.Load(), int32(families+services); got != want {
+		t.Errorf("described %d task definitions, want %d", got, want)
+	}
+	var scanned bool
+	for _, f := range findings {
+		if f.ResourceID == "arn:aws:ecs:us-east-1:123456789012:task-definition/app-0:1" {
+			scanned = true
+		}
+	}
+	if !scanned {
+		t.Error("no findings for app-0:1, which a service still runs")
+	}
+}
+
+func TestScanner_Scan_ClustersUnavailable(t *testing.T) {
+	client := newFakeECSClient(5, 0)
+	client.clustersErr = errors.New("AccessDeniedException: not authorized to perform: ecs:ListClusters")
+	s := &Scanner{client: client, region: "us-east-1", accountID: "123456789012"}
+
+	findings, err := s.Scan(context.Background(), "us-east-1")
+	if err != nil {
+		t.Fatalf("Scan() error = %v, want the listed revisions scanned", err)
+	}
+	if len(findings) == 0 {
+		t.Error("Scan() returned no findings without the in-use lookup")
+	}
+	if got, want := client.describes.Load(), int32(5); got != want {
+		t.Errorf("described %d task definitions, want the %d listed", got, want)
+	}
+}
+
+func BenchmarkScanner_Scan(b *testing.B) {
+	client := newFakeECSClient(200, time.Millisecond)
+
+	for _, concurrency := range []int{1, describeConcurrency} {
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			s := &Scanner{client: client, region: "us-east-1", accountID: "123456789012", describeConcurrency: concurrency}
+			for i := 0; i < b.N; i++ {
+				if _, err := s.Scan(context.Background(), "us-east-1"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceARN(t *testing.T) {
+	taskDef := "arn:aws:ecs:us-east-1:123456789012:task-definition/web:3"
+	if got := resourceARN(taskDef); got != taskDef {
+		t.Errorf("resourceARN(%q) = %q, want it unchanged", taskDef, got)
+	}
+	if got := resourceARN("web"); got != "" {
+		t.Errorf("resourceARN(web) = %q, want empty", got)
+	}
+}
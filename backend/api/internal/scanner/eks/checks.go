@@ -0,0 +1,166 @@
+package eks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+)
+
+// checkIRSA verifies the cluster's OIDC issuer is registered as an IAM
+// identity provider. Without it, IAM roles for service accounts cannot be
+// used and pods fall back to the node instance role.
+func (e *Scanner) checkIRSA(cluster *types.Cluster, oidcProviders []string) []scanner.Finding {
+	name := aws.ToString(cluster.Name)
+
+	var issuer string
+	if cluster.Identity != nil && cluster.Identity.Oidc != nil {
+		issuer = aws.ToString(cluster.Identity.Oidc.Issuer)
+	}
+
+	if issuer == "" {
+		return []scanner.Finding{e.createFinding(
+			"eks_irsa_configured",
+			name,
+			"EKS cluster has no OIDC issuer",
+			fmt.Sprintf("Cluster %s has no OIDC issuer, so pods can only use the node instance role", name),
+			scanner.StatusFail,
+			scanner.SeverityHigh,
+		)}
+	}
+
+	if !hasOIDCProvider(issuer, oidcProviders) {
+		return []scanner.Finding{e.createFinding(
+			"eks_irsa_configured",
+			name,
+			"EKS cluster does not have IRSA configured",
+			fmt.Sprintf("No IAM OIDC provider is registered for cluster %s issuer %s; service accounts use the node instance role", name, issuer),
+			scanner.StatusFail,
+			scanner.SeverityHigh,
+		)}
+	}
+
+	return []scanner.Finding{e.createFinding(
+		"eks_irsa_configured",
+		name,
+		"EKS cluster has IRSA configured",
+		fmt.Sprintf("Cluster %s issuer is registered as an IAM OIDC provider", name),
+		scanner.StatusPass,
+		scanner.SeverityHigh,
+	)}
+}
+
+// hasOIDCProvider reports whether any provider ARN matches the issuer URL.
+// Provider ARNs embed the issuer host and path, e.g.
+// arn:aws:iam::123456789012:oidc-provider/oidc.eks.us-east-1.amazonaws.com/id/ABC.
+func hasOIDCProvider(issuer string, providerARNs []string) bool {
+	issuerPath := strings.TrimPrefix(issuer, "https://")
+	for _, providerARN := range providerARNs {
+		_, path, ok := strings.Cut(providerARN, ":oidc-provider/")
+		if ok && path == issuerPath {
+			return true
+		}
+	}
+	return false
+}
+
+// checkNodeIMDSv2 flags node groups whose instances accept IMDSv1, which lets
+// any pod on the node steal the node role's credentials via SSRF.
+func (e *Scanner) checkNodeIMDSv2(ctx context.Context, clusterName string) []scanner.Finding {
+	var findings []scanner.Finding
+	paginator := eks.NewListNodegroupsPaginator(e.client, &eks.ListNodegroupsInput{ClusterName: aws.String(clusterName)})
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return findings
+		}
+		for _, ngName := range output.Nodegroups {
+			out, err := e.client.DescribeNodegroup(ctx, &eks.DescribeNodegroupInput{
+				ClusterName:   aws.String(clusterName),
+				NodegroupName: aws.String(ngName),
+			})
+			if err != nil || out.Nodegroup == nil {
+				continue
+			}
+
+			required, err := e.nodegroupRequiresIMDSv2(ctx, out.Nodegroup)
+			if err != nil {
+				continue
+			}
+
+			resourceID := fmt.Sprintf("%s/%s", clusterName, ngName)
+			finding := e.createFinding(
+				"eks_node_imdsv2",
+				resourceID,
+				"EKS node group allows IMDSv1",
+				fmt.Sprintf("Node group %s in cluster %s allows IMDSv1, exposing node role credentials to pods", ngName, clusterName),
+				scanner.StatusFail,
+				scanner.SeverityHigh,
+			)
+			if required {
+				finding = e.createFinding(
+					"eks_node_imdsv2",
+					resourceID,
+					"EKS node group requires IMDSv2",
+					fmt.Sprintf("Node group %s in cluster %s requires IMDSv2 session tokens", ngName, clusterName),
+					scanner.StatusPass,
+					scanner.SeverityHigh,
+				)
+			}
+			finding.ARN = aws.ToString(out.Nodegroup.NodegroupArn)
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// nodegroupRequiresIMDSv2 evaluates the node group's launch template metadata
+// options. Node groups without a custom launch template use the EKS default,
+// which leaves IMDSv1 enabled.
+func (e *Scanner) nodegroupRequiresIMDSv2(ctx context.Context, ng *types.Nodegroup) (bool, error) {
+	lt := ng.LaunchTemplate
+	if lt == nil {
+		return false, nil
+	}
+
+	input := &ec2.DescribeLaunchTemplateVersionsInput{
+		Versions: []string{aws.ToString(lt.Version)},
+	}
+	if lt.Version == nil {
+		input.Versions = []string{"$Default"}
+	}
+	if lt.Id != nil {
+		input.LaunchTemplateId = lt.Id
+	} else {
+		input.LaunchTemplateName = lt.Name
+	}
+
+	out, err := e.ec2Client.DescribeLaunchTemplateVersions(ctx, input)
+	if err != nil {
+		return false, err
+	}
+	if len(out.LaunchTemplateVersions) == 0 || out.LaunchTemplateVersions[0].LaunchTemplateData == nil {
+		return false, nil
+	}
+	return metadataRequiresIMDSv2(out.LaunchTemplateVersions[0].LaunchTemplateData.MetadataOptions), nil
+}
+
+// metadataRequiresIMDSv2 reports whether launch template metadata options
+// block IMDSv1, either by requiring tokens or disabling the endpoint.
+func metadataRequiresIMDSv2(opts *ec2types.LaunchTemplateInstanceMetadataOptions) bool {
+	if opts == nil {
+		return false
+	}
+	if opts.HttpEndpoint == ec2types.LaunchTemplateInstanceMetadataEndpointStateDisabled {
+		return true
+	}
+	return opts.HttpTokens == ec2types.LaunchTemplateHttpTokensStateRequired
+}
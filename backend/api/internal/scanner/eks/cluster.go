@@ -0,0 +1,214 @@
+package eks
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+)
+
+// defaultMinSupportedVersion is the oldest Kubernetes version still in EKS
+// standard support. Older clusters run on paid extended support or are forced
+// to upgrade. Raise it as versions reach their end of standard support.
+const defaultMinSupportedVersion = "1.34"
+
+// requiredLogTypes are the control plane logs needed to audit who did what
+// in a cluster.
+var requiredLogTypes = []types.LogType{types.LogTypeAudit, types.LogTypeAuthenticator}
+
+// openCIDRs are the public access CIDRs that allow any address.
+var openCIDRs = []string{"0.0.0.0/0", "::/0"}
+
+// checkEndpointAccess flags clusters whose API server endpoint is reachable
+// from any address on the internet.
+func (e *Scanner) checkEndpointAccess(cluster *types.Cluster) []scanner.Finding {
+	name := aws.ToString(cluster.Name)
+	vpc := cluster.ResourcesVpcConfig
+
+	if vpc == nil || !vpc.EndpointPublicAccess {
+		return []scanner.Finding{e.createFinding(
+			"eks_private_endpoint",
+			name,
+			"EKS cluster endpoint is private",
+			fmt.Sprintf("Cluster %s API server endpoint is only reachable from within the VPC", name),
+			scanner.StatusPass,
+			scanner.SeverityHigh,
+		)}
+	}
+	if publicToInternet(vpc.PublicAccessCidrs) {
+		return []scanner.Finding{e.createFinding(
+			"eks_private_endpoint",
+			name,
+			"EKS cluster endpoint is open to the internet",
+			fmt.Sprintf("Cluster %s API server endpoint is publicly reachable from any address", name),
+			scanner.StatusFail,
+			scanner.SeverityHigh,
+		)}
+	}
+	return []scanner.Finding{e.createFinding(
+		"eks_private_endpoint",
+		name,
+		"EKS cluster public endpoint is restricted",
+		fmt.Sprintf("Cluster %s API server endpoint is public but limited to %s", name, strings.Join(vpc.PublicAccessCidrs, ", ")),
+		scanner.StatusPass,
+		scanner.SeverityHigh,
+	)}
+}
+
+// publicToInternet reports whether a public endpoint with the given access
+// CIDRs accepts any address. EKS treats an empty list as 0.0.0.0/0.
+func publicToInternet(cidrs []string) bool {
+	if len(cidrs) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(cidrs, func(cidr string) bool {
+		return slices.Contains(openCIDRs, strings.TrimSpace(cidr))
+	})
+}
+
+// checkControlPlaneLogging flags clusters that do not send the audit and
+// authenticator control plane logs to CloudWatch.
+func (e *Scanner) checkControlPlaneLogging(cluster *types.Cluster) []scanner.Finding {
+	name := aws.ToString(cluster.Name)
+	missing := missingLogTypes(cluster.Logging, requiredLogTypes)
+	if len(missing) > 0 {
+		names := make([]string, len(missing))
+		for i, t := range missing {
+			names[i] = string(t)
+		}
+		return []scanner.Finding{e.createFinding(
+			"eks_control_plane_logging",
+			name,
+			"EKS control plane logging is incomplete",
+			fmt.Sprintf("Cluster %s does not log %s control plane events", name, strings.Join(names, ", ")),
+			scanner.StatusFail,
+			scanner.SeverityMedium,
+		)}
+	}
+	return []scanner.Finding{e.createFinding(
+		"eks_control_plane_logging",
+		name,
+		"EKS control plane logging is enabled",
+		fmt.Sprintf("Cluster %s logs audit and authenticator control plane events", name),
+		scanner.StatusPass,
+		scanner.SeverityMedium,
+	)}
+}
+
+// missingLogTypes returns the log types in required that logging does not
+// enable, in the order given.
+func missingLogTypes(logging *types.Logging, required []types.LogType) []types.LogType {
+	enabled := make(map[types.LogType]bool)
+	if logging != nil {
+		for _, setup := range logging.ClusterLogging {
+			for _, t := range setup.Types {
+				// A type can appear in both an enabled and a disabled setup;
+				// any enabled setup wins.
+				enabled[t] = enabled[t] || aws.ToBool(setup.Enabled)
+			}
+		}
+	}
+
+	var missing []types.LogType
+	for _, t := range required {
+		if !enabled[t] {
+			missing = append(missing, t)
+		}
+	}
+	return missing
+}
+
+// checkSecretsEncryption flags clusters that do not envelope-encrypt
+// Kubernetes secrets with a KMS key.
+func (e *Scanner) checkSecretsEncryption(cluster *types.Cluster) []scanner.Finding {
+	name := aws.ToString(cluster.Name)
+	for _, config := range cluster.EncryptionConfig {
+		if slices.Contains(config.Resources, "secrets") && config.Provider != nil && aws.ToString(config.Provider.KeyArn) != "" {
+			return []scanner.Finding{e.createFinding(
+				"eks_secrets_encryption",
+				name,
+				"EKS secrets are envelope encrypted",
+				fmt.Sprintf("Cluster %s encrypts secrets with KMS key %s", name, aws.ToString(config.Provider.KeyArn)),
+				scanner.StatusPass,
+				scanner.SeverityHigh,
+			)}
+		}
+	}
+	return []scanner.Finding{e.createFinding(
+		"eks_secrets_encryption",
+		name,
+		"EKS secrets are not envelope encrypted",
+		fmt.Sprintf("Cluster %s stores Kubernetes secrets without KMS envelope encryption", name),
+		scanner.StatusFail,
+		scanner.SeverityHigh,
+	)}
+}
+
+// checkVersionSupported flags clusters running a Kubernetes version older
+// than the minimum in standard support.
+func (e *Scanner) checkVersionSupported(cluster *types.Cluster) []scanner.Finding {
+	name := aws.ToString(cluster.Name)
+	version := aws.ToString(cluster.Version)
+	minimum := e.minVersion()
+
+	supported, err := versionAtLeast(version, minimum)
+	if err != nil {
+		return nil
+	}
+	if !supported {
+		return []scanner.Finding{e.createFinding(
+			"eks_version_supported",
+			name,
+			"EKS cluster runs an unsupported Kubernetes version",
+			fmt.Sprintf("Cluster %s runs Kubernetes %s, older than %s, the oldest version in standard support", name, version, minimum),
+			scanner.StatusFail,
+			scanner.SeverityMedium,
+		)}
+	}
+	return []scanner.Finding{e.createFinding(
+		"eks_version_supported",
+		name,
+		"EKS cluster runs a supported Kubernetes version",
+		fmt.Sprintf("Cluster %s runs Kubernetes %s", name, version),
+		scanner.StatusPass,
+		scanner.SeverityMedium,
+	)}
+}
+
+// versionAtLeast reports whether the major.minor Kubernetes version is at
+// least minimum.
+func versionAtLeast(version, minimum string) (bool, error) {
+	v, err := parseVersion(version)
+	if err != nil {
+		return false, err
+	}
+	m, err := parseVersion(minimum)
+	if err != nil {
+		return false, err
+	}
+	if v[0] != m[0] {
+		return v[0] > m[0], nil
+	}
+	return v[1] >= m[1], nil
+}
+
+func parseVersion(version string) ([2]int, error) {
+	majorStr, minorStr, ok := strings.Cut(version, ".")
+	if !ok {
+		return [2]int{}, fmt.Errorf("invalid Kubernetes version %q", version)
+	}
+	major, err := strconv.Atoi(majorStr)
+	if err != nil {
+		return [2]int{}, fmt.Errorf("invalid Kubernetes version %q", version)
+	}
+	minor, err := strconv.Atoi(minorStr)
+	if err != nil {
+		return [2]int{}, fmt.Errorf("invalid Kubernetes version %q", version)
+	}
+	return [2]int{major, minor}, nil
+}
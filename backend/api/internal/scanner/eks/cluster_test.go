@@ -0,0 +1,155 @@
+package eks
+
+import (
+	"slices"
+	"testing"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+)
+
+func TestCheckEndpointAccess(t *testing.T) {
+	tests := []struct {
+		name string
+		vpc  *types.VpcConfigResponse
+		want scanner.FindingStatus
+	}{
+		{"private only", &types.VpcConfigResponse{EndpointPrivateAccess: true}, scanner.StatusPass},
+		{"no VPC config", nil, scanner.StatusPass},
+		{"public to any IPv4", &types.VpcConfigResponse{EndpointPublicAccess: true, PublicAccessCidrs: []string{"0.0.0.0/0"}}, scanner.StatusFail},
+		{"public to any IPv6", &types.VpcConfigResponse{EndpointPublicAccess: true, PublicAccessCidrs: []string{"203.0.113.0/24", "::/0"}}, scanner.StatusFail},
+		{"public without CIDRs", &types.VpcConfigResponse{EndpointPublicAccess: true}, scanner.StatusFail},
+		{"public to office range", &types.VpcConfigResponse{EndpointPublicAccess: true, PublicAccessCidrs: []string{"203.0.113.0/24"}}, scanner.StatusPass},
+	}
+
+	s := &Scanner{region: "us-east-1", accountID: "123456789012"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := s.checkEndpointAccess(&types.Cluster{Name: aws.String("prod"), ResourcesVpcConfig: tt.vpc})
+
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			if f := findings[0]; f.CheckID != "eks_private_endpoint" || f.Status != tt.want {
+				t.Errorf("got %s %s, want eks_private_endpoint %s", f.CheckID, f.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestMissingLogTypes(t *testing.T) {
+	setup := func(enabled bool, logTypes ...types.LogType) types.LogSetup {
+		return types.LogSetup{Enabled: aws.Bool(enabled), Types: logTypes}
+	}
+
+	tests := []struct {
+		name    string
+		logging *types.Logging
+		want    []types.LogType
+	}{
+		{"no logging config", nil, []types.LogType{types.LogTypeAudit, types.LogTypeAuthenticator}},
+		{
+			name: "all disabled",
+			logging: &types.Logging{ClusterLogging: []types.LogSetup{
+				setup(false, types.LogTypeApi, types.LogTypeAudit, types.LogTypeAuthenticator),
+			}},
+			want: []types.LogType{types.LogTypeAudit, types.LogTypeAuthenticator},
+		},
+		{
+			name: "audit only",
+			logging: &types.Logging{ClusterLogging: []types.LogSetup{
+				setup(true, types.LogTypeApi, types.LogTypeAudit),
+				setup(false, types.LogTypeAuthenticator, types.LogTypeScheduler),
+			}},
+			want: []types.LogType{types.LogTypeAuthenticator},
+		},
+		{
+			name: "required types enabled",
+			logging: &types.Logging{ClusterLogging: []types.LogSetup{
+				setup(true, types.LogTypeAudit, types.LogTypeAuthenticator),
+				setup(false, types.LogTypeApi),
+			}},
+		},
+		{
+			name: "enabled setup wins over disabled",
+			logging: &types.Logging{ClusterLogging: []types.LogSetup{
+				setup(false, types.LogTypeAudit),
+				setup(true, types.LogTypeAudit, types.LogTypeAuthenticator),
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := missingLogTypes(tt.logging, requiredLogTypes); !slices.Equal(got, tt.want) {
+				t.Errorf("missingLogTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckSecretsEncryption(t *testing.T) {
+	s := &Scanner{region: "us-east-1", accountID: "123456789012"}
+	keyARN := "arn:aws:kms:us-east-1:123456789012:key/abc"
+
+	tests := []struct {
+		name   string
+		config []types.EncryptionConfig
+		want   scanner.FindingStatus
+	}{
+		{"not configured", nil, scanner.StatusFail},
+		{"secrets with key", []types.EncryptionConfig{{Resources: []string{"secrets"}, Provider: &types.Provider{KeyArn: aws.String(keyARN)}}}, scanner.StatusPass},
+		{"no provider", []types.EncryptionConfig{{Resources: []string{"secrets"}}}, scanner.StatusFail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := s.checkSecretsEncryption(&types.Cluster{Name: aws.String("prod"), EncryptionConfig: tt.config})
+			if len(findings) != 1 || findings[0].Status != tt.want {
+				t.Errorf("findings = %+v, want one %s finding", findings, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckVersionSupported(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		version string
+		want    scanner.FindingStatus
+	}{
+		{"current", nil, defaultMinSupportedVersion, scanner.StatusPass},
+		{"old", nil, "1.27", scanner.StatusFail},
+		{"minor compared numerically", []Option{WithMinSupportedVersion("1.9")}, "1.10", scanner.StatusPass},
+		{"configured minimum", []Option{WithMinSupportedVersion("1.35")}, "1.34", scanner.StatusFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Scanner{region: "us-east-1", accountID: "123456789012"}
+			for _, opt := range tt.opts {
+				opt(s)
+			}
+			findings := s.checkVersionSupported(&types.Cluster{Name: aws.String("prod"), Version: aws.String(tt.version)})
+			if len(findings) != 1 || findings[0].Status != tt.want {
+				t.Errorf("findings = %+v, want one %s finding", findings, tt.want)
+			}
+		})
+	}
+
+	s := &Scanner{}
+	if findings := s.checkVersionSupported(&types.Cluster{Name: aws.String("prod"), Version: aws.String("latest")}); len(findings) != 0 {
+		t.Errorf("unparseable version produced findings %+v", findings)
+	}
+}
+
+func TestScanner_resourceARN(t *testing.T) {
+	s := &Scanner{region: "us-east-1", accountID: "123456789012"}
+
+	findings := s.checkSecretsEncryption(&types.Cluster{Name: aws.String("prod")})
+	if want := "arn:aws:eks:us-east-1:123456789012:cluster/prod"; len(findings) != 1 || findings[0].ARN != want {
+		t.Errorf("findings = %+v, want one finding with ARN %s", findings, want)
+	}
+}
@@ -0,0 +1,191 @@
+// Package eks provides EKS security scanning capabilities.
+package eks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloudcop/api/internal/awsauth"
+	"cloudcop/api/internal/scanner"
+	"cloudcop/api/internal/scanner/checks"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+)
+
+// eksAPI is the subset of the EKS client used by the scanner.
+type eksAPI interface {
+	ListClusters(ctx context.Context, params *eks.ListClustersInput, optFns ...func(*eks.Options)) (*eks.ListClustersOutput, error)
+	DescribeCluster(ctx context.Context, params *eks.DescribeClusterInput, optFns ...func(*eks.Options)) (*eks.DescribeClusterOutput, error)
+	ListNodegroups(ctx context.Context, params *eks.ListNodegroupsInput, optFns ...func(*eks.Options)) (*eks.ListNodegroupsOutput, error)
+	DescribeNodegroup(ctx context.Context, params *eks.DescribeNodegroupInput, optFns ...func(*eks.Options)) (*eks.DescribeNodegroupOutput, error)
+}
+
+// iamAPI is the subset of the IAM client used to look up OIDC providers.
+type iamAPI interface {
+	ListOpenIDConnectProviders(ctx context.Context, params *iam.ListOpenIDConnectProvidersInput, optFns ...func(*iam.Options)) (*iam.ListOpenIDConnectProvidersOutput, error)
+}
+
+// ec2API is the subset of the EC2 client used to inspect node launch templates.
+type ec2API interface {
+	DescribeLaunchTemplateVersions(ctx context.Context, params *ec2.DescribeLaunchTemplateVersionsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeLaunchTemplateVersionsOutput, error)
+}
+
+// Scanner performs security checks on EKS clusters and their node groups.
+type Scanner struct {
+	client    eksAPI
+	iamClient iamAPI
+	ec2Client ec2API
+	region    string
+	accountID string
+
+	minSupportedVersion string
+}
+
+// Option configures a Scanner.
+type Option func(*Scanner)
+
+// WithMinSupportedVersion overrides the oldest Kubernetes version, such as
+// "1.33", that eks_version_supported accepts. Empty keeps the default.
+func WithMinSupportedVersion(version string) Option {
+	return func(s *Scanner) {
+		s.minSupportedVersion = version
+	}
+}
+
+// NewScanner creates a new EKS scanner for the given region and account ID.
+func NewScanner(cfg aws.Config, region, accountID string, opts ...Option) scanner.ServiceScanner {
+	s := &Scanner{
+		client:    eks.NewFromConfig(cfg),
+		iamClient: iam.NewFromConfig(cfg),
+		ec2Client: ec2.NewFromConfig(cfg),
+		region:    region,
+		accountID: accountID,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// NewFactory returns a scanner.Factory that builds Scanners with opts applied.
+func NewFactory(opts ...Option) scanner.Factory {
+	return func(cfg aws.Config, region, accountID string) scanner.ServiceScanner {
+		return NewScanner(cfg, region, accountID, opts...)
+	}
+}
+
+// Service returns the AWS service name.
+func (e *Scanner) Service() string {
+	return "eks"
+}
+
+// Scan executes all EKS security checks.
+func (e *Scanner) Scan(ctx context.Context, _ string) ([]scanner.Finding, error) {
+	clusters, err := e.listClusters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing clusters: %w", err)
+	}
+	scanner.CountResources(ctx, len(clusters))
+
+	var findings []scanner.Finding
+	var oidcProviders []string
+	if len(clusters) > 0 {
+		oidcProviders, err = e.listOIDCProviders(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing OIDC providers: %w", err)
+		}
+	}
+
+	findings = append(findings, scanner.TraceChecks(ctx, "eks.clusters", func(ctx context.Context) []scanner.Finding {
+		var clusterFindings []scanner.Finding
+		for _, name := range clusters {
+			out, err := e.client.DescribeCluster(ctx, &eks.DescribeClusterInput{Name: aws.String(name)})
+			if err != nil || out.Cluster == nil {
+				continue
+			}
+			clusterFindings = append(clusterFindings, e.checkEndpointAccess(out.Cluster)...)
+			clusterFindings = append(clusterFindings, e.checkControlPlaneLogging(out.Cluster)...)
+			clusterFindings = append(clusterFindings, e.checkSecretsEncryption(out.Cluster)...)
+			clusterFindings = append(clusterFindings, e.checkVersionSupported(out.Cluster)...)
+			clusterFindings = append(clusterFindings, e.checkIRSA(out.Cluster, oidcProviders)...)
+			clusterFindings = append(clusterFindings, e.checkNodeIMDSv2(ctx, name)...)
+		}
+		return clusterFindings
+	})...)
+
+	return findings, nil
+}
+
+func (e *Scanner) listClusters(ctx context.Context) ([]string, error) {
+	var clusters []string
+	paginator := eks.NewListClustersPaginator(e.client, &eks.ListClustersInput{})
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, output.Clusters...)
+	}
+	return clusters, nil
+}
+
+// listOIDCProviders returns the ARNs of the account's IAM OIDC identity providers.
+func (e *Scanner) listOIDCProviders(ctx context.Context) ([]string, error) {
+	out, err := e.iamClient.ListOpenIDConnectProviders(ctx, &iam.ListOpenIDConnectProvidersInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	arns := make([]string, 0, len(out.OpenIDConnectProviderList))
+	for _, p := range out.OpenIDConnectProviderList {
+		arns = append(arns, aws.ToString(p.Arn))
+	}
+	return arns, nil
+}
+
+func (e *Scanner) createFinding(checkID, resourceID, title, description string, status scanner.FindingStatus, severity scanner.Severity) scanner.Finding {
+	return scanner.Finding{
+		FindingID:   scanner.FindingID(e.accountID, e.Service(), e.region, checkID, resourceID),
+		Service:     e.Service(),
+		Region:      e.region,
+		ResourceID:  resourceID,
+		ARN:         e.resourceARN(checkID, resourceID),
+		CheckID:     checkID,
+		Status:      status,
+		Severity:    severity,
+		Confidence:  scanner.ConfidenceFor(checkID),
+		Title:       title,
+		Description: description,
+		Compliance:  checks.Compliance(checkID),
+		Timestamp:   time.Now(),
+	}
+}
+
+// resourceARN returns the ARN of the named cluster. Node group ARNs end in an
+// ID that EKS assigns, so node group findings have their ARN set by the check.
+func (e *Scanner) resourceARN(checkID, clusterName string) string {
+	if checkID == "eks_node_imdsv2" {
+		return ""
+	}
+	return arn.ARN{
+		Partition: awsauth.PartitionForRegion(e.region),
+		Service:   "eks",
+		Region:    e.region,
+		AccountID: e.accountID,
+		Resource:  "cluster/" + clusterName,
+	}.String()
+}
+
+// minVersion returns the configured oldest supported Kubernetes version.
+func (e *Scanner) minVersion() string {
+	if e.minSupportedVersion != "" {
+		return e.minSupportedVersion
+	}
+	return defaultMinSupportedVersion
+}
@@ -0,0 +1,193 @@
+package eks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+)
+
+const testServiceName = "eks"
+
+// fakeEKSClient implements eksAPI with canned responses.
+// Methods that are not overridden panic via the nil embedded interface.
+type fakeEKSClient struct {
+	eksAPI
+	nodegroups map[string]*types.Nodegroup
+}
+
+func (f *fakeEKSClient) ListNodegroups(_ context.Context, _ *eks.ListNodegroupsInput, _ ...func(*eks.Options)) (*eks.ListNodegroupsOutput, error) {
+	out := &eks.ListNodegroupsOutput{}
+	for name := range f.nodegroups {
+		out.Nodegroups = append(out.Nodegroups, name)
+	}
+	return out, nil
+}
+
+func (f *fakeEKSClient) DescribeNodegroup(_ context.Context, params *eks.DescribeNodegroupInput, _ ...func(*eks.Options)) (*eks.DescribeNodegroupOutput, error) {
+	return &eks.DescribeNodegroupOutput{Nodegroup: f.nodegroups[aws.ToString(params.NodegroupName)]}, nil
+}
+
+// fakeEC2Client serves launch template metadata options by template ID.
+type fakeEC2Client struct {
+	ec2API
+	metadata map[string]*ec2types.LaunchTemplateInstanceMetadataOptions
+}
+
+func (f *fakeEC2Client) DescribeLaunchTemplateVersions(_ context.Context, params *ec2.DescribeLaunchTemplateVersionsInput, _ ...func(*ec2.Options)) (*ec2.DescribeLaunchTemplateVersionsOutput, error) {
+	opts, ok := f.metadata[aws.ToString(params.LaunchTemplateId)]
+	if !ok {
+		return nil, errors.New("launch template not found")
+	}
+	return &ec2.DescribeLaunchTemplateVersionsOutput{
+		LaunchTemplateVersions: []ec2types.LaunchTemplateVersion{
+			{LaunchTemplateData: &ec2types.ResponseLaunchTemplateData{MetadataOptions: opts}},
+		},
+	}, nil
+}
+
+func TestNewScanner(t *testing.T) {
+	s := NewScanner(aws.Config{Region: "us-east-1"}, "us-east-1", "123456789012")
+
+	es, ok := s.(*Scanner)
+	if !ok {
+		t.Fatal("NewScanner did not return *Scanner type")
+	}
+	if es.region != "us-east-1" || es.accountID != "123456789012" {
+		t.Errorf("got region=%s accountID=%s", es.region, es.accountID)
+	}
+	if es.client == nil || es.iamClient == nil || es.ec2Client == nil {
+		t.Error("clients not initialized")
+	}
+}
+
+func TestScanner_Service(t *testing.T) {
+	s := &Scanner{}
+
+	if got := s.Service(); got != testServiceName {
+		t.Errorf("Service() = %v, want %s", got, testServiceName)
+	}
+}
+
+func TestCheckIRSA(t *testing.T) {
+	issuer := "https://oidc.eks.us-east-1.amazonaws.com/id/ABC123"
+	providers := []string{
+		"arn:aws:iam::123456789012:oidc-provider/token.actions.githubusercontent.com",
+		"arn:aws:iam::123456789012:oidc-provider/oidc.eks.us-east-1.amazonaws.com/id/ABC123",
+	}
+
+	tests := []struct {
+		name      string
+		identity  *types.Identity
+		providers []string
+		want      scanner.FindingStatus
+	}{
+		{
+			name:      "registered provider",
+			identity:  &types.Identity{Oidc: &types.OIDC{Issuer: aws.String(issuer)}},
+			providers: providers,
+			want:      scanner.StatusPass,
+		},
+		{
+			name:      "issuer without provider",
+			identity:  &types.Identity{Oidc: &types.OIDC{Issuer: aws.String(issuer)}},
+			providers: providers[:1],
+			want:      scanner.StatusFail,
+		},
+		{
+			name:      "provider for another cluster",
+			identity:  &types.Identity{Oidc: &types.OIDC{Issuer: aws.String("https://oidc.eks.us-east-1.amazonaws.com/id/OTHER")}},
+			providers: providers,
+			want:      scanner.StatusFail,
+		},
+		{
+			name:      "no issuer",
+			identity:  nil,
+			providers: providers,
+			want:      scanner.StatusFail,
+		},
+	}
+
+	s := &Scanner{region: "us-east-1", accountID: "123456789012"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := &types.Cluster{Name: aws.String("prod"), Identity: tt.identity}
+
+			findings := s.checkIRSA(cluster, tt.providers)
+
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			f := findings[0]
+			if f.Status != tt.want {
+				t.Errorf("Status = %s, want %s", f.Status, tt.want)
+			}
+			if f.CheckID != "eks_irsa_configured" || f.Severity != scanner.SeverityHigh || f.ResourceID != "prod" {
+				t.Errorf("unexpected finding: %+v", f)
+			}
+		})
+	}
+}
+
+func TestCheckNodeIMDSv2(t *testing.T) {
+	client := &fakeEKSClient{
+		nodegroups: map[string]*types.Nodegroup{
+			"default": {NodegroupName: aws.String("default")},
+			"hardened": {
+				NodegroupName:  aws.String("hardened"),
+				NodegroupArn:   aws.String("arn:aws:eks:us-east-1:123456789012:nodegroup/prod/hardened/abc"),
+				LaunchTemplate: &types.LaunchTemplateSpecification{Id: aws.String("lt-required"), Version: aws.String("3")},
+			},
+			"legacy": {
+				NodegroupName:  aws.String("legacy"),
+				LaunchTemplate: &types.LaunchTemplateSpecification{Id: aws.String("lt-optional")},
+			},
+			"no-endpoint": {
+				NodegroupName:  aws.String("no-endpoint"),
+				LaunchTemplate: &types.LaunchTemplateSpecification{Id: aws.String("lt-disabled")},
+			},
+			"missing-template": {
+				NodegroupName:  aws.String("missing-template"),
+				LaunchTemplate: &types.LaunchTemplateSpecification{Id: aws.String("lt-deleted")},
+			},
+		},
+	}
+	ec2Client := &fakeEC2Client{
+		metadata: map[string]*ec2types.LaunchTemplateInstanceMetadataOptions{
+			"lt-required": {HttpTokens: ec2types.LaunchTemplateHttpTokensStateRequired},
+			"lt-optional": {HttpTokens: ec2types.LaunchTemplateHttpTokensStateOptional},
+			"lt-disabled": {HttpEndpoint: ec2types.LaunchTemplateInstanceMetadataEndpointStateDisabled},
+		},
+	}
+	s := &Scanner{client: client, ec2Client: ec2Client, region: "us-east-1", accountID: "123456789012"}
+
+	findings := s.checkNodeIMDSv2(context.Background(), "prod")
+
+	want := map[string]scanner.FindingStatus{
+		"prod/default":     scanner.StatusFail,
+		"prod/hardened":    scanner.StatusPass,
+		"prod/legacy":      scanner.StatusFail,
+		"prod/no-endpoint": scanner.StatusPass,
+	}
+	if len(findings) != len(want) {
+		t.Fatalf("expected %d findings, got %d: %+v", len(want), len(findings), findings)
+	}
+	for _, f := range findings {
+		if f.CheckID != "eks_node_imdsv2" || f.Severity != scanner.SeverityHigh {
+			t.Errorf("unexpected finding: %+v", f)
+		}
+		if status, ok := want[f.ResourceID]; !ok || f.Status != status {
+			t.Errorf("%s: Status = %s, want %s", f.ResourceID, f.Status, status)
+		}
+		if f.ResourceID == "prod/hardened" && f.ARN != "arn:aws:eks:us-east-1:123456789012:nodegroup/prod/hardened/abc" {
+			t.Errorf("%s: ARN = %q, want the node group's ARN", f.ResourceID, f.ARN)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package elb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+)
+
+// deprecatedTLSPolicies lists predefined security policies that allow TLS 1.0
+// or 1.1 but whose names do not carry a protocol version.
+var deprecatedTLSPolicies = map[string]bool{
+	"ELBSecurityPolicy-2015-05":    true,
+	"ELBSecurityPolicy-2016-08":    true,
+	"ELBSecurityPolicy-FS-2018-06": true,
+}
+
+// deprecatedTLSVersionMarkers identify versioned policy names whose minimum
+// protocol is TLS 1.0 or 1.1, e.g. ELBSecurityPolicy-TLS-1-1-2017-01 or
+// ELBSecurityPolicy-TLS13-1-0-2021-06.
+var deprecatedTLSVersionMarkers = []string{"-1-0-", "-1-1-"}
+
+// isDeprecatedTLSPolicy reports whether an ELB security policy still
+// negotiates TLS 1.0 or 1.1.
+func isDeprecatedTLSPolicy(policy string) bool {
+	if deprecatedTLSPolicies[policy] {
+		return true
+	}
+	for _, marker := range deprecatedTLSVersionMarkers {
+		if strings.Contains(policy, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTLSListener reports whether the listener terminates TLS on the load balancer.
+func isTLSListener(l types.Listener) bool {
+	return l.Protocol == types.ProtocolEnumHttps || l.Protocol == types.ProtocolEnumTls
+}
+
+// checkHTTPSListener fails load balancers whose only listeners are plain HTTP.
+// An HTTP listener alongside an HTTPS one, typically redirecting, is accepted.
+func (e *Scanner) checkHTTPSListener(name string, listeners []types.Listener) []scanner.Finding {
+	hasHTTP := false
+	for _, l := range listeners {
+		switch {
+		case isTLSListener(l):
+			return []scanner.Finding{e.createFinding(
+				"elb_https_listener",
+				name,
+				"Load balancer has an encrypted listener",
+				fmt.Sprintf("Load balancer %s terminates TLS on at least one listener", name),
+				scanner.StatusPass,
+				scanner.SeverityHigh,
+			)}
+		case l.Protocol == types.ProtocolEnumHttp:
+			hasHTTP = true
+		}
+	}
+
+	if !hasHTTP {
+		return nil
+	}
+	return []scanner.Finding{e.createFinding(
+		"elb_https_listener",
+		name,
+		"Load balancer only has HTTP listeners",
+		fmt.Sprintf("Load balancer %s serves traffic over unencrypted HTTP only", name),
+		scanner.StatusFail,
+		scanner.SeverityHigh,
+	)}
+}
+
+// checkTLSPolicy fails load balancers with a TLS listener using a security
+// policy that allows TLS 1.0 or 1.1. Load balancers without TLS listeners are skipped.
+func (e *Scanner) checkTLSPolicy(name string, listeners []types.Listener) []scanner.Finding {
+	var deprecated []string
+	checked := false
+	for _, l := range listeners {
+		if !isTLSListener(l) {
+			continue
+		}
+		checked = true
+		if policy := aws.ToString(l.SslPolicy); isDeprecatedTLSPolicy(policy) {
+			deprecated = append(deprecated, fmt.Sprintf("port %d (%s)", aws.ToInt32(l.Port), policy))
+		}
+	}
+
+	if !checked {
+		return nil
+	}
+	if len(deprecated) > 0 {
+		return []scanner.Finding{e.createFinding(
+			"elb_tls_policy",
+			name,
+			"Load balancer uses a deprecated TLS policy",
+			fmt.Sprintf("Load balancer %s allows TLS 1.0/1.1 on %s", name, strings.Join(deprecated, ", ")),
+			scanner.StatusFail,
+			scanner.SeverityMedium,
+		)}
+	}
+	return []scanner.Finding{e.createFinding(
+		"elb_tls_policy",
+		name,
+		"Load balancer TLS policies are current",
+		fmt.Sprintf("Load balancer %s requires TLS 1.2 or later on all TLS listeners", name),
+		scanner.StatusPass,
+		scanner.SeverityMedium,
+	)}
+}
+
+// checkAttributes evaluates access logging and deletion protection, which
+// both come from the load balancer's attributes.
+func (e *Scanner) checkAttributes(ctx context.Context, lb types.LoadBalancer) []scanner.Finding {
+	name := aws.ToString(lb.LoadBalancerName)
+	out, err := e.client.DescribeLoadBalancerAttributes(ctx, &elb.DescribeLoadBalancerAttributesInput{
+		LoadBalancerArn: lb.LoadBalancerArn,
+	})
+	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{
+				e.accessDeniedFinding("elb_access_logs", name, err),
+				e.accessDeniedFinding("elb_deletion_protection", name, err),
+			}
+		}
+		return nil
+	}
+
+	attrs := make(map[string]string, len(out.Attributes))
+	for _, a := range out.Attributes {
+		attrs[aws.ToString(a.Key)] = aws.ToString(a.Value)
+	}
+
+	var findings []scanner.Finding
+	if attrs["access_logs.s3.enabled"] == "true" {
+		findings = append(findings, e.createFinding(
+			"elb_access_logs",
+			name,
+			"Load balancer access logging is enabled",
+			fmt.Sprintf("Load balancer %s delivers access logs to S3", name),
+			scanner.StatusPass,
+			scanner.SeverityMedium,
+		))
+	} else {
+		findings = append(findings, e.createFinding(
+			"elb_access_logs",
+			name,
+			"Load balancer access logging is disabled",
+			fmt.Sprintf("Load balancer %s does not record access logs", name),
+			scanner.StatusFail,
+			scanner.SeverityMedium,
+		))
+	}
+
+	if attrs["deletion_protection.enabled"] == "true" {
+		findings = append(findings, e.createFinding(
+			"elb_deletion_protection",
+			name,
+			"Load balancer deletion protection is enabled",
+			fmt.Sprintf("Load balancer %s cannot be deleted until protection is removed", name),
+			scanner.StatusPass,
+			scanner.SeverityLow,
+		))
+	} else {
+		findings = append(findings, e.createFinding(
+			"elb_deletion_protection",
+			name,
+			"Load balancer deletion protection is disabled",
+			fmt.Sprintf("Load balancer %s can be deleted without first disabling protection", name),
+			scanner.StatusFail,
+			scanner.SeverityLow,
+		))
+	}
+	return findings
+}
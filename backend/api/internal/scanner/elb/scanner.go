@@ -0,0 +1,153 @@
+// Package elb provides Elastic Load Balancing (ALB/NLB) security scanning capabilities.
+package elb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloudcop/api/internal/scanner"
+	"cloudcop/api/internal/scanner/checks"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+)
+
+// elbAPI is the subset of the Elastic Load Balancing v2 client used by the scanner.
+type elbAPI interface {
+	DescribeLoadBalancers(ctx context.Context, params *elb.DescribeLoadBalancersInput, optFns ...func(*elb.Options)) (*elb.DescribeLoadBalancersOutput, error)
+	DescribeListeners(ctx context.Context, params *elb.DescribeListenersInput, optFns ...func(*elb.Options)) (*elb.DescribeListenersOutput, error)
+	DescribeLoadBalancerAttributes(ctx context.Context, params *elb.DescribeLoadBalancerAttributesInput, optFns ...func(*elb.Options)) (*elb.DescribeLoadBalancerAttributesOutput, error)
+}
+
+// Scanner performs security checks on application and network load balancers.
+type Scanner struct {
+	client    elbAPI
+	region    string
+	accountID string
+}
+
+// Option configures a Scanner.
+type Option func(*Scanner)
+
+// NewScanner creates a new load balancer scanner for the given region and account ID.
+func NewScanner(cfg aws.Config, region, accountID string, opts ...Option) scanner.ServiceScanner {
+	s := &Scanner{
+		client:    elb.NewFromConfig(cfg),
+		region:    region,
+		accountID: accountID,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// NewFactory returns a scanner.Factory that builds Scanners with opts applied.
+func NewFactory(opts ...Option) scanner.Factory {
+	return func(cfg aws.Config, region, accountID string) scanner.ServiceScanner {
+		return NewScanner(cfg, region, accountID, opts...)
+	}
+}
+
+// Service returns the AWS service name.
+func (e *Scanner) Service() string {
+	return "elb"
+}
+
+// Scan executes all load balancer security checks.
+func (e *Scanner) Scan(ctx context.Context, _ string) ([]scanner.Finding, error) {
+	loadBalancers, err := e.listLoadBalancers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing load balancers: %w", err)
+	}
+	scanner.CountResources(ctx, len(loadBalancers))
+
+	scope := scanner.ScopeFromContext(ctx)
+	return scanner.TraceChecks(ctx, "elb.load_balancers", func(ctx context.Context) []scanner.Finding {
+		var findings []scanner.Finding
+		for _, lb := range loadBalancers {
+			name := aws.ToString(lb.LoadBalancerName)
+			if !scope.Includes(name) {
+				continue
+			}
+			var lbFindings []scanner.Finding
+			// Gateway load balancers forward GENEVE traffic and have no TLS or HTTP listeners.
+			if lb.Type != types.LoadBalancerTypeEnumGateway {
+				listeners, err := e.listListeners(ctx, lb.LoadBalancerArn)
+				if err != nil {
+					if scanner.IsAccessDenied(err) {
+						lbFindings = append(lbFindings, e.accessDeniedFinding("elb_https_listener", name, err))
+					}
+				} else {
+					lbFindings = append(lbFindings, e.checkHTTPSListener(name, listeners)...)
+					lbFindings = append(lbFindings, e.checkTLSPolicy(name, listeners)...)
+				}
+			}
+			lbFindings = append(lbFindings, e.checkAttributes(ctx, lb)...)
+			// Load balancer ARNs end in an ID AWS assigns, so they are taken
+			// from the load balancer rather than built from its name.
+			findings = append(findings, scanner.WithARN(lbFindings, aws.ToString(lb.LoadBalancerArn))...)
+		}
+		return findings
+	}), nil
+}
+
+func (e *Scanner) listLoadBalancers(ctx context.Context) ([]types.LoadBalancer, error) {
+	var loadBalancers []types.LoadBalancer
+	paginator := elb.NewDescribeLoadBalancersPaginator(e.client, &elb.DescribeLoadBalancersInput{})
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		loadBalancers = append(loadBalancers, output.LoadBalancers...)
+	}
+	return loadBalancers, nil
+}
+
+func (e *Scanner) listListeners(ctx context.Context, lbArn *string) ([]types.Listener, error) {
+	var listeners []types.Listener
+	paginator := elb.NewDescribeListenersPaginator(e.client, &elb.DescribeListenersInput{LoadBalancerArn: lbArn})
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		listeners = append(listeners, output.Listeners...)
+	}
+	return listeners, nil
+}
+
+func (e *Scanner) createFinding(checkID, resourceID, title, description string, status scanner.FindingStatus, severity scanner.Severity) scanner.Finding {
+	return scanner.Finding{
+		FindingID:   scanner.FindingID(e.accountID, e.Service(), e.region, checkID, resourceID),
+		Service:     e.Service(),
+		Region:      e.region,
+		ResourceID:  resourceID,
+		CheckID:     checkID,
+		Status:      status,
+		Severity:    severity,
+		Confidence:  scanner.ConfidenceFor(checkID),
+		Title:       title,
+		Description: description,
+		Compliance:  checks.Compliance(checkID),
+		Timestamp:   time.Now(),
+	}
+}
+
+// accessDeniedFinding records that checkID could not be evaluated for
+// resourceID because the scan role was denied the underlying API call.
+func (e *Scanner) accessDeniedFinding(checkID, resourceID string, err error) scanner.Finding {
+	return e.createFinding(
+		checkID,
+		resourceID,
+		"Insufficient permissions to evaluate check",
+		scanner.AccessDeniedDescription(err),
+		scanner.StatusError,
+		scanner.SeverityMedium,
+	)
+}
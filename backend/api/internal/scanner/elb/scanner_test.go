@@ -0,0 +1,146 @@
+package elb
+
+import (
+	"context"
+	"testing"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+)
+
+// fakeELBClient implements elbAPI with canned responses.
+// Methods that are not overridden panic via the nil embedded interface.
+type fakeELBClient struct {
+	elbAPI
+	loadBalancers []types.LoadBalancer
+	listeners     map[string][]types.Listener
+	attributes    map[string]map[string]string
+}
+
+func (f *fakeELBClient) DescribeLoadBalancers(_ context.Context, _ *elb.DescribeLoadBalancersInput, _ ...func(*elb.Options)) (*elb.DescribeLoadBalancersOutput, error) {
+	return &elb.DescribeLoadBalancersOutput{LoadBalancers: f.loadBalancers}, nil
+}
+
+func (f *fakeELBClient) DescribeListeners(_ context.Context, params *elb.DescribeListenersInput, _ ...func(*elb.Options)) (*elb.DescribeListenersOutput, error) {
+	return &elb.DescribeListenersOutput{Listeners: f.listeners[aws.ToString(params.LoadBalancerArn)]}, nil
+}
+
+func (f *fakeELBClient) DescribeLoadBalancerAttributes(_ context.Context, params *elb.DescribeLoadBalancerAttributesInput, _ ...func(*elb.Options)) (*elb.DescribeLoadBalancerAttributesOutput, error) {
+	out := &elb.DescribeLoadBalancerAttributesOutput{}
+	for k, v := range f.attributes[aws.ToString(params.LoadBalancerArn)] {
+		out.Attributes = append(out.Attributes, types.LoadBalancerAttribute{Key: aws.String(k), Value: aws.String(v)})
+	}
+	return out, nil
+}
+
+func TestNewScanner(t *testing.T) {
+	s := NewScanner(aws.Config{Region: "us-east-1"}, "us-east-1", "123456789012")
+
+	es, ok := s.(*Scanner)
+	if !ok {
+		t.Fatal("NewScanner did not return *Scanner type")
+	}
+	if es.region != "us-east-1" || es.accountID != "123456789012" || es.client == nil {
+		t.Errorf("unexpected scanner: %+v", es)
+	}
+	if got := es.Service(); got != "elb" {
+		t.Errorf("Service() = %s, want elb", got)
+	}
+}
+
+func TestIsDeprecatedTLSPolicy(t *testing.T) {
+	tests := []struct {
+		policy string
+		want   bool
+	}{
+		{"ELBSecurityPolicy-2016-08", true},
+		{"ELBSecurityPolicy-2015-05", true},
+		{"ELBSecurityPolicy-FS-2018-06", true},
+		{"ELBSecurityPolicy-TLS-1-0-2015-04", true},
+		{"ELBSecurityPolicy-TLS-1-1-2017-01", true},
+		{"ELBSecurityPolicy-FS-1-1-2019-08", true},
+		{"ELBSecurityPolicy-TLS13-1-0-2021-06", true},
+		{"ELBSecurityPolicy-TLS13-1-1-2021-06", true},
+		{"ELBSecurityPolicy-TLS13-1-0-FIPS-2023-04", true},
+		{"ELBSecurityPolicy-TLS-1-2-2017-01", false},
+		{"ELBSecurityPolicy-TLS-1-2-Ext-2018-06", false},
+		{"ELBSecurityPolicy-FS-1-2-Res-2020-10", false},
+		{"ELBSecurityPolicy-TLS13-1-2-2021-06", false},
+		{"ELBSecurityPolicy-TLS13-1-3-2021-06", false},
+		{"ELBSecurityPolicy-TLS13-1-2-Res-PQ-2025-09", false},
+		// Similar names must not match on a substring of the denylist.
+		{"ELBSecurityPolicy-2016-08-custom", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			if got := isDeprecatedTLSPolicy(tt.policy); got != tt.want {
+				t.Errorf("isDeprecatedTLSPolicy(%q) = %v, want %v", tt.policy, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanner_Scan(t *testing.T) {
+	client := &fakeELBClient{
+		loadBalancers: []types.LoadBalancer{
+			{LoadBalancerName: aws.String("web"), LoadBalancerArn: aws.String("arn:web"), Type: types.LoadBalancerTypeEnumApplication},
+			{LoadBalancerName: aws.String("legacy"), LoadBalancerArn: aws.String("arn:legacy"), Type: types.LoadBalancerTypeEnumApplication},
+			{LoadBalancerName: aws.String("plain"), LoadBalancerArn: aws.String("arn:plain"), Type: types.LoadBalancerTypeEnumApplication},
+		},
+		listeners: map[string][]types.Listener{
+			"arn:web": {
+				{Protocol: types.ProtocolEnumHttp, Port: aws.Int32(80)},
+				{Protocol: types.ProtocolEnumHttps, Port: aws.Int32(443), SslPolicy: aws.String("ELBSecurityPolicy-TLS13-1-2-2021-06")},
+			},
+			"arn:legacy": {
+				{Protocol: types.ProtocolEnumHttps, Port: aws.Int32(443), SslPolicy: aws.String("ELBSecurityPolicy-2016-08")},
+			},
+			"arn:plain": {
+				{Protocol: types.ProtocolEnumHttp, Port: aws.Int32(80)},
+			},
+		},
+		attributes: map[string]map[string]string{
+			"arn:web":    {"access_logs.s3.enabled": "true", "deletion_protection.enabled": "true"},
+			"arn:legacy": {"access_logs.s3.enabled": "false", "deletion_protection.enabled": "false"},
+		},
+	}
+	s := &Scanner{client: client, region: "us-east-1", accountID: "123456789012"}
+
+	findings, err := s.Scan(context.Background(), "us-east-1")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	want := map[string]scanner.FindingStatus{
+		"web/elb_https_listener":         scanner.StatusPass,
+		"web/elb_tls_policy":             scanner.StatusPass,
+		"web/elb_access_logs":            scanner.StatusPass,
+		"web/elb_deletion_protection":    scanner.StatusPass,
+		"legacy/elb_https_listener":      scanner.StatusPass,
+		"legacy/elb_tls_policy":          scanner.StatusFail,
+		"legacy/elb_access_logs":         scanner.StatusFail,
+		"legacy/elb_deletion_protection": scanner.StatusFail,
+		"plain/elb_https_listener":       scanner.StatusFail,
+		"plain/elb_access_logs":          scanner.StatusFail,
+		"plain/elb_deletion_protection":  scanner.StatusFail,
+	}
+	if len(findings) != len(want) {
+		t.Fatalf("expected %d findings, got %d: %+v", len(want), len(findings), findings)
+	}
+	for _, f := range findings {
+		key := f.ResourceID + "/" + f.CheckID
+		if status, ok := want[key]; !ok || f.Status != status {
+			t.Errorf("%s: Status = %s, want %s", key, f.Status, status)
+		}
+		if len(f.Compliance) == 0 {
+			t.Errorf("%s: missing compliance mapping", key)
+		}
+		if f.ARN != "arn:"+f.ResourceID {
+			t.Errorf("%s: ARN = %q, want the load balancer's ARN", key, f.ARN)
+		}
+	}
+}
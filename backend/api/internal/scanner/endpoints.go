@@ -0,0 +1,54 @@
+package scanner
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+)
+
+// EndpointOptions redirects the AWS clients that scanners build, for FIPS
+// compliance or to scan an AWS-compatible test harness such as LocalStack.
+// The zero value uses the default endpoints of each region's partition.
+type EndpointOptions struct {
+	// BaseEndpoint sends every service's requests to this URL, for example
+	// "http://localhost:4566".
+	BaseEndpoint string
+	// Resolve returns the endpoint URL for a service in a region, taking
+	// precedence over BaseEndpoint. Returning "" falls back to BaseEndpoint
+	// or the default endpoint. Services are named as registered with the
+	// coordinator, such as "s3" or "ec2".
+	Resolve func(service, region string) string
+	// UseFIPS selects FIPS 140 validated endpoints in services and regions
+	// that offer them. It has no effect on an overridden endpoint.
+	UseFIPS bool
+}
+
+// UseEndpoints configures the endpoints of the clients built by subsequent
+// scans, replacing any earlier options.
+func (c *Coordinator) UseEndpoints(opts EndpointOptions) {
+	c.endpoints = opts
+}
+
+// regionalConfig returns the config handed to the factory of service when
+// scanning region.
+func (c *Coordinator) regionalConfig(service, region string) aws.Config {
+	cfg := c.cfg.Copy()
+	cfg.Region = region
+
+	endpoint := c.endpoints.BaseEndpoint
+	if c.endpoints.Resolve != nil {
+		if url := c.endpoints.Resolve(service, region); url != "" {
+			endpoint = url
+		}
+	}
+	if endpoint != "" {
+		cfg.BaseEndpoint = aws.String(endpoint)
+	}
+
+	if c.endpoints.UseFIPS {
+		// Clients take the first config source that sets the option, so the
+		// override goes ahead of whatever the config was loaded with.
+		fips := config.LoadOptions{UseFIPSEndpoint: aws.FIPSEndpointStateEnabled}
+		cfg.ConfigSources = append([]interface{}{fips}, cfg.ConfigSources...)
+	}
+	return cfg
+}
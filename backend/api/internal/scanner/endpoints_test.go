@@ -0,0 +1,127 @@
+package scanner
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+// configRecorder is a scanner factory that records the config each scanner
+// is built with, keyed by service and region.
+type configRecorder struct {
+	mu      sync.Mutex
+	configs map[string]aws.Config
+}
+
+func (r *configRecorder) factory(service string) Factory {
+	return func(cfg aws.Config, region, _ string) ServiceScanner {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.configs[service+"/"+region] = cfg
+		return &mockScanner{service: service}
+	}
+}
+
+func TestCoordinator_UseEndpoints(t *testing.T) {
+	resolve := func(service, region string) string {
+		if service == "s3" && region == "eu-west-1" {
+			return "https://s3.internal.example"
+		}
+		return ""
+	}
+
+	tests := []struct {
+		name string
+		opts EndpointOptions
+		want map[string]string
+	}{
+		{
+			name: "default",
+			want: map[string]string{"s3/us-east-1": "", "s3/eu-west-1": "", "ec2/us-east-1": "", "ec2/eu-west-1": ""},
+		},
+		{
+			name: "base endpoint",
+			opts: EndpointOptions{BaseEndpoint: "http://localhost:4566"},
+			want: map[string]string{
+				"s3/us-east-1":  "http://localhost:4566",
+				"s3/eu-west-1":  "http://localhost:4566",
+				"ec2/us-east-1": "http://localhost:4566",
+				"ec2/eu-west-1": "http://localhost:4566",
+			},
+		},
+		{
+			name: "resolver overrides base endpoint",
+			opts: EndpointOptions{BaseEndpoint: "http://localhost:4566", Resolve: resolve},
+			want: map[string]string{
+				"s3/us-east-1":  "http://localhost:4566",
+				"s3/eu-west-1":  "https://s3.internal.example",
+				"ec2/us-east-1": "http://localhost:4566",
+				"ec2/eu-west-1": "http://localhost:4566",
+			},
+		},
+		{
+			name: "resolver only",
+			opts: EndpointOptions{Resolve: resolve},
+			want: map[string]string{"s3/us-east-1": "", "s3/eu-west-1": "https://s3.internal.example", "ec2/us-east-1": "", "ec2/eu-west-1": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &configRecorder{configs: make(map[string]aws.Config)}
+			coord := NewCoordinator(aws.Config{Region: "us-east-1"}, "123456789012")
+			coord.RegisterScanner("s3", rec.factory("s3"))
+			coord.RegisterScanner("ec2", rec.factory("ec2"))
+			coord.UseEndpoints(tt.opts)
+
+			_, err := coord.StartScan(context.Background(), ScanConfig{
+				AccountID: "123456789012",
+				Regions:   []string{"us-east-1", "eu-west-1"},
+				Services:  []string{"s3", "ec2"},
+			})
+			if err != nil {
+				t.Fatalf("StartScan() error = %v", err)
+			}
+
+			for key, want := range tt.want {
+				cfg, ok := rec.configs[key]
+				if !ok {
+					t.Errorf("no scanner built for %s", key)
+					continue
+				}
+				if got := aws.ToString(cfg.BaseEndpoint); got != want {
+					t.Errorf("%s BaseEndpoint = %q, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCoordinator_UseEndpoints_FIPS(t *testing.T) {
+	rec := &configRecorder{configs: make(map[string]aws.Config)}
+	base := NewCoordinator(aws.Config{Region: "us-east-1"}, "123456789012")
+	base.RegisterScanner("ec2", rec.factory("ec2"))
+	base.UseEndpoints(EndpointOptions{UseFIPS: true})
+
+	// Options set on the base coordinator carry over to other accounts.
+	coord := base.ForAccount(aws.Config{Region: "us-east-1"}, "210987654321")
+	_, err := coord.StartScan(context.Background(), ScanConfig{
+		AccountID: "210987654321",
+		Regions:   []string{"us-west-2"},
+		Services:  []string{"ec2"},
+	})
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+
+	cfg := rec.configs["ec2/us-west-2"]
+	if cfg.Region != "us-west-2" {
+		t.Errorf("Region = %q, want us-west-2", cfg.Region)
+	}
+	if got := ec2.NewFromConfig(cfg).Options().EndpointOptions.UseFIPSEndpoint; got != aws.FIPSEndpointStateEnabled {
+		t.Errorf("client UseFIPSEndpoint = %v, want enabled", got)
+	}
+}
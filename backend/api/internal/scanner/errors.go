@@ -0,0 +1,88 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/smithy-go"
+)
+
+// ErrorType classifies why a service/region scan failed, so users can tell
+// whether to fix IAM, retry later, or look at the resource.
+type ErrorType string
+
+const (
+	// ErrorAccessDenied means the scan role lacks a permission.
+	ErrorAccessDenied ErrorType = "ACCESS_DENIED"
+	// ErrorThrottling means AWS rate-limited the scan; retrying later may succeed.
+	ErrorThrottling ErrorType = "THROTTLING"
+	// ErrorResource means a resource was missing or rejected the request.
+	ErrorResource ErrorType = "RESOURCE"
+	// ErrorCancelled means the scan was cancelled or timed out.
+	ErrorCancelled ErrorType = "CANCELLED"
+	// ErrorUnknown covers every other failure.
+	ErrorUnknown ErrorType = "UNKNOWN"
+)
+
+// throttlingCodes are the AWS error codes returned when a request is rate limited.
+var throttlingCodes = map[string]bool{
+	"Throttling":                             true,
+	"ThrottlingException":                    true,
+	"ThrottledException":                     true,
+	"RequestThrottled":                       true,
+	"RequestThrottledException":              true,
+	"RequestLimitExceeded":                   true,
+	"TooManyRequestsException":               true,
+	"SlowDown":                               true,
+	"ProvisionedThroughputExceededException": true,
+}
+
+// ClassifyError returns the ErrorType of an error returned by a scanner.
+func ClassifyError(err error) ErrorType {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrorCancelled
+	}
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return ErrorUnknown
+	}
+	code := apiErr.ErrorCode()
+	switch {
+	case accessDeniedCodes[code]:
+		return ErrorAccessDenied
+	case throttlingCodes[code]:
+		return ErrorThrottling
+	case strings.HasPrefix(code, "NoSuch"), strings.Contains(code, "NotFound"), apiErr.ErrorFault() == smithy.FaultClient:
+		return ErrorResource
+	default:
+		return ErrorUnknown
+	}
+}
+
+// accessDeniedCodes are the AWS error codes returned when the caller lacks
+// permission for an API call. EC2 uses UnauthorizedOperation; most other
+// services use a variant of AccessDenied.
+var accessDeniedCodes = map[string]bool{
+	"AccessDenied":          true,
+	"AccessDeniedException": true,
+	"UnauthorizedOperation": true,
+	"AuthorizationError":    true,
+}
+
+// IsAccessDenied reports whether err is an AWS authorization failure.
+func IsAccessDenied(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && accessDeniedCodes[apiErr.ErrorCode()]
+}
+
+// AccessDeniedDescription explains which API call a check was denied, for use
+// as the description of a StatusError finding.
+func AccessDeniedDescription(err error) string {
+	var opErr *smithy.OperationError
+	if errors.As(err, &opErr) {
+		return fmt.Sprintf("The scan role is not permitted to call %s:%s, so this check could not be evaluated", opErr.ServiceID, opErr.OperationName)
+	}
+	return fmt.Sprintf("The scan role lacks a required permission, so this check could not be evaluated: %v", err)
+}
@@ -0,0 +1,74 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/smithy-go"
+)
+
+func TestIsAccessDenied(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"access denied", &smithy.GenericAPIError{Code: "AccessDenied"}, true},
+		{"access denied exception", &smithy.GenericAPIError{Code: "AccessDeniedException"}, true},
+		{"ec2 unauthorized", &smithy.GenericAPIError{Code: "UnauthorizedOperation"}, true},
+		{"wrapped", fmt.Errorf("calling api: %w", &smithy.GenericAPIError{Code: "AccessDenied"}), true},
+		{"not found", &smithy.GenericAPIError{Code: "NoSuchBucketPolicy"}, false},
+		{"plain error", errors.New("boom"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAccessDenied(tt.err); got != tt.want {
+				t.Errorf("IsAccessDenied() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccessDeniedDescription(t *testing.T) {
+	err := &smithy.OperationError{
+		ServiceID:     "S3",
+		OperationName: "GetBucketAcl",
+		Err:           &smithy.GenericAPIError{Code: "AccessDenied"},
+	}
+
+	if got := AccessDeniedDescription(err); !strings.Contains(got, "S3:GetBucketAcl") {
+		t.Errorf("AccessDeniedDescription() = %q, want it to name S3:GetBucketAcl", got)
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorType
+	}{
+		{"access denied", &smithy.GenericAPIError{Code: "AccessDeniedException"}, ErrorAccessDenied},
+		{"ec2 unauthorized", &smithy.GenericAPIError{Code: "UnauthorizedOperation"}, ErrorAccessDenied},
+		{"throttling", &smithy.GenericAPIError{Code: "ThrottlingException"}, ErrorThrottling},
+		{"ec2 rate limit", fmt.Errorf("listing: %w", &smithy.GenericAPIError{Code: "RequestLimitExceeded"}), ErrorThrottling},
+		{"missing resource", &smithy.GenericAPIError{Code: "ResourceNotFoundException"}, ErrorResource},
+		{"no such bucket", &smithy.GenericAPIError{Code: "NoSuchBucket"}, ErrorResource},
+		{"cancelled", fmt.Errorf("scanning: %w", context.Canceled), ErrorCancelled},
+		{"timeout", context.DeadlineExceeded, ErrorCancelled},
+		{"server error", &smithy.GenericAPIError{Code: "InternalError", Fault: smithy.FaultServer}, ErrorUnknown},
+		{"plain error", errors.New("boom"), ErrorUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyError(tt.err); got != tt.want {
+				t.Errorf("ClassifyError() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package scanner
+
+// FindingFilter selects which findings are returned to API consumers.
+// The zero value matches every finding.
+type FindingFilter struct {
+	// OnlyFailures drops findings whose status is not FAIL.
+	OnlyFailures bool
+	// Service keeps only findings from this service when set.
+	Service string
+	// Severity keeps only findings of this severity when set.
+	Severity Severity
+	// Status keeps only findings with this status when set.
+	Status FindingStatus
+}
+
+// Matches reports whether f passes the filter.
+func (ff FindingFilter) Matches(f Finding) bool {
+	if ff.OnlyFailures && f.Status != StatusFail {
+		return false
+	}
+	if ff.Service != "" && f.Service != ff.Service {
+		return false
+	}
+	if ff.Severity != "" && f.Severity != ff.Severity {
+		return false
+	}
+	if ff.Status != "" && f.Status != ff.Status {
+		return false
+	}
+	return true
+}
+
+// Apply returns the findings that pass the filter, preserving order.
+func (ff FindingFilter) Apply(findings []Finding) []Finding {
+	filtered := make([]Finding, 0, len(findings))
+	for _, f := range findings {
+		if ff.Matches(f) {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
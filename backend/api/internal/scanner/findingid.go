@@ -0,0 +1,18 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// FindingID returns the stable identifier of the finding a check reports for
+// a resource. The same inputs always produce the same ID, so findings can be
+// diffed between scans, suppressed, or linked to tickets. Status, severity,
+// and wording are not part of the ID, so a fixed or re-weighted issue keeps it.
+func FindingID(accountID, service, region, checkID, resourceID string) string {
+	// The separator keeps inputs such as ("ab", "c") and ("a", "bc") from
+	// hashing to the same ID.
+	sum := sha256.Sum256([]byte(strings.Join([]string{accountID, service, region, checkID, resourceID}, "\x00")))
+	return hex.EncodeToString(sum[:16])
+}
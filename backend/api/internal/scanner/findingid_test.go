@@ -0,0 +1,32 @@
+package scanner
+
+import "testing"
+
+func TestFindingID(t *testing.T) {
+	base := FindingID("123456789012", "s3", "us-east-1", "s3_bucket_encryption", "logs")
+	if again := FindingID("123456789012", "s3", "us-east-1", "s3_bucket_encryption", "logs"); again != base {
+		t.Fatalf("FindingID() = %s then %s for identical inputs", base, again)
+	}
+	if len(base) != 32 {
+		t.Errorf("FindingID() = %q, want 32 hex characters", base)
+	}
+
+	tests := []struct {
+		name                                            string
+		accountID, service, region, checkID, resourceID string
+	}{
+		{"account", "210987654321", "s3", "us-east-1", "s3_bucket_encryption", "logs"},
+		{"service", "123456789012", "ec2", "us-east-1", "s3_bucket_encryption", "logs"},
+		{"region", "123456789012", "s3", "eu-west-1", "s3_bucket_encryption", "logs"},
+		{"check", "123456789012", "s3", "us-east-1", "s3_bucket_versioning", "logs"},
+		{"resource", "123456789012", "s3", "us-east-1", "s3_bucket_encryption", "assets"},
+		{"shifted boundary", "123456789012", "s3", "us-east-1", "s3_bucket_encryptionl", "ogs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindingID(tt.accountID, tt.service, tt.region, tt.checkID, tt.resourceID); got == base {
+				t.Errorf("FindingID() = %s, same as the unchanged finding", got)
+			}
+		})
+	}
+}
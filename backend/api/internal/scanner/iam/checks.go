@@ -23,41 +23,53 @@ func (i *Scanner) checkUnusedAccessKeys(ctx context.Context, user types.User) []
 
 	keys, err := i.client.ListAccessKeys(ctx, &iam.ListAccessKeysInput{UserName: user.UserName})
 	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return scanner.WithARN([]scanner.Finding{i.accessDeniedFinding("iam_unused_access_keys", userName, err)}, aws.ToString(user.Arn))
+		}
 		return nil
 	}
 
 	for _, key := range keys.AccessKeyMetadata {
-		keyID := aws.ToString(key.AccessKeyId)
-		lastUsed, err := i.client.GetAccessKeyLastUsed(ctx, &iam.GetAccessKeyLastUsedInput{AccessKeyId: key.AccessKeyId})
-		if err != nil {
-			continue
-		}
+		findings = append(findings, scanner.WithResourceCreatedAt(i.checkUnusedAccessKey(ctx, userName, key), key.CreateDate)...)
+	}
+	return scanner.WithARN(findings, aws.ToString(user.Arn))
+}
 
-		if lastUsed.AccessKeyLastUsed.LastUsedDate == nil {
-			findings = append(findings, i.createFinding(
-				"iam_unused_access_keys",
-				keyID,
-				"IAM access key has never been used",
-				fmt.Sprintf("Access key %s for user %s has never been used", keyID, userName),
-				scanner.StatusFail,
-				scanner.SeverityMedium,
-			))
-			continue
+// checkUnusedAccessKey reports whether one of a user's access keys has gone
+// unused for longer than the allowed age.
+func (i *Scanner) checkUnusedAccessKey(ctx context.Context, userName string, key types.AccessKeyMetadata) []scanner.Finding {
+	keyID := aws.ToString(key.AccessKeyId)
+	lastUsed, err := i.client.GetAccessKeyLastUsed(ctx, &iam.GetAccessKeyLastUsedInput{AccessKeyId: key.AccessKeyId})
+	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{i.accessDeniedFinding("iam_unused_access_keys", keyID, err)}
 		}
+		return nil
+	}
 
-		daysSinceUse := int(time.Since(*lastUsed.AccessKeyLastUsed.LastUsedDate).Hours() / 24)
-		if daysSinceUse > accessKeyMaxAgeDays {
-			findings = append(findings, i.createFinding(
-				"iam_unused_access_keys",
-				keyID,
-				"IAM access key unused for over 90 days",
-				fmt.Sprintf("Access key %s for user %s unused for %d days", keyID, userName, daysSinceUse),
-				scanner.StatusFail,
-				scanner.SeverityMedium,
-			))
-		}
+	if lastUsed.AccessKeyLastUsed.LastUsedDate == nil {
+		return []scanner.Finding{i.createFinding(
+			"iam_unused_access_keys",
+			keyID,
+			"IAM access key has never been used",
+			fmt.Sprintf("Access key %s for user %s has never been used", keyID, userName),
+			scanner.StatusFail,
+			scanner.SeverityMedium,
+		)}
 	}
-	return findings
+
+	daysSinceUse := int(time.Since(*lastUsed.AccessKeyLastUsed.LastUsedDate).Hours() / 24)
+	if maxAge := i.unusedKeyMaxAge(); daysSinceUse > maxAge {
+		return []scanner.Finding{i.createFinding(
+			"iam_unused_access_keys",
+			keyID,
+			fmt.Sprintf("IAM access key unused for over %d days", maxAge),
+			fmt.Sprintf("Access key %s for user %s unused for %d days, over the %d-day limit", keyID, userName, daysSinceUse, maxAge),
+			scanner.StatusFail,
+			scanner.SeverityMedium,
+		)}
+	}
+	return nil
 }
 
 func (i *Scanner) checkAccessKeyRotation(ctx context.Context, user types.User) []scanner.Finding {
@@ -66,33 +78,42 @@ func (i *Scanner) checkAccessKeyRotation(ctx context.Context, user types.User) [
 
 	keys, err := i.client.ListAccessKeys(ctx, &iam.ListAccessKeysInput{UserName: user.UserName})
 	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return scanner.WithARN([]scanner.Finding{i.accessDeniedFinding("iam_access_key_rotation", userName, err)}, aws.ToString(user.Arn))
+		}
 		return nil
 	}
 
+	maxAge := i.rotationMaxAge()
 	for _, key := range keys.AccessKeyMetadata {
 		keyID := aws.ToString(key.AccessKeyId)
 		if key.CreateDate == nil {
 			continue
 		}
 		daysSinceCreation := int(time.Since(*key.CreateDate).Hours() / 24)
-		if daysSinceCreation > accessKeyMaxAgeDays {
-			findings = append(findings, i.createFinding(
+		if daysSinceCreation > maxAge {
+			finding := i.createFinding(
 				"iam_access_key_rotation",
 				keyID,
-				"IAM access key not rotated in over 90 days",
-				fmt.Sprintf("Access key %s for user %s is %d days old", keyID, userName, daysSinceCreation),
+				fmt.Sprintf("IAM access key not rotated in over %d days", maxAge),
+				fmt.Sprintf("Access key %s for user %s is %d days old, over the %d-day rotation limit", keyID, userName, daysSinceCreation, maxAge),
 				scanner.StatusFail,
 				scanner.SeverityMedium,
-			))
+			)
+			finding.ResourceCreatedAt = key.CreateDate
+			findings = append(findings, finding)
 		}
 	}
-	return findings
+	return scanner.WithARN(findings, aws.ToString(user.Arn))
 }
 
 func (i *Scanner) checkUserMFA(ctx context.Context, user types.User) []scanner.Finding {
 	userName := aws.ToString(user.UserName)
 	mfaDevices, err := i.client.ListMFADevices(ctx, &iam.ListMFADevicesInput{UserName: user.UserName})
 	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{i.accessDeniedFinding("iam_user_mfa", userName, err)}
+		}
 		return nil
 	}
 
@@ -119,6 +140,9 @@ func (i *Scanner) checkUserMFA(ctx context.Context, user types.User) []scanner.F
 func (i *Scanner) checkRootMFA(ctx context.Context) []scanner.Finding {
 	summary, err := i.client.GetAccountSummary(ctx, &iam.GetAccountSummaryInput{})
 	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{i.accessDeniedFinding("iam_root_mfa", "root", err)}
+		}
 		return nil
 	}
 
@@ -160,6 +184,9 @@ func (i *Scanner) checkRootMFA(ctx context.Context) []scanner.Finding {
 func (i *Scanner) checkPasswordPolicy(ctx context.Context) []scanner.Finding {
 	policy, err := i.client.GetAccountPasswordPolicy(ctx, &iam.GetAccountPasswordPolicyInput{})
 	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{i.accessDeniedFinding("iam_password_policy", "account", err)}
+		}
 		return []scanner.Finding{i.createFinding(
 			"iam_password_policy",
 			"account",
@@ -208,24 +235,46 @@ func (i *Scanner) checkPasswordPolicy(ctx context.Context) []scanner.Finding {
 	)}
 }
 
+// checkInlinePolicies flags users with inline policies and runs the
+// NotAction check over their documents.
 func (i *Scanner) checkInlinePolicies(ctx context.Context, user types.User) []scanner.Finding {
 	userName := aws.ToString(user.UserName)
 	policies, err := i.client.ListUserPolicies(ctx, &iam.ListUserPoliciesInput{UserName: user.UserName})
 	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{i.accessDeniedFinding("iam_inline_policies", userName, err)}
+		}
+		return nil
+	}
+	if len(policies.PolicyNames) == 0 {
 		return nil
 	}
 
-	if len(policies.PolicyNames) > 0 {
-		return []scanner.Finding{i.createFinding(
-			"iam_inline_policies",
-			userName,
-			"IAM user has inline policies",
-			fmt.Sprintf("User %s has %d inline policies (use managed policies instead)", userName, len(policies.PolicyNames)),
-			scanner.StatusFail,
-			scanner.SeverityLow,
-		)}
+	findings := []scanner.Finding{i.createFinding(
+		"iam_inline_policies",
+		userName,
+		"IAM user has inline policies",
+		fmt.Sprintf("User %s has %d inline policies (use managed policies instead)", userName, len(policies.PolicyNames)),
+		scanner.StatusFail,
+		scanner.SeverityLow,
+	)}
+
+	var docs []namedPolicy
+	for _, name := range policies.PolicyNames {
+		policy, err := i.client.GetUserPolicy(ctx, &iam.GetUserPolicyInput{UserName: user.UserName, PolicyName: aws.String(name)})
+		if err != nil {
+			if scanner.IsAccessDenied(err) {
+				return append(findings, i.accessDeniedFinding("iam_not_action", userName, err))
+			}
+			continue
+		}
+		doc, err := decodePolicyDocument(aws.ToString(policy.PolicyDocument))
+		if err != nil {
+			continue
+		}
+		docs = append(docs, namedPolicy{name: name, doc: doc})
 	}
-	return nil
+	return append(findings, i.checkNotAction(userName, docs...)...)
 }
 
 func (i *Scanner) checkOverlyPermissivePolicies(ctx context.Context) []scanner.Finding {
@@ -235,6 +284,9 @@ func (i *Scanner) checkOverlyPermissivePolicies(ctx context.Context) []scanner.F
 	for paginator.HasMorePages() {
 		output, err := paginator.NextPage(ctx)
 		if err != nil {
+			if scanner.IsAccessDenied(err) {
+				findings = append(findings, i.accessDeniedFinding("iam_overly_permissive", "account", err))
+			}
 			break
 		}
 		for _, policy := range output.Policies {
@@ -244,23 +296,16 @@ func (i *Scanner) checkOverlyPermissivePolicies(ctx context.Context) []scanner.F
 				VersionId: policy.DefaultVersionId,
 			})
 			if err != nil {
+				if scanner.IsAccessDenied(err) {
+					findings = append(findings, i.accessDeniedFinding("iam_overly_permissive", policyArn, err))
+				}
 				continue
 			}
-			doc, err := url.QueryUnescape(aws.ToString(version.PolicyVersion.Document))
+			doc, err := decodePolicyDocument(aws.ToString(version.PolicyVersion.Document))
 			if err != nil {
 				continue
 			}
-			var policyDoc struct {
-				Statement []struct {
-					Effect   string      `json:"Effect"`
-					Action   interface{} `json:"Action"`
-					Resource interface{} `json:"Resource"`
-				} `json:"Statement"`
-			}
-			if err := json.Unmarshal([]byte(doc), &policyDoc); err != nil {
-				continue
-			}
-			for _, stmt := range policyDoc.Statement {
+			for _, stmt := range doc.Statement {
 				if stmt.Effect == "Allow" && isWildcard(stmt.Action) && isWildcard(stmt.Resource) {
 					findings = append(findings, i.createFinding(
 						"iam_overly_permissive",
@@ -272,11 +317,46 @@ func (i *Scanner) checkOverlyPermissivePolicies(ctx context.Context) []scanner.F
 					))
 				}
 			}
+			findings = append(findings, i.checkNotAction(policyArn, namedPolicy{name: aws.ToString(policy.PolicyName), doc: doc})...)
 		}
 	}
 	return findings
 }
 
+// namedPolicy is a decoded policy document and the name of its policy.
+type namedPolicy struct {
+	name string
+	doc  policyDocument
+}
+
+// checkNotAction flags policies that allow NotAction. Such a statement grants
+// every action except the listed ones, including actions of services added
+// later, and is usually meant as a Deny. Deny statements using NotAction are
+// a legitimate guardrail and are not reported. One finding is reported for
+// resourceID, naming each offending policy.
+func (i *Scanner) checkNotAction(resourceID string, policies ...namedPolicy) []scanner.Finding {
+	var offending []string
+	for _, p := range policies {
+		for _, stmt := range p.doc.Statement {
+			if stmt.Effect == "Allow" && stmt.NotAction != nil {
+				offending = append(offending, fmt.Sprintf("%s (all actions except %s)", p.name, strings.Join(policyActions(stmt.NotAction), ", ")))
+				break
+			}
+		}
+	}
+	if len(offending) == 0 {
+		return nil
+	}
+	return []scanner.Finding{i.createFinding(
+		"iam_not_action",
+		resourceID,
+		"IAM policy allows NotAction",
+		fmt.Sprintf("Allow statements with NotAction in %s grant every action except those listed", strings.Join(offending, "; ")),
+		scanner.StatusFail,
+		scanner.SeverityHigh,
+	)}
+}
+
 func (i *Scanner) checkCrossAccountTrust(ctx context.Context) []scanner.Finding {
 	var findings []scanner.Finding
 	paginator := iam.NewListRolesPaginator(i.client, &iam.ListRolesInput{})
@@ -284,6 +364,9 @@ func (i *Scanner) checkCrossAccountTrust(ctx context.Context) []scanner.Finding
 	for paginator.HasMorePages() {
 		output, err := paginator.NextPage(ctx)
 		if err != nil {
+			if scanner.IsAccessDenied(err) {
+				findings = append(findings, i.accessDeniedFinding("iam_cross_account_trust", "account", err))
+			}
 			break
 		}
 		for _, role := range output.Roles {
@@ -300,17 +383,37 @@ func (i *Scanner) checkCrossAccountTrust(ctx context.Context) []scanner.Finding
 			if err := json.Unmarshal([]byte(doc), &trustPolicy); err != nil {
 				continue
 			}
+			trustsAllowed := false
+			flagged := false
 			for _, stmt := range trustPolicy.Statement {
-				if hasCrossAccountPrincipal(stmt.Principal, i.accountID) {
-					findings = append(findings, i.createFinding(
-						"iam_cross_account_trust",
-						roleName,
-						"IAM role has cross-account trust",
-						fmt.Sprintf("Role %s trusts external AWS accounts", roleName),
-						scanner.StatusFail,
-						scanner.SeverityHigh,
-					))
+				if !hasCrossAccountPrincipal(stmt.Principal, i.accountID, nil) {
+					continue
 				}
+				if !hasCrossAccountPrincipal(stmt.Principal, i.accountID, i.allowedExternalAccounts) {
+					trustsAllowed = true
+					continue
+				}
+				finding := i.createFinding(
+					"iam_cross_account_trust",
+					roleName,
+					"IAM role has cross-account trust",
+					fmt.Sprintf("Role %s trusts external AWS accounts", roleName),
+					scanner.StatusFail,
+					scanner.SeverityHigh,
+				)
+				finding.Managed = isServiceLinkedRole(role)
+				findings = append(findings, finding)
+				flagged = true
+			}
+			if trustsAllowed && !flagged {
+				findings = append(findings, i.createFinding(
+					"iam_cross_account_trust",
+					roleName,
+					"IAM role only trusts allowed external accounts",
+					fmt.Sprintf("Role %s trusts external AWS accounts that are on the allowlist", roleName),
+					scanner.StatusPass,
+					scanner.SeverityHigh,
+				))
 			}
 		}
 	}
@@ -322,11 +425,17 @@ func (i *Scanner) checkConsoleWithoutMFA(ctx context.Context, user types.User) [
 
 	_, err := i.client.GetLoginProfile(ctx, &iam.GetLoginProfileInput{UserName: user.UserName})
 	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{i.accessDeniedFinding("iam_console_without_mfa", userName, err)}
+		}
 		return nil // User has no console access
 	}
 
 	mfaDevices, err := i.client.ListMFADevices(ctx, &iam.ListMFADevicesInput{UserName: user.UserName})
 	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{i.accessDeniedFinding("iam_console_without_mfa", userName, err)}
+		}
 		return nil
 	}
 
@@ -359,11 +468,20 @@ func isWildcard(v interface{}) bool {
 	return false
 }
 
+// serviceLinkedRolePath is the path AWS creates service-linked roles under.
+const serviceLinkedRolePath = "/aws-service-role/"
+
+// isServiceLinkedRole reports whether a role is owned by an AWS service. Its
+// trust and permissions policies are managed by AWS and cannot be edited.
+func isServiceLinkedRole(role types.Role) bool {
+	return strings.HasPrefix(aws.ToString(role.Path), serviceLinkedRolePath)
+}
+
 // hasCrossAccountPrincipal reports whether the given principal represents cross-account access
 // relative to the provided account ID.
 // It returns true if the principal is a wildcard (`"*"`) or contains an `AWS` principal value
-// that does not include the provided account ID, false otherwise.
-func hasCrossAccountPrincipal(principal interface{}, accountID string) bool {
+// that includes neither the provided account ID nor one of the allowed account IDs, false otherwise.
+func hasCrossAccountPrincipal(principal interface{}, accountID string, allowed []string) bool {
 	switch p := principal.(type) {
 	case string:
 		return p == "*"
@@ -371,10 +489,10 @@ func hasCrossAccountPrincipal(principal interface{}, accountID string) bool {
 		if aws, ok := p["AWS"]; ok {
 			switch v := aws.(type) {
 			case string:
-				return !containsAccountID(v, accountID)
+				return !isTrustedPrincipal(v, accountID, allowed)
 			case []interface{}:
 				for _, item := range v {
-					if s, ok := item.(string); ok && !containsAccountID(s, accountID) {
+					if s, ok := item.(string); ok && !isTrustedPrincipal(s, accountID, allowed) {
 						return true
 					}
 				}
@@ -384,6 +502,20 @@ func hasCrossAccountPrincipal(principal interface{}, accountID string) bool {
 	return false
 }
 
+// isTrustedPrincipal reports whether an AWS principal belongs to the scanned
+// account or one of the allowed external accounts.
+func isTrustedPrincipal(arn, accountID string, allowed []string) bool {
+	if containsAccountID(arn, accountID) {
+		return true
+	}
+	for _, id := range allowed {
+		if containsAccountID(arn, id) {
+			return true
+		}
+	}
+	return false
+}
+
 // containsAccountID reports whether arn contains the provided accountID.
 // It returns true only when both arn and accountID are non-empty and either
 // arn equals accountID or arn contains accountID according to the contains helper.
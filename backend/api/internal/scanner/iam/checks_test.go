@@ -0,0 +1,493 @@
+package iam
+
+import (
+	"context"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/smithy-go"
+)
+
+// fakeIAMClient implements iamAPI with canned responses.
+// Methods that are not overridden panic via the nil embedded interface.
+type fakeIAMClient struct {
+	iamAPI
+	err error
+}
+
+func (f *fakeIAMClient) ListMFADevices(_ context.Context, _ *iam.ListMFADevicesInput, _ ...func(*iam.Options)) (*iam.ListMFADevicesOutput, error) {
+	return nil, f.err
+}
+
+func (f *fakeIAMClient) ListAccessKeys(_ context.Context, _ *iam.ListAccessKeysInput, _ ...func(*iam.Options)) (*iam.ListAccessKeysOutput, error) {
+	return nil, f.err
+}
+
+func (f *fakeIAMClient) GetAccountSummary(_ context.Context, _ *iam.GetAccountSummaryInput, _ ...func(*iam.Options)) (*iam.GetAccountSummaryOutput, error) {
+	return nil, f.err
+}
+
+func (f *fakeIAMClient) GetAccountPasswordPolicy(_ context.Context, _ *iam.GetAccountPasswordPolicyInput, _ ...func(*iam.Options)) (*iam.GetAccountPasswordPolicyOutput, error) {
+	return nil, f.err
+}
+
+func newTestScanner(client iamAPI) *Scanner {
+	return &Scanner{client: client, region: "us-east-1", accountID: "123456789012"}
+}
+
+func TestChecks_AccessDenied(t *testing.T) {
+	s := newTestScanner(&fakeIAMClient{err: &smithy.GenericAPIError{Code: "AccessDenied"}})
+	user := types.User{UserName: aws.String("alice")}
+
+	tests := []struct {
+		name       string
+		findings   []scanner.Finding
+		checkID    string
+		resourceID string
+	}{
+		{"user mfa", s.checkUserMFA(context.Background(), user), "iam_user_mfa", "alice"},
+		{"root mfa", s.checkRootMFA(context.Background()), "iam_root_mfa", "root"},
+		{"password policy", s.checkPasswordPolicy(context.Background()), "iam_password_policy", "account"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(tt.findings))
+			}
+			f := tt.findings[0]
+			if f.Status != scanner.StatusError || f.Severity != scanner.SeverityMedium {
+				t.Errorf("got %s/%s, want ERROR/MEDIUM", f.Status, f.Severity)
+			}
+			if f.CheckID != tt.checkID || f.ResourceID != tt.resourceID {
+				t.Errorf("got %s on %s, want %s on %s", f.CheckID, f.ResourceID, tt.checkID, tt.resourceID)
+			}
+		})
+	}
+}
+
+func TestChecks_AccessKeyFindingsUseUserARN(t *testing.T) {
+	s := newTestScanner(&fakeIAMClient{err: &smithy.GenericAPIError{Code: "AccessDenied"}})
+	user := types.User{UserName: aws.String("alice"), Arn: aws.String("arn:aws:iam::123456789012:user/eng/alice")}
+
+	for _, findings := range [][]scanner.Finding{
+		s.checkUnusedAccessKeys(context.Background(), user),
+		s.checkAccessKeyRotation(context.Background(), user),
+	} {
+		if len(findings) != 1 || findings[0].ARN != aws.ToString(user.Arn) {
+			t.Errorf("findings = %+v, want one finding with the user's ARN", findings)
+		}
+	}
+}
+
+// fakeAccessKeyClient serves one access key, never used unless lastUsed is set.
+type fakeAccessKeyClient struct {
+	iamAPI
+	created  time.Time
+	lastUsed *time.Time
+}
+
+func (f *fakeAccessKeyClient) ListAccessKeys(_ context.Context, params *iam.ListAccessKeysInput, _ ...func(*iam.Options)) (*iam.ListAccessKeysOutput, error) {
+	return &iam.ListAccessKeysOutput{AccessKeyMetadata: []types.AccessKeyMetadata{{
+		UserName:    params.UserName,
+		AccessKeyId: aws.String("AKIAOLD"),
+		CreateDate:  aws.Time(f.created),
+	}}}, nil
+}
+
+func (f *fakeAccessKeyClient) GetAccessKeyLastUsed(_ context.Context, _ *iam.GetAccessKeyLastUsedInput, _ ...func(*iam.Options)) (*iam.GetAccessKeyLastUsedOutput, error) {
+	return &iam.GetAccessKeyLastUsedOutput{AccessKeyLastUsed: &types.AccessKeyLastUsed{LastUsedDate: f.lastUsed}}, nil
+}
+
+func TestChecks_AccessKeyFindingsCarryKeyCreateDate(t *testing.T) {
+	created := time.Now().Add(-200 * 24 * time.Hour).Truncate(time.Second)
+	s := newTestScanner(&fakeAccessKeyClient{created: created})
+	user := types.User{UserName: aws.String("alice"), CreateDate: aws.Time(created.Add(-24 * time.Hour))}
+
+	for _, findings := range [][]scanner.Finding{
+		s.checkUnusedAccessKeys(context.Background(), user),
+		s.checkAccessKeyRotation(context.Background(), user),
+	} {
+		if len(findings) != 1 {
+			t.Fatalf("findings = %+v, want one", findings)
+		}
+		f := findings[0]
+		if f.ResourceCreatedAt == nil || !f.ResourceCreatedAt.Equal(created) {
+			t.Errorf("%s ResourceCreatedAt = %v, want the key's creation date %v", f.CheckID, f.ResourceCreatedAt, created)
+		}
+		if !f.Timestamp.After(created) {
+			t.Errorf("%s Timestamp = %v, want the detection time", f.CheckID, f.Timestamp)
+		}
+	}
+}
+
+func TestChecks_SplitAccessKeyThresholds(t *testing.T) {
+	// The key is 45 days old and was last used 45 days ago: past the
+	// rotation limit but within the unused limit.
+	aged := time.Now().Add(-45 * 24 * time.Hour)
+	client := &fakeAccessKeyClient{created: aged, lastUsed: aws.Time(aged)}
+	user := types.User{UserName: aws.String("alice")}
+
+	s := NewScanner(aws.Config{Region: "us-east-1"}, "us-east-1", "123456789012",
+		WithKeyRotationMaxAgeDays(30), WithUnusedKeyMaxAgeDays(60)).(*Scanner)
+	s.client = client
+
+	rotation := s.checkAccessKeyRotation(context.Background(), user)
+	if len(rotation) != 1 || rotation[0].Status != scanner.StatusFail {
+		t.Fatalf("rotation findings = %+v, want one failure", rotation)
+	}
+	if !strings.Contains(rotation[0].Title, "30 days") || !strings.Contains(rotation[0].Description, "30-day") {
+		t.Errorf("rotation finding %q / %q does not state the 30-day limit", rotation[0].Title, rotation[0].Description)
+	}
+	if unused := s.checkUnusedAccessKeys(context.Background(), user); len(unused) != 0 {
+		t.Errorf("unused findings = %+v, want none within the 60-day limit", unused)
+	}
+
+	// Without the split options both checks keep the shared 90-day default.
+	s = newTestScanner(&fakeAccessKeyClient{created: aged, lastUsed: aws.Time(aged)})
+	if findings := s.checkAccessKeyRotation(context.Background(), user); len(findings) != 0 {
+		t.Errorf("default rotation findings = %+v, want none", findings)
+	}
+}
+
+func TestChecks_OtherErrorsStillDropped(t *testing.T) {
+	s := newTestScanner(&fakeIAMClient{err: &smithy.GenericAPIError{Code: "ServiceFailure"}})
+
+	if findings := s.checkRootMFA(context.Background()); len(findings) != 0 {
+		t.Errorf("expected no findings for non-permission error, got %+v", findings)
+	}
+}
+
+// fakeScanRoleClient serves the scan role's inline and attached managed policies.
+type fakeScanRoleClient struct {
+	iamAPI
+	inline  map[string]string
+	managed map[string]string
+}
+
+func (f *fakeScanRoleClient) ListRolePolicies(_ context.Context, params *iam.ListRolePoliciesInput, _ ...func(*iam.Options)) (*iam.ListRolePoliciesOutput, error) {
+	if aws.ToString(params.RoleName) != defaultScanRoleName {
+		return nil, &types.NoSuchEntityException{Message: aws.String("role not found")}
+	}
+	out := &iam.ListRolePoliciesOutput{}
+	for name := range f.inline {
+		out.PolicyNames = append(out.PolicyNames, name)
+	}
+	return out, nil
+}
+
+func (f *fakeScanRoleClient) GetRolePolicy(_ context.Context, params *iam.GetRolePolicyInput, _ ...func(*iam.Options)) (*iam.GetRolePolicyOutput, error) {
+	return &iam.GetRolePolicyOutput{PolicyDocument: aws.String(url.QueryEscape(f.inline[aws.ToString(params.PolicyName)]))}, nil
+}
+
+func (f *fakeScanRoleClient) ListAttachedRolePolicies(_ context.Context, _ *iam.ListAttachedRolePoliciesInput, _ ...func(*iam.Options)) (*iam.ListAttachedRolePoliciesOutput, error) {
+	out := &iam.ListAttachedRolePoliciesOutput{}
+	for arn := range f.managed {
+		out.AttachedPolicies = append(out.AttachedPolicies, types.AttachedPolicy{PolicyArn: aws.String(arn)})
+	}
+	return out, nil
+}
+
+func (f *fakeScanRoleClient) GetPolicy(_ context.Context, params *iam.GetPolicyInput, _ ...func(*iam.Options)) (*iam.GetPolicyOutput, error) {
+	return &iam.GetPolicyOutput{Policy: &types.Policy{Arn: params.PolicyArn, DefaultVersionId: aws.String("v2")}}, nil
+}
+
+func (f *fakeScanRoleClient) GetPolicyVersion(_ context.Context, params *iam.GetPolicyVersionInput, _ ...func(*iam.Options)) (*iam.GetPolicyVersionOutput, error) {
+	return &iam.GetPolicyVersionOutput{PolicyVersion: &types.PolicyVersion{
+		Document: aws.String(url.QueryEscape(f.managed[aws.ToString(params.PolicyArn)])),
+	}}, nil
+}
+
+const scanRolePolicy = `{
+	"Version": "2012-10-17",
+	"Statement": [
+		{"Effect": "Allow", "Action": ["s3:ListAllMyBuckets", "s3:GetBucketAcl", "s3:GetEncryptionConfiguration"], "Resource": "*"},
+		{"Effect": "Allow", "Action": ["iam:ListRoles", "iam:GetPolicyVersion"], "Resource": "*"},
+		{"Effect": "Allow", "Action": "ec2:DescribeInstances", "Resource": "*"}
+	]
+}`
+
+func TestCheckScanRolePermissions(t *testing.T) {
+	tests := []struct {
+		name       string
+		client     *fakeScanRoleClient
+		roleName   string
+		wantStatus scanner.FindingStatus
+		wantExcess []string
+	}{
+		{
+			name:       "documented permissions only",
+			client:     &fakeScanRoleClient{inline: map[string]string{"CloudCopPermissions": scanRolePolicy}},
+			wantStatus: scanner.StatusPass,
+		},
+		{
+			name: "extra s3:PutObject",
+			client: &fakeScanRoleClient{inline: map[string]string{
+				"CloudCopPermissions": scanRolePolicy,
+				"Extra":               `{"Statement": [{"Effect": "Allow", "Action": "s3:PutObject", "Resource": "*"}]}`,
+			}},
+			wantStatus: scanner.StatusFail,
+			wantExcess: []string{"s3:PutObject"},
+		},
+		{
+			name: "broad managed policy",
+			client: &fakeScanRoleClient{
+				inline:  map[string]string{"CloudCopPermissions": scanRolePolicy},
+				managed: map[string]string{"arn:aws:iam::aws:policy/PowerUserAccess": `{"Statement": [{"Effect": "Allow", "NotAction": "iam:*", "Action": ["s3:*", "ec2:TerminateInstances"], "Resource": "*"}]}`},
+			},
+			wantStatus: scanner.StatusFail,
+			wantExcess: []string{"ec2:TerminateInstances", "s3:*", `NotAction ["iam:*"]`},
+		},
+		{
+			name: "s3:Get* reaches object data",
+			client: &fakeScanRoleClient{inline: map[string]string{
+				"CloudCopPermissions": scanRolePolicy,
+				"Legacy":              `{"Statement": [{"Effect": "Allow", "Action": ["s3:Get*", "s3:GetObject"], "Resource": "*"}]}`,
+			}},
+			wantStatus: scanner.StatusFail,
+			wantExcess: []string{"s3:Get*", "s3:GetObject"},
+		},
+		{
+			name: "allow with NotAction",
+			client: &fakeScanRoleClient{inline: map[string]string{
+				"CloudCopPermissions": scanRolePolicy,
+				"Extra":               `{"Statement": [{"Effect": "Allow", "NotAction": "iam:*", "Resource": "*"}]}`,
+			}},
+			wantStatus: scanner.StatusFail,
+			wantExcess: []string{`NotAction ["iam:*"]`},
+		},
+		{
+			name: "denied actions are ignored",
+			client: &fakeScanRoleClient{inline: map[string]string{
+				"CloudCopPermissions": scanRolePolicy,
+				"Guard":               `{"Statement": [{"Effect": "Deny", "Action": "s3:DeleteBucket", "Resource": "*"}]}`,
+			}},
+			wantStatus: scanner.StatusPass,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := newTestScanner(tt.client).checkScanRolePermissions(context.Background())
+
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			f := findings[0]
+			if f.CheckID != "iam_scan_role_least_privilege" || f.ResourceID != defaultScanRoleName || f.Severity != scanner.SeverityMedium {
+				t.Errorf("unexpected finding: %+v", f)
+			}
+			if f.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", f.Status, tt.wantStatus)
+			}
+			for _, action := range tt.wantExcess {
+				if !strings.Contains(f.Description, action) {
+					t.Errorf("Description %q does not mention %s", f.Description, action)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckScanRolePermissions_RoleMissing(t *testing.T) {
+	s := newTestScanner(&fakeScanRoleClient{})
+	s.scanRoleName = "SelfHostedRole"
+
+	if findings := s.checkScanRolePermissions(context.Background()); len(findings) != 0 {
+		t.Errorf("expected no findings without the scan role, got %+v", findings)
+	}
+}
+
+// fakePolicyClient serves customer managed policies and a user's inline
+// policies, keyed by policy ARN and policy name.
+type fakePolicyClient struct {
+	iamAPI
+	managed map[string]string
+	inline  map[string]string
+}
+
+func (f *fakePolicyClient) ListPolicies(_ context.Context, _ *iam.ListPoliciesInput, _ ...func(*iam.Options)) (*iam.ListPoliciesOutput, error) {
+	out := &iam.ListPoliciesOutput{}
+	for _, arn := range slices.Sorted(maps.Keys(f.managed)) {
+		name := arn[strings.LastIndex(arn, "/")+1:]
+		out.Policies = append(out.Policies, types.Policy{Arn: aws.String(arn), PolicyName: aws.String(name), DefaultVersionId: aws.String("v1")})
+	}
+	return out, nil
+}
+
+func (f *fakePolicyClient) GetPolicyVersion(_ context.Context, params *iam.GetPolicyVersionInput, _ ...func(*iam.Options)) (*iam.GetPolicyVersionOutput, error) {
+	return &iam.GetPolicyVersionOutput{PolicyVersion: &types.PolicyVersion{
+		Document: aws.String(url.QueryEscape(f.managed[aws.ToString(params.PolicyArn)])),
+	}}, nil
+}
+
+func (f *fakePolicyClient) ListUserPolicies(_ context.Context, _ *iam.ListUserPoliciesInput, _ ...func(*iam.Options)) (*iam.ListUserPoliciesOutput, error) {
+	return &iam.ListUserPoliciesOutput{PolicyNames: slices.Sorted(maps.Keys(f.inline))}, nil
+}
+
+func (f *fakePolicyClient) GetUserPolicy(_ context.Context, params *iam.GetUserPolicyInput, _ ...func(*iam.Options)) (*iam.GetUserPolicyOutput, error) {
+	return &iam.GetUserPolicyOutput{PolicyDocument: aws.String(url.QueryEscape(f.inline[aws.ToString(params.PolicyName)]))}, nil
+}
+
+const (
+	allowNotActionPolicy = `{"Statement": [{"Effect": "Allow", "NotAction": ["iam:*", "organizations:*"], "Resource": "*"}]}`
+	denyNotActionPolicy  = `{"Statement": [{"Effect": "Deny", "NotAction": ["iam:*", "sts:*"], "Resource": "*", "Condition": {"BoolIfExists": {"aws:MultiFactorAuthPresent": "false"}}}]}`
+	readOnlyPolicy       = `{"Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": "arn:aws:s3:::reports/*"}]}`
+)
+
+// notActionFindings returns the iam_not_action findings in findings.
+func notActionFindings(findings []scanner.Finding) []scanner.Finding {
+	return slices.DeleteFunc(findings, func(f scanner.Finding) bool { return f.CheckID != "iam_not_action" })
+}
+
+func TestCheckNotAction_ManagedPolicies(t *testing.T) {
+	client := &fakePolicyClient{managed: map[string]string{
+		"arn:aws:iam::123456789012:policy/PowerUser":  allowNotActionPolicy,
+		"arn:aws:iam::123456789012:policy/RequireMFA": denyNotActionPolicy,
+		"arn:aws:iam::123456789012:policy/Reports":    readOnlyPolicy,
+	}}
+
+	findings := notActionFindings(newTestScanner(client).checkOverlyPermissivePolicies(context.Background()))
+
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 iam_not_action finding, got %+v", findings)
+	}
+	f := findings[0]
+	if f.ResourceID != "arn:aws:iam::123456789012:policy/PowerUser" || f.Status != scanner.StatusFail || f.Severity != scanner.SeverityHigh {
+		t.Errorf("got %s %s/%s, want the PowerUser policy FAIL/HIGH", f.ResourceID, f.Status, f.Severity)
+	}
+	if !strings.Contains(f.Description, "PowerUser") || !strings.Contains(f.Description, "organizations:*") {
+		t.Errorf("Description %q does not name the policy and its excluded actions", f.Description)
+	}
+}
+
+func TestCheckNotAction_InlinePolicies(t *testing.T) {
+	user := types.User{UserName: aws.String("alice")}
+
+	tests := []struct {
+		name   string
+		inline map[string]string
+		want   []string // policies named in the finding; none means no finding
+	}{
+		{"allow NotAction", map[string]string{"Admin": allowNotActionPolicy, "Reports": readOnlyPolicy}, []string{"Admin"}},
+		{"deny NotAction", map[string]string{"RequireMFA": denyNotActionPolicy}, nil},
+		{"several offending policies", map[string]string{"Admin": allowNotActionPolicy, "Ops": allowNotActionPolicy}, []string{"Admin", "Ops"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestScanner(&fakePolicyClient{inline: tt.inline})
+
+			findings := notActionFindings(s.checkInlinePolicies(context.Background(), user))
+			if len(tt.want) == 0 {
+				if len(findings) != 0 {
+					t.Errorf("expected no iam_not_action findings, got %+v", findings)
+				}
+				return
+			}
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 iam_not_action finding, got %d", len(findings))
+			}
+			f := findings[0]
+			if f.ResourceID != "alice" || f.Severity != scanner.SeverityHigh {
+				t.Errorf("got %s/%s, want alice/HIGH", f.ResourceID, f.Severity)
+			}
+			for _, name := range tt.want {
+				if !strings.Contains(f.Description, name) {
+					t.Errorf("Description %q does not name %s", f.Description, name)
+				}
+			}
+		})
+	}
+}
+
+// fakeRolesClient lists a fixed set of roles.
+type fakeRolesClient struct {
+	iamAPI
+	roles []types.Role
+}
+
+func (f *fakeRolesClient) ListRoles(_ context.Context, _ *iam.ListRolesInput, _ ...func(*iam.Options)) (*iam.ListRolesOutput, error) {
+	return &iam.ListRolesOutput{Roles: f.roles}, nil
+}
+
+func TestCheckCrossAccountTrust_ServiceLinkedRoleManaged(t *testing.T) {
+	trust := url.QueryEscape(`{"Statement":[{"Principal":{"AWS":"arn:aws:iam::999999999999:root"}}]}`)
+	client := &fakeRolesClient{roles: []types.Role{
+		{RoleName: aws.String("AWSServiceRoleForSupport"), Path: aws.String("/aws-service-role/support.amazonaws.com/"), AssumeRolePolicyDocument: aws.String(trust)},
+		{RoleName: aws.String("partner-access"), Path: aws.String("/"), AssumeRolePolicyDocument: aws.String(trust)},
+	}}
+	s := &Scanner{client: client, region: "global", accountID: "123456789012"}
+
+	findings := s.checkCrossAccountTrust(context.Background())
+
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d", len(findings))
+	}
+	want := map[string]bool{"AWSServiceRoleForSupport": true, "partner-access": false}
+	for _, f := range findings {
+		if f.Managed != want[f.ResourceID] {
+			t.Errorf("%s: Managed = %v, want %v", f.ResourceID, f.Managed, want[f.ResourceID])
+		}
+	}
+}
+
+func TestCheckCrossAccountTrust_AllowedExternalAccounts(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal string
+		want      scanner.FindingStatus
+	}{
+		{"allowlisted account", `{"AWS":"arn:aws:iam::111111111111:root"}`, scanner.StatusPass},
+		{"allowlisted bare account ID", `{"AWS":"111111111111"}`, scanner.StatusPass},
+		{"unknown account", `{"AWS":"arn:aws:iam::999999999999:root"}`, scanner.StatusFail},
+		{"allowlisted and unknown accounts", `{"AWS":["arn:aws:iam::111111111111:root","arn:aws:iam::999999999999:root"]}`, scanner.StatusFail},
+		{"wildcard principal", `"*"`, scanner.StatusFail},
+		{"wildcard AWS principal", `{"AWS":"*"}`, scanner.StatusFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trust := url.QueryEscape(`{"Statement":[{"Principal":` + tt.principal + `}]}`)
+			client := &fakeRolesClient{roles: []types.Role{
+				{RoleName: aws.String("partner-access"), Path: aws.String("/"), AssumeRolePolicyDocument: aws.String(trust)},
+			}}
+			s := &Scanner{
+				client:                  client,
+				region:                  "global",
+				accountID:               "123456789012",
+				allowedExternalAccounts: []string{"111111111111", "222222222222"},
+			}
+
+			findings := s.checkCrossAccountTrust(context.Background())
+
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			if findings[0].Status != tt.want {
+				t.Errorf("Status = %s, want %s", findings[0].Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckCrossAccountTrust_SameAccountNotReported(t *testing.T) {
+	trust := url.QueryEscape(`{"Statement":[{"Principal":{"AWS":"arn:aws:iam::123456789012:root"}},{"Principal":{"Service":"lambda.amazonaws.com"}}]}`)
+	client := &fakeRolesClient{roles: []types.Role{
+		{RoleName: aws.String("internal"), Path: aws.String("/"), AssumeRolePolicyDocument: aws.String(trust)},
+	}}
+	s := &Scanner{client: client, region: "global", accountID: "123456789012", allowedExternalAccounts: []string{"111111111111"}}
+
+	if findings := s.checkCrossAccountTrust(context.Background()); len(findings) != 0 {
+		t.Errorf("expected no findings for a role without external trust, got %d", len(findings))
+	}
+}
@@ -0,0 +1,257 @@
+package iam
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+)
+
+const (
+	unusedUserMaxDays   = 90
+	rootUsageWindowDays = 90
+
+	rootAccountUser = "<root_account>"
+
+	credentialReportPollAttempts = 5
+	credentialReportPollInterval = 2 * time.Second
+)
+
+// credentialReportEntry is a single row of the IAM credential report.
+// Timestamps are nil when the report holds N/A, no_information or any other
+// non-date value for the column.
+type credentialReportEntry struct {
+	User               string
+	ARN                string
+	UserCreationTime   *time.Time
+	PasswordEnabled    bool
+	PasswordLastUsed   *time.Time
+	AccessKey1Active   bool
+	AccessKey1LastUsed *time.Time
+	AccessKey2Active   bool
+	AccessKey2LastUsed *time.Time
+	MFAActive          bool
+}
+
+// isRoot reports whether the entry is the special root account row.
+func (e credentialReportEntry) isRoot() bool {
+	return e.User == rootAccountUser
+}
+
+// lastActivity returns the most recent password or access key use, or nil if
+// the credentials have never been used.
+func (e credentialReportEntry) lastActivity() *time.Time {
+	var latest *time.Time
+	for _, t := range []*time.Time{e.PasswordLastUsed, e.AccessKey1LastUsed, e.AccessKey2LastUsed} {
+		if t != nil && (latest == nil || t.After(*latest)) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// getCredentialReport requests a fresh credential report, polling briefly
+// while AWS is still generating it, and returns the parsed rows.
+func (i *Scanner) getCredentialReport(ctx context.Context) ([]credentialReportEntry, error) {
+	for attempt := 0; ; attempt++ {
+		gen, err := i.client.GenerateCredentialReport(ctx, &iam.GenerateCredentialReportInput{})
+		if err != nil {
+			return nil, fmt.Errorf("generating credential report: %w", err)
+		}
+		if gen.State == types.ReportStateTypeComplete {
+			break
+		}
+		if attempt+1 >= credentialReportPollAttempts {
+			return nil, errors.New("credential report not ready")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(credentialReportPollInterval):
+		}
+	}
+
+	report, err := i.client.GetCredentialReport(ctx, &iam.GetCredentialReportInput{})
+	if err != nil {
+		return nil, fmt.Errorf("getting credential report: %w", err)
+	}
+	return parseCredentialReport(report.Content)
+}
+
+// parseCredentialReport parses the CSV credential report. Columns are looked
+// up by header name so reordered or added columns don't break parsing, and
+// rows with a mismatched field count are skipped rather than failing the
+// whole report.
+func parseCredentialReport(content []byte) ([]credentialReportEntry, error) {
+	r := csv.NewReader(bytes.NewReader(content))
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading credential report header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for idx, name := range header {
+		columns[strings.TrimSpace(name)] = idx
+	}
+	if _, ok := columns["user"]; !ok {
+		return nil, errors.New("credential report missing user column")
+	}
+
+	var entries []credentialReportEntry
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading credential report: %w", err)
+		}
+		if len(record) != len(header) {
+			continue
+		}
+
+		field := func(name string) string {
+			if idx, ok := columns[name]; ok {
+				return strings.TrimSpace(record[idx])
+			}
+			return ""
+		}
+
+		entries = append(entries, credentialReportEntry{
+			User:               field("user"),
+			ARN:                field("arn"),
+			UserCreationTime:   parseReportTime(field("user_creation_time")),
+			PasswordEnabled:    field("password_enabled") == "true",
+			PasswordLastUsed:   parseReportTime(field("password_last_used")),
+			AccessKey1Active:   field("access_key_1_active") == "true",
+			AccessKey1LastUsed: parseReportTime(field("access_key_1_last_used_date")),
+			AccessKey2Active:   field("access_key_2_active") == "true",
+			AccessKey2LastUsed: parseReportTime(field("access_key_2_last_used_date")),
+			MFAActive:          field("mfa_active") == "true",
+		})
+	}
+	return entries, nil
+}
+
+// parseReportTime parses an ISO 8601 timestamp from the credential report,
+// returning nil for placeholders such as N/A, no_information or not_supported.
+func parseReportTime(value string) *time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+func (i *Scanner) checkCredentialReport(ctx context.Context) []scanner.Finding {
+	entries, err := i.getCredentialReport(ctx)
+	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{
+				i.accessDeniedFinding("iam_unused_users", "account", err),
+				i.accessDeniedFinding("iam_root_usage", "root", err),
+			}
+		}
+		scanner.Logf(ctx, "Warning: failed to get credential report for unused user and root usage checks: %v", err)
+		return nil
+	}
+
+	now := time.Now()
+	var findings []scanner.Finding
+	findings = append(findings, i.checkUnusedUsers(entries, now)...)
+	findings = append(findings, i.checkRootUsage(entries, now)...)
+	return findings
+}
+
+func (i *Scanner) checkUnusedUsers(entries []credentialReportEntry, now time.Time) []scanner.Finding {
+	var findings []scanner.Finding
+	for _, e := range entries {
+		if e.isRoot() {
+			continue
+		}
+
+		if last := e.lastActivity(); last != nil {
+			daysSinceUse := int(now.Sub(*last).Hours() / 24)
+			if daysSinceUse > unusedUserMaxDays {
+				findings = append(findings, i.createFinding(
+					"iam_unused_users",
+					e.User,
+					"IAM user inactive for over 90 days",
+					fmt.Sprintf("User %s has not used a password or access key in %d days", e.User, daysSinceUse),
+					scanner.StatusFail,
+					scanner.SeverityMedium,
+				))
+			}
+			continue
+		}
+
+		// Never used: only flag once the user is old enough to have had a chance.
+		if e.UserCreationTime == nil {
+			continue
+		}
+		daysSinceCreation := int(now.Sub(*e.UserCreationTime).Hours() / 24)
+		if daysSinceCreation > unusedUserMaxDays {
+			findings = append(findings, i.createFinding(
+				"iam_unused_users",
+				e.User,
+				"IAM user has never been used",
+				fmt.Sprintf("User %s was created %d days ago and has never signed in or used an access key", e.User, daysSinceCreation),
+				scanner.StatusFail,
+				scanner.SeverityMedium,
+			))
+		}
+	}
+	return findings
+}
+
+func (i *Scanner) checkRootUsage(entries []credentialReportEntry, now time.Time) []scanner.Finding {
+	for _, e := range entries {
+		if !e.isRoot() {
+			continue
+		}
+
+		var issues []string
+		if e.AccessKey1Active || e.AccessKey2Active {
+			issues = append(issues, "root account has active access keys")
+		}
+		if e.PasswordLastUsed != nil && int(now.Sub(*e.PasswordLastUsed).Hours()/24) <= rootUsageWindowDays {
+			issues = append(issues, fmt.Sprintf("root password used on %s", e.PasswordLastUsed.Format(time.DateOnly)))
+		}
+		for _, t := range []*time.Time{e.AccessKey1LastUsed, e.AccessKey2LastUsed} {
+			if t != nil && int(now.Sub(*t).Hours()/24) <= rootUsageWindowDays {
+				issues = append(issues, fmt.Sprintf("root access key used on %s", t.Format(time.DateOnly)))
+			}
+		}
+
+		if len(issues) > 0 {
+			return []scanner.Finding{i.createFinding(
+				"iam_root_usage",
+				"root",
+				"Root account is in use",
+				fmt.Sprintf("Root account usage detected: %s", strings.Join(issues, "; ")),
+				scanner.StatusFail,
+				scanner.SeverityCritical,
+			)}
+		}
+		return []scanner.Finding{i.createFinding(
+			"iam_root_usage",
+			"root",
+			"Root account not recently used",
+			"The AWS root account has no access keys and no recent sign-ins",
+			scanner.StatusPass,
+			scanner.SeverityCritical,
+		)}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package iam
+
+import (
+	"testing"
+	"time"
+
+	"cloudcop/api/internal/scanner"
+)
+
+const testCredentialReport = `user,arn,user_creation_time,password_enabled,password_last_used,password_last_changed,password_next_rotation,mfa_active,access_key_1_active,access_key_1_last_rotated,access_key_1_last_used_date,access_key_1_last_used_region,access_key_1_last_used_service,access_key_2_active,access_key_2_last_rotated,access_key_2_last_used_date,access_key_2_last_used_region,access_key_2_last_used_service,cert_1_active,cert_1_last_rotated,cert_2_active,cert_2_last_rotated
+<root_account>,arn:aws:iam::123456789012:root,2020-01-01T00:00:00+00:00,not_supported,2024-05-20T10:00:00+00:00,not_supported,not_supported,true,false,N/A,N/A,N/A,N/A,false,N/A,N/A,N/A,N/A,false,N/A,false,N/A
+alice,arn:aws:iam::123456789012:user/alice,2021-03-04T12:00:00+00:00,true,2024-06-01T08:30:00+00:00,2021-03-04T12:00:00+00:00,N/A,true,true,2021-03-04T12:05:00+00:00,2024-06-10T09:00:00+00:00,us-east-1,s3,false,N/A,N/A,N/A,N/A,false,N/A,false,N/A
+bob,arn:aws:iam::123456789012:user/bob,2022-07-15T00:00:00+00:00,false,N/A,N/A,N/A,false,true,2022-07-15T00:00:00+00:00,N/A,N/A,N/A,false,N/A,N/A,N/A,N/A,false,N/A,false,N/A
+`
+
+func TestParseCredentialReport(t *testing.T) {
+	entries, err := parseCredentialReport([]byte(testCredentialReport))
+	if err != nil {
+		t.Fatalf("parseCredentialReport() error = %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+
+	root := entries[0]
+	if !root.isRoot() {
+		t.Errorf("first entry User = %q, want root account", root.User)
+	}
+	if root.PasswordEnabled {
+		t.Error("root PasswordEnabled should be false for not_supported")
+	}
+	if root.PasswordLastUsed == nil || !root.PasswordLastUsed.Equal(time.Date(2024, 5, 20, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("root PasswordLastUsed = %v, want 2024-05-20T10:00:00Z", root.PasswordLastUsed)
+	}
+	if !root.MFAActive {
+		t.Error("root MFAActive should be true")
+	}
+
+	alice := entries[1]
+	if alice.User != "alice" || alice.ARN != "arn:aws:iam::123456789012:user/alice" {
+		t.Errorf("unexpected alice entry: %+v", alice)
+	}
+	if !alice.PasswordEnabled || !alice.AccessKey1Active || alice.AccessKey2Active {
+		t.Errorf("alice flags parsed incorrectly: %+v", alice)
+	}
+	if last := alice.lastActivity(); last == nil || !last.Equal(time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("alice lastActivity() = %v, want access key use on 2024-06-10", last)
+	}
+
+	bob := entries[2]
+	if bob.PasswordLastUsed != nil || bob.AccessKey1LastUsed != nil {
+		t.Errorf("bob N/A timestamps should parse as nil: %+v", bob)
+	}
+	if bob.lastActivity() != nil {
+		t.Error("bob lastActivity() should be nil for never-used credentials")
+	}
+	if bob.UserCreationTime == nil {
+		t.Error("bob UserCreationTime should be set")
+	}
+}
+
+func TestParseCredentialReport_Malformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+		wantErr bool
+	}{
+		{name: "empty", content: "", wantErr: true},
+		{name: "missing user column", content: "arn,mfa_active\narn:aws:iam::1:user/a,true\n", wantErr: true},
+		{name: "header only", content: "user,arn\n", want: 0},
+		{name: "short row skipped", content: "user,arn,mfa_active\nalice,arn:aws:iam::1:user/alice\nbob,arn:aws:iam::1:user/bob,true\n", want: 1},
+		{name: "reordered columns", content: "mfa_active,user\ntrue,alice\n", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := parseCredentialReport([]byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCredentialReport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(entries) != tt.want {
+				t.Errorf("got %d entries, want %d", len(entries), tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckUnusedUsersAndRootUsage(t *testing.T) {
+	s := &Scanner{region: "us-east-1", accountID: "123456789012"}
+	entries, err := parseCredentialReport([]byte(testCredentialReport))
+	if err != nil {
+		t.Fatalf("parseCredentialReport() error = %v", err)
+	}
+
+	now := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	unused := s.checkUnusedUsers(entries, now)
+	if len(unused) != 1 || unused[0].ResourceID != "bob" {
+		t.Fatalf("checkUnusedUsers() = %+v, want a single finding for bob", unused)
+	}
+	if unused[0].CheckID != "iam_unused_users" || unused[0].Status != scanner.StatusFail {
+		t.Errorf("unexpected unused user finding: %+v", unused[0])
+	}
+
+	root := s.checkRootUsage(entries, now)
+	if len(root) != 1 || root[0].Status != scanner.StatusFail {
+		t.Fatalf("checkRootUsage() = %+v, want a single FAIL finding", root)
+	}
+
+	later := now.AddDate(1, 0, 0)
+	root = s.checkRootUsage(entries, later)
+	if len(root) != 1 || root[0].Status != scanner.StatusPass {
+		t.Errorf("checkRootUsage() a year later = %+v, want PASS", root)
+	}
+}
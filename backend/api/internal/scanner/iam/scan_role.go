@@ -0,0 +1,238 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+)
+
+// defaultScanRoleName is the role created by the onboarding CloudFormation template.
+const defaultScanRoleName = "CloudCopSecurityScanRole"
+
+// scanRolePermissions is the documented read-only permission set for the scan
+// role, kept in sync with infra/cloudformation/guard-scan-role.yaml by
+// TestScanRolePermissions_MatchTemplate. Entries may use the same trailing
+// wildcards as the template, but never one that reaches object data, such as
+// s3:Get*, which would include s3:GetObject.
+var scanRolePermissions = []string{
+	"account:Get*",
+	"apigateway:GET",
+	"cloudfront:ListDistributions",
+	"cloudtrail:GetInsightSelectors", "cloudtrail:GetTrailStatus", "cloudtrail:DescribeTrails", "cloudtrail:GetEventSelectors",
+	"cloudtrail:ListEventDataStores", "cloudtrail:GetEventDataStore",
+	"dynamodb:ListTables", "dynamodb:DescribeTable", "dynamodb:DescribeContinuousBackups", "dynamodb:DescribeTimeToLive", "dynamodb:ListBackups", "dynamodb:ListTagsOfResource",
+	"ec2:DescribeInstances", "ec2:DescribeVolumes", "ec2:DescribeSecurityGroups", "ec2:DescribeAddresses",
+	"ec2:DescribeInstanceAttribute", "ec2:DescribeVolumesModifications", "ec2:DescribeInstanceStatus",
+	"ec2:DescribeNetworkInterfaces", "ec2:DescribeVpcs", "ec2:DescribeSubnets",
+	"ec2:DescribeVpcEndpoints", "ec2:DescribeLaunchTemplateVersions", "ec2:DescribeRouteTables",
+	"ec2:DescribeSnapshots", "ec2:DescribeSnapshotAttribute", "ec2:DescribeRegions",
+	"ecr:Describe*", "ecr:GetRepositoryPolicy", "ecr:ListImages",
+	"ecs:ListClusters", "ecs:DescribeTaskDefinition", "ecs:ListTaskDefinitions", "ecs:DescribeTasks",
+	"ecs:DescribeContainerInstances", "ecs:ListServices", "ecs:DescribeServices", "ecs:DescribeClusters", "ecs:ListTasks",
+	"eks:ListClusters", "eks:DescribeCluster", "eks:ListNodegroups", "eks:DescribeNodegroup",
+	"elasticloadbalancing:DescribeLoadBalancers", "elasticloadbalancing:DescribeListeners", "elasticloadbalancing:DescribeLoadBalancerAttributes",
+	"es:ListDomainNames", "es:DescribeDomain",
+	"glue:GetConnections", "glue:GetSecurityConfiguration*",
+	"iam:GetRole", "iam:List*", "iam:GetPolicy", "iam:GetPolicyVersion", "iam:GetRolePolicy", "iam:GetUserPolicy", "iam:GetAccountSummary",
+	"iam:GetAccessKeyLastUsed", "iam:GetLoginProfile", "iam:GetAccountPasswordPolicy", "iam:GetUser",
+	"iam:GenerateCredentialReport", "iam:GetCredentialReport",
+	"kms:ListKeys", "kms:DescribeKey", "kms:ListGrants",
+	"lambda:ListFunctions", "lambda:GetFunction*", "lambda:GetPolicy", "lambda:GetLayerVersion", "lambda:ListTags",
+	"logs:FilterLogEvents", "logs:DescribeLogGroups", "logs:DescribeLogStreams",
+	"macie2:GetMacieSession",
+	"s3:ListAllMyBuckets", "s3:GetBucketLocation", "s3:GetBucketAcl", "s3:GetBucketPolicy", "s3:GetBucketPolicyStatus",
+	"s3:GetBucketPublicAccessBlock", "s3:GetEncryptionConfiguration", "s3:GetBucketVersioning", "s3:GetBucketLogging",
+	"s3:GetLifecycleConfiguration", "s3:GetBucketObjectLockConfiguration", "s3:GetBucketTagging", "s3:GetBucketWebsite",
+	"s3:GetReplicationConfiguration", "s3:GetBucketNotification",
+	"securityhub:GetFindings", "securityhub:DescribeHub",
+	"ssm:GetDocument", "ssm-incidents:List*",
+	"tag:GetTagKeys", "tag:GetResources",
+	"wafv2:ListWebACLs", "wafv2:ListResourcesForWebACL",
+	"rds:Describe*", "rds:ListTagsForResource",
+}
+
+// policyDocument is the subset of an IAM policy document the checks read.
+type policyDocument struct {
+	Statement []struct {
+		Effect    string      `json:"Effect"`
+		Action    interface{} `json:"Action"`
+		NotAction interface{} `json:"NotAction"`
+		Resource  interface{} `json:"Resource"`
+	} `json:"Statement"`
+}
+
+// checkScanRolePermissions audits CloudCop's own scan role and flags any
+// allowed action outside the documented read-only permission set. Accounts
+// without the role, such as self-hosted installs, produce no finding.
+func (i *Scanner) checkScanRolePermissions(ctx context.Context) []scanner.Finding {
+	roleName := i.scanRole()
+
+	docs, err := i.scanRolePolicyDocuments(ctx, roleName)
+	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{i.accessDeniedFinding("iam_scan_role_least_privilege", roleName, err)}
+		}
+		var noSuchEntity *types.NoSuchEntityException
+		if !errors.As(err, &noSuchEntity) {
+			scanner.Logf(ctx, "Warning: failed to audit scan role %s: %v", roleName, err)
+		}
+		return nil
+	}
+
+	var excess []string
+	for _, doc := range docs {
+		for _, action := range excessActions(doc) {
+			if !slices.Contains(excess, action) {
+				excess = append(excess, action)
+			}
+		}
+	}
+	slices.Sort(excess)
+
+	if len(excess) > 0 {
+		return []scanner.Finding{i.createFinding(
+			"iam_scan_role_least_privilege",
+			roleName,
+			"CloudCop scan role has permissions beyond read-only scanning",
+			fmt.Sprintf("Role %s allows %s, which CloudCop does not need; remove these actions from the role", roleName, strings.Join(excess, ", ")),
+			scanner.StatusFail,
+			scanner.SeverityMedium,
+		)}
+	}
+	return []scanner.Finding{i.createFinding(
+		"iam_scan_role_least_privilege",
+		roleName,
+		"CloudCop scan role is limited to read-only permissions",
+		fmt.Sprintf("Role %s only allows actions from the documented scan permission set", roleName),
+		scanner.StatusPass,
+		scanner.SeverityMedium,
+	)}
+}
+
+// scanRolePolicyDocuments returns the decoded inline and attached managed
+// policy documents of the role.
+func (i *Scanner) scanRolePolicyDocuments(ctx context.Context, roleName string) ([]policyDocument, error) {
+	var docs []policyDocument
+
+	inline := iam.NewListRolePoliciesPaginator(i.client, &iam.ListRolePoliciesInput{RoleName: aws.String(roleName)})
+	for inline.HasMorePages() {
+		output, err := inline.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range output.PolicyNames {
+			policy, err := i.client.GetRolePolicy(ctx, &iam.GetRolePolicyInput{
+				RoleName:   aws.String(roleName),
+				PolicyName: aws.String(name),
+			})
+			if err != nil {
+				return nil, err
+			}
+			doc, err := decodePolicyDocument(aws.ToString(policy.PolicyDocument))
+			if err != nil {
+				return nil, fmt.Errorf("inline policy %s: %w", name, err)
+			}
+			docs = append(docs, doc)
+		}
+	}
+
+	attached := iam.NewListAttachedRolePoliciesPaginator(i.client, &iam.ListAttachedRolePoliciesInput{RoleName: aws.String(roleName)})
+	for attached.HasMorePages() {
+		output, err := attached.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, attachedPolicy := range output.AttachedPolicies {
+			policy, err := i.client.GetPolicy(ctx, &iam.GetPolicyInput{PolicyArn: attachedPolicy.PolicyArn})
+			if err != nil {
+				return nil, err
+			}
+			version, err := i.client.GetPolicyVersion(ctx, &iam.GetPolicyVersionInput{
+				PolicyArn: attachedPolicy.PolicyArn,
+				VersionId: policy.Policy.DefaultVersionId,
+			})
+			if err != nil {
+				return nil, err
+			}
+			doc, err := decodePolicyDocument(aws.ToString(version.PolicyVersion.Document))
+			if err != nil {
+				return nil, fmt.Errorf("managed policy %s: %w", aws.ToString(attachedPolicy.PolicyArn), err)
+			}
+			docs = append(docs, doc)
+		}
+	}
+
+	return docs, nil
+}
+
+// decodePolicyDocument parses a URL-encoded policy document as returned by IAM.
+func decodePolicyDocument(encoded string) (policyDocument, error) {
+	var doc policyDocument
+	raw, err := url.QueryUnescape(encoded)
+	if err != nil {
+		return doc, err
+	}
+	err = json.Unmarshal([]byte(raw), &doc)
+	return doc, err
+}
+
+// excessActions returns the allowed actions in doc that no documented scan
+// permission covers. An Allow statement with NotAction grants everything but
+// the listed actions, so it is always excess and is reported as written.
+func excessActions(doc policyDocument) []string {
+	var excess []string
+	for _, stmt := range doc.Statement {
+		if stmt.Effect != "Allow" {
+			continue
+		}
+		if stmt.NotAction != nil {
+			excess = append(excess, fmt.Sprintf("NotAction %q", policyActions(stmt.NotAction)))
+		}
+		for _, action := range policyActions(stmt.Action) {
+			if !scanPermissionCovers(action) {
+				excess = append(excess, action)
+			}
+		}
+	}
+	return excess
+}
+
+// scanPermissionCovers reports whether a granted action, which may itself be a
+// wildcard, is no broader than one of the documented scan permissions.
+func scanPermissionCovers(action string) bool {
+	action = strings.ToLower(action)
+	for _, permitted := range scanRolePermissions {
+		if ok, _ := path.Match(strings.ToLower(permitted), action); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// policyActions normalizes a statement's Action, which may be a string or a list.
+func policyActions(v interface{}) []string {
+	switch val := v.(type) {
+	case string:
+		return []string{val}
+	case []interface{}:
+		actions := make([]string, 0, len(val))
+		for _, item := range val {
+			if s, ok := item.(string); ok {
+				actions = append(actions, s)
+			}
+		}
+		return actions
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package iam
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// scanRoleTemplate is the onboarding template that creates the scan role.
+const scanRoleTemplate = "../../../../../infra/cloudformation/guard-scan-role.yaml"
+
+// templateActions returns the actions the template's scan role allows.
+func templateActions(t *testing.T) []string {
+	t.Helper()
+	raw, err := os.ReadFile(scanRoleTemplate)
+	if err != nil {
+		t.Fatalf("reading template: %v", err)
+	}
+
+	var template struct {
+		Resources struct {
+			CloudCopSecurityScanRole struct {
+				Properties struct {
+					Policies []struct {
+						PolicyDocument struct {
+							Statement []struct {
+								Effect string   `yaml:"Effect"`
+								Action []string `yaml:"Action"`
+							} `yaml:"Statement"`
+						} `yaml:"PolicyDocument"`
+					} `yaml:"Policies"`
+				} `yaml:"Properties"`
+			} `yaml:"CloudCopSecurityScanRole"`
+		} `yaml:"Resources"`
+	}
+	if err := yaml.Unmarshal(raw, &template); err != nil {
+		t.Fatalf("parsing template: %v", err)
+	}
+
+	var actions []string
+	for _, policy := range template.Resources.CloudCopSecurityScanRole.Properties.Policies {
+		for _, stmt := range policy.PolicyDocument.Statement {
+			if stmt.Effect == "Allow" {
+				actions = append(actions, stmt.Action...)
+			}
+		}
+	}
+	return actions
+}
+
+func TestScanRolePermissions_MatchTemplate(t *testing.T) {
+	got, want := templateActions(t), scanRolePermissions
+
+	if len(got) == 0 {
+		t.Fatal("template allows no actions")
+	}
+	for _, action := range got {
+		if !slices.Contains(want, action) {
+			t.Errorf("template allows %s, which scanRolePermissions does not list", action)
+		}
+	}
+	for _, action := range want {
+		if !slices.Contains(got, action) {
+			t.Errorf("scanRolePermissions lists %s, which the template does not allow", action)
+		}
+	}
+	if scanPermissionCovers("s3:GetObject") {
+		t.Error("the scan permissions allow s3:GetObject")
+	}
+}
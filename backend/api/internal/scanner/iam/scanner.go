@@ -4,30 +4,119 @@ package iam
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
+	"cloudcop/api/internal/awsauth"
 	"cloudcop/api/internal/scanner"
-	"cloudcop/api/internal/scanner/compliance"
+	"cloudcop/api/internal/scanner/checks"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/aws/aws-sdk-go-v2/service/iam/types"
 )
 
+// iamAPI is the subset of the IAM client used by the scanner.
+type iamAPI interface {
+	ListUsers(ctx context.Context, params *iam.ListUsersInput, optFns ...func(*iam.Options)) (*iam.ListUsersOutput, error)
+	ListAccessKeys(ctx context.Context, params *iam.ListAccessKeysInput, optFns ...func(*iam.Options)) (*iam.ListAccessKeysOutput, error)
+	GetAccessKeyLastUsed(ctx context.Context, params *iam.GetAccessKeyLastUsedInput, optFns ...func(*iam.Options)) (*iam.GetAccessKeyLastUsedOutput, error)
+	ListMFADevices(ctx context.Context, params *iam.ListMFADevicesInput, optFns ...func(*iam.Options)) (*iam.ListMFADevicesOutput, error)
+	GetAccountSummary(ctx context.Context, params *iam.GetAccountSummaryInput, optFns ...func(*iam.Options)) (*iam.GetAccountSummaryOutput, error)
+	GetAccountPasswordPolicy(ctx context.Context, params *iam.GetAccountPasswordPolicyInput, optFns ...func(*iam.Options)) (*iam.GetAccountPasswordPolicyOutput, error)
+	ListUserPolicies(ctx context.Context, params *iam.ListUserPoliciesInput, optFns ...func(*iam.Options)) (*iam.ListUserPoliciesOutput, error)
+	GetUserPolicy(ctx context.Context, params *iam.GetUserPolicyInput, optFns ...func(*iam.Options)) (*iam.GetUserPolicyOutput, error)
+	ListPolicies(ctx context.Context, params *iam.ListPoliciesInput, optFns ...func(*iam.Options)) (*iam.ListPoliciesOutput, error)
+	GetPolicyVersion(ctx context.Context, params *iam.GetPolicyVersionInput, optFns ...func(*iam.Options)) (*iam.GetPolicyVersionOutput, error)
+	ListRoles(ctx context.Context, params *iam.ListRolesInput, optFns ...func(*iam.Options)) (*iam.ListRolesOutput, error)
+	GetLoginProfile(ctx context.Context, params *iam.GetLoginProfileInput, optFns ...func(*iam.Options)) (*iam.GetLoginProfileOutput, error)
+	GenerateCredentialReport(ctx context.Context, params *iam.GenerateCredentialReportInput, optFns ...func(*iam.Options)) (*iam.GenerateCredentialReportOutput, error)
+	GetCredentialReport(ctx context.Context, params *iam.GetCredentialReportInput, optFns ...func(*iam.Options)) (*iam.GetCredentialReportOutput, error)
+	ListRolePolicies(ctx context.Context, params *iam.ListRolePoliciesInput, optFns ...func(*iam.Options)) (*iam.ListRolePoliciesOutput, error)
+	GetRolePolicy(ctx context.Context, params *iam.GetRolePolicyInput, optFns ...func(*iam.Options)) (*iam.GetRolePolicyOutput, error)
+	ListAttachedRolePolicies(ctx context.Context, params *iam.ListAttachedRolePoliciesInput, optFns ...func(*iam.Options)) (*iam.ListAttachedRolePoliciesOutput, error)
+	GetPolicy(ctx context.Context, params *iam.GetPolicyInput, optFns ...func(*iam.Options)) (*iam.GetPolicyOutput, error)
+}
+
 // Scanner performs security checks on IAM resources.
 type Scanner struct {
-	client    *iam.Client
+	client    iamAPI
 	region    string
 	accountID string
+
+	accessKeyMaxAgeDays     int
+	rotationMaxAgeDays      int
+	unusedKeyMaxAgeDays     int
+	scanRoleName            string
+	allowedExternalAccounts []string
+}
+
+// Option configures a Scanner.
+type Option func(*Scanner)
+
+// WithAccessKeyMaxAgeDays overrides how many days an access key may go unused
+// or unrotated before it is flagged. Non-positive values keep the default.
+// WithKeyRotationMaxAgeDays and WithUnusedKeyMaxAgeDays set each check apart.
+func WithAccessKeyMaxAgeDays(days int) Option {
+	return func(s *Scanner) {
+		s.accessKeyMaxAgeDays = days
+	}
+}
+
+// WithKeyRotationMaxAgeDays overrides how many days old an access key may be
+// before iam_access_key_rotation flags it, taking precedence over
+// WithAccessKeyMaxAgeDays. Non-positive values keep the shared threshold.
+func WithKeyRotationMaxAgeDays(days int) Option {
+	return func(s *Scanner) {
+		s.rotationMaxAgeDays = days
+	}
+}
+
+// WithUnusedKeyMaxAgeDays overrides how many days an access key may go unused
+// before iam_unused_access_keys flags it, taking precedence over
+// WithAccessKeyMaxAgeDays. Non-positive values keep the shared threshold.
+func WithUnusedKeyMaxAgeDays(days int) Option {
+	return func(s *Scanner) {
+		s.unusedKeyMaxAgeDays = days
+	}
+}
+
+// WithScanRoleName sets the name of CloudCop's own scan role audited for
+// excess permissions. Empty names keep the default CloudCopSecurityScanRole.
+func WithScanRoleName(name string) Option {
+	return func(s *Scanner) {
+		s.scanRoleName = name
+	}
+}
+
+// WithAllowedExternalAccounts lists AWS account IDs, such as trusted partners,
+// that roles may trust without being flagged. Wildcard principals are still
+// flagged.
+func WithAllowedExternalAccounts(accountIDs ...string) Option {
+	return func(s *Scanner) {
+		s.allowedExternalAccounts = accountIDs
+	}
 }
 
 // NewScanner creates a new IAM scanner for the given region and account ID.
-func NewScanner(cfg aws.Config, region, accountID string) scanner.ServiceScanner {
-	return &Scanner{
+func NewScanner(cfg aws.Config, region, accountID string, opts ...Option) scanner.ServiceScanner {
+	s := &Scanner{
 		client:    iam.NewFromConfig(cfg),
 		region:    region,
 		accountID: accountID,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// NewFactory returns a scanner.Factory that builds Scanners with opts applied.
+func NewFactory(opts ...Option) scanner.Factory {
+	return func(cfg aws.Config, region, accountID string) scanner.ServiceScanner {
+		return NewScanner(cfg, region, accountID, opts...)
+	}
 }
 
 // Service returns the AWS service name.
@@ -43,19 +132,35 @@ func (i *Scanner) Scan(ctx context.Context, _ string) ([]scanner.Finding, error)
 	if err != nil {
 		return nil, fmt.Errorf("listing users: %w", err)
 	}
+	scanner.CountResources(ctx, len(users))
 
-	for _, user := range users {
-		findings = append(findings, i.checkUnusedAccessKeys(ctx, user)...)
-		findings = append(findings, i.checkAccessKeyRotation(ctx, user)...)
-		findings = append(findings, i.checkUserMFA(ctx, user)...)
-		findings = append(findings, i.checkInlinePolicies(ctx, user)...)
-		findings = append(findings, i.checkConsoleWithoutMFA(ctx, user)...)
-	}
+	findings = append(findings, scanner.TraceChecks(ctx, "iam.users", func(ctx context.Context) []scanner.Finding {
+		var userFindings []scanner.Finding
+		for _, user := range users {
+			userFindings = append(userFindings, i.checkUnusedAccessKeys(ctx, user)...)
+			userFindings = append(userFindings, i.checkAccessKeyRotation(ctx, user)...)
+			// Access key findings carry the key's creation time; the
+			// remaining checks are about the user itself.
+			userChecks := slices.Concat(
+				i.checkUserMFA(ctx, user),
+				i.checkInlinePolicies(ctx, user),
+				i.checkConsoleWithoutMFA(ctx, user),
+			)
+			userFindings = append(userFindings, scanner.WithResourceCreatedAt(userChecks, user.CreateDate)...)
+		}
+		return userFindings
+	})...)
 
-	findings = append(findings, i.checkRootMFA(ctx)...)
-	findings = append(findings, i.checkPasswordPolicy(ctx)...)
-	findings = append(findings, i.checkOverlyPermissivePolicies(ctx)...)
-	findings = append(findings, i.checkCrossAccountTrust(ctx)...)
+	findings = append(findings, scanner.TraceChecks(ctx, "iam.account", func(ctx context.Context) []scanner.Finding {
+		var accountFindings []scanner.Finding
+		accountFindings = append(accountFindings, i.checkRootMFA(ctx)...)
+		accountFindings = append(accountFindings, i.checkPasswordPolicy(ctx)...)
+		accountFindings = append(accountFindings, i.checkOverlyPermissivePolicies(ctx)...)
+		accountFindings = append(accountFindings, i.checkCrossAccountTrust(ctx)...)
+		accountFindings = append(accountFindings, i.checkCredentialReport(ctx)...)
+		accountFindings = append(accountFindings, i.checkScanRolePermissions(ctx)...)
+		return accountFindings
+	})...)
 
 	return findings, nil
 }
@@ -76,15 +181,98 @@ func (i *Scanner) listUsers(ctx context.Context) ([]types.User, error) {
 
 func (i *Scanner) createFinding(checkID, resourceID, title, description string, status scanner.FindingStatus, severity scanner.Severity) scanner.Finding {
 	return scanner.Finding{
+		FindingID:   scanner.FindingID(i.accountID, i.Service(), "global", checkID, resourceID),
 		Service:     i.Service(),
 		Region:      "global",
 		ResourceID:  resourceID,
+		ARN:         i.resourceARN(checkID, resourceID),
 		CheckID:     checkID,
 		Status:      status,
 		Severity:    severity,
+		Confidence:  scanner.ConfidenceFor(checkID),
 		Title:       title,
 		Description: description,
-		Compliance:  compliance.GetCompliance(checkID),
+		Compliance:  checks.Compliance(checkID),
 		Timestamp:   time.Now(),
 	}
 }
+
+// roleChecks are the checks whose resource ID is a role name rather than a
+// user name.
+var roleChecks = map[string]bool{
+	"iam_cross_account_trust":       true,
+	"iam_scan_role_least_privilege": true,
+}
+
+// accessKeyChecks are the checks reported per access key. Access keys have no
+// ARN, so these findings carry the owning user's ARN, set by the check.
+var accessKeyChecks = map[string]bool{
+	"iam_unused_access_keys":  true,
+	"iam_access_key_rotation": true,
+}
+
+// resourceARN returns the ARN of the user, role, or policy a check reported
+// on. Account-wide findings use the root ARN. Users and roles are assumed to
+// have the default path, since only their name is known here.
+func (i *Scanner) resourceARN(checkID, resourceID string) string {
+	var resource string
+	switch {
+	case arn.IsARN(resourceID):
+		return resourceID
+	case accessKeyChecks[checkID]:
+		return ""
+	case resourceID == "root" || resourceID == "account":
+		resource = "root"
+	case roleChecks[checkID]:
+		resource = "role/" + resourceID
+	default:
+		resource = "user/" + resourceID
+	}
+	return arn.ARN{Partition: awsauth.PartitionForRegion(i.region), Service: "iam", AccountID: i.accountID, Resource: resource}.String()
+}
+
+// accessDeniedFinding records that checkID could not be evaluated for
+// resourceID because the scan role was denied the underlying API call.
+func (i *Scanner) accessDeniedFinding(checkID, resourceID string, err error) scanner.Finding {
+	return i.createFinding(
+		checkID,
+		resourceID,
+		"Insufficient permissions to evaluate check",
+		scanner.AccessDeniedDescription(err),
+		scanner.StatusError,
+		scanner.SeverityMedium,
+	)
+}
+
+// keyMaxAgeDays returns the configured access key age threshold.
+func (i *Scanner) keyMaxAgeDays() int {
+	if i.accessKeyMaxAgeDays > 0 {
+		return i.accessKeyMaxAgeDays
+	}
+	return accessKeyMaxAgeDays
+}
+
+// rotationMaxAge returns the age in days past which an access key is due
+// for rotation.
+func (i *Scanner) rotationMaxAge() int {
+	if i.rotationMaxAgeDays > 0 {
+		return i.rotationMaxAgeDays
+	}
+	return i.keyMaxAgeDays()
+}
+
+// unusedKeyMaxAge returns how many days an access key may go unused.
+func (i *Scanner) unusedKeyMaxAge() int {
+	if i.unusedKeyMaxAgeDays > 0 {
+		return i.unusedKeyMaxAgeDays
+	}
+	return i.keyMaxAgeDays()
+}
+
+// scanRole returns the name of the scan role to audit.
+func (i *Scanner) scanRole() string {
+	if i.scanRoleName != "" {
+		return i.scanRoleName
+	}
+	return defaultScanRoleName
+}
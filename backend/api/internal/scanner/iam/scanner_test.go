@@ -108,6 +108,9 @@ func TestScanner_createFinding(t *testing.T) {
 			if finding.CheckID != tt.checkID {
 				t.Errorf("CheckID = %v, want %v", finding.CheckID, tt.checkID)
 			}
+			if want := scanner.FindingID(s.accountID, finding.Service, finding.Region, tt.checkID, tt.resourceID); finding.FindingID != want {
+				t.Errorf("FindingID = %v, want %v", finding.FindingID, want)
+			}
 			if finding.Status != tt.status {
 				t.Errorf("Status = %v, want %v", finding.Status, tt.status)
 			}
@@ -126,3 +129,45 @@ func TestAccessKeyMaxAgeDays(t *testing.T) {
 		t.Errorf("accessKeyMaxAgeDays = %d, want 90", accessKeyMaxAgeDays)
 	}
 }
+
+func TestWithAccessKeyMaxAgeDays(t *testing.T) {
+	cfg := aws.Config{Region: "us-east-1"}
+
+	if got := NewScanner(cfg, "us-east-1", "123456789012").(*Scanner).keyMaxAgeDays(); got != accessKeyMaxAgeDays {
+		t.Errorf("default keyMaxAgeDays() = %d, want %d", got, accessKeyMaxAgeDays)
+	}
+	if got := NewScanner(cfg, "us-east-1", "123456789012", WithAccessKeyMaxAgeDays(30)).(*Scanner).keyMaxAgeDays(); got != 30 {
+		t.Errorf("keyMaxAgeDays() = %d, want 30", got)
+	}
+}
+
+func TestWithAllowedExternalAccounts(t *testing.T) {
+	s := NewScanner(aws.Config{Region: "us-east-1"}, "us-east-1", "123456789012",
+		WithAllowedExternalAccounts("111111111111", "222222222222")).(*Scanner)
+
+	if len(s.allowedExternalAccounts) != 2 || s.allowedExternalAccounts[0] != "111111111111" {
+		t.Errorf("allowedExternalAccounts = %v", s.allowedExternalAccounts)
+	}
+}
+
+func TestScanner_resourceARN(t *testing.T) {
+	s := &Scanner{region: "us-east-1", accountID: "123456789012"}
+
+	tests := []struct {
+		checkID    string
+		resourceID string
+		want       string
+	}{
+		{"iam_user_mfa", "alice", "arn:aws:iam::123456789012:user/alice"},
+		{"iam_cross_account_trust", "deployer", "arn:aws:iam::123456789012:role/deployer"},
+		{"iam_root_mfa", "root", "arn:aws:iam::123456789012:root"},
+		{"iam_password_policy", "account", "arn:aws:iam::123456789012:root"},
+		{"iam_overly_permissive", "arn:aws:iam::123456789012:policy/admin", "arn:aws:iam::123456789012:policy/admin"},
+		{"iam_access_key_rotation", "AKIAEXAMPLE", ""},
+	}
+	for _, tt := range tests {
+		if got := s.createFinding(tt.checkID, tt.resourceID, "", "", scanner.StatusPass, scanner.SeverityHigh).ARN; got != tt.want {
+			t.Errorf("%s on %s: ARN = %q, want %q", tt.checkID, tt.resourceID, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package scanner
+
+import (
+	"context"
+	"time"
+)
+
+// Incremental is implemented by scanners that can skip resources left
+// unmodified since an earlier scan.
+type Incremental interface {
+	// ScanSince runs the same checks as Scan but may skip resources last
+	// modified before since. It returns the IDs of the skipped resources so
+	// their findings from the earlier scan can be carried forward.
+	ScanSince(ctx context.Context, region string, since time.Time) (findings []Finding, skipped []string, err error)
+}
+
+// ModifiedBefore reports whether a resource last modified at modifiedAt can
+// be skipped by an incremental scan since since. Resources without a known
+// modification time are never skipped.
+func ModifiedBefore(modifiedAt *time.Time, since time.Time) bool {
+	return modifiedAt != nil && !since.IsZero() && modifiedAt.Before(since)
+}
+
+// scanIncremental runs s for task, incrementally when the task has a since
+// time and s implements Incremental. Prior findings of the resources it
+// skipped are appended, except for checks the scan evaluated anyway.
+func scanIncremental(ctx context.Context, s ServiceScanner, task ScanTask) ([]Finding, error) {
+	inc, ok := s.(Incremental)
+	if !ok || task.Since.IsZero() {
+		return s.Scan(ctx, task.Region)
+	}
+
+	findings, skipped, err := inc.ScanSince(ctx, task.Region, task.Since)
+	if err != nil {
+		return nil, err
+	}
+	return append(findings, carryForward(task.Prior, findings, skipped)...), nil
+}
+
+// carryForward returns the findings in prior about the skipped resources
+// whose finding IDs the new scan did not report.
+func carryForward(prior, current []Finding, skipped []string) []Finding {
+	if len(skipped) == 0 || len(prior) == 0 {
+		return nil
+	}
+	skippedSet := make(map[string]bool, len(skipped))
+	for _, id := range skipped {
+		skippedSet[id] = true
+	}
+	reported := make(map[string]bool, len(current))
+	for _, f := range current {
+		reported[f.FindingID] = true
+	}
+
+	var carried []Finding
+	for _, f := range prior {
+		if skippedSet[f.ResourceID] && !reported[f.FindingID] {
+			carried = append(carried, f)
+		}
+	}
+	return carried
+}
@@ -0,0 +1,142 @@
+package scanner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// incrementalScanner reports the since time it was given and skips the
+// resources in skipped.
+type incrementalScanner struct {
+	mockScanner
+	skipped []string
+	since   time.Time
+}
+
+func (s *incrementalScanner) ScanSince(_ context.Context, _ string, since time.Time) ([]Finding, []string, error) {
+	s.since = since
+	return s.findings, s.skipped, nil
+}
+
+func TestModifiedBefore(t *testing.T) {
+	since := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	older, newer := since.Add(-time.Hour), since.Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		modifiedAt *time.Time
+		since      time.Time
+		want       bool
+	}{
+		{"unmodified", &older, since, true},
+		{"modified", &newer, since, false},
+		{"unknown modification time", nil, since, false},
+		{"full scan", &older, time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ModifiedBefore(tt.modifiedAt, tt.since); got != tt.want {
+				t.Errorf("ModifiedBefore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoordinator_StartScan_Incremental(t *testing.T) {
+	since := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	finding := func(resource, check string, status FindingStatus) Finding {
+		return Finding{
+			FindingID:  FindingID("123456789012", "lambda", "us-east-1", check, resource),
+			Service:    "lambda",
+			Region:     "us-east-1",
+			ResourceID: resource,
+			CheckID:    check,
+			Status:     status,
+			Severity:   SeverityHigh,
+		}
+	}
+	inc := &incrementalScanner{
+		mockScanner: mockScanner{service: "lambda", findings: []Finding{
+			finding("changed", "lambda_timeout", StatusPass),
+			finding("stable", "lambda_public_url", StatusPass),
+		}},
+		skipped: []string{"stable"},
+	}
+
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+	coord.RegisterScanner("lambda", func(_ aws.Config, _, _ string) ServiceScanner { return inc })
+
+	prior := []Finding{
+		finding("stable", "lambda_timeout", StatusFail),
+		finding("stable", "lambda_public_url", StatusFail),
+		finding("changed", "lambda_timeout", StatusFail),
+		{Service: "lambda", Region: "eu-west-1", ResourceID: "stable", CheckID: "lambda_timeout", Status: StatusFail, Severity: SeverityHigh},
+	}
+	result, err := coord.StartScan(context.Background(), ScanConfig{
+		AccountID:        "123456789012",
+		Regions:          []string{"us-east-1"},
+		Services:         []string{"lambda"},
+		IncrementalSince: since,
+		PriorFindings:    prior,
+	})
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+
+	if !inc.since.Equal(since) {
+		t.Errorf("ScanSince() got since %v, want %v", inc.since, since)
+	}
+	got := make(map[string]FindingStatus)
+	for _, f := range result.Findings {
+		got[f.ResourceID+"/"+f.CheckID] = f.Status
+		if f.ScanID != result.ScanID {
+			t.Errorf("finding %s has scan ID %q, want %q", f.FindingID, f.ScanID, result.ScanID)
+		}
+	}
+	want := map[string]FindingStatus{
+		// Carried forward from the prior scan.
+		"stable/lambda_timeout": StatusFail,
+		// Reported again by the scan, replacing the prior finding.
+		"stable/lambda_public_url": StatusPass,
+		"changed/lambda_timeout":   StatusPass,
+	}
+	if len(got) != len(want) || len(result.Findings) != len(want) {
+		t.Fatalf("findings = %v, want %v", got, want)
+	}
+	for key, status := range want {
+		if got[key] != status {
+			t.Errorf("%s = %s, want %s", key, got[key], status)
+		}
+	}
+	if result.FailedChecks != 1 {
+		t.Errorf("FailedChecks = %d, want 1 for the carried-forward finding", result.FailedChecks)
+	}
+}
+
+func TestCoordinator_StartScan_FullScanIgnoresIncremental(t *testing.T) {
+	inc := &incrementalScanner{
+		mockScanner: mockScanner{service: "lambda", findings: []Finding{{CheckID: "lambda_timeout", Status: StatusPass, Severity: SeverityLow}}},
+		skipped:     []string{"stable"},
+	}
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+	coord.RegisterScanner("lambda", func(_ aws.Config, _, _ string) ServiceScanner { return inc })
+
+	result, err := coord.StartScan(context.Background(), ScanConfig{
+		AccountID:     "123456789012",
+		Regions:       []string{"us-east-1"},
+		Services:      []string{"lambda"},
+		PriorFindings: []Finding{{Service: "lambda", Region: "us-east-1", ResourceID: "stable", CheckID: "lambda_tracing", Status: StatusFail}},
+	})
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+	if !inc.since.IsZero() {
+		t.Error("ScanSince() called without IncrementalSince")
+	}
+	if len(result.Findings) != 1 {
+		t.Errorf("findings = %+v, want only the scanner's own finding", result.Findings)
+	}
+}
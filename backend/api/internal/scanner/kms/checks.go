@@ -0,0 +1,100 @@
+package kms
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+// keyMaterialOperations are the grant operations that let the grantee
+// decrypt data or create data keys under the key. A grant allowing them
+// without an encryption context constraint is usable on any ciphertext.
+var keyMaterialOperations = []types.GrantOperation{
+	types.GrantOperationDecrypt,
+	types.GrantOperationReEncryptFrom,
+	types.GrantOperationReEncryptTo,
+	types.GrantOperationGenerateDataKey,
+	types.GrantOperationGenerateDataKeyWithoutPlaintext,
+	types.GrantOperationGenerateDataKeyPair,
+	types.GrantOperationGenerateDataKeyPairWithoutPlaintext,
+}
+
+// checkKeyGrants flags grants that hand key usage to principals outside the
+// account, that let the grantee create further grants of its own, or that
+// allow key material operations on any encryption context. An error listing
+// the grants is returned, except for AccessDenied, which is reported as a
+// StatusError finding on the key.
+func (k *Scanner) checkKeyGrants(ctx context.Context, keyID string) ([]scanner.Finding, error) {
+	var findings []scanner.Finding
+	paginator := kms.NewListGrantsPaginator(k.client, &kms.ListGrantsInput{KeyId: aws.String(keyID)})
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			if scanner.IsAccessDenied(err) {
+				return []scanner.Finding{k.accessDeniedFinding("kms_broad_grant", keyID, err)}, nil
+			}
+			return nil, fmt.Errorf("listing grants of key %s: %w", keyID, err)
+		}
+		for _, grant := range output.Grants {
+			grantee := aws.ToString(grant.GranteePrincipal)
+
+			var issues []string
+			if k.isExternalPrincipal(grantee) {
+				issues = append(issues, fmt.Sprintf("grantee %s is outside account %s", grantee, k.accountID))
+			}
+			if slices.Contains(grant.Operations, types.GrantOperationCreateGrant) {
+				issues = append(issues, "grantee can create further grants")
+			}
+			if ops := unconstrainedKeyMaterialOperations(grant); len(ops) > 0 {
+				issues = append(issues, fmt.Sprintf("grantee can call %s without an encryption context constraint", strings.Join(ops, ", ")))
+			}
+			if len(issues) == 0 {
+				continue
+			}
+
+			findings = append(findings, k.createFinding(
+				"kms_broad_grant",
+				keyID,
+				"KMS key grant is overly permissive",
+				fmt.Sprintf("Grant %s on key %s: %s", aws.ToString(grant.GrantId), keyID, strings.Join(issues, "; ")),
+				scanner.StatusFail,
+				scanner.SeverityMedium,
+			))
+		}
+	}
+	return findings, nil
+}
+
+// unconstrainedKeyMaterialOperations returns the key material operations a
+// grant allows, or nil when the grant constrains the encryption context.
+func unconstrainedKeyMaterialOperations(grant types.GrantListEntry) []string {
+	if c := grant.Constraints; c != nil && (len(c.EncryptionContextEquals) > 0 || len(c.EncryptionContextSubset) > 0) {
+		return nil
+	}
+	var ops []string
+	for _, op := range grant.Operations {
+		if slices.Contains(keyMaterialOperations, op) {
+			ops = append(ops, string(op))
+		}
+	}
+	return ops
+}
+
+// isExternalPrincipal reports whether principal is an ARN belonging to another
+// AWS account. Service principals and unparseable values are not treated as external.
+func (k *Scanner) isExternalPrincipal(principal string) bool {
+	parsed, err := arn.Parse(principal)
+	if err != nil || parsed.AccountID == "" {
+		return false
+	}
+	return k.accountID != "" && parsed.AccountID != k.accountID
+}
@@ -0,0 +1,177 @@
+// Package kms provides KMS security scanning capabilities.
+package kms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloudcop/api/internal/awsauth"
+	"cloudcop/api/internal/scanner"
+	"cloudcop/api/internal/scanner/checks"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+// kmsAPI is the subset of the KMS client used by the scanner.
+type kmsAPI interface {
+	ListKeys(ctx context.Context, params *kms.ListKeysInput, optFns ...func(*kms.Options)) (*kms.ListKeysOutput, error)
+	DescribeKey(ctx context.Context, params *kms.DescribeKeyInput, optFns ...func(*kms.Options)) (*kms.DescribeKeyOutput, error)
+	ListGrants(ctx context.Context, params *kms.ListGrantsInput, optFns ...func(*kms.Options)) (*kms.ListGrantsOutput, error)
+}
+
+// Scanner performs security checks on KMS keys.
+type Scanner struct {
+	client    kmsAPI
+	region    string
+	accountID string
+}
+
+// Option configures a Scanner.
+type Option func(*Scanner)
+
+// NewScanner creates a new KMS scanner for the given region and account ID.
+func NewScanner(cfg aws.Config, region, accountID string, opts ...Option) scanner.ServiceScanner {
+	s := &Scanner{
+		client:    kms.NewFromConfig(cfg),
+		region:    region,
+		accountID: accountID,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// NewFactory returns a scanner.Factory that builds Scanners with opts applied.
+func NewFactory(opts ...Option) scanner.Factory {
+	return func(cfg aws.Config, region, accountID string) scanner.ServiceScanner {
+		return NewScanner(cfg, region, accountID, opts...)
+	}
+}
+
+// Service returns the AWS service name.
+func (k *Scanner) Service() string {
+	return "kms"
+}
+
+// Scan executes all KMS security checks against customer-managed keys.
+func (k *Scanner) Scan(ctx context.Context, _ string) ([]scanner.Finding, error) {
+	var findings []scanner.Finding
+
+	keys, denied, err := k.listCustomerKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing keys: %w", err)
+	}
+	scanner.CountResources(ctx, len(keys)+len(denied))
+	findings = append(findings, denied...)
+
+	var grantsErr error
+	findings = append(findings, scanner.TraceChecks(ctx, "kms.keys", func(ctx context.Context) []scanner.Finding {
+		var keyFindings []scanner.Finding
+		for _, keyID := range keys {
+			grantFindings, err := k.checkKeyGrants(ctx, keyID)
+			if err != nil {
+				grantsErr = err
+				return nil
+			}
+			keyFindings = append(keyFindings, grantFindings...)
+		}
+		return keyFindings
+	})...)
+	if grantsErr != nil {
+		return nil, grantsErr
+	}
+
+	return findings, nil
+}
+
+// listCustomerKeys returns the IDs of enabled customer-managed keys. AWS-managed
+// keys are skipped since their grants are controlled by the owning service.
+// Keys the scan role may not describe are returned as StatusError findings,
+// since whether they are customer-managed is unknown; keys deleted since they
+// were listed are skipped.
+func (k *Scanner) listCustomerKeys(ctx context.Context) ([]string, []scanner.Finding, error) {
+	var keys []string
+	var denied []scanner.Finding
+	paginator := kms.NewListKeysPaginator(k.client, &kms.ListKeysInput{})
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, entry := range output.Keys {
+			keyID := aws.ToString(entry.KeyId)
+			desc, err := k.client.DescribeKey(ctx, &kms.DescribeKeyInput{KeyId: entry.KeyId})
+			var notFound *types.NotFoundException
+			switch {
+			case scanner.IsAccessDenied(err):
+				denied = append(denied, k.accessDeniedFinding("kms_broad_grant", keyID, err))
+				continue
+			case errors.As(err, &notFound):
+				continue
+			case err != nil:
+				return nil, nil, fmt.Errorf("describing key %s: %w", keyID, err)
+			case desc.KeyMetadata == nil:
+				continue
+			}
+			meta := desc.KeyMetadata
+			if meta.KeyManager != types.KeyManagerTypeCustomer || meta.KeyState == types.KeyStatePendingDeletion {
+				continue
+			}
+			keys = append(keys, keyID)
+		}
+	}
+	return keys, denied, nil
+}
+
+func (k *Scanner) createFinding(checkID, resourceID, title, description string, status scanner.FindingStatus, severity scanner.Severity) scanner.Finding {
+	return scanner.Finding{
+		FindingID:   scanner.FindingID(k.accountID, k.Service(), k.region, checkID, resourceID),
+		Service:     k.Service(),
+		Region:      k.region,
+		ResourceID:  resourceID,
+		ARN:         k.resourceARN(resourceID),
+		CheckID:     checkID,
+		Status:      status,
+		Severity:    severity,
+		Confidence:  scanner.ConfidenceFor(checkID),
+		Title:       title,
+		Description: description,
+		Compliance:  checks.Compliance(checkID),
+		Timestamp:   time.Now(),
+	}
+}
+
+// accessDeniedFinding records that checkID could not be evaluated for
+// resourceID because the scan role was denied the underlying API call.
+func (k *Scanner) accessDeniedFinding(checkID, resourceID string, err error) scanner.Finding {
+	return k.createFinding(
+		checkID,
+		resourceID,
+		"Insufficient permissions to evaluate check",
+		scanner.AccessDeniedDescription(err),
+		scanner.StatusError,
+		scanner.SeverityMedium,
+	)
+}
+
+// resourceARN returns the ARN of the key with the given ID, or the ID itself
+// when it is already an ARN.
+func (k *Scanner) resourceARN(keyID string) string {
+	if arn.IsARN(keyID) {
+		return keyID
+	}
+	return arn.ARN{
+		Partition: awsauth.PartitionForRegion(k.region),
+		Service:   "kms",
+		Region:    k.region,
+		AccountID: k.accountID,
+		Resource:  "key/" + keyID,
+	}.String()
+}
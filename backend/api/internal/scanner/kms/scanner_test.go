@@ -0,0 +1,204 @@
+package kms
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/aws/smithy-go"
+)
+
+const testServiceName = "kms"
+
+// fakeKMSClient implements kmsAPI with canned responses.
+// Methods that are not overridden panic via the nil embedded interface.
+type fakeKMSClient struct {
+	kmsAPI
+	grantPages [][]types.GrantListEntry
+	grantsErr  error
+	keys       []string
+	// describeErrs holds the DescribeKey error of each key, by key ID.
+	describeErrs map[string]error
+}
+
+func (f *fakeKMSClient) ListKeys(context.Context, *kms.ListKeysInput, ...func(*kms.Options)) (*kms.ListKeysOutput, error) {
+	out := &kms.ListKeysOutput{}
+	for _, id := range f.keys {
+		out.Keys = append(out.Keys, types.KeyListEntry{KeyId: aws.String(id)})
+	}
+	return out, nil
+}
+
+func (f *fakeKMSClient) DescribeKey(_ context.Context, params *kms.DescribeKeyInput, _ ...func(*kms.Options)) (*kms.DescribeKeyOutput, error) {
+	if err := f.describeErrs[aws.ToString(params.KeyId)]; err != nil {
+		return nil, err
+	}
+	return &kms.DescribeKeyOutput{KeyMetadata: &types.KeyMetadata{
+		KeyId:      params.KeyId,
+		KeyManager: types.KeyManagerTypeCustomer,
+		KeyState:   types.KeyStateEnabled,
+	}}, nil
+}
+
+func (f *fakeKMSClient) ListGrants(_ context.Context, params *kms.ListGrantsInput, _ ...func(*kms.Options)) (*kms.ListGrantsOutput, error) {
+	if f.grantsErr != nil {
+		return nil, f.grantsErr
+	}
+	page := 0
+	if params.Marker != nil {
+		page = len(aws.ToString(params.Marker))
+	}
+	out := &kms.ListGrantsOutput{Grants: f.grantPages[page]}
+	if page+1 < len(f.grantPages) {
+		out.Truncated = true
+		out.NextMarker = aws.String(strings.Repeat("x", page+1))
+	}
+	return out, nil
+}
+
+func TestNewScanner(t *testing.T) {
+	s := NewScanner(aws.Config{Region: "us-east-1"}, "us-east-1", "123456789012")
+
+	ks, ok := s.(*Scanner)
+	if !ok {
+		t.Fatal("NewScanner did not return *Scanner type")
+	}
+	if ks.region != "us-east-1" || ks.accountID != "123456789012" {
+		t.Errorf("got region=%s accountID=%s", ks.region, ks.accountID)
+	}
+	if ks.client == nil {
+		t.Error("client not initialized")
+	}
+}
+
+func TestScanner_Service(t *testing.T) {
+	s := &Scanner{}
+
+	if got := s.Service(); got != testServiceName {
+		t.Errorf("Service() = %v, want %s", got, testServiceName)
+	}
+}
+
+func TestCheckKeyGrants(t *testing.T) {
+	client := &fakeKMSClient{
+		grantPages: [][]types.GrantListEntry{
+			{
+				{
+					GrantId:          aws.String("grant-internal"),
+					GranteePrincipal: aws.String("arn:aws:iam::123456789012:role/app"),
+					Operations:       []types.GrantOperation{types.GrantOperationEncrypt, types.GrantOperationDescribeKey},
+				},
+				{
+					GrantId:          aws.String("grant-service"),
+					GranteePrincipal: aws.String("dynamodb.us-east-1.amazonaws.com"),
+					Operations:       []types.GrantOperation{types.GrantOperationDecrypt, types.GrantOperationGenerateDataKey},
+					Constraints: &types.GrantConstraints{
+						EncryptionContextSubset: map[string]string{"aws:dynamodb:tableName": "orders"},
+					},
+				},
+			},
+			{
+				{
+					GrantId:          aws.String("grant-external"),
+					GranteePrincipal: aws.String("arn:aws:iam::999988887777:root"),
+					Operations:       []types.GrantOperation{types.GrantOperationEncrypt},
+				},
+				{
+					GrantId:          aws.String("grant-delegating"),
+					GranteePrincipal: aws.String("arn:aws:iam::123456789012:role/ops"),
+					Operations:       []types.GrantOperation{types.GrantOperationDescribeKey, types.GrantOperationCreateGrant},
+				},
+				{
+					GrantId:          aws.String("grant-unconstrained"),
+					GranteePrincipal: aws.String("arn:aws:iam::123456789012:role/batch"),
+					Operations:       []types.GrantOperation{types.GrantOperationReEncryptFrom, types.GrantOperationGenerateDataKeyWithoutPlaintext},
+				},
+			},
+		},
+	}
+	s := &Scanner{client: client, region: "us-east-1", accountID: "123456789012"}
+
+	findings, err := s.checkKeyGrants(context.Background(), "key-1")
+	if err != nil {
+		t.Fatalf("checkKeyGrants() error = %v", err)
+	}
+
+	if len(findings) != 3 {
+		t.Fatalf("expected 3 findings, got %d: %+v", len(findings), findings)
+	}
+	for _, f := range findings {
+		if f.CheckID != "kms_broad_grant" || f.Status != scanner.StatusFail || f.Severity != scanner.SeverityMedium {
+			t.Errorf("unexpected finding: %+v", f)
+		}
+		if f.ResourceID != "key-1" {
+			t.Errorf("ResourceID = %v, want key-1", f.ResourceID)
+		}
+	}
+	if !strings.Contains(findings[0].Description, "grant-external") || !strings.Contains(findings[0].Description, "999988887777") {
+		t.Errorf("first finding should describe the external grant, got %q", findings[0].Description)
+	}
+	if !strings.Contains(findings[1].Description, "grant-delegating") {
+		t.Errorf("second finding should describe the delegating grant, got %q", findings[1].Description)
+	}
+	if desc := findings[2].Description; !strings.Contains(desc, "grant-unconstrained") || !strings.Contains(desc, "ReEncryptFrom, GenerateDataKeyWithoutPlaintext") {
+		t.Errorf("third finding should describe the unconstrained grant's operations, got %q", desc)
+	}
+}
+
+func TestScan_ReportsKeyErrors(t *testing.T) {
+	denied := &smithy.GenericAPIError{Code: "AccessDeniedException"}
+	client := &fakeKMSClient{
+		keys: []string{"key-denied", "key-deleted", "key-1"},
+		describeErrs: map[string]error{
+			"key-denied":  denied,
+			"key-deleted": &types.NotFoundException{},
+		},
+		grantsErr: denied,
+	}
+	s := &Scanner{client: client, region: "us-east-1", accountID: "123456789012"}
+
+	findings, err := s.Scan(context.Background(), "us-east-1")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("got %d findings, want one per key that could not be checked: %+v", len(findings), findings)
+	}
+	for i, want := range []string{"key-denied", "key-1"} {
+		if f := findings[i]; f.ResourceID != want || f.Status != scanner.StatusError || f.CheckID != "kms_broad_grant" {
+			t.Errorf("finding %d = %+v, want a kms_broad_grant error on %s", i, f, want)
+		}
+	}
+
+	client.grantsErr = &smithy.GenericAPIError{Code: "ThrottlingException"}
+	if _, err := s.Scan(context.Background(), "us-east-1"); err == nil {
+		t.Error("Scan() with failing ListGrants returned no error")
+	}
+
+	client.describeErrs["key-1"] = &smithy.GenericAPIError{Code: "KMSInternalException"}
+	if _, err := s.Scan(context.Background(), "us-east-1"); err == nil {
+		t.Error("Scan() with failing DescribeKey returned no error")
+	}
+}
+
+func TestScanner_resourceARN(t *testing.T) {
+	s := &Scanner{region: "eu-west-1", accountID: "123456789012"}
+
+	tests := []struct {
+		keyID string
+		want  string
+	}{
+		{"1234abcd-12ab-34cd-56ef-1234567890ab", "arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"},
+		{"arn:aws:kms:eu-west-1:123456789012:key/abc", "arn:aws:kms:eu-west-1:123456789012:key/abc"},
+	}
+	for _, tt := range tests {
+		if got := s.resourceARN(tt.keyID); got != tt.want {
+			t.Errorf("resourceARN(%q) = %q, want %q", tt.keyID, got, tt.want)
+		}
+	}
+}
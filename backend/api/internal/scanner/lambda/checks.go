@@ -4,7 +4,6 @@ package lambda
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"cloudcop/api/internal/scanner"
 
@@ -26,14 +25,11 @@ func (l *Scanner) checkEnvSecrets(_ context.Context, fn types.FunctionConfigurat
 		return nil
 	}
 
+	matcher := l.envSecretMatcher()
 	var sensitiveVars []string
 	for key := range fn.Environment.Variables {
-		upperKey := strings.ToUpper(key)
-		for _, pattern := range sensitiveEnvVarPatterns {
-			if strings.Contains(upperKey, pattern) {
-				sensitiveVars = append(sensitiveVars, key)
-				break
-			}
+		if matcher.Matches(key) {
+			sensitiveVars = append(sensitiveVars, key)
 		}
 	}
 
@@ -142,12 +138,13 @@ func (l *Scanner) checkTracing(_ context.Context, fn types.FunctionConfiguration
 func (l *Scanner) checkTimeout(_ context.Context, fn types.FunctionConfiguration) []scanner.Finding {
 	fnName := aws.ToString(fn.FunctionName)
 	timeout := aws.ToInt32(fn.Timeout)
-	if timeout > maxRecommendedTimeoutSeconds {
+	limit := l.timeoutLimit()
+	if timeout > limit {
 		return []scanner.Finding{l.createFinding(
 			"lambda_timeout",
 			fnName,
 			"Lambda function timeout exceeds recommended limit",
-			fmt.Sprintf("Function %s has timeout of %d seconds (recommended: ≤%d)", fnName, timeout, maxRecommendedTimeoutSeconds),
+			fmt.Sprintf("Function %s has timeout of %d seconds (recommended: ≤%d)", fnName, timeout, limit),
 			scanner.StatusFail,
 			scanner.SeverityLow,
 		)}
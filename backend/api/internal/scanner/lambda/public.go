@@ -0,0 +1,189 @@
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// sourceConditionKeys are the condition keys that tie a "*" principal to a
+// specific caller, such as the S3 bucket or account allowed to invoke the
+// function. Condition keys are case-insensitive.
+var sourceConditionKeys = []string{
+	"aws:sourceaccount",
+	"aws:sourcearn",
+	"aws:sourceowner",
+	"aws:principalorgid",
+}
+
+// checkFunctionURL flags functions whose function URL accepts unsigned
+// requests, making the function callable by anyone on the internet.
+func (l *Scanner) checkFunctionURL(ctx context.Context, fn types.FunctionConfiguration) []scanner.Finding {
+	fnName := aws.ToString(fn.FunctionName)
+	urlConfig, err := l.client.GetFunctionUrlConfig(ctx, &lambda.GetFunctionUrlConfigInput{
+		FunctionName: fn.FunctionName,
+	})
+	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{l.accessDeniedFinding("lambda_public_url", fnName, err)}
+		}
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return []scanner.Finding{l.createFinding(
+				"lambda_public_url",
+				fnName,
+				"Lambda function has no function URL",
+				fmt.Sprintf("Function %s has no function URL configured", fnName),
+				scanner.StatusPass,
+				scanner.SeverityCritical,
+			)}
+		}
+		scanner.Logf(ctx, "Warning: failed to get function URL of %s: %v", fnName, err)
+		return nil
+	}
+
+	if urlConfig.AuthType == types.FunctionUrlAuthTypeNone {
+		return []scanner.Finding{l.createFinding(
+			"lambda_public_url",
+			fnName,
+			"Lambda function URL does not require authentication",
+			fmt.Sprintf("Function %s can be invoked by anyone at %s (auth type NONE)", fnName, aws.ToString(urlConfig.FunctionUrl)),
+			scanner.StatusFail,
+			scanner.SeverityCritical,
+		)}
+	}
+	return []scanner.Finding{l.createFinding(
+		"lambda_public_url",
+		fnName,
+		"Lambda function URL requires IAM authentication",
+		fmt.Sprintf("Function %s URL uses auth type %s", fnName, urlConfig.AuthType),
+		scanner.StatusPass,
+		scanner.SeverityCritical,
+	)}
+}
+
+// checkResourcePolicy flags functions whose resource-based policy lets any
+// principal invoke or manage them without restricting the source account.
+func (l *Scanner) checkResourcePolicy(ctx context.Context, fn types.FunctionConfiguration) []scanner.Finding {
+	fnName := aws.ToString(fn.FunctionName)
+	policy, err := l.client.GetPolicy(ctx, &lambda.GetPolicyInput{
+		FunctionName: fn.FunctionName,
+	})
+	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{l.accessDeniedFinding("lambda_public_permission", fnName, err)}
+		}
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return []scanner.Finding{l.createFinding(
+				"lambda_public_permission",
+				fnName,
+				"Lambda function has no resource-based policy",
+				fmt.Sprintf("Function %s has no resource-based policy", fnName),
+				scanner.StatusPass,
+				scanner.SeverityCritical,
+			)}
+		}
+		scanner.Logf(ctx, "Warning: failed to get policy of function %s: %v", fnName, err)
+		return nil
+	}
+
+	statements, err := publicPolicyStatements(aws.ToString(policy.Policy))
+	if err != nil {
+		scanner.Logf(ctx, "Warning: failed to parse policy of function %s: %v", fnName, err)
+		return nil
+	}
+	if len(statements) > 0 {
+		return []scanner.Finding{l.createFinding(
+			"lambda_public_permission",
+			fnName,
+			"Lambda function policy allows public access",
+			fmt.Sprintf("Function %s policy grants any principal access without a source condition in statements %v", fnName, statements),
+			scanner.StatusFail,
+			scanner.SeverityCritical,
+		)}
+	}
+	return []scanner.Finding{l.createFinding(
+		"lambda_public_permission",
+		fnName,
+		"Lambda function policy does not allow public access",
+		fmt.Sprintf("Function %s policy only grants access to specific principals", fnName),
+		scanner.StatusPass,
+		scanner.SeverityCritical,
+	)}
+}
+
+// publicPolicyStatements returns the Sid of each Allow statement in a
+// resource-based policy that grants the "*" principal access without a
+// source-account, source-ARN, or organization condition. Statements without
+// a Sid are reported by their index.
+func publicPolicyStatements(doc string) ([]string, error) {
+	var policy struct {
+		Statement []struct {
+			Sid       string                            `json:"Sid"`
+			Effect    string                            `json:"Effect"`
+			Principal interface{}                       `json:"Principal"`
+			Condition map[string]map[string]interface{} `json:"Condition"`
+		} `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(doc), &policy); err != nil {
+		return nil, fmt.Errorf("decoding policy: %w", err)
+	}
+
+	var public []string
+	for i, stmt := range policy.Statement {
+		if stmt.Effect != "Allow" || !isPublicPrincipal(stmt.Principal) || hasSourceCondition(stmt.Condition) {
+			continue
+		}
+		sid := stmt.Sid
+		if sid == "" {
+			sid = fmt.Sprintf("#%d", i)
+		}
+		public = append(public, sid)
+	}
+	return public, nil
+}
+
+// isPublicPrincipal reports whether principal is "*" or {"AWS": "*"}, in
+// either its string or list form.
+func isPublicPrincipal(principal interface{}) bool {
+	switch p := principal.(type) {
+	case string:
+		return p == "*"
+	case map[string]interface{}:
+		switch v := p["AWS"].(type) {
+		case string:
+			return v == "*"
+		case []interface{}:
+			for _, item := range v {
+				if s, ok := item.(string); ok && s == "*" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// hasSourceCondition reports whether any condition operator restricts one of
+// the sourceConditionKeys.
+func hasSourceCondition(condition map[string]map[string]interface{}) bool {
+	for _, keys := range condition {
+		for key := range keys {
+			for _, source := range sourceConditionKeys {
+				if strings.EqualFold(key, source) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
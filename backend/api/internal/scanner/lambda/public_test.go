@@ -0,0 +1,79 @@
+package lambda
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPublicPolicyStatements(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want []string
+	}{
+		{
+			name: "wildcard principal",
+			doc: `{"Statement":[{"Sid":"open","Effect":"Allow","Principal":"*",
+				"Action":"lambda:InvokeFunction","Resource":"arn:aws:lambda:us-east-1:123456789012:function:fn"}]}`,
+			want: []string{"open"},
+		},
+		{
+			name: "AWS wildcard in a list",
+			doc:  `{"Statement":[{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123456789012:root","*"]},"Action":"lambda:*"}]}`,
+			want: []string{"#0"},
+		},
+		{
+			name: "public function URL permission",
+			doc: `{"Statement":[{"Sid":"url","Effect":"Allow","Principal":"*","Action":"lambda:InvokeFunctionUrl",
+				"Condition":{"StringEquals":{"lambda:FunctionUrlAuthType":"NONE"}}}]}`,
+			want: []string{"url"},
+		},
+		{
+			name: "source account condition",
+			doc: `{"Statement":[{"Sid":"s3","Effect":"Allow","Principal":"*","Action":"lambda:InvokeFunction",
+				"Condition":{"StringEquals":{"AWS:SourceAccount":"123456789012"}}}]}`,
+		},
+		{
+			name: "source ARN condition",
+			doc: `{"Statement":[{"Sid":"sns","Effect":"Allow","Principal":"*","Action":"lambda:InvokeFunction",
+				"Condition":{"ArnLike":{"aws:SourceArn":"arn:aws:sns:us-east-1:123456789012:topic"}}}]}`,
+		},
+		{
+			name: "service principal",
+			doc:  `{"Statement":[{"Sid":"events","Effect":"Allow","Principal":{"Service":"events.amazonaws.com"},"Action":"lambda:InvokeFunction"}]}`,
+		},
+		{
+			name: "specific account",
+			doc:  `{"Statement":[{"Sid":"acct","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::210987654321:root"},"Action":"lambda:InvokeFunction"}]}`,
+		},
+		{
+			name: "deny statement",
+			doc:  `{"Statement":[{"Sid":"deny","Effect":"Deny","Principal":"*","Action":"lambda:*"}]}`,
+		},
+		{
+			name: "mixed statements",
+			doc: `{"Statement":[
+				{"Sid":"scoped","Effect":"Allow","Principal":"*","Action":"lambda:InvokeFunction","Condition":{"StringEquals":{"aws:PrincipalOrgID":"o-abc"}}},
+				{"Sid":"public","Effect":"Allow","Principal":{"AWS":"*"},"Action":"lambda:GetFunction"}]}`,
+			want: []string{"public"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := publicPolicyStatements(tt.doc)
+			if err != nil {
+				t.Fatalf("publicPolicyStatements() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("publicPolicyStatements() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublicPolicyStatements_Invalid(t *testing.T) {
+	if _, err := publicPolicyStatements("not json"); err == nil {
+		t.Error("publicPolicyStatements() error = nil, want a decoding error")
+	}
+}
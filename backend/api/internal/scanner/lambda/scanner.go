@@ -4,31 +4,89 @@ package lambda
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
+	"cloudcop/api/internal/awsauth"
 	"cloudcop/api/internal/scanner"
-	"cloudcop/api/internal/scanner/compliance"
+	"cloudcop/api/internal/scanner/checks"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
 	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
 )
 
+// lastModifiedLayout is the format of FunctionConfiguration.LastModified,
+// such as 2019-11-14T18:58:25.707+0000.
+const lastModifiedLayout = "2006-01-02T15:04:05.999-0700"
+
+// lambdaAPI is the subset of the Lambda client used by the scanner.
+type lambdaAPI interface {
+	ListFunctions(ctx context.Context, params *lambda.ListFunctionsInput, optFns ...func(*lambda.Options)) (*lambda.ListFunctionsOutput, error)
+	GetFunctionConcurrency(ctx context.Context, params *lambda.GetFunctionConcurrencyInput, optFns ...func(*lambda.Options)) (*lambda.GetFunctionConcurrencyOutput, error)
+	GetFunctionUrlConfig(ctx context.Context, params *lambda.GetFunctionUrlConfigInput, optFns ...func(*lambda.Options)) (*lambda.GetFunctionUrlConfigOutput, error)
+	GetPolicy(ctx context.Context, params *lambda.GetPolicyInput, optFns ...func(*lambda.Options)) (*lambda.GetPolicyOutput, error)
+	ListTags(ctx context.Context, params *lambda.ListTagsInput, optFns ...func(*lambda.Options)) (*lambda.ListTagsOutput, error)
+}
+
 // Scanner performs security checks on Lambda functions.
 type Scanner struct {
-	client    *lambda.Client
+	client    lambdaAPI
 	region    string
 	accountID string
+
+	extraEnvPatterns  []string
+	allowedEnvVars    []string
+	maxTimeoutSeconds int32
+}
+
+// Option configures a Scanner.
+type Option func(*Scanner)
+
+// WithSensitiveEnvPatterns adds substrings that mark an environment variable
+// name as sensitive, on top of the built-in patterns.
+func WithSensitiveEnvPatterns(patterns ...string) Option {
+	return func(s *Scanner) {
+		s.extraEnvPatterns = append(s.extraEnvPatterns, patterns...)
+	}
+}
+
+// WithAllowedEnvVars exempts exact environment variable names (e.g. PUBLIC_KEY)
+// from secret detection.
+func WithAllowedEnvVars(names ...string) Option {
+	return func(s *Scanner) {
+		s.allowedEnvVars = append(s.allowedEnvVars, names...)
+	}
+}
+
+// WithMaxTimeoutSeconds overrides the function timeout above which
+// lambda_timeout fails. Non-positive values keep the default.
+func WithMaxTimeoutSeconds(seconds int32) Option {
+	return func(s *Scanner) {
+		s.maxTimeoutSeconds = seconds
+	}
 }
 
 // NewScanner creates a new Lambda scanner configured for the given AWS configuration, region, and account ID.
 // The returned scanner.ServiceScanner uses an AWS Lambda client initialized from cfg and is associated with the specified region and account.
-func NewScanner(cfg aws.Config, region, accountID string) scanner.ServiceScanner {
-	return &Scanner{
+func NewScanner(cfg aws.Config, region, accountID string, opts ...Option) scanner.ServiceScanner {
+	s := &Scanner{
 		client:    lambda.NewFromConfig(cfg),
 		region:    region,
 		accountID: accountID,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// NewFactory returns a scanner.Factory that builds Scanners with opts applied.
+func NewFactory(opts ...Option) scanner.Factory {
+	return func(cfg aws.Config, region, accountID string) scanner.ServiceScanner {
+		return NewScanner(cfg, region, accountID, opts...)
+	}
 }
 
 // Service returns the AWS service name.
@@ -38,24 +96,91 @@ func (l *Scanner) Service() string {
 
 // Scan executes all Lambda security checks.
 func (l *Scanner) Scan(ctx context.Context, _ string) ([]scanner.Finding, error) {
+	findings, _, err := l.scan(ctx, time.Time{})
+	return findings, err
+}
+
+// ScanSince executes the Lambda security checks, skipping the configuration
+// checks of functions last modified before since. Reserved concurrency,
+// function URLs, and resource policies change without updating
+// LastModified, so those checks still run for every function.
+func (l *Scanner) ScanSince(ctx context.Context, _ string, since time.Time) ([]scanner.Finding, []string, error) {
+	return l.scan(ctx, since)
+}
+
+// scan runs the checks, skipping configuration checks of functions
+// unmodified since since unless it is zero, and returns the names of the
+// functions it skipped.
+func (l *Scanner) scan(ctx context.Context, since time.Time) ([]scanner.Finding, []string, error) {
 	var findings []scanner.Finding
 
 	functions, err := l.listFunctions(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("listing functions: %w", err)
+		return nil, nil, fmt.Errorf("listing functions: %w", err)
+	}
+	scanner.CountResources(ctx, len(functions))
+
+	var skipped []string
+	findings = append(findings, scanner.TraceChecks(ctx, "lambda.functions", func(ctx context.Context) []scanner.Finding {
+		var fnFindings []scanner.Finding
+		for _, fn := range functions {
+			if scanner.ModifiedBefore(lastModified(fn), since) {
+				skipped = append(skipped, aws.ToString(fn.FunctionName))
+			} else {
+				fnFindings = append(fnFindings, l.checkEnvSecrets(ctx, fn)...)
+				fnFindings = append(fnFindings, l.checkCloudWatchLogs(ctx, fn)...)
+				fnFindings = append(fnFindings, l.checkVPCConfig(ctx, fn)...)
+				fnFindings = append(fnFindings, l.checkDLQ(ctx, fn)...)
+				fnFindings = append(fnFindings, l.checkTracing(ctx, fn)...)
+				fnFindings = append(fnFindings, l.checkTimeout(ctx, fn)...)
+			}
+			fnFindings = append(fnFindings, l.checkReservedConcurrency(ctx, fn)...)
+		}
+		return fnFindings
+	})...)
+
+	findings = append(findings, scanner.TraceChecks(ctx, "lambda.exposure", func(ctx context.Context) []scanner.Finding {
+		var fnFindings []scanner.Finding
+		for _, fn := range functions {
+			fnFindings = append(fnFindings, l.checkFunctionURL(ctx, fn)...)
+			fnFindings = append(fnFindings, l.checkResourcePolicy(ctx, fn)...)
+		}
+		return fnFindings
+	})...)
+
+	if scanner.ScopeFromContext(ctx).IncludeTags {
+		l.attachTags(ctx, functions, findings)
 	}
+	return findings, skipped, nil
+}
 
+// attachTags sets each function's tags on its findings. ListTags costs a
+// call per function, so it only runs when the scan asks for tags.
+func (l *Scanner) attachTags(ctx context.Context, functions []types.FunctionConfiguration, findings []scanner.Finding) {
+	tags := make(map[string]map[string]string, len(functions))
 	for _, fn := range functions {
-		findings = append(findings, l.checkEnvSecrets(ctx, fn)...)
-		findings = append(findings, l.checkCloudWatchLogs(ctx, fn)...)
-		findings = append(findings, l.checkVPCConfig(ctx, fn)...)
-		findings = append(findings, l.checkDLQ(ctx, fn)...)
-		findings = append(findings, l.checkTracing(ctx, fn)...)
-		findings = append(findings, l.checkTimeout(ctx, fn)...)
-		findings = append(findings, l.checkReservedConcurrency(ctx, fn)...)
+		out, err := l.client.ListTags(ctx, &lambda.ListTagsInput{Resource: fn.FunctionArn})
+		if err != nil {
+			scanner.Logf(ctx, "Warning: failed to list tags of function %s: %v", aws.ToString(fn.FunctionName), err)
+			continue
+		}
+		tags[aws.ToString(fn.FunctionName)] = out.Tags
+	}
+	for i := range findings {
+		if fnTags := tags[findings[i].ResourceID]; len(fnTags) > 0 {
+			findings[i].Tags = fnTags
+		}
 	}
+}
 
-	return findings, nil
+// lastModified returns when fn's code or configuration last changed, or nil
+// if LastModified cannot be parsed.
+func lastModified(fn types.FunctionConfiguration) *time.Time {
+	t, err := time.Parse(lastModifiedLayout, aws.ToString(fn.LastModified))
+	if err != nil {
+		return nil
+	}
+	return &t
 }
 
 func (l *Scanner) listFunctions(ctx context.Context) ([]types.FunctionConfiguration, error) {
@@ -74,15 +199,57 @@ func (l *Scanner) listFunctions(ctx context.Context) ([]types.FunctionConfigurat
 
 func (l *Scanner) createFinding(checkID, resourceID, title, description string, status scanner.FindingStatus, severity scanner.Severity) scanner.Finding {
 	return scanner.Finding{
+		FindingID:   scanner.FindingID(l.accountID, l.Service(), l.region, checkID, resourceID),
 		Service:     l.Service(),
 		Region:      l.region,
 		ResourceID:  resourceID,
+		ARN:         l.resourceARN(resourceID),
 		CheckID:     checkID,
 		Status:      status,
 		Severity:    severity,
+		Confidence:  scanner.ConfidenceFor(checkID),
 		Title:       title,
 		Description: description,
-		Compliance:  compliance.GetCompliance(checkID),
+		Compliance:  checks.Compliance(checkID),
 		Timestamp:   time.Now(),
 	}
 }
+
+// resourceARN returns the unqualified ARN of the named function.
+func (l *Scanner) resourceARN(fnName string) string {
+	if arn.IsARN(fnName) {
+		return fnName
+	}
+	return arn.ARN{
+		Partition: awsauth.PartitionForRegion(l.region),
+		Service:   "lambda",
+		Region:    l.region,
+		AccountID: l.accountID,
+		Resource:  "function:" + fnName,
+	}.String()
+}
+
+func (l *Scanner) accessDeniedFinding(checkID, resourceID string, err error) scanner.Finding {
+	return l.createFinding(
+		checkID,
+		resourceID,
+		"Insufficient permissions to evaluate check",
+		scanner.AccessDeniedDescription(err),
+		scanner.StatusError,
+		scanner.SeverityMedium,
+	)
+}
+
+// envSecretMatcher returns the matcher for sensitive environment variable
+// names, combining the built-in patterns with any configured via options.
+func (l *Scanner) envSecretMatcher() scanner.SecretNameMatcher {
+	return scanner.NewSecretNameMatcher(slices.Concat(sensitiveEnvVarPatterns, l.extraEnvPatterns), l.allowedEnvVars)
+}
+
+// timeoutLimit returns the configured maximum recommended function timeout.
+func (l *Scanner) timeoutLimit() int32 {
+	if l.maxTimeoutSeconds > 0 {
+		return l.maxTimeoutSeconds
+	}
+	return maxRecommendedTimeoutSeconds
+}
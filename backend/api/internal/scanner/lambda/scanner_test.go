@@ -1,12 +1,17 @@
 package lambda
 
 import (
+	"context"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"cloudcop/api/internal/scanner"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
 )
 
 const testServiceName = "lambda"
@@ -108,6 +113,9 @@ func TestScanner_createFinding(t *testing.T) {
 			if finding.CheckID != tt.checkID {
 				t.Errorf("CheckID = %v, want %v", finding.CheckID, tt.checkID)
 			}
+			if want := scanner.FindingID(s.accountID, finding.Service, finding.Region, tt.checkID, tt.resourceID); finding.FindingID != want {
+				t.Errorf("FindingID = %v, want %v", finding.FindingID, want)
+			}
 			if finding.Status != tt.status {
 				t.Errorf("Status = %v, want %v", finding.Status, tt.status)
 			}
@@ -145,3 +153,199 @@ func TestSensitiveEnvVarPatterns(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckEnvSecrets_Confidence(t *testing.T) {
+	s := &Scanner{region: "us-east-1", accountID: "123456789012"}
+	fn := types.FunctionConfiguration{
+		FunctionName: aws.String("payments"),
+		Environment: &types.EnvironmentResponse{
+			Variables: map[string]string{"STRIPE_API_KEY": "sk_test_value"},
+		},
+	}
+
+	findings := s.checkEnvSecrets(context.Background(), fn)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(findings))
+	}
+	if strings.Contains(findings[0].Description, "sk_test_value") {
+		t.Errorf("Description = %q, includes the variable's value", findings[0].Description)
+	}
+	if findings[0].Confidence == scanner.ConfidenceHigh {
+		t.Errorf("lambda_env_secrets Confidence = %v, want non-HIGH for heuristic check", findings[0].Confidence)
+	}
+}
+
+func TestCheckEnvSecrets_Options(t *testing.T) {
+	cfg := aws.Config{Region: "us-east-1"}
+	fn := types.FunctionConfiguration{
+		FunctionName: aws.String("billing"),
+		Environment: &types.EnvironmentResponse{
+			Variables: map[string]string{
+				"PUBLIC_KEY": "ssh-ed25519 AAAA",
+				"ACME_DSN":   "postgres://...",
+				"LOG_LEVEL":  "debug",
+			},
+		},
+	}
+
+	defaults := NewScanner(cfg, "us-east-1", "123456789012").(*Scanner)
+	findings := defaults.checkEnvSecrets(context.Background(), fn)
+	if len(findings) != 1 || !strings.Contains(findings[0].Description, "PUBLIC_KEY") || strings.Contains(findings[0].Description, "ACME_DSN") {
+		t.Fatalf("default patterns: got %+v, want only PUBLIC_KEY flagged", findings)
+	}
+
+	custom := NewScanner(cfg, "us-east-1", "123456789012",
+		WithSensitiveEnvPatterns("ACME_"),
+		WithAllowedEnvVars("PUBLIC_KEY"),
+	).(*Scanner)
+	findings = custom.checkEnvSecrets(context.Background(), fn)
+	if len(findings) != 1 {
+		t.Fatalf("custom patterns: expected 1 finding, got %d", len(findings))
+	}
+	if !strings.Contains(findings[0].Description, "ACME_DSN") || strings.Contains(findings[0].Description, "PUBLIC_KEY") {
+		t.Errorf("custom patterns: description = %q, want ACME_DSN flagged and PUBLIC_KEY allowed", findings[0].Description)
+	}
+}
+
+func TestWithMaxTimeoutSeconds(t *testing.T) {
+	cfg := aws.Config{Region: "us-east-1"}
+	fn := types.FunctionConfiguration{FunctionName: aws.String("report"), Timeout: aws.Int32(120)}
+
+	defaults := NewScanner(cfg, "us-east-1", "123456789012").(*Scanner)
+	if got := defaults.checkTimeout(context.Background(), fn); got[0].Status != scanner.StatusPass {
+		t.Errorf("default threshold: Status = %v, want PASS", got[0].Status)
+	}
+
+	var factory scanner.Factory = NewFactory(WithMaxTimeoutSeconds(60))
+	strict := factory(cfg, "us-east-1", "123456789012").(*Scanner)
+	got := strict.checkTimeout(context.Background(), fn)
+	if got[0].Status != scanner.StatusFail {
+		t.Errorf("custom threshold: Status = %v, want FAIL", got[0].Status)
+	}
+	if !strings.Contains(got[0].Description, "≤60") {
+		t.Errorf("custom threshold: Description = %q, want limit of 60", got[0].Description)
+	}
+}
+
+func TestScanner_resourceARN(t *testing.T) {
+	s := &Scanner{region: "us-east-1", accountID: "123456789012"}
+
+	f := s.createFinding("lambda_tracing", "orders", "", "", scanner.StatusFail, scanner.SeverityLow)
+	if want := "arn:aws:lambda:us-east-1:123456789012:function:orders"; f.ARN != want {
+		t.Errorf("ARN = %q, want %q", f.ARN, want)
+	}
+	if f.ResourceID != "orders" {
+		t.Errorf("ResourceID = %q, want the function name", f.ResourceID)
+	}
+}
+
+// fakeLambdaClient lists fixed functions that have no function URL, policy,
+// or reserved concurrency.
+type fakeLambdaClient struct {
+	functions []types.FunctionConfiguration
+	tags      map[string]map[string]string // by function ARN
+	tagCalls  int
+}
+
+func (f *fakeLambdaClient) ListFunctions(context.Context, *lambda.ListFunctionsInput, ...func(*lambda.Options)) (*lambda.ListFunctionsOutput, error) {
+	return &lambda.ListFunctionsOutput{Functions: f.functions}, nil
+}
+
+func (f *fakeLambdaClient) GetFunctionConcurrency(context.Context, *lambda.GetFunctionConcurrencyInput, ...func(*lambda.Options)) (*lambda.GetFunctionConcurrencyOutput, error) {
+	return &lambda.GetFunctionConcurrencyOutput{}, nil
+}
+
+func (f *fakeLambdaClient) GetFunctionUrlConfig(context.Context, *lambda.GetFunctionUrlConfigInput, ...func(*lambda.Options)) (*lambda.GetFunctionUrlConfigOutput, error) {
+	return nil, &types.ResourceNotFoundException{}
+}
+
+func (f *fakeLambdaClient) GetPolicy(context.Context, *lambda.GetPolicyInput, ...func(*lambda.Options)) (*lambda.GetPolicyOutput, error) {
+	return nil, &types.ResourceNotFoundException{}
+}
+
+func (f *fakeLambdaClient) ListTags(_ context.Context, params *lambda.ListTagsInput, _ ...func(*lambda.Options)) (*lambda.ListTagsOutput, error) {
+	f.tagCalls++
+	return &lambda.ListTagsOutput{Tags: f.tags[aws.ToString(params.Resource)]}, nil
+}
+
+func TestScanner_ScanSince(t *testing.T) {
+	fn := func(name, lastModified string) types.FunctionConfiguration {
+		return types.FunctionConfiguration{FunctionName: aws.String(name), LastModified: aws.String(lastModified), Timeout: aws.Int32(30)}
+	}
+	s := &Scanner{
+		client: &fakeLambdaClient{functions: []types.FunctionConfiguration{
+			fn("stable", "2025-01-14T18:58:25.707+0000"),
+			fn("changed", "2026-10-01T09:00:00.000+0000"),
+			fn("unknown", ""),
+		}},
+		region:    "us-east-1",
+		accountID: "123456789012",
+	}
+	since := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	var inc scanner.Incremental = s
+	findings, skipped, err := inc.ScanSince(context.Background(), "us-east-1", since)
+	if err != nil {
+		t.Fatalf("ScanSince() error = %v", err)
+	}
+	if !slices.Equal(skipped, []string{"stable"}) {
+		t.Errorf("skipped = %v, want [stable]", skipped)
+	}
+
+	checked := make(map[string][]string)
+	for _, f := range findings {
+		checked[f.ResourceID] = append(checked[f.ResourceID], f.CheckID)
+	}
+	for _, name := range []string{"changed", "unknown"} {
+		if !slices.Contains(checked[name], "lambda_timeout") {
+			t.Errorf("%s: configuration checks did not run: %v", name, checked[name])
+		}
+	}
+	if slices.Contains(checked["stable"], "lambda_timeout") {
+		t.Errorf("stable: configuration checks ran for an unmodified function: %v", checked["stable"])
+	}
+	for _, id := range []string{"lambda_reserved_concurrency", "lambda_public_url", "lambda_public_permission"} {
+		if !slices.Contains(checked["stable"], id) {
+			t.Errorf("stable: %s did not run; it is not covered by LastModified", id)
+		}
+	}
+
+	full, err := s.Scan(context.Background(), "us-east-1")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(full) <= len(findings) {
+		t.Errorf("Scan() returned %d findings, want more than the %d of the incremental scan", len(full), len(findings))
+	}
+}
+
+func TestScanner_Scan_IncludeTags(t *testing.T) {
+	const fnARN = "arn:aws:lambda:us-east-1:123456789012:function:api"
+	for _, include := range []bool{true, false} {
+		client := &fakeLambdaClient{
+			functions: []types.FunctionConfiguration{{FunctionName: aws.String("api"), FunctionArn: aws.String(fnARN), Timeout: aws.Int32(30)}},
+			tags:      map[string]map[string]string{fnARN: {"owner": "platform"}},
+		}
+		s := &Scanner{client: client, region: "us-east-1", accountID: "123456789012"}
+		ctx := scanner.WithScope(context.Background(), scanner.Scope{IncludeTags: include})
+
+		findings, err := s.Scan(ctx, "us-east-1")
+		if err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+		if len(findings) == 0 {
+			t.Fatal("Scan() returned no findings")
+		}
+		for _, f := range findings {
+			if include && f.Tags["owner"] != "platform" {
+				t.Errorf("%s tags = %v, want owner=platform", f.CheckID, f.Tags)
+			}
+			if !include && f.Tags != nil {
+				t.Errorf("%s tags = %v without IncludeTags, want none", f.CheckID, f.Tags)
+			}
+		}
+		if !include && client.tagCalls != 0 {
+			t.Errorf("ListTags called %d times without IncludeTags", client.tagCalls)
+		}
+	}
+}
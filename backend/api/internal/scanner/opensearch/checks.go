@@ -0,0 +1,150 @@
+package opensearch
+
+import (
+	"context"
+	"fmt"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/opensearch/types"
+)
+
+// checkEncryptionAtRest verifies the domain encrypts its indices, logs, and
+// snapshots at rest.
+func (o *Scanner) checkEncryptionAtRest(domain *types.DomainStatus) []scanner.Finding {
+	name := aws.ToString(domain.DomainName)
+	if domain.EncryptionAtRestOptions != nil && aws.ToBool(domain.EncryptionAtRestOptions.Enabled) {
+		return []scanner.Finding{o.createFinding(
+			"opensearch_encryption_at_rest",
+			name,
+			"OpenSearch domain is encrypted at rest",
+			fmt.Sprintf("Domain %s encrypts data at rest", name),
+			scanner.StatusPass,
+			scanner.SeverityHigh,
+		)}
+	}
+	return []scanner.Finding{o.createFinding(
+		"opensearch_encryption_at_rest",
+		name,
+		"OpenSearch domain is not encrypted at rest",
+		fmt.Sprintf("Domain %s stores indices and snapshots unencrypted", name),
+		scanner.StatusFail,
+		scanner.SeverityHigh,
+	)}
+}
+
+// checkNodeToNodeEncryption verifies traffic between the domain's nodes is
+// encrypted with TLS.
+func (o *Scanner) checkNodeToNodeEncryption(domain *types.DomainStatus) []scanner.Finding {
+	name := aws.ToString(domain.DomainName)
+	if domain.NodeToNodeEncryptionOptions != nil && aws.ToBool(domain.NodeToNodeEncryptionOptions.Enabled) {
+		return []scanner.Finding{o.createFinding(
+			"opensearch_node_to_node_encryption",
+			name,
+			"OpenSearch domain encrypts node-to-node traffic",
+			fmt.Sprintf("Domain %s encrypts traffic between its nodes", name),
+			scanner.StatusPass,
+			scanner.SeverityMedium,
+		)}
+	}
+	return []scanner.Finding{o.createFinding(
+		"opensearch_node_to_node_encryption",
+		name,
+		"OpenSearch domain does not encrypt node-to-node traffic",
+		fmt.Sprintf("Domain %s sends traffic between its nodes in plaintext", name),
+		scanner.StatusFail,
+		scanner.SeverityMedium,
+	)}
+}
+
+// checkHTTPSEnforced verifies the domain endpoint rejects plain HTTP requests.
+func (o *Scanner) checkHTTPSEnforced(domain *types.DomainStatus) []scanner.Finding {
+	name := aws.ToString(domain.DomainName)
+	if domain.DomainEndpointOptions != nil && aws.ToBool(domain.DomainEndpointOptions.EnforceHTTPS) {
+		return []scanner.Finding{o.createFinding(
+			"opensearch_https_enforced",
+			name,
+			"OpenSearch domain requires HTTPS",
+			fmt.Sprintf("Domain %s rejects requests over plain HTTP", name),
+			scanner.StatusPass,
+			scanner.SeverityHigh,
+		)}
+	}
+	return []scanner.Finding{o.createFinding(
+		"opensearch_https_enforced",
+		name,
+		"OpenSearch domain accepts plain HTTP",
+		fmt.Sprintf("Domain %s does not enforce HTTPS, so requests and credentials can travel unencrypted", name),
+		scanner.StatusFail,
+		scanner.SeverityHigh,
+	)}
+}
+
+// checkVPCOnly fails domains with a public endpoint. Domains placed in a VPC
+// are only reachable through their VPC endpoint.
+func (o *Scanner) checkVPCOnly(domain *types.DomainStatus) []scanner.Finding {
+	name := aws.ToString(domain.DomainName)
+	if endpoint, public := publicEndpoint(domain); public {
+		return []scanner.Finding{o.createFinding(
+			"opensearch_vpc_only",
+			name,
+			"OpenSearch domain has a public endpoint",
+			fmt.Sprintf("Domain %s is reachable from the internet at %s instead of being placed in a VPC", name, endpoint),
+			scanner.StatusFail,
+			scanner.SeverityHigh,
+		)}
+	}
+	return []scanner.Finding{o.createFinding(
+		"opensearch_vpc_only",
+		name,
+		"OpenSearch domain is placed in a VPC",
+		fmt.Sprintf("Domain %s is only reachable from VPC %s", name, aws.ToString(domain.VPCOptions.VPCId)),
+		scanner.StatusPass,
+		scanner.SeverityHigh,
+	)}
+}
+
+// publicEndpoint reports whether domain is reachable from the internet and
+// returns its public endpoint. Domains without VPC options are public; the
+// endpoint may still be empty while such a domain is being created.
+func publicEndpoint(domain *types.DomainStatus) (string, bool) {
+	if domain.VPCOptions != nil && aws.ToString(domain.VPCOptions.VPCId) != "" {
+		return "", false
+	}
+	if endpoint := aws.ToString(domain.Endpoint); endpoint != "" {
+		return endpoint, true
+	}
+	return domain.Endpoints["dualstack"], true
+}
+
+// checkAccessPolicy fails public domains whose access policy lets anyone
+// call the domain. A domain in a VPC is not flagged for an open policy, since
+// only clients inside the VPC can reach it.
+func (o *Scanner) checkAccessPolicy(ctx context.Context, domain *types.DomainStatus) []scanner.Finding {
+	name := aws.ToString(domain.DomainName)
+	statements, err := publicPolicyStatements(aws.ToString(domain.AccessPolicies))
+	if err != nil {
+		scanner.Logf(ctx, "Warning: failed to parse access policy of OpenSearch domain %s: %v", name, err)
+		return nil
+	}
+
+	if _, public := publicEndpoint(domain); public && len(statements) > 0 {
+		return []scanner.Finding{o.createFinding(
+			"opensearch_access_policy_public",
+			name,
+			"OpenSearch domain access policy allows public access",
+			fmt.Sprintf("Domain %s has a public endpoint and its access policy grants any principal access without an IP or source condition in statements %v", name, statements),
+			scanner.StatusFail,
+			scanner.SeverityCritical,
+		)}
+	}
+	return []scanner.Finding{o.createFinding(
+		"opensearch_access_policy_public",
+		name,
+		"OpenSearch domain access policy does not allow public access",
+		fmt.Sprintf("Domain %s is not open to anonymous callers", name),
+		scanner.StatusPass,
+		scanner.SeverityCritical,
+	)}
+}
@@ -0,0 +1,90 @@
+package opensearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// restrictingConditionKeys are the condition keys that limit a "*" principal
+// to known callers. Domain access policies usually do this with an IP
+// allowlist. Condition keys are case-insensitive.
+var restrictingConditionKeys = []string{
+	"aws:sourceip",
+	"aws:sourcevpc",
+	"aws:sourcevpce",
+	"aws:principalorgid",
+	"aws:principalaccount",
+	"aws:sourceaccount",
+}
+
+// publicPolicyStatements returns the Sid of each Allow statement in a domain
+// access policy that grants the "*" principal access without an IP, VPC, or
+// organization condition. Statements without a Sid are reported by their
+// index. A domain without an access policy has no public statements.
+func publicPolicyStatements(doc string) ([]string, error) {
+	if strings.TrimSpace(doc) == "" {
+		return nil, nil
+	}
+
+	var policy struct {
+		Statement []struct {
+			Sid       string                            `json:"Sid"`
+			Effect    string                            `json:"Effect"`
+			Principal interface{}                       `json:"Principal"`
+			Condition map[string]map[string]interface{} `json:"Condition"`
+		} `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(doc), &policy); err != nil {
+		return nil, fmt.Errorf("decoding policy: %w", err)
+	}
+
+	var public []string
+	for i, stmt := range policy.Statement {
+		if stmt.Effect != "Allow" || !isPublicPrincipal(stmt.Principal) || hasRestrictingCondition(stmt.Condition) {
+			continue
+		}
+		sid := stmt.Sid
+		if sid == "" {
+			sid = fmt.Sprintf("#%d", i)
+		}
+		public = append(public, sid)
+	}
+	return public, nil
+}
+
+// isPublicPrincipal reports whether principal is "*" or {"AWS": "*"}, in
+// either its string or list form.
+func isPublicPrincipal(principal interface{}) bool {
+	switch p := principal.(type) {
+	case string:
+		return p == "*"
+	case map[string]interface{}:
+		switch v := p["AWS"].(type) {
+		case string:
+			return v == "*"
+		case []interface{}:
+			for _, item := range v {
+				if s, ok := item.(string); ok && s == "*" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// hasRestrictingCondition reports whether any condition operator restricts
+// one of the restrictingConditionKeys.
+func hasRestrictingCondition(condition map[string]map[string]interface{}) bool {
+	for _, keys := range condition {
+		for key := range keys {
+			for _, restricting := range restrictingConditionKeys {
+				if strings.EqualFold(key, restricting) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
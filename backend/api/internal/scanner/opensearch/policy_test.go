@@ -0,0 +1,80 @@
+package opensearch
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPublicPolicyStatements(t *testing.T) {
+	const resource = `"Resource":"arn:aws:es:us-east-1:123456789012:domain/logs/*"`
+
+	tests := []struct {
+		name string
+		doc  string
+		want []string
+	}{
+		{
+			name: "no policy",
+			doc:  "",
+		},
+		{
+			name: "wildcard principal",
+			doc:  `{"Statement":[{"Sid":"open","Effect":"Allow","Principal":"*","Action":"es:*",` + resource + `}]}`,
+			want: []string{"open"},
+		},
+		{
+			name: "AWS wildcard in a list",
+			doc:  `{"Statement":[{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123456789012:root","*"]},"Action":"es:ESHttpGet",` + resource + `}]}`,
+			want: []string{"#0"},
+		},
+		{
+			name: "IP allowlist",
+			doc: `{"Statement":[{"Sid":"office","Effect":"Allow","Principal":{"AWS":"*"},"Action":"es:*",` + resource + `,
+				"Condition":{"IpAddress":{"aws:SourceIp":["203.0.113.0/24"]}}}]}`,
+		},
+		{
+			name: "organization condition",
+			doc: `{"Statement":[{"Sid":"org","Effect":"Allow","Principal":"*","Action":"es:ESHttp*",` + resource + `,
+				"Condition":{"StringEquals":{"aws:PrincipalOrgID":"o-abc"}}}]}`,
+		},
+		{
+			name: "unrelated condition",
+			doc: `{"Statement":[{"Sid":"tls","Effect":"Allow","Principal":"*","Action":"es:*",` + resource + `,
+				"Condition":{"Bool":{"aws:SecureTransport":"true"}}}]}`,
+			want: []string{"tls"},
+		},
+		{
+			name: "specific role",
+			doc:  `{"Statement":[{"Sid":"app","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123456789012:role/app"},"Action":"es:*",` + resource + `}]}`,
+		},
+		{
+			name: "deny statement",
+			doc:  `{"Statement":[{"Sid":"deny","Effect":"Deny","Principal":"*","Action":"es:ESHttpDelete",` + resource + `}]}`,
+		},
+		{
+			name: "mixed statements",
+			doc: `{"Statement":[
+				{"Sid":"office","Effect":"Allow","Principal":"*","Action":"es:*","Condition":{"IpAddress":{"aws:SourceIp":"203.0.113.7"}}},
+				{"Sid":"read","Effect":"Allow","Principal":{"AWS":"*"},"Action":"es:ESHttpGet"}]}`,
+			want: []string{"read"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := publicPolicyStatements(tt.doc)
+			if err != nil {
+				t.Fatalf("publicPolicyStatements() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("publicPolicyStatements() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublicPolicyStatements_Invalid(t *testing.T) {
+	if _, err := publicPolicyStatements("not json"); err == nil {
+		t.Error("publicPolicyStatements() error = nil, want a decoding error")
+	}
+}
@@ -0,0 +1,150 @@
+// Package opensearch provides OpenSearch Service security scanning
+// capabilities, covering both OpenSearch and legacy Elasticsearch domains.
+package opensearch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloudcop/api/internal/awsauth"
+	"cloudcop/api/internal/scanner"
+	"cloudcop/api/internal/scanner/checks"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/opensearch"
+)
+
+// openSearchAPI is the subset of the OpenSearch Service client used by the scanner.
+type openSearchAPI interface {
+	ListDomainNames(ctx context.Context, params *opensearch.ListDomainNamesInput, optFns ...func(*opensearch.Options)) (*opensearch.ListDomainNamesOutput, error)
+	DescribeDomain(ctx context.Context, params *opensearch.DescribeDomainInput, optFns ...func(*opensearch.Options)) (*opensearch.DescribeDomainOutput, error)
+}
+
+// Scanner performs security checks on OpenSearch Service domains.
+type Scanner struct {
+	client    openSearchAPI
+	region    string
+	accountID string
+}
+
+// Option configures a Scanner.
+type Option func(*Scanner)
+
+// NewScanner creates a new OpenSearch scanner for the given region and account ID.
+func NewScanner(cfg aws.Config, region, accountID string, opts ...Option) scanner.ServiceScanner {
+	s := &Scanner{
+		client:    opensearch.NewFromConfig(cfg),
+		region:    region,
+		accountID: accountID,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// NewFactory returns a scanner.Factory that builds Scanners with opts applied.
+func NewFactory(opts ...Option) scanner.Factory {
+	return func(cfg aws.Config, region, accountID string) scanner.ServiceScanner {
+		return NewScanner(cfg, region, accountID, opts...)
+	}
+}
+
+// Service returns the AWS service name.
+func (o *Scanner) Service() string {
+	return "opensearch"
+}
+
+// Scan executes all OpenSearch security checks.
+func (o *Scanner) Scan(ctx context.Context, _ string) ([]scanner.Finding, error) {
+	domains, err := o.listDomains(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing domains: %w", err)
+	}
+	scanner.CountResources(ctx, len(domains))
+
+	return scanner.TraceChecks(ctx, "opensearch.domains", func(ctx context.Context) []scanner.Finding {
+		var findings []scanner.Finding
+		for _, name := range domains {
+			out, err := o.client.DescribeDomain(ctx, &opensearch.DescribeDomainInput{DomainName: aws.String(name)})
+			if err != nil {
+				if scanner.IsAccessDenied(err) {
+					findings = append(findings, o.accessDeniedFinding("opensearch_encryption_at_rest", name, err))
+					continue
+				}
+				scanner.Logf(ctx, "Warning: failed to describe OpenSearch domain %s: %v", name, err)
+				continue
+			}
+			domain := out.DomainStatus
+			if domain == nil || aws.ToBool(domain.Deleted) {
+				continue
+			}
+			findings = append(findings, o.checkEncryptionAtRest(domain)...)
+			findings = append(findings, o.checkNodeToNodeEncryption(domain)...)
+			findings = append(findings, o.checkHTTPSEnforced(domain)...)
+			findings = append(findings, o.checkVPCOnly(domain)...)
+			findings = append(findings, o.checkAccessPolicy(ctx, domain)...)
+		}
+		return findings
+	}), nil
+}
+
+// listDomains returns the names of the account's domains in the region.
+// ListDomainNames is not paginated.
+func (o *Scanner) listDomains(ctx context.Context) ([]string, error) {
+	out, err := o.client.ListDomainNames(ctx, &opensearch.ListDomainNamesInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(out.DomainNames))
+	for _, d := range out.DomainNames {
+		names = append(names, aws.ToString(d.DomainName))
+	}
+	return names, nil
+}
+
+func (o *Scanner) createFinding(checkID, resourceID, title, description string, status scanner.FindingStatus, severity scanner.Severity) scanner.Finding {
+	return scanner.Finding{
+		FindingID:   scanner.FindingID(o.accountID, o.Service(), o.region, checkID, resourceID),
+		Service:     o.Service(),
+		Region:      o.region,
+		ResourceID:  resourceID,
+		ARN:         o.resourceARN(resourceID),
+		CheckID:     checkID,
+		Status:      status,
+		Severity:    severity,
+		Confidence:  scanner.ConfidenceFor(checkID),
+		Title:       title,
+		Description: description,
+		Compliance:  checks.Compliance(checkID),
+		Timestamp:   time.Now(),
+	}
+}
+
+// resourceARN returns the ARN of the named domain. OpenSearch domains keep
+// the "es" service namespace from Elasticsearch.
+func (o *Scanner) resourceARN(domainName string) string {
+	return arn.ARN{
+		Partition: awsauth.PartitionForRegion(o.region),
+		Service:   "es",
+		Region:    o.region,
+		AccountID: o.accountID,
+		Resource:  "domain/" + domainName,
+	}.String()
+}
+
+// accessDeniedFinding records that checkID could not be evaluated for
+// resourceID because the scan role was denied the underlying API call.
+func (o *Scanner) accessDeniedFinding(checkID, resourceID string, err error) scanner.Finding {
+	return o.createFinding(
+		checkID,
+		resourceID,
+		"Insufficient permissions to evaluate check",
+		scanner.AccessDeniedDescription(err),
+		scanner.StatusError,
+		scanner.SeverityMedium,
+	)
+}
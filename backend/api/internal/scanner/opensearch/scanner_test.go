@@ -0,0 +1,214 @@
+package opensearch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/opensearch"
+	"github.com/aws/aws-sdk-go-v2/service/opensearch/types"
+	"github.com/aws/smithy-go"
+)
+
+const openPolicy = `{"Statement":[{"Sid":"open","Effect":"Allow","Principal":{"AWS":"*"},"Action":"es:*"}]}`
+
+// fakeOpenSearchClient serves DescribeDomain from a fixed set of domains.
+type fakeOpenSearchClient struct {
+	openSearchAPI
+	domains map[string]*types.DomainStatus
+	errs    map[string]error
+	listErr error
+}
+
+func (f *fakeOpenSearchClient) ListDomainNames(context.Context, *opensearch.ListDomainNamesInput, ...func(*opensearch.Options)) (*opensearch.ListDomainNamesOutput, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	out := &opensearch.ListDomainNamesOutput{}
+	for name := range f.domains {
+		out.DomainNames = append(out.DomainNames, types.DomainInfo{DomainName: aws.String(name)})
+	}
+	for name := range f.errs {
+		out.DomainNames = append(out.DomainNames, types.DomainInfo{DomainName: aws.String(name)})
+	}
+	return out, nil
+}
+
+func (f *fakeOpenSearchClient) DescribeDomain(_ context.Context, in *opensearch.DescribeDomainInput, _ ...func(*opensearch.Options)) (*opensearch.DescribeDomainOutput, error) {
+	name := aws.ToString(in.DomainName)
+	if err := f.errs[name]; err != nil {
+		return nil, err
+	}
+	return &opensearch.DescribeDomainOutput{DomainStatus: f.domains[name]}, nil
+}
+
+// hardenedDomain returns a domain that passes every check, placed in a VPC.
+func hardenedDomain(name string) *types.DomainStatus {
+	return &types.DomainStatus{
+		DomainName:                  aws.String(name),
+		EncryptionAtRestOptions:     &types.EncryptionAtRestOptions{Enabled: aws.Bool(true)},
+		NodeToNodeEncryptionOptions: &types.NodeToNodeEncryptionOptions{Enabled: aws.Bool(true)},
+		DomainEndpointOptions:       &types.DomainEndpointOptions{EnforceHTTPS: aws.Bool(true)},
+		VPCOptions:                  &types.VPCDerivedInfo{VPCId: aws.String("vpc-0abc")},
+		Endpoints:                   map[string]string{"vpc": "vpc-" + name + ".us-east-1.es.amazonaws.com"},
+		AccessPolicies:              aws.String(openPolicy),
+	}
+}
+
+func TestNewScanner(t *testing.T) {
+	s, ok := NewScanner(aws.Config{Region: "us-east-1"}, "us-east-1", "123456789012").(*Scanner)
+	if !ok {
+		t.Fatal("NewScanner did not return *Scanner type")
+	}
+	if s.client == nil {
+		t.Error("client not initialized")
+	}
+	if got := s.Service(); got != "opensearch" {
+		t.Errorf("Service() = %v, want opensearch", got)
+	}
+}
+
+func TestScanner_resourceARN(t *testing.T) {
+	s := &Scanner{region: "us-east-1", accountID: "123456789012"}
+	want := "arn:aws:es:us-east-1:123456789012:domain/logs"
+	if got := s.resourceARN("logs"); got != want {
+		t.Errorf("resourceARN() = %s, want %s", got, want)
+	}
+}
+
+func TestPublicEndpoint(t *testing.T) {
+	tests := []struct {
+		name         string
+		domain       *types.DomainStatus
+		wantEndpoint string
+		wantPublic   bool
+	}{
+		{
+			name:   "VPC domain",
+			domain: hardenedDomain("logs"),
+		},
+		{
+			name: "public endpoint",
+			domain: &types.DomainStatus{
+				DomainName: aws.String("logs"),
+				Endpoint:   aws.String("search-logs-abc.us-east-1.es.amazonaws.com"),
+			},
+			wantEndpoint: "search-logs-abc.us-east-1.es.amazonaws.com",
+			wantPublic:   true,
+		},
+		{
+			name: "dual-stack public endpoint",
+			domain: &types.DomainStatus{
+				DomainName: aws.String("logs"),
+				Endpoints:  map[string]string{"dualstack": "search-logs-abc.us-east-1.on.aws"},
+			},
+			wantEndpoint: "search-logs-abc.us-east-1.on.aws",
+			wantPublic:   true,
+		},
+		{
+			name: "empty VPC options",
+			domain: &types.DomainStatus{
+				DomainName: aws.String("logs"),
+				Endpoint:   aws.String("search-logs-abc.us-east-1.es.amazonaws.com"),
+				VPCOptions: &types.VPCDerivedInfo{},
+			},
+			wantEndpoint: "search-logs-abc.us-east-1.es.amazonaws.com",
+			wantPublic:   true,
+		},
+		{
+			name:       "public domain still provisioning",
+			domain:     &types.DomainStatus{DomainName: aws.String("logs")},
+			wantPublic: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint, public := publicEndpoint(tt.domain)
+			if endpoint != tt.wantEndpoint || public != tt.wantPublic {
+				t.Errorf("publicEndpoint() = (%q, %v), want (%q, %v)", endpoint, public, tt.wantEndpoint, tt.wantPublic)
+			}
+		})
+	}
+}
+
+func TestScanner_Scan(t *testing.T) {
+	publicOpen := &types.DomainStatus{
+		DomainName:     aws.String("public-open"),
+		Endpoint:       aws.String("search-public-open.us-east-1.es.amazonaws.com"),
+		AccessPolicies: aws.String(openPolicy),
+	}
+	publicRestricted := &types.DomainStatus{
+		DomainName: aws.String("public-restricted"),
+		Endpoint:   aws.String("search-public-restricted.us-east-1.es.amazonaws.com"),
+		AccessPolicies: aws.String(`{"Statement":[{"Effect":"Allow","Principal":"*","Action":"es:*",
+			"Condition":{"IpAddress":{"aws:SourceIp":"203.0.113.0/24"}}}]}`),
+	}
+	denied := &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "not authorized to perform es:DescribeDomain"}
+
+	client := &fakeOpenSearchClient{
+		domains: map[string]*types.DomainStatus{
+			"vpc":               hardenedDomain("vpc"),
+			"public-open":       publicOpen,
+			"public-restricted": publicRestricted,
+			"deleted":           {DomainName: aws.String("deleted"), Deleted: aws.Bool(true)},
+		},
+		errs: map[string]error{
+			"denied": denied,
+			"broken": errors.New("throttled"),
+		},
+	}
+	s := &Scanner{client: client, region: "us-east-1", accountID: "123456789012"}
+
+	findings, err := s.Scan(context.Background(), "us-east-1")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	got := make(map[string]scanner.FindingStatus)
+	for _, f := range findings {
+		got[f.ResourceID+"/"+f.CheckID] = f.Status
+	}
+	want := map[string]scanner.FindingStatus{
+		"vpc/opensearch_encryption_at_rest":                    scanner.StatusPass,
+		"vpc/opensearch_node_to_node_encryption":               scanner.StatusPass,
+		"vpc/opensearch_https_enforced":                        scanner.StatusPass,
+		"vpc/opensearch_vpc_only":                              scanner.StatusPass,
+		"vpc/opensearch_access_policy_public":                  scanner.StatusPass,
+		"public-open/opensearch_encryption_at_rest":            scanner.StatusFail,
+		"public-open/opensearch_node_to_node_encryption":       scanner.StatusFail,
+		"public-open/opensearch_https_enforced":                scanner.StatusFail,
+		"public-open/opensearch_vpc_only":                      scanner.StatusFail,
+		"public-open/opensearch_access_policy_public":          scanner.StatusFail,
+		"public-restricted/opensearch_encryption_at_rest":      scanner.StatusFail,
+		"public-restricted/opensearch_node_to_node_encryption": scanner.StatusFail,
+		"public-restricted/opensearch_https_enforced":          scanner.StatusFail,
+		"public-restricted/opensearch_vpc_only":                scanner.StatusFail,
+		"public-restricted/opensearch_access_policy_public":    scanner.StatusPass,
+		"denied/opensearch_encryption_at_rest":                 scanner.StatusError,
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %d findings, want %d: %v", len(got), len(want), got)
+	}
+	for key, status := range want {
+		if got[key] != status {
+			t.Errorf("%s = %q, want %q", key, got[key], status)
+		}
+	}
+
+	for _, f := range findings {
+		if f.ResourceID == "public-open" && f.CheckID == "opensearch_access_policy_public" && f.Severity != scanner.SeverityCritical {
+			t.Errorf("public access policy severity = %s, want %s", f.Severity, scanner.SeverityCritical)
+		}
+	}
+}
+
+func TestScanner_Scan_ListError(t *testing.T) {
+	s := &Scanner{client: &fakeOpenSearchClient{listErr: errors.New("boom")}, region: "us-east-1", accountID: "123456789012"}
+	if _, err := s.Scan(context.Background(), "us-east-1"); err == nil {
+		t.Error("Scan() error = nil, want the ListDomainNames error")
+	}
+}
@@ -0,0 +1,125 @@
+// Package policy loads check policies, YAML files in which an organization
+// selects the checks it runs and re-weights their severities without code
+// changes.
+//
+// A policy looks like:
+//
+//	enabled:        # optional; when set, only these checks are reported
+//	  - s3_bucket_encryption
+//	  - iam_root_mfa
+//	disabled:       # checks never reported
+//	  - ec2_detailed_monitoring
+//	severity:       # per-check severity overrides
+//	  iam_user_mfa: CRITICAL
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+
+	"cloudcop/api/internal/scanner"
+	"cloudcop/api/internal/scanner/checks"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Policy selects which checks a scan reports and at what severity.
+type Policy struct {
+	// Enabled lists the only checks to report. Empty enables every check.
+	Enabled []string `yaml:"enabled"`
+	// Disabled lists checks whose findings are never reported.
+	Disabled []string `yaml:"disabled"`
+	// Severity overrides the severity of findings by check ID.
+	Severity map[string]scanner.Severity `yaml:"severity"`
+}
+
+// Load reads and validates the policy file at path against the built-in checks.
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading policy: %w", err)
+	}
+	p, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("policy %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// Parse decodes and validates a YAML policy against the built-in checks.
+// Unknown keys are rejected so a misspelled section is not silently ignored.
+func Parse(data []byte) (*Policy, error) {
+	var p Policy
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&p); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing policy: %w", err)
+	}
+	if err := p.Validate(checks.Default); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Validate reports every check ID in the policy that registry does not know,
+// checks that are both enabled and disabled, and invalid severities.
+func (p *Policy) Validate(registry *checks.Registry) error {
+	var errs []error
+	unknown := func(section, id string) {
+		if _, ok := registry.Get(id); !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown check ID %q", section, id))
+		}
+	}
+
+	for _, id := range p.Enabled {
+		unknown("enabled", id)
+	}
+	for _, id := range p.Disabled {
+		unknown("disabled", id)
+		if slices.Contains(p.Enabled, id) {
+			errs = append(errs, fmt.Errorf("disabled: check %q is also enabled", id))
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(p.Severity)) {
+		unknown("severity", id)
+		if !validSeverity(p.Severity[id]) {
+			errs = append(errs, fmt.Errorf("severity: check %q has invalid severity %q; want LOW, MEDIUM, HIGH, or CRITICAL", id, p.Severity[id]))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Apply returns config with the policy's check selection and severity
+// overrides added. Overrides already set on config take precedence, so a
+// single scan can still deviate from the organization policy. A nil policy
+// returns config unchanged.
+func (p *Policy) Apply(config scanner.ScanConfig) scanner.ScanConfig {
+	if p == nil {
+		return config
+	}
+	if len(config.CheckIDs) == 0 {
+		config.CheckIDs = slices.Clone(p.Enabled)
+	}
+	config.DisabledChecks = append(slices.Clone(config.DisabledChecks), p.Disabled...)
+
+	if len(p.Severity) > 0 {
+		overrides := make(map[string]scanner.Severity, len(p.Severity)+len(config.SeverityOverrides))
+		maps.Copy(overrides, p.Severity)
+		maps.Copy(overrides, config.SeverityOverrides)
+		config.SeverityOverrides = overrides
+	}
+	return config
+}
+
+func validSeverity(s scanner.Severity) bool {
+	switch s {
+	case scanner.SeverityLow, scanner.SeverityMedium, scanner.SeverityHigh, scanner.SeverityCritical:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package policy
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"cloudcop/api/internal/scanner"
+)
+
+func TestLoad_Valid(t *testing.T) {
+	p, err := Load("testdata/valid.yaml")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if want := []string{"s3_bucket_encryption", "s3_bucket_versioning", "iam_root_mfa", "iam_user_mfa"}; !slices.Equal(p.Enabled, want) {
+		t.Errorf("Enabled = %v, want %v", p.Enabled, want)
+	}
+	if want := []string{"ec2_detailed_monitoring"}; !slices.Equal(p.Disabled, want) {
+		t.Errorf("Disabled = %v, want %v", p.Disabled, want)
+	}
+	want := map[string]scanner.Severity{"iam_user_mfa": scanner.SeverityCritical, "s3_bucket_versioning": scanner.SeverityLow}
+	if !maps.Equal(p.Severity, want) {
+		t.Errorf("Severity = %v, want %v", p.Severity, want)
+	}
+}
+
+func TestLoad_Empty(t *testing.T) {
+	p, err := Parse(nil)
+	if err != nil {
+		t.Fatalf("Parse() of an empty policy error = %v", err)
+	}
+	if len(p.Enabled) != 0 || len(p.Disabled) != 0 || len(p.Severity) != 0 {
+		t.Errorf("empty policy = %+v, want no settings", p)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		file string
+		want []string
+	}{
+		{"testdata/unknown_checks.yaml", []string{
+			`enabled: unknown check ID "s3_bucket_encrypton"`,
+			`disabled: unknown check ID "rds_public_access"`,
+			`severity: unknown check ID "lambda_runtime_eol"`,
+		}},
+		{"testdata/invalid_severity.yaml", []string{`severity: check "iam_user_mfa" has invalid severity "urgent"`}},
+		{"testdata/conflict.yaml", []string{`disabled: check "iam_root_mfa" is also enabled`}},
+		{"testdata/unknown_section.yaml", []string{"parsing policy", "field enable not found"}},
+		{"testdata/missing.yaml", []string{"reading policy"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			_, err := Load(tt.file)
+			if err == nil {
+				t.Fatal("Load() succeeded, want an error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Load() error = %q, want it to mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPolicy_Apply(t *testing.T) {
+	p := &Policy{
+		Enabled:  []string{"iam_root_mfa", "iam_user_mfa"},
+		Disabled: []string{"ec2_detailed_monitoring"},
+		Severity: map[string]scanner.Severity{"iam_user_mfa": scanner.SeverityCritical, "iam_root_mfa": scanner.SeverityHigh},
+	}
+
+	config := p.Apply(scanner.ScanConfig{
+		AccountID:         "123456789012",
+		DisabledChecks:    []string{"s3_lifecycle_policy"},
+		SeverityOverrides: map[string]scanner.Severity{"iam_root_mfa": scanner.SeverityLow},
+	})
+	if !slices.Equal(config.CheckIDs, p.Enabled) {
+		t.Errorf("CheckIDs = %v, want %v", config.CheckIDs, p.Enabled)
+	}
+	if want := []string{"s3_lifecycle_policy", "ec2_detailed_monitoring"}; !slices.Equal(config.DisabledChecks, want) {
+		t.Errorf("DisabledChecks = %v, want %v", config.DisabledChecks, want)
+	}
+	// The scan's own override wins over the policy.
+	want := map[string]scanner.Severity{"iam_user_mfa": scanner.SeverityCritical, "iam_root_mfa": scanner.SeverityLow}
+	if !maps.Equal(config.SeverityOverrides, want) {
+		t.Errorf("SeverityOverrides = %v, want %v", config.SeverityOverrides, want)
+	}
+
+	// An explicit check selection on the scan is kept.
+	if got := p.Apply(scanner.ScanConfig{CheckIDs: []string{"s3_bucket_encryption"}}).CheckIDs; !slices.Equal(got, []string{"s3_bucket_encryption"}) {
+		t.Errorf("CheckIDs = %v, want the scan's selection", got)
+	}
+
+	var none *Policy
+	if got := none.Apply(scanner.ScanConfig{AccountID: "123456789012"}); got.AccountID != "123456789012" || got.CheckIDs != nil {
+		t.Errorf("nil policy changed the config: %+v", got)
+	}
+}
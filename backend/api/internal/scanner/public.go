@@ -0,0 +1,69 @@
+package scanner
+
+import (
+	"slices"
+	"strings"
+)
+
+// PublicIntentionalTag is the resource tag that marks a resource as public on purpose.
+const PublicIntentionalTag = "PublicIntentional"
+
+// publicAccessChecks lists the checks that report a resource as publicly reachable.
+var publicAccessChecks = map[string]bool{
+	"s3_bucket_public_access":         true,
+	"s3_bucket_policy_public":         true,
+	"s3_bucket_policy_overbroad":      true,
+	"s3_block_public_access":          true,
+	"s3_static_website":               true,
+	"ec2_public_ip":                   true,
+	"ec2_public_subnet":               true,
+	"ec2_snapshot_public":             true,
+	"lambda_public_url":               true,
+	"lambda_public_permission":        true,
+	"eks_private_endpoint":            true,
+	"opensearch_vpc_only":             true,
+	"opensearch_access_policy_public": true,
+}
+
+// IsPublicAccessCheck reports whether checkID flags public exposure of a resource.
+func IsPublicAccessCheck(checkID string) bool {
+	return publicAccessChecks[checkID]
+}
+
+// PublicAllowList names resources that are expected to be public, such as
+// static website buckets or public datasets. Unlike a general suppression it
+// only affects public-access checks, and resources can opt in with a tag.
+type PublicAllowList struct {
+	// ResourceIDs lists resources accepted as public regardless of their tags.
+	ResourceIDs []string
+	// DisableTag stops the PublicIntentional=true tag from marking resources as accepted.
+	DisableTag bool
+}
+
+// Allows reports whether a resource with the given ID and tags is accepted as public.
+func (l PublicAllowList) Allows(resourceID string, tags map[string]string) bool {
+	if slices.Contains(l.ResourceIDs, resourceID) {
+		return true
+	}
+	return !l.DisableTag && strings.EqualFold(tags[PublicIntentionalTag], "true")
+}
+
+// UsesTags reports whether Allows depends on resource tags, so scanners can
+// skip fetching tags when only resource IDs matter.
+func (l PublicAllowList) UsesTags() bool {
+	return !l.DisableTag
+}
+
+// AcceptPublic downgrades failed public-access findings to low severity and
+// notes that the exposure is intentional. Other findings are returned unchanged.
+func AcceptPublic(findings []Finding) []Finding {
+	for i, f := range findings {
+		if f.Status != StatusFail || !IsPublicAccessCheck(f.CheckID) {
+			continue
+		}
+		findings[i].Severity = SeverityLow
+		findings[i].Title = f.Title + " (accepted public resource)"
+		findings[i].Description = f.Description + ". The resource is on the account's public allow-list."
+	}
+	return findings
+}
@@ -0,0 +1,47 @@
+package scanner
+
+import "regexp"
+
+// redactedValue replaces each credential found by redact.
+const redactedValue = "[REDACTED]"
+
+// credentialPatterns match values that look like credentials. Checks only
+// ever report the names of suspicious settings, never their values, but a
+// value can still reach a description through a resource name or an error
+// message returned by AWS.
+var credentialPatterns = []*regexp.Regexp{
+	// AWS access key IDs, long-term (AKIA) and temporary (ASIA).
+	regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+	// AWS secret access keys when labeled as such.
+	regexp.MustCompile(`(?i)(aws_?secret_?(?:access_?)?key\s*[:=]\s*)[0-9A-Za-z/+]{40}\b`),
+	// GitHub, Slack, and Stripe tokens.
+	regexp.MustCompile(`\bgh[pousr]_[0-9A-Za-z]{36,}\b`),
+	regexp.MustCompile(`\bxox[abpors]-[0-9A-Za-z-]{10,}\b`),
+	regexp.MustCompile(`\b[sr]k_live_[0-9A-Za-z]{16,}\b`),
+	// JSON Web Tokens.
+	regexp.MustCompile(`\beyJ[0-9A-Za-z_-]{10,}\.eyJ[0-9A-Za-z_-]{10,}\.[0-9A-Za-z_-]+`),
+	// PEM private keys.
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+}
+
+// redact returns s with anything resembling a key or token masked. A
+// labeled secret keeps its label so the masked text still reads naturally.
+func redact(s string) string {
+	for _, re := range credentialPatterns {
+		if re.NumSubexp() > 0 {
+			s = re.ReplaceAllString(s, "${1}"+redactedValue)
+			continue
+		}
+		s = re.ReplaceAllLiteralString(s, redactedValue)
+	}
+	return s
+}
+
+// redactFindings masks credentials in the free-text fields of findings before
+// they are returned to callers, stored, or logged.
+func redactFindings(findings []Finding) {
+	for i := range findings {
+		findings[i].Title = redact(findings[i].Title)
+		findings[i].Description = redact(findings[i].Description)
+	}
+}
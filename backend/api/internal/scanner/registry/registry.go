@@ -0,0 +1,63 @@
+// Package registry lists the built-in service scanners so every entrypoint
+// registers the same set.
+package registry
+
+import (
+	"slices"
+
+	"cloudcop/api/internal/scanner"
+	"cloudcop/api/internal/scanner/cloudtrail"
+	"cloudcop/api/internal/scanner/dynamodb"
+	"cloudcop/api/internal/scanner/ec2"
+	"cloudcop/api/internal/scanner/ecs"
+	"cloudcop/api/internal/scanner/eks"
+	"cloudcop/api/internal/scanner/elb"
+	"cloudcop/api/internal/scanner/iam"
+	"cloudcop/api/internal/scanner/kms"
+	"cloudcop/api/internal/scanner/lambda"
+	"cloudcop/api/internal/scanner/opensearch"
+	"cloudcop/api/internal/scanner/s3"
+	"cloudcop/api/internal/scanner/waf"
+)
+
+// Registrar accepts scanner factories. It is satisfied by *scanner.Coordinator
+// and *security.Service.
+type Registrar interface {
+	RegisterScanner(service string, factory scanner.Factory)
+}
+
+// Factories returns a factory with default options for every built-in
+// scanner, keyed by service name.
+func Factories() map[string]scanner.Factory {
+	return map[string]scanner.Factory{
+		"cloudtrail": cloudtrail.NewFactory(),
+		"dynamodb":   dynamodb.NewFactory(),
+		"ec2":        ec2.NewFactory(),
+		"ecs":        ecs.NewFactory(),
+		"eks":        eks.NewFactory(),
+		"elb":        elb.NewFactory(),
+		"iam":        iam.NewFactory(),
+		"kms":        kms.NewFactory(),
+		"lambda":     lambda.NewFactory(),
+		"opensearch": opensearch.NewFactory(),
+		"s3":         s3.NewFactory(),
+		"waf":        waf.NewFactory(),
+	}
+}
+
+// Services returns the names of the built-in scanners in sorted order.
+func Services() []string {
+	services := make([]string, 0, len(Factories()))
+	for service := range Factories() {
+		services = append(services, service)
+	}
+	slices.Sort(services)
+	return services
+}
+
+// RegisterAll registers every built-in scanner with r.
+func RegisterAll(r Registrar) {
+	for service, factory := range Factories() {
+		r.RegisterScanner(service, factory)
+	}
+}
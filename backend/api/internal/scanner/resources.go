@@ -0,0 +1,47 @@
+package scanner
+
+import (
+	"context"
+	"sync"
+)
+
+// resourceCounter tallies the resources a scan task enumerated, so coverage
+// can tell a region with nothing to check from one whose checks reported
+// nothing.
+type resourceCounter struct {
+	mu      sync.Mutex
+	n       int
+	counted bool
+}
+
+func (c *resourceCounter) add(n int) {
+	c.mu.Lock()
+	c.n += n
+	c.counted = true
+	c.mu.Unlock()
+}
+
+// total returns the resources counted so far and whether any were reported.
+func (c *resourceCounter) total() (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.n, c.counted
+}
+
+type resourceCounterKey struct{}
+
+// withResourceCounter returns a copy of ctx whose scanner reports the
+// resources it enumerates to counter.
+func withResourceCounter(ctx context.Context, counter *resourceCounter) context.Context {
+	return context.WithValue(ctx, resourceCounterKey{}, counter)
+}
+
+// CountResources records that the scanner running under ctx enumerated n
+// resources. Scanners call it after each successful list call, including
+// with n == 0, so the scan is only reported as empty when the listing
+// actually came back empty. It does nothing outside a coordinator scan.
+func CountResources(ctx context.Context, n int) {
+	if counter, ok := ctx.Value(resourceCounterKey{}).(*resourceCounter); ok {
+		counter.add(n)
+	}
+}
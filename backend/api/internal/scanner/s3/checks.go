@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"cloudcop/api/internal/scanner"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
 )
 
@@ -21,23 +23,48 @@ func (s *Scanner) checkPublicAccess(ctx context.Context, bucketName string) []sc
 		Bucket: aws.String(bucketName),
 	})
 	if err != nil {
+		// The ACL is the first per-bucket call, so it is where a bucket
+		// deleted since its region was cached shows up.
+		s.forgetBucketIfGone(bucketName, err)
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{s.accessDeniedFinding("s3_bucket_public_access", bucketName, err)}
+		}
 		return nil
 	}
 
-	for _, grant := range acl.Grants {
-		if grant.Grantee != nil && grant.Grantee.URI != nil {
-			uri := aws.ToString(grant.Grantee.URI)
-			if strings.Contains(uri, "AllUsers") || strings.Contains(uri, "AuthenticatedUsers") {
-				return []scanner.Finding{s.createFinding(
-					"s3_bucket_public_access",
-					bucketName,
-					"S3 bucket has public access via ACL",
-					fmt.Sprintf("Bucket %s grants access to %s via ACL", bucketName, uri),
-					scanner.StatusFail,
-					scanner.SeverityCritical,
-				)}
-			}
-		}
+	everyone := publicGrantPermissions(acl.Grants, allUsersGroup)
+	anyAccount := publicGrantPermissions(acl.Grants, authenticatedUsersGroup)
+
+	// AllUsers exposes the bucket to anonymous requests. AuthenticatedUsers
+	// only requires a signed request, but from any AWS account, so it is
+	// nearly as broad and reported a step lower.
+	var grants []string
+	if len(everyone) > 0 {
+		grants = append(grants, fmt.Sprintf("%s to everyone (AllUsers)", strings.Join(everyone, ", ")))
+	}
+	if len(anyAccount) > 0 {
+		grants = append(grants, fmt.Sprintf("%s to any AWS account (AuthenticatedUsers)", strings.Join(anyAccount, ", ")))
+	}
+	description := fmt.Sprintf("Bucket %s grants %s via ACL", bucketName, strings.Join(grants, " and "))
+	switch {
+	case len(everyone) > 0:
+		return []scanner.Finding{s.createFinding(
+			"s3_bucket_public_access",
+			bucketName,
+			"S3 bucket has public access via ACL",
+			description,
+			scanner.StatusFail,
+			scanner.SeverityCritical,
+		)}
+	case len(anyAccount) > 0:
+		return []scanner.Finding{s.createFinding(
+			"s3_bucket_public_access",
+			bucketName,
+			"S3 bucket grants access to any AWS account via ACL",
+			description,
+			scanner.StatusFail,
+			scanner.SeverityHigh,
+		)}
 	}
 
 	return []scanner.Finding{s.createFinding(
@@ -50,11 +77,33 @@ func (s *Scanner) checkPublicAccess(ctx context.Context, bucketName string) []sc
 	)}
 }
 
+const (
+	allUsersGroup           = "http://acs.amazonaws.com/groups/global/AllUsers"
+	authenticatedUsersGroup = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+)
+
+// publicGrantPermissions returns the sorted, distinct permissions (READ,
+// WRITE, FULL_CONTROL, ...) that grants give to the group with the given URI.
+func publicGrantPermissions(grants []types.Grant, group string) []string {
+	var perms []string
+	for _, grant := range grants {
+		if grant.Grantee == nil || aws.ToString(grant.Grantee.URI) != group {
+			continue
+		}
+		perms = append(perms, string(grant.Permission))
+	}
+	slices.Sort(perms)
+	return slices.Compact(perms)
+}
+
 func (s *Scanner) checkBucketPolicy(ctx context.Context, bucketName string) []scanner.Finding {
 	policyStatus, err := s.client.GetBucketPolicyStatus(ctx, &s3.GetBucketPolicyStatusInput{
 		Bucket: aws.String(bucketName),
 	})
 	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{s.accessDeniedFinding("s3_bucket_policy_public", bucketName, err)}
+		}
 		var apiErr smithy.APIError
 		if ok := errors.As(err, &apiErr); ok && apiErr.ErrorCode() == "NoSuchBucketPolicy" {
 			return []scanner.Finding{s.createFinding(
@@ -95,6 +144,9 @@ func (s *Scanner) checkEncryption(ctx context.Context, bucketName string) []scan
 		Bucket: aws.String(bucketName),
 	})
 	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{s.accessDeniedFinding("s3_bucket_encryption", bucketName, err)}
+		}
 		var apiErr smithy.APIError
 		if ok := errors.As(err, &apiErr); ok && apiErr.ErrorCode() == "ServerSideEncryptionConfigurationNotFoundError" {
 			return []scanner.Finding{s.createFinding(
@@ -130,11 +182,58 @@ func (s *Scanner) checkEncryption(ctx context.Context, bucketName string) []scan
 	)}
 }
 
+// checkBucketKey reports whether buckets encrypted by default with SSE-KMS use
+// an S3 Bucket Key, which cuts the KMS requests, and cost, of every object
+// operation. Buckets without default SSE-KMS are left to checkEncryption.
+func (s *Scanner) checkBucketKey(ctx context.Context, bucketName string) []scanner.Finding {
+	encryption, err := s.client.GetBucketEncryption(ctx, &s3.GetBucketEncryptionInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{s.accessDeniedFinding("s3_bucket_key", bucketName, err)}
+		}
+		return nil
+	}
+	if encryption.ServerSideEncryptionConfiguration == nil {
+		return nil
+	}
+
+	for _, rule := range encryption.ServerSideEncryptionConfiguration.Rules {
+		if rule.ApplyServerSideEncryptionByDefault == nil ||
+			rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm != types.ServerSideEncryptionAwsKms {
+			continue
+		}
+		if aws.ToBool(rule.BucketKeyEnabled) {
+			return []scanner.Finding{s.createFinding(
+				"s3_bucket_key",
+				bucketName,
+				"S3 Bucket Key is enabled",
+				fmt.Sprintf("Bucket %s uses an S3 Bucket Key for SSE-KMS encryption", bucketName),
+				scanner.StatusPass,
+				scanner.SeverityLow,
+			)}
+		}
+		return []scanner.Finding{s.createFinding(
+			"s3_bucket_key",
+			bucketName,
+			"S3 Bucket Key is not enabled",
+			fmt.Sprintf("Bucket %s encrypts with SSE-KMS without an S3 Bucket Key, so every object operation calls KMS", bucketName),
+			scanner.StatusFail,
+			scanner.SeverityLow,
+		)}
+	}
+	return nil
+}
+
 func (s *Scanner) checkVersioning(ctx context.Context, bucketName string) []scanner.Finding {
 	versioning, err := s.client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{
 		Bucket: aws.String(bucketName),
 	})
 	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{s.accessDeniedFinding("s3_bucket_versioning", bucketName, err)}
+		}
 		return nil
 	}
 
@@ -164,6 +263,9 @@ func (s *Scanner) checkLogging(ctx context.Context, bucketName string) []scanner
 		Bucket: aws.String(bucketName),
 	})
 	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{s.accessDeniedFinding("s3_bucket_logging", bucketName, err)}
+		}
 		return nil
 	}
 
@@ -193,6 +295,9 @@ func (s *Scanner) checkBlockPublicAccess(ctx context.Context, bucketName string)
 		Bucket: aws.String(bucketName),
 	})
 	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{s.accessDeniedFinding("s3_block_public_access", bucketName, err)}
+		}
 		return []scanner.Finding{s.createFinding(
 			"s3_block_public_access",
 			bucketName,
@@ -234,6 +339,9 @@ func (s *Scanner) checkMFADelete(ctx context.Context, bucketName string) []scann
 		Bucket: aws.String(bucketName),
 	})
 	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{s.accessDeniedFinding("s3_mfa_delete", bucketName, err)}
+		}
 		return nil
 	}
 
@@ -263,6 +371,9 @@ func (s *Scanner) checkLifecyclePolicy(ctx context.Context, bucketName string) [
 		Bucket: aws.String(bucketName),
 	})
 	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{s.accessDeniedFinding("s3_lifecycle_policy", bucketName, err)}
+		}
 		return []scanner.Finding{s.createFinding(
 			"s3_lifecycle_policy",
 			bucketName,
@@ -299,6 +410,9 @@ func (s *Scanner) checkSSLOnly(ctx context.Context, bucketName string) []scanner
 		Bucket: aws.String(bucketName),
 	})
 	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{s.accessDeniedFinding("s3_ssl_only", bucketName, err)}
+		}
 		return []scanner.Finding{s.createFinding(
 			"s3_ssl_only",
 			bucketName,
@@ -358,6 +472,9 @@ func (s *Scanner) checkObjectLock(ctx context.Context, bucketName string) []scan
 		Bucket: aws.String(bucketName),
 	})
 	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{s.accessDeniedFinding("s3_object_lock", bucketName, err)}
+		}
 		return []scanner.Finding{s.createFinding(
 			"s3_object_lock",
 			bucketName,
@@ -388,3 +505,117 @@ func (s *Scanner) checkObjectLock(ctx context.Context, bucketName string) []scan
 		scanner.SeverityMedium,
 	)}
 }
+
+// checkWebsiteConfig fails buckets configured for static website hosting,
+// since website endpoints serve objects to anyone without authentication.
+func (s *Scanner) checkWebsiteConfig(ctx context.Context, bucketName string) []scanner.Finding {
+	_, err := s.client.GetBucketWebsite(ctx, &s3.GetBucketWebsiteInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{s.accessDeniedFinding("s3_static_website", bucketName, err)}
+		}
+		var apiErr smithy.APIError
+		if ok := errors.As(err, &apiErr); ok && apiErr.ErrorCode() == "NoSuchWebsiteConfiguration" {
+			return []scanner.Finding{s.createFinding(
+				"s3_static_website",
+				bucketName,
+				"S3 bucket is not a static website",
+				fmt.Sprintf("Bucket %s has no website configuration", bucketName),
+				scanner.StatusPass,
+				scanner.SeverityHigh,
+			)}
+		}
+		return nil
+	}
+
+	return []scanner.Finding{s.createFinding(
+		"s3_static_website",
+		bucketName,
+		"S3 bucket is configured as a static website",
+		fmt.Sprintf("Bucket %s serves its objects through a public website endpoint", bucketName),
+		scanner.StatusFail,
+		scanner.SeverityHigh,
+	)}
+}
+
+// checkReplication reports whether a bucket replicates its objects to
+// another bucket. Replication is only expected for critical data, so this is
+// informational.
+func (s *Scanner) checkReplication(ctx context.Context, bucketName string) []scanner.Finding {
+	replication, err := s.client.GetBucketReplication(ctx, &s3.GetBucketReplicationInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{s.accessDeniedFinding("s3_replication", bucketName, err)}
+		}
+		var apiErr smithy.APIError
+		if ok := errors.As(err, &apiErr); !ok || apiErr.ErrorCode() != "ReplicationConfigurationNotFoundError" {
+			return nil
+		}
+	} else if replication.ReplicationConfiguration != nil {
+		for _, rule := range replication.ReplicationConfiguration.Rules {
+			if rule.Status == types.ReplicationRuleStatusEnabled {
+				return []scanner.Finding{s.createFinding(
+					"s3_replication",
+					bucketName,
+					"S3 bucket replication is enabled",
+					fmt.Sprintf("Bucket %s replicates objects to %s", bucketName, replicationDestination(rule)),
+					scanner.StatusPass,
+					scanner.SeverityLow,
+				)}
+			}
+		}
+	}
+
+	return []scanner.Finding{s.createFinding(
+		"s3_replication",
+		bucketName,
+		"S3 bucket replication is not configured",
+		fmt.Sprintf("Bucket %s has no enabled replication rules", bucketName),
+		scanner.StatusFail,
+		scanner.SeverityLow,
+	)}
+}
+
+func replicationDestination(rule types.ReplicationRule) string {
+	if rule.Destination == nil {
+		return "another bucket"
+	}
+	return aws.ToString(rule.Destination.Bucket)
+}
+
+// applyPublicAllowList downgrades failed public-access findings for buckets the
+// account has accepted as public, either by name or by the PublicIntentional tag.
+func (s *Scanner) applyPublicAllowList(ctx context.Context, bucketName string, findings []scanner.Finding) []scanner.Finding {
+	if !slices.ContainsFunc(findings, func(f scanner.Finding) bool { return f.Status == scanner.StatusFail }) {
+		return findings
+	}
+
+	allow := scanner.ScopeFromContext(ctx).PublicAllowList
+	var tags map[string]string
+	if allow.UsesTags() {
+		tags = s.bucketTags(ctx, bucketName)
+	}
+	if !allow.Allows(bucketName, tags) {
+		return findings
+	}
+	return scanner.AcceptPublic(findings)
+}
+
+// bucketTags returns the bucket's tags, or nil if it has none or they cannot be read.
+func (s *Scanner) bucketTags(ctx context.Context, bucketName string) map[string]string {
+	out, err := s.client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		return nil
+	}
+	tags := make(map[string]string, len(out.TagSet))
+	for _, tag := range out.TagSet {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return tags
+}
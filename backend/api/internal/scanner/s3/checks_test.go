@@ -0,0 +1,423 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+// fakeS3Client implements s3API with canned responses.
+// Methods that are not overridden panic via the nil embedded interface.
+type fakeS3Client struct {
+	s3API
+	err error
+}
+
+func (f *fakeS3Client) GetBucketAcl(_ context.Context, _ *s3.GetBucketAclInput, _ ...func(*s3.Options)) (*s3.GetBucketAclOutput, error) {
+	return nil, f.err
+}
+
+func (f *fakeS3Client) GetBucketVersioning(_ context.Context, _ *s3.GetBucketVersioningInput, _ ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error) {
+	return nil, f.err
+}
+
+func (f *fakeS3Client) GetPublicAccessBlock(_ context.Context, _ *s3.GetPublicAccessBlockInput, _ ...func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error) {
+	return nil, f.err
+}
+
+func (f *fakeS3Client) GetBucketWebsite(_ context.Context, _ *s3.GetBucketWebsiteInput, _ ...func(*s3.Options)) (*s3.GetBucketWebsiteOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &s3.GetBucketWebsiteOutput{IndexDocument: &types.IndexDocument{Suffix: aws.String("index.html")}}, nil
+}
+
+func (f *fakeS3Client) GetBucketReplication(_ context.Context, _ *s3.GetBucketReplicationInput, _ ...func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &s3.GetBucketReplicationOutput{ReplicationConfiguration: &types.ReplicationConfiguration{
+		Rules: []types.ReplicationRule{{
+			Status:      types.ReplicationRuleStatusEnabled,
+			Destination: &types.Destination{Bucket: aws.String("arn:aws:s3:::backup")},
+		}},
+	}}, nil
+}
+
+func accessDenied(operation string) error {
+	return &smithy.OperationError{
+		ServiceID:     "S3",
+		OperationName: operation,
+		Err:           &smithy.GenericAPIError{Code: "AccessDenied", Message: "Access Denied"},
+	}
+}
+
+func TestChecks_AccessDenied(t *testing.T) {
+	s := &Scanner{
+		client:    &fakeS3Client{err: accessDenied("GetBucketAcl")},
+		region:    "us-east-1",
+		accountID: "123456789012",
+	}
+
+	tests := []struct {
+		name    string
+		check   func(context.Context, string) []scanner.Finding
+		checkID string
+	}{
+		{"public access", s.checkPublicAccess, "s3_bucket_public_access"},
+		{"versioning", s.checkVersioning, "s3_bucket_versioning"},
+		{"block public access", s.checkBlockPublicAccess, "s3_block_public_access"},
+		{"static website", s.checkWebsiteConfig, "s3_static_website"},
+		{"replication", s.checkReplication, "s3_replication"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := tt.check(context.Background(), "locked-bucket")
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			f := findings[0]
+			if f.Status != scanner.StatusError || f.Severity != scanner.SeverityMedium {
+				t.Errorf("got %s/%s, want ERROR/MEDIUM", f.Status, f.Severity)
+			}
+			if f.CheckID != tt.checkID || f.ResourceID != "locked-bucket" {
+				t.Errorf("got %s on %s, want %s on locked-bucket", f.CheckID, f.ResourceID, tt.checkID)
+			}
+		})
+	}
+}
+
+func TestCheckWebsiteAndReplication(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		check   func(*Scanner, context.Context, string) []scanner.Finding
+		checkID string
+		want    scanner.FindingStatus
+	}{
+		{"website configured", nil, (*Scanner).checkWebsiteConfig, "s3_static_website", scanner.StatusFail},
+		{"no website", &smithy.GenericAPIError{Code: "NoSuchWebsiteConfiguration"}, (*Scanner).checkWebsiteConfig, "s3_static_website", scanner.StatusPass},
+		{"replication enabled", nil, (*Scanner).checkReplication, "s3_replication", scanner.StatusPass},
+		{"no replication", &smithy.GenericAPIError{Code: "ReplicationConfigurationNotFoundError"}, (*Scanner).checkReplication, "s3_replication", scanner.StatusFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Scanner{client: &fakeS3Client{err: tt.err}, region: "us-east-1", accountID: "123456789012"}
+
+			findings := tt.check(s, context.Background(), "site")
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			if f := findings[0]; f.CheckID != tt.checkID || f.Status != tt.want {
+				t.Errorf("got %s %s, want %s %s", f.CheckID, f.Status, tt.checkID, tt.want)
+			}
+		})
+	}
+}
+
+// cannedS3Client answers the encryption, versioning, and policy calls with
+// fixed responses, or with err when it is set.
+type cannedS3Client struct {
+	s3API
+	encryption *types.ServerSideEncryptionConfiguration
+	versioning types.BucketVersioningStatus
+	policy     string
+	err        error
+}
+
+func (f *cannedS3Client) GetBucketEncryption(_ context.Context, _ *s3.GetBucketEncryptionInput, _ ...func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: f.encryption}, nil
+}
+
+func (f *cannedS3Client) GetBucketVersioning(_ context.Context, _ *s3.GetBucketVersioningInput, _ ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &s3.GetBucketVersioningOutput{Status: f.versioning}, nil
+}
+
+func (f *cannedS3Client) GetBucketPolicy(_ context.Context, _ *s3.GetBucketPolicyInput, _ ...func(*s3.Options)) (*s3.GetBucketPolicyOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &s3.GetBucketPolicyOutput{Policy: aws.String(f.policy)}, nil
+}
+
+const (
+	denyInsecurePolicy = `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Principal":"*","Action":"s3:*",` +
+		`"Resource":"arn:aws:s3:::data/*","Condition":{"Bool":{"aws:SecureTransport":"false"}}}]}`
+	allowReadPolicy = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123456789012:root"},` +
+		`"Action":"s3:GetObject","Resource":"arn:aws:s3:::data/*"}]}`
+)
+
+func TestCheckEncryptionVersioningSSL(t *testing.T) {
+	sseRules := &types.ServerSideEncryptionConfiguration{Rules: []types.ServerSideEncryptionRule{{
+		ApplyServerSideEncryptionByDefault: &types.ServerSideEncryptionByDefault{SSEAlgorithm: types.ServerSideEncryptionAwsKms},
+	}}}
+
+	tests := []struct {
+		name    string
+		client  *cannedS3Client
+		check   func(*Scanner, context.Context, string) []scanner.Finding
+		checkID string
+		want    scanner.FindingStatus // empty when no finding is expected
+	}{
+		{"encryption configured", &cannedS3Client{encryption: sseRules}, (*Scanner).checkEncryption, "s3_bucket_encryption", scanner.StatusPass},
+		{"encryption without rules", &cannedS3Client{encryption: &types.ServerSideEncryptionConfiguration{}}, (*Scanner).checkEncryption, "s3_bucket_encryption", scanner.StatusFail},
+		{"encryption not configured", &cannedS3Client{err: &smithy.GenericAPIError{Code: "ServerSideEncryptionConfigurationNotFoundError"}}, (*Scanner).checkEncryption, "s3_bucket_encryption", scanner.StatusFail},
+		{"encryption access denied", &cannedS3Client{err: accessDenied("GetBucketEncryption")}, (*Scanner).checkEncryption, "s3_bucket_encryption", scanner.StatusError},
+		{"encryption other error", &cannedS3Client{err: errors.New("throttled")}, (*Scanner).checkEncryption, "s3_bucket_encryption", ""},
+
+		{"versioning enabled", &cannedS3Client{versioning: types.BucketVersioningStatusEnabled}, (*Scanner).checkVersioning, "s3_bucket_versioning", scanner.StatusPass},
+		{"versioning suspended", &cannedS3Client{versioning: types.BucketVersioningStatusSuspended}, (*Scanner).checkVersioning, "s3_bucket_versioning", scanner.StatusFail},
+		{"versioning never enabled", &cannedS3Client{}, (*Scanner).checkVersioning, "s3_bucket_versioning", scanner.StatusFail},
+		{"versioning other error", &cannedS3Client{err: errors.New("throttled")}, (*Scanner).checkVersioning, "s3_bucket_versioning", ""},
+
+		{"ssl enforced", &cannedS3Client{policy: denyInsecurePolicy}, (*Scanner).checkSSLOnly, "s3_ssl_only", scanner.StatusPass},
+		{"policy without ssl", &cannedS3Client{policy: allowReadPolicy}, (*Scanner).checkSSLOnly, "s3_ssl_only", scanner.StatusFail},
+		{"no bucket policy", &cannedS3Client{err: &smithy.GenericAPIError{Code: "NoSuchBucketPolicy"}}, (*Scanner).checkSSLOnly, "s3_ssl_only", scanner.StatusFail},
+		{"ssl access denied", &cannedS3Client{err: accessDenied("GetBucketPolicy")}, (*Scanner).checkSSLOnly, "s3_ssl_only", scanner.StatusError},
+		{"malformed policy", &cannedS3Client{policy: "{"}, (*Scanner).checkSSLOnly, "s3_ssl_only", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Scanner{client: tt.client, region: "us-east-1", accountID: "123456789012"}
+
+			findings := tt.check(s, context.Background(), "data")
+			if tt.want == "" {
+				if len(findings) != 0 {
+					t.Errorf("got %+v, want no findings", findings)
+				}
+				return
+			}
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			if f := findings[0]; f.CheckID != tt.checkID || f.Status != tt.want || f.ResourceID != "data" {
+				t.Errorf("got %s %s on %s, want %s %s on data", f.CheckID, f.Status, f.ResourceID, tt.checkID, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckBucketKey(t *testing.T) {
+	rule := func(algorithm types.ServerSideEncryption, bucketKey *bool) *types.ServerSideEncryptionConfiguration {
+		return &types.ServerSideEncryptionConfiguration{Rules: []types.ServerSideEncryptionRule{{
+			ApplyServerSideEncryptionByDefault: &types.ServerSideEncryptionByDefault{SSEAlgorithm: algorithm},
+			BucketKeyEnabled:                   bucketKey,
+		}}}
+	}
+
+	tests := []struct {
+		name   string
+		client *cannedS3Client
+		want   scanner.FindingStatus // empty when no finding is expected
+	}{
+		{"kms with bucket key", &cannedS3Client{encryption: rule(types.ServerSideEncryptionAwsKms, aws.Bool(true))}, scanner.StatusPass},
+		{"kms with bucket key disabled", &cannedS3Client{encryption: rule(types.ServerSideEncryptionAwsKms, aws.Bool(false))}, scanner.StatusFail},
+		{"kms with bucket key unset", &cannedS3Client{encryption: rule(types.ServerSideEncryptionAwsKms, nil)}, scanner.StatusFail},
+		{"sse-s3", &cannedS3Client{encryption: rule(types.ServerSideEncryptionAes256, nil)}, ""},
+		{"dsse-kms", &cannedS3Client{encryption: rule(types.ServerSideEncryptionAwsKmsDsse, nil)}, ""},
+		{"no rules", &cannedS3Client{encryption: &types.ServerSideEncryptionConfiguration{}}, ""},
+		{"encryption not configured", &cannedS3Client{err: &smithy.GenericAPIError{Code: "ServerSideEncryptionConfigurationNotFoundError"}}, ""},
+		{"access denied", &cannedS3Client{err: accessDenied("GetBucketEncryption")}, scanner.StatusError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Scanner{client: tt.client, region: "us-east-1", accountID: "123456789012"}
+
+			findings := s.checkBucketKey(context.Background(), "data")
+			if tt.want == "" {
+				if len(findings) != 0 {
+					t.Errorf("got %+v, want no findings", findings)
+				}
+				return
+			}
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			if f := findings[0]; f.CheckID != "s3_bucket_key" || f.Status != tt.want {
+				t.Errorf("got %s %s, want s3_bucket_key %s", f.CheckID, f.Status, tt.want)
+			} else if f.Status != scanner.StatusError && f.Severity != scanner.SeverityLow {
+				t.Errorf("severity = %s, want LOW", f.Severity)
+			}
+
+			// The encryption check itself still passes for any configured rule.
+			if tt.client.encryption != nil {
+				enc := s.checkEncryption(context.Background(), "data")
+				if len(enc) != 1 || enc[0].Status != scanner.StatusPass {
+					t.Errorf("checkEncryption() = %+v, want a single pass", enc)
+				}
+			}
+		})
+	}
+}
+
+// aclClient serves a fixed set of ACL grants for every bucket.
+type aclClient struct {
+	s3API
+	grants []types.Grant
+}
+
+func (f *aclClient) GetBucketAcl(_ context.Context, _ *s3.GetBucketAclInput, _ ...func(*s3.Options)) (*s3.GetBucketAclOutput, error) {
+	return &s3.GetBucketAclOutput{Grants: f.grants}, nil
+}
+
+func groupGrant(group string, perm types.Permission) types.Grant {
+	return types.Grant{
+		Grantee:    &types.Grantee{Type: types.TypeGroup, URI: aws.String(group)},
+		Permission: perm,
+	}
+}
+
+func TestCheckPublicAccess_Grantees(t *testing.T) {
+	owner := types.Grant{
+		Grantee:    &types.Grantee{Type: types.TypeCanonicalUser, ID: aws.String("owner-id")},
+		Permission: types.PermissionFullControl,
+	}
+
+	tests := []struct {
+		name     string
+		grants   []types.Grant
+		status   scanner.FindingStatus
+		severity scanner.Severity
+		desc     string
+	}{
+		{
+			name:     "all users",
+			grants:   []types.Grant{owner, groupGrant(allUsersGroup, types.PermissionRead)},
+			status:   scanner.StatusFail,
+			severity: scanner.SeverityCritical,
+			desc:     "Bucket site grants READ to everyone (AllUsers) via ACL",
+		},
+		{
+			name: "authenticated users",
+			grants: []types.Grant{
+				owner,
+				groupGrant(authenticatedUsersGroup, types.PermissionFullControl),
+			},
+			status:   scanner.StatusFail,
+			severity: scanner.SeverityHigh,
+			desc:     "Bucket site grants FULL_CONTROL to any AWS account (AuthenticatedUsers) via ACL",
+		},
+		{
+			name: "mixed grantees",
+			grants: []types.Grant{
+				owner,
+				groupGrant(authenticatedUsersGroup, types.PermissionWrite),
+				groupGrant(allUsersGroup, types.PermissionRead),
+				groupGrant(authenticatedUsersGroup, types.PermissionReadAcp),
+				groupGrant(authenticatedUsersGroup, types.PermissionWrite),
+			},
+			status:   scanner.StatusFail,
+			severity: scanner.SeverityCritical,
+			desc:     "Bucket site grants READ to everyone (AllUsers) and READ_ACP, WRITE to any AWS account (AuthenticatedUsers) via ACL",
+		},
+		{
+			name:     "owner only",
+			grants:   []types.Grant{owner},
+			status:   scanner.StatusPass,
+			severity: scanner.SeverityCritical,
+			desc:     "Bucket site has no public ACL grants",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Scanner{client: &aclClient{grants: tt.grants}, region: "us-east-1", accountID: "123456789012"}
+
+			findings := s.checkPublicAccess(context.Background(), "site")
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			f := findings[0]
+			if f.Status != tt.status || f.Severity != tt.severity {
+				t.Errorf("got %s %s, want %s %s", f.Status, f.Severity, tt.status, tt.severity)
+			}
+			if f.Description != tt.desc {
+				t.Errorf("Description = %q, want %q", f.Description, tt.desc)
+			}
+		})
+	}
+}
+
+// publicBucketClient serves a public-read ACL for every bucket and the tags
+// configured per bucket.
+type publicBucketClient struct {
+	s3API
+	tags map[string][]types.Tag
+}
+
+func (f *publicBucketClient) GetBucketAcl(_ context.Context, _ *s3.GetBucketAclInput, _ ...func(*s3.Options)) (*s3.GetBucketAclOutput, error) {
+	return &s3.GetBucketAclOutput{
+		Grants: []types.Grant{{
+			Grantee:    &types.Grantee{URI: aws.String("http://acs.amazonaws.com/groups/global/AllUsers")},
+			Permission: types.PermissionRead,
+		}},
+	}, nil
+}
+
+func (f *publicBucketClient) GetBucketTagging(_ context.Context, params *s3.GetBucketTaggingInput, _ ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+	tags, ok := f.tags[aws.ToString(params.Bucket)]
+	if !ok {
+		return nil, &smithy.GenericAPIError{Code: "NoSuchTagSet"}
+	}
+	return &s3.GetBucketTaggingOutput{TagSet: tags}, nil
+}
+
+func TestApplyPublicAllowList(t *testing.T) {
+	s := &Scanner{
+		client: &publicBucketClient{tags: map[string][]types.Tag{
+			"static-site": {{Key: aws.String("PublicIntentional"), Value: aws.String("true")}},
+			"team-data":   {{Key: aws.String("PublicIntentional"), Value: aws.String("false")}},
+		}},
+		region:    "us-east-1",
+		accountID: "123456789012",
+	}
+
+	tests := []struct {
+		name   string
+		bucket string
+		allow  scanner.PublicAllowList
+		want   scanner.Severity
+	}{
+		{"tagged intentional", "static-site", scanner.PublicAllowList{}, scanner.SeverityLow},
+		{"tagged not intentional", "team-data", scanner.PublicAllowList{}, scanner.SeverityCritical},
+		{"untagged", "uploads", scanner.PublicAllowList{}, scanner.SeverityCritical},
+		{"allow-listed by name", "uploads", scanner.PublicAllowList{ResourceIDs: []string{"uploads"}}, scanner.SeverityLow},
+		{"tag rule disabled", "static-site", scanner.PublicAllowList{DisableTag: true}, scanner.SeverityCritical},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := scanner.WithScope(context.Background(), scanner.Scope{PublicAllowList: tt.allow})
+
+			findings := s.applyPublicAllowList(ctx, tt.bucket, s.checkPublicAccess(ctx, tt.bucket))
+
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			f := findings[0]
+			if f.CheckID != "s3_bucket_public_access" || f.Status != scanner.StatusFail {
+				t.Errorf("got %s %s, want a failed s3_bucket_public_access finding", f.CheckID, f.Status)
+			}
+			if f.Severity != tt.want {
+				t.Errorf("Severity = %s, want %s", f.Severity, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package s3
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/aws/smithy-go"
+)
+
+const (
+	// defaultLocationCacheSize bounds how many bucket regions a cache holds.
+	defaultLocationCacheSize = 1000
+	// defaultLocationTTL is how long a cached bucket region is trusted.
+	defaultLocationTTL = 6 * time.Hour
+)
+
+// LocationCache is a bounded LRU cache of bucket regions with a TTL. Bucket
+// regions only change when a bucket is deleted and recreated, so recurring
+// scans can skip GetBucketLocation for buckets they have already resolved.
+// It is safe for concurrent use.
+type LocationCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	now     func() time.Time
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type locationEntry struct {
+	key       string
+	region    string
+	expiresAt time.Time
+}
+
+// NewLocationCache creates a cache holding up to size bucket regions for ttl.
+// Non-positive values use the defaults.
+func NewLocationCache(size int, ttl time.Duration) *LocationCache {
+	if size <= 0 {
+		size = defaultLocationCacheSize
+	}
+	if ttl <= 0 {
+		ttl = defaultLocationTTL
+	}
+	return &LocationCache{
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached region of a bucket in an account, if still fresh.
+func (c *LocationCache) Get(accountID, bucket string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[locationKey(accountID, bucket)]
+	if !ok {
+		return "", false
+	}
+	entry := elem.Value.(*locationEntry)
+	if c.now().After(entry.expiresAt) {
+		c.remove(elem)
+		return "", false
+	}
+	c.order.MoveToFront(elem)
+	return entry.region, true
+}
+
+// Put caches the region of a bucket, evicting the least recently used entry
+// when the cache is full.
+func (c *LocationCache) Put(accountID, bucket, region string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := locationKey(accountID, bucket)
+	expiresAt := c.now().Add(c.ttl)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*locationEntry)
+		entry.region, entry.expiresAt = region, expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&locationEntry{key: key, region: region, expiresAt: expiresAt})
+	if c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+// Invalidate drops the cached region of a bucket.
+func (c *LocationCache) Invalidate(accountID, bucket string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[locationKey(accountID, bucket)]; ok {
+		c.remove(elem)
+	}
+}
+
+// Len returns the number of cached buckets, including expired ones not yet evicted.
+func (c *LocationCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *LocationCache) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*locationEntry).key)
+}
+
+func locationKey(accountID, bucket string) string {
+	return accountID + "/" + bucket
+}
+
+// isBucketGone reports whether err means the bucket no longer exists where
+// it was expected, so a cached region for it is stale.
+func isBucketGone(err error) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.ErrorCode() {
+	case "NoSuchBucket", "PermanentRedirect":
+		return true
+	}
+	return false
+}
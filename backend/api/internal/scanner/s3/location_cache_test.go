@@ -0,0 +1,109 @@
+package s3
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+// locationClient serves a fixed bucket listing and counts location lookups.
+type locationClient struct {
+	s3API
+	regions       map[string]string
+	locationCalls int
+}
+
+func (f *locationClient) ListBuckets(_ context.Context, _ *s3.ListBucketsInput, _ ...func(*s3.Options)) (*s3.ListBucketsOutput, error) {
+	out := &s3.ListBucketsOutput{}
+	for name := range f.regions {
+		out.Buckets = append(out.Buckets, types.Bucket{Name: aws.String(name)})
+	}
+	return out, nil
+}
+
+func (f *locationClient) GetBucketLocation(_ context.Context, params *s3.GetBucketLocationInput, _ ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+	f.locationCalls++
+	region, ok := f.regions[aws.ToString(params.Bucket)]
+	if !ok {
+		return nil, &smithy.GenericAPIError{Code: "NoSuchBucket", Message: "The specified bucket does not exist"}
+	}
+	if region == "us-east-1" {
+		region = ""
+	}
+	return &s3.GetBucketLocationOutput{LocationConstraint: types.BucketLocationConstraint(region)}, nil
+}
+
+func TestListBucketsInRegion_CachesLocations(t *testing.T) {
+	client := &locationClient{regions: map[string]string{
+		"logs":    "us-east-1",
+		"assets":  "us-east-1",
+		"archive": "eu-west-1",
+	}}
+	cache := NewLocationCache(0, 0)
+
+	// Each scan builds its own scanner and resource cache, as the coordinator does.
+	for scan := 1; scan <= 2; scan++ {
+		s := &Scanner{client: client, region: "us-east-1", accountID: "123456789012", locations: cache}
+		buckets, err := s.listBucketsInRegion(context.Background())
+		if err != nil {
+			t.Fatalf("scan %d: listBucketsInRegion() error = %v", scan, err)
+		}
+		if len(buckets) != 2 {
+			t.Errorf("scan %d: got %d buckets in us-east-1, want 2", scan, len(buckets))
+		}
+	}
+
+	if client.locationCalls != 3 {
+		t.Errorf("GetBucketLocation called %d times, want 3 (first scan only)", client.locationCalls)
+	}
+}
+
+func TestBucketRegion_InvalidatesMissingBucket(t *testing.T) {
+	client := &locationClient{regions: map[string]string{}}
+	cache := NewLocationCache(0, 0)
+	cache.Put("123456789012", "deleted", "eu-west-1")
+	s := &Scanner{client: client, region: "eu-west-1", accountID: "123456789012", locations: cache}
+
+	s.forgetBucketIfGone("deleted", &smithy.GenericAPIError{Code: "NoSuchBucket"})
+
+	if _, ok := cache.Get("123456789012", "deleted"); ok {
+		t.Fatal("cached region survived NoSuchBucket")
+	}
+	if _, err := s.bucketRegion(context.Background(), "deleted"); err == nil {
+		t.Fatal("bucketRegion() error = nil, want NoSuchBucket")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("cache holds %d entries after a failed lookup, want 0", cache.Len())
+	}
+}
+
+func TestLocationCache(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	cache := NewLocationCache(2, time.Hour)
+	cache.now = func() time.Time { return now }
+
+	cache.Put("111111111111", "a", "us-east-1")
+	cache.Put("111111111111", "b", "eu-west-1")
+	cache.Get("111111111111", "a")
+	cache.Put("111111111111", "c", "ap-south-1")
+
+	if _, ok := cache.Get("111111111111", "b"); ok {
+		t.Error("least recently used bucket b was not evicted")
+	}
+	if region, ok := cache.Get("111111111111", "a"); !ok || region != "us-east-1" {
+		t.Errorf("Get(a) = %q, %v, want us-east-1, true", region, ok)
+	}
+	if _, ok := cache.Get("222222222222", "a"); ok {
+		t.Error("bucket a leaked across accounts")
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, ok := cache.Get("111111111111", "c"); ok {
+		t.Error("expired entry was returned")
+	}
+}
@@ -0,0 +1,76 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// checkNotificationTargets fails buckets whose event notifications are sent
+// to a Lambda function, SNS topic, or SQS queue in another account, which
+// exposes object keys and event metadata outside the account. Buckets without
+// notifications pass.
+func (s *Scanner) checkNotificationTargets(ctx context.Context, bucketName string) []scanner.Finding {
+	config, err := s.client.GetBucketNotificationConfiguration(ctx, &s3.GetBucketNotificationConfigurationInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{s.accessDeniedFinding("s3_notification_cross_account", bucketName, err)}
+		}
+		return nil
+	}
+
+	var targets []*string
+	for _, c := range config.LambdaFunctionConfigurations {
+		targets = append(targets, c.LambdaFunctionArn)
+	}
+	for _, c := range config.TopicConfigurations {
+		targets = append(targets, c.TopicArn)
+	}
+	for _, c := range config.QueueConfigurations {
+		targets = append(targets, c.QueueArn)
+	}
+
+	var external []string
+	for _, target := range targets {
+		if s.isExternalTarget(aws.ToString(target)) {
+			external = append(external, aws.ToString(target))
+		}
+	}
+
+	if len(external) > 0 {
+		return []scanner.Finding{s.createFinding(
+			"s3_notification_cross_account",
+			bucketName,
+			"S3 bucket sends event notifications to another account",
+			fmt.Sprintf("Bucket %s sends event notifications to targets outside account %s: %s", bucketName, s.accountID, strings.Join(external, ", ")),
+			scanner.StatusFail,
+			scanner.SeverityMedium,
+		)}
+	}
+	return []scanner.Finding{s.createFinding(
+		"s3_notification_cross_account",
+		bucketName,
+		"S3 bucket event notifications stay within the account",
+		fmt.Sprintf("Bucket %s sends no event notifications to other accounts", bucketName),
+		scanner.StatusPass,
+		scanner.SeverityMedium,
+	)}
+}
+
+// isExternalTarget reports whether target is an ARN in an account other than
+// the scanned one. Unparseable ARNs are not treated as external.
+func (s *Scanner) isExternalTarget(target string) bool {
+	parsed, err := arn.Parse(target)
+	if err != nil || parsed.AccountID == "" {
+		return false
+	}
+	return parsed.AccountID != s.accountID
+}
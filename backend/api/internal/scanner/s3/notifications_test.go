@@ -0,0 +1,99 @@
+package s3
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// notificationClient serves a fixed notification configuration for every bucket.
+type notificationClient struct {
+	s3API
+	config *s3.GetBucketNotificationConfigurationOutput
+	err    error
+}
+
+func (f *notificationClient) GetBucketNotificationConfiguration(context.Context, *s3.GetBucketNotificationConfigurationInput, ...func(*s3.Options)) (*s3.GetBucketNotificationConfigurationOutput, error) {
+	return f.config, f.err
+}
+
+func TestCheckNotificationTargets(t *testing.T) {
+	const (
+		ownFunction     = "arn:aws:lambda:us-east-1:123456789012:function:thumbnails"
+		ownQueue        = "arn:aws:sqs:us-east-1:123456789012:uploads"
+		foreignTopic    = "arn:aws:sns:us-east-1:210987654321:partner-events"
+		foreignQueue    = "arn:aws:sqs:eu-west-1:210987654321:ingest"
+		foreignFunction = "arn:aws:lambda:us-east-1:999999999999:function:audit"
+	)
+
+	tests := []struct {
+		name     string
+		client   *notificationClient
+		want     scanner.FindingStatus
+		external []string
+	}{
+		{
+			name:   "no notifications",
+			client: &notificationClient{config: &s3.GetBucketNotificationConfigurationOutput{}},
+			want:   scanner.StatusPass,
+		},
+		{
+			name: "same-account targets",
+			client: &notificationClient{config: &s3.GetBucketNotificationConfigurationOutput{
+				LambdaFunctionConfigurations: []types.LambdaFunctionConfiguration{{LambdaFunctionArn: aws.String(ownFunction)}},
+				QueueConfigurations:          []types.QueueConfiguration{{QueueArn: aws.String(ownQueue)}},
+			}},
+			want: scanner.StatusPass,
+		},
+		{
+			name: "cross-account targets",
+			client: &notificationClient{config: &s3.GetBucketNotificationConfigurationOutput{
+				LambdaFunctionConfigurations: []types.LambdaFunctionConfiguration{
+					{LambdaFunctionArn: aws.String(ownFunction)},
+					{LambdaFunctionArn: aws.String(foreignFunction)},
+				},
+				TopicConfigurations: []types.TopicConfiguration{{TopicArn: aws.String(foreignTopic)}},
+				QueueConfigurations: []types.QueueConfiguration{{QueueArn: aws.String(foreignQueue)}},
+			}},
+			want:     scanner.StatusFail,
+			external: []string{foreignFunction, foreignTopic, foreignQueue},
+		},
+		{
+			name:   "access denied",
+			client: &notificationClient{err: accessDenied("GetBucketNotificationConfiguration")},
+			want:   scanner.StatusError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Scanner{client: tt.client, region: "us-east-1", accountID: "123456789012"}
+
+			findings := s.checkNotificationTargets(context.Background(), "uploads")
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			f := findings[0]
+			if f.CheckID != "s3_notification_cross_account" || f.Status != tt.want {
+				t.Fatalf("got %s %s, want s3_notification_cross_account %s", f.CheckID, f.Status, tt.want)
+			}
+			if tt.want == scanner.StatusFail && f.Severity != scanner.SeverityMedium {
+				t.Errorf("Severity = %s, want %s", f.Severity, scanner.SeverityMedium)
+			}
+			for _, target := range tt.external {
+				if !strings.Contains(f.Description, target) {
+					t.Errorf("description %q does not name %s", f.Description, target)
+				}
+			}
+			if strings.Contains(f.Description, ownFunction) {
+				t.Errorf("description %q names a same-account target", f.Description)
+			}
+		})
+	}
+}
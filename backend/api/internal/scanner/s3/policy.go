@@ -0,0 +1,218 @@
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+)
+
+// restrictingConditionKeys are the condition keys that limit a "*" principal
+// to a network or to known accounts. Condition keys are case-insensitive.
+var restrictingConditionKeys = map[string]bool{
+	"aws:sourcevpc":        true,
+	"aws:sourcevpce":       true,
+	"aws:sourceip":         true,
+	"aws:sourceaccount":    true,
+	"aws:principalaccount": true,
+	"aws:principalorgid":   true,
+	"aws:principalarn":     true,
+	"aws:sourcearn":        true,
+}
+
+// openCIDRs are aws:SourceIp values that match every address.
+var openCIDRs = map[string]bool{
+	"0.0.0.0/0": true,
+	"::/0":      true,
+}
+
+// checkBucketPolicyOverbroad flags bucket policies that grant the "*"
+// principal access gated only by conditions that do not limit who the caller
+// is, such as aws:SecureTransport or aws:Referer. GetBucketPolicyStatus does
+// not treat some of these as public, so the policy is analyzed directly.
+func (s *Scanner) checkBucketPolicyOverbroad(ctx context.Context, bucketName string) []scanner.Finding {
+	policy, err := s.client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{s.accessDeniedFinding("s3_bucket_policy_overbroad", bucketName, err)}
+		}
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchBucketPolicy" {
+			return []scanner.Finding{s.createFinding(
+				"s3_bucket_policy_overbroad",
+				bucketName,
+				"S3 bucket has no bucket policy",
+				fmt.Sprintf("Bucket %s has no bucket policy configured", bucketName),
+				scanner.StatusPass,
+				scanner.SeverityHigh,
+			)}
+		}
+		return nil
+	}
+
+	statements, err := analyzeBucketPolicy(aws.ToString(policy.Policy))
+	if err != nil {
+		scanner.Logf(ctx, "Warning: failed to parse policy of bucket %s: %v", bucketName, err)
+		return nil
+	}
+	if len(statements) > 0 {
+		return []scanner.Finding{s.createFinding(
+			"s3_bucket_policy_overbroad",
+			bucketName,
+			"S3 bucket policy grants over-broad access",
+			fmt.Sprintf("Bucket %s policy grants any principal access without a VPC, IP, or account condition in statements %v", bucketName, statements),
+			scanner.StatusFail,
+			scanner.SeverityHigh,
+		)}
+	}
+	return []scanner.Finding{s.createFinding(
+		"s3_bucket_policy_overbroad",
+		bucketName,
+		"S3 bucket policy restricts its principals",
+		fmt.Sprintf("Bucket %s policy only grants access to specific principals or networks", bucketName),
+		scanner.StatusPass,
+		scanner.SeverityHigh,
+	)}
+}
+
+// analyzeBucketPolicy returns the Sid of each Allow statement in a bucket
+// policy that grants the "*" principal access without a restricting
+// condition. Statements without a Sid are reported by their index.
+func analyzeBucketPolicy(doc string) ([]string, error) {
+	var policy struct {
+		Statement statementList `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(doc), &policy); err != nil {
+		return nil, fmt.Errorf("decoding policy: %w", err)
+	}
+
+	var overbroad []string
+	for i, stmt := range policy.Statement {
+		if stmt.Effect != "Allow" || !isPublicPrincipal(stmt.Principal) || hasRestrictingCondition(stmt.Condition) {
+			continue
+		}
+		sid := stmt.Sid
+		if sid == "" {
+			sid = fmt.Sprintf("#%d", i)
+		}
+		overbroad = append(overbroad, sid)
+	}
+	return overbroad, nil
+}
+
+// policyStatement is the subset of a policy statement the analyzer reads.
+type policyStatement struct {
+	Sid       string                            `json:"Sid"`
+	Effect    string                            `json:"Effect"`
+	Principal interface{}                       `json:"Principal"`
+	Condition map[string]map[string]interface{} `json:"Condition"`
+}
+
+// statementList decodes a policy's Statement, which may be a single
+// statement object instead of a list.
+type statementList []policyStatement
+
+func (l *statementList) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		var stmt policyStatement
+		if err := json.Unmarshal(data, &stmt); err != nil {
+			return err
+		}
+		*l = statementList{stmt}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]policyStatement)(l))
+}
+
+// isPublicPrincipal reports whether principal is "*" or {"AWS": "*"}, in
+// either its string or list form.
+func isPublicPrincipal(principal interface{}) bool {
+	switch p := principal.(type) {
+	case string:
+		return p == "*"
+	case map[string]interface{}:
+		switch v := p["AWS"].(type) {
+		case string:
+			return v == "*"
+		case []interface{}:
+			for _, item := range v {
+				if s, ok := item.(string); ok && s == "*" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// hasRestrictingCondition reports whether any condition limits one of the
+// restrictingConditionKeys to specific values. Negated operators such as
+// StringNotEquals, Null, and IfExists operators, which match requests without
+// the key, do not restrict, and neither do wildcard values such as an
+// aws:PrincipalOrgID of "o-*" or an aws:SourceIp of 0.0.0.0/0.
+func hasRestrictingCondition(condition map[string]map[string]interface{}) bool {
+	for operator, keys := range condition {
+		op := strings.ToLower(operator)
+		if op == "null" || strings.Contains(op, "not") || strings.HasSuffix(op, "ifexists") {
+			continue
+		}
+		for key, value := range keys {
+			if restrictingConditionKeys[strings.ToLower(key)] && isSpecificValue(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isSpecificValue reports whether every value of a condition key names
+// something specific, rather than a wildcard or an open CIDR range.
+func isSpecificValue(value interface{}) bool {
+	var values []string
+	switch v := value.(type) {
+	case string:
+		values = []string{v}
+	case []interface{}:
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return false
+			}
+			values = append(values, s)
+		}
+	default:
+		return false
+	}
+	if len(values) == 0 {
+		return false
+	}
+
+	for _, v := range values {
+		if openCIDRs[v] || isWildcard(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// isWildcard reports whether v is a pattern that matches any account or
+// organization, such as "*", "o-*", or "arn:aws:iam::*:root".
+func isWildcard(v string) bool {
+	if !strings.ContainsAny(v, "*?") {
+		return false
+	}
+	if fields := strings.Split(v, ":"); len(fields) >= 5 && fields[0] == "arn" {
+		// An ARN pattern is specific as long as it pins the account.
+		return fields[4] == "" || strings.ContainsAny(fields[4], "*?")
+	}
+	return true
+}
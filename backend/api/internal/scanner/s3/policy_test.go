@@ -0,0 +1,159 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/smithy-go"
+)
+
+func TestAnalyzeBucketPolicy(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want []string
+	}{
+		{
+			name: "public read",
+			doc:  `{"Statement":[{"Sid":"read","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::data/*"}]}`,
+			want: []string{"read"},
+		},
+		{
+			name: "single statement object",
+			doc:  `{"Statement":{"Effect":"Allow","Principal":{"AWS":"*"},"Action":"s3:GetObject","Resource":"arn:aws:s3:::data/*"}}`,
+			want: []string{"#0"},
+		},
+		{
+			name: "secure transport only",
+			doc: `{"Statement":[{"Sid":"tls","Effect":"Allow","Principal":"*","Action":"s3:GetObject",
+				"Condition":{"Bool":{"aws:SecureTransport":"true"}}}]}`,
+			want: []string{"tls"},
+		},
+		{
+			name: "referer only",
+			doc: `{"Statement":[{"Sid":"site","Effect":"Allow","Principal":"*","Action":"s3:GetObject",
+				"Condition":{"StringLike":{"aws:Referer":"https://example.com/*"}}}]}`,
+			want: []string{"site"},
+		},
+		{
+			name: "VPC endpoint",
+			doc: `{"Statement":[{"Sid":"vpce","Effect":"Allow","Principal":"*","Action":"s3:*",
+				"Condition":{"StringEquals":{"aws:SourceVpce":"vpce-1a2b3c4d"}}}]}`,
+		},
+		{
+			name: "IP allowlist",
+			doc: `{"Statement":[{"Sid":"office","Effect":"Allow","Principal":"*","Action":"s3:GetObject",
+				"Condition":{"IpAddress":{"aws:SourceIp":["203.0.113.0/24","198.51.100.7/32"]}}}]}`,
+		},
+		{
+			name: "open CIDR",
+			doc: `{"Statement":[{"Sid":"any","Effect":"Allow","Principal":"*","Action":"s3:GetObject",
+				"Condition":{"IpAddress":{"aws:SourceIp":"0.0.0.0/0"}}}]}`,
+			want: []string{"any"},
+		},
+		{
+			name: "exact organization",
+			doc: `{"Statement":[{"Sid":"org","Effect":"Allow","Principal":"*","Action":"s3:GetObject",
+				"Condition":{"StringEquals":{"aws:PrincipalOrgID":"o-a1b2c3d4e5"}}}]}`,
+		},
+		{
+			name: "wildcard organization",
+			doc: `{"Statement":[{"Sid":"anyorg","Effect":"Allow","Principal":"*","Action":"s3:GetObject",
+				"Condition":{"StringLike":{"aws:PrincipalOrgID":"o-*"}}}]}`,
+			want: []string{"anyorg"},
+		},
+		{
+			name: "source account",
+			doc: `{"Statement":[{"Sid":"logs","Effect":"Allow","Principal":"*","Action":"s3:PutObject",
+				"Condition":{"StringEquals":{"AWS:SourceAccount":"123456789012"}}}]}`,
+		},
+		{
+			name: "principal ARN pinned to an account",
+			doc: `{"Statement":[{"Sid":"roles","Effect":"Allow","Principal":"*","Action":"s3:GetObject",
+				"Condition":{"ArnLike":{"aws:PrincipalArn":"arn:aws:iam::123456789012:role/app-*"}}}]}`,
+		},
+		{
+			name: "principal ARN in any account",
+			doc: `{"Statement":[{"Sid":"anyacct","Effect":"Allow","Principal":"*","Action":"s3:GetObject",
+				"Condition":{"ArnLike":{"aws:PrincipalArn":"arn:aws:iam::*:role/app"}}}]}`,
+			want: []string{"anyacct"},
+		},
+		{
+			name: "negated VPC",
+			doc: `{"Statement":[{"Sid":"notvpc","Effect":"Allow","Principal":"*","Action":"s3:GetObject",
+				"Condition":{"StringNotEquals":{"aws:SourceVpc":"vpc-111bbb22"}}}]}`,
+			want: []string{"notvpc"},
+		},
+		{
+			name: "IfExists VPC",
+			doc: `{"Statement":[{"Sid":"ifexists","Effect":"Allow","Principal":"*","Action":"s3:GetObject",
+				"Condition":{"StringEqualsIfExists":{"aws:SourceVpc":"vpc-111bbb22"}}}]}`,
+			want: []string{"ifexists"},
+		},
+		{
+			name: "specific principal",
+			doc:  `{"Statement":[{"Sid":"acct","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::210987654321:root"},"Action":"s3:GetObject"}]}`,
+		},
+		{
+			name: "deny statement",
+			doc:  `{"Statement":[{"Sid":"deny","Effect":"Deny","Principal":"*","Action":"s3:*","Condition":{"Bool":{"aws:SecureTransport":"false"}}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := analyzeBucketPolicy(tt.doc)
+			if err != nil {
+				t.Fatalf("analyzeBucketPolicy() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("analyzeBucketPolicy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeBucketPolicy_Invalid(t *testing.T) {
+	if _, err := analyzeBucketPolicy("not json"); err == nil {
+		t.Error("analyzeBucketPolicy() error = nil, want a decoding error")
+	}
+}
+
+func TestCheckBucketPolicyOverbroad(t *testing.T) {
+	tests := []struct {
+		name   string
+		client *cannedS3Client
+		want   scanner.FindingStatus // empty when no finding is expected
+	}{
+		{"over-broad policy", &cannedS3Client{policy: `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject"}]}`}, scanner.StatusFail},
+		{"restricted policy", &cannedS3Client{policy: allowReadPolicy}, scanner.StatusPass},
+		{"no bucket policy", &cannedS3Client{err: &smithy.GenericAPIError{Code: "NoSuchBucketPolicy"}}, scanner.StatusPass},
+		{"access denied", &cannedS3Client{err: accessDenied("GetBucketPolicy")}, scanner.StatusError},
+		{"other error", &cannedS3Client{err: errors.New("throttled")}, ""},
+		{"malformed policy", &cannedS3Client{policy: "{"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Scanner{client: tt.client, region: "us-east-1", accountID: "123456789012"}
+
+			findings := s.checkBucketPolicyOverbroad(context.Background(), "data")
+			if tt.want == "" {
+				if len(findings) != 0 {
+					t.Errorf("expected no findings, got %+v", findings)
+				}
+				return
+			}
+			if len(findings) != 1 {
+				t.Fatalf("expected 1 finding, got %d", len(findings))
+			}
+			if f := findings[0]; f.CheckID != "s3_bucket_policy_overbroad" || f.Status != tt.want {
+				t.Errorf("got %s %s, want s3_bucket_policy_overbroad %s", f.CheckID, f.Status, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+)
+
+// regionalClient sends bucket requests to the bucket's home region. S3
+// rejects requests sent to another region with a PermanentRedirect (301),
+// which the SDK returns as an error rather than following. A redirected
+// request is retried once in the bucket's region, which is then used for the
+// bucket's remaining requests.
+type regionalClient struct {
+	s3API
+	scanner *Scanner
+
+	mu      sync.Mutex
+	regions map[string]string // bucket -> region learned from a redirect
+}
+
+func newRegionalClient(client s3API, s *Scanner) *regionalClient {
+	return &regionalClient{s3API: client, scanner: s, regions: make(map[string]string)}
+}
+
+// isRedirect reports whether err is S3 refusing a request sent to the wrong
+// region for the bucket.
+func isRedirect(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() == "PermanentRedirect" {
+		return true
+	}
+	var respErr *awshttp.ResponseError
+	return errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusMovedPermanently
+}
+
+// inRegion returns an option sending a request to region.
+func inRegion(region string) func(*s3.Options) {
+	return func(o *s3.Options) {
+		o.Region = region
+	}
+}
+
+// knownRegion returns the region a redirect showed bucket to be in.
+func (c *regionalClient) knownRegion(bucket string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	region, ok := c.regions[bucket]
+	return region, ok
+}
+
+// relocate looks up the region of a bucket whose request was redirected. The
+// cached location, now known to be stale, is dropped first.
+func (c *regionalClient) relocate(ctx context.Context, bucket string) (string, bool) {
+	if c.scanner.locations != nil {
+		c.scanner.locations.Invalidate(c.scanner.accountID, bucket)
+	}
+	region, lookupErr := c.scanner.bucketRegion(ctx, bucket)
+	if lookupErr != nil {
+		scanner.Logf(ctx, "Warning: bucket %s was redirected but its region could not be resolved: %v", bucket, lookupErr)
+		return "", false
+	}
+
+	c.mu.Lock()
+	c.regions[bucket] = region
+	c.mu.Unlock()
+	return region, true
+}
+
+// bucketRequest runs call against bucket's region, retrying once in the
+// bucket's actual region if S3 redirects it.
+func bucketRequest[T any](ctx context.Context, c *regionalClient, bucket *string, optFns []func(*s3.Options), call func(optFns ...func(*s3.Options)) (T, error)) (T, error) {
+	name := aws.ToString(bucket)
+	if region, ok := c.knownRegion(name); ok {
+		optFns = append(optFns, inRegion(region))
+	}
+
+	out, err := call(optFns...)
+	if err == nil || !isRedirect(err) {
+		return out, err
+	}
+	region, ok := c.relocate(ctx, name)
+	if !ok {
+		return out, err
+	}
+	return call(append(optFns, inRegion(region))...)
+}
+
+func (c *regionalClient) GetBucketAcl(ctx context.Context, params *s3.GetBucketAclInput, optFns ...func(*s3.Options)) (*s3.GetBucketAclOutput, error) {
+	return bucketRequest(ctx, c, params.Bucket, optFns, func(optFns ...func(*s3.Options)) (*s3.GetBucketAclOutput, error) {
+		return c.s3API.GetBucketAcl(ctx, params, optFns...)
+	})
+}
+
+func (c *regionalClient) GetBucketPolicyStatus(ctx context.Context, params *s3.GetBucketPolicyStatusInput, optFns ...func(*s3.Options)) (*s3.GetBucketPolicyStatusOutput, error) {
+	return bucketRequest(ctx, c, params.Bucket, optFns, func(optFns ...func(*s3.Options)) (*s3.GetBucketPolicyStatusOutput, error) {
+		return c.s3API.GetBucketPolicyStatus(ctx, params, optFns...)
+	})
+}
+
+func (c *regionalClient) GetBucketPolicy(ctx context.Context, params *s3.GetBucketPolicyInput, optFns ...func(*s3.Options)) (*s3.GetBucketPolicyOutput, error) {
+	return bucketRequest(ctx, c, params.Bucket, optFns, func(optFns ...func(*s3.Options)) (*s3.GetBucketPolicyOutput, error) {
+		return c.s3API.GetBucketPolicy(ctx, params, optFns...)
+	})
+}
+
+func (c *regionalClient) GetBucketEncryption(ctx context.Context, params *s3.GetBucketEncryptionInput, optFns ...func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+	return bucketRequest(ctx, c, params.Bucket, optFns, func(optFns ...func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+		return c.s3API.GetBucketEncryption(ctx, params, optFns...)
+	})
+}
+
+func (c *regionalClient) GetBucketVersioning(ctx context.Context, params *s3.GetBucketVersioningInput, optFns ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error) {
+	return bucketRequest(ctx, c, params.Bucket, optFns, func(optFns ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error) {
+		return c.s3API.GetBucketVersioning(ctx, params, optFns...)
+	})
+}
+
+func (c *regionalClient) GetBucketLogging(ctx context.Context, params *s3.GetBucketLoggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+	return bucketRequest(ctx, c, params.Bucket, optFns, func(optFns ...func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+		return c.s3API.GetBucketLogging(ctx, params, optFns...)
+	})
+}
+
+func (c *regionalClient) GetPublicAccessBlock(ctx context.Context, params *s3.GetPublicAccessBlockInput, optFns ...func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error) {
+	return bucketRequest(ctx, c, params.Bucket, optFns, func(optFns ...func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error) {
+		return c.s3API.GetPublicAccessBlock(ctx, params, optFns...)
+	})
+}
+
+func (c *regionalClient) GetBucketLifecycleConfiguration(ctx context.Context, params *s3.GetBucketLifecycleConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+	return bucketRequest(ctx, c, params.Bucket, optFns, func(optFns ...func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+		return c.s3API.GetBucketLifecycleConfiguration(ctx, params, optFns...)
+	})
+}
+
+func (c *regionalClient) GetObjectLockConfiguration(ctx context.Context, params *s3.GetObjectLockConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetObjectLockConfigurationOutput, error) {
+	return bucketRequest(ctx, c, params.Bucket, optFns, func(optFns ...func(*s3.Options)) (*s3.GetObjectLockConfigurationOutput, error) {
+		return c.s3API.GetObjectLockConfiguration(ctx, params, optFns...)
+	})
+}
+
+func (c *regionalClient) GetBucketTagging(ctx context.Context, params *s3.GetBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+	return bucketRequest(ctx, c, params.Bucket, optFns, func(optFns ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+		return c.s3API.GetBucketTagging(ctx, params, optFns...)
+	})
+}
+
+func (c *regionalClient) GetBucketWebsite(ctx context.Context, params *s3.GetBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.GetBucketWebsiteOutput, error) {
+	return bucketRequest(ctx, c, params.Bucket, optFns, func(optFns ...func(*s3.Options)) (*s3.GetBucketWebsiteOutput, error) {
+		return c.s3API.GetBucketWebsite(ctx, params, optFns...)
+	})
+}
+
+func (c *regionalClient) GetBucketReplication(ctx context.Context, params *s3.GetBucketReplicationInput, optFns ...func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+	return bucketRequest(ctx, c, params.Bucket, optFns, func(optFns ...func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+		return c.s3API.GetBucketReplication(ctx, params, optFns...)
+	})
+}
+
+func (c *regionalClient) GetBucketNotificationConfiguration(ctx context.Context, params *s3.GetBucketNotificationConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketNotificationConfigurationOutput, error) {
+	return bucketRequest(ctx, c, params.Bucket, optFns, func(optFns ...func(*s3.Options)) (*s3.GetBucketNotificationConfigurationOutput, error) {
+		return c.s3API.GetBucketNotificationConfiguration(ctx, params, optFns...)
+	})
+}
@@ -0,0 +1,83 @@
+package s3
+
+import (
+	"context"
+	"testing"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+// redirectingClient behaves like S3 for a bucket homed in bucketRegion:
+// versioning requests sent to any other region are redirected.
+type redirectingClient struct {
+	locationClient
+	bucketRegion string
+	requests     []string // region of each versioning request
+}
+
+func (f *redirectingClient) GetBucketVersioning(_ context.Context, _ *s3.GetBucketVersioningInput, optFns ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error) {
+	opts := s3.Options{Region: "us-east-1"}
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+	f.requests = append(f.requests, opts.Region)
+	if opts.Region != f.bucketRegion {
+		return nil, &smithy.GenericAPIError{Code: "PermanentRedirect", Message: "The bucket you are attempting to access must be addressed using the specified endpoint."}
+	}
+	return &s3.GetBucketVersioningOutput{Status: types.BucketVersioningStatusEnabled}, nil
+}
+
+func TestRegionalClient_FollowsRedirect(t *testing.T) {
+	fake := &redirectingClient{
+		locationClient: locationClient{regions: map[string]string{"moved": "eu-west-1"}},
+		bucketRegion:   "eu-west-1",
+	}
+	cache := NewLocationCache(0, 0)
+	// The bucket was recreated in eu-west-1 after its region was cached.
+	cache.Put("123456789012", "moved", "us-east-1")
+
+	s := &Scanner{region: "us-east-1", accountID: "123456789012", locations: cache}
+	s.client = newRegionalClient(fake, s)
+
+	findings := s.checkVersioning(context.Background(), "moved")
+	if len(findings) != 1 || findings[0].Status != scanner.StatusPass {
+		t.Fatalf("findings = %+v, want one PASS finding", findings)
+	}
+	if want := []string{"us-east-1", "eu-west-1"}; len(fake.requests) != 2 || fake.requests[0] != want[0] || fake.requests[1] != want[1] {
+		t.Errorf("requests went to %v, want %v", fake.requests, want)
+	}
+	if region, ok := cache.Get("123456789012", "moved"); !ok || region != "eu-west-1" {
+		t.Errorf("cached region = %q, %v; want eu-west-1", region, ok)
+	}
+
+	// Later requests for the bucket go straight to its region.
+	s.checkVersioning(context.Background(), "moved")
+	if got := fake.requests[2:]; len(got) != 1 || got[0] != "eu-west-1" {
+		t.Errorf("follow-up requests went to %v, want [eu-west-1]", got)
+	}
+	if fake.locationCalls != 1 {
+		t.Errorf("GetBucketLocation called %d times, want 1", fake.locationCalls)
+	}
+}
+
+func TestRegionalClient_UnresolvableRedirect(t *testing.T) {
+	// The bucket no longer exists, so its region cannot be looked up and the
+	// redirect is returned as is.
+	fake := &redirectingClient{bucketRegion: "eu-west-1"}
+	s := &Scanner{region: "us-east-1", accountID: "123456789012"}
+	client := newRegionalClient(fake, s)
+	s.client = client
+
+	_, err := client.GetBucketVersioning(context.Background(), &s3.GetBucketVersioningInput{Bucket: aws.String("gone")})
+	if !isRedirect(err) {
+		t.Errorf("err = %v, want the redirect", err)
+	}
+	if len(fake.requests) != 1 {
+		t.Errorf("made %d requests, want 1", len(fake.requests))
+	}
+}
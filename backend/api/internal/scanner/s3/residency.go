@@ -0,0 +1,65 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"cloudcop/api/internal/scanner"
+)
+
+// DataResidency restricts which regions buckets may be located in, such as
+// keeping buckets holding personal data inside the EU for GDPR.
+type DataResidency struct {
+	// AllowedRegions are the regions matched buckets may be located in.
+	AllowedRegions []string
+	// BucketPrefixes limits the check to buckets whose names start with one
+	// of the prefixes; a full bucket name matches that bucket. When empty,
+	// every bucket is checked.
+	BucketPrefixes []string
+}
+
+// matches reports whether the policy applies to bucketName.
+func (d DataResidency) matches(bucketName string) bool {
+	if len(d.BucketPrefixes) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(d.BucketPrefixes, func(prefix string) bool {
+		return strings.HasPrefix(bucketName, prefix)
+	})
+}
+
+// checkDataResidency fails buckets located outside the configured allowed
+// regions. It only runs when a data residency policy is configured.
+func (s *Scanner) checkDataResidency(ctx context.Context, bucketName string) []scanner.Finding {
+	if s.residency == nil || len(s.residency.AllowedRegions) == 0 || !s.residency.matches(bucketName) {
+		return nil
+	}
+
+	region, err := s.bucketRegion(ctx, bucketName)
+	if err != nil {
+		scanner.Logf(ctx, "Warning: could not resolve the region of bucket %s for data residency: %v", bucketName, err)
+		return nil
+	}
+
+	allowed := strings.Join(s.residency.AllowedRegions, ", ")
+	if !slices.Contains(s.residency.AllowedRegions, region) {
+		return []scanner.Finding{s.createFinding(
+			"s3_data_residency",
+			bucketName,
+			"S3 bucket is located outside the allowed regions",
+			fmt.Sprintf("Bucket %s is in %s, which is not one of the allowed regions: %s", bucketName, region, allowed),
+			scanner.StatusFail,
+			scanner.SeverityHigh,
+		)}
+	}
+	return []scanner.Finding{s.createFinding(
+		"s3_data_residency",
+		bucketName,
+		"S3 bucket is located in an allowed region",
+		fmt.Sprintf("Bucket %s is in %s, one of the allowed regions: %s", bucketName, region, allowed),
+		scanner.StatusPass,
+		scanner.SeverityHigh,
+	)}
+}
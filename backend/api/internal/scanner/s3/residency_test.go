@@ -0,0 +1,62 @@
+package s3
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"cloudcop/api/internal/scanner"
+)
+
+func TestCheckDataResidency(t *testing.T) {
+	locations := NewLocationCache(0, 0)
+	for bucket, region := range map[string]string{
+		"pii-customers":  "eu-west-1",
+		"pii-analytics":  "us-east-1",
+		"public-assets":  "us-east-1",
+		"invoices":       "eu-central-1",
+		"invoices-cache": "ap-south-1",
+	} {
+		locations.Put("123456789012", bucket, region)
+	}
+	policy := DataResidency{
+		AllowedRegions: []string{"eu-west-1", "eu-central-1"},
+		BucketPrefixes: []string{"pii-", "invoices"},
+	}
+
+	tests := []struct {
+		bucket string
+		policy *DataResidency
+		want   scanner.FindingStatus // empty when no finding is expected
+	}{
+		{"pii-customers", &policy, scanner.StatusPass},
+		{"pii-analytics", &policy, scanner.StatusFail},
+		{"invoices", &policy, scanner.StatusPass},
+		{"invoices-cache", &policy, scanner.StatusFail},
+		{"public-assets", &policy, ""},
+		{"pii-analytics", nil, ""},
+		{"public-assets", &DataResidency{AllowedRegions: []string{"eu-west-1"}}, scanner.StatusFail},
+	}
+	for _, tt := range tests {
+		s := &Scanner{region: "us-east-1", accountID: "123456789012", locations: locations, residency: tt.policy}
+
+		findings := s.checkDataResidency(context.Background(), tt.bucket)
+
+		if tt.want == "" {
+			if len(findings) != 0 {
+				t.Errorf("%s: got %+v, want no finding", tt.bucket, findings)
+			}
+			continue
+		}
+		if len(findings) != 1 {
+			t.Fatalf("%s: got %d findings, want 1", tt.bucket, len(findings))
+		}
+		f := findings[0]
+		if f.CheckID != "s3_data_residency" || f.Status != tt.want || f.Severity != scanner.SeverityHigh {
+			t.Errorf("%s: got %s %s/%s, want s3_data_residency %s/HIGH", tt.bucket, f.CheckID, f.Status, f.Severity, tt.want)
+		}
+		if !slices.Contains(f.Compliance, "GDPR-44") {
+			t.Errorf("%s: compliance = %v, want it to include GDPR-44", tt.bucket, f.Compliance)
+		}
+	}
+}
@@ -4,31 +4,102 @@ package s3
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
+	"cloudcop/api/internal/awsauth"
 	"cloudcop/api/internal/scanner"
-	"cloudcop/api/internal/scanner/compliance"
+	"cloudcop/api/internal/scanner/checks"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"golang.org/x/sync/errgroup"
 )
 
+// checkConcurrency is the default number of checks run in parallel per bucket.
+const checkConcurrency = 4
+
+// s3API is the subset of the S3 client used by the scanner.
+type s3API interface {
+	ListBuckets(ctx context.Context, params *s3.ListBucketsInput, optFns ...func(*s3.Options)) (*s3.ListBucketsOutput, error)
+	GetBucketLocation(ctx context.Context, params *s3.GetBucketLocationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error)
+	GetBucketAcl(ctx context.Context, params *s3.GetBucketAclInput, optFns ...func(*s3.Options)) (*s3.GetBucketAclOutput, error)
+	GetBucketPolicyStatus(ctx context.Context, params *s3.GetBucketPolicyStatusInput, optFns ...func(*s3.Options)) (*s3.GetBucketPolicyStatusOutput, error)
+	GetBucketPolicy(ctx context.Context, params *s3.GetBucketPolicyInput, optFns ...func(*s3.Options)) (*s3.GetBucketPolicyOutput, error)
+	GetBucketEncryption(ctx context.Context, params *s3.GetBucketEncryptionInput, optFns ...func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error)
+	GetBucketVersioning(ctx context.Context, params *s3.GetBucketVersioningInput, optFns ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error)
+	GetBucketLogging(ctx context.Context, params *s3.GetBucketLoggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketLoggingOutput, error)
+	GetPublicAccessBlock(ctx context.Context, params *s3.GetPublicAccessBlockInput, optFns ...func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error)
+	GetBucketLifecycleConfiguration(ctx context.Context, params *s3.GetBucketLifecycleConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error)
+	GetObjectLockConfiguration(ctx context.Context, params *s3.GetObjectLockConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetObjectLockConfigurationOutput, error)
+	GetBucketTagging(ctx context.Context, params *s3.GetBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error)
+	GetBucketWebsite(ctx context.Context, params *s3.GetBucketWebsiteInput, optFns ...func(*s3.Options)) (*s3.GetBucketWebsiteOutput, error)
+	GetBucketReplication(ctx context.Context, params *s3.GetBucketReplicationInput, optFns ...func(*s3.Options)) (*s3.GetBucketReplicationOutput, error)
+	GetBucketNotificationConfiguration(ctx context.Context, params *s3.GetBucketNotificationConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketNotificationConfigurationOutput, error)
+}
+
 // Scanner performs security checks on S3 buckets.
 type Scanner struct {
-	client    *s3.Client
+	client    s3API
 	region    string
 	accountID string
+	locations *LocationCache
+
+	checkConcurrency int
+	residency        *DataResidency
+}
+
+// Option configures a Scanner.
+type Option func(*Scanner)
+
+// WithLocationCache resolves bucket regions through cache, so scanners that
+// share it skip GetBucketLocation for buckets already resolved.
+func WithLocationCache(cache *LocationCache) Option {
+	return func(s *Scanner) {
+		s.locations = cache
+	}
+}
+
+// WithCheckConcurrency sets how many of a bucket's checks run in parallel.
+// Non-positive values keep the default.
+func WithCheckConcurrency(n int) Option {
+	return func(s *Scanner) {
+		s.checkConcurrency = n
+	}
+}
+
+// WithDataResidency enables s3_data_residency, failing buckets matched by
+// policy that are located outside its allowed regions.
+func WithDataResidency(policy DataResidency) Option {
+	return func(s *Scanner) {
+		s.residency = &policy
+	}
 }
 
 // NewScanner creates a new S3 scanner using the provided AWS configuration, region, and account ID.
 // The returned Scanner implements scanner.ServiceScanner and uses an S3 client constructed from cfg.
-func NewScanner(cfg aws.Config, region, accountID string) scanner.ServiceScanner {
-	return &Scanner{
-		client:    s3.NewFromConfig(cfg),
+func NewScanner(cfg aws.Config, region, accountID string, opts ...Option) scanner.ServiceScanner {
+	s := &Scanner{
 		region:    region,
 		accountID: accountID,
 	}
+	s.client = newRegionalClient(s3.NewFromConfig(cfg), s)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// NewFactory returns a scanner.Factory that builds Scanners with opts applied.
+// Scanners from the same factory share a bucket location cache unless one is
+// set with WithLocationCache.
+func NewFactory(opts ...Option) scanner.Factory {
+	opts = append([]Option{WithLocationCache(NewLocationCache(0, 0))}, opts...)
+	return func(cfg aws.Config, region, accountID string) scanner.ServiceScanner {
+		return NewScanner(cfg, region, accountID, opts...)
+	}
 }
 
 // Service returns the AWS service name.
@@ -42,50 +113,91 @@ func (s *Scanner) Scan(ctx context.Context, _ string) ([]scanner.Finding, error)
 	if err != nil {
 		return nil, fmt.Errorf("listing buckets: %w", err)
 	}
+	scanner.CountResources(ctx, len(buckets))
 
-	var findings []scanner.Finding
+	return scanner.TraceChecks(ctx, "s3.buckets", func(ctx context.Context) []scanner.Finding {
+		includeTags := scanner.ScopeFromContext(ctx).IncludeTags
+		var findings []scanner.Finding
+		for _, bucket := range buckets {
+			name := aws.ToString(bucket.Name)
+			bucketFindings := scanner.WithResourceCreatedAt(s.scanBucket(ctx, name), bucket.CreationDate)
+			if includeTags {
+				bucketFindings = scanner.WithTags(bucketFindings, s.bucketTags(ctx, name))
+			}
+			findings = append(findings, bucketFindings...)
+		}
+		return findings
+	}), nil
+}
 
-	for _, bucket := range buckets {
-		bucketName := aws.ToString(bucket.Name)
+// bucketCheck evaluates a single check against a bucket.
+type bucketCheck func(ctx context.Context, bucketName string) []scanner.Finding
 
-		// Execute all S3 checks
-		findings = append(findings, s.checkPublicAccess(ctx, bucketName)...)
-		findings = append(findings, s.checkBucketPolicy(ctx, bucketName)...)
-		findings = append(findings, s.checkEncryption(ctx, bucketName)...)
-		findings = append(findings, s.checkVersioning(ctx, bucketName)...)
-		findings = append(findings, s.checkLogging(ctx, bucketName)...)
-		findings = append(findings, s.checkBlockPublicAccess(ctx, bucketName)...)
-		findings = append(findings, s.checkMFADelete(ctx, bucketName)...)
-		findings = append(findings, s.checkLifecyclePolicy(ctx, bucketName)...)
-		findings = append(findings, s.checkSSLOnly(ctx, bucketName)...)
-		findings = append(findings, s.checkObjectLock(ctx, bucketName)...)
+// scanBucket runs every check on a bucket. Each check is an independent API
+// call, so they run concurrently, bounded by the configured check
+// concurrency; findings keep the order of the checks.
+func (s *Scanner) scanBucket(ctx context.Context, bucketName string) []scanner.Finding {
+	// Public-access checks come first so the allow-list can be applied to
+	// their findings together.
+	public := []bucketCheck{
+		s.checkPublicAccess,
+		s.checkBucketPolicy,
+		s.checkBucketPolicyOverbroad,
+		s.checkBlockPublicAccess,
+		s.checkWebsiteConfig,
 	}
+	bucketChecks := slices.Concat(public, []bucketCheck{
+		s.checkEncryption,
+		s.checkBucketKey,
+		s.checkVersioning,
+		s.checkLogging,
+		s.checkMFADelete,
+		s.checkLifecyclePolicy,
+		s.checkSSLOnly,
+		s.checkObjectLock,
+		s.checkReplication,
+		s.checkNotificationTargets,
+		s.checkDataResidency,
+	})
 
-	return findings, nil
+	// Each goroutine writes only its own slot, so no locking is needed.
+	results := make([][]scanner.Finding, len(bucketChecks))
+	var g errgroup.Group
+	g.SetLimit(s.concurrency())
+	for i, check := range bucketChecks {
+		g.Go(func() error {
+			results[i] = check(ctx, bucketName)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	findings := s.applyPublicAllowList(ctx, bucketName, slices.Concat(results[:len(public)]...))
+	return append(findings, slices.Concat(results[len(public):]...)...)
+}
+
+// concurrency returns the configured per-bucket check concurrency.
+func (s *Scanner) concurrency() int {
+	if s.checkConcurrency > 0 {
+		return s.checkConcurrency
+	}
+	return checkConcurrency
 }
 
 func (s *Scanner) listBucketsInRegion(ctx context.Context) ([]types.Bucket, error) {
-	result, err := s.client.ListBuckets(ctx, &s3.ListBucketsInput{})
+	// ListBuckets is global, so every regional scan shares one cached listing.
+	key := scanner.CacheKey{AccountID: s.accountID, Service: s.Service(), Resource: "buckets"}
+	buckets, err := scanner.CachedList(ctx, key, s.listBuckets)
 	if err != nil {
 		return nil, err
 	}
 
 	var bucketsInRegion []types.Bucket
-	for _, bucket := range result.Buckets {
-		bucketName := aws.ToString(bucket.Name)
-		location, err := s.client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{
-			Bucket: aws.String(bucketName),
-		})
+	for _, bucket := range buckets {
+		bucketRegion, err := s.bucketRegion(ctx, aws.ToString(bucket.Name))
 		if err != nil {
 			continue // Skip buckets we can't access
 		}
-
-		// AWS returns empty string for us-east-1
-		bucketRegion := string(location.LocationConstraint)
-		if bucketRegion == "" {
-			bucketRegion = "us-east-1"
-		}
-
 		if bucketRegion == s.region {
 			bucketsInRegion = append(bucketsInRegion, bucket)
 		}
@@ -94,17 +206,83 @@ func (s *Scanner) listBucketsInRegion(ctx context.Context) ([]types.Bucket, erro
 	return bucketsInRegion, nil
 }
 
+// bucketRegion resolves the region of a bucket, consulting the location
+// cache first when one is configured.
+func (s *Scanner) bucketRegion(ctx context.Context, bucketName string) (string, error) {
+	if s.locations != nil {
+		if region, ok := s.locations.Get(s.accountID, bucketName); ok {
+			return region, nil
+		}
+	}
+
+	location, err := s.client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		s.forgetBucketIfGone(bucketName, err)
+		return "", err
+	}
+
+	// AWS returns empty string for us-east-1
+	region := string(location.LocationConstraint)
+	if region == "" {
+		region = "us-east-1"
+	}
+	if s.locations != nil {
+		s.locations.Put(s.accountID, bucketName, region)
+	}
+	return region, nil
+}
+
+// forgetBucketIfGone drops the cached region of a bucket when err shows the
+// bucket is no longer where the cache placed it.
+func (s *Scanner) forgetBucketIfGone(bucketName string, err error) {
+	if s.locations != nil && isBucketGone(err) {
+		s.locations.Invalidate(s.accountID, bucketName)
+	}
+}
+
+func (s *Scanner) listBuckets(ctx context.Context) ([]types.Bucket, error) {
+	result, err := s.client.ListBuckets(ctx, &s3.ListBucketsInput{})
+	if err != nil {
+		return nil, err
+	}
+	return result.Buckets, nil
+}
+
 func (s *Scanner) createFinding(checkID, resourceID, title, description string, status scanner.FindingStatus, severity scanner.Severity) scanner.Finding {
 	return scanner.Finding{
+		FindingID:   scanner.FindingID(s.accountID, s.Service(), s.region, checkID, resourceID),
 		Service:     s.Service(),
 		Region:      s.region,
 		ResourceID:  resourceID,
+		ARN:         s.resourceARN(resourceID),
 		CheckID:     checkID,
 		Status:      status,
 		Severity:    severity,
+		Confidence:  scanner.ConfidenceFor(checkID),
 		Title:       title,
 		Description: description,
-		Compliance:  compliance.GetCompliance(checkID),
+		Compliance:  checks.Compliance(checkID),
 		Timestamp:   time.Now(),
 	}
 }
+
+// resourceARN returns the ARN of the named bucket. Bucket ARNs carry neither
+// a region nor an account.
+func (s *Scanner) resourceARN(bucketName string) string {
+	return arn.ARN{Partition: awsauth.PartitionForRegion(s.region), Service: "s3", Resource: bucketName}.String()
+}
+
+// accessDeniedFinding records that checkID could not be evaluated for
+// resourceID because the scan role was denied the underlying API call.
+func (s *Scanner) accessDeniedFinding(checkID, resourceID string, err error) scanner.Finding {
+	return s.createFinding(
+		checkID,
+		resourceID,
+		"Insufficient permissions to evaluate check",
+		scanner.AccessDeniedDescription(err),
+		scanner.StatusError,
+		scanner.SeverityMedium,
+	)
+}
@@ -1,12 +1,17 @@
 package s3
 
 import (
+	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"cloudcop/api/internal/scanner"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
 func TestNewScanner(t *testing.T) {
@@ -106,6 +111,9 @@ func TestScanner_createFinding(t *testing.T) {
 			if finding.CheckID != tt.checkID {
 				t.Errorf("CheckID = %v, want %v", finding.CheckID, tt.checkID)
 			}
+			if want := scanner.FindingID(s.accountID, finding.Service, finding.Region, tt.checkID, tt.resourceID); finding.FindingID != want {
+				t.Errorf("FindingID = %v, want %v", finding.FindingID, want)
+			}
 			if finding.Status != tt.status {
 				t.Errorf("Status = %v, want %v", finding.Status, tt.status)
 			}
@@ -118,3 +126,182 @@ func TestScanner_createFinding(t *testing.T) {
 		})
 	}
 }
+
+func TestScanner_createFinding_Confidence(t *testing.T) {
+	s := &Scanner{region: "us-east-1", accountID: "123456789012"}
+
+	finding := s.createFinding("s3_bucket_encryption", "my-bucket", "title", "desc", scanner.StatusFail, scanner.SeverityHigh)
+	if finding.Confidence != scanner.ConfidenceHigh {
+		t.Errorf("s3_bucket_encryption Confidence = %v, want %v", finding.Confidence, scanner.ConfidenceHigh)
+	}
+}
+
+// slowS3Client answers every per-bucket call after a fixed latency with an
+// error, tracking how many calls are in flight at once.
+type slowS3Client struct {
+	s3API
+	latency  time.Duration
+	inFlight atomic.Int32
+	peak     atomic.Int32
+	calls    atomic.Int32
+}
+
+func (f *slowS3Client) wait() error {
+	n := f.inFlight.Add(1)
+	defer f.inFlight.Add(-1)
+	for {
+		peak := f.peak.Load()
+		if n <= peak || f.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	f.calls.Add(1)
+	time.Sleep(f.latency)
+	return errors.New("service unavailable")
+}
+
+func (f *slowS3Client) GetBucketAcl(context.Context, *s3.GetBucketAclInput, ...func(*s3.Options)) (*s3.GetBucketAclOutput, error) {
+	return nil, f.wait()
+}
+
+func (f *slowS3Client) GetBucketPolicyStatus(context.Context, *s3.GetBucketPolicyStatusInput, ...func(*s3.Options)) (*s3.GetBucketPolicyStatusOutput, error) {
+	return nil, f.wait()
+}
+
+func (f *slowS3Client) GetBucketPolicy(context.Context, *s3.GetBucketPolicyInput, ...func(*s3.Options)) (*s3.GetBucketPolicyOutput, error) {
+	return nil, f.wait()
+}
+
+func (f *slowS3Client) GetPublicAccessBlock(context.Context, *s3.GetPublicAccessBlockInput, ...func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error) {
+	return nil, f.wait()
+}
+
+func (f *slowS3Client) GetBucketWebsite(context.Context, *s3.GetBucketWebsiteInput, ...func(*s3.Options)) (*s3.GetBucketWebsiteOutput, error) {
+	return nil, f.wait()
+}
+
+func (f *slowS3Client) GetBucketEncryption(context.Context, *s3.GetBucketEncryptionInput, ...func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+	return nil, f.wait()
+}
+
+func (f *slowS3Client) GetBucketVersioning(context.Context, *s3.GetBucketVersioningInput, ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error) {
+	return nil, f.wait()
+}
+
+func (f *slowS3Client) GetBucketLogging(context.Context, *s3.GetBucketLoggingInput, ...func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+	return nil, f.wait()
+}
+
+func (f *slowS3Client) GetBucketLifecycleConfiguration(context.Context, *s3.GetBucketLifecycleConfigurationInput, ...func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+	return nil, f.wait()
+}
+
+func (f *slowS3Client) GetObjectLockConfiguration(context.Context, *s3.GetObjectLockConfigurationInput, ...func(*s3.Options)) (*s3.GetObjectLockConfigurationOutput, error) {
+	return nil, f.wait()
+}
+
+func (f *slowS3Client) GetBucketReplication(context.Context, *s3.GetBucketReplicationInput, ...func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+	return nil, f.wait()
+}
+
+func (f *slowS3Client) GetBucketTagging(context.Context, *s3.GetBucketTaggingInput, ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+	return nil, f.wait()
+}
+
+func (f *slowS3Client) GetBucketNotificationConfiguration(context.Context, *s3.GetBucketNotificationConfigurationInput, ...func(*s3.Options)) (*s3.GetBucketNotificationConfigurationOutput, error) {
+	return nil, f.wait()
+}
+
+func TestScanBucket_RunsChecksConcurrently(t *testing.T) {
+	const latency = 40 * time.Millisecond
+	client := &slowS3Client{latency: latency}
+	s := &Scanner{client: client, region: "us-east-1", accountID: "123456789012"}
+
+	s.scanBucket(context.Background(), "slow-bucket")
+
+	if calls := client.calls.Load(); calls < 10 {
+		t.Fatalf("made %d calls, want one per check", calls)
+	}
+	if peak := int(client.peak.Load()); peak < 2 || peak > checkConcurrency {
+		t.Errorf("peak concurrent calls = %d, want between 2 and %d", peak, checkConcurrency)
+	}
+}
+
+func TestWithCheckConcurrency(t *testing.T) {
+	client := &slowS3Client{latency: time.Millisecond}
+	s := NewScanner(aws.Config{}, "us-east-1", "123456789012", WithCheckConcurrency(1)).(*Scanner)
+	s.client = client
+
+	s.scanBucket(context.Background(), "bucket")
+
+	if peak := client.peak.Load(); peak != 1 {
+		t.Errorf("peak concurrent calls = %d, want 1", peak)
+	}
+}
+
+func TestScanner_resourceARN(t *testing.T) {
+	tests := []struct {
+		region string
+		want   string
+	}{
+		{"us-east-1", "arn:aws:s3:::my-bucket"},
+		{"cn-north-1", "arn:aws-cn:s3:::my-bucket"},
+	}
+	for _, tt := range tests {
+		s := &Scanner{region: tt.region, accountID: "123456789012"}
+		if got := s.createFinding("s3_bucket_encryption", "my-bucket", "", "", scanner.StatusPass, scanner.SeverityHigh).ARN; got != tt.want {
+			t.Errorf("%s: ARN = %q, want %q", tt.region, got, tt.want)
+		}
+	}
+}
+
+// taggedBucketClient lists one unencrypted, tagged bucket in us-east-1. Its
+// other bucket calls fail, so the scan reports only the encryption finding.
+type taggedBucketClient struct {
+	*slowS3Client
+	tagCalls atomic.Int32
+}
+
+func (f *taggedBucketClient) ListBuckets(context.Context, *s3.ListBucketsInput, ...func(*s3.Options)) (*s3.ListBucketsOutput, error) {
+	return &s3.ListBucketsOutput{Buckets: []types.Bucket{{Name: aws.String("data")}}}, nil
+}
+
+func (f *taggedBucketClient) GetBucketLocation(context.Context, *s3.GetBucketLocationInput, ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+	return &s3.GetBucketLocationOutput{}, nil
+}
+
+func (f *taggedBucketClient) GetBucketEncryption(context.Context, *s3.GetBucketEncryptionInput, ...func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+	return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: &types.ServerSideEncryptionConfiguration{}}, nil
+}
+
+func (f *taggedBucketClient) GetBucketTagging(context.Context, *s3.GetBucketTaggingInput, ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+	f.tagCalls.Add(1)
+	return &s3.GetBucketTaggingOutput{TagSet: []types.Tag{{Key: aws.String("team"), Value: aws.String("payments")}}}, nil
+}
+
+func TestScanner_Scan_IncludeTags(t *testing.T) {
+	for _, include := range []bool{true, false} {
+		client := &taggedBucketClient{slowS3Client: &slowS3Client{}}
+		s := &Scanner{client: client, region: "us-east-1", accountID: "123456789012"}
+		ctx := scanner.WithScope(context.Background(), scanner.Scope{IncludeTags: include, PublicAllowList: scanner.PublicAllowList{DisableTag: true}})
+
+		findings, err := s.Scan(ctx, "us-east-1")
+		if err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+		if len(findings) == 0 {
+			t.Fatal("Scan() returned no findings")
+		}
+		for _, f := range findings {
+			if include && f.Tags["team"] != "payments" {
+				t.Errorf("%s tags = %v, want team=payments", f.CheckID, f.Tags)
+			}
+			if !include && f.Tags != nil {
+				t.Errorf("%s tags = %v without IncludeTags, want none", f.CheckID, f.Tags)
+			}
+		}
+		if !include && client.tagCalls.Load() != 0 {
+			t.Errorf("GetBucketTagging called %d times without IncludeTags", client.tagCalls.Load())
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package scanner
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+)
+
+type scanIDKey struct{}
+
+// NewScanID returns a random identifier for a single scan run.
+func NewScanID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b) // crypto/rand.Read never returns an error
+	return "scan-" + hex.EncodeToString(b)
+}
+
+// WithScanID returns a copy of ctx carrying the given scan ID.
+func WithScanID(ctx context.Context, scanID string) context.Context {
+	return context.WithValue(ctx, scanIDKey{}, scanID)
+}
+
+// ScanIDFromContext returns the scan ID attached to ctx, or "" if none is set.
+func ScanIDFromContext(ctx context.Context) string {
+	scanID, _ := ctx.Value(scanIDKey{}).(string)
+	return scanID
+}
+
+// ensureScanID returns ctx and the scan ID it carries, attaching a new ID
+// when the caller has not already chosen one.
+func ensureScanID(ctx context.Context) (context.Context, string) {
+	if scanID := ScanIDFromContext(ctx); scanID != "" {
+		return ctx, scanID
+	}
+	scanID := NewScanID()
+	return WithScanID(ctx, scanID), scanID
+}
+
+// Logf logs a message prefixed with the scan ID in ctx, so the lines of one
+// scan can be correlated across the coordinator and scanners. Credentials in
+// the message are masked.
+func Logf(ctx context.Context, format string, args ...any) {
+	msg := redact(fmt.Sprintf(format, args...))
+	if scanID := ScanIDFromContext(ctx); scanID != "" {
+		msg = "[" + scanID + "] " + msg
+	}
+	log.Print(msg)
+}
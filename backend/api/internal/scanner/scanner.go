@@ -3,6 +3,7 @@ package scanner
 
 import (
 	"context"
+	"slices"
 	"time"
 )
 
@@ -14,6 +15,9 @@ const (
 	StatusPass FindingStatus = "PASS"
 	// StatusFail indicates the resource failed the security check.
 	StatusFail FindingStatus = "FAIL"
+	// StatusError indicates the check could not be evaluated, e.g. because
+	// the scan role lacks a required permission.
+	StatusError FindingStatus = "ERROR"
 )
 
 // Severity represents the severity level of a security finding.
@@ -30,28 +34,68 @@ const (
 	SeverityCritical Severity = "CRITICAL"
 )
 
+// Confidence represents how reliable a check's detection logic is.
+type Confidence string
+
+const (
+	// ConfidenceLow indicates a heuristic check that is prone to false positives.
+	ConfidenceLow Confidence = "LOW"
+	// ConfidenceMedium indicates a heuristic check that is usually accurate.
+	ConfidenceMedium Confidence = "MEDIUM"
+	// ConfidenceHigh indicates a deterministic check against resource configuration.
+	ConfidenceHigh Confidence = "HIGH"
+)
+
 // Finding represents a security finding from a scan.
 type Finding struct {
+	// FindingID identifies the same issue across scans. It is derived from
+	// the account, service, region, check, and resource; see FindingID.
+	FindingID string `json:"finding_id"`
+	// ScanID is the scan run that reported the finding. Unlike FindingID it
+	// differs on every scan.
+	ScanID string `json:"scan_id,omitempty"`
 	// Service is the AWS service name (e.g., "s3", "ec2").
 	Service string `json:"service"`
 	// Region is the AWS region where the finding was detected.
 	Region string `json:"region"`
 	// ResourceID is the AWS resource identifier (ARN or ID).
 	ResourceID string `json:"resource_id"`
+	// ARN is the fully-qualified ARN of the resource, for correlating
+	// findings across services. ResourceID stays the identifier shown to
+	// users. Empty when the finding is not about a single resource, such as
+	// a region-wide check.
+	ARN string `json:"arn,omitempty"`
 	// CheckID is the unique identifier for the security check.
 	CheckID string `json:"check_id"`
 	// Status indicates whether the check passed or failed.
 	Status FindingStatus `json:"status"`
 	// Severity indicates the importance of the finding.
 	Severity Severity `json:"severity"`
+	// Confidence indicates how likely the finding is to be accurate.
+	Confidence Confidence `json:"confidence"`
 	// Title is a short description of the finding.
 	Title string `json:"title"`
 	// Description provides detailed information about the finding.
 	Description string `json:"description"`
 	// Compliance lists the compliance frameworks this check maps to.
 	Compliance []string `json:"compliance"`
+	// Managed marks findings on AWS-managed resources, such as service-linked
+	// roles, that the customer cannot meaningfully change.
+	Managed bool `json:"managed"`
 	// Timestamp is when the finding was detected.
 	Timestamp time.Time `json:"timestamp"`
+	// ResourceCreatedAt is when the resource was created, as reported by
+	// AWS, so the age of a resource can be shown alongside when the issue was
+	// detected. Nil when the resource's metadata has no creation time.
+	ResourceCreatedAt *time.Time `json:"resource_created_at,omitempty"`
+	// Tags are the resource's tags, such as owner or environment, to help
+	// triage. Only set when the scan was configured with IncludeTags.
+	Tags map[string]string `json:"tags,omitempty"`
+	// Aggregated is set on the finding that stands in for the failures a
+	// check reported beyond ScanConfig.MaxFindingsPerCheck, counting those
+	// failures by severity so severity totals and risk scores still include
+	// them. Nil on every other finding.
+	Aggregated *SeverityCounts `json:"aggregated,omitempty"`
 }
 
 // ServiceScanner defines the interface for service-specific scanners.
@@ -66,16 +110,148 @@ type ServiceScanner interface {
 type ScanConfig struct {
 	// AccountID is the AWS account being scanned.
 	AccountID string
-	// Regions is the list of AWS regions to scan.
+	// ConnectionID is the database ID of the connected account being
+	// scanned. The same AWS account can be connected by several teams, so
+	// its credentials, suppressions and saved scans are looked up by this
+	// ID rather than by AccountID. Zero for scans outside a connection.
+	ConnectionID int32
+	// Regions is the list of AWS regions to scan. Empty scans every region
+	// enabled for the account.
 	Regions []string
 	// Services is the list of AWS services to scan.
 	Services []string
+	// ResourceIDs restricts the scan to specific resources in scanners that support targeting.
+	ResourceIDs []string
+	// DisableAccountChecks lists services whose account-level checks are skipped.
+	DisableAccountChecks []string
+	// PublicAllowList names resources in this account that are public on purpose.
+	PublicAllowList PublicAllowList
+	// CheckIDs keeps only findings from these checks when set, and
+	// DisabledChecks drops findings from the checks it lists. Filtered
+	// findings are dropped before counting, like unmanaged ones.
+	CheckIDs       []string
+	DisabledChecks []string
+	// SuppressedFindings lists the finding IDs a team has accepted as risks.
+	// Their findings are dropped before counting, like disabled checks.
+	SuppressedFindings []string
+	// SeverityOverrides replaces the severity of findings by check ID, letting
+	// an organization weight risks differently. Status is never changed.
+	SeverityOverrides map[string]Severity
+	// IncludePassing controls whether passed findings are returned in the
+	// result. Nil means true; pass/fail counts are computed either way.
+	IncludePassing *bool
+	// IncludeManaged controls whether findings on AWS-managed resources are
+	// kept. Nil means true; when false they are dropped before counting, so
+	// they do not affect check totals or the risk score.
+	IncludeManaged *bool
+	// IncludeTags attaches resource tags to findings. Scanners that must
+	// make extra API calls to read tags only do so when it is set.
+	IncludeTags bool
+	// MaxWorkers bounds how many service/region tasks run concurrently.
+	// Zero or negative uses the default of 10; lower it for rate-limited
+	// accounts, raise it for large multi-region scans.
+	MaxWorkers int
+	// RegionConcurrency bounds how many regions are scanned at once, and
+	// ServiceConcurrency how many services run at once within each region.
+	// Each region gets its own service bound, so a large multi-region scan
+	// cannot starve one region's services. MaxWorkers still caps the total.
+	// Zero or negative leaves the level bounded by MaxWorkers alone.
+	RegionConcurrency  int
+	ServiceConcurrency int
+	// MaxFindingsPerCheck limits how many failed findings each check returns
+	// individually. The remaining failures of a check are collapsed into one
+	// aggregate finding whose description lists their resources. Check
+	// counts still include every failure. Zero or negative means no limit.
+	MaxFindingsPerCheck int
+	// TrackAPICalls counts the AWS API requests each service makes during the
+	// scan and reports them in ScanResult.APICallCounts. Retried attempts
+	// count separately, since each is billed and throttled as a request.
+	TrackAPICalls bool
+	// IncrementalSince, when set, lets scanners that implement Incremental
+	// skip resources unmodified since then, usually the start of the last
+	// scan. PriorFindings holds that scan's findings; those of skipped
+	// resources are carried forward into the result.
+	IncrementalSince time.Time
+	PriorFindings    []Finding
+}
+
+// includePassing reports whether passed findings belong in the scan result.
+func (c ScanConfig) includePassing() bool {
+	return c.IncludePassing == nil || *c.IncludePassing
+}
+
+// includeManaged reports whether findings on AWS-managed resources belong in the scan result.
+func (c ScanConfig) includeManaged() bool {
+	return c.IncludeManaged == nil || *c.IncludeManaged
+}
+
+// includesCheck reports whether findings from checkID belong in the scan result.
+func (c ScanConfig) includesCheck(checkID string) bool {
+	if len(c.CheckIDs) > 0 && !slices.Contains(c.CheckIDs, checkID) {
+		return false
+	}
+	return !slices.Contains(c.DisabledChecks, checkID)
+}
+
+// suppressed reports whether findingID has been suppressed.
+func (c ScanConfig) suppressed(findingID string) bool {
+	return findingID != "" && slices.Contains(c.SuppressedFindings, findingID)
+}
+
+// maxWorkers returns the size of the scan's worker pool.
+func (c ScanConfig) maxWorkers() int {
+	if c.MaxWorkers <= 0 {
+		return defaultMaxWorkers
+	}
+	return c.MaxWorkers
+}
+
+// regionConcurrency returns how many regions are scanned at once.
+func (c ScanConfig) regionConcurrency() int {
+	if c.RegionConcurrency <= 0 {
+		return c.maxWorkers()
+	}
+	return min(c.RegionConcurrency, c.maxWorkers())
+}
+
+// serviceConcurrency returns how many services run at once within a region.
+func (c ScanConfig) serviceConcurrency() int {
+	if c.ServiceConcurrency <= 0 {
+		return c.maxWorkers()
+	}
+	return min(c.ServiceConcurrency, c.maxWorkers())
+}
+
+// priorFindings returns the prior findings of service in region.
+func (c ScanConfig) priorFindings(service, region string) []Finding {
+	var prior []Finding
+	for _, f := range c.PriorFindings {
+		if f.Service == service && f.Region == region {
+			prior = append(prior, f)
+		}
+	}
+	return prior
+}
+
+// ScopeFor returns the scan scope that applies to the given service.
+func (c ScanConfig) ScopeFor(service string) Scope {
+	return Scope{
+		ResourceIDs:       c.ResourceIDs,
+		SkipAccountChecks: slices.Contains(c.DisableAccountChecks, service),
+		PublicAllowList:   c.PublicAllowList,
+		IncludeTags:       c.IncludeTags,
+	}
 }
 
 // ScanResult holds the aggregated results of a security scan.
 type ScanResult struct {
+	// ScanID identifies this scan run in logs and findings.
+	ScanID string `json:"scan_id"`
 	// AccountID is the AWS account that was scanned.
 	AccountID string `json:"account_id"`
+	// ConnectionID is the connected account the scan ran for, from
+	// ScanConfig.ConnectionID.
+	ConnectionID int32 `json:"connection_id,omitempty"`
 	// Regions is the list of regions that were scanned.
 	Regions []string `json:"regions"`
 	// Services is the list of services that were scanned.
@@ -92,6 +268,54 @@ type ScanResult struct {
 	PassedChecks int `json:"passed_checks"`
 	// FailedChecks is the number of checks that failed.
 	FailedChecks int `json:"failed_checks"`
+	// ErrorChecks is the number of checks that could not be evaluated.
+	ErrorChecks int `json:"error_checks"`
+	// Coverage records the outcome of each service/region scan so empty
+	// results can be told apart from failures.
+	Coverage []ServiceCoverage `json:"coverage"`
+	// Cancelled reports that the scan context was cancelled before every
+	// task finished. Findings then hold only the tasks that completed, and
+	// Coverage marks the rest as failed.
+	Cancelled bool `json:"cancelled"`
+	// Errors lists the service/region scans that failed, classified so
+	// permission problems can be told apart from transient throttling.
+	Errors []ScanError `json:"errors"`
+	// APICallCounts is the number of AWS API requests made per service,
+	// keyed by SDK service ID such as "S3". Set only when
+	// ScanConfig.TrackAPICalls is enabled.
+	APICallCounts map[string]int `json:"api_call_counts,omitempty"`
+}
+
+// ScanError describes a service/region scan that failed.
+type ScanError struct {
+	Service string    `json:"service"`
+	Region  string    `json:"region"`
+	Type    ErrorType `json:"type"`
+	Message string    `json:"message"`
+}
+
+// CoverageStatus describes the outcome of a single service/region scan.
+type CoverageStatus string
+
+const (
+	// CoverageScanned indicates the scan completed and produced findings.
+	CoverageScanned CoverageStatus = "SCANNED"
+	// CoverageEmpty indicates the scan completed but found no resources to check.
+	CoverageEmpty CoverageStatus = "EMPTY"
+	// CoverageFailed indicates the scan could not be completed.
+	CoverageFailed CoverageStatus = "FAILED"
+)
+
+// ServiceCoverage records what a single service/region scan covered.
+type ServiceCoverage struct {
+	// Service is the AWS service name.
+	Service string `json:"service"`
+	// Region is the AWS region.
+	Region string `json:"region"`
+	// Status is the outcome of the scan.
+	Status CoverageStatus `json:"status"`
+	// Message is a human-readable explanation of the status.
+	Message string `json:"message"`
 }
 
 // ScanItem represents a scan result for a specific service/region combination.
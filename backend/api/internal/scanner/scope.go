@@ -0,0 +1,37 @@
+package scanner
+
+import (
+	"context"
+	"slices"
+)
+
+// Scope narrows what a single service scan covers.
+type Scope struct {
+	// ResourceIDs restricts per-resource checks to the listed resources. Empty means all resources.
+	ResourceIDs []string
+	// SkipAccountChecks disables account-wide sweeps that are not tied to a scanned resource.
+	SkipAccountChecks bool
+	// PublicAllowList downgrades public-access findings for intentionally public resources.
+	PublicAllowList PublicAllowList
+	// IncludeTags asks scanners to attach resource tags to their findings.
+	IncludeTags bool
+}
+
+// Includes reports whether resourceID is within the scope.
+func (s Scope) Includes(resourceID string) bool {
+	return len(s.ResourceIDs) == 0 || slices.Contains(s.ResourceIDs, resourceID)
+}
+
+type scopeKey struct{}
+
+// WithScope returns a copy of ctx carrying the given scan scope.
+func WithScope(ctx context.Context, scope Scope) context.Context {
+	return context.WithValue(ctx, scopeKey{}, scope)
+}
+
+// ScopeFromContext returns the scan scope attached to ctx, or the zero Scope
+// (all resources, account checks enabled) if none is set.
+func ScopeFromContext(ctx context.Context) Scope {
+	scope, _ := ctx.Value(scopeKey{}).(Scope)
+	return scope
+}
@@ -0,0 +1,139 @@
+package scanner
+
+// severityWeights is the risk score contribution of a single failed finding.
+var severityWeights = map[Severity]int{
+	SeverityCritical: 25,
+	SeverityHigh:     10,
+	SeverityMedium:   4,
+	SeverityLow:      1,
+}
+
+// AtLeast reports whether s is as severe as minimum. An empty minimum
+// admits every severity.
+func (s Severity) AtLeast(minimum Severity) bool {
+	return severityWeights[s] >= severityWeights[minimum]
+}
+
+// maxRiskScore caps the risk score so it stays on a 0-100 scale.
+const maxRiskScore = 100
+
+// SeverityCounts holds the number of failed findings at each severity.
+type SeverityCounts struct {
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Medium   int `json:"medium"`
+	Low      int `json:"low"`
+}
+
+// Add increments the count for the given severity by n.
+func (c *SeverityCounts) Add(severity Severity, n int) {
+	switch severity {
+	case SeverityCritical:
+		c.Critical += n
+	case SeverityHigh:
+		c.High += n
+	case SeverityMedium:
+		c.Medium += n
+	case SeverityLow:
+		c.Low += n
+	}
+}
+
+// Total returns the number of failed findings across all severities.
+func (c SeverityCounts) Total() int {
+	return c.Critical + c.High + c.Medium + c.Low
+}
+
+// CountFailedBySeverity tallies failed findings by severity. Passed and
+// errored checks are ignored. An aggregate finding counts every failure it
+// stands in for.
+func CountFailedBySeverity(findings []Finding) SeverityCounts {
+	var counts SeverityCounts
+	for _, f := range findings {
+		switch {
+		case f.Status != StatusFail:
+		case f.Aggregated != nil:
+			counts.Critical += f.Aggregated.Critical
+			counts.High += f.Aggregated.High
+			counts.Medium += f.Aggregated.Medium
+			counts.Low += f.Aggregated.Low
+		default:
+			counts.Add(f.Severity, 1)
+		}
+	}
+	return counts
+}
+
+// SeverityBreakdown counts the result's failed findings per severity.
+// Passed and errored checks are not counted, and severities without a
+// failure are absent from the map.
+func (r *ScanResult) SeverityBreakdown() map[Severity]int {
+	counts := CountFailedBySeverity(r.Findings)
+	breakdown := make(map[Severity]int)
+	for severity, n := range map[Severity]int{
+		SeverityCritical: counts.Critical,
+		SeverityHigh:     counts.High,
+		SeverityMedium:   counts.Medium,
+		SeverityLow:      counts.Low,
+	} {
+		if n > 0 {
+			breakdown[severity] = n
+		}
+	}
+	return breakdown
+}
+
+// StatusCounts holds the number of passed and failed checks.
+type StatusCounts struct {
+	Pass int `json:"pass"`
+	Fail int `json:"fail"`
+}
+
+// ServiceBreakdown counts the result's passed and failed findings per
+// service. Errored checks are not counted. Passed findings are only present
+// when the scan was configured to include them.
+func (r *ScanResult) ServiceBreakdown() map[string]StatusCounts {
+	breakdown := make(map[string]StatusCounts)
+	for _, f := range r.Findings {
+		counts := breakdown[f.Service]
+		switch f.Status {
+		case StatusPass:
+			counts.Pass++
+		case StatusFail:
+			if f.Aggregated != nil {
+				counts.Fail += f.Aggregated.Total()
+			} else {
+				counts.Fail++
+			}
+		default:
+			continue
+		}
+		breakdown[f.Service] = counts
+	}
+	return breakdown
+}
+
+// RiskScore computes a deterministic 0-100 risk score from failed finding
+// counts, where higher is riskier. Unlike the AI summary's score it depends
+// only on the counts, so scores are comparable between scans.
+func RiskScore(counts SeverityCounts) int {
+	score := counts.Critical*severityWeights[SeverityCritical] +
+		counts.High*severityWeights[SeverityHigh] +
+		counts.Medium*severityWeights[SeverityMedium] +
+		counts.Low*severityWeights[SeverityLow]
+	return min(score, maxRiskScore)
+}
+
+// RiskLevel maps a risk score to a level (LOW, MEDIUM, HIGH, CRITICAL).
+func RiskLevel(score int) string {
+	switch {
+	case score >= 75:
+		return string(SeverityCritical)
+	case score >= 50:
+		return string(SeverityHigh)
+	case score >= 25:
+		return string(SeverityMedium)
+	default:
+		return string(SeverityLow)
+	}
+}
@@ -0,0 +1,100 @@
+package scanner
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestRiskScore(t *testing.T) {
+	tests := []struct {
+		name      string
+		counts    SeverityCounts
+		wantScore int
+		wantLevel string
+	}{
+		{"no findings", SeverityCounts{}, 0, "LOW"},
+		{"low only", SeverityCounts{Low: 5}, 5, "LOW"},
+		{"mixed", SeverityCounts{Critical: 1, High: 2, Medium: 1, Low: 1}, 50, "HIGH"},
+		{"capped", SeverityCounts{Critical: 10}, 100, "CRITICAL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := RiskScore(tt.counts)
+			if score != tt.wantScore {
+				t.Errorf("RiskScore() = %d, want %d", score, tt.wantScore)
+			}
+			if level := RiskLevel(score); level != tt.wantLevel {
+				t.Errorf("RiskLevel(%d) = %s, want %s", score, level, tt.wantLevel)
+			}
+		})
+	}
+}
+
+func TestCountFailedBySeverity(t *testing.T) {
+	findings := []Finding{
+		{Status: StatusFail, Severity: SeverityCritical},
+		{Status: StatusFail, Severity: SeverityHigh},
+		{Status: StatusPass, Severity: SeverityHigh},
+		{Status: StatusError, Severity: SeverityMedium},
+		{Status: StatusFail, Severity: SeverityHigh, Aggregated: &SeverityCounts{High: 3, Low: 2}},
+	}
+
+	got := CountFailedBySeverity(findings)
+	want := SeverityCounts{Critical: 1, High: 4, Low: 2}
+	if got != want {
+		t.Errorf("CountFailedBySeverity() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSeverity_AtLeast(t *testing.T) {
+	tests := []struct {
+		severity, minimum Severity
+		want              bool
+	}{
+		{SeverityCritical, SeverityHigh, true},
+		{SeverityHigh, SeverityHigh, true},
+		{SeverityMedium, SeverityHigh, false},
+		{SeverityLow, "", true},
+	}
+	for _, tt := range tests {
+		if got := tt.severity.AtLeast(tt.minimum); got != tt.want {
+			t.Errorf("%s.AtLeast(%q) = %v, want %v", tt.severity, tt.minimum, got, tt.want)
+		}
+	}
+}
+
+func TestScanResult_Breakdowns(t *testing.T) {
+	result := &ScanResult{Findings: []Finding{
+		{Service: "s3", Status: StatusFail, Severity: SeverityCritical},
+		{Service: "s3", Status: StatusFail, Severity: SeverityHigh},
+		{Service: "s3", Status: StatusPass, Severity: SeverityHigh},
+		{Service: "ec2", Status: StatusFail, Severity: SeverityHigh},
+		{Service: "ec2", Status: StatusPass, Severity: SeverityLow},
+		{Service: "ec2", Status: StatusPass, Severity: SeverityMedium},
+		{Service: "iam", Status: StatusPass, Severity: SeverityCritical},
+		{Service: "kms", Status: StatusError, Severity: SeverityMedium},
+		{Service: "ec2", Status: StatusFail, Severity: SeverityMedium, Aggregated: &SeverityCounts{Medium: 4, Low: 1}},
+	}}
+
+	severities := result.SeverityBreakdown()
+	wantSeverities := map[Severity]int{SeverityCritical: 1, SeverityHigh: 2, SeverityMedium: 4, SeverityLow: 1}
+	if !maps.Equal(severities, wantSeverities) {
+		t.Errorf("SeverityBreakdown() = %v, want %v", severities, wantSeverities)
+	}
+
+	services := result.ServiceBreakdown()
+	wantServices := map[string]StatusCounts{
+		"s3":  {Pass: 1, Fail: 2},
+		"ec2": {Pass: 2, Fail: 6},
+		"iam": {Pass: 1},
+	}
+	if !maps.Equal(services, wantServices) {
+		t.Errorf("ServiceBreakdown() = %v, want %v", services, wantServices)
+	}
+
+	empty := &ScanResult{}
+	if len(empty.SeverityBreakdown()) != 0 || len(empty.ServiceBreakdown()) != 0 {
+		t.Error("breakdowns of an empty result are not empty")
+	}
+}
@@ -0,0 +1,40 @@
+package scanner
+
+import (
+	"slices"
+	"strings"
+)
+
+// SecretNameMatcher flags environment variable names that look like they hold
+// secrets. Matching is case-insensitive.
+type SecretNameMatcher struct {
+	patterns []string
+	allowed  []string
+}
+
+// NewSecretNameMatcher returns a matcher that flags names containing any of
+// patterns, except names listed exactly in allowed.
+func NewSecretNameMatcher(patterns, allowed []string) SecretNameMatcher {
+	m := SecretNameMatcher{}
+	for _, p := range patterns {
+		m.patterns = append(m.patterns, strings.ToUpper(p))
+	}
+	for _, a := range allowed {
+		m.allowed = append(m.allowed, strings.ToUpper(a))
+	}
+	return m
+}
+
+// Matches reports whether name looks like a secret.
+func (m SecretNameMatcher) Matches(name string) bool {
+	upper := strings.ToUpper(name)
+	if slices.Contains(m.allowed, upper) {
+		return false
+	}
+	for _, p := range m.patterns {
+		if strings.Contains(upper, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package scanner
+
+import "testing"
+
+func TestSecretNameMatcher(t *testing.T) {
+	m := NewSecretNameMatcher(
+		[]string{"SECRET", "KEY", "acme_"},
+		[]string{"public_key"},
+	)
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"DB_SECRET", true},
+		{"stripe_api_key", true},
+		{"ACME_TOKEN", true},
+		{"PUBLIC_KEY", false},
+		{"Public_Key", false},
+		{"PUBLIC_KEY_PATH", true},
+		{"LOG_LEVEL", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.Matches(tt.name); got != tt.want {
+				t.Errorf("Matches(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
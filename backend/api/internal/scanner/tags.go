@@ -0,0 +1,14 @@
+package scanner
+
+// WithTags sets the resource tags of each finding to tags and returns
+// findings. Like WithResourceCreatedAt, scanners use it on the findings of
+// one resource; empty tags leave them unchanged.
+func WithTags(findings []Finding, tags map[string]string) []Finding {
+	if len(tags) == 0 {
+		return findings
+	}
+	for i := range findings {
+		findings[i].Tags = tags
+	}
+	return findings
+}
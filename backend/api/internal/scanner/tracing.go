@@ -0,0 +1,55 @@
+package scanner
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// TracerName is the instrumentation scope of CloudCop's scan spans.
+const TracerName = "cloudcop/api/internal/scanner"
+
+// Span attribute keys recorded on scan spans.
+const (
+	attrAccountID = attribute.Key("cloudcop.account_id")
+	attrScanID    = attribute.Key("cloudcop.scan_id")
+	attrService   = attribute.Key("cloudcop.service")
+	attrRegion    = attribute.Key("cloudcop.region")
+	attrGroup     = attribute.Key("cloudcop.check_group")
+	attrFindings  = attribute.Key("cloudcop.findings")
+)
+
+// UseTracerProvider makes subsequent scans record spans with tp. Scans are
+// not traced until a provider is configured.
+func (c *Coordinator) UseTracerProvider(tp trace.TracerProvider) {
+	if tp == nil {
+		tp = noop.NewTracerProvider()
+	}
+	c.tracer = tp.Tracer(TracerName)
+}
+
+// TraceChecks runs a group of checks in a child span of the scan task in ctx
+// and records how many findings the group produced. Scanners use it to show
+// where a service's scan time goes.
+func TraceChecks(ctx context.Context, group string, run func(context.Context) []Finding) []Finding {
+	tracer := trace.SpanFromContext(ctx).TracerProvider().Tracer(TracerName)
+	ctx, span := tracer.Start(ctx, "checks."+group, trace.WithAttributes(attrGroup.String(group)))
+	defer span.End()
+
+	findings := run(ctx)
+	span.SetAttributes(attrFindings.Int(len(findings)))
+	return findings
+}
+
+// endTaskSpan records the outcome of a scan task on its span and ends it.
+func endTaskSpan(span trace.Span, result ScanTaskResult) {
+	if result.Error != nil {
+		span.RecordError(result.Error)
+		span.SetStatus(codes.Error, string(ClassifyError(result.Error)))
+	}
+	span.SetAttributes(attrFindings.Int(len(result.Findings)))
+	span.End()
+}
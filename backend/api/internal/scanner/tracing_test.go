@@ -0,0 +1,106 @@
+package scanner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// groupedScanner runs its checks in two traced groups, like the service scanners.
+type groupedScanner struct{}
+
+func (groupedScanner) Service() string { return "s3" }
+
+func (groupedScanner) Scan(ctx context.Context, region string) ([]Finding, error) {
+	findings := TraceChecks(ctx, "s3.buckets", func(context.Context) []Finding {
+		return []Finding{
+			{Service: "s3", Region: region, ResourceID: "a", Status: StatusFail, Severity: SeverityHigh},
+			{Service: "s3", Region: region, ResourceID: "b", Status: StatusFail, Severity: SeverityHigh},
+		}
+	})
+	findings = append(findings, TraceChecks(ctx, "s3.account", func(context.Context) []Finding {
+		return []Finding{{Service: "s3", Region: region, ResourceID: region, Status: StatusFail, Severity: SeverityLow}}
+	})...)
+	return findings, nil
+}
+
+func TestCoordinator_Tracing(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+	coord.UseTracerProvider(tp)
+	coord.RegisterScanner("s3", func(_ aws.Config, _, _ string) ServiceScanner {
+		return groupedScanner{}
+	})
+
+	_, err := coord.StartScan(context.Background(), ScanConfig{
+		AccountID: "123456789012",
+		Regions:   []string{"us-east-1"},
+		Services:  []string{"s3"},
+	})
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+
+	spans := make(map[string]tracetest.SpanStub)
+	for _, s := range exp.GetSpans() {
+		spans[s.Name] = s
+	}
+	if len(spans) != 4 {
+		t.Fatalf("recorded spans %v, want scan, scan.s3, checks.s3.buckets and checks.s3.account", spanNames(exp.GetSpans()))
+	}
+
+	root, task := spans["scan"], spans["scan.s3"]
+	if root.Parent.IsValid() {
+		t.Errorf("scan span has parent %s, want a root span", root.Parent.SpanID())
+	}
+	if task.Parent.SpanID() != root.SpanContext.SpanID() {
+		t.Errorf("scan.s3 is not a child of scan")
+	}
+	for _, group := range []string{"checks.s3.buckets", "checks.s3.account"} {
+		if spans[group].Parent.SpanID() != task.SpanContext.SpanID() {
+			t.Errorf("%s is not a child of scan.s3", group)
+		}
+	}
+
+	wantAttrs := []struct {
+		span string
+		attr attribute.KeyValue
+	}{
+		{"scan", attrAccountID.String("123456789012")},
+		{"scan", attrFindings.Int(3)},
+		{"scan.s3", attrService.String("s3")},
+		{"scan.s3", attrRegion.String("us-east-1")},
+		{"scan.s3", attrFindings.Int(3)},
+		{"checks.s3.buckets", attrFindings.Int(2)},
+		{"checks.s3.account", attrFindings.Int(1)},
+	}
+	for _, want := range wantAttrs {
+		if !hasAttribute(spans[want.span].Attributes, want.attr) {
+			t.Errorf("span %s missing attribute %s=%s", want.span, want.attr.Key, want.attr.Value.Emit())
+		}
+	}
+}
+
+func spanNames(spans tracetest.SpanStubs) []string {
+	names := make([]string, len(spans))
+	for i, s := range spans {
+		names[i] = s.Name
+	}
+	return names
+}
+
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, a := range attrs {
+		if a == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	// fallbackStatus replaces an unknown status. ERROR keeps the finding
+	// visible without counting it as passed or failed.
+	fallbackStatus = StatusError
+	// fallbackSeverity replaces an unknown severity, which would otherwise
+	// weigh nothing in the risk score.
+	fallbackSeverity = SeverityMedium
+)
+
+// Valid reports whether s is one of the defined finding statuses.
+func (s FindingStatus) Valid() bool {
+	switch s {
+	case StatusPass, StatusFail, StatusError:
+		return true
+	}
+	return false
+}
+
+// Valid reports whether s is one of the defined severities.
+func (s Severity) Valid() bool {
+	switch s {
+	case SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical:
+		return true
+	}
+	return false
+}
+
+// Validate returns an error describing an unknown Status or Severity, or nil
+// when both are defined values.
+func (f Finding) Validate() error {
+	var problems []string
+	if !f.Status.Valid() {
+		problems = append(problems, fmt.Sprintf("unknown status %q", f.Status))
+	}
+	if !f.Severity.Valid() {
+		problems = append(problems, fmt.Sprintf("unknown severity %q", f.Severity))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("finding %s/%s: %s", f.CheckID, f.ResourceID, strings.Join(problems, ", "))
+}
+
+// Normalize fixes an invalid Status or Severity in place and reports whether
+// it changed anything. Values that differ from a defined one only in case or
+// surrounding space are corrected; anything else falls back to ERROR status
+// and MEDIUM severity.
+func (f *Finding) Normalize() bool {
+	changed := false
+	if !f.Status.Valid() {
+		status := FindingStatus(strings.ToUpper(strings.TrimSpace(string(f.Status))))
+		if !status.Valid() {
+			status = fallbackStatus
+		}
+		f.Status = status
+		changed = true
+	}
+	if !f.Severity.Valid() {
+		severity := Severity(strings.ToUpper(strings.TrimSpace(string(f.Severity))))
+		if !severity.Valid() {
+			severity = fallbackSeverity
+		}
+		f.Severity = severity
+		changed = true
+	}
+	return changed
+}
+
+// normalizeFindings normalizes every finding a scanner returned, logging a
+// warning for each one that had to be changed.
+func normalizeFindings(ctx context.Context, findings []Finding) {
+	for i := range findings {
+		err := findings[i].Validate()
+		if err == nil {
+			continue
+		}
+		findings[i].Normalize()
+		Logf(ctx, "Warning: %v; using status %s and severity %s", err, findings[i].Status, findings[i].Severity)
+	}
+}
@@ -0,0 +1,105 @@
+package scanner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+func TestFinding_Normalize(t *testing.T) {
+	tests := []struct {
+		name         string
+		finding      Finding
+		wantStatus   FindingStatus
+		wantSeverity Severity
+		wantChanged  bool
+	}{
+		{
+			name:         "valid",
+			finding:      Finding{Status: StatusFail, Severity: SeverityCritical},
+			wantStatus:   StatusFail,
+			wantSeverity: SeverityCritical,
+		},
+		{
+			name:         "lowercase values",
+			finding:      Finding{Status: "fail", Severity: " high "},
+			wantStatus:   StatusFail,
+			wantSeverity: SeverityHigh,
+			wantChanged:  true,
+		},
+		{
+			name:         "unknown values",
+			finding:      Finding{Status: "unknown", Severity: "SEVERE"},
+			wantStatus:   StatusError,
+			wantSeverity: SeverityMedium,
+			wantChanged:  true,
+		},
+		{
+			name:         "empty values",
+			finding:      Finding{},
+			wantStatus:   StatusError,
+			wantSeverity: SeverityMedium,
+			wantChanged:  true,
+		},
+		{
+			name:         "unknown severity only",
+			finding:      Finding{Status: StatusPass, Severity: "info"},
+			wantStatus:   StatusPass,
+			wantSeverity: SeverityMedium,
+			wantChanged:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.finding
+			if err := f.Validate(); (err != nil) != tt.wantChanged {
+				t.Errorf("Validate() error = %v, want error %v", err, tt.wantChanged)
+			}
+			if changed := f.Normalize(); changed != tt.wantChanged {
+				t.Errorf("Normalize() = %v, want %v", changed, tt.wantChanged)
+			}
+			if f.Status != tt.wantStatus || f.Severity != tt.wantSeverity {
+				t.Errorf("got %s/%s, want %s/%s", f.Status, f.Severity, tt.wantStatus, tt.wantSeverity)
+			}
+			if err := f.Validate(); err != nil {
+				t.Errorf("Validate() after Normalize() = %v", err)
+			}
+		})
+	}
+}
+
+func TestCoordinator_StartScan_NormalizesFindings(t *testing.T) {
+	coord := NewCoordinator(aws.Config{}, "123456789012")
+	coord.RegisterScanner("s3", func(_ aws.Config, _, _ string) ServiceScanner {
+		return &mockScanner{
+			service: "s3",
+			findings: []Finding{
+				{CheckID: "s3_bucket_encryption", Status: "fail", Severity: "critical"},
+				{CheckID: "s3_bucket_logging", Status: "unknown", Severity: "unknown"},
+			},
+		}
+	})
+
+	result, err := coord.StartScan(context.Background(), ScanConfig{
+		AccountID: "123456789012",
+		Regions:   []string{"us-east-1"},
+		Services:  []string{"s3"},
+	})
+	if err != nil {
+		t.Fatalf("StartScan() error = %v", err)
+	}
+
+	for _, f := range result.Findings {
+		if err := f.Validate(); err != nil {
+			t.Errorf("StartScan() returned invalid finding: %v", err)
+		}
+	}
+	if result.FailedChecks != 1 || result.ErrorChecks != 1 {
+		t.Errorf("got %d failed / %d errors, want 1 / 1", result.FailedChecks, result.ErrorChecks)
+	}
+	if counts := CountFailedBySeverity(result.Findings); counts.Critical != 1 {
+		t.Errorf("critical failures = %d, want 1", counts.Critical)
+	}
+}
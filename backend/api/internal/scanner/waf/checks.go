@@ -0,0 +1,79 @@
+package waf
+
+import (
+	"fmt"
+
+	"cloudcop/api/internal/scanner"
+)
+
+// webACL is a WAFv2 web ACL and the ARNs of the regional resources it is
+// associated with.
+type webACL struct {
+	name      string
+	arn       string
+	resources []string
+}
+
+// publicResource is an internet-facing resource a web ACL can protect.
+type publicResource struct {
+	kind string
+	name string
+	arn  string
+	// webACLID is the web ACL a CloudFront distribution names in its own
+	// configuration: a WAFv2 ARN, or the ID of a WAF Classic web ACL.
+	webACLID string
+}
+
+// matchWebACLs maps the ARN of each resource protected by a web ACL to that
+// ACL's name. Regional resources are matched against the ACLs' associations;
+// distributions by the web ACL they name, which is reported by ID when it is
+// not one of acls.
+func matchWebACLs(acls []webACL, resources []publicResource) map[string]string {
+	byResource := make(map[string]string)
+	byARN := make(map[string]string, len(acls))
+	for _, acl := range acls {
+		byARN[acl.arn] = acl.name
+		for _, arn := range acl.resources {
+			byResource[arn] = acl.name
+		}
+	}
+
+	associations := make(map[string]string)
+	for _, r := range resources {
+		switch {
+		case r.webACLID != "" && byARN[r.webACLID] != "":
+			associations[r.arn] = byARN[r.webACLID]
+		case r.webACLID != "":
+			associations[r.arn] = r.webACLID
+		case byResource[r.arn] != "":
+			associations[r.arn] = byResource[r.arn]
+		}
+	}
+	return associations
+}
+
+// checkAssociation reports whether a public resource is protected by the
+// web ACL named acl, which is empty when none is associated.
+func (w *Scanner) checkAssociation(r publicResource, acl string) []scanner.Finding {
+	var finding scanner.Finding
+	if acl != "" {
+		finding = w.createFinding(
+			"waf_not_associated",
+			r.name,
+			r.kind+" is protected by a web ACL",
+			fmt.Sprintf("%s %s is associated with web ACL %s", r.kind, r.name, acl),
+			scanner.StatusPass,
+			scanner.SeverityMedium,
+		)
+	} else {
+		finding = w.createFinding(
+			"waf_not_associated",
+			r.name,
+			r.kind+" is not protected by a web ACL",
+			fmt.Sprintf("%s %s is reachable from the internet with no WAF web ACL associated", r.kind, r.name),
+			scanner.StatusFail,
+			scanner.SeverityMedium,
+		)
+	}
+	return scanner.WithARN([]scanner.Finding{finding}, r.arn)
+}
@@ -0,0 +1,336 @@
+// Package waf checks that internet-facing load balancers, API Gateway stages,
+// and CloudFront distributions are protected by an AWS WAF web ACL.
+package waf
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloudcop/api/internal/awsauth"
+	"cloudcop/api/internal/scanner"
+	"cloudcop/api/internal/scanner/checks"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	apigatewaytypes "github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2"
+	waftypes "github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+)
+
+// cloudFrontRegion is the region CloudFront distributions and their web ACLs
+// are managed from. Distributions are global, so they are only checked when
+// scanning this region.
+const cloudFrontRegion = "us-east-1"
+
+// wafAPI is the subset of the WAFv2 client used by the scanner.
+type wafAPI interface {
+	ListWebACLs(ctx context.Context, params *wafv2.ListWebACLsInput, optFns ...func(*wafv2.Options)) (*wafv2.ListWebACLsOutput, error)
+	ListResourcesForWebACL(ctx context.Context, params *wafv2.ListResourcesForWebACLInput, optFns ...func(*wafv2.Options)) (*wafv2.ListResourcesForWebACLOutput, error)
+}
+
+// elbAPI is the subset of the Elastic Load Balancing v2 client used by the scanner.
+type elbAPI interface {
+	DescribeLoadBalancers(ctx context.Context, params *elb.DescribeLoadBalancersInput, optFns ...func(*elb.Options)) (*elb.DescribeLoadBalancersOutput, error)
+}
+
+// apiGatewayAPI is the subset of the API Gateway client used by the scanner.
+type apiGatewayAPI interface {
+	GetRestApis(ctx context.Context, params *apigateway.GetRestApisInput, optFns ...func(*apigateway.Options)) (*apigateway.GetRestApisOutput, error)
+	GetStages(ctx context.Context, params *apigateway.GetStagesInput, optFns ...func(*apigateway.Options)) (*apigateway.GetStagesOutput, error)
+}
+
+// cloudFrontAPI is the subset of the CloudFront client used by the scanner.
+type cloudFrontAPI interface {
+	ListDistributions(ctx context.Context, params *cloudfront.ListDistributionsInput, optFns ...func(*cloudfront.Options)) (*cloudfront.ListDistributionsOutput, error)
+}
+
+// Scanner checks public resources for an associated WAF web ACL.
+type Scanner struct {
+	waf        wafAPI
+	elb        elbAPI
+	apigateway apiGatewayAPI
+	cloudfront cloudFrontAPI
+	region     string
+	accountID  string
+}
+
+// Option configures a Scanner.
+type Option func(*Scanner)
+
+// NewScanner creates a new WAF scanner for the given region and account ID.
+func NewScanner(cfg aws.Config, region, accountID string, opts ...Option) scanner.ServiceScanner {
+	s := &Scanner{
+		waf:        wafv2.NewFromConfig(cfg),
+		elb:        elb.NewFromConfig(cfg),
+		apigateway: apigateway.NewFromConfig(cfg),
+		cloudfront: cloudfront.NewFromConfig(cfg),
+		region:     region,
+		accountID:  accountID,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// NewFactory returns a scanner.Factory that builds Scanners with opts applied.
+func NewFactory(opts ...Option) scanner.Factory {
+	return func(cfg aws.Config, region, accountID string) scanner.ServiceScanner {
+		return NewScanner(cfg, region, accountID, opts...)
+	}
+}
+
+// Service returns the AWS service name.
+func (w *Scanner) Service() string {
+	return "waf"
+}
+
+// Scan checks every public resource in the region for a web ACL.
+func (w *Scanner) Scan(ctx context.Context, _ string) ([]scanner.Finding, error) {
+	// Without the web ACLs no association can be evaluated, so a denied
+	// listing is reported in place of the check.
+	acls, err := w.listWebACLs(ctx, waftypes.ScopeRegional)
+	if scanner.IsAccessDenied(err) {
+		return []scanner.Finding{w.accessDeniedFinding("waf_not_associated", "web-acls", err)}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing web ACLs: %w", err)
+	}
+	if w.region == cloudFrontRegion {
+		global, err := w.listWebACLs(ctx, waftypes.ScopeCloudfront)
+		if scanner.IsAccessDenied(err) {
+			return []scanner.Finding{w.accessDeniedFinding("waf_not_associated", "cloudfront-web-acls", err)}, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("listing CloudFront web ACLs: %w", err)
+		}
+		acls = append(acls, global...)
+	}
+
+	scope := scanner.ScopeFromContext(ctx)
+	return scanner.TraceChecks(ctx, "waf.associations", func(ctx context.Context) []scanner.Finding {
+		var findings []scanner.Finding
+		var resources []publicResource
+
+		loadBalancers, err := w.listPublicLoadBalancers(ctx)
+		if err != nil {
+			findings = append(findings, w.listError(ctx, "load-balancers", err)...)
+		}
+		resources = append(resources, loadBalancers...)
+
+		stages, err := w.listPublicStages(ctx)
+		if err != nil {
+			findings = append(findings, w.listError(ctx, "rest-apis", err)...)
+		}
+		resources = append(resources, stages...)
+
+		if w.region == cloudFrontRegion {
+			distributions, err := w.listDistributions(ctx)
+			if err != nil {
+				findings = append(findings, w.listError(ctx, "distributions", err)...)
+			}
+			resources = append(resources, distributions...)
+		}
+
+		scanner.CountResources(ctx, len(resources))
+		associations := matchWebACLs(acls, resources)
+		for _, r := range resources {
+			if !scope.Includes(r.name) {
+				continue
+			}
+			findings = append(findings, w.checkAssociation(r, associations[r.arn])...)
+		}
+		return findings
+	}), nil
+}
+
+// listWebACLs returns the web ACLs of scope along with the regional
+// resources each one is associated with. CloudFront distributions are not
+// listed here; they name their web ACL themselves.
+func (w *Scanner) listWebACLs(ctx context.Context, scope waftypes.Scope) ([]webACL, error) {
+	var acls []webACL
+	input := &wafv2.ListWebACLsInput{Scope: scope}
+	for {
+		out, err := w.waf.ListWebACLs(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, summary := range out.WebACLs {
+			acl := webACL{name: aws.ToString(summary.Name), arn: aws.ToString(summary.ARN)}
+			if scope == waftypes.ScopeRegional {
+				acl.resources, err = w.listAssociatedResources(ctx, acl.arn)
+				if err != nil {
+					return nil, fmt.Errorf("listing resources of web ACL %s: %w", acl.name, err)
+				}
+			}
+			acls = append(acls, acl)
+		}
+		if aws.ToString(out.NextMarker) == "" {
+			return acls, nil
+		}
+		input.NextMarker = out.NextMarker
+	}
+}
+
+// listAssociatedResources returns the ARNs of the load balancers and API
+// Gateway stages a regional web ACL is associated with.
+func (w *Scanner) listAssociatedResources(ctx context.Context, aclARN string) ([]string, error) {
+	var arns []string
+	for _, resourceType := range []waftypes.ResourceType{
+		waftypes.ResourceTypeApplicationLoadBalancer,
+		waftypes.ResourceTypeApiGateway,
+	} {
+		out, err := w.waf.ListResourcesForWebACL(ctx, &wafv2.ListResourcesForWebACLInput{
+			WebACLArn:    aws.String(aclARN),
+			ResourceType: resourceType,
+		})
+		if err != nil {
+			return nil, err
+		}
+		arns = append(arns, out.ResourceArns...)
+	}
+	return arns, nil
+}
+
+// listPublicLoadBalancers returns the region's internet-facing application
+// load balancers. Network and gateway load balancers cannot use WAF.
+func (w *Scanner) listPublicLoadBalancers(ctx context.Context) ([]publicResource, error) {
+	var resources []publicResource
+	paginator := elb.NewDescribeLoadBalancersPaginator(w.elb, &elb.DescribeLoadBalancersInput{})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, lb := range out.LoadBalancers {
+			if lb.Type != elbtypes.LoadBalancerTypeEnumApplication || lb.Scheme != elbtypes.LoadBalancerSchemeEnumInternetFacing {
+				continue
+			}
+			resources = append(resources, publicResource{
+				kind: "Load balancer",
+				name: aws.ToString(lb.LoadBalancerName),
+				arn:  aws.ToString(lb.LoadBalancerArn),
+			})
+		}
+	}
+	return resources, nil
+}
+
+// listPublicStages returns the stages of the region's REST APIs that are not
+// private. HTTP and WebSocket APIs cannot use WAF.
+func (w *Scanner) listPublicStages(ctx context.Context) ([]publicResource, error) {
+	var resources []publicResource
+	paginator := apigateway.NewGetRestApisPaginator(w.apigateway, &apigateway.GetRestApisInput{})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, api := range out.Items {
+			if isPrivateAPI(api) {
+				continue
+			}
+			stages, err := w.apigateway.GetStages(ctx, &apigateway.GetStagesInput{RestApiId: api.Id})
+			if err != nil {
+				return nil, err
+			}
+			for _, stage := range stages.Item {
+				resources = append(resources, publicResource{
+					kind: "API stage",
+					name: aws.ToString(api.Name) + "/" + aws.ToString(stage.StageName),
+					arn:  w.stageARN(aws.ToString(api.Id), aws.ToString(stage.StageName)),
+				})
+			}
+		}
+	}
+	return resources, nil
+}
+
+// isPrivateAPI reports whether a REST API is only reachable through VPC
+// endpoints.
+func isPrivateAPI(api apigatewaytypes.RestApi) bool {
+	if api.EndpointConfiguration == nil {
+		return false
+	}
+	for _, t := range api.EndpointConfiguration.Types {
+		if t == apigatewaytypes.EndpointTypePrivate {
+			return true
+		}
+	}
+	return false
+}
+
+// listDistributions returns the account's CloudFront distributions with the
+// web ACL each one reports.
+func (w *Scanner) listDistributions(ctx context.Context) ([]publicResource, error) {
+	var resources []publicResource
+	paginator := cloudfront.NewListDistributionsPaginator(w.cloudfront, &cloudfront.ListDistributionsInput{})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if out.DistributionList == nil {
+			continue
+		}
+		for _, d := range out.DistributionList.Items {
+			resources = append(resources, publicResource{
+				kind:     "Distribution",
+				name:     aws.ToString(d.Id),
+				arn:      aws.ToString(d.ARN),
+				webACLID: aws.ToString(d.WebACLId),
+			})
+		}
+	}
+	return resources, nil
+}
+
+// listError reports a resource type that could not be listed: an error
+// finding when the scan role was denied, otherwise a logged warning.
+func (w *Scanner) listError(ctx context.Context, resourceID string, err error) []scanner.Finding {
+	if scanner.IsAccessDenied(err) {
+		return []scanner.Finding{w.accessDeniedFinding("waf_not_associated", resourceID, err)}
+	}
+	scanner.Logf(ctx, "Warning: failed to list %s for WAF check: %v", resourceID, err)
+	return nil
+}
+
+// stageARN returns the ARN of a REST API stage, in the form WAF reports for
+// its associated resources.
+func (w *Scanner) stageARN(apiID, stage string) string {
+	return fmt.Sprintf("arn:%s:apigateway:%s::/restapis/%s/stages/%s", awsauth.PartitionForRegion(w.region), w.region, apiID, stage)
+}
+
+func (w *Scanner) createFinding(checkID, resourceID, title, description string, status scanner.FindingStatus, severity scanner.Severity) scanner.Finding {
+	return scanner.Finding{
+		FindingID:   scanner.FindingID(w.accountID, w.Service(), w.region, checkID, resourceID),
+		Service:     w.Service(),
+		Region:      w.region,
+		ResourceID:  resourceID,
+		CheckID:     checkID,
+		Status:      status,
+		Severity:    severity,
+		Confidence:  scanner.ConfidenceFor(checkID),
+		Title:       title,
+		Description: description,
+		Compliance:  checks.Compliance(checkID),
+		Timestamp:   time.Now(),
+	}
+}
+
+// accessDeniedFinding records that checkID could not be evaluated for
+// resourceID because the scan role was denied the underlying API call.
+func (w *Scanner) accessDeniedFinding(checkID, resourceID string, err error) scanner.Finding {
+	return w.createFinding(
+		checkID,
+		resourceID,
+		"Insufficient permissions to evaluate check",
+		scanner.AccessDeniedDescription(err),
+		scanner.StatusError,
+		scanner.SeverityMedium,
+	)
+}
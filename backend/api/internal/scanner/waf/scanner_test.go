@@ -0,0 +1,235 @@
+package waf
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"testing"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	apigatewaytypes "github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	cloudfronttypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2"
+	waftypes "github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+	"github.com/aws/smithy-go"
+)
+
+const (
+	albARN      = "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/web/1234567890abcdef"
+	openALBARN  = "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/admin/fedcba0987654321"
+	prodStage   = "arn:aws:apigateway:us-east-1::/restapis/a1b2c3/stages/prod"
+	regionalACL = "arn:aws:wafv2:us-east-1:123456789012:regional/webacl/app-acl/11111111"
+	globalACL   = "arn:aws:wafv2:us-east-1:123456789012:global/webacl/edge-acl/22222222"
+)
+
+func TestMatchWebACLs(t *testing.T) {
+	acls := []webACL{
+		{name: "app-acl", arn: regionalACL, resources: []string{albARN, prodStage}},
+		{name: "edge-acl", arn: globalACL},
+		{name: "unused-acl", arn: "arn:aws:wafv2:us-east-1:123456789012:regional/webacl/unused/33333333"},
+	}
+	resources := []publicResource{
+		{kind: "Load balancer", name: "web", arn: albARN},
+		{kind: "Load balancer", name: "admin", arn: openALBARN},
+		{kind: "API stage", name: "orders/prod", arn: prodStage},
+		{kind: "API stage", name: "orders/dev", arn: "arn:aws:apigateway:us-east-1::/restapis/a1b2c3/stages/dev"},
+		{kind: "Distribution", name: "E1WAFV2", arn: "arn:aws:cloudfront::123456789012:distribution/E1WAFV2", webACLID: globalACL},
+		{kind: "Distribution", name: "E2CLASSIC", arn: "arn:aws:cloudfront::123456789012:distribution/E2CLASSIC", webACLID: "473e64fd-f30b-4765-81a0-62ad96dd167a"},
+		{kind: "Distribution", name: "E3OPEN", arn: "arn:aws:cloudfront::123456789012:distribution/E3OPEN"},
+	}
+
+	got := matchWebACLs(acls, resources)
+	want := map[string]string{
+		albARN:    "app-acl",
+		prodStage: "app-acl",
+		"arn:aws:cloudfront::123456789012:distribution/E1WAFV2":   "edge-acl",
+		"arn:aws:cloudfront::123456789012:distribution/E2CLASSIC": "473e64fd-f30b-4765-81a0-62ad96dd167a",
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("matchWebACLs() = %v, want %v", got, want)
+	}
+}
+
+func TestMatchWebACLs_NoACLs(t *testing.T) {
+	got := matchWebACLs(nil, []publicResource{{name: "web", arn: albARN}})
+	if len(got) != 0 {
+		t.Errorf("matchWebACLs() with no web ACLs = %v, want none", got)
+	}
+}
+
+type fakeWAFClient struct {
+	acls      map[waftypes.Scope][]waftypes.WebACLSummary
+	resources map[string][]string
+	listErr   map[waftypes.Scope]error
+}
+
+func (f *fakeWAFClient) ListWebACLs(_ context.Context, in *wafv2.ListWebACLsInput, _ ...func(*wafv2.Options)) (*wafv2.ListWebACLsOutput, error) {
+	if err := f.listErr[in.Scope]; err != nil {
+		return nil, err
+	}
+	return &wafv2.ListWebACLsOutput{WebACLs: f.acls[in.Scope]}, nil
+}
+
+func (f *fakeWAFClient) ListResourcesForWebACL(_ context.Context, in *wafv2.ListResourcesForWebACLInput, _ ...func(*wafv2.Options)) (*wafv2.ListResourcesForWebACLOutput, error) {
+	if in.ResourceType != waftypes.ResourceTypeApplicationLoadBalancer {
+		return &wafv2.ListResourcesForWebACLOutput{}, nil
+	}
+	return &wafv2.ListResourcesForWebACLOutput{ResourceArns: f.resources[aws.ToString(in.WebACLArn)]}, nil
+}
+
+type fakeELBClient struct {
+	loadBalancers []elbtypes.LoadBalancer
+}
+
+func (f *fakeELBClient) DescribeLoadBalancers(context.Context, *elb.DescribeLoadBalancersInput, ...func(*elb.Options)) (*elb.DescribeLoadBalancersOutput, error) {
+	return &elb.DescribeLoadBalancersOutput{LoadBalancers: f.loadBalancers}, nil
+}
+
+type fakeAPIGatewayClient struct {
+	err error
+}
+
+func (f *fakeAPIGatewayClient) GetRestApis(context.Context, *apigateway.GetRestApisInput, ...func(*apigateway.Options)) (*apigateway.GetRestApisOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &apigateway.GetRestApisOutput{Items: []apigatewaytypes.RestApi{{
+		Id:                    aws.String("internal"),
+		Name:                  aws.String("internal"),
+		EndpointConfiguration: &apigatewaytypes.EndpointConfiguration{Types: []apigatewaytypes.EndpointType{apigatewaytypes.EndpointTypePrivate}},
+	}}}, nil
+}
+
+func (f *fakeAPIGatewayClient) GetStages(context.Context, *apigateway.GetStagesInput, ...func(*apigateway.Options)) (*apigateway.GetStagesOutput, error) {
+	return &apigateway.GetStagesOutput{Item: []apigatewaytypes.Stage{{StageName: aws.String("prod")}}}, nil
+}
+
+type fakeCloudFrontClient struct {
+	distributions []cloudfronttypes.DistributionSummary
+}
+
+func (f *fakeCloudFrontClient) ListDistributions(context.Context, *cloudfront.ListDistributionsInput, ...func(*cloudfront.Options)) (*cloudfront.ListDistributionsOutput, error) {
+	return &cloudfront.ListDistributionsOutput{DistributionList: &cloudfronttypes.DistributionList{Items: f.distributions}}, nil
+}
+
+func newTestScanner(region string, apiErr error) *Scanner {
+	return &Scanner{
+		waf: &fakeWAFClient{
+			acls: map[waftypes.Scope][]waftypes.WebACLSummary{
+				waftypes.ScopeRegional:   {{Name: aws.String("app-acl"), ARN: aws.String(regionalACL)}},
+				waftypes.ScopeCloudfront: {{Name: aws.String("edge-acl"), ARN: aws.String(globalACL)}},
+			},
+			resources: map[string][]string{regionalACL: {albARN}},
+		},
+		elb: &fakeELBClient{loadBalancers: []elbtypes.LoadBalancer{
+			{LoadBalancerName: aws.String("web"), LoadBalancerArn: aws.String(albARN), Type: elbtypes.LoadBalancerTypeEnumApplication, Scheme: elbtypes.LoadBalancerSchemeEnumInternetFacing},
+			{LoadBalancerName: aws.String("admin"), LoadBalancerArn: aws.String(openALBARN), Type: elbtypes.LoadBalancerTypeEnumApplication, Scheme: elbtypes.LoadBalancerSchemeEnumInternetFacing},
+			{LoadBalancerName: aws.String("internal"), Type: elbtypes.LoadBalancerTypeEnumApplication, Scheme: elbtypes.LoadBalancerSchemeEnumInternal},
+			{LoadBalancerName: aws.String("tcp"), Type: elbtypes.LoadBalancerTypeEnumNetwork, Scheme: elbtypes.LoadBalancerSchemeEnumInternetFacing},
+		}},
+		apigateway: &fakeAPIGatewayClient{err: apiErr},
+		cloudfront: &fakeCloudFrontClient{distributions: []cloudfronttypes.DistributionSummary{
+			{Id: aws.String("E1WAFV2"), ARN: aws.String("arn:aws:cloudfront::123456789012:distribution/E1WAFV2"), WebACLId: aws.String(globalACL)},
+			{Id: aws.String("E3OPEN"), ARN: aws.String("arn:aws:cloudfront::123456789012:distribution/E3OPEN")},
+		}},
+		region:    region,
+		accountID: "123456789012",
+	}
+}
+
+func TestScanner_Scan(t *testing.T) {
+	s := newTestScanner("us-east-1", nil)
+
+	findings, err := s.Scan(context.Background(), "us-east-1")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	got := make(map[string]scanner.FindingStatus)
+	for _, f := range findings {
+		if f.CheckID != "waf_not_associated" {
+			t.Errorf("unexpected check %s", f.CheckID)
+		}
+		got[f.ResourceID] = f.Status
+	}
+	want := map[string]scanner.FindingStatus{
+		"web":     scanner.StatusPass,
+		"admin":   scanner.StatusFail,
+		"E1WAFV2": scanner.StatusPass,
+		"E3OPEN":  scanner.StatusFail,
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("Scan() statuses = %v, want %v", got, want)
+	}
+}
+
+func TestScanner_Scan_SkipsCloudFrontOutsideUSEast1(t *testing.T) {
+	s := newTestScanner("eu-west-1", nil)
+
+	findings, err := s.Scan(context.Background(), "eu-west-1")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	for _, f := range findings {
+		if f.ResourceID == "E1WAFV2" || f.ResourceID == "E3OPEN" {
+			t.Errorf("distribution %s checked outside %s", f.ResourceID, cloudFrontRegion)
+		}
+	}
+}
+
+func TestScanner_Scan_AccessDenied(t *testing.T) {
+	denied := &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "not authorized to perform: apigateway:GET"}
+	s := newTestScanner("eu-west-1", denied)
+
+	findings, err := s.Scan(context.Background(), "eu-west-1")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	var errored, checked int
+	for _, f := range findings {
+		switch {
+		case f.Status == scanner.StatusError && f.ResourceID == "rest-apis":
+			errored++
+		case f.Status != scanner.StatusError:
+			checked++
+		}
+	}
+	if errored != 1 || checked != 2 {
+		t.Errorf("got %d error findings and %d checked load balancers, want 1 and 2", errored, checked)
+	}
+}
+
+func TestScanner_Scan_WebACLsAccessDenied(t *testing.T) {
+	denied := &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "not authorized to perform: wafv2:ListWebACLs"}
+	for _, tt := range []struct {
+		scope    waftypes.Scope
+		resource string
+	}{
+		{waftypes.ScopeRegional, "web-acls"},
+		{waftypes.ScopeCloudfront, "cloudfront-web-acls"},
+	} {
+		s := newTestScanner("us-east-1", nil)
+		s.waf.(*fakeWAFClient).listErr = map[waftypes.Scope]error{tt.scope: denied}
+
+		findings, err := s.Scan(context.Background(), "us-east-1")
+		if err != nil {
+			t.Fatalf("%s: Scan() error = %v", tt.scope, err)
+		}
+		if len(findings) != 1 || findings[0].Status != scanner.StatusError || findings[0].ResourceID != tt.resource {
+			t.Errorf("%s: Scan() = %+v, want one ERROR finding for %s", tt.scope, findings, tt.resource)
+		}
+	}
+
+	s := newTestScanner("us-east-1", nil)
+	s.waf.(*fakeWAFClient).listErr = map[waftypes.Scope]error{waftypes.ScopeRegional: errors.New("throttled")}
+	if _, err := s.Scan(context.Background(), "us-east-1"); err == nil {
+		t.Error("Scan() should fail when listing web ACLs fails for another reason")
+	}
+}
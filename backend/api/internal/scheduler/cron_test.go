@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_Next(t *testing.T) {
+	// 2025-03-14 is a Friday.
+	from := time.Date(2025, 3, 14, 10, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2025, 3, 14, 10, 31, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2025, 3, 15, 2, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, 3, 14, 11, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 3, 14, 10, 45, 0, 0, time.UTC)},
+		{"5/20 * * * *", time.Date(2025, 3, 14, 10, 45, 0, 0, time.UTC)},
+		{"0,30 9-17 * * *", time.Date(2025, 3, 14, 11, 0, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2025, 3, 17, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * mon-fri", time.Date(2025, 3, 17, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 * *", time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)},
+		// With both day fields restricted, either one matching fires.
+		{"0 0 20 * 1", time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.expr, err)
+			}
+			if got := s.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedule_NextFromMatchingMinute(t *testing.T) {
+	s, err := Parse("0 2 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2025, 3, 14, 2, 0, 0, 0, time.UTC)
+	if got, want := s.Next(at), at.AddDate(0, 0, 1); !got.Equal(want) {
+		t.Errorf("Next(%v) = %v, want the following day %v", at, got, want)
+	}
+
+	// Times in other zones are converted to UTC.
+	est := time.FixedZone("EST", -5*60*60)
+	if got, want := s.Next(time.Date(2025, 3, 13, 20, 0, 0, 0, est)), time.Date(2025, 3, 14, 2, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"* * * foo *",
+		"0 0 30 2 *",
+		"@reboot",
+	} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", expr)
+		}
+	}
+}
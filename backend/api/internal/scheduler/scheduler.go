@@ -0,0 +1,211 @@
+// Package scheduler runs recurring scans on cron schedules stored in the
+// database.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"cloudcop/api/internal/database"
+	"cloudcop/api/internal/scanner"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// defaultInterval is how often the scheduler looks for due schedules. Cron
+// expressions have minute resolution, so checking more often gains nothing.
+const defaultInterval = time.Minute
+
+// Store reads due schedules and records their runs. It is satisfied by
+// *database.Queries.
+type Store interface {
+	ListDueScanSchedules(ctx context.Context, nextRunAt pgtype.Timestamp) ([]database.ListDueScanSchedulesRow, error)
+	ClaimScanScheduleRun(ctx context.Context, arg database.ClaimScanScheduleRunParams) (int64, error)
+}
+
+// Scanner runs a scan. It is satisfied by *security.Service.
+type Scanner interface {
+	Scan(ctx context.Context, config scanner.ScanConfig) (*scanner.ScanResultWithSummary, error)
+}
+
+// ScannerFunc adapts a function to a Scanner.
+type ScannerFunc func(ctx context.Context, config scanner.ScanConfig) (*scanner.ScanResultWithSummary, error)
+
+// Scan calls f(ctx, config).
+func (f ScannerFunc) Scan(ctx context.Context, config scanner.ScanConfig) (*scanner.ScanResultWithSummary, error) {
+	return f(ctx, config)
+}
+
+// Config holds the dependencies of a Scheduler.
+type Config struct {
+	// Store holds the schedules.
+	Store Store
+	// Scanner runs the scheduled scans.
+	Scanner Scanner
+	// Persist saves a completed scan. Scans cut short by shutdown are not
+	// persisted.
+	Persist func(ctx context.Context, result *scanner.ScanResult) (database.Scan, error)
+	// Interval is how often due schedules are checked (default one minute).
+	Interval time.Duration
+}
+
+// Scheduler starts scans whose schedule is due. Scans run one at a time on
+// a single goroutine, so a slow scan delays later schedules rather than
+// piling up concurrent scans.
+type Scheduler struct {
+	store    Store
+	scanner  Scanner
+	persist  func(ctx context.Context, result *scanner.ScanResult) (database.Scan, error)
+	interval time.Duration
+	now      func() time.Time
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+// New creates a Scheduler. Call Start to begin running schedules.
+func New(cfg Config) *Scheduler {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	return &Scheduler{
+		store:    cfg.Store,
+		scanner:  cfg.Scanner,
+		persist:  cfg.Persist,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// NewScheduleParams validates a cron expression and returns the parameters
+// for creating a schedule with its first run computed from now.
+func NewScheduleParams(awsAccountID int32, cronExpr string, services, regions []string, now time.Time) (database.CreateScanScheduleParams, error) {
+	schedule, err := Parse(cronExpr)
+	if err != nil {
+		return database.CreateScanScheduleParams{}, err
+	}
+	return database.CreateScanScheduleParams{
+		AwsAccountID: awsAccountID,
+		CronExpr:     cronExpr,
+		Services:     services,
+		Regions:      regions,
+		NextRunAt:    timestamp(schedule.Next(now)),
+	}, nil
+}
+
+// Start runs the scheduler in a background goroutine until Stop is called or
+// ctx is cancelled.
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			s.tick(ctx)
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop cancels any running scan and waits for the scheduler goroutine to
+// exit. It is safe to call more than once.
+func (s *Scheduler) Stop() {
+	s.once.Do(func() {
+		if s.cancel == nil {
+			return
+		}
+		s.cancel()
+		<-s.done
+	})
+}
+
+// tick runs every schedule that is due now.
+func (s *Scheduler) tick(ctx context.Context) {
+	now := s.now().UTC()
+	due, err := s.store.ListDueScanSchedules(ctx, timestamp(now))
+	if err != nil {
+		log.Printf("Scheduler: listing due schedules: %v", err)
+		return
+	}
+
+	for _, row := range due {
+		if ctx.Err() != nil {
+			return
+		}
+		claimed, err := s.claim(ctx, row, now)
+		if err != nil {
+			log.Printf("Scheduler: schedule %d: %v", row.ID, err)
+			continue
+		}
+		if claimed {
+			s.run(ctx, row)
+		}
+	}
+}
+
+// claim advances a due schedule to its next run before the scan starts, so
+// a schedule fires once per due time even if the scan is slow or fails.
+// Runs missed while the server was down collapse into this one. It reports
+// false when another scheduler claimed the run first.
+func (s *Scheduler) claim(ctx context.Context, row database.ListDueScanSchedulesRow, now time.Time) (bool, error) {
+	schedule, err := Parse(row.CronExpr)
+	if err != nil {
+		return false, err
+	}
+	n, err := s.store.ClaimScanScheduleRun(ctx, database.ClaimScanScheduleRunParams{
+		LastRunAt: timestamp(now),
+		NextRunAt: timestamp(schedule.Next(now)),
+		ID:        row.ID,
+		DueAt:     row.NextRunAt,
+	})
+	if err != nil {
+		return false, fmt.Errorf("claiming run: %w", err)
+	}
+	return n == 1, nil
+}
+
+// run scans the schedule's account and persists the result. The scan runs
+// for the connection the schedule belongs to, so when several teams connect
+// the same AWS account it uses the schedule owner's role and suppressions.
+func (s *Scheduler) run(ctx context.Context, row database.ListDueScanSchedulesRow) {
+	log.Printf("Scheduler: running schedule %d for account %s of team %d", row.ID, row.AccountID, row.TeamID.Int32)
+	result, err := s.scanner.Scan(ctx, scanner.ScanConfig{
+		AccountID:    row.AccountID,
+		ConnectionID: row.AwsAccountID,
+		Regions:      row.Regions,
+		Services:     row.Services,
+	})
+	if err != nil {
+		log.Printf("Scheduler: schedule %d scan failed: %v", row.ID, err)
+		return
+	}
+	if result.Cancelled {
+		log.Printf("Scheduler: schedule %d scan cancelled, not saving partial results", row.ID)
+		return
+	}
+
+	saved, err := s.persist(ctx, result.ScanResult)
+	if err != nil {
+		log.Printf("Scheduler: schedule %d: saving scan: %v", row.ID, err)
+		return
+	}
+	log.Printf("Scheduler: schedule %d saved scan %d", row.ID, saved.ID)
+}
+
+// timestamp converts t to the UTC wall-clock time stored in TIMESTAMP columns.
+func timestamp(t time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{Time: t.UTC(), Valid: !t.IsZero()}
+}
@@ -0,0 +1,236 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"cloudcop/api/internal/database"
+	"cloudcop/api/internal/scanner"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// fakeStore keeps schedules in memory with the same claim semantics as the
+// ClaimScanScheduleRun query.
+type fakeStore struct {
+	mu        sync.Mutex
+	schedules []database.ScanSchedule
+	accounts  map[int32]string
+}
+
+func (f *fakeStore) ListDueScanSchedules(_ context.Context, now pgtype.Timestamp) ([]database.ListDueScanSchedulesRow, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var due []database.ListDueScanSchedulesRow
+	for _, s := range f.schedules {
+		if s.Enabled && !s.NextRunAt.Time.After(now.Time) {
+			due = append(due, database.ListDueScanSchedulesRow{
+				ID:           s.ID,
+				AwsAccountID: s.AwsAccountID,
+				CronExpr:     s.CronExpr,
+				Services:     s.Services,
+				Regions:      s.Regions,
+				NextRunAt:    s.NextRunAt,
+				AccountID:    f.accounts[s.AwsAccountID],
+			})
+		}
+	}
+	return due, nil
+}
+
+func (f *fakeStore) ClaimScanScheduleRun(_ context.Context, arg database.ClaimScanScheduleRunParams) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, s := range f.schedules {
+		if s.ID == arg.ID && s.NextRunAt.Time.Equal(arg.DueAt.Time) {
+			f.schedules[i].LastRunAt = arg.LastRunAt
+			f.schedules[i].NextRunAt = arg.NextRunAt
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (f *fakeStore) add(t *testing.T, accountID int32, expr string, now time.Time) {
+	t.Helper()
+	params, err := NewScheduleParams(accountID, expr, []string{"s3"}, []string{"us-east-1"}, now)
+	if err != nil {
+		t.Fatalf("NewScheduleParams() error = %v", err)
+	}
+	f.schedules = append(f.schedules, database.ScanSchedule{
+		ID:           int32(len(f.schedules) + 1),
+		AwsAccountID: params.AwsAccountID,
+		CronExpr:     params.CronExpr,
+		Services:     params.Services,
+		Regions:      params.Regions,
+		Enabled:      true,
+		NextRunAt:    params.NextRunAt,
+	})
+}
+
+// fakeScanner records the scans it is asked to run.
+type fakeScanner struct {
+	mu      sync.Mutex
+	configs []scanner.ScanConfig
+	err     error
+}
+
+func (f *fakeScanner) Scan(_ context.Context, config scanner.ScanConfig) (*scanner.ScanResultWithSummary, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.configs = append(f.configs, config)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &scanner.ScanResultWithSummary{ScanResult: &scanner.ScanResult{AccountID: config.AccountID}}, nil
+}
+
+func (f *fakeScanner) scans() []scanner.ScanConfig {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]scanner.ScanConfig(nil), f.configs...)
+}
+
+// testScheduler returns a Scheduler whose clock is read from *now.
+func testScheduler(store Store, scan Scanner, saved *[]string, now *time.Time) *Scheduler {
+	s := New(Config{
+		Store:   store,
+		Scanner: scan,
+		Persist: func(_ context.Context, result *scanner.ScanResult) (database.Scan, error) {
+			*saved = append(*saved, result.AccountID)
+			return database.Scan{ID: int32(len(*saved))}, nil
+		},
+	})
+	s.now = func() time.Time { return *now }
+	return s
+}
+
+func TestScheduler_FiresOncePerInterval(t *testing.T) {
+	now := time.Date(2025, 3, 14, 1, 0, 0, 0, time.UTC)
+	store := &fakeStore{accounts: map[int32]string{1: "123456789012", 2: "210987654321"}}
+	store.add(t, 1, "0 2 * * *", now)
+	store.add(t, 2, "0 */6 * * *", now)
+
+	scan := &fakeScanner{}
+	var saved []string
+	s := testScheduler(store, scan, &saved, &now)
+	ctx := context.Background()
+
+	// Tick every 20 seconds for two days.
+	counts := make(map[string]int)
+	for end := now.Add(48 * time.Hour); now.Before(end); now = now.Add(20 * time.Second) {
+		before := len(scan.scans())
+		s.tick(ctx)
+		for _, c := range scan.scans()[before:] {
+			counts[c.AccountID]++
+			if minute := now.Truncate(time.Minute); minute.Minute() != 0 || now.Sub(minute) >= 20*time.Second {
+				t.Errorf("scan of %s started at %v, want it at the first tick of the hour", c.AccountID, now)
+			}
+		}
+	}
+
+	// The daily schedule fires at 02:00 on each day; the six-hourly one at
+	// 06:00, 12:00, 18:00, and 00:00.
+	if counts["123456789012"] != 2 {
+		t.Errorf("daily schedule fired %d times in two days, want 2", counts["123456789012"])
+	}
+	if counts["210987654321"] != 8 {
+		t.Errorf("six-hourly schedule fired %d times in two days, want 8", counts["210987654321"])
+	}
+	if len(saved) != 10 {
+		t.Errorf("persisted %d scans, want 10", len(saved))
+	}
+	if c := scan.scans()[0]; c.Services[0] != "s3" || c.Regions[0] != "us-east-1" {
+		t.Errorf("scan config = %+v, want the schedule's services and regions", c)
+	}
+	for _, c := range scan.scans() {
+		if want := map[string]int32{"123456789012": 1, "210987654321": 2}[c.AccountID]; c.ConnectionID != want {
+			t.Errorf("scan of %s ran for connection %d, want %d", c.AccountID, c.ConnectionID, want)
+		}
+	}
+}
+
+func TestScheduler_MissedRunsFireOnce(t *testing.T) {
+	now := time.Date(2025, 3, 14, 1, 0, 0, 0, time.UTC)
+	store := &fakeStore{accounts: map[int32]string{1: "123456789012"}}
+	store.add(t, 1, "@hourly", now)
+
+	scan := &fakeScanner{}
+	var saved []string
+	s := testScheduler(store, scan, &saved, &now)
+
+	// The server was down for five scheduled runs.
+	now = now.Add(5*time.Hour + 30*time.Minute)
+	s.tick(context.Background())
+	s.tick(context.Background())
+
+	if n := len(scan.scans()); n != 1 {
+		t.Errorf("ran %d scans after downtime, want 1", n)
+	}
+	if next := store.schedules[0].NextRunAt.Time; !next.Equal(time.Date(2025, 3, 14, 7, 0, 0, 0, time.UTC)) {
+		t.Errorf("next run = %v, want 07:00", next)
+	}
+}
+
+func TestScheduler_SharedStoreFiresOnce(t *testing.T) {
+	now := time.Date(2025, 3, 14, 1, 59, 0, 0, time.UTC)
+	store := &fakeStore{accounts: map[int32]string{1: "123456789012"}}
+	store.add(t, 1, "0 2 * * *", now)
+	now = now.Add(time.Minute)
+
+	// Two servers see the same due schedule.
+	scan := &fakeScanner{}
+	var saved []string
+	first := testScheduler(store, scan, &saved, &now)
+	second := testScheduler(store, scan, &saved, &now)
+	first.tick(context.Background())
+	second.tick(context.Background())
+
+	if n := len(scan.scans()); n != 1 {
+		t.Errorf("two schedulers ran %d scans, want 1", n)
+	}
+}
+
+func TestScheduler_FailedScanNotPersisted(t *testing.T) {
+	now := time.Date(2025, 3, 14, 1, 59, 0, 0, time.UTC)
+	store := &fakeStore{accounts: map[int32]string{1: "123456789012"}}
+	store.add(t, 1, "0 2 * * *", now)
+	now = now.Add(time.Minute)
+
+	scan := &fakeScanner{err: errors.New("assume role failed")}
+	var saved []string
+	s := testScheduler(store, scan, &saved, &now)
+	s.tick(context.Background())
+	s.tick(context.Background())
+
+	if n := len(scan.scans()); n != 1 || len(saved) != 0 {
+		t.Errorf("ran %d scans and saved %d, want 1 failed scan that is not retried or saved", n, len(saved))
+	}
+}
+
+func TestScheduler_StartStop(t *testing.T) {
+	now := time.Date(2025, 3, 14, 1, 59, 0, 0, time.UTC)
+	store := &fakeStore{accounts: map[int32]string{1: "123456789012"}}
+	store.add(t, 1, "0 2 * * *", now)
+	now = now.Add(time.Minute)
+
+	scan := &fakeScanner{}
+	var saved []string
+	s := testScheduler(store, scan, &saved, &now)
+	s.interval = time.Millisecond
+
+	s.Start(context.Background())
+	deadline := time.Now().Add(time.Second)
+	for len(scan.scans()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	s.Stop()
+	s.Stop()
+
+	if n := len(scan.scans()); n != 1 {
+		t.Errorf("ran %d scans, want 1", n)
+	}
+}
@@ -0,0 +1,74 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrScanInProgress is returned by Scan when Config.RejectConcurrentScans is
+// set and the account is already being scanned.
+var ErrScanInProgress = errors.New("scan already running for this account")
+
+// accountLocks serializes scans per account. Each account's lock exists only
+// while a scan holds or waits for it, so the map does not grow with every
+// account ever scanned.
+type accountLocks struct {
+	mu    sync.Mutex
+	locks map[string]*accountLock
+}
+
+type accountLock struct {
+	// held has room for one token, taken by the scan running for the account.
+	held chan struct{}
+	// refs counts the scans holding or waiting for the lock.
+	refs int
+}
+
+// acquire takes the lock for accountID and returns the function releasing it.
+// With wait unset it fails with ErrScanInProgress instead of blocking; with
+// wait set it blocks until the lock is free or ctx is done.
+func (l *accountLocks) acquire(ctx context.Context, accountID string, wait bool) (func(), error) {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*accountLock)
+	}
+	lock, ok := l.locks[accountID]
+	if !ok {
+		lock = &accountLock{held: make(chan struct{}, 1)}
+		l.locks[accountID] = lock
+	}
+	lock.refs++
+	l.mu.Unlock()
+
+	if wait {
+		select {
+		case lock.held <- struct{}{}:
+		case <-ctx.Done():
+			l.drop(accountID, lock)
+			return nil, ctx.Err()
+		}
+	} else {
+		select {
+		case lock.held <- struct{}{}:
+		default:
+			l.drop(accountID, lock)
+			return nil, ErrScanInProgress
+		}
+	}
+
+	return func() {
+		<-lock.held
+		l.drop(accountID, lock)
+	}, nil
+}
+
+// drop releases one reference to lock, removing it once no scan uses it.
+func (l *accountLocks) drop(accountID string, lock *accountLock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lock.refs--
+	if lock.refs == 0 {
+		delete(l.locks, accountID)
+	}
+}
@@ -3,21 +3,52 @@ package security
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"sync"
+	"time"
 
 	"cloudcop/api/internal/scanner"
+	"cloudcop/api/internal/scanner/policy"
 	"cloudcop/api/internal/summarization"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// defaultAccountConcurrency bounds how many accounts MultiAccountScan scans at once.
+const defaultAccountConcurrency = 5
+
+const (
+	// defaultSummarizationMinBudget is the least time that must remain before
+	// a scan's deadline for Scan to attempt summarization.
+	defaultSummarizationMinBudget = 10 * time.Second
+	// summarizationBudgetShare is the share of the remaining time Scan gives
+	// the summarization call, keeping the rest to return the scan results
+	// should the AI service be slow.
+	summarizationBudgetShare = 0.75
 )
 
 // Service orchestrates security scanning and AI summarization.
 type Service struct {
-	coordinator *scanner.Coordinator
-	summClient  *summarization.Client
-	summAddress string
-	summEnabled bool
+	coordinator   *scanner.Coordinator
+	awsConfig     aws.Config
+	accountConc   int
+	summClient    *summarization.Client
+	summAddress   string
+	summTimeout   time.Duration
+	summMinBudget time.Duration
+	summEnabled   bool
+	publicAllow   map[string]scanner.PublicAllowList
+	persistMin    scanner.Severity
+	policy        *policy.Policy
+	tracer        trace.Tracer
+	scanLocks     accountLocks
+	rejectBusy    bool
+	credsFor      func(ctx context.Context, config scanner.ScanConfig) (aws.CredentialsProvider, error)
 }
 
 // Config holds configuration for the security service.
@@ -28,40 +59,153 @@ type Config struct {
 	AccountID string
 	// SummarizationAddress is the gRPC address for the AI service (e.g., "localhost:50051").
 	SummarizationAddress string
+	// SummarizationTimeout bounds each call to the AI service. Zero uses the
+	// summarization client's default.
+	SummarizationTimeout time.Duration
+	// SummarizationMinBudget is the least time that must remain before the
+	// scan context's deadline for Scan to summarize; with less, the raw
+	// results are returned unsummarized. Zero uses 10 seconds.
+	SummarizationMinBudget time.Duration
 	// EnableSummarization controls whether AI summarization is enabled.
 	EnableSummarization bool
+	// ResourceCache configures reuse of resource listings between scans.
+	ResourceCache scanner.CacheOptions
+	// Endpoints redirects the AWS clients used for scanning, such as to FIPS
+	// endpoints. The default endpoints are used when empty.
+	Endpoints scanner.EndpointOptions
+	// PublicAllowLists maps account IDs to resources accepted as intentionally public.
+	PublicAllowLists map[string]scanner.PublicAllowList
+	// AccountConcurrency limits parallel accounts in MultiAccountScan (default 5).
+	AccountConcurrency int
+	// PersistMinSeverity drops findings below this severity when scans are
+	// saved, while scan results and the saved check counts stay complete.
+	// Empty persists every finding.
+	PersistMinSeverity scanner.Severity
+	// Policy selects the checks every scan reports and overrides their
+	// severities. Nil reports every check at its default severity.
+	Policy *policy.Policy
+	// TracerProvider records spans for scans and summarization calls. Tracing
+	// is disabled when nil.
+	TracerProvider trace.TracerProvider
+	// RejectConcurrentScans makes a scan of an account that is already being
+	// scanned fail with ErrScanInProgress. By default it waits for the
+	// running scan to finish instead.
+	RejectConcurrentScans bool
+	// CredentialsFor returns the credentials Scan uses for the account a scan
+	// config targets, such as those of the connection's assumed scan role. A
+	// nil provider, or a nil CredentialsFor, scans with AWSConfig's own
+	// credentials.
+	CredentialsFor func(ctx context.Context, config scanner.ScanConfig) (aws.CredentialsProvider, error)
+}
+
+// AccountScanConfig describes one account in a multi-account scan.
+type AccountScanConfig struct {
+	// AccountID is the AWS account to scan.
+	AccountID string
+	// Credentials are the account's assumed-role credentials.
+	Credentials aws.CredentialsProvider
+	// Regions is the list of AWS regions to scan.
+	Regions []string
+	// Services is the list of AWS services to scan.
+	Services []string
 }
 
 // NewService creates a new security service.
 func NewService(cfg Config) (*Service, error) {
 	coordinator := scanner.NewCoordinator(cfg.AWSConfig, cfg.AccountID)
+	coordinator.UseResourceCache(cfg.ResourceCache)
+	coordinator.UseEndpoints(cfg.Endpoints)
+	coordinator.UseTracerProvider(cfg.TracerProvider)
+
+	tp := cfg.TracerProvider
+	if tp == nil {
+		tp = noop.NewTracerProvider()
+	}
+
+	accountConc := cfg.AccountConcurrency
+	if accountConc <= 0 {
+		accountConc = defaultAccountConcurrency
+	}
+	summMinBudget := cfg.SummarizationMinBudget
+	if summMinBudget <= 0 {
+		summMinBudget = defaultSummarizationMinBudget
+	}
 
 	s := &Service{
-		coordinator: coordinator,
-		summAddress: cfg.SummarizationAddress,
-		summEnabled: cfg.EnableSummarization,
+		coordinator:   coordinator,
+		awsConfig:     cfg.AWSConfig,
+		accountConc:   accountConc,
+		summAddress:   cfg.SummarizationAddress,
+		summTimeout:   cfg.SummarizationTimeout,
+		summMinBudget: summMinBudget,
+		summEnabled:   cfg.EnableSummarization,
+		publicAllow:   cfg.PublicAllowLists,
+		persistMin:    cfg.PersistMinSeverity,
+		policy:        cfg.Policy,
+		tracer:        tp.Tracer(scanner.TracerName),
+		rejectBusy:    cfg.RejectConcurrentScans,
+		credsFor:      cfg.CredentialsFor,
+	}
+
+	// The client is kept for the service's lifetime so that every Summarize
+	// call shares one connection. It connects lazily, so an AI service that
+	// is not up yet does not stop the service from starting.
+	if s.summEnabled && s.summAddress != "" {
+		var opts []summarization.Option
+		if s.summTimeout > 0 {
+			opts = append(opts, summarization.WithCallTimeout(s.summTimeout))
+		}
+		client, err := summarization.NewClient(s.summAddress, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("creating summarization client: %w", err)
+		}
+		s.summClient = client
 	}
 
 	return s, nil
 }
 
 // RegisterScanner registers a scanner factory with the coordinator.
-func (s *Service) RegisterScanner(service string, factory func(aws.Config, string, string) scanner.ServiceScanner) {
+func (s *Service) RegisterScanner(service string, factory scanner.Factory) {
 	s.coordinator.RegisterScanner(service, factory)
 }
 
+// PersistMinSeverity returns the lowest severity of findings that should be
+// saved with a scan.
+func (s *Service) PersistMinSeverity() scanner.Severity {
+	return s.persistMin
+}
+
 // GetSupportedServices returns the list of registered scanner services.
 func (s *Service) GetSupportedServices() []string {
 	return s.coordinator.GetSupportedServices()
 }
 
 // Scan executes security scans and optionally summarizes findings with AI.
+// Only one scan runs per account at a time; see Config.RejectConcurrentScans.
 func (s *Service) Scan(ctx context.Context, config scanner.ScanConfig) (*scanner.ScanResultWithSummary, error) {
+	config = s.policy.Apply(config)
+	if allow, ok := s.publicAllow[config.AccountID]; ok {
+		config.PublicAllowList = allow
+	}
+
+	coordinator, err := s.coordinatorFor(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := s.scanLocks.acquire(ctx, config.AccountID, !s.rejectBusy)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Execute the scan
-	result, err := s.coordinator.StartScan(ctx, config)
+	result, err := coordinator.StartScan(ctx, config)
 	if err != nil {
 		return nil, fmt.Errorf("scan failed: %w", err)
 	}
+	ctx = scanner.WithScanID(ctx, result.ScanID)
 
 	// Return early if summarization is disabled or no failed findings
 	if !s.summEnabled || result.FailedChecks == 0 {
@@ -71,55 +215,167 @@ func (s *Service) Scan(ctx context.Context, config scanner.ScanConfig) (*scanner
 		}, nil
 	}
 
-	// Connect to summarization service
-	summClient, err := s.connectSummarization()
-	if err != nil {
-		log.Printf("Warning: Could not connect to summarization service: %v", err)
+	// A summary that cannot finish before the deadline would only cost the
+	// caller its scan results, so near the deadline it is skipped.
+	budget, ok := summarizationBudget(ctx, s.summMinBudget)
+	if !ok {
+		deadline, _ := ctx.Deadline()
+		scanner.Logf(ctx, "Skipping summarization: %s left before the scan deadline, less than the %s minimum",
+			time.Until(deadline).Round(time.Millisecond), s.summMinBudget)
 		return &scanner.ScanResultWithSummary{
 			ScanResult: result,
 			Summary:    nil,
 		}, nil
 	}
-	defer func() { _ = summClient.Close() }()
-
-	// Generate scan ID
-	scanID := fmt.Sprintf("scan-%d", result.StartedAt.Unix())
+	summCtx := ctx
+	if budget > 0 {
+		var cancel context.CancelFunc
+		summCtx, cancel = context.WithTimeout(ctx, budget)
+		defer cancel()
+	}
 
-	// Call summarization service
-	summResult, err := summClient.SummarizeFindings(ctx, scanID, config.AccountID, result.Findings)
+	summary, err := s.Summarize(summCtx, result)
 	if err != nil {
-		log.Printf("Warning: Summarization failed: %v", err)
-		return &scanner.ScanResultWithSummary{
-			ScanResult: result,
-			Summary:    nil,
-		}, nil
+		scanner.Logf(ctx, "Warning: Summarization failed: %v", err)
 	}
 
-	// Convert summarization result to ScanSummary
-	summary := convertSummaryResult(summResult)
-
 	return &scanner.ScanResultWithSummary{
 		ScanResult: result,
 		Summary:    summary,
 	}, nil
 }
 
-// connectSummarization creates a connection to the summarization service.
-func (s *Service) connectSummarization() (*summarization.Client, error) {
-	if s.summClient != nil {
-		return s.summClient, nil
+// coordinatorFor returns the coordinator that scans the account of config
+// with the credentials from Config.CredentialsFor, if any.
+func (s *Service) coordinatorFor(ctx context.Context, config scanner.ScanConfig) (*scanner.Coordinator, error) {
+	if s.credsFor == nil {
+		return s.coordinator, nil
+	}
+	creds, err := s.credsFor(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("loading credentials for account %s: %w", config.AccountID, err)
+	}
+	if creds == nil {
+		return s.coordinator, nil
+	}
+	cfg := s.awsConfig.Copy()
+	cfg.Credentials = creds
+	return s.coordinator.ForAccount(cfg, config.AccountID), nil
+}
+
+// Summarize generates an AI summary of a scan's findings. It can be called
+// for a persisted scan whose summary was skipped, for example because the
+// AI service was down when the scan ran.
+func (s *Service) Summarize(ctx context.Context, result *scanner.ScanResult) (*scanner.ScanSummary, error) {
+	if !s.summEnabled {
+		return nil, fmt.Errorf("summarization is disabled")
 	}
 
-	if s.summAddress == "" {
+	if s.summClient == nil {
 		return nil, fmt.Errorf("summarization address not configured")
 	}
 
-	client, err := summarization.NewClient(s.summAddress)
+	// Scans read back from the database do not keep their scan ID.
+	scanID := result.ScanID
+	if scanID == "" {
+		scanID = fmt.Sprintf("scan-%d", result.StartedAt.Unix())
+	}
+	ctx, span := s.tracer.Start(ctx, "summarize", trace.WithAttributes(
+		attribute.String("cloudcop.account_id", result.AccountID),
+		attribute.Int("cloudcop.findings", len(result.Findings)),
+	))
+	defer span.End()
+
+	summResult, err := s.summClient.SummarizeFindings(ctx, scanID, result.AccountID, result.Findings)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "summarization failed")
+		return nil, err
+	}
+	return convertSummaryResult(summResult), nil
+}
+
+// summarizationBudget returns how long a summarization call may take under
+// ctx's deadline, or false when less than minLeft remains. A context without a
+// deadline imposes no budget, reported as zero.
+func summarizationBudget(ctx context.Context, minLeft time.Duration) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, true
+	}
+	remaining := time.Until(deadline)
+	if remaining < minLeft {
+		return 0, false
+	}
+	return time.Duration(float64(remaining) * summarizationBudgetShare), true
+}
+
+// MultiAccountScan scans each account with its own credentials, running at
+// most AccountConcurrency accounts at a time. Results are returned in the
+// order of accounts; an account that fails has a nil result and its error is
+// included in the joined error without stopping the other accounts.
+// Summarization is not run for multi-account scans.
+func (s *Service) MultiAccountScan(ctx context.Context, accounts []AccountScanConfig) ([]*scanner.ScanResult, error) {
+	results := make([]*scanner.ScanResult, len(accounts))
+	errs := make([]error, len(accounts))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, s.accountConc)
+	for i, account := range accounts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			result, err := s.scanAccount(ctx, account)
+			if err != nil {
+				errs[i] = fmt.Errorf("account %s: %w", account.AccountID, err)
+				return
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+
+	return results, errors.Join(errs...)
+}
+
+func (s *Service) scanAccount(ctx context.Context, account AccountScanConfig) (*scanner.ScanResult, error) {
+	if account.AccountID == "" {
+		return nil, fmt.Errorf("account ID is required")
+	}
+	if account.Credentials == nil {
+		return nil, fmt.Errorf("credentials are required")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	cfg := s.awsConfig.Copy()
+	cfg.Credentials = account.Credentials
+
+	config := s.policy.Apply(scanner.ScanConfig{
+		AccountID: account.AccountID,
+		Regions:   account.Regions,
+		Services:  account.Services,
+	})
+	if allow, ok := s.publicAllow[account.AccountID]; ok {
+		config.PublicAllowList = allow
+	}
+
+	release, err := s.scanLocks.acquire(ctx, account.AccountID, !s.rejectBusy)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+	return s.coordinator.ForAccount(cfg, account.AccountID).StartScan(ctx, config)
+}
 
-	return client, nil
+// SummarizationClient returns the client Summarize uses, or nil when
+// summarization is disabled.
+func (s *Service) SummarizationClient() *summarization.Client {
+	return s.summClient
 }
 
 // Close closes any open connections.
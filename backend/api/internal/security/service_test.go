@@ -0,0 +1,381 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"cloudcop/api/internal/scanner"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"google.golang.org/grpc/connectivity"
+)
+
+// accountCredentials is a credentials provider tagged with the account it belongs to.
+type accountCredentials string
+
+func (c accountCredentials) Retrieve(context.Context) (aws.Credentials, error) {
+	return aws.Credentials{AccessKeyID: string(c)}, nil
+}
+
+// mockScanner reports one failed finding per scan, tagged with the account
+// and the access key it was built with.
+type mockScanner struct {
+	accountID string
+	keyID     string
+	tracker   *concurrencyTracker
+}
+
+func (m *mockScanner) Service() string { return "mock" }
+
+func (m *mockScanner) Scan(_ context.Context, region string) ([]scanner.Finding, error) {
+	m.tracker.enter()
+	defer m.tracker.exit()
+	time.Sleep(20 * time.Millisecond)
+
+	return []scanner.Finding{{
+		Service:    "mock",
+		Region:     region,
+		ResourceID: m.accountID,
+		CheckID:    "mock_check",
+		Status:     scanner.StatusFail,
+		Title:      m.keyID,
+	}}, nil
+}
+
+// concurrencyTracker records the peak number of scanners running at once.
+type concurrencyTracker struct {
+	running atomic.Int32
+	mu      sync.Mutex
+	peak    int32
+}
+
+func (t *concurrencyTracker) enter() {
+	n := t.running.Add(1)
+	t.mu.Lock()
+	t.peak = max(t.peak, n)
+	t.mu.Unlock()
+}
+
+func (t *concurrencyTracker) exit() { t.running.Add(-1) }
+
+func newTestService(t *testing.T, tracker *concurrencyTracker, accountConcurrency int) *Service {
+	t.Helper()
+	s, err := NewService(Config{AWSConfig: aws.Config{Region: "us-east-1"}, AccountConcurrency: accountConcurrency})
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	s.RegisterScanner("mock", func(cfg aws.Config, _ string, accountID string) scanner.ServiceScanner {
+		creds, _ := cfg.Credentials.Retrieve(context.Background())
+		return &mockScanner{accountID: accountID, keyID: creds.AccessKeyID, tracker: tracker}
+	})
+	return s
+}
+
+func TestMultiAccountScan(t *testing.T) {
+	s := newTestService(t, &concurrencyTracker{}, 0)
+
+	accounts := []AccountScanConfig{
+		{AccountID: "111111111111", Credentials: accountCredentials("key-1"), Regions: []string{"us-east-1"}, Services: []string{"mock"}},
+		{AccountID: "222222222222", Credentials: nil, Regions: []string{"us-east-1"}, Services: []string{"mock"}},
+		{AccountID: "333333333333", Credentials: accountCredentials("key-3"), Regions: []string{"us-east-1", "eu-west-1"}, Services: []string{"mock"}},
+	}
+
+	results, err := s.MultiAccountScan(context.Background(), accounts)
+
+	if err == nil || !strings.Contains(err.Error(), "account 222222222222") {
+		t.Errorf("error = %v, want a failure for account 222222222222", err)
+	}
+	if len(results) != len(accounts) {
+		t.Fatalf("got %d results, want %d", len(results), len(accounts))
+	}
+	if results[1] != nil {
+		t.Errorf("failed account returned a result: %+v", results[1])
+	}
+
+	for _, i := range []int{0, 2} {
+		result := results[i]
+		if result == nil {
+			t.Fatalf("account %s: missing result", accounts[i].AccountID)
+		}
+		if result.AccountID != accounts[i].AccountID {
+			t.Errorf("result %d AccountID = %s, want %s", i, result.AccountID, accounts[i].AccountID)
+		}
+		if len(result.Findings) != len(accounts[i].Regions) {
+			t.Errorf("account %s: got %d findings, want %d", accounts[i].AccountID, len(result.Findings), len(accounts[i].Regions))
+		}
+		wantKey := string(accounts[i].Credentials.(accountCredentials))
+		for _, f := range result.Findings {
+			if f.ResourceID != accounts[i].AccountID || f.Title != wantKey {
+				t.Errorf("account %s: finding scanned as %s with %s", accounts[i].AccountID, f.ResourceID, f.Title)
+			}
+		}
+	}
+}
+
+func TestMultiAccountScan_BoundedConcurrency(t *testing.T) {
+	tracker := &concurrencyTracker{}
+	s := newTestService(t, tracker, 2)
+
+	var accounts []AccountScanConfig
+	for _, id := range []string{"111111111111", "222222222222", "333333333333", "444444444444", "555555555555"} {
+		accounts = append(accounts, AccountScanConfig{
+			AccountID:   id,
+			Credentials: accountCredentials(id),
+			Regions:     []string{"us-east-1"},
+			Services:    []string{"mock"},
+		})
+	}
+
+	results, err := s.MultiAccountScan(context.Background(), accounts)
+	if err != nil {
+		t.Fatalf("MultiAccountScan() error = %v", err)
+	}
+	for i, result := range results {
+		if result == nil {
+			t.Errorf("account %s: missing result", accounts[i].AccountID)
+		}
+	}
+	if tracker.peak > 2 {
+		t.Errorf("peak concurrent accounts = %d, want at most 2", tracker.peak)
+	}
+}
+
+func TestSummarizationBudget(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if budget, ok := summarizationBudget(ctx, 10*time.Second); !ok || budget <= 40*time.Second || budget > 45*time.Second {
+		t.Errorf("budget with a minute left = %v, %v; want about 45s", budget, ok)
+	}
+	if _, ok := summarizationBudget(ctx, 2*time.Minute); ok {
+		t.Error("summarization allowed with less than the minimum budget left")
+	}
+	if budget, ok := summarizationBudget(context.Background(), 10*time.Second); !ok || budget != 0 {
+		t.Errorf("budget without a deadline = %v, %v; want 0, true", budget, ok)
+	}
+}
+
+func TestScan_SkipsSummarizationNearDeadline(t *testing.T) {
+	s, err := NewService(Config{
+		AWSConfig:              aws.Config{Region: "us-east-1", Credentials: accountCredentials("key-1")},
+		AccountID:              "111111111111",
+		SummarizationAddress:   "localhost:1",
+		EnableSummarization:    true,
+		SummarizationMinBudget: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	s.RegisterScanner("mock", func(_ aws.Config, _ string, accountID string) scanner.ServiceScanner {
+		return &mockScanner{accountID: accountID, tracker: &concurrencyTracker{}}
+	})
+
+	var logs strings.Builder
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := s.Scan(ctx, scanner.ScanConfig{
+		AccountID: "111111111111",
+		Regions:   []string{"us-east-1"},
+		Services:  []string{"mock"},
+	})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	if result.Summary != nil {
+		t.Errorf("Summary = %+v, want none", result.Summary)
+	}
+	if result.FailedChecks != 1 || len(result.Findings) != 1 {
+		t.Errorf("got %d failed checks and %d findings, want the raw scan result", result.FailedChecks, len(result.Findings))
+	}
+	if !strings.Contains(logs.String(), "Skipping summarization") {
+		t.Errorf("logs %q do not explain the skipped summary", logs.String())
+	}
+	if strings.Contains(logs.String(), "Summarization failed") {
+		t.Errorf("summarization was attempted: %q", logs.String())
+	}
+}
+
+// newSingleAccountService returns a service scanning with default credentials,
+// as Scan does, rather than per-account ones.
+func newSingleAccountService(t *testing.T, tracker *concurrencyTracker, rejectConcurrent bool) *Service {
+	t.Helper()
+	s, err := NewService(Config{
+		AWSConfig:             aws.Config{Region: "us-east-1", Credentials: accountCredentials("key-1")},
+		RejectConcurrentScans: rejectConcurrent,
+	})
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	s.RegisterScanner("mock", func(_ aws.Config, _ string, accountID string) scanner.ServiceScanner {
+		return &mockScanner{accountID: accountID, tracker: tracker}
+	})
+	return s
+}
+
+func TestScan_SerializesSameAccount(t *testing.T) {
+	tracker := &concurrencyTracker{}
+	s := newSingleAccountService(t, tracker, false)
+	config := scanner.ScanConfig{
+		AccountID: "111111111111",
+		Regions:   []string{"us-east-1"},
+		Services:  []string{"mock"},
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = s.Scan(context.Background(), config)
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("scan %d error = %v, want it to wait for the other", i, err)
+		}
+	}
+	if tracker.peak != 1 {
+		t.Errorf("peak concurrent scans = %d, want 1", tracker.peak)
+	}
+	if len(s.scanLocks.locks) != 0 {
+		t.Errorf("%d account locks left after the scans finished", len(s.scanLocks.locks))
+	}
+}
+
+func TestScan_RejectsConcurrentScan(t *testing.T) {
+	tracker := &concurrencyTracker{}
+	s := newSingleAccountService(t, tracker, true)
+	config := scanner.ScanConfig{
+		AccountID: "111111111111",
+		Regions:   []string{"us-east-1"},
+		Services:  []string{"mock"},
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := s.Scan(context.Background(), config)
+		done <- err
+	}()
+	for tracker.running.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	if _, err := s.Scan(context.Background(), config); !errors.Is(err, ErrScanInProgress) {
+		t.Errorf("second Scan() error = %v, want ErrScanInProgress", err)
+	}
+	other := config
+	other.AccountID = "222222222222"
+	if _, err := s.Scan(context.Background(), other); err != nil {
+		t.Errorf("Scan() of another account error = %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("first Scan() error = %v", err)
+	}
+
+	if _, err := s.Scan(context.Background(), config); err != nil {
+		t.Errorf("Scan() after the first finished error = %v", err)
+	}
+}
+
+func TestScan_WaitHonorsContext(t *testing.T) {
+	s := newSingleAccountService(t, &concurrencyTracker{}, false)
+	release, err := s.scanLocks.acquire(context.Background(), "111111111111", true)
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = s.Scan(ctx, scanner.ScanConfig{AccountID: "111111111111", Regions: []string{"us-east-1"}, Services: []string{"mock"}})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Scan() error = %v, want the context's deadline", err)
+	}
+}
+
+func TestScan_CredentialsFor(t *testing.T) {
+	s, err := NewService(Config{
+		AWSConfig: aws.Config{Region: "us-east-1", Credentials: accountCredentials("server-key")},
+		CredentialsFor: func(_ context.Context, config scanner.ScanConfig) (aws.CredentialsProvider, error) {
+			switch config.AccountID {
+			case "111111111111":
+				return accountCredentials("role-key"), nil
+			case "222222222222":
+				return nil, nil
+			}
+			return nil, errors.New("account not connected")
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	s.RegisterScanner("mock", func(cfg aws.Config, _ string, accountID string) scanner.ServiceScanner {
+		creds, _ := cfg.Credentials.Retrieve(context.Background())
+		return &mockScanner{accountID: accountID, keyID: creds.AccessKeyID, tracker: &concurrencyTracker{}}
+	})
+
+	for account, wantKey := range map[string]string{"111111111111": "role-key", "222222222222": "server-key"} {
+		result, err := s.Scan(context.Background(), scanner.ScanConfig{AccountID: account, Regions: []string{"us-east-1"}, Services: []string{"mock"}})
+		if err != nil {
+			t.Fatalf("Scan(%s) error = %v", account, err)
+		}
+		if got := result.Findings[0].Title; got != wantKey {
+			t.Errorf("account %s scanned with %s, want %s", account, got, wantKey)
+		}
+	}
+	if _, err := s.Scan(context.Background(), scanner.ScanConfig{AccountID: "333333333333", Services: []string{"mock"}}); err == nil {
+		t.Error("Scan() of an account without credentials should fail")
+	}
+}
+
+func TestNewService_KeepsOneSummarizationClient(t *testing.T) {
+	s, err := NewService(Config{
+		SummarizationAddress: "localhost:1",
+		EnableSummarization:  true,
+	})
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	client := s.SummarizationClient()
+	if client == nil {
+		t.Fatal("SummarizationClient() = nil, want the service's client")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, _ = s.Summarize(ctx, &scanner.ScanResult{AccountID: "111111111111"})
+	if s.SummarizationClient() != client {
+		t.Error("Summarize replaced the service's client")
+	}
+	if state := client.State(); state == connectivity.Shutdown {
+		t.Error("Summarize closed the shared client")
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if state := client.State(); state != connectivity.Shutdown {
+		t.Errorf("state after Close() = %v, want Shutdown", state)
+	}
+
+	disabled, err := NewService(Config{})
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	if disabled.SummarizationClient() != nil {
+		t.Error("disabled service has a summarization client")
+	}
+}
@@ -3,45 +3,166 @@ package summarization
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	pb "cloudcop/api/internal/grpc"
 	"cloudcop/api/internal/scanner"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 )
 
-// Client wraps the gRPC client for summarization.
+const (
+	// defaultCallTimeout bounds a single SummarizeFindings attempt. Model
+	// calls on large scans can take a while, so this is generous.
+	defaultCallTimeout = 2 * time.Minute
+	// defaultMaxRetries is how many times a call is retried after the
+	// service reports itself unavailable.
+	defaultMaxRetries = 3
+	// defaultRetryBackoff is the wait before the first retry; it doubles on
+	// each further retry.
+	defaultRetryBackoff = 250 * time.Millisecond
+)
+
+// keepaliveParams pings an idle connection so that a restarted or vanished
+// AI service is noticed before the next call rather than during it.
+var keepaliveParams = keepalive.ClientParameters{
+	Time:                30 * time.Second,
+	Timeout:             10 * time.Second,
+	PermitWithoutStream: true,
+}
+
+// Client wraps the gRPC client for summarization. It redials the service
+// when a call fails because the service is unavailable, so a restart of the
+// AI service does not leave the client permanently broken.
 type Client struct {
+	address     string
+	dialOpts    []grpc.DialOption
+	callTimeout time.Duration
+	maxRetries  int
+	backoff     time.Duration
+
+	mu     sync.Mutex
 	conn   *grpc.ClientConn
 	client pb.SummarizationServiceClient
 }
 
+// Option configures a Client.
+type Option func(*Client)
+
+// WithCallTimeout bounds each SummarizeFindings attempt. Zero or negative
+// disables the timeout, leaving only the caller's context deadline.
+func WithCallTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.callTimeout = d
+	}
+}
+
+// WithMaxRetries sets how many times a call is retried after the service
+// reports itself unavailable. Zero disables retries.
+func WithMaxRetries(n int) Option {
+	return func(c *Client) {
+		c.maxRetries = max(n, 0)
+	}
+}
+
+// WithDialOptions adds gRPC dial options, for example a custom dialer or
+// transport credentials. They are applied after the client's defaults.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(c *Client) {
+		c.dialOpts = append(c.dialOpts, opts...)
+	}
+}
+
 // NewClient creates a new summarization client.
-func NewClient(address string) (*Client, error) {
-	conn, err := grpc.NewClient(address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+func NewClient(address string, opts ...Option) (*Client, error) {
+	c := &Client{
+		address: address,
+		dialOpts: []grpc.DialOption{
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithKeepaliveParams(keepaliveParams),
+		},
+		callTimeout: defaultCallTimeout,
+		maxRetries:  defaultMaxRetries,
+		backoff:     defaultRetryBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	conn, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+	c.setConn(conn)
+	return c, nil
+}
+
+func (c *Client) dial() (*grpc.ClientConn, error) {
+	conn, err := grpc.NewClient(c.address, c.dialOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to summarization service: %w", err)
 	}
+	return conn, nil
+}
+
+func (c *Client) setConn(conn *grpc.ClientConn) {
+	c.conn = conn
+	c.client = pb.NewSummarizationServiceClient(conn)
+}
+
+// current returns the connection and stub calls should use.
+func (c *Client) current() (*grpc.ClientConn, pb.SummarizationServiceClient) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn, c.client
+}
 
-	return &Client{
-		conn:   conn,
-		client: pb.NewSummarizationServiceClient(conn),
-	}, nil
+// reconnect replaces failed with a fresh connection. Concurrent callers that
+// saw the same failed connection redial only once.
+func (c *Client) reconnect(failed *grpc.ClientConn) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn != failed {
+		return nil
+	}
+
+	conn, err := c.dial()
+	if err != nil {
+		return err
+	}
+	_ = failed.Close()
+	c.setConn(conn)
+	return nil
 }
 
 // Close closes the gRPC connection.
 func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	if c.conn != nil {
 		return c.conn.Close()
 	}
 	return nil
 }
 
+// State returns the connectivity state of the current connection to the
+// summarization service.
+func (c *Client) State() connectivity.State {
+	conn, _ := c.current()
+	return conn.GetState()
+}
+
 // SummarizeFindings sends findings to the AI service for summarization.
+// Calls failing with codes.Unavailable are retried on a new connection, up
+// to the client's retry limit.
 func (c *Client) SummarizeFindings(ctx context.Context, scanID, accountID string, findings []scanner.Finding) (*SummaryResult, error) {
 	// Convert scanner findings to protobuf format
 	pbFindings := make([]*pb.Finding, len(findings))
@@ -61,12 +182,37 @@ func (c *Client) SummarizeFindings(ctx context.Context, scanID, accountID string
 		},
 	}
 
-	resp, err := c.client.SummarizeFindings(ctx, req)
-	if err != nil {
-		return nil, fmt.Errorf("summarization failed: %w", err)
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		conn, client := c.current()
+		resp, err := c.call(ctx, client, req)
+		if err == nil {
+			return convertResponse(resp), nil
+		}
+		if status.Code(err) != codes.Unavailable || attempt >= c.maxRetries {
+			return nil, fmt.Errorf("summarization failed: %w", err)
+		}
+
+		if rerr := c.reconnect(conn); rerr != nil {
+			return nil, fmt.Errorf("summarization failed: %w", errors.Join(err, rerr))
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("summarization failed: %w", errors.Join(err, ctx.Err()))
+		case <-time.After(backoff):
+		}
+		backoff *= 2
 	}
+}
 
-	return convertResponse(resp), nil
+// call makes one SummarizeFindings attempt, bounded by the call timeout.
+func (c *Client) call(ctx context.Context, client pb.SummarizationServiceClient, req *pb.SummarizeFindingsRequest) (*pb.SummarizeFindingsResponse, error) {
+	if c.callTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.callTimeout)
+		defer cancel()
+	}
+	return client.SummarizeFindings(ctx, req)
 }
 
 // SummaryResult contains the summarized findings.
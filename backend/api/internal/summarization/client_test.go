@@ -1,11 +1,19 @@
 package summarization
 
 import (
+	"context"
+	"net"
+	"sync"
 	"testing"
 	"time"
 
 	pb "cloudcop/api/internal/grpc"
 	"cloudcop/api/internal/scanner"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
 )
 
 func TestConvertFinding(t *testing.T) {
@@ -185,3 +193,131 @@ func TestConvertResponse(t *testing.T) {
 		t.Errorf("Commands count = %d, want 1", len(result.Actions[0].Commands))
 	}
 }
+
+// summaryServer answers SummarizeFindings with the request's scan ID, after
+// an optional delay.
+type summaryServer struct {
+	pb.UnimplementedSummarizationServiceServer
+	delay time.Duration
+}
+
+func (s *summaryServer) SummarizeFindings(ctx context.Context, req *pb.SummarizeFindingsRequest) (*pb.SummarizeFindingsResponse, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(s.delay):
+	}
+	return &pb.SummarizeFindingsResponse{ScanId: req.ScanId}, nil
+}
+
+// restartableServer runs a summaryServer over bufconn that can be stopped
+// and started again, standing in for an AI service that restarts.
+type restartableServer struct {
+	t     *testing.T
+	delay time.Duration
+
+	mu     sync.Mutex
+	lis    *bufconn.Listener
+	server *grpc.Server
+}
+
+func (r *restartableServer) start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lis = bufconn.Listen(1 << 20)
+	r.server = grpc.NewServer()
+	pb.RegisterSummarizationServiceServer(r.server, &summaryServer{delay: r.delay})
+	go func(server *grpc.Server, lis net.Listener) { _ = server.Serve(lis) }(r.server, r.lis)
+}
+
+func (r *restartableServer) stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.server.Stop()
+}
+
+func (r *restartableServer) dial(ctx context.Context, _ string) (net.Conn, error) {
+	r.mu.Lock()
+	lis := r.lis
+	r.mu.Unlock()
+	return lis.DialContext(ctx)
+}
+
+func (r *restartableServer) client(opts ...Option) *Client {
+	r.t.Helper()
+	opts = append([]Option{WithDialOptions(grpc.WithContextDialer(r.dial))}, opts...)
+	client, err := NewClient("passthrough:///bufnet", opts...)
+	if err != nil {
+		r.t.Fatalf("NewClient() error = %v", err)
+	}
+	client.backoff = 10 * time.Millisecond
+	r.t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestClient_ReconnectsAfterRestart(t *testing.T) {
+	srv := &restartableServer{t: t}
+	srv.start()
+	t.Cleanup(srv.stop)
+	client := srv.client()
+	ctx := context.Background()
+
+	if _, err := client.SummarizeFindings(ctx, "scan-1", "123456789012", nil); err != nil {
+		t.Fatalf("first call error = %v", err)
+	}
+
+	srv.stop()
+	srv.start()
+
+	result, err := client.SummarizeFindings(ctx, "scan-2", "123456789012", nil)
+	if err != nil {
+		t.Fatalf("call after restart error = %v", err)
+	}
+	if result.ScanID != "scan-2" {
+		t.Errorf("ScanID = %q, want scan-2", result.ScanID)
+	}
+}
+
+func TestClient_RetriesWhileUnavailable(t *testing.T) {
+	srv := &restartableServer{t: t}
+	srv.start()
+	t.Cleanup(srv.stop)
+	client := srv.client(WithMaxRetries(20))
+
+	srv.stop()
+	// The service comes back while the client is still retrying.
+	restarted := time.AfterFunc(50*time.Millisecond, srv.start)
+	defer restarted.Stop()
+
+	if _, err := client.SummarizeFindings(context.Background(), "scan-1", "123456789012", nil); err != nil {
+		t.Fatalf("SummarizeFindings() error = %v, want recovery once the service restarts", err)
+	}
+}
+
+func TestClient_GivesUpAfterMaxRetries(t *testing.T) {
+	srv := &restartableServer{t: t}
+	srv.start()
+	client := srv.client(WithMaxRetries(2))
+	srv.stop()
+
+	_, err := client.SummarizeFindings(context.Background(), "scan-1", "123456789012", nil)
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("SummarizeFindings() error = %v, want Unavailable", err)
+	}
+}
+
+func TestClient_CallTimeout(t *testing.T) {
+	srv := &restartableServer{t: t, delay: time.Second}
+	srv.start()
+	t.Cleanup(srv.stop)
+	client := srv.client(WithCallTimeout(50 * time.Millisecond))
+
+	start := time.Now()
+	_, err := client.SummarizeFindings(context.Background(), "scan-1", "123456789012", nil)
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("SummarizeFindings() error = %v, want DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("call took %v, want it cut off by the call timeout", elapsed)
+	}
+}